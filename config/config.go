@@ -3,7 +3,18 @@ package config
 import (
 	"fmt"
 	"log"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/service"
+	"sandbox/pkg/business_trip_number"
+	"sandbox/pkg/featureflag"
+	"sandbox/pkg/pagination"
+	"sandbox/pkg/retry"
 
 	"github.com/joho/godotenv"
 )
@@ -19,11 +30,28 @@ type Config struct {
 	User         UserConfig
 	CDC          CDCConfig
 	CORS         CORSConfig
+	Desk         DeskConfig
+	Signature    SignatureConfig
+	BusinessTrip BusinessTripConfig
+	FeatureFlags FeatureFlagConfig
+	RateLimit    RateLimitConfig
+	Pagination   PaginationConfig
 }
 
 // ServerConfig holds server-related configuration
 type ServerConfig struct {
 	Port string
+	// LogLevel is the minimum level of application logs: debug, info, warn or error
+	LogLevel string
+	// LogFormat is the application log format: text (default) or json
+	LogFormat string
+	// BusinessTripTimeoutSeconds bounds business trip requests; 0 disables the timeout
+	BusinessTripTimeoutSeconds int
+	// SignatureTimeoutSeconds bounds work paper signature requests, which may call the timestamp
+	// authority; 0 disables the timeout
+	SignatureTimeoutSeconds int
+	// ShutdownTimeoutSeconds is the grace period in-flight requests get to finish after SIGINT or SIGTERM
+	ShutdownTimeoutSeconds int
 }
 
 // DatabaseConfig holds database-related configuration
@@ -40,6 +68,18 @@ type DatabaseConfig struct {
 // GeminiConfig holds Gemini API configuration
 type GeminiConfig struct {
 	APIKey string
+	// MaxAttempts is how often an LLM call is tried before giving up on a timeout, 429 or 5xx (1 disables retries)
+	MaxAttempts int
+	// RetryBaseDelayMs is the wait before the first retry; it doubles with every further retry
+	RetryBaseDelayMs int
+	// RetryMaxDelayMs caps the wait between two attempts
+	RetryMaxDelayMs int
+	// MonthlyTokenBudget is the number of tokens document checks may spend per calendar month (0 only tracks usage)
+	MonthlyTokenBudget int
+	// PromptTemplatePath is a text file with the document check prompt; empty uses the built-in prompt
+	PromptTemplatePath string
+	// PromptTemplatePaths overrides the prompt per work paper item type (A, B or C)
+	PromptTemplatePaths map[string]string
 }
 
 // ZoomConfig holds Zoom API configuration
@@ -51,6 +91,8 @@ type ZoomConfig struct {
 // DriveConfig holds Google Drive API configuration
 type DriveConfig struct {
 	APIKey string
+	// ReceiptsFolderID is the Drive folder transaction receipts are uploaded to; empty uses the drive root
+	ReceiptsFolderID string
 }
 
 // NotificationConfig holds notification service configuration
@@ -69,11 +111,138 @@ type CDCConfig struct {
 	BaseURL    string
 	WebBaseURL string
 	APIKey     string
+	// AliasCacheTTLSeconds is how long country and vaccine aliases are cached before they are reloaded
+	AliasCacheTTLSeconds int
+	// RecommendationCacheTTLHours is how long an extracted CDC recommendation is served before it is fetched again
+	RecommendationCacheTTLHours int
 }
 
 // CORSConfig holds CORS configuration
 type CORSConfig struct {
-	AllowOrigins string
+	AllowOrigins  string
+	AllowMethods  string
+	AllowHeaders  string
+	ExposeHeaders string
+	// AllowCredentials lets browsers send cookies and Authorization headers; it needs explicit origins
+	AllowCredentials bool
+}
+
+// DeskConfig holds desk module configuration
+type DeskConfig struct {
+	// EnsureNotesOnRead lazily creates missing work paper notes when a paper is read
+	EnsureNotesOnRead bool
+	// RequireSignaturesForCompletion blocks completing a work paper while signatures are pending or rejected
+	RequireSignaturesForCompletion bool
+	// MinSigners is the minimum number of signers a work paper must keep (0 disables the check)
+	MinSigners int
+	// DownloadConcurrency is the number of Google Drive files downloaded in parallel when checking a document
+	DownloadConcurrency int
+	// CheckConcurrency is the number of notes checked in parallel when checking a whole work paper
+	CheckConcurrency int
+	// VerdictCacheTTLHours is how long an LLM verdict is reused for the same documents and item (0 disables the cache)
+	VerdictCacheTTLHours int
+}
+
+// SignatureConfig holds digital signature configuration
+type SignatureConfig struct {
+	// TSAURL is an optional RFC 3161 timestamp authority; signatures use server time when empty
+	TSAURL string
+	// TSACertificatePath is the PEM certificate of the timestamp authority or its CA that timestamp
+	// tokens must be signed under; required with TSAURL
+	TSACertificatePath string
+	// HashAlgorithm is the digest used for new signatures (SHA256, SHA512, SHA3-256, SHA3-512)
+	HashAlgorithm string
+	// AllowedHashAlgorithms limits the digests accepted for signing and verification; empty allows all
+	AllowedHashAlgorithms []string
+	// CertificatePath is an optional PEM X.509 certificate of the signing key; verification reports
+	// whether it was valid when a document was signed
+	CertificatePath string
+}
+
+// BusinessTripConfig holds business trip module configuration
+type BusinessTripConfig struct {
+	// NumberScope is the uniqueness scope of generated trip numbers: "global" (default) or "per_year".
+	// It must match the unique index in the database, which is checked at startup.
+	NumberScope business_trip_number.Scope
+	// NumberFormat is the prefix and zero-padded sequence width of generated trip numbers; the prefix
+	// may contain {YYYY} to start a new sequence every year
+	NumberFormat business_trip_number.Format
+	// ReminderThresholdHours is how long a verificator must stay pending before being reminded
+	ReminderThresholdHours int
+	// ReminderIntervalMinutes is how often the reminder job runs; 0 disables the schedule
+	// and reminders can only be sent through the manual trigger endpoint
+	ReminderIntervalMinutes int
+	// DuplicateMatchFields are the transaction fields that must match for a duplicate warning
+	// (type, subtype, amount, name, description); empty uses type, amount and name
+	DuplicateMatchFields []string
+	// DuplicateAmountTolerance is the largest amount difference still treated as the same amount
+	DuplicateAmountTolerance float64
+	// DuplicateTextSimilarity is the minimum name/description similarity (0 to 1) for a duplicate warning
+	DuplicateTextSimilarity float64
+	// MaxTripDays caps the days from departure to return unless a request forces it; 0 disables the cap
+	MaxTripDays int
+	// MaxVerificators caps the number of verificators of a business trip; 0 disables the cap
+	MaxVerificators int
+	// VerificatorPreview is how many verificators the trip detail embeds; the rest are listed by the
+	// verificators sub-resource. 0 embeds them all
+	VerificatorPreview int
+	// WebhookURLs receive a signed POST when a trip is completed or canceled; empty disables webhooks
+	WebhookURLs []string
+	// WebhookSecret is the HMAC-SHA256 key of the X-Webhook-Signature header; required with WebhookURLs
+	WebhookSecret string
+	// WebhookMaxAttempts is how often a webhook is attempted per receiver, including the first attempt
+	WebhookMaxAttempts int
+	// CompletionTemplatePath is a DOCX file with {{placeholder}} fields used for completion documents;
+	// empty uses the built-in layout
+	CompletionTemplatePath string
+	// SignPDF signs exported SPD PDFs with the digital signature key and prints the signature as a QR code
+	SignPDF bool
+	// PDFVerifyBaseURL is the public base URL of this API that the QR code of a signed SPD links to;
+	// required with SignPDF
+	PDFVerifyBaseURL string
+	// BaseCurrency is the ISO 4217 currency subtotals and trip totals are reported in; transactions
+	// paid in another currency carry an exchange rate to it
+	BaseCurrency string
+	// MaxTransactionAmounts caps the amount of a new transaction per type to catch data-entry errors;
+	// administrators can override it per transaction. A missing or zero cap does not limit the type.
+	MaxTransactionAmounts entity.TransactionAmountCaps
+}
+
+// RateLimitConfig holds the per-client request limits of the route groups
+type RateLimitConfig struct {
+	// Default applies to every authenticated route and the vaccine routes
+	Default RateLimitRule
+	// LLM applies to the Gemini-backed document checks and receipt extraction, on top of Default
+	LLM RateLimitRule
+	// CDC applies to the vaccine recommendations fetched from the CDC, on top of Default
+	CDC RateLimitRule
+}
+
+// PaginationConfig holds the page sizes of the list endpoints
+type PaginationConfig struct {
+	// Default applies to the list endpoints without their own page sizes
+	Default pagination.Limits
+	// Signatures applies to the work paper signature and signing log lists
+	Signatures pagination.Limits
+	// WorkPapers applies to the desk work paper and work paper note lists
+	WorkPapers pagination.Limits
+	// BusinessTrips applies to the business trip list and the trips of an employee
+	BusinessTrips pagination.Limits
+	// WorkPaperItems applies to the work paper item and master LAKIP item lists
+	WorkPaperItems pagination.Limits
+}
+
+// RateLimitRule is a token bucket of Burst requests refilled at RequestsPerMinute; 0 requests per
+// minute disables the limit
+type RateLimitRule struct {
+	RequestsPerMinute int
+	Burst             int
+}
+
+// FeatureFlagConfig holds the environment-wide feature flag defaults
+type FeatureFlagConfig struct {
+	// Defaults overrides the built-in flag defaults; organizations can override them again in the database
+	Defaults map[featureflag.Name]bool
 }
 
 // Load loads configuration from environment variables
@@ -91,9 +260,22 @@ func Load() (*Config, error) {
 
 	dsn := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s", user, password, host, port, dbName, sslMode)
 
+	flagDefaults, err := featureflag.ParseDefaults(getEnvList("FEATURE_FLAGS"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid FEATURE_FLAGS: %w", err)
+	}
+
+	maxPageSize := getEnvInt("PAGINATION_MAX_PAGE_SIZE", pagination.MaxPageSize)
+
 	config := &Config{
 		Server: ServerConfig{
-			Port: getEnv("PORT", "5002"),
+			Port:      getEnv("PORT", "5002"),
+			LogLevel:  getEnv("LOG_LEVEL", "info"),
+			LogFormat: getEnv("LOG_FORMAT", "text"),
+
+			BusinessTripTimeoutSeconds: getEnvInt("BUSINESS_TRIP_REQUEST_TIMEOUT_SECONDS", 30),
+			SignatureTimeoutSeconds:    getEnvInt("SIGNATURE_REQUEST_TIMEOUT_SECONDS", 60),
+			ShutdownTimeoutSeconds:     getEnvInt("SHUTDOWN_TIMEOUT_SECONDS", 30),
 		},
 		Database: DatabaseConfig{
 			Host:     host,
@@ -105,14 +287,21 @@ func Load() (*Config, error) {
 			DSN:      dsn,
 		},
 		Gemini: GeminiConfig{
-			APIKey: os.Getenv("GEMINI_API_KEY"),
+			APIKey:              os.Getenv("GEMINI_API_KEY"),
+			MaxAttempts:         getEnvInt("GEMINI_MAX_ATTEMPTS", retry.DefaultMaxAttempts),
+			RetryBaseDelayMs:    getEnvInt("GEMINI_RETRY_BASE_DELAY_MS", int(retry.DefaultBaseDelay/time.Millisecond)),
+			RetryMaxDelayMs:     getEnvInt("GEMINI_RETRY_MAX_DELAY_MS", int(retry.DefaultMaxDelay/time.Millisecond)),
+			MonthlyTokenBudget:  getEnvInt("GEMINI_MONTHLY_TOKEN_BUDGET", 0),
+			PromptTemplatePath:  os.Getenv("GEMINI_PROMPT_TEMPLATE_PATH"),
+			PromptTemplatePaths: getPromptTemplatePaths(),
 		},
 		Zoom: ZoomConfig{
 			APIKey:    os.Getenv("ZOOM_API_KEY"),
 			APISecret: os.Getenv("ZOOM_API_SECRET"),
 		},
 		Drive: DriveConfig{
-			APIKey: os.Getenv("GOOGLE_DRIVE_API_KEY"),
+			APIKey:           os.Getenv("GOOGLE_DRIVE_API_KEY"),
+			ReceiptsFolderID: os.Getenv("GOOGLE_DRIVE_RECEIPTS_FOLDER_ID"),
 		},
 		Notification: NotificationConfig{
 			APIKey: os.Getenv("NOTIFICATION_API_KEY"),
@@ -122,12 +311,95 @@ func Load() (*Config, error) {
 			APIKey:  getEnv("USER_SERVICE_API_KEY", "56c290ad131b1f3e3131059c6c33ff46be0cff5cab3673de2bf2c1d81798b1d8"),
 		},
 		CDC: CDCConfig{
-			BaseURL:    getEnv("CDC_API_BASE_URL", "https://travel.state.gov/_travel-resources/content/travel-resources/www.tripsofia.com/api/v1"),
-			WebBaseURL: getEnv("CDC_WEB_BASE_URL", "https://wwwnc.cdc.gov"),
-			APIKey:     getEnv("CDC_API_KEY", ""),
+			BaseURL:                     getEnv("CDC_API_BASE_URL", "https://travel.state.gov/_travel-resources/content/travel-resources/www.tripsofia.com/api/v1"),
+			WebBaseURL:                  getEnv("CDC_WEB_BASE_URL", "https://wwwnc.cdc.gov"),
+			APIKey:                      getEnv("CDC_API_KEY", ""),
+			AliasCacheTTLSeconds:        getEnvInt("CDC_ALIAS_CACHE_TTL_SECONDS", 300),
+			RecommendationCacheTTLHours: getEnvInt("CDC_RECOMMENDATION_CACHE_TTL_HOURS", 24),
 		},
 		CORS: CORSConfig{
-			AllowOrigins: getEnv("CORS_ALLOW_ORIGINS", "http://localhost:3000"),
+			AllowOrigins:     getEnv("CORS_ALLOW_ORIGINS", "http://localhost:3000"),
+			AllowMethods:     getEnv("CORS_ALLOW_METHODS", "GET,POST,PUT,DELETE,OPTIONS,PATCH,HEAD"),
+			AllowHeaders:     getEnv("CORS_ALLOW_HEADERS", "Origin, Content-Type, Accept, Authorization"),
+			ExposeHeaders:    getEnv("CORS_EXPOSE_HEADERS", ""),
+			AllowCredentials: getEnvBool("CORS_ALLOW_CREDENTIALS", true),
+		},
+		Signature: SignatureConfig{
+			TSAURL:                os.Getenv("SIGNATURE_TSA_URL"),
+			TSACertificatePath:    os.Getenv("SIGNATURE_TSA_CERTIFICATE_PATH"),
+			HashAlgorithm:         getEnv("SIGNATURE_HASH_ALGORITHM", "SHA256"),
+			AllowedHashAlgorithms: getEnvList("SIGNATURE_ALLOWED_HASH_ALGORITHMS"),
+			CertificatePath:       os.Getenv("SIGNATURE_CERTIFICATE_PATH"),
+		},
+		BusinessTrip: BusinessTripConfig{
+			NumberScope:              business_trip_number.Scope(getEnv("BUSINESS_TRIP_NUMBER_SCOPE", string(business_trip_number.ScopeGlobal))),
+			ReminderThresholdHours:   getEnvInt("VERIFICATOR_REMINDER_THRESHOLD_HOURS", 72),
+			ReminderIntervalMinutes:  getEnvInt("VERIFICATOR_REMINDER_INTERVAL_MINUTES", 0),
+			DuplicateMatchFields:     getEnvList("TRANSACTION_DUPLICATE_MATCH_FIELDS"),
+			DuplicateAmountTolerance: getEnvFloat("TRANSACTION_DUPLICATE_AMOUNT_TOLERANCE", 0),
+			DuplicateTextSimilarity:  getEnvFloat("TRANSACTION_DUPLICATE_TEXT_SIMILARITY", 0.8),
+			MaxTripDays:              getEnvInt("BUSINESS_TRIP_MAX_DURATION_DAYS", entity.DefaultMaxTripDays),
+			MaxVerificators:          getEnvInt("BUSINESS_TRIP_MAX_VERIFICATORS", entity.DefaultMaxVerificators),
+			VerificatorPreview:       getEnvInt("BUSINESS_TRIP_VERIFICATOR_PREVIEW", 5),
+			CompletionTemplatePath:   os.Getenv("BUSINESS_TRIP_COMPLETION_TEMPLATE_PATH"),
+			SignPDF:                  getEnvBool("BUSINESS_TRIP_PDF_SIGN", false),
+			PDFVerifyBaseURL:         strings.TrimSuffix(os.Getenv("BUSINESS_TRIP_PDF_VERIFY_BASE_URL"), "/"),
+			BaseCurrency:             entity.NormalizeCurrency(getEnv("BUSINESS_TRIP_BASE_CURRENCY", entity.DefaultBaseCurrency)),
+			MaxTransactionAmounts:    getTransactionAmountCaps(),
+			WebhookURLs:              getEnvList("BUSINESS_TRIP_WEBHOOK_URLS"),
+			WebhookSecret:            os.Getenv("BUSINESS_TRIP_WEBHOOK_SECRET"),
+			WebhookMaxAttempts:       getEnvInt("BUSINESS_TRIP_WEBHOOK_MAX_ATTEMPTS", 5),
+			NumberFormat: business_trip_number.Format{
+				Prefix: getEnv("BUSINESS_TRIP_NUMBER_PREFIX", business_trip_number.DefaultFormat.Prefix),
+				Width:  getEnvInt("BUSINESS_TRIP_NUMBER_WIDTH", business_trip_number.DefaultFormat.Width),
+			},
+		},
+		Desk: DeskConfig{
+			EnsureNotesOnRead:              getEnvBool("DESK_ENSURE_NOTES_ON_READ", false),
+			RequireSignaturesForCompletion: getEnvBool("DESK_REQUIRE_SIGNATURES_FOR_COMPLETION", false),
+			MinSigners:                     getEnvInt("DESK_MIN_SIGNERS", 0),
+			DownloadConcurrency:            getEnvInt("DESK_DOWNLOAD_CONCURRENCY", 5),
+			CheckConcurrency:               getEnvInt("DESK_CHECK_CONCURRENCY", 3),
+			VerdictCacheTTLHours:           getEnvInt("DESK_VERDICT_CACHE_TTL_HOURS", 168),
+		},
+		FeatureFlags: FeatureFlagConfig{
+			Defaults: flagDefaults,
+		},
+		RateLimit: RateLimitConfig{
+			Default: RateLimitRule{
+				RequestsPerMinute: getEnvInt("RATE_LIMIT_DEFAULT_PER_MINUTE", 300),
+				Burst:             getEnvInt("RATE_LIMIT_DEFAULT_BURST", 60),
+			},
+			LLM: RateLimitRule{
+				RequestsPerMinute: getEnvInt("RATE_LIMIT_LLM_PER_MINUTE", 10),
+				Burst:             getEnvInt("RATE_LIMIT_LLM_BURST", 5),
+			},
+			CDC: RateLimitRule{
+				RequestsPerMinute: getEnvInt("RATE_LIMIT_CDC_PER_MINUTE", 30),
+				Burst:             getEnvInt("RATE_LIMIT_CDC_BURST", 10),
+			},
+		},
+		Pagination: PaginationConfig{
+			Default: pagination.Limits{
+				Default: getEnvInt("PAGINATION_DEFAULT_PAGE_SIZE", pagination.DefaultPageSize),
+				Max:     maxPageSize,
+			},
+			Signatures: pagination.Limits{
+				Default: getEnvInt("PAGINATION_SIGNATURES_DEFAULT_PAGE_SIZE", 20),
+				Max:     getEnvInt("PAGINATION_SIGNATURES_MAX_PAGE_SIZE", maxPageSize),
+			},
+			WorkPapers: pagination.Limits{
+				Default: getEnvInt("PAGINATION_WORK_PAPERS_DEFAULT_PAGE_SIZE", 10),
+				Max:     getEnvInt("PAGINATION_WORK_PAPERS_MAX_PAGE_SIZE", maxPageSize),
+			},
+			BusinessTrips: pagination.Limits{
+				Default: getEnvInt("PAGINATION_BUSINESS_TRIPS_DEFAULT_PAGE_SIZE", 10),
+				Max:     getEnvInt("PAGINATION_BUSINESS_TRIPS_MAX_PAGE_SIZE", maxPageSize),
+			},
+			WorkPaperItems: pagination.Limits{
+				Default: getEnvInt("PAGINATION_WORK_PAPER_ITEMS_DEFAULT_PAGE_SIZE", 10),
+				Max:     getEnvInt("PAGINATION_WORK_PAPER_ITEMS_MAX_PAGE_SIZE", maxPageSize),
+			},
 		},
 	}
 
@@ -155,6 +427,124 @@ func (c *Config) Validate() error {
 	log.Printf("📊 Database Config: Host=%s, Port=%s, User=%s, DB=%s, SSL=%s",
 		c.Database.Host, c.Database.Port, c.Database.User, c.Database.DBName, c.Database.SSLMode)
 
+	if c.Server.LogFormat != "json" && c.Server.LogFormat != "text" {
+		return fmt.Errorf("LOG_FORMAT must be json or text")
+	}
+
+	if c.Server.BusinessTripTimeoutSeconds < 0 || c.Server.SignatureTimeoutSeconds < 0 {
+		return fmt.Errorf("BUSINESS_TRIP_REQUEST_TIMEOUT_SECONDS and SIGNATURE_REQUEST_TIMEOUT_SECONDS must not be negative")
+	}
+
+	if c.Server.ShutdownTimeoutSeconds < 1 {
+		return fmt.Errorf("SHUTDOWN_TIMEOUT_SECONDS must be at least 1")
+	}
+
+	if c.CORS.AllowCredentials && strings.Contains(c.CORS.AllowOrigins, "*") {
+		return fmt.Errorf("CORS_ALLOW_ORIGINS must list explicit origins when CORS_ALLOW_CREDENTIALS is true")
+	}
+
+	if _, err := business_trip_number.ParseScope(string(c.BusinessTrip.NumberScope)); err != nil {
+		return err
+	}
+	if err := c.BusinessTrip.NumberFormat.Validate(); err != nil {
+		return fmt.Errorf("BUSINESS_TRIP_NUMBER_PREFIX/BUSINESS_TRIP_NUMBER_WIDTH: %w", err)
+	}
+
+	if c.BusinessTrip.ReminderThresholdHours < 0 {
+		return fmt.Errorf("VERIFICATOR_REMINDER_THRESHOLD_HOURS must not be negative")
+	}
+	if c.BusinessTrip.ReminderIntervalMinutes < 0 {
+		return fmt.Errorf("VERIFICATOR_REMINDER_INTERVAL_MINUTES must not be negative")
+	}
+	if err := service.ValidateDuplicateMatchFields(c.BusinessTrip.DuplicateMatchFields); err != nil {
+		return fmt.Errorf("TRANSACTION_DUPLICATE_MATCH_FIELDS: %w", err)
+	}
+	if c.BusinessTrip.DuplicateAmountTolerance < 0 {
+		return fmt.Errorf("TRANSACTION_DUPLICATE_AMOUNT_TOLERANCE must not be negative")
+	}
+	if c.BusinessTrip.DuplicateTextSimilarity < 0 || c.BusinessTrip.DuplicateTextSimilarity > 1 {
+		return fmt.Errorf("TRANSACTION_DUPLICATE_TEXT_SIMILARITY must be between 0 and 1")
+	}
+	if c.BusinessTrip.MaxTripDays < 0 {
+		return fmt.Errorf("BUSINESS_TRIP_MAX_DURATION_DAYS must not be negative")
+	}
+	if c.BusinessTrip.MaxVerificators < 0 {
+		return fmt.Errorf("BUSINESS_TRIP_MAX_VERIFICATORS must not be negative")
+	}
+	if c.BusinessTrip.VerificatorPreview < 0 {
+		return fmt.Errorf("BUSINESS_TRIP_VERIFICATOR_PREVIEW must not be negative")
+	}
+	if !entity.IsSupportedCurrency(c.BusinessTrip.BaseCurrency) {
+		return fmt.Errorf("BUSINESS_TRIP_BASE_CURRENCY: %q is not a supported ISO 4217 currency code", c.BusinessTrip.BaseCurrency)
+	}
+	for txType, maxAmount := range c.BusinessTrip.MaxTransactionAmounts {
+		if maxAmount < 0 {
+			return fmt.Errorf("%s must not be negative", transactionAmountCapEnv(txType))
+		}
+	}
+	if len(c.BusinessTrip.WebhookURLs) > 0 && c.BusinessTrip.WebhookSecret == "" {
+		return fmt.Errorf("BUSINESS_TRIP_WEBHOOK_SECRET is required when BUSINESS_TRIP_WEBHOOK_URLS is set")
+	}
+	for _, webhookURL := range c.BusinessTrip.WebhookURLs {
+		if u, err := url.Parse(webhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("BUSINESS_TRIP_WEBHOOK_URLS: %q is not an http(s) URL", webhookURL)
+		}
+	}
+	if c.BusinessTrip.SignPDF {
+		if u, err := url.Parse(c.BusinessTrip.PDFVerifyBaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("BUSINESS_TRIP_PDF_VERIFY_BASE_URL must be an http(s) URL when BUSINESS_TRIP_PDF_SIGN is set")
+		}
+	}
+	if c.BusinessTrip.WebhookMaxAttempts < 1 {
+		return fmt.Errorf("BUSINESS_TRIP_WEBHOOK_MAX_ATTEMPTS must be at least 1")
+	}
+	if path := c.BusinessTrip.CompletionTemplatePath; path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("BUSINESS_TRIP_COMPLETION_TEMPLATE_PATH: %w", err)
+		}
+	}
+	for name, rule := range map[string]RateLimitRule{"DEFAULT": c.RateLimit.Default, "LLM": c.RateLimit.LLM, "CDC": c.RateLimit.CDC} {
+		if rule.RequestsPerMinute < 0 {
+			return fmt.Errorf("RATE_LIMIT_%s_PER_MINUTE must not be negative", name)
+		}
+		if rule.RequestsPerMinute > 0 && rule.Burst < 1 {
+			return fmt.Errorf("RATE_LIMIT_%s_BURST must be at least 1", name)
+		}
+	}
+	for name, limits := range map[string]pagination.Limits{
+		"":                  c.Pagination.Default,
+		"SIGNATURES_":       c.Pagination.Signatures,
+		"WORK_PAPERS_":      c.Pagination.WorkPapers,
+		"BUSINESS_TRIPS_":   c.Pagination.BusinessTrips,
+		"WORK_PAPER_ITEMS_": c.Pagination.WorkPaperItems,
+	} {
+		if limits.Default < 1 {
+			return fmt.Errorf("PAGINATION_%sDEFAULT_PAGE_SIZE must be at least 1", name)
+		}
+		if limits.Max < limits.Default {
+			return fmt.Errorf("PAGINATION_%sMAX_PAGE_SIZE must be at least PAGINATION_%sDEFAULT_PAGE_SIZE", name, name)
+		}
+	}
+	if c.Gemini.MaxAttempts < 1 {
+		return fmt.Errorf("GEMINI_MAX_ATTEMPTS must be at least 1")
+	}
+	if c.Gemini.RetryBaseDelayMs < 0 || c.Gemini.RetryMaxDelayMs < 0 {
+		return fmt.Errorf("GEMINI_RETRY_BASE_DELAY_MS and GEMINI_RETRY_MAX_DELAY_MS must not be negative")
+	}
+	if c.Gemini.MonthlyTokenBudget < 0 {
+		return fmt.Errorf("GEMINI_MONTHLY_TOKEN_BUDGET must not be negative")
+	}
+	if path := c.Gemini.PromptTemplatePath; path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("GEMINI_PROMPT_TEMPLATE_PATH: %w", err)
+		}
+	}
+	for itemType, path := range c.Gemini.PromptTemplatePaths {
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("%s: %w", promptTemplatePathEnv(itemType), err)
+		}
+	}
+
 	// Gemini API Key is optional for basic functionality
 	// If not provided, transaction extraction won't work but other features will
 	if c.Gemini.APIKey == "" {
@@ -177,3 +567,69 @@ func getEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+func getEnvBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getTransactionAmountCaps reads BUSINESS_TRIP_MAX_AMOUNT as the cap of every transaction type, and
+// BUSINESS_TRIP_MAX_AMOUNT_<TYPE>, e.g. BUSINESS_TRIP_MAX_AMOUNT_ACCOMMODATION, as the cap of one type
+func getTransactionAmountCaps() entity.TransactionAmountCaps {
+	defaultCap := getEnvFloat("BUSINESS_TRIP_MAX_AMOUNT", 0)
+	caps := make(entity.TransactionAmountCaps, len(entity.TransactionTypes))
+	for _, txType := range entity.TransactionTypes {
+		caps[txType] = getEnvFloat(transactionAmountCapEnv(txType), defaultCap)
+	}
+	return caps
+}
+
+func transactionAmountCapEnv(txType entity.TransactionType) string {
+	return "BUSINESS_TRIP_MAX_AMOUNT_" + strings.ToUpper(string(txType))
+}
+
+// getPromptTemplatePaths reads GEMINI_PROMPT_TEMPLATE_PATH_<TYPE>, e.g. GEMINI_PROMPT_TEMPLATE_PATH_A, as the
+// prompt template of one work paper item type
+func getPromptTemplatePaths() map[string]string {
+	paths := make(map[string]string)
+	for _, itemType := range []string{entity.WorkPaperItemTypeA, entity.WorkPaperItemTypeB, entity.WorkPaperItemTypeC} {
+		if path := os.Getenv(promptTemplatePathEnv(itemType)); path != "" {
+			paths[itemType] = path
+		}
+	}
+	return paths
+}
+
+func promptTemplatePathEnv(itemType string) string {
+	return "GEMINI_PROMPT_TEMPLATE_PATH_" + itemType
+}
+
+// getEnvList reads a comma separated environment variable, skipping empty entries
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -1,6 +1,9 @@
 package config
 
 import (
+	"context"
+	"time"
+
 	"sandbox/internal/delivery/http/handler"
 	deskHandler "sandbox/internal/delivery/http/handler/desk"
 	"sandbox/internal/domain/repository"
@@ -24,7 +27,10 @@ import (
 	workPaperUC "sandbox/internal/usecase/work_paper"
 	workPaperItemUC "sandbox/internal/usecase/work_paper_item"
 	workPaperSignatureUC "sandbox/internal/usecase/work_paper_signature"
+	"sandbox/pkg/business_trip_number"
 	"sandbox/pkg/database"
+	"sandbox/pkg/featureflag"
+	"sandbox/pkg/retry"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -43,6 +49,7 @@ type Container struct {
 	WorkPaperHandler                *deskHandler.WorkPaperHandler
 	WorkPaperSignatureHandler       *handler.WorkPaperSignatureHandler
 	VaccineHandler                  *handler.VaccineHandler
+	FeatureFlagHandler              *handler.FeatureFlagHandler
 
 	// Backward compatibility aliases (deprecated)
 	MasterLakipItemHandler *deskHandler.WorkPaperItemHandler
@@ -67,6 +74,7 @@ type Container struct {
 	GetBusinessTripSummaryUseCase          *businessTripUC.GetBusinessTripSummaryUseCase
 	GetAssigneeSummaryUseCase              *businessTripUC.GetAssigneeSummaryUseCase
 	GetDashboardUseCase                    *businessTripUC.GetDashboardUseCase
+	GetCostCenterReportUseCase             *businessTripUC.GetCostCenterReportUseCase
 	VerifyBusinessTripUseCase              *businessTripUC.VerifyBusinessTripUseCase
 	ListVerificatorsUseCase                *businessTripUC.ListVerificatorsUseCase
 
@@ -79,23 +87,33 @@ type Container struct {
 	// Transaction Use Cases
 	GetTransactionUseCase    *businessTripUC.GetTransactionUseCase
 	UpdateTransactionUseCase *businessTripUC.UpdateTransactionUseCase
+	PatchTransactionUseCase  *businessTripUC.PatchTransactionUseCase
 	DeleteTransactionUseCase *businessTripUC.DeleteTransactionUseCase
 	ListTransactionsUseCase  *businessTripUC.ListTransactionsUseCase
 
 	// Desk Module Use Cases
-	CreateWorkPaperItemUseCase *workPaperItemUC.CreateWorkPaperItemUseCase
-	GetWorkPaperItemUseCase    *workPaperItemUC.GetWorkPaperItemUseCase
-	UpdateWorkPaperItemUseCase *workPaperItemUC.UpdateWorkPaperItemUseCase
-	DeleteWorkPaperItemUseCase *workPaperItemUC.DeleteWorkPaperItemUseCase
-	ListWorkPaperItemsUseCase  *workPaperItemUC.ListWorkPaperItemsUseCase
-	CreateWorkPaperUseCase     *workPaperUC.CreateWorkPaperUseCase
-	CheckWorkPaperNoteUseCase  *workPaperUC.CheckWorkPaperNoteUseCase
+	CreateWorkPaperItemUseCase   *workPaperItemUC.CreateWorkPaperItemUseCase
+	GetWorkPaperItemUseCase      *workPaperItemUC.GetWorkPaperItemUseCase
+	UpdateWorkPaperItemUseCase   *workPaperItemUC.UpdateWorkPaperItemUseCase
+	DeleteWorkPaperItemUseCase   *workPaperItemUC.DeleteWorkPaperItemUseCase
+	ListWorkPaperItemsUseCase    *workPaperItemUC.ListWorkPaperItemsUseCase
+	ReorderWorkPaperItemsUseCase *workPaperItemUC.ReorderWorkPaperItemsUseCase
+	CreateWorkPaperUseCase       *workPaperUC.CreateWorkPaperUseCase
+	CheckWorkPaperNoteUseCase    *workPaperUC.CheckWorkPaperNoteUseCase
 
 	// Work Paper Signature Use Cases
 	ListWorkPaperSignaturesUseCase             *workPaperSignatureUC.ListWorkPaperSignaturesUseCase
 	GetWorkPaperSignaturesByWorkPaperIDUseCase *workPaperSignatureUC.GetWorkPaperSignaturesByWorkPaperIDUseCase
 	CreateDigitalSignatureUseCase              *workPaperSignatureUC.CreateDigitalSignatureUseCase
 	VerifyDigitalSignatureUseCase              *workPaperSignatureUC.VerifyDigitalSignatureUseCase
+	GetSignatureCertificateUseCase             *workPaperSignatureUC.GetSignatureCertificateUseCase
+	GetDocumentSignatureUseCase                *workPaperSignatureUC.GetDocumentSignatureUseCase
+	ListSigningLogUseCase                      *workPaperSignatureUC.ListSigningLogUseCase
+
+	// Services
+	VerificatorReminderService    *service.VerificatorReminderService
+	FeatureFlagService            *featureflag.Service
+	BusinessTripWebhookDispatcher *service.BusinessTripWebhookDispatcher
 
 	// Backward compatibility aliases (deprecated)
 	CreateMasterLakipItemUseCase *workPaperItemUC.CreateWorkPaperItemUseCase
@@ -142,6 +160,12 @@ func NewContainer(cfg *Config) *Container {
 
 	// Infrastructure layer
 	geminiClient := gemini.NewClient(cfg.Gemini.APIKey)
+	// Transient Gemini failures (timeouts, 429, 5xx) are retried with backoff
+	geminiRetryOptions := retry.Options{
+		MaxAttempts: cfg.Gemini.MaxAttempts,
+		BaseDelay:   time.Duration(cfg.Gemini.RetryBaseDelayMs) * time.Millisecond,
+		MaxDelay:    time.Duration(cfg.Gemini.RetryMaxDelayMs) * time.Millisecond,
+	}
 	identityService := infrastructure.NewIdentityServiceWithAPIKey(cfg.User.BaseURL, cfg.User.APIKey)
 	fileProcessor := file.NewProcessor()
 	excelGenerator := excel.NewGenerator()
@@ -153,10 +177,21 @@ func NewContainer(cfg *Config) *Container {
 	meetingRepo := postgresInfra.NewRepository(zoomClient, driveClient, notificationClient)
 
 	// Business Trip infrastructure - Now implemented!
-	businessTripRepo := postgresRepo.NewBusinessTripRepository(dbWrapper)
+	// Fail fast when the trip number scope does not match the unique index in the database
+	numberGenerator := business_trip_number.NewGenerator(dbWrapper.UnderlyingDB(), cfg.BusinessTrip.NumberScope, cfg.BusinessTrip.NumberFormat)
+	if err := numberGenerator.ValidateConstraint(context.Background()); err != nil {
+		panic("Invalid business trip number configuration: " + err.Error())
+	}
+
+	// New transactions above these amounts are rejected unless an administrator overrides the cap
+
+	businessTripRepo := postgresRepo.NewBusinessTripRepository(dbWrapper, cfg.BusinessTrip.NumberScope, cfg.BusinessTrip.NumberFormat)
 	assigneeRepo := postgresRepo.NewAssigneeRepository(dbWrapper)
 	transactionRepo := postgresRepo.NewBusinessTripTransactionRepository(dbWrapper)
 
+	// Feature flags: environment defaults with per-organization overrides from the database
+	featureFlagService := featureflag.NewService(cfg.FeatureFlags.Defaults, postgresRepo.NewFeatureFlagRepository(dbWrapper))
+
 	// Domain Services - moved up before use cases that use it
 	transactionService := service.NewTransactionService(geminiClient)
 	meetingService := service.NewMeetingService(meetingRepo)
@@ -166,40 +201,106 @@ func NewContainer(cfg *Config) *Container {
 	// Vaccines infrastructure
 	vaccinesRepo := postgresRepo.NewVaccinesRepository(dbWrapper)
 
+	// Google Drive service account, used by the desk module and for transaction receipts
+	gdriveService, err := drive.NewGoogleDriveService("") // Will use default credentials file
+	if err != nil {
+		panic("Failed to create Google Drive service: " + err.Error())
+	}
+
+	duplicateTransactionDetector := service.NewDuplicateTransactionDetector(service.DuplicateTransactionConfig{
+		MatchFields:     cfg.BusinessTrip.DuplicateMatchFields,
+		AmountTolerance: cfg.BusinessTrip.DuplicateAmountTolerance,
+		TextSimilarity:  cfg.BusinessTrip.DuplicateTextSimilarity,
+	})
+	transactionTypePolicy := service.NewTransactionTypePolicy(postgresRepo.NewTransactionTypeRestrictionRepository(dbWrapper))
+	businessTripWebhooks := service.NewBusinessTripWebhookDispatcher(
+		notification.NewWebhookClient(cfg.BusinessTrip.WebhookSecret),
+		postgresRepo.NewWebhookDeliveryRepository(dbWrapper),
+		cfg.BusinessTrip.WebhookURLs,
+		retry.Options{MaxAttempts: cfg.BusinessTrip.WebhookMaxAttempts, BaseDelay: time.Second, MaxDelay: time.Minute},
+	)
+
+	// Initialize cryptographic service
+	cryptoService := cryptography.NewDigitalSignatureService("private.pem", "public.pem")
+	if err := cryptoService.SetTimestampAuthority(cfg.Signature.TSAURL, cfg.Signature.TSACertificatePath); err != nil {
+		panic("Invalid signature timestamp authority configuration: " + err.Error())
+	}
+	cryptoService.SetCertificatePath(cfg.Signature.CertificatePath)
+	if err := cryptoService.SetHashAlgorithm(cfg.Signature.HashAlgorithm); err != nil {
+		panic("Invalid signature hash configuration: " + err.Error())
+	}
+	if err := cryptoService.SetAllowedHashAlgorithms(cfg.Signature.AllowedHashAlgorithms); err != nil {
+		panic("Invalid signature hash configuration: " + err.Error())
+	}
+
 	// Business Trip Use Cases - Now enabled!
-	createBusinessTripUseCase := businessTripUC.NewCreateBusinessTripUseCase(businessTripRepo, assigneeRepo, transactionRepo, userService, dbWrapper)
-	getBusinessTripUseCase := businessTripUC.NewGetBusinessTripUseCase(businessTripRepo)
-	updateBusinessTripUseCase := businessTripUC.NewUpdateBusinessTripUseCase(businessTripRepo)
-	updateBusinessTripWithAssigneesUseCase := businessTripUC.NewUpdateBusinessTripWithAssigneesUseCase(businessTripRepo, assigneeRepo, transactionRepo, userService, dbWrapper)
+	createBusinessTripUseCase := businessTripUC.NewCreateBusinessTripUseCase(businessTripRepo, assigneeRepo, transactionRepo, userService, dbWrapper, featureFlagService, duplicateTransactionDetector, transactionTypePolicy, cfg.BusinessTrip.MaxTripDays, cfg.BusinessTrip.MaxVerificators, cfg.BusinessTrip.MaxTransactionAmounts, cfg.BusinessTrip.BaseCurrency)
+	getBusinessTripUseCase := businessTripUC.NewGetBusinessTripUseCase(businessTripRepo, meetingRepo, cfg.BusinessTrip.VerificatorPreview)
+	updateBusinessTripUseCase := businessTripUC.NewUpdateBusinessTripUseCase(businessTripRepo, featureFlagService, businessTripWebhooks, cfg.BusinessTrip.MaxTripDays)
+	updateBusinessTripWithAssigneesUseCase := businessTripUC.NewUpdateBusinessTripWithAssigneesUseCase(businessTripRepo, assigneeRepo, transactionRepo, userService, dbWrapper, duplicateTransactionDetector, transactionTypePolicy, businessTripWebhooks, cfg.BusinessTrip.MaxTripDays, cfg.BusinessTrip.MaxVerificators, cfg.BusinessTrip.MaxTransactionAmounts, cfg.BusinessTrip.BaseCurrency)
 	deleteBusinessTripUseCase := businessTripUC.NewDeleteBusinessTripUseCase(businessTripRepo)
 	listBusinessTripsUseCase := businessTripUC.NewListBusinessTripsUseCase(businessTripRepo)
-	addAssigneeUseCase := businessTripUC.NewAddAssigneeUseCase(businessTripRepo, assigneeRepo, transactionRepo, userService, dbWrapper)
-	addTransactionUseCase := businessTripUC.NewAddTransactionUseCase(businessTripRepo, assigneeRepo)
+	findTripsByEmployeeUseCase := businessTripUC.NewFindTripsByEmployeeUseCase(businessTripRepo)
+	listDestinationsUseCase := businessTripUC.NewListDestinationsUseCase(businessTripRepo)
+	setBusinessTripMeetingUseCase := businessTripUC.NewSetBusinessTripMeetingUseCase(businessTripRepo, meetingRepo)
+	recomputeSubtotalsUseCase := businessTripUC.NewRecomputeTransactionSubtotalsUseCase(businessTripRepo, transactionRepo, dbWrapper)
+	addAssigneeUseCase := businessTripUC.NewAddAssigneeUseCase(businessTripRepo, assigneeRepo, transactionRepo, userService, dbWrapper, duplicateTransactionDetector, transactionTypePolicy, cfg.BusinessTrip.MaxTransactionAmounts, cfg.BusinessTrip.BaseCurrency)
+	addTransactionUseCase := businessTripUC.NewAddTransactionUseCase(businessTripRepo, assigneeRepo, transactionTypePolicy, cfg.BusinessTrip.MaxTransactionAmounts, cfg.BusinessTrip.BaseCurrency)
 	getBusinessTripSummaryUseCase := businessTripUC.NewGetBusinessTripSummaryUseCase(businessTripRepo, assigneeRepo)
 	getAssigneeSummaryUseCase := businessTripUC.NewGetAssigneeSummaryUseCase(businessTripRepo, assigneeRepo)
+	exportTransactionsCSVUseCase := businessTripUC.NewExportTransactionsCSVUseCase(businessTripRepo, assigneeRepo, excelGenerator)
+	exportBusinessTripsNDJSONUseCase := businessTripUC.NewExportBusinessTripsNDJSONUseCase(businessTripRepo, assigneeRepo)
+	cloneBusinessTripUseCase := businessTripUC.NewCloneBusinessTripUseCase(businessTripRepo, assigneeRepo, transactionRepo, dbWrapper)
+	previewBusinessTripNumberUseCase := businessTripUC.NewPreviewBusinessTripNumberUseCase(businessTripRepo)
+	generateCompletionDocumentUseCase := businessTripUC.NewGenerateCompletionDocumentUseCase(businessTripRepo, cfg.BusinessTrip.CompletionTemplatePath)
+	var pdfSigner businessTripUC.DocumentSigner
+	if cfg.BusinessTrip.SignPDF {
+		pdfSigner = cryptoService
+	}
+	generateBusinessTripPDFUseCase := businessTripUC.NewGenerateBusinessTripPDFUseCase(businessTripRepo, pdfSigner, cfg.BusinessTrip.PDFVerifyBaseURL)
+	verifyBusinessTripPDFUseCase := businessTripUC.NewVerifyBusinessTripPDFUseCase(businessTripRepo, cryptoService)
 
 	// New Assignee Use Cases
 	getAssigneeUseCase := businessTripUC.NewGetAssigneeUseCase(assigneeRepo)
 	updateAssigneeUseCase := businessTripUC.NewUpdateAssigneeUseCase(businessTripRepo, assigneeRepo, userService)
+	transferAssigneeUseCase := businessTripUC.NewTransferAssigneeUseCase(businessTripRepo, assigneeRepo, dbWrapper)
 	deleteAssigneeUseCase := businessTripUC.NewDeleteAssigneeUseCase(businessTripRepo, assigneeRepo)
+	restoreAssigneeUseCase := businessTripUC.NewRestoreAssigneeUseCase(businessTripRepo, assigneeRepo)
 	listAssigneesUseCase := businessTripUC.NewListAssigneesUseCase(businessTripRepo, assigneeRepo)
 
 	// New Dashboard Use Case
 	getDashboardUseCase := businessTripUC.NewGetDashboardUseCase(businessTripRepo, assigneeRepo, transactionRepo)
+	getCostCenterReportUseCase := businessTripUC.NewGetCostCenterReportUseCase(transactionRepo)
 
 	// New Verification Use Cases
-	verifyBusinessTripUseCase := businessTripUC.NewVerifyBusinessTripUseCase(businessTripRepo, userService, dbWrapper)
+	verifyBusinessTripUseCase := businessTripUC.NewVerifyBusinessTripUseCase(businessTripRepo, userService, dbWrapper, businessTripWebhooks)
 	listVerificatorsUseCase := businessTripUC.NewListVerificatorsUseCase(businessTripRepo)
+	listBusinessTripVerificatorsUseCase := businessTripUC.NewListBusinessTripVerificatorsUseCase(businessTripRepo)
+	bulkUpdateVerificatorsUseCase := businessTripUC.NewBulkUpdateVerificatorsUseCase(businessTripRepo, dbWrapper, businessTripWebhooks)
+	getVerificatorHistoryUseCase := businessTripUC.NewGetVerificatorHistoryUseCase(businessTripRepo)
+	verificatorReminderService := service.NewVerificatorReminderService(
+		businessTripRepo,
+		userService,
+		notificationClient,
+		time.Duration(cfg.BusinessTrip.ReminderThresholdHours)*time.Hour,
+	)
 
 	// New Transaction Use Cases
 	getTransactionUseCase := businessTripUC.NewGetTransactionUseCase(businessTripRepo)
-	updateTransactionUseCase := businessTripUC.NewUpdateTransactionUseCase(businessTripRepo, assigneeRepo)
-	deleteTransactionUseCase := businessTripUC.NewDeleteTransactionUseCase(businessTripRepo)
+	updateTransactionUseCase := businessTripUC.NewUpdateTransactionUseCase(businessTripRepo, assigneeRepo, transactionTypePolicy, cfg.BusinessTrip.MaxTransactionAmounts, cfg.BusinessTrip.BaseCurrency)
+	patchTransactionUseCase := businessTripUC.NewPatchTransactionUseCase(businessTripRepo, assigneeRepo, transactionTypePolicy, cfg.BusinessTrip.MaxTransactionAmounts, cfg.BusinessTrip.BaseCurrency)
+	deleteTransactionUseCase := businessTripUC.NewDeleteTransactionUseCase(businessTripRepo, assigneeRepo)
 	listTransactionsUseCase := businessTripUC.NewListTransactionsUseCase(businessTripRepo, assigneeRepo)
+	addTransactionAttachmentUseCase := businessTripUC.NewAddTransactionAttachmentUseCase(transactionRepo, gdriveService, cfg.Drive.ReceiptsFolderID)
+	listTransactionAttachmentsUseCase := businessTripUC.NewListTransactionAttachmentsUseCase(transactionRepo)
+	deleteTransactionAttachmentUseCase := businessTripUC.NewDeleteTransactionAttachmentUseCase(transactionRepo)
 	// CDC Service for vaccine recommendations
-	vaccineExtractor := gemini.NewVaccineExtractorAdapter(geminiClient)
+	vaccineExtractor := service.NewRetryingVaccineExtractor(gemini.NewVaccineExtractorAdapter(geminiClient), geminiRetryOptions)
 	cdcClient := cdc.NewCDCClient(cfg.CDC.BaseURL, cfg.CDC.WebBaseURL, cfg.CDC.APIKey)
-	cdcService := service.NewCDCService(vaccinesRepo, cdcClient, vaccineExtractor)
+	cdcService := service.NewCDCService(vaccinesRepo, cdcClient, vaccineExtractor, service.CDCOptions{
+		AliasCacheTTL:          time.Duration(cfg.CDC.AliasCacheTTLSeconds) * time.Second,
+		RecommendationCacheTTL: time.Duration(cfg.CDC.RecommendationCacheTTLHours) * time.Hour,
+	})
 
 	// Transaction Use Cases
 	extractTransactionsUseCase := transactionUC.NewExtractTransactionsUseCase(transactionService)
@@ -211,12 +312,19 @@ func NewContainer(cfg *Config) *Container {
 	// Vaccines Use Cases
 	listMasterVaccinesUseCase := vaccineUC.NewListMasterVaccinesUseCase(vaccinesRepo)
 	listCountriesUseCase := vaccineUC.NewListCountriesUseCase(vaccinesRepo)
-	getCDCRecommendationsUseCase := vaccineUC.NewGetCDCRecommendationsUseCase(cdcService)
+	getCDCRecommendationsUseCase := vaccineUC.NewGetCDCRecommendationsUseCase(cdcService, vaccinesRepo)
+	recordCDCRecommendationUseCase := vaccineUC.NewRecordCDCRecommendationUseCase(cdcService, vaccinesRepo, businessTripRepo, assigneeRepo)
+	listAliasesUseCase := vaccineUC.NewListAliasesUseCase(vaccinesRepo)
+	upsertAliasUseCase := vaccineUC.NewUpsertAliasUseCase(vaccinesRepo, cdcService)
+	listRecommendationHistoryUseCase := vaccineUC.NewListRecommendationHistoryUseCase(vaccinesRepo)
+	importMasterVaccinesUseCase := vaccineUC.NewImportMasterVaccinesUseCase(vaccinesRepo)
 
 	// Interface layer
 	transactionHandler := handler.NewTransactionHandler(extractTransactionsUseCase, fileProcessor, generateRecapExcelUseCase)
 	meetingHandler := handler.NewMeetingHandler(createMeetingUseCase)
-	vaccineHandler := handler.NewVaccineHandler(listMasterVaccinesUseCase, listCountriesUseCase, getCDCRecommendationsUseCase)
+	vaccineHandler := handler.NewVaccineHandler(listMasterVaccinesUseCase, listCountriesUseCase, getCDCRecommendationsUseCase, recordCDCRecommendationUseCase, listAliasesUseCase, upsertAliasUseCase, listRecommendationHistoryUseCase, importMasterVaccinesUseCase, cfg.Pagination.Default)
+
+	featureFlagHandler := handler.NewFeatureFlagHandler(featureFlagService)
 
 	// Business Trip handler - Now enabled!
 	businessTripHandler := handler.NewBusinessTripHandler(
@@ -230,6 +338,18 @@ func NewContainer(cfg *Config) *Container {
 		addTransactionUseCase,
 		getBusinessTripSummaryUseCase,
 		getAssigneeSummaryUseCase,
+		exportTransactionsCSVUseCase,
+		cloneBusinessTripUseCase,
+		previewBusinessTripNumberUseCase,
+		exportBusinessTripsNDJSONUseCase,
+		generateCompletionDocumentUseCase,
+		findTripsByEmployeeUseCase,
+		recomputeSubtotalsUseCase,
+		generateBusinessTripPDFUseCase,
+		verifyBusinessTripPDFUseCase,
+		listDestinationsUseCase,
+		setBusinessTripMeetingUseCase,
+		cfg.Pagination.BusinessTrips,
 	)
 
 	// Assignee handler
@@ -239,6 +359,8 @@ func NewContainer(cfg *Config) *Container {
 		updateAssigneeUseCase,
 		deleteAssigneeUseCase,
 		listAssigneesUseCase,
+		transferAssigneeUseCase,
+		restoreAssigneeUseCase,
 	)
 
 	// Business Trip Transaction handler
@@ -246,20 +368,30 @@ func NewContainer(cfg *Config) *Container {
 		addTransactionUseCase,
 		getTransactionUseCase,
 		updateTransactionUseCase,
+		patchTransactionUseCase,
 		deleteTransactionUseCase,
 		listTransactionsUseCase,
 		getAssigneeUseCase,
+		addTransactionAttachmentUseCase,
+		listTransactionAttachmentsUseCase,
+		deleteTransactionAttachmentUseCase,
 	)
 
 	// Business Trip Dashboard handler
 	businessTripDashboardHandler := handler.NewBusinessTripDashboardHandler(
 		getDashboardUseCase,
+		getCostCenterReportUseCase,
 	)
 
 	// Business Trip Verification handler
 	businessTripVerificationHandler := handler.NewBusinessTripVerificationHandler(
 		verifyBusinessTripUseCase,
 		listVerificatorsUseCase,
+		listBusinessTripVerificatorsUseCase,
+		bulkUpdateVerificatorsUseCase,
+		getVerificatorHistoryUseCase,
+		verificatorReminderService,
+		cfg.Pagination.Default,
 	)
 
 	// Desk Module Infrastructure
@@ -267,20 +399,31 @@ func NewContainer(cfg *Config) *Container {
 	workPaperRepo := postgresRepo.NewWorkPaperRepository(dbWrapper)
 	workPaperNoteRepo := postgresRepo.NewWorkPaperNoteRepository(dbWrapper)
 	workPaperSignatureRepo := postgresRepo.NewWorkPaperSignatureRepository(dbx)
+	workPaperNoteCheckHistoryRepo := postgresRepo.NewWorkPaperNoteCheckHistoryRepository(dbWrapper)
+	signatureAuditLogRepo := postgresRepo.NewSignatureAuditLogRepository(dbWrapper)
 
 	// Organization Service - now using unified IdentityService
 	organizationRepo := infrastructure.NewOrganizationRepository(identityService)
 
-	// Desk Module Services - Use service account authentication
-	gdriveService, err := drive.NewGoogleDriveService("") // Will use default credentials file
+	// Desk Module Services
+	// Fail fast when a prompt template cannot be read or misses a placeholder
+	promptTemplates, err := llm.LoadPromptTemplates(cfg.Gemini.PromptTemplatePath, cfg.Gemini.PromptTemplatePaths)
 	if err != nil {
-		panic("Failed to create Google Drive service: " + err.Error())
+		panic("Invalid Gemini prompt template: " + err.Error())
 	}
-
-	llmService, err := llm.NewGeminiService(cfg.Gemini.APIKey)
+	geminiLLMService, err := llm.NewGeminiService(cfg.Gemini.APIKey, promptTemplates)
 	if err != nil {
 		panic("Failed to create LLM service: " + err.Error())
 	}
+	// Every successful check counts against the monthly token budget; retries are counted once
+	llmTokenBudget := service.NewTokenBudget(postgresRepo.NewLLMTokenUsageRepository(dbWrapper), int64(cfg.Gemini.MonthlyTokenBudget))
+	llmService := service.NewBudgetedLLMService(service.NewRetryingLLMService(geminiLLMService, geminiRetryOptions), llmTokenBudget)
+
+	// LLM verdicts are reused for identical documents and items unless the cache is disabled
+	var llmVerdictCacheRepo repository.LLMVerdictCacheRepository
+	if cfg.Desk.VerdictCacheTTLHours > 0 {
+		llmVerdictCacheRepo = postgresRepo.NewLLMVerdictCacheRepository(dbWrapper)
+	}
 
 	deskService := service.NewDeskService(
 		workPaperItemRepo,
@@ -288,8 +431,19 @@ func NewContainer(cfg *Config) *Container {
 		workPaperRepo,
 		workPaperNoteRepo,
 		workPaperSignatureRepo,
+		workPaperNoteCheckHistoryRepo,
+		signatureAuditLogRepo,
+		llmVerdictCacheRepo,
+		dbWrapper,
 		gdriveService,
 		llmService,
+		service.DeskOptions{
+			RequireSignaturesForCompletion: cfg.Desk.RequireSignaturesForCompletion,
+			MinSigners:                     cfg.Desk.MinSigners,
+			DownloadConcurrency:            cfg.Desk.DownloadConcurrency,
+			CheckConcurrency:               cfg.Desk.CheckConcurrency,
+			VerdictCacheTTL:                time.Duration(cfg.Desk.VerdictCacheTTLHours) * time.Hour,
+		},
 	)
 
 	// Backward compatibility aliases (deprecated)
@@ -303,28 +457,37 @@ func NewContainer(cfg *Config) *Container {
 	updateWorkPaperItemUseCase := workPaperItemUC.NewUpdateWorkPaperItemUseCase(deskService)
 	deleteWorkPaperItemUseCase := workPaperItemUC.NewDeleteWorkPaperItemUseCase(deskService)
 	listWorkPaperItemsUseCase := workPaperItemUC.NewListWorkPaperItemsUseCase(workPaperItemRepo)
+	reorderWorkPaperItemsUseCase := workPaperItemUC.NewReorderWorkPaperItemsUseCase(deskService)
 	createWorkPaperUseCase := workPaperUC.NewCreateWorkPaperUseCase(deskService)
 	checkWorkPaperNoteUseCase := workPaperUC.NewCheckWorkPaperNoteUseCase(deskService)
-	listWorkPapersUseCase := workPaperUC.NewListWorkPapersUseCase(deskService)
+	listWorkPapersUseCase := workPaperUC.NewListWorkPapersUseCase(deskService, cfg.Pagination.WorkPapers)
 	updateWorkPaperStatusUseCase := workPaperUC.NewUpdateWorkPaperStatusUseCase(deskService)
 	updateWorkPaperNoteUseCase := workPaperUC.NewUpdateWorkPaperNoteUseCase(deskService)
-	getWorkPaperDetailsUseCase := workPaperUC.NewGetWorkPaperDetailsUseCase(deskService)
+	getWorkPaperDetailsUseCase := workPaperUC.NewGetWorkPaperDetailsUseCase(deskService, cfg.Desk.EnsureNotesOnRead)
 	manageSignersUseCase := workPaperUC.NewManageSignersUseCase(deskService)
 	generateWorkPaperDocxUseCase := workPaperUC.NewGenerateWorkPaperDocxUseCase(deskService)
+	downloadWorkPaperDocumentsUseCase := workPaperUC.NewDownloadWorkPaperDocumentsUseCase(deskService)
+	getNoteCheckHistoryUseCase := workPaperUC.NewGetNoteCheckHistoryUseCase(deskService)
+	bulkValidateWorkPaperNotesUseCase := workPaperUC.NewBulkValidateWorkPaperNotesUseCase(deskService, workPaperNoteRepo, dbWrapper)
+	pruneInactiveWorkPaperNotesUseCase := workPaperUC.NewPruneInactiveWorkPaperNotesUseCase(deskService)
+	getNotesNeedingAttentionUseCase := workPaperUC.NewGetNotesNeedingAttentionUseCase(deskService)
+	getLLMTokenUsageUseCase := workPaperUC.NewGetLLMTokenUsageUseCase(llmTokenBudget)
+	listWorkPaperNotesUseCase := workPaperUC.NewListWorkPaperNotesUseCase(workPaperNoteRepo)
 
 	// Backward compatibility aliases
 	createMasterLakipItemUseCase := workPaperItemUC.NewCreateMasterLakipItemUseCase(deskService)
 	listMasterLakipItemsUseCase := workPaperItemUC.NewListMasterLakipItemsUseCase(workPaperItemRepo)
 	createPaperWorkUseCase := workPaperUC.NewCreatePaperWorkUseCase(deskService)
 	checkDocumentUseCase := workPaperUC.NewCheckDocumentUseCase(deskService)
-	// Initialize cryptographic service
-	cryptoService := cryptography.NewDigitalSignatureService("private.pem", "public.pem")
 
 	// Work Paper Signature Use Cases
 	listWorkPaperSignaturesUseCase := workPaperSignatureUC.NewListWorkPaperSignaturesUseCase(workPaperSignatureRepo)
 	getWorkPaperSignaturesByWorkPaperIDUseCase := workPaperSignatureUC.NewGetWorkPaperSignaturesByWorkPaperIDUseCase(workPaperSignatureRepo)
 	createDigitalSignatureUseCase := workPaperSignatureUC.NewCreateDigitalSignatureUseCase(workPaperSignatureRepo, cryptoService)
 	verifyDigitalSignatureUseCase := workPaperSignatureUC.NewVerifyDigitalSignatureUseCase(workPaperSignatureRepo, cryptoService)
+	getSignatureCertificateUseCase := workPaperSignatureUC.NewGetSignatureCertificateUseCase(workPaperSignatureRepo, cryptoService)
+	getDocumentSignatureUseCase := workPaperSignatureUC.NewGetDocumentSignatureUseCase(workPaperSignatureRepo, cryptoService)
+	listSigningLogUseCase := workPaperSignatureUC.NewListSigningLogUseCase(workPaperSignatureRepo)
 
 	// Desk Module Handlers
 	workPaperItemHandler := deskHandler.NewWorkPaperItemHandler(
@@ -333,6 +496,8 @@ func NewContainer(cfg *Config) *Container {
 		updateWorkPaperItemUseCase,
 		deleteWorkPaperItemUseCase,
 		listWorkPaperItemsUseCase,
+		reorderWorkPaperItemsUseCase,
+		cfg.Pagination.WorkPaperItems,
 	)
 
 	workPaperHandler := deskHandler.NewWorkPaperHandler(
@@ -344,6 +509,14 @@ func NewContainer(cfg *Config) *Container {
 		updateWorkPaperNoteUseCase,
 		manageSignersUseCase,
 		generateWorkPaperDocxUseCase,
+		downloadWorkPaperDocumentsUseCase,
+		getNoteCheckHistoryUseCase,
+		bulkValidateWorkPaperNotesUseCase,
+		pruneInactiveWorkPaperNotesUseCase,
+		getNotesNeedingAttentionUseCase,
+		getLLMTokenUsageUseCase,
+		listWorkPaperNotesUseCase,
+		cfg.Pagination.WorkPapers,
 	)
 
 	// Work Paper Signature Handler
@@ -353,6 +526,10 @@ func NewContainer(cfg *Config) *Container {
 		getWorkPaperSignaturesByWorkPaperIDUseCase,
 		createDigitalSignatureUseCase,
 		verifyDigitalSignatureUseCase,
+		getSignatureCertificateUseCase,
+		getDocumentSignatureUseCase,
+		listSigningLogUseCase,
+		cfg.Pagination.Signatures,
 	)
 
 	// Backward compatibility handler aliases
@@ -362,6 +539,8 @@ func NewContainer(cfg *Config) *Container {
 		updateWorkPaperItemUseCase,
 		deleteWorkPaperItemUseCase,
 		listMasterLakipItemsUseCase,
+		reorderWorkPaperItemsUseCase,
+		cfg.Pagination.WorkPaperItems,
 	)
 
 	paperWorkHandler := deskHandler.NewPaperWorkHandler(
@@ -381,6 +560,7 @@ func NewContainer(cfg *Config) *Container {
 		WorkPaperHandler:                workPaperHandler,
 		WorkPaperSignatureHandler:       workPaperSignatureHandler,
 		VaccineHandler:                  vaccineHandler,
+		FeatureFlagHandler:              featureFlagHandler,
 		ExtractTransactionsUseCase:      extractTransactionsUseCase,
 		GenerateRecapExcelUseCase:       generateRecapExcelUseCase,
 		CreateMeetingUseCase:            createMeetingUseCase,
@@ -394,6 +574,7 @@ func NewContainer(cfg *Config) *Container {
 		GetBusinessTripSummaryUseCase:   getBusinessTripSummaryUseCase,
 		GetAssigneeSummaryUseCase:       getAssigneeSummaryUseCase,
 		GetDashboardUseCase:             getDashboardUseCase,
+		GetCostCenterReportUseCase:      getCostCenterReportUseCase,
 		VerifyBusinessTripUseCase:       verifyBusinessTripUseCase,
 		ListVerificatorsUseCase:         listVerificatorsUseCase,
 		GetAssigneeUseCase:              getAssigneeUseCase,
@@ -402,21 +583,31 @@ func NewContainer(cfg *Config) *Container {
 		ListAssigneesUseCase:            listAssigneesUseCase,
 		GetTransactionUseCase:           getTransactionUseCase,
 		UpdateTransactionUseCase:        updateTransactionUseCase,
+		PatchTransactionUseCase:         patchTransactionUseCase,
 		DeleteTransactionUseCase:        deleteTransactionUseCase,
 		ListTransactionsUseCase:         listTransactionsUseCase,
 
 		// Desk Module Use Cases
-		CreateWorkPaperItemUseCase: createWorkPaperItemUseCase,
-		GetWorkPaperItemUseCase:    getWorkPaperItemUseCase,
-		ListWorkPaperItemsUseCase:  listWorkPaperItemsUseCase,
-		CreateWorkPaperUseCase:     createWorkPaperUseCase,
-		CheckWorkPaperNoteUseCase:  checkWorkPaperNoteUseCase,
+		CreateWorkPaperItemUseCase:   createWorkPaperItemUseCase,
+		GetWorkPaperItemUseCase:      getWorkPaperItemUseCase,
+		ListWorkPaperItemsUseCase:    listWorkPaperItemsUseCase,
+		ReorderWorkPaperItemsUseCase: reorderWorkPaperItemsUseCase,
+		CreateWorkPaperUseCase:       createWorkPaperUseCase,
+		CheckWorkPaperNoteUseCase:    checkWorkPaperNoteUseCase,
 
 		// Work Paper Signature Use Cases
 		ListWorkPaperSignaturesUseCase:             listWorkPaperSignaturesUseCase,
 		GetWorkPaperSignaturesByWorkPaperIDUseCase: getWorkPaperSignaturesByWorkPaperIDUseCase,
 		CreateDigitalSignatureUseCase:              createDigitalSignatureUseCase,
 		VerifyDigitalSignatureUseCase:              verifyDigitalSignatureUseCase,
+		GetSignatureCertificateUseCase:             getSignatureCertificateUseCase,
+		GetDocumentSignatureUseCase:                getDocumentSignatureUseCase,
+		ListSigningLogUseCase:                      listSigningLogUseCase,
+
+		// Services
+		VerificatorReminderService:    verificatorReminderService,
+		FeatureFlagService:            featureFlagService,
+		BusinessTripWebhookDispatcher: businessTripWebhooks,
 
 		// Backward compatibility aliases (deprecated)
 		MasterLakipItemHandler:       masterLakipItemHandler,
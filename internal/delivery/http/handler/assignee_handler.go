@@ -1,8 +1,10 @@
 package handler
 
 import (
-	"context"
+	"errors"
 
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/service"
 	"sandbox/internal/usecase/business_trip"
 
 	"github.com/gofiber/fiber/v2"
@@ -14,6 +16,8 @@ type AssigneeHandler struct {
 	updateAssigneeUseCase *business_trip.UpdateAssigneeUseCase
 	deleteAssigneeUseCase *business_trip.DeleteAssigneeUseCase
 	listAssigneesUseCase  *business_trip.ListAssigneesUseCase
+	transferUseCase       *business_trip.TransferAssigneeUseCase
+	restoreUseCase        *business_trip.RestoreAssigneeUseCase
 }
 
 func NewAssigneeHandler(
@@ -22,6 +26,8 @@ func NewAssigneeHandler(
 	updateAssigneeUseCase *business_trip.UpdateAssigneeUseCase,
 	deleteAssigneeUseCase *business_trip.DeleteAssigneeUseCase,
 	listAssigneesUseCase *business_trip.ListAssigneesUseCase,
+	transferUseCase *business_trip.TransferAssigneeUseCase,
+	restoreUseCase *business_trip.RestoreAssigneeUseCase,
 ) *AssigneeHandler {
 	return &AssigneeHandler{
 		addAssigneeUseCase:    addAssigneeUseCase,
@@ -29,6 +35,8 @@ func NewAssigneeHandler(
 		updateAssigneeUseCase: updateAssigneeUseCase,
 		deleteAssigneeUseCase: deleteAssigneeUseCase,
 		listAssigneesUseCase:  listAssigneesUseCase,
+		transferUseCase:       transferUseCase,
+		restoreUseCase:        restoreUseCase,
 	}
 }
 
@@ -50,8 +58,12 @@ func (h *AssigneeHandler) CreateAssignee(c *fiber.Ctx) error {
 		})
 	}
 
+	if largeAmountOverrideDenied(c, req.Transactions...) {
+		return largeAmountOverrideDeniedResponse(c)
+	}
+
 	// Validate request
-	if err := req.Validate(); err != nil {
+	if err := req.ValidateForAutofill(); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error":   "Validation failed",
 			"details": err.Error(),
@@ -68,20 +80,41 @@ func (h *AssigneeHandler) CreateAssignee(c *fiber.Ctx) error {
 		}
 	}
 
-	_, err := h.addAssigneeUseCase.Execute(context.Background(), tripID, &req)
+	response, err := h.addAssigneeUseCase.Execute(c.UserContext(), tripID, &req)
 	if err != nil {
+		if errors.Is(err, entity.ErrTransactionTypeNotAllowed) {
+			return transactionTypeNotAllowedResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrAmountExceedsCap) {
+			return amountExceedsCapResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrCreditExceedsCost) || errors.Is(err, entity.ErrCreditWithoutCostKind) {
+			return invalidCreditResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrUnsupportedCurrency) || errors.Is(err, entity.ErrInvalidExchangeRate) {
+			return invalidCurrencyResponse(c, err)
+		}
 		if err != nil && err.Error() == "business trip not found" {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "Business trip not found",
 			})
 		}
+		if errors.Is(err, entity.ErrDuplicateSPDNumber) {
+			return duplicateSPDNumberResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrAssigneeDetailsBlank) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   "Failed to add assignee",
 			"details": err.Error(),
 		})
 	}
 
-	return c.Status(fiber.StatusCreated).SendStatus(fiber.StatusCreated)
+	return sendAddedAssigneeWarnings(c, response)
 }
 
 // ListAssignees lists all assignees for a business trip
@@ -93,7 +126,7 @@ func (h *AssigneeHandler) ListAssignees(c *fiber.Ctx) error {
 		})
 	}
 
-	response, err := h.listAssigneesUseCase.Execute(context.Background(), tripID)
+	response, err := h.listAssigneesUseCase.Execute(c.UserContext(), tripID)
 	if err != nil {
 		if err != nil && err.Error() == "business trip not found" {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
@@ -121,7 +154,7 @@ func (h *AssigneeHandler) GetAssignee(c *fiber.Ctx) error {
 		})
 	}
 
-	response, err := h.getAssigneeUseCase.Execute(context.Background(), assigneeID)
+	response, err := h.getAssigneeUseCase.Execute(c.UserContext(), assigneeID)
 	if err != nil {
 		if err != nil && err.Error() == "assignee not found" {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
@@ -167,6 +200,7 @@ func (h *AssigneeHandler) UpdateAssignee(c *fiber.Ctx) error {
 			"details": err.Error(),
 		})
 	}
+	req.AllowLockedTrip = allowLockedTrip(c)
 
 	// Validate request
 	if err := req.Validate(); err != nil {
@@ -176,13 +210,19 @@ func (h *AssigneeHandler) UpdateAssignee(c *fiber.Ctx) error {
 		})
 	}
 
-	_, err := h.updateAssigneeUseCase.Execute(context.Background(), req)
+	_, err := h.updateAssigneeUseCase.Execute(c.UserContext(), req)
 	if err != nil {
 		if err != nil && err.Error() == "assignee not found" {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "Assignee not found",
 			})
 		}
+		if errors.Is(err, entity.ErrBusinessTripLocked) {
+			return lockedTripResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrDuplicateSPDNumber) {
+			return duplicateSPDNumberResponse(c, err)
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   "Failed to update assignee",
 			"details": err.Error(),
@@ -209,7 +249,7 @@ func (h *AssigneeHandler) DeleteAssignee(c *fiber.Ctx) error {
 
 	// Manual parent validation before deleting
 	// Get assignee to verify it belongs to business trip
-	assignee, err := h.getAssigneeUseCase.Execute(context.Background(), assigneeID)
+	assignee, err := h.getAssigneeUseCase.Execute(c.UserContext(), assigneeID)
 	if err != nil {
 		if err != nil && err.Error() == "assignee not found" {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
@@ -228,7 +268,7 @@ func (h *AssigneeHandler) DeleteAssignee(c *fiber.Ctx) error {
 		})
 	}
 
-	err = h.deleteAssigneeUseCase.Execute(context.Background(), assigneeID)
+	err = h.deleteAssigneeUseCase.Execute(c.UserContext(), assigneeID)
 	if err != nil {
 		if err != nil && err.Error() == "assignee not found" {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
@@ -245,3 +285,141 @@ func (h *AssigneeHandler) DeleteAssignee(c *fiber.Ctx) error {
 		"message": "Assignee deleted successfully",
 	})
 }
+
+// RestoreAssignee restores a deleted assignee of a business trip and the transactions deleted with it
+func (h *AssigneeHandler) RestoreAssignee(c *fiber.Ctx) error {
+	tripID := c.Params("tripId")
+	assigneeID := c.Params("assigneeId")
+	if tripID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Business trip ID is required",
+		})
+	}
+	if assigneeID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Assignee ID is required",
+		})
+	}
+
+	if err := h.restoreUseCase.Execute(c.UserContext(), tripID, assigneeID, allowLockedTrip(c)); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrBusinessTripNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error":   "Business trip not found",
+				"details": err.Error(),
+			})
+		case errors.Is(err, entity.ErrAssigneeNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error":   "Deleted assignee not found",
+				"details": err.Error(),
+			})
+		case errors.Is(err, entity.ErrBusinessTripLocked):
+			return lockedTripResponse(c, err)
+		case errors.Is(err, entity.ErrDuplicateSPDNumber):
+			return duplicateSPDNumberResponse(c, err)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to restore assignee",
+			"details": err.Error(),
+		})
+	}
+
+	response, err := h.getAssigneeUseCase.Execute(c.UserContext(), assigneeID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to get assignee",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Assignee restored successfully",
+		"data":    response,
+	})
+}
+
+// sendDuplicateWarnings keeps the empty success response unless duplicate transactions were detected,
+// in which case the warnings are returned so the user can review the saved transactions
+func sendDuplicateWarnings(c *fiber.Ctx, status int, warnings []service.DuplicateTransactionWarning) error {
+	if len(warnings) == 0 {
+		return c.SendStatus(status)
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"warnings": warnings,
+	})
+}
+
+// sendAddedAssigneeWarnings answers an added assignee like sendDuplicateWarnings, and also reports when
+// the employee could not be looked up in the identity service
+func sendAddedAssigneeWarnings(c *fiber.Ctx, response *business_trip.AssigneeResponse) error {
+	if response.IdentityWarning == "" {
+		return sendDuplicateWarnings(c, fiber.StatusCreated, response.Warnings)
+	}
+
+	body := fiber.Map{"identity_warning": response.IdentityWarning}
+	if len(response.Warnings) > 0 {
+		body["warnings"] = response.Warnings
+	}
+	return c.Status(fiber.StatusCreated).JSON(body)
+}
+
+// TransferAssignee moves an assignee and its transactions to another business trip
+func (h *AssigneeHandler) TransferAssignee(c *fiber.Ctx) error {
+	assigneeID := c.Params("assigneeId")
+	if assigneeID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Assignee ID is required",
+		})
+	}
+
+	var req business_trip.TransferAssigneeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+	}
+	req.AssigneeID = assigneeID
+	req.AllowLockedTrip = allowLockedTrip(c)
+
+	if err := req.Validate(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Validation failed",
+			"details": err.Error(),
+		})
+	}
+
+	response, err := h.transferUseCase.Execute(c.UserContext(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrAssigneeNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Assignee not found",
+			})
+		case errors.Is(err, entity.ErrBusinessTripNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error":   "Business trip not found",
+				"details": err.Error(),
+			})
+		case errors.Is(err, entity.ErrAssigneeOnSameTrip):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Assignee already belongs to the target business trip",
+				"details": err.Error(),
+			})
+		case errors.Is(err, entity.ErrBusinessTripLocked):
+			return lockedTripResponse(c, err)
+		case errors.Is(err, entity.ErrDuplicateSPDNumber):
+			return duplicateSPDNumberResponse(c, err)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to transfer assignee",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Assignee transferred successfully",
+		"data":    response,
+	})
+}
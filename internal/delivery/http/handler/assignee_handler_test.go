@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/usecase/business_trip"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestLockedTripOverrideCannotBeSentInFormBody(t *testing.T) {
+	repo := &stubTripRepository{trip: &entity.BusinessTrip{ID: "trip-1", Status: entity.BusinessTripStatusCompleted}}
+	assignees := NewAssigneeHandler(nil, nil, business_trip.NewUpdateAssigneeUseCase(repo, nil, nil), nil, nil, nil, nil)
+	transactions := NewBusinessTripTransactionHandler(nil, nil, business_trip.NewUpdateTransactionUseCase(repo, nil, nil, nil, "IDR"),
+		nil, nil, nil, nil, nil, nil, nil)
+
+	app := fiber.New()
+	app.Put("/business-trips/:tripId/assignees/:assigneeId", assignees.UpdateAssignee)
+	app.Put("/business-trips/:tripId/assignees/:assigneeId/transactions/:transactionId", transactions.Update)
+
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{
+			name: "assignee update",
+			path: "/business-trips/trip-1/assignees/assignee-1",
+			body: "Name=Budi&SPDNumber=SPD-1&EmployeeNumber=198501&Position=Analis&Rank=III/a&AllowLockedTrip=true",
+		},
+		{
+			name: "transaction update",
+			path: "/business-trips/trip-1/assignees/assignee-1/transactions/tx-1",
+			body: "Name=Taxi&Type=other&Amount=100000&AllowLockedTrip=true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodPut, tt.path, strings.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationForm)
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if resp.StatusCode != fiber.StatusConflict {
+				t.Errorf("status = %d, want %d for a locked trip", resp.StatusCode, fiber.StatusConflict)
+			}
+		})
+	}
+}
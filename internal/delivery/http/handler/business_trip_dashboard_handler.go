@@ -1,27 +1,30 @@
 package handler
 
 import (
-	"context"
+	"errors"
 	"strconv"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 
+	"sandbox/internal/domain/entity"
 	"sandbox/internal/usecase/business_trip"
 )
 
 // BusinessTripDashboardHandler handles HTTP requests for business trip dashboard
 type BusinessTripDashboardHandler struct {
-	dashboardUseCase *business_trip.GetDashboardUseCase
-	validator        *validator.Validate
+	dashboardUseCase        *business_trip.GetDashboardUseCase
+	costCenterReportUseCase *business_trip.GetCostCenterReportUseCase
+	validator               *validator.Validate
 }
 
 // NewBusinessTripDashboardHandler creates a new handler instance
-func NewBusinessTripDashboardHandler(dashboardUseCase *business_trip.GetDashboardUseCase) *BusinessTripDashboardHandler {
+func NewBusinessTripDashboardHandler(dashboardUseCase *business_trip.GetDashboardUseCase, costCenterReportUseCase *business_trip.GetCostCenterReportUseCase) *BusinessTripDashboardHandler {
 	return &BusinessTripDashboardHandler{
-		dashboardUseCase: dashboardUseCase,
-		validator:        validator.New(),
+		dashboardUseCase:        dashboardUseCase,
+		costCenterReportUseCase: costCenterReportUseCase,
+		validator:               validator.New(),
 	}
 }
 
@@ -70,7 +73,7 @@ func (h *BusinessTripDashboardHandler) GetDashboard(c *fiber.Ctx) error {
 	}
 
 	// Execute use case
-	ctx := context.Background()
+	ctx := c.UserContext()
 	response, err := h.dashboardUseCase.Execute(ctx, req)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -85,6 +88,144 @@ func (h *BusinessTripDashboardHandler) GetDashboard(c *fiber.Ctx) error {
 	})
 }
 
+// GetDashboardSummary retrieves every dashboard statistic in one call
+// @Summary Get Dashboard Summary
+// @Description Loads status counts, total cost, destination, monthly and transaction type stats and the upcoming trip count concurrently. A statistic that fails to load carries its own error instead of failing the whole response.
+// @Tags business-trips
+// @Produce json
+// @Param start_date query string false "Start date filter (YYYY-MM-DD format)"
+// @Param end_date query string false "End date filter (YYYY-MM-DD format)"
+// @Param destination query string false "Destination city filter"
+// @Success 200 {object} StandardResponse{data=business_trip.DashboardSummaryResponse}
+// @Failure 400 {object} StandardResponse
+// @Router /api/v1/dashboard/summary [get]
+func (h *BusinessTripDashboardHandler) GetDashboardSummary(c *fiber.Ctx) error {
+	startDate := parseDateQueryParam(c.Query("start_date"))
+	endDate := parseDateQueryParam(c.Query("end_date"))
+	if startDate != nil && endDate != nil && startDate.After(*endDate) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid date range",
+			"details": "start_date must be before or equal to end_date",
+		})
+	}
+
+	response := h.dashboardUseCase.GetDashboardSummary(c.UserContext(), startDate, endDate, c.Query("destination"))
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    response,
+	})
+}
+
+// GetCostByRank sums business trip spend per assignee rank
+// @Summary Get Cost By Rank
+// @Description Groups the cost of business trips in the date range by assignee rank, with the number of trips and the average cost per assignee of each rank
+// @Tags business-trips
+// @Produce json
+// @Param start_date query string false "Start date filter (YYYY-MM-DD format)"
+// @Param end_date query string false "End date filter (YYYY-MM-DD format)"
+// @Param destination query string false "Destination city filter"
+// @Success 200 {object} StandardResponse{data=business_trip.CostByRankResponse}
+// @Failure 400 {object} StandardResponse
+// @Failure 500 {object} StandardResponse
+// @Router /api/v1/dashboard/cost-by-rank [get]
+func (h *BusinessTripDashboardHandler) GetCostByRank(c *fiber.Ctx) error {
+	startDate := parseDateQueryParam(c.Query("start_date"))
+	endDate := parseDateQueryParam(c.Query("end_date"))
+
+	response, err := h.dashboardUseCase.GetCostByRank(c.UserContext(), startDate, endDate, c.Query("destination"))
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidDateRange) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid date range",
+				"details": "start_date must be before or equal to end_date",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to retrieve cost by rank",
+			"details": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    response,
+	})
+}
+
+// GetStatusFacets counts business trips per status for filter UIs
+// @Summary Get Business Trip Status Facets
+// @Description Returns the number of business trips per status plus the total. Every status is listed, with zero when no trip has it.
+// @Tags business-trips
+// @Produce json
+// @Param start query string false "Start date filter (YYYY-MM-DD format)"
+// @Param end query string false "End date filter (YYYY-MM-DD format)"
+// @Param destination query string false "Destination city filter"
+// @Success 200 {object} StandardResponse{data=business_trip.StatusFacetsResponse}
+// @Failure 400 {object} StandardResponse
+// @Failure 500 {object} StandardResponse
+// @Router /api/v1/business-trips/status-facets [get]
+func (h *BusinessTripDashboardHandler) GetStatusFacets(c *fiber.Ctx) error {
+	startDate := parseDateQueryParam(c.Query("start"))
+	endDate := parseDateQueryParam(c.Query("end"))
+	if startDate != nil && endDate != nil && startDate.After(*endDate) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid date range",
+			"details": "start must be before or equal to end",
+		})
+	}
+
+	response, err := h.dashboardUseCase.GetStatusFacets(c.UserContext(), startDate, endDate, c.Query("destination"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to retrieve status facets",
+			"details": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    response,
+	})
+}
+
+// GetCostCenterReport sums allocated transaction cost per cost center
+// @Summary Get Cost Center Report
+// @Description Sums the allocated cost of business trip transactions per cost center for trips starting within the date range
+// @Tags business-trips
+// @Produce json
+// @Param start_date query string false "Start date filter (YYYY-MM-DD format)"
+// @Param end_date query string false "End date filter (YYYY-MM-DD format)"
+// @Success 200 {object} StandardResponse{data=business_trip.GetCostCenterReportResponse}
+// @Failure 400 {object} StandardResponse
+// @Failure 500 {object} StandardResponse
+// @Router /api/v1/business-trips/reports/cost-centers [get]
+func (h *BusinessTripDashboardHandler) GetCostCenterReport(c *fiber.Ctx) error {
+	req := business_trip.GetCostCenterReportRequest{
+		StartDate: parseDateQueryParam(c.Query("start_date")),
+		EndDate:   parseDateQueryParam(c.Query("end_date")),
+	}
+
+	response, err := h.costCenterReportUseCase.Execute(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidDateRange) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid date range",
+				"details": "start_date must be before or equal to end_date",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to retrieve cost center report",
+			"details": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    response,
+	})
+}
+
 // parseDateQueryParam parses date query parameter
 func parseDateQueryParam(dateStr string) *time.Time {
 	if dateStr == "" {
@@ -1,9 +1,16 @@
 package handler
 
 import (
+	"bufio"
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
 
 	"sandbox/internal/delivery/http/middleware"
+	"sandbox/internal/domain/entity"
 	"sandbox/internal/usecase/business_trip"
 	"sandbox/pkg/pagination"
 
@@ -21,6 +28,18 @@ type BusinessTripHandler struct {
 	addTransactionUseCase                  *business_trip.AddTransactionUseCase
 	getBusinessTripSummaryUseCase          *business_trip.GetBusinessTripSummaryUseCase
 	getAssigneeSummaryUseCase              *business_trip.GetAssigneeSummaryUseCase
+	exportTransactionsCSVUseCase           *business_trip.ExportTransactionsCSVUseCase
+	cloneBusinessTripUseCase               *business_trip.CloneBusinessTripUseCase
+	previewBusinessTripNumberUseCase       *business_trip.PreviewBusinessTripNumberUseCase
+	exportBusinessTripsNDJSONUseCase       *business_trip.ExportBusinessTripsNDJSONUseCase
+	generateCompletionDocumentUseCase      *business_trip.GenerateCompletionDocumentUseCase
+	findTripsByEmployeeUseCase             *business_trip.FindTripsByEmployeeUseCase
+	recomputeSubtotalsUseCase              *business_trip.RecomputeTransactionSubtotalsUseCase
+	generateBusinessTripPDFUseCase         *business_trip.GenerateBusinessTripPDFUseCase
+	verifyBusinessTripPDFUseCase           *business_trip.VerifyBusinessTripPDFUseCase
+	listDestinationsUseCase                *business_trip.ListDestinationsUseCase
+	setBusinessTripMeetingUseCase          *business_trip.SetBusinessTripMeetingUseCase
+	queryParser                            *pagination.QueryParser
 }
 
 func NewBusinessTripHandler(
@@ -34,6 +53,18 @@ func NewBusinessTripHandler(
 	addTransactionUseCase *business_trip.AddTransactionUseCase,
 	getBusinessTripSummaryUseCase *business_trip.GetBusinessTripSummaryUseCase,
 	getAssigneeSummaryUseCase *business_trip.GetAssigneeSummaryUseCase,
+	exportTransactionsCSVUseCase *business_trip.ExportTransactionsCSVUseCase,
+	cloneBusinessTripUseCase *business_trip.CloneBusinessTripUseCase,
+	previewBusinessTripNumberUseCase *business_trip.PreviewBusinessTripNumberUseCase,
+	exportBusinessTripsNDJSONUseCase *business_trip.ExportBusinessTripsNDJSONUseCase,
+	generateCompletionDocumentUseCase *business_trip.GenerateCompletionDocumentUseCase,
+	findTripsByEmployeeUseCase *business_trip.FindTripsByEmployeeUseCase,
+	recomputeSubtotalsUseCase *business_trip.RecomputeTransactionSubtotalsUseCase,
+	generateBusinessTripPDFUseCase *business_trip.GenerateBusinessTripPDFUseCase,
+	verifyBusinessTripPDFUseCase *business_trip.VerifyBusinessTripPDFUseCase,
+	listDestinationsUseCase *business_trip.ListDestinationsUseCase,
+	setBusinessTripMeetingUseCase *business_trip.SetBusinessTripMeetingUseCase,
+	pageLimits pagination.Limits,
 ) *BusinessTripHandler {
 	return &BusinessTripHandler{
 		createBusinessTripUseCase:              createBusinessTripUseCase,
@@ -46,6 +77,18 @@ func NewBusinessTripHandler(
 		addTransactionUseCase:                  addTransactionUseCase,
 		getBusinessTripSummaryUseCase:          getBusinessTripSummaryUseCase,
 		getAssigneeSummaryUseCase:              getAssigneeSummaryUseCase,
+		exportTransactionsCSVUseCase:           exportTransactionsCSVUseCase,
+		cloneBusinessTripUseCase:               cloneBusinessTripUseCase,
+		previewBusinessTripNumberUseCase:       previewBusinessTripNumberUseCase,
+		exportBusinessTripsNDJSONUseCase:       exportBusinessTripsNDJSONUseCase,
+		generateCompletionDocumentUseCase:      generateCompletionDocumentUseCase,
+		findTripsByEmployeeUseCase:             findTripsByEmployeeUseCase,
+		recomputeSubtotalsUseCase:              recomputeSubtotalsUseCase,
+		generateBusinessTripPDFUseCase:         generateBusinessTripPDFUseCase,
+		verifyBusinessTripPDFUseCase:           verifyBusinessTripPDFUseCase,
+		listDestinationsUseCase:                listDestinationsUseCase,
+		setBusinessTripMeetingUseCase:          setBusinessTripMeetingUseCase,
+		queryParser:                            &pagination.QueryParser{Limits: pageLimits},
 	}
 }
 
@@ -77,6 +120,10 @@ func (h *BusinessTripHandler) CreateBusinessTrip(c *fiber.Ctx) error {
 		})
 	}
 
+	if largeAmountOverrideDenied(c, assigneeTransactions(req.Assignees)...) {
+		return largeAmountOverrideDeniedResponse(c)
+	}
+
 	// Validate request
 	if err := req.Validate(); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -86,8 +133,35 @@ func (h *BusinessTripHandler) CreateBusinessTrip(c *fiber.Ctx) error {
 	}
 
 	// Call usecase directly
-	response, err := h.createBusinessTripUseCase.Execute(context.Background(), req)
+	response, err := h.createBusinessTripUseCase.Execute(c.UserContext(), req)
 	if err != nil {
+		if errors.Is(err, entity.ErrTransactionTypeNotAllowed) {
+			return transactionTypeNotAllowedResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrCreditExceedsCost) || errors.Is(err, entity.ErrCreditWithoutCostKind) {
+			return invalidCreditResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrUnsupportedCurrency) || errors.Is(err, entity.ErrInvalidExchangeRate) {
+			return invalidCurrencyResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrAmountExceedsCap) {
+			return amountExceedsCapResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrUnknownEmployee) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error":   "Unknown employee",
+				"details": err.Error(),
+			})
+		}
+		if errors.Is(err, entity.ErrTripTooLong) {
+			return tripTooLongResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrTooManyVerificators) {
+			return tooManyVerificatorsResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrDuplicateSPDNumber) {
+			return duplicateSPDNumberResponse(c, err)
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   "Failed to create business trip",
 			"details": err.Error(),
@@ -109,7 +183,7 @@ func (h *BusinessTripHandler) GetBusinessTrip(c *fiber.Ctx) error {
 		})
 	}
 
-	response, err := h.getBusinessTripUseCase.Execute(context.Background(), id)
+	response, err := h.getBusinessTripUseCase.Execute(c.UserContext(), id)
 	if err != nil {
 		// Check if it's a not found error
 		if err != nil && err.Error() == "business trip not found" {
@@ -123,6 +197,13 @@ func (h *BusinessTripHandler) GetBusinessTrip(c *fiber.Ctx) error {
 		})
 	}
 
+	// Polling clients send back the ETag they have; answer 304 without a body while it is current
+	c.Set(fiber.HeaderETag, response.ETag)
+	if c.Get(fiber.HeaderIfNoneMatch) != "" && c.Fresh() {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+	h.getBusinessTripUseCase.LoadMeeting(c.UserContext(), response)
+
 	return c.JSON(fiber.Map{
 		"message": "Business trip retrieved successfully",
 		"data":    response,
@@ -158,9 +239,14 @@ func (h *BusinessTripHandler) UpdateBusinessTrip(c *fiber.Ctx) error {
 		})
 	}
 
+	req.AllowLockedTrip = allowLockedTrip(c)
+
 	// Call usecase directly
-	_, err := h.updateBusinessTripUseCase.Execute(context.Background(), req)
+	_, err := h.updateBusinessTripUseCase.Execute(c.UserContext(), req)
 	if err != nil {
+		if errors.Is(err, entity.ErrBusinessTripLocked) {
+			return lockedTripResponse(c, err)
+		}
 		if err != nil && (err.Error() == "business trip not found" || err.Error() == "entity.ErrBusinessTripNotFound") {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "Business trip not found",
@@ -172,6 +258,27 @@ func (h *BusinessTripHandler) UpdateBusinessTrip(c *fiber.Ctx) error {
 				"details": err.Error(),
 			})
 		}
+		if errors.Is(err, entity.ErrDateOrderViolation) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error":   "Invalid date order",
+				"details": err.Error(),
+			})
+		}
+		if errors.Is(err, entity.ErrTripTooLong) {
+			return tripTooLongResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrStaleBusinessTrip) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   "Business trip has been modified, reload it and try again",
+				"details": err.Error(),
+			})
+		}
+		if errors.Is(err, entity.ErrVerificatorsRequired) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error":   "Verificators required",
+				"details": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   "Failed to update business trip",
 			"details": err.Error(),
@@ -202,6 +309,10 @@ func (h *BusinessTripHandler) UpdateBusinessTripWithAssignees(c *fiber.Ctx) erro
 		})
 	}
 
+	if largeAmountOverrideDenied(c, assigneeTransactions(req.Assignees)...) {
+		return largeAmountOverrideDeniedResponse(c)
+	}
+
 	// Validate request
 	if err := req.Validate(); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -210,9 +321,23 @@ func (h *BusinessTripHandler) UpdateBusinessTripWithAssignees(c *fiber.Ctx) erro
 		})
 	}
 
+	req.AllowLockedTrip = allowLockedTrip(c)
+
 	// Call usecase directly
-	_, err := h.updateBusinessTripWithAssigneesUseCase.Execute(context.Background(), req)
+	response, err := h.updateBusinessTripWithAssigneesUseCase.Execute(c.UserContext(), req)
 	if err != nil {
+		if errors.Is(err, entity.ErrBusinessTripLocked) {
+			return lockedTripResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrTransactionTypeNotAllowed) {
+			return transactionTypeNotAllowedResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrCreditExceedsCost) || errors.Is(err, entity.ErrCreditWithoutCostKind) {
+			return invalidCreditResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrUnsupportedCurrency) || errors.Is(err, entity.ErrInvalidExchangeRate) {
+			return invalidCurrencyResponse(c, err)
+		}
 		if err != nil && (err.Error() == "business trip not found" || err.Error() == "entity.ErrBusinessTripNotFound") {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "Business trip not found",
@@ -224,13 +349,119 @@ func (h *BusinessTripHandler) UpdateBusinessTripWithAssignees(c *fiber.Ctx) erro
 				"details": err.Error(),
 			})
 		}
+		if errors.Is(err, entity.ErrDateOrderViolation) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error":   "Invalid date order",
+				"details": err.Error(),
+			})
+		}
+		if errors.Is(err, entity.ErrTripTooLong) {
+			return tripTooLongResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrTooManyVerificators) {
+			return tooManyVerificatorsResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrAmountExceedsCap) {
+			return amountExceedsCapResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrStaleBusinessTrip) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   "Business trip has been modified, reload it and try again",
+				"details": err.Error(),
+			})
+		}
+		if errors.Is(err, entity.ErrDuplicateSPDNumber) {
+			return duplicateSPDNumberResponse(c, err)
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   "Failed to update business trip with assignees",
 			"details": err.Error(),
 		})
 	}
 
-	return c.SendStatus(fiber.StatusOK)
+	return sendDuplicateWarnings(c, fiber.StatusOK, response.Warnings)
+}
+
+// GetNextBusinessTripNumber previews the number the next created business trip will receive.
+// The number is not reserved, so the actual create may assign a different one under concurrency.
+func (h *BusinessTripHandler) GetNextBusinessTripNumber(c *fiber.Ctx) error {
+	year := 0
+	if value := c.Query("year"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid year",
+				"details": err.Error(),
+			})
+		}
+		year = parsed
+	}
+
+	response, err := h.previewBusinessTripNumberUseCase.Execute(c.UserContext(), year)
+	if err != nil {
+		if errors.Is(err, business_trip.ErrInvalidNumberPreviewYear) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid year",
+				"details": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to preview business trip number",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    response,
+	})
+}
+
+// CloneBusinessTrip copies a business trip with its assignees, transactions and verificators into a new draft
+func (h *BusinessTripHandler) CloneBusinessTrip(c *fiber.Ctx) error {
+	var req business_trip.CloneBusinessTripRequest
+	req.BusinessTripID = c.Params("tripId")
+
+	// The date overrides are optional, so an empty body is allowed
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid request body",
+				"details": err.Error(),
+			})
+		}
+	}
+
+	if err := req.Validate(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Validation failed",
+			"details": err.Error(),
+		})
+	}
+
+	response, err := h.cloneBusinessTripUseCase.Execute(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, entity.ErrBusinessTripNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Business trip not found",
+			})
+		}
+		if errors.Is(err, entity.ErrDateOrderViolation) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error":   "Invalid date order",
+				"details": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to clone business trip",
+			"details": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"data":    response,
+	})
 }
 
 // DeleteBusinessTrip deletes a business trip
@@ -242,7 +473,7 @@ func (h *BusinessTripHandler) DeleteBusinessTrip(c *fiber.Ctx) error {
 		})
 	}
 
-	err := h.deleteBusinessTripUseCase.Execute(context.Background(), id)
+	err := h.deleteBusinessTripUseCase.Execute(c.UserContext(), id)
 	if err != nil {
 		// Check if it's a not found error
 		if err != nil && err.Error() == "business trip not found" {
@@ -268,24 +499,131 @@ func (h *BusinessTripHandler) ListBusinessTrips(c *fiber.Ctx) error {
 		queryParams[string(key)] = string(value)
 	})
 
-	queryParser := &pagination.QueryParser{}
-	params, err := queryParser.Parse(queryParams)
+	params, err := h.queryParser.Parse(queryParams)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "Invalid query parameters: " + err.Error(),
 		})
 	}
 
-	businessTrips, pagination, err := h.listBusinessTripsUseCase.Execute(context.Background(), params)
+	businessTrips, pagedResponse, err := h.listBusinessTripsUseCase.Execute(c.UserContext(), params)
 	if err != nil {
+		if errors.Is(err, pagination.ErrInvalidFilter) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid query parameters: " + err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
-	pagination.Data = businessTrips
+	pagedResponse.Data = businessTrips
+
+	return c.JSON(pagedResponse)
+}
+
+// ListDestinations suggests destination cities starting with the q query parameter for autocomplete,
+// the most visited first, with the number of trips to each
+func (h *BusinessTripHandler) ListDestinations(c *fiber.Ctx) error {
+	destinations, err := h.listDestinationsUseCase.Execute(c.UserContext(), c.Query("q"), c.QueryInt("limit", business_trip.DefaultDestinationLimit))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to list destinations",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    destinations,
+	})
+}
+
+// SetBusinessTripMeeting links a business trip to the meeting it is made to attend, or unlinks it when
+// meeting_id is empty
+func (h *BusinessTripHandler) SetBusinessTripMeeting(c *fiber.Ctx) error {
+	tripID := c.Params("tripId")
+	if tripID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Business trip ID is required",
+		})
+	}
 
-	return c.JSON(pagination)
+	var req business_trip.SetBusinessTripMeetingRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+	}
+	req.AllowLockedTrip = allowLockedTrip(c)
+
+	response, err := h.setBusinessTripMeetingUseCase.Execute(c.UserContext(), tripID, &req)
+	if err != nil {
+		if errors.Is(err, entity.ErrBusinessTripNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error":   "Business trip not found",
+				"details": err.Error(),
+			})
+		}
+		if errors.Is(err, entity.ErrMeetingNotFound) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error":   "Meeting not found",
+				"details": err.Error(),
+			})
+		}
+		if errors.Is(err, entity.ErrBusinessTripLocked) {
+			return lockedTripResponse(c, err)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to set business trip meeting",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    response,
+	})
+}
+
+// FindTripsByEmployee lists the business trips an employee number was assigned to
+func (h *BusinessTripHandler) FindTripsByEmployee(c *fiber.Ctx) error {
+	employeeNumber := c.Params("employeeNumber")
+	if employeeNumber == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Employee number is required",
+		})
+	}
+
+	queryParams := make(map[string]string)
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		queryParams[string(key)] = string(value)
+	})
+
+	params, err := h.queryParser.Parse(queryParams)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid query parameters: " + err.Error(),
+		})
+	}
+
+	businessTrips, pagedResponse, err := h.findTripsByEmployeeUseCase.Execute(c.UserContext(), employeeNumber, params)
+	if err != nil {
+		if errors.Is(err, pagination.ErrInvalidFilter) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid query parameters: " + err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	pagedResponse.Data = businessTrips
+
+	return c.JSON(pagedResponse)
 }
 
 // AddAssignee adds an assignee to a business trip
@@ -306,8 +644,12 @@ func (h *BusinessTripHandler) AddAssignee(c *fiber.Ctx) error {
 		})
 	}
 
+	if largeAmountOverrideDenied(c, req.Transactions...) {
+		return largeAmountOverrideDeniedResponse(c)
+	}
+
 	// Validate request
-	if err := req.Validate(); err != nil {
+	if err := req.ValidateForAutofill(); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error":   "Validation failed",
 			"details": err.Error(),
@@ -324,21 +666,42 @@ func (h *BusinessTripHandler) AddAssignee(c *fiber.Ctx) error {
 		}
 	}
 
-	_, err := h.addAssigneeUseCase.Execute(context.Background(), businessTripID, &req)
+	response, err := h.addAssigneeUseCase.Execute(c.UserContext(), businessTripID, &req)
 	if err != nil {
+		if errors.Is(err, entity.ErrTransactionTypeNotAllowed) {
+			return transactionTypeNotAllowedResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrAmountExceedsCap) {
+			return amountExceedsCapResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrCreditExceedsCost) || errors.Is(err, entity.ErrCreditWithoutCostKind) {
+			return invalidCreditResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrUnsupportedCurrency) || errors.Is(err, entity.ErrInvalidExchangeRate) {
+			return invalidCurrencyResponse(c, err)
+		}
 		// Check if it's a not found error
 		if err != nil && err.Error() == "business trip not found" {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "Business trip not found",
 			})
 		}
+		if errors.Is(err, entity.ErrDuplicateSPDNumber) {
+			return duplicateSPDNumberResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrAssigneeDetailsBlank) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   "Failed to add assignee",
 			"details": err.Error(),
 		})
 	}
 
-	return c.Status(fiber.StatusCreated).SendStatus(fiber.StatusCreated)
+	return sendAddedAssigneeWarnings(c, response)
 }
 
 // AddTransaction adds a transaction to an assignee
@@ -359,6 +722,10 @@ func (h *BusinessTripHandler) AddTransaction(c *fiber.Ctx) error {
 		})
 	}
 
+	if largeAmountOverrideDenied(c, req) {
+		return largeAmountOverrideDeniedResponse(c)
+	}
+
 	// Validate request
 	if err := req.Validate(); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -367,13 +734,28 @@ func (h *BusinessTripHandler) AddTransaction(c *fiber.Ctx) error {
 		})
 	}
 
-	_, err := h.addTransactionUseCase.Execute(context.Background(), assigneeID, req)
+	_, err := h.addTransactionUseCase.Execute(c.UserContext(), assigneeID, req, allowLockedTrip(c))
 	if err != nil {
+		if errors.Is(err, entity.ErrTransactionTypeNotAllowed) {
+			return transactionTypeNotAllowedResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrAmountExceedsCap) {
+			return amountExceedsCapResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrCreditExceedsCost) || errors.Is(err, entity.ErrCreditWithoutCostKind) {
+			return invalidCreditResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrUnsupportedCurrency) || errors.Is(err, entity.ErrInvalidExchangeRate) {
+			return invalidCurrencyResponse(c, err)
+		}
 		if err != nil && err.Error() == "assignee not found" {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "Assignee not found",
 			})
 		}
+		if errors.Is(err, entity.ErrBusinessTripLocked) {
+			return lockedTripResponse(c, err)
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   "Failed to add transaction",
 			"details": err.Error(),
@@ -392,7 +774,7 @@ func (h *BusinessTripHandler) GetBusinessTripSummary(c *fiber.Ctx) error {
 		})
 	}
 
-	summary, err := h.getBusinessTripSummaryUseCase.Execute(context.Background(), id)
+	summary, err := h.getBusinessTripSummaryUseCase.Execute(c.UserContext(), id)
 	if err != nil {
 		// Check if it's a not found error
 		if err != nil && err.Error() == "business trip not found" {
@@ -421,7 +803,7 @@ func (h *BusinessTripHandler) GetAssigneeSummary(c *fiber.Ctx) error {
 		})
 	}
 
-	summary, err := h.getAssigneeSummaryUseCase.Execute(context.Background(), id)
+	summary, err := h.getAssigneeSummaryUseCase.Execute(c.UserContext(), id)
 	if err != nil {
 		if err != nil && err.Error() == "assignee not found" {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
@@ -439,3 +821,350 @@ func (h *BusinessTripHandler) GetAssigneeSummary(c *fiber.Ctx) error {
 		"data":    summary,
 	})
 }
+
+// ExportTransactionsCSV streams all transactions of a business trip as a CSV file
+func (h *BusinessTripHandler) ExportTransactionsCSV(c *fiber.Ctx) error {
+	tripID := c.Params("tripId")
+	if tripID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Business trip ID is required",
+		})
+	}
+
+	err := h.exportTransactionsCSVUseCase.Execute(c.UserContext(), tripID, c.Response().BodyWriter())
+	if err != nil {
+		c.Response().ResetBody()
+		if errors.Is(err, entity.ErrBusinessTripNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Business trip not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to export transactions",
+			"details": err.Error(),
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="business-trip-%s-transactions.csv"`, tripID))
+
+	return nil
+}
+
+// GenerateCompletionDocument returns the completion report of a completed business trip as DOCX,
+// filled from the configured template or the built-in layout
+func (h *BusinessTripHandler) GenerateCompletionDocument(c *fiber.Ctx) error {
+	tripID := c.Params("tripId")
+	if tripID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Business trip ID is required",
+		})
+	}
+
+	data, err := h.generateCompletionDocumentUseCase.Execute(c.UserContext(), tripID)
+	if err != nil {
+		if errors.Is(err, entity.ErrBusinessTripNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Business trip not found",
+			})
+		}
+		if errors.Is(err, entity.ErrTripNotCompleted) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   "Business trip is not completed",
+				"details": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to generate completion document",
+			"details": err.Error(),
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "application/vnd.openxmlformats-officedocument.wordprocessingml.document")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="business-trip-%s-completion.docx"`, tripID))
+	return c.Send(data)
+}
+
+// GenerateBusinessTripPDF returns the SPD of a business trip as PDF. Trips still waiting for verificator
+// approvals are rejected unless ?draft=true asks for a watermarked draft.
+func (h *BusinessTripHandler) GenerateBusinessTripPDF(c *fiber.Ctx) error {
+	tripID := c.Params("tripId")
+	if tripID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Business trip ID is required",
+		})
+	}
+
+	data, err := h.generateBusinessTripPDFUseCase.Execute(c.UserContext(), tripID, c.QueryBool("draft"))
+	if err != nil {
+		if errors.Is(err, entity.ErrBusinessTripNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Business trip not found",
+			})
+		}
+		if errors.Is(err, entity.ErrTripNotApproved) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   "Business trip is not approved by all verificators",
+				"details": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to generate business trip PDF",
+			"details": err.Error(),
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="business-trip-%s.pdf"`, tripID))
+	return c.Send(data)
+}
+
+// VerifyBusinessTripPDF checks the signature printed in the QR code of a signed SPD against the trip as it is
+// stored now. The QR code links here, so the route is public.
+func (h *BusinessTripHandler) VerifyBusinessTripPDF(c *fiber.Ctx) error {
+	var req business_trip.VerifyBusinessTripPDFRequest
+	if err := c.ParamsParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid business trip ID",
+			"details": err.Error(),
+		})
+	}
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid query parameters",
+			"details": err.Error(),
+		})
+	}
+	if req.BusinessTripID == "" || req.Algorithm == "" || req.Signature == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Business trip ID, algorithm and signature are required",
+		})
+	}
+
+	response, err := h.verifyBusinessTripPDFUseCase.Execute(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, entity.ErrBusinessTripNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Business trip not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to verify business trip PDF",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Business trip PDF verified",
+		"data":    response,
+	})
+}
+
+// RecomputeTransactionSubtotals recalculates the stored subtotals of a business trip's transactions
+// with the current subtotal rules (admin only)
+func (h *BusinessTripHandler) RecomputeTransactionSubtotals(c *fiber.Ctx) error {
+	user, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   "Authentication required",
+			"details": err.Error(),
+		})
+	}
+	if !user.HasRole(entity.RoleAdmin) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Only administrators can recompute transaction subtotals",
+		})
+	}
+
+	tripID := c.Params("tripId")
+	if tripID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Business trip ID is required",
+		})
+	}
+
+	result, err := h.recomputeSubtotalsUseCase.Execute(c.UserContext(), tripID)
+	if err != nil {
+		if errors.Is(err, entity.ErrBusinessTripNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Business trip not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to recompute transaction subtotals",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(result)
+}
+
+// ExportBusinessTripsNDJSON streams every business trip as newline-delimited JSON (admin only).
+// start_date and end_date (YYYY-MM-DD) limit the export by trip start date; full=true includes transactions.
+func (h *BusinessTripHandler) ExportBusinessTripsNDJSON(c *fiber.Ctx) error {
+	user, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   "Authentication required",
+			"details": err.Error(),
+		})
+	}
+	if !user.HasRole(entity.RoleAdmin) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Only administrators can export business trips",
+		})
+	}
+
+	req := business_trip.ExportBusinessTripsRequest{Full: c.QueryBool("full")}
+	if req.StartDate, err = parseExportDate(c.Query("start_date")); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid start_date, expected YYYY-MM-DD",
+			"details": err.Error(),
+		})
+	}
+	if req.EndDate, err = parseExportDate(c.Query("end_date")); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid end_date, expected YYYY-MM-DD",
+			"details": err.Error(),
+		})
+	}
+	if req.StartDate != nil && req.EndDate != nil && req.StartDate.After(*req.EndDate) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "start_date must not be after end_date",
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "application/x-ndjson")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="business-trips.ndjson"`)
+
+	// The status and headers are sent before the first trip is read, so errors
+	// past this point can only be logged; the client sees a truncated stream.
+	// The stream is written after the handler returns, when the request timeout
+	// has already been released, so only the values of the request context are kept.
+	ctx := context.WithoutCancel(c.UserContext())
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := h.exportBusinessTripsNDJSONUseCase.Execute(ctx, req, w); err != nil {
+			log.Printf("Error exporting business trips: %v", err)
+		}
+		if err := w.Flush(); err != nil {
+			log.Printf("Error flushing business trip export: %v", err)
+		}
+	})
+
+	return nil
+}
+
+// parseExportDate parses an optional YYYY-MM-DD query value
+func parseExportDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
+
+// allowLockedTrip reports whether the request overrides the lock on a completed or canceled
+// business trip. Only administrators correcting a reopened trip may pass override=true.
+func allowLockedTrip(c *fiber.Ctx) bool {
+	return c.QueryBool("override") && callerIsAdmin(c)
+}
+
+// callerIsAdmin reports whether the authenticated caller is an administrator
+func callerIsAdmin(c *fiber.Ctx) bool {
+	user, err := middleware.GetAuthenticatedUser(c)
+	return err == nil && user.HasRole(entity.RoleAdmin)
+}
+
+// largeAmountOverrideDenied reports whether any of the transactions asks to skip the amount cap of its
+// type while the caller is not an administrator
+func largeAmountOverrideDenied(c *fiber.Ctx, transactions ...business_trip.TransactionRequest) bool {
+	for _, transaction := range transactions {
+		if transaction.AllowLargeAmount {
+			return !callerIsAdmin(c)
+		}
+	}
+	return false
+}
+
+func largeAmountOverrideDeniedResponse(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+		"error": "Only administrators can set allow_large_amount to enter an amount above the maximum for its type",
+	})
+}
+
+// assigneeTransactions lists the transactions of all assignees in a request
+func assigneeTransactions(assignees []business_trip.AssigneeRequest) []business_trip.TransactionRequest {
+	var transactions []business_trip.TransactionRequest
+	for _, assignee := range assignees {
+		transactions = append(transactions, assignee.Transactions...)
+	}
+	return transactions
+}
+
+// amountExceedsCapResponse rejects a transaction amount above the configured maximum for its type
+func amountExceedsCapResponse(c *fiber.Ctx, err error) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error":   "Validation failed",
+		"details": err.Error(),
+	})
+}
+
+// tooManyVerificatorsResponse rejects a business trip with more verificators than BUSINESS_TRIP_MAX_VERIFICATORS
+func tooManyVerificatorsResponse(c *fiber.Ctx, err error) error {
+	return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+		"error":   "Too many verificators",
+		"details": err.Error(),
+	})
+}
+
+// tripTooLongResponse reports a trip longer than the configured maximum that was not forced
+func tripTooLongResponse(c *fiber.Ctx, err error) error {
+	return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+		"error":   "Business trip is longer than allowed, check the dates or set force for a long assignment",
+		"details": err.Error(),
+	})
+}
+
+// lockedTripResponse rejects a change to a completed or canceled business trip
+func lockedTripResponse(c *fiber.Ctx, err error) error {
+	return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+		"error":   "Business trip is locked",
+		"details": err.Error(),
+	})
+}
+
+// duplicateSPDNumberResponse rejects an assignee whose SPD number is already used on the business trip
+func duplicateSPDNumberResponse(c *fiber.Ctx, err error) error {
+	return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+		"error":   "SPD number already exists for this business trip",
+		"details": err.Error(),
+	})
+}
+
+// invalidCreditResponse rejects a refund that does not offset a cost of the same kind
+func invalidCreditResponse(c *fiber.Ctx, err error) error {
+	return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+		"error":   "Invalid credit transaction",
+		"details": err.Error(),
+	})
+}
+
+// invalidCurrencyResponse rejects a transaction currency or exchange rate that cannot convert it to the base currency
+func invalidCurrencyResponse(c *fiber.Ctx, err error) error {
+	return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+		"error":   "Invalid transaction currency",
+		"details": err.Error(),
+	})
+}
+
+// transactionTypeNotAllowedResponse rejects a transaction type the caller's organization does not allow
+func transactionTypeNotAllowedResponse(c *fiber.Ctx, err error) error {
+	return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+		"error":   "Transaction type not allowed",
+		"details": err.Error(),
+	})
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"sandbox/internal/delivery/http/middleware"
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/internal/usecase/business_trip"
+	"sandbox/pkg/pagination"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// stubSlowTripRepository blocks in GetByID until the context is done and reports why it stopped
+type stubSlowTripRepository struct {
+	repository.BusinessTripRepository
+	stopped chan error
+}
+
+func (r *stubSlowTripRepository) GetByID(ctx context.Context, _ string) (*entity.BusinessTrip, error) {
+	select {
+	case <-ctx.Done():
+		r.stopped <- ctx.Err()
+		return nil, ctx.Err()
+	case <-time.After(5 * time.Second):
+		r.stopped <- nil
+		return &entity.BusinessTrip{}, nil
+	}
+}
+
+func newSlowTripApp(repo *stubSlowTripRepository, handlers ...fiber.Handler) *fiber.App {
+	h := NewBusinessTripHandler(nil, business_trip.NewGetBusinessTripUseCase(repo, nil, 0),
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, pagination.Limits{})
+	app := fiber.New()
+	app.Get("/business-trips/:tripId", append(handlers, h.GetBusinessTrip)...)
+	return app
+}
+
+func TestGetBusinessTripAbortsSlowQueryOnTimeout(t *testing.T) {
+	repo := &stubSlowTripRepository{stopped: make(chan error, 1)}
+	app := newSlowTripApp(repo, middleware.RequestTimeout(50*time.Millisecond))
+
+	start := time.Now()
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/business-trips/trip-1", nil), 2000)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusGatewayTimeout {
+		t.Errorf("status = %d, want %d", resp.StatusCode, fiber.StatusGatewayTimeout)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("request took %v, want it cut short by the timeout", elapsed)
+	}
+	if got := <-repo.stopped; !errors.Is(got, context.DeadlineExceeded) {
+		t.Errorf("query stopped with %v, want context.DeadlineExceeded", got)
+	}
+}
+
+func TestGetBusinessTripAbortsSlowQueryOnCancel(t *testing.T) {
+	repo := &stubSlowTripRepository{stopped: make(chan error, 1)}
+	cancelRequest := func(c *fiber.Ctx) error {
+		ctx, cancel := context.WithCancel(c.UserContext())
+		time.AfterFunc(20*time.Millisecond, cancel)
+		c.SetUserContext(ctx)
+		return c.Next()
+	}
+	app := newSlowTripApp(repo, cancelRequest)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/business-trips/trip-1", nil), 2000)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", resp.StatusCode, fiber.StatusInternalServerError)
+	}
+	if got := <-repo.stopped; !errors.Is(got, context.Canceled) {
+		t.Errorf("query stopped with %v, want context.Canceled", got)
+	}
+}
+
+type stubTripRepository struct {
+	repository.BusinessTripRepository
+	trip *entity.BusinessTrip
+}
+
+func (r *stubTripRepository) GetByID(context.Context, string) (*entity.BusinessTrip, error) {
+	return r.trip, nil
+}
+
+func TestGetBusinessTripNotModified(t *testing.T) {
+	updatedAt := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	transaction := &entity.Transaction{ID: "tx-1", UpdatedAt: updatedAt}
+	trip := &entity.BusinessTrip{ID: "trip-1", Version: 2, UpdatedAt: updatedAt, Assignees: []*entity.Assignee{
+		{ID: "assignee-1", UpdatedAt: updatedAt, Transactions: []*entity.Transaction{transaction}},
+	}}
+	h := NewBusinessTripHandler(nil, business_trip.NewGetBusinessTripUseCase(&stubTripRepository{trip: trip}, nil, 0),
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, pagination.Limits{})
+	app := fiber.New()
+	app.Get("/business-trips/:tripId", h.GetBusinessTrip)
+
+	get := func(ifNoneMatch string) *http.Response {
+		t.Helper()
+		req := httptest.NewRequest(fiber.MethodGet, "/business-trips/trip-1", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set(fiber.HeaderIfNoneMatch, ifNoneMatch)
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		return resp
+	}
+
+	resp := get("")
+	etag := resp.Header.Get(fiber.HeaderETag)
+	if resp.StatusCode != fiber.StatusOK || !strings.HasPrefix(etag, `W/"`) {
+		t.Fatalf("status = %d, ETag = %q, want 200 with a weak ETag", resp.StatusCode, etag)
+	}
+
+	resp = get(etag)
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != fiber.StatusNotModified || len(body) != 0 {
+		t.Errorf("status = %d with %d body bytes, want 304 without a body", resp.StatusCode, len(body))
+	}
+
+	// Editing a nested transaction must invalidate the client's copy
+	transaction.UpdatedAt = updatedAt.Add(time.Second)
+	resp = get(etag)
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("status = %d after a transaction changed, want %d", resp.StatusCode, fiber.StatusOK)
+	}
+	if got := resp.Header.Get(fiber.HeaderETag); got == etag {
+		t.Errorf("ETag stayed %q after a transaction changed", got)
+	}
+}
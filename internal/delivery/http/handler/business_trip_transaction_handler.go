@@ -2,7 +2,14 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
 
+	"sandbox/internal/domain/entity"
 	"sandbox/internal/usecase/business_trip"
 
 	"github.com/gofiber/fiber/v2"
@@ -13,26 +20,38 @@ type BusinessTripTransactionHandler struct {
 	addTransactionUseCase    *business_trip.AddTransactionUseCase
 	getTransactionUseCase    *business_trip.GetTransactionUseCase
 	updateTransactionUseCase *business_trip.UpdateTransactionUseCase
+	patchTransactionUseCase  *business_trip.PatchTransactionUseCase
 	deleteTransactionUseCase *business_trip.DeleteTransactionUseCase
 	listTransactionsUseCase  *business_trip.ListTransactionsUseCase
 	getAssigneeUseCase       *business_trip.GetAssigneeUseCase
+	addAttachmentUseCase     *business_trip.AddTransactionAttachmentUseCase
+	listAttachmentsUseCase   *business_trip.ListTransactionAttachmentsUseCase
+	deleteAttachmentUseCase  *business_trip.DeleteTransactionAttachmentUseCase
 }
 
 func NewBusinessTripTransactionHandler(
 	addTransactionUseCase *business_trip.AddTransactionUseCase,
 	getTransactionUseCase *business_trip.GetTransactionUseCase,
 	updateTransactionUseCase *business_trip.UpdateTransactionUseCase,
+	patchTransactionUseCase *business_trip.PatchTransactionUseCase,
 	deleteTransactionUseCase *business_trip.DeleteTransactionUseCase,
 	listTransactionsUseCase *business_trip.ListTransactionsUseCase,
 	getAssigneeUseCase *business_trip.GetAssigneeUseCase,
+	addAttachmentUseCase *business_trip.AddTransactionAttachmentUseCase,
+	listAttachmentsUseCase *business_trip.ListTransactionAttachmentsUseCase,
+	deleteAttachmentUseCase *business_trip.DeleteTransactionAttachmentUseCase,
 ) *BusinessTripTransactionHandler {
 	return &BusinessTripTransactionHandler{
 		addTransactionUseCase:    addTransactionUseCase,
 		getTransactionUseCase:    getTransactionUseCase,
 		updateTransactionUseCase: updateTransactionUseCase,
+		patchTransactionUseCase:  patchTransactionUseCase,
 		deleteTransactionUseCase: deleteTransactionUseCase,
 		listTransactionsUseCase:  listTransactionsUseCase,
 		getAssigneeUseCase:       getAssigneeUseCase,
+		addAttachmentUseCase:     addAttachmentUseCase,
+		listAttachmentsUseCase:   listAttachmentsUseCase,
+		deleteAttachmentUseCase:  deleteAttachmentUseCase,
 	}
 }
 
@@ -54,6 +73,10 @@ func (h *BusinessTripTransactionHandler) Create(c *fiber.Ctx) error {
 		})
 	}
 
+	if largeAmountOverrideDenied(c, req) {
+		return largeAmountOverrideDeniedResponse(c)
+	}
+
 	// Validate request
 	if err := req.Validate(); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -62,13 +85,28 @@ func (h *BusinessTripTransactionHandler) Create(c *fiber.Ctx) error {
 		})
 	}
 
-	_, err := h.addTransactionUseCase.Execute(context.Background(), assigneeID, req)
+	_, err := h.addTransactionUseCase.Execute(c.UserContext(), assigneeID, req, allowLockedTrip(c))
 	if err != nil {
+		if errors.Is(err, entity.ErrTransactionTypeNotAllowed) {
+			return transactionTypeNotAllowedResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrAmountExceedsCap) {
+			return amountExceedsCapResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrCreditExceedsCost) || errors.Is(err, entity.ErrCreditWithoutCostKind) {
+			return invalidCreditResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrUnsupportedCurrency) || errors.Is(err, entity.ErrInvalidExchangeRate) {
+			return invalidCurrencyResponse(c, err)
+		}
 		if err != nil && err.Error() == "assignee not found" {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "Assignee not found",
 			})
 		}
+		if errors.Is(err, entity.ErrBusinessTripLocked) {
+			return lockedTripResponse(c, err)
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   "Failed to add transaction",
 			"details": err.Error(),
@@ -87,7 +125,7 @@ func (h *BusinessTripTransactionHandler) List(c *fiber.Ctx) error {
 		})
 	}
 
-	response, err := h.listTransactionsUseCase.Execute(context.Background(), assigneeID)
+	response, err := h.listTransactionsUseCase.Execute(c.UserContext(), assigneeID)
 	if err != nil {
 		if err != nil && err.Error() == "assignee not found" {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
@@ -115,7 +153,7 @@ func (h *BusinessTripTransactionHandler) Get(c *fiber.Ctx) error {
 		})
 	}
 
-	response, err := h.getTransactionUseCase.Execute(context.Background(), transactionID)
+	response, err := h.getTransactionUseCase.Execute(c.UserContext(), transactionID)
 	if err != nil {
 		if err != nil && err.Error() == "transaction not found" {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
@@ -168,6 +206,11 @@ func (h *BusinessTripTransactionHandler) Update(c *fiber.Ctx) error {
 			"details": err.Error(),
 		})
 	}
+	req.AllowLockedTrip = allowLockedTrip(c)
+
+	if req.AllowLargeAmount && !callerIsAdmin(c) {
+		return largeAmountOverrideDeniedResponse(c)
+	}
 
 	// Validate request
 	if err := req.Validate(); err != nil {
@@ -177,13 +220,127 @@ func (h *BusinessTripTransactionHandler) Update(c *fiber.Ctx) error {
 		})
 	}
 
-	_, err := h.updateTransactionUseCase.Execute(context.Background(), req)
+	_, err := h.updateTransactionUseCase.Execute(c.UserContext(), req)
 	if err != nil {
-		if err != nil && err.Error() == "transaction not found" {
+		if errors.Is(err, entity.ErrIncompatibleSubtype) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": err.Error(),
+			})
+		}
+		if errors.Is(err, entity.ErrTransactionTypeNotAllowed) {
+			return transactionTypeNotAllowedResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrCreditExceedsCost) || errors.Is(err, entity.ErrCreditWithoutCostKind) {
+			return invalidCreditResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrUnsupportedCurrency) || errors.Is(err, entity.ErrInvalidExchangeRate) {
+			return invalidCurrencyResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrNightsAndDaysBothSet) || errors.Is(err, entity.ErrAmountExceedsCap) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": err.Error(),
+			})
+		}
+		if errors.Is(err, entity.ErrBusinessTripNotFound) || errors.Is(err, entity.ErrAssigneeNotFound) || errors.Is(err, entity.ErrTransactionNotFound) {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "Transaction not found",
+				"error":   "Transaction not found",
+				"details": err.Error(),
+			})
+		}
+		if errors.Is(err, entity.ErrBusinessTripLocked) {
+			return lockedTripResponse(c, err)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to update transaction",
+			"details": err.Error(),
+		})
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// Patch updates only the fields of a transaction present in the request body
+func (h *BusinessTripTransactionHandler) Patch(c *fiber.Ctx) error {
+	tripId := c.Params("tripId")
+	assigneeID := c.Params("assigneeId")
+	transactionID := c.Params("transactionId")
+	if tripId == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Business trip ID is required",
+		})
+	}
+	if assigneeID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Assignee ID is required",
+		})
+	}
+	if transactionID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Transaction ID is required",
+		})
+	}
+
+	var req business_trip.PatchTransactionRequest
+
+	// Parse path parameters first
+	req.BusinessTripID = tripId
+	req.AssigneeID = assigneeID
+	req.TransactionID = transactionID
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+	}
+	req.AllowLockedTrip = allowLockedTrip(c)
+
+	if req.AllowLargeAmount && !callerIsAdmin(c) {
+		return largeAmountOverrideDeniedResponse(c)
+	}
+
+	// Validate request
+	if err := req.Validate(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Validation failed",
+			"details": err.Error(),
+		})
+	}
+
+	_, err := h.patchTransactionUseCase.Execute(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, entity.ErrIncompatibleSubtype) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": err.Error(),
+			})
+		}
+		if errors.Is(err, entity.ErrTransactionTypeNotAllowed) {
+			return transactionTypeNotAllowedResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrCreditExceedsCost) || errors.Is(err, entity.ErrCreditWithoutCostKind) {
+			return invalidCreditResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrUnsupportedCurrency) || errors.Is(err, entity.ErrInvalidExchangeRate) {
+			return invalidCurrencyResponse(c, err)
+		}
+		if errors.Is(err, entity.ErrNightsAndDaysBothSet) || errors.Is(err, entity.ErrAmountExceedsCap) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": err.Error(),
 			})
 		}
+		if errors.Is(err, entity.ErrBusinessTripNotFound) || errors.Is(err, entity.ErrAssigneeNotFound) || errors.Is(err, entity.ErrTransactionNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error":   "Transaction not found",
+				"details": err.Error(),
+			})
+		}
+		if errors.Is(err, entity.ErrBusinessTripLocked) {
+			return lockedTripResponse(c, err)
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   "Failed to update transaction",
 			"details": err.Error(),
@@ -216,7 +373,7 @@ func (h *BusinessTripTransactionHandler) Delete(c *fiber.Ctx) error {
 
 	// Manual parent validation before deleting
 	// Verify transaction belongs to the assignee and assignee belongs to business trip
-	transaction, err := h.getTransactionUseCase.Execute(context.Background(), transactionID)
+	transaction, err := h.getTransactionUseCase.Execute(c.UserContext(), transactionID)
 	if err != nil {
 		if err != nil && err.Error() == "transaction not found" {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
@@ -236,7 +393,7 @@ func (h *BusinessTripTransactionHandler) Delete(c *fiber.Ctx) error {
 	}
 
 	// Get assignee to verify it belongs to business trip
-	assignee, err := h.getAssigneeUseCase.Execute(context.Background(), assigneeID)
+	assignee, err := h.getAssigneeUseCase.Execute(c.UserContext(), assigneeID)
 	if err != nil {
 		if err != nil && err.Error() == "assignee not found" {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
@@ -256,13 +413,16 @@ func (h *BusinessTripTransactionHandler) Delete(c *fiber.Ctx) error {
 	}
 
 	// Delete transaction
-	err = h.deleteTransactionUseCase.Execute(context.Background(), transactionID)
+	err = h.deleteTransactionUseCase.Execute(c.UserContext(), transactionID, allowLockedTrip(c))
 	if err != nil {
 		if err != nil && err.Error() == "transaction not found" {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "Transaction not found",
 			})
 		}
+		if errors.Is(err, entity.ErrBusinessTripLocked) {
+			return lockedTripResponse(c, err)
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   "Failed to delete transaction",
 			"details": err.Error(),
@@ -273,3 +433,166 @@ func (h *BusinessTripTransactionHandler) Delete(c *fiber.Ctx) error {
 		"message": "Transaction deleted successfully",
 	})
 }
+
+// UploadAttachments attaches one or more receipt files, sent as multipart "file" fields, to a transaction
+func (h *BusinessTripTransactionHandler) UploadAttachments(c *fiber.Ctx) error {
+	if err := h.checkAssigneeInTrip(c.UserContext(), c.Params("tripId"), c.Params("assigneeId")); err != nil {
+		return attachmentErrorResponse(c, err, "Failed to upload attachments")
+	}
+
+	// Parse multipart form
+	form, err := c.MultipartForm()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to parse form data",
+		})
+	}
+
+	fileHeaders := form.File["file"]
+	if len(fileHeaders) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "No files uploaded",
+		})
+	}
+
+	req := business_trip.AddTransactionAttachmentRequest{
+		AssigneeID:    c.Params("assigneeId"),
+		TransactionID: c.Params("transactionId"),
+		Files:         make([]business_trip.AttachmentFile, 0, len(fileHeaders)),
+	}
+	for _, fileHeader := range fileHeaders {
+		if fileHeader.Size > entity.MaxAttachmentSize {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": fmt.Sprintf("%s exceeds the maximum size of %d MB", fileHeader.Filename, entity.MaxAttachmentSize>>20),
+			})
+		}
+
+		content, err := readMultipartFile(fileHeader)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Failed to read uploaded file",
+				"details": err.Error(),
+			})
+		}
+
+		req.Files = append(req.Files, business_trip.AttachmentFile{
+			FileName:    fileHeader.Filename,
+			ContentType: attachmentContentType(fileHeader, content),
+			Content:     content,
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Validation failed",
+			"details": err.Error(),
+		})
+	}
+
+	response, err := h.addAttachmentUseCase.Execute(c.UserContext(), req)
+	if err != nil {
+		return attachmentErrorResponse(c, err, "Failed to upload attachments")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Attachments uploaded successfully",
+		"data":    response,
+	})
+}
+
+// ListAttachments lists the receipt files attached to a transaction
+func (h *BusinessTripTransactionHandler) ListAttachments(c *fiber.Ctx) error {
+	if err := h.checkAssigneeInTrip(c.UserContext(), c.Params("tripId"), c.Params("assigneeId")); err != nil {
+		return attachmentErrorResponse(c, err, "Failed to get attachments")
+	}
+
+	response, err := h.listAttachmentsUseCase.Execute(c.UserContext(), c.Params("assigneeId"), c.Params("transactionId"))
+	if err != nil {
+		return attachmentErrorResponse(c, err, "Failed to get attachments")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Attachments retrieved successfully",
+		"data":    response,
+	})
+}
+
+// DeleteAttachment removes a receipt file from a transaction
+func (h *BusinessTripTransactionHandler) DeleteAttachment(c *fiber.Ctx) error {
+	if err := h.checkAssigneeInTrip(c.UserContext(), c.Params("tripId"), c.Params("assigneeId")); err != nil {
+		return attachmentErrorResponse(c, err, "Failed to delete attachment")
+	}
+
+	err := h.deleteAttachmentUseCase.Execute(c.UserContext(), c.Params("assigneeId"), c.Params("transactionId"), c.Params("attachmentId"))
+	if err != nil {
+		return attachmentErrorResponse(c, err, "Failed to delete attachment")
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Attachment deleted successfully",
+	})
+}
+
+// checkAssigneeInTrip verifies that the assignee exists and belongs to the business trip
+func (h *BusinessTripTransactionHandler) checkAssigneeInTrip(ctx context.Context, tripID, assigneeID string) error {
+	assignee, err := h.getAssigneeUseCase.Execute(ctx, assigneeID)
+	if err != nil {
+		if err.Error() == "assignee not found" {
+			return entity.ErrAssigneeNotFound
+		}
+		return err
+	}
+	if assignee.BusinessTripID != tripID {
+		return entity.ErrAssigneeNotFound
+	}
+	return nil
+}
+
+func attachmentErrorResponse(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, entity.ErrAssigneeNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Assignee not found",
+		})
+	case errors.Is(err, entity.ErrTransactionNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Transaction not found",
+		})
+	case errors.Is(err, entity.ErrAttachmentNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Attachment not found",
+		})
+	case errors.Is(err, entity.ErrInvalidAttachment):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Validation failed",
+			"details": err.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error":   message,
+		"details": err.Error(),
+	})
+}
+
+func readMultipartFile(fileHeader *multipart.FileHeader) ([]byte, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return io.ReadAll(file)
+}
+
+// attachmentContentType uses the declared part content type, falling back to sniffing the content
+func attachmentContentType(fileHeader *multipart.FileHeader, content []byte) string {
+	contentType := fileHeader.Header.Get("Content-Type")
+	if contentType == "" || contentType == "application/octet-stream" {
+		contentType = http.DetectContentType(content)
+	}
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType
+	}
+	return contentType
+}
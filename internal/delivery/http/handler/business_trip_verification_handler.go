@@ -1,7 +1,14 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
 	"sandbox/internal/delivery/http/middleware"
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/service"
 	"sandbox/internal/usecase/business_trip"
 	"sandbox/pkg/pagination"
 
@@ -13,6 +20,11 @@ import (
 type BusinessTripVerificationHandler struct {
 	verifyUseCase           *business_trip.VerifyBusinessTripUseCase
 	listVerificatorsUseCase *business_trip.ListVerificatorsUseCase
+	tripVerificatorsUseCase *business_trip.ListBusinessTripVerificatorsUseCase
+	bulkUpdateUseCase       *business_trip.BulkUpdateVerificatorsUseCase
+	historyUseCase          *business_trip.GetVerificatorHistoryUseCase
+	reminderService         *service.VerificatorReminderService
+	queryParser             *pagination.QueryParser
 	validator               *validator.Validate
 }
 
@@ -20,17 +32,27 @@ type BusinessTripVerificationHandler struct {
 func NewBusinessTripVerificationHandler(
 	verifyUseCase *business_trip.VerifyBusinessTripUseCase,
 	listVerificatorsUseCase *business_trip.ListVerificatorsUseCase,
+	tripVerificatorsUseCase *business_trip.ListBusinessTripVerificatorsUseCase,
+	bulkUpdateUseCase *business_trip.BulkUpdateVerificatorsUseCase,
+	historyUseCase *business_trip.GetVerificatorHistoryUseCase,
+	reminderService *service.VerificatorReminderService,
+	pageLimits pagination.Limits,
 ) *BusinessTripVerificationHandler {
 	return &BusinessTripVerificationHandler{
 		verifyUseCase:           verifyUseCase,
 		listVerificatorsUseCase: listVerificatorsUseCase,
+		tripVerificatorsUseCase: tripVerificatorsUseCase,
+		bulkUpdateUseCase:       bulkUpdateUseCase,
+		historyUseCase:          historyUseCase,
+		reminderService:         reminderService,
+		queryParser:             &pagination.QueryParser{Limits: pageLimits},
 		validator:               validator.New(),
 	}
 }
 
 // VerifyBusinessTrip handles the verification of a business trip
 // @Summary Verify Business Trip
-// @Description Allows a verificator to approve or reject a business trip that is in ready_to_verify status
+// @Description Approves or rejects a business trip in ready_to_verify status as the authenticated user's own verificator; users who are not verificators of the trip get 403
 // @Tags business-trips
 // @Accept json
 // @Produce json
@@ -39,6 +61,7 @@ func NewBusinessTripVerificationHandler(
 // @Success 200 {object} StandardResponse{data=business_trip.VerifyBusinessTripResponse}
 // @Failure 400 {object} StandardResponse
 // @Failure 401 {object} StandardResponse
+// @Failure 403 {object} StandardResponse
 // @Failure 404 {object} StandardResponse
 // @Failure 500 {object} StandardResponse
 // @Router /api/v1/business-trips/{tripId}/verify [post]
@@ -83,46 +106,32 @@ func (h *BusinessTripVerificationHandler) VerifyBusinessTrip(c *fiber.Ctx) error
 		})
 	}
 
-	authenticatedUser, _ := middleware.GetAuthenticatedUser(c)
-
-	// Execute use case
-	// The context should contain user_id from authentication middleware
-	response, err := h.verifyUseCase.Execute(c.Context(), req, *authenticatedUser)
+	authenticatedUser, err := middleware.GetAuthenticatedUser(c)
 	if err != nil {
-		// Handle authentication error
-		if err.Error() == "authentication error: user not authenticated or user_id not found in context" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"success": false,
-				"error":   "Authentication required",
-			})
-		}
-
-		// Handle different types of errors appropriately
-		if err.Error() == "business trip must be in ready_to_verify status to be verified" {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"success": false,
-				"error":   err.Error(),
-			})
-		}
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"error":   "Authentication required",
+		})
+	}
 
-		// Check if it's a "not found" error
-		if err.Error() == "failed to get business trip: record not found" {
+	// The verificator is the authenticated user's own record on the trip
+	response, err := h.verifyUseCase.Execute(c.UserContext(), req, *authenticatedUser)
+	if err != nil {
+		if errors.Is(err, entity.ErrBusinessTripNotFound) {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"success": false,
 				"error":   "Business trip not found",
 			})
 		}
 
-		// Check if it's a verificator not found or unauthorized error
-		if err.Error() == "failed to get verificator: record not found" {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+		if errors.Is(err, entity.ErrNotTripVerificator) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
 				"success": false,
 				"error":   "You are not assigned as a verificator for this business trip",
 			})
 		}
 
-		if err.Error() == "verificator has already approved this business trip" ||
-			err.Error() == "verificator has already rejected this business trip" {
+		if errors.Is(err, entity.ErrTripNotReadyToVerify) || errors.Is(err, entity.ErrAlreadyVerified) {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 				"success": false,
 				"error":   err.Error(),
@@ -150,10 +159,12 @@ func (h *BusinessTripVerificationHandler) VerifyBusinessTrip(c *fiber.Ctx) error
 // @Accept json
 // @Produce json
 // @Param page query int false "Page number (default: 1)"
-// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Param limit query int false "Items per page (default: 20, max: 100 unless configured otherwise)"
 // @Param sort query string false "Sort fields (e.g., 'status asc,business_trip_number desc')"
-// @Param status query string false "Filter by verification status (pending, approved, rejected)"
-// @Param business_trip_status query string false "Filter by business trip status (draft, ongoing, completed, canceled, ready_to_verify)"
+// @Param status query string false "Filter by verification status, comma-separated (pending, approved, rejected)"
+// @Param business_trip_status query string false "Filter by business trip status, comma-separated (draft, ongoing, completed, canceled, ready_to_verify)"
+// @Param verified_from query string false "Only verifications on or after this date (YYYY-MM-DD or RFC3339)"
+// @Param verified_to query string false "Only verifications on or before this date (YYYY-MM-DD or RFC3339)"
 // @Param user_id query string false "Filter by user ID"
 // @Param destination_city query string false "Filter by destination city"
 // @Param activity_purpose query string false "Filter by activity purpose (contains)"
@@ -168,8 +179,8 @@ func (h *BusinessTripVerificationHandler) ListVerificators(c *fiber.Ctx) error {
 		queryParams[string(key)] = string(value)
 	})
 
-	queryParser := &pagination.QueryParser{}
-	params, err := queryParser.Parse(queryParams)
+	// The explicit filters are taken out first so the generic parser does not treat them as column filters
+	verificatorFilters, err := parseVerificatorListFilters(queryParams)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"success": false,
@@ -177,9 +188,24 @@ func (h *BusinessTripVerificationHandler) ListVerificators(c *fiber.Ctx) error {
 		})
 	}
 
+	params, err := h.queryParser.Parse(queryParams)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "Invalid query parameters: " + err.Error(),
+		})
+	}
+	params.Filters = append(params.Filters, verificatorFilters...)
+
 	// Execute use case
-	verificators, pagination, err := h.listVerificatorsUseCase.Execute(c.Context(), params)
+	verificators, pagedResponse, err := h.listVerificatorsUseCase.Execute(c.UserContext(), params)
 	if err != nil {
+		if errors.Is(err, pagination.ErrInvalidFilter) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"success": false,
+				"error":   "Invalid query parameters: " + err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"success": false,
 			"error":   "Failed to retrieve verificators",
@@ -188,7 +214,435 @@ func (h *BusinessTripVerificationHandler) ListVerificators(c *fiber.Ctx) error {
 	}
 
 	// Set the data in pagination response
-	pagination.Data = verificators
+	pagedResponse.Data = verificators
+
+	return c.JSON(pagedResponse)
+}
+
+// ListMyVerifications lists the verifications assigned to the authenticated user with their trips
+// @Summary List My Verifications
+// @Description Retrieves the paginated verificator entries of the authenticated user joined to their business trips; only pending ones unless status says otherwise
+// @Tags business-trips
+// @Produce json
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100 unless configured otherwise)"
+// @Param sort query string false "Sort fields (e.g., 'created_at desc')"
+// @Param status query string false "Filter by verification status, comma-separated (default: pending)"
+// @Param business_trip_status query string false "Filter by business trip status, comma-separated (e.g., ready_to_verify)"
+// @Success 200 {object} pagination.PagedResponse{data=[]business_trip.ListVerificatorsResponse}
+// @Failure 400 {object} StandardResponse
+// @Failure 401 {object} StandardResponse
+// @Failure 500 {object} StandardResponse
+// @Router /api/v1/me/verifications [get]
+func (h *BusinessTripVerificationHandler) ListMyVerifications(c *fiber.Ctx) error {
+	user, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"error":   "Authentication required",
+			"details": err.Error(),
+		})
+	}
+
+	queryParams := make(map[string]string)
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		queryParams[string(key)] = string(value)
+	})
+	if _, ok := queryParams["status"]; !ok {
+		queryParams["status"] = string(entity.VerificatorStatusPending)
+	}
+	// The user is always the authenticated one
+	delete(queryParams, "user_id")
 
-	return c.JSON(pagination)
+	verificatorFilters, err := parseVerificatorListFilters(queryParams)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "Invalid query parameters: " + err.Error(),
+		})
+	}
+
+	params, err := h.queryParser.Parse(queryParams)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "Invalid query parameters: " + err.Error(),
+		})
+	}
+	params.Filters = append(params.Filters, verificatorFilters...)
+	params.Filters = append(params.Filters, pagination.Filter{Field: "v.user_id", Operator: "eq", Value: user.ID})
+	if len(params.Sorts) == 0 {
+		params.Sorts = []pagination.Sort{{Field: "v.created_at", Order: "asc"}}
+	}
+
+	verifications, pagedResponse, err := h.listVerificatorsUseCase.Execute(c.UserContext(), params)
+	if err != nil {
+		if errors.Is(err, pagination.ErrInvalidFilter) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"success": false,
+				"error":   "Invalid query parameters: " + err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"error":   "Failed to retrieve verifications",
+			"details": err.Error(),
+		})
+	}
+
+	pagedResponse.Data = verifications
+
+	return c.JSON(pagedResponse)
+}
+
+// ListBusinessTripVerificators lists the verificators of one business trip with pagination
+// @Summary List Verificators of a Business Trip
+// @Description Pages through the verificators of a business trip; the trip detail only embeds the first few
+// @Tags business-trips
+// @Produce json
+// @Param tripId path string true "Business Trip ID"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100 unless configured otherwise)"
+// @Param sort query string false "Sort by creation time: 'created_at asc' (default) or 'created_at desc'"
+// @Param status query string false "Filter by verification status, comma-separated (pending, approved, rejected)"
+// @Success 200 {object} pagination.PagedResponse{data=[]business_trip.VerificatorResponse}
+// @Failure 400 {object} StandardResponse
+// @Failure 404 {object} StandardResponse
+// @Failure 500 {object} StandardResponse
+// @Router /api/v1/business-trips/{tripId}/verificators [get]
+func (h *BusinessTripVerificationHandler) ListBusinessTripVerificators(c *fiber.Ctx) error {
+	businessTripID := c.Params("tripId")
+	if businessTripID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "Business trip ID is required",
+		})
+	}
+
+	queryParams := make(map[string]string)
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		queryParams[string(key)] = string(value)
+	})
+
+	params, err := parseTripVerificatorListParams(h.queryParser, queryParams)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "Invalid query parameters: " + err.Error(),
+		})
+	}
+
+	verificators, pagedResponse, err := h.tripVerificatorsUseCase.Execute(c.UserContext(), businessTripID, params)
+	if err != nil {
+		if errors.Is(err, entity.ErrBusinessTripNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"success": false,
+				"error":   "Business trip not found",
+			})
+		}
+		if errors.Is(err, pagination.ErrInvalidFilter) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"success": false,
+				"error":   "Invalid query parameters: " + err.Error(),
+			})
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"error":   "Failed to retrieve verificators",
+			"details": err.Error(),
+		})
+	}
+
+	pagedResponse.Data = verificators
+
+	return c.JSON(pagedResponse)
+}
+
+// parseTripVerificatorListParams reads the page, limit, status and sort parameters of a trip's
+// verificator list. Only created_at can be sorted on, and other parameters are ignored.
+func parseTripVerificatorListParams(parser *pagination.QueryParser, params map[string]string) (*pagination.QueryParams, error) {
+	pageParams := make(map[string]string)
+	for _, key := range []string{"page", "limit"} {
+		if value, ok := params[key]; ok {
+			pageParams[key] = value
+		}
+	}
+
+	result, err := parser.Parse(pageParams)
+	if err != nil {
+		return nil, err
+	}
+
+	if value, ok := params["status"]; ok {
+		if filter, ok := statusFilter("v.status", value); ok {
+			result.Filters = append(result.Filters, filter)
+		}
+	}
+
+	if value := strings.TrimSpace(params["sort"]); value != "" {
+		parts := strings.Fields(value)
+		if parts[0] != "created_at" || len(parts) > 2 {
+			return nil, fmt.Errorf("verificators can only be sorted by created_at")
+		}
+		order := "asc"
+		if len(parts) == 2 {
+			order = strings.ToLower(parts[1])
+		}
+		if order != "asc" && order != "desc" {
+			return nil, fmt.Errorf("sort order must be asc or desc")
+		}
+		result.Sorts = []pagination.Sort{{Field: "v.created_at", Order: order}}
+	}
+
+	return result, nil
+}
+
+// parseVerificatorListFilters turns the status, business_trip_status, verified_from and verified_to
+// query parameters into filters on the verificator list query, removing them from params.
+// Statuses accept a comma-separated list; verification dates are YYYY-MM-DD or RFC3339 and verified_to
+// includes the whole day when given as a date.
+func parseVerificatorListFilters(params map[string]string) ([]pagination.Filter, error) {
+	var filters []pagination.Filter
+
+	statusColumns := []struct {
+		param  string
+		column string
+	}{
+		{param: "status", column: "v.status"},
+		{param: "business_trip_status", column: "bt.status"},
+	}
+	for _, status := range statusColumns {
+		value, ok := params[status.param]
+		if !ok {
+			continue
+		}
+		delete(params, status.param)
+
+		if filter, ok := statusFilter(status.column, value); ok {
+			filters = append(filters, filter)
+		}
+	}
+
+	verifiedFrom, err := parseVerifiedAtParam(params, "verified_from", false)
+	if err != nil {
+		return nil, err
+	}
+	verifiedTo, err := parseVerifiedAtParam(params, "verified_to", true)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case verifiedFrom != nil && verifiedTo != nil:
+		if verifiedTo.Before(*verifiedFrom) {
+			return nil, fmt.Errorf("verified_to must not be before verified_from")
+		}
+		filters = append(filters, pagination.Filter{Field: "v.verified_at", Operator: "between", Value: []interface{}{*verifiedFrom, *verifiedTo}})
+	case verifiedFrom != nil:
+		filters = append(filters, pagination.Filter{Field: "v.verified_at", Operator: "gte", Value: *verifiedFrom})
+	case verifiedTo != nil:
+		filters = append(filters, pagination.Filter{Field: "v.verified_at", Operator: "lte", Value: *verifiedTo})
+	}
+
+	return filters, nil
+}
+
+// statusFilter matches column against one or more comma-separated statuses
+func statusFilter(column, value string) (pagination.Filter, bool) {
+	var statuses []interface{}
+	for _, status := range strings.Split(value, ",") {
+		if status = strings.TrimSpace(status); status != "" {
+			statuses = append(statuses, status)
+		}
+	}
+
+	switch len(statuses) {
+	case 0:
+		return pagination.Filter{}, false
+	case 1:
+		return pagination.Filter{Field: column, Operator: "eq", Value: statuses[0]}, true
+	default:
+		return pagination.Filter{Field: column, Operator: "in", Value: statuses}, true
+	}
+}
+
+// parseVerifiedAtParam parses and removes a verification date parameter. With endOfDay a plain date
+// is moved to its last microsecond so the whole day is included.
+func parseVerifiedAtParam(params map[string]string, name string, endOfDay bool) (*time.Time, error) {
+	value, ok := params[name]
+	if !ok {
+		return nil, nil
+	}
+	delete(params, name)
+
+	if value == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a date in YYYY-MM-DD or RFC3339 format", name)
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1).Add(-time.Microsecond)
+	}
+	return &t, nil
+}
+
+// BulkUpdateVerificators approves or rejects several verificators at once
+// @Summary Bulk Update Business Trip Verificators
+// @Description Sets the same verification status and notes on multiple verificators in a single transaction (admin only)
+// @Tags business-trips
+// @Accept json
+// @Produce json
+// @Param request body business_trip.BulkUpdateVerificatorsRequest true "Bulk Update Request"
+// @Success 200 {object} StandardResponse{data=business_trip.BulkUpdateVerificatorsResponse}
+// @Failure 400 {object} StandardResponse
+// @Failure 401 {object} StandardResponse
+// @Failure 403 {object} StandardResponse
+// @Failure 500 {object} StandardResponse
+// @Router /api/v1/business-trips/verificators/bulk [post]
+func (h *BusinessTripVerificationHandler) BulkUpdateVerificators(c *fiber.Ctx) error {
+	user, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"error":   "Authentication required",
+			"details": err.Error(),
+		})
+	}
+	if !user.HasRole(entity.RoleAdmin) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"success": false,
+			"error":   "Only administrators can bulk update verificators",
+		})
+	}
+
+	var req business_trip.BulkUpdateVerificatorsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "Validation failed",
+			"details": err.Error(),
+		})
+	}
+
+	req.ChangedBy = user.ID
+
+	response, err := h.bulkUpdateUseCase.Execute(c.UserContext(), req)
+	if err != nil {
+		if errors.Is(err, entity.ErrVerificatorNotOnTrip) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"success": false,
+				"error":   err.Error(),
+			})
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"error":   "Failed to update verificators",
+			"details": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    response,
+	})
+}
+
+// GetVerificatorHistory returns the status changes of a verificator
+// @Summary Get Verificator Status History
+// @Description Lists every status change of a verificator, oldest first, with who made it and when
+// @Tags business-trips
+// @Produce json
+// @Param verificatorId path string true "Verificator ID"
+// @Success 200 {object} StandardResponse{data=[]business_trip.VerificatorStatusHistoryResponse}
+// @Failure 400 {object} StandardResponse
+// @Failure 404 {object} StandardResponse
+// @Failure 500 {object} StandardResponse
+// @Router /api/v1/business-trips/verificators/{verificatorId}/history [get]
+func (h *BusinessTripVerificationHandler) GetVerificatorHistory(c *fiber.Ctx) error {
+	verificatorID := c.Params("verificatorId")
+	if verificatorID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"error":   "Verificator ID is required",
+		})
+	}
+
+	history, err := h.historyUseCase.Execute(c.UserContext(), verificatorID)
+	if err != nil {
+		if errors.Is(err, entity.ErrVerificatorNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"success": false,
+				"error":   "Verificator not found",
+			})
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"error":   "Failed to retrieve verificator history",
+			"details": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    history,
+	})
+}
+
+// SendVerificatorReminders manually triggers the pending verificator reminder job
+// @Summary Send Verificator Reminders
+// @Description Emails every verificator with business trips pending verification longer than the configured threshold. Verificators already reminded in the last 24 hours are skipped. Admin only.
+// @Tags business-trips
+// @Produce json
+// @Success 200 {object} StandardResponse{data=service.VerificatorReminderResult}
+// @Failure 401 {object} StandardResponse
+// @Failure 403 {object} StandardResponse
+// @Failure 500 {object} StandardResponse
+// @Router /api/v1/business-trips/verificators/reminders [post]
+func (h *BusinessTripVerificationHandler) SendVerificatorReminders(c *fiber.Ctx) error {
+	user, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"error":   "Authentication required",
+			"details": err.Error(),
+		})
+	}
+	if !user.HasRole(entity.RoleAdmin) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"success": false,
+			"error":   "Only administrators can send verificator reminders",
+		})
+	}
+
+	result, err := h.reminderService.SendPendingVerificatorReminders(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"error":   "Failed to send verificator reminders",
+			"details": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
 }
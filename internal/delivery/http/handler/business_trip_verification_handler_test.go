@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"context"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/internal/usecase/business_trip"
+	"sandbox/pkg/pagination"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// stubVerificatorQueryRepository records the query verificators were listed with
+type stubVerificatorQueryRepository struct {
+	repository.BusinessTripRepository
+	params *pagination.QueryParams
+}
+
+func (r *stubVerificatorQueryRepository) ListVerificators(_ context.Context, params *pagination.QueryParams) ([]*entity.VerificatorWithBusinessTrip, int64, error) {
+	r.params = params
+	return nil, 0, nil
+}
+
+func TestListMyVerificationsFiltersByAuthenticatedUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus interface{}
+	}{
+		{"pending by default", "", "pending"},
+		{"explicit status", "?status=approved,rejected", []interface{}{"approved", "rejected"}},
+		{"another user is ignored", "?user_id=user-2", "pending"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubVerificatorQueryRepository{}
+			h := NewBusinessTripVerificationHandler(nil, business_trip.NewListVerificatorsUseCase(repo), nil, nil, nil, nil, pagination.Limits{})
+			app := fiber.New()
+			app.Get("/me/verifications", func(c *fiber.Ctx) error {
+				c.Locals("authenticatedUser", &entity.AuthenticatedUser{ID: "user-1"})
+				return c.Next()
+			}, h.ListMyVerifications)
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/me/verifications"+tt.query, nil))
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if resp.StatusCode != fiber.StatusOK {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusOK)
+			}
+
+			filters := make(map[string]interface{})
+			for _, filter := range repo.params.Filters {
+				filters[filter.Field] = filter.Value
+			}
+			if len(filters) != 2 || filters["v.user_id"] != "user-1" {
+				t.Errorf("filters = %+v, want only the status and the authenticated user", repo.params.Filters)
+			}
+			if got, want := filters["v.status"], tt.wantStatus; !reflect.DeepEqual(got, want) {
+				t.Errorf("status filter = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// stubFilteringVerificatorRepository applies the listed filters to a query builder like the postgres repository
+type stubFilteringVerificatorRepository struct {
+	repository.BusinessTripRepository
+}
+
+func (r *stubFilteringVerificatorRepository) ListVerificators(_ context.Context, params *pagination.QueryParams) ([]*entity.VerificatorWithBusinessTrip, int64, error) {
+	qb := pagination.NewQueryBuilder("SELECT * FROM business_trip_verificators v")
+	for _, filter := range params.Filters {
+		if err := qb.AddFilter(filter); err != nil {
+			return nil, 0, err
+		}
+	}
+	return nil, 0, nil
+}
+
+func TestListMyVerificationsRejectsInvalidFilter(t *testing.T) {
+	h := NewBusinessTripVerificationHandler(nil, business_trip.NewListVerificatorsUseCase(&stubFilteringVerificatorRepository{}), nil, nil, nil, nil, pagination.Limits{})
+	app := fiber.New()
+	app.Get("/me/verifications", func(c *fiber.Ctx) error {
+		c.Locals("authenticatedUser", &entity.AuthenticatedUser{ID: "user-1"})
+		return c.Next()
+	}, h.ListMyVerifications)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/me/verifications?created_at=between%202024-01-01", nil))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("status = %d, want %d", resp.StatusCode, fiber.StatusBadRequest)
+	}
+}
+
+func TestListMyVerificationsRequiresAuthentication(t *testing.T) {
+	h := NewBusinessTripVerificationHandler(nil, nil, nil, nil, nil, nil, pagination.Limits{})
+	app := fiber.New()
+	app.Get("/me/verifications", h.ListMyVerifications)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/me/verifications", nil))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", resp.StatusCode, fiber.StatusUnauthorized)
+	}
+}
+
+func TestBulkUpdateVerificatorsRequiresAdmin(t *testing.T) {
+	tests := []struct {
+		name       string
+		user       *entity.AuthenticatedUser
+		wantStatus int
+	}{
+		{"anonymous", nil, fiber.StatusUnauthorized},
+		{"not an admin", &entity.AuthenticatedUser{ID: "user-1"}, fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewBusinessTripVerificationHandler(nil, nil, nil, nil, nil, nil, pagination.Limits{})
+			app := fiber.New()
+			app.Post("/verificators/bulk", func(c *fiber.Ctx) error {
+				if tt.user != nil {
+					c.Locals("authenticatedUser", tt.user)
+				}
+				return c.Next()
+			}, h.BulkUpdateVerificators)
+
+			body := strings.NewReader(`{"verificator_ids":["verificator-1"],"status":"approved"}`)
+			req := httptest.NewRequest(fiber.MethodPost, "/verificators/bulk", body)
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestSendVerificatorRemindersRequiresAdmin(t *testing.T) {
+	tests := []struct {
+		name       string
+		user       *entity.AuthenticatedUser
+		wantStatus int
+	}{
+		{"anonymous", nil, fiber.StatusUnauthorized},
+		{"not an admin", &entity.AuthenticatedUser{ID: "user-1"}, fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewBusinessTripVerificationHandler(nil, nil, nil, nil, nil, nil, pagination.Limits{})
+			app := fiber.New()
+			app.Post("/verificators/reminders", func(c *fiber.Ctx) error {
+				if tt.user != nil {
+					c.Locals("authenticatedUser", tt.user)
+				}
+				return c.Next()
+			}, h.SendVerificatorReminders)
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/verificators/reminders", nil))
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -1,14 +1,20 @@
 package desk
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"log"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 
+	"sandbox/internal/delivery/http/middleware"
+	"sandbox/internal/domain/entity"
 	"sandbox/internal/domain/service"
 	"sandbox/internal/usecase/work_paper"
+	"sandbox/pkg/pagination"
 )
 
 // WorkPaperHandler handles HTTP requests for work paper
@@ -21,6 +27,14 @@ type WorkPaperHandler struct {
 	updateWorkPaperNoteCase *work_paper.UpdateWorkPaperNoteUseCase
 	manageSignersUseCase    *work_paper.ManageSignersUseCase
 	generateDocxUseCase     *work_paper.GenerateWorkPaperDocxUseCase
+	downloadDocsUseCase     *work_paper.DownloadWorkPaperDocumentsUseCase
+	checkHistoryUseCase     *work_paper.GetNoteCheckHistoryUseCase
+	bulkValidateUseCase     *work_paper.BulkValidateWorkPaperNotesUseCase
+	pruneNotesUseCase       *work_paper.PruneInactiveWorkPaperNotesUseCase
+	attentionUseCase        *work_paper.GetNotesNeedingAttentionUseCase
+	llmUsageUseCase         *work_paper.GetLLMTokenUsageUseCase
+	listNotesUseCase        *work_paper.ListWorkPaperNotesUseCase
+	queryParser             *pagination.QueryParser
 	validator               *validator.Validate
 }
 
@@ -34,6 +48,14 @@ func NewWorkPaperHandler(
 	updateWorkPaperNoteCase *work_paper.UpdateWorkPaperNoteUseCase,
 	manageSignersUseCase *work_paper.ManageSignersUseCase,
 	generateDocxUseCase *work_paper.GenerateWorkPaperDocxUseCase,
+	downloadDocsUseCase *work_paper.DownloadWorkPaperDocumentsUseCase,
+	checkHistoryUseCase *work_paper.GetNoteCheckHistoryUseCase,
+	bulkValidateUseCase *work_paper.BulkValidateWorkPaperNotesUseCase,
+	pruneNotesUseCase *work_paper.PruneInactiveWorkPaperNotesUseCase,
+	attentionUseCase *work_paper.GetNotesNeedingAttentionUseCase,
+	llmUsageUseCase *work_paper.GetLLMTokenUsageUseCase,
+	listNotesUseCase *work_paper.ListWorkPaperNotesUseCase,
+	pageLimits pagination.Limits,
 ) *WorkPaperHandler {
 	return &WorkPaperHandler{
 		createUseCase:           createUseCase,
@@ -44,6 +66,14 @@ func NewWorkPaperHandler(
 		updateWorkPaperNoteCase: updateWorkPaperNoteCase,
 		manageSignersUseCase:    manageSignersUseCase,
 		generateDocxUseCase:     generateDocxUseCase,
+		downloadDocsUseCase:     downloadDocsUseCase,
+		checkHistoryUseCase:     checkHistoryUseCase,
+		bulkValidateUseCase:     bulkValidateUseCase,
+		pruneNotesUseCase:       pruneNotesUseCase,
+		attentionUseCase:        attentionUseCase,
+		llmUsageUseCase:         llmUsageUseCase,
+		listNotesUseCase:        listNotesUseCase,
+		queryParser:             &pagination.QueryParser{Limits: pageLimits},
 		validator:               validator.New(),
 	}
 }
@@ -94,7 +124,7 @@ func (h *WorkPaperHandler) CreateWorkPaper(c *fiber.Ctx) error {
 
 // ListWorkPapers lists work papers with pagination and filtering
 // @Summary List Work Papers
-// @Description Lists work papers with optional filters for organization, year, semester, and status
+// @Description Lists work papers with optional filters for organization, year, semester, status, and completion of the notes' Drive links
 // @Tags desk
 // @Accept json
 // @Produce json
@@ -102,8 +132,9 @@ func (h *WorkPaperHandler) CreateWorkPaper(c *fiber.Ctx) error {
 // @Param year query int false "Year filter"
 // @Param semester query int false "Semester filter (1 or 2)"
 // @Param status query string false "Status filter"
+// @Param completion query string false "Completion filter (complete or incomplete)"
 // @Param page query int false "Page number" default(1)
-// @Param page_size query int false "Page size" default(10)
+// @Param page_size query int false "Page size (default: 10, max: 100 unless configured otherwise)"
 // @Success 200 {object} StandardResponse{data=work_paper.ListResponse}
 // @Failure 400 {object} StandardResponse
 // @Failure 500 {object} StandardResponse
@@ -112,7 +143,7 @@ func (h *WorkPaperHandler) ListWorkPapers(c *fiber.Ctx) error {
 	// Parse query parameters
 	req := work_paper.ListRequest{
 		Page:     c.QueryInt("page", 1),
-		PageSize: c.QueryInt("page_size", 10),
+		PageSize: c.QueryInt("page_size", 0),
 	}
 
 	// Set optional filters
@@ -128,6 +159,9 @@ func (h *WorkPaperHandler) ListWorkPapers(c *fiber.Ctx) error {
 	if status := c.Query("status"); status != "" {
 		req.Status = status
 	}
+	if completion := c.Query("completion"); completion != "" {
+		req.Completion = completion
+	}
 
 	// Validate request
 	if err := h.validator.Struct(&req); err != nil {
@@ -168,6 +202,8 @@ func (h *WorkPaperHandler) ListWorkPapers(c *fiber.Ctx) error {
 // @Success 200 {object} StandardResponse{data=work_paper.UpdateStatusResponse}
 // @Failure 400 {object} StandardResponse
 // @Failure 404 {object} StandardResponse
+// @Failure 409 {object} StandardResponse
+// @Failure 422 {object} StandardResponse
 // @Failure 500 {object} StandardResponse
 // @Router /api/v1/desk/work-papers/{id}/status [put]
 func (h *WorkPaperHandler) UpdateWorkPaperStatus(c *fiber.Ctx) error {
@@ -203,6 +239,35 @@ func (h *WorkPaperHandler) UpdateWorkPaperStatus(c *fiber.Ctx) error {
 	ctx := context.Background()
 	response, err := h.updateStatusUseCase.Execute(ctx, req)
 	if err != nil {
+		var incompleteErr *service.SignaturesIncompleteError
+		if errors.As(err, &incompleteErr) {
+			outstanding := make([]fiber.Map, 0, len(incompleteErr.Outstanding))
+			for _, signature := range incompleteErr.Outstanding {
+				outstanding = append(outstanding, fiber.Map{
+					"signature_id": signature.ID.String(),
+					"user_id":      signature.UserID,
+					"user_name":    signature.UserName,
+					"status":       signature.Status,
+				})
+			}
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error":               "Work paper cannot be completed until all signers have signed",
+				"details":             err.Error(),
+				"outstanding_signers": outstanding,
+			})
+		}
+		if errors.Is(err, entity.ErrInsufficientSigners) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error":   "Work paper does not have enough signers",
+				"details": err.Error(),
+			})
+		}
+		if errors.Is(err, entity.ErrVersionConflict) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   "Work paper has been modified, reload it and try again",
+				"details": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   "Failed to update work paper status",
 			"details": err.Error(),
@@ -222,6 +287,8 @@ func (h *WorkPaperHandler) UpdateWorkPaperStatus(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param id path string true "Work Paper ID"
+// @Param only_invalid query bool false "Only return notes marked invalid"
+// @Param unchecked query bool false "Only return notes not yet checked"
 // @Success 200 {object} StandardResponse{data=work_paper.GetWorkPaperDetailsResponse}
 // @Failure 404 {object} StandardResponse
 // @Failure 500 {object} StandardResponse
@@ -235,9 +302,15 @@ func (h *WorkPaperHandler) GetWorkPaperByID(c *fiber.Ctx) error {
 		})
 	}
 
+	req := work_paper.GetWorkPaperDetailsRequest{
+		ID:          id,
+		OnlyInvalid: c.QueryBool("only_invalid", false),
+		Unchecked:   c.QueryBool("unchecked", false),
+	}
+
 	// Execute use case to get complete work paper details
 	ctx := context.Background()
-	response, err := h.getDetailsUseCase.Execute(ctx, id)
+	response, err := h.getDetailsUseCase.Execute(ctx, req)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   "Failed to get work paper details",
@@ -280,13 +353,14 @@ func (h *WorkPaperHandler) GetStatusTransitions(c *fiber.Ctx) error {
 
 // CheckWorkPaperNote checks a work paper note using LLM
 // @Summary Check Work Paper Note
-// @Description Checks a work paper note document using LLM
+// @Description Checks a work paper note document using LLM, optionally with another supported model or temperature
 // @Tags desk
 // @Accept json
 // @Produce json
 // @Param request body work_paper.CheckRequest true "Check Work Paper Note Request"
 // @Success 200 {object} StandardResponse{data=work_paper.CheckResponse}
 // @Failure 400 {object} StandardResponse
+// @Failure 429 {object} StandardResponse
 // @Failure 500 {object} StandardResponse
 // @Router /api/v1/desk/work-paper-notes/check [post]
 func (h *WorkPaperHandler) CheckWorkPaperNote(c *fiber.Ctx) error {
@@ -306,10 +380,21 @@ func (h *WorkPaperHandler) CheckWorkPaperNote(c *fiber.Ctx) error {
 		})
 	}
 
-	// Execute use case
-	ctx := context.Background()
-	response, err := h.checkDocumentUseCase.Execute(ctx, req)
+	// Execute use case with the request context so external calls carry its correlation ID
+	response, err := h.checkDocumentUseCase.Execute(c.UserContext(), req)
 	if err != nil {
+		if errors.Is(err, entity.ErrUnsupportedLLMModel) || errors.Is(err, entity.ErrInvalidLLMTemperature) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid LLM options",
+				"details": err.Error(),
+			})
+		}
+		if errors.Is(err, entity.ErrTokenBudgetExceeded) {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error":   "Monthly LLM token budget exceeded",
+				"details": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   "Failed to check work paper note",
 			"details": err.Error(),
@@ -333,6 +418,7 @@ func (h *WorkPaperHandler) CheckWorkPaperNote(c *fiber.Ctx) error {
 // @Success 200 {object} StandardResponse{data=work_paper.UpdateWorkPaperNoteResponse}
 // @Failure 400 {object} StandardResponse
 // @Failure 404 {object} StandardResponse
+// @Failure 409 {object} StandardResponse
 // @Failure 500 {object} StandardResponse
 // @Router /api/v1/desk/work-paper-notes/{id} [put]
 func (h *WorkPaperHandler) UpdateWorkPaperNote(c *fiber.Ctx) error {
@@ -368,6 +454,12 @@ func (h *WorkPaperHandler) UpdateWorkPaperNote(c *fiber.Ctx) error {
 	ctx := context.Background()
 	response, err := h.updateWorkPaperNoteCase.Execute(ctx, req)
 	if err != nil {
+		if errors.Is(err, entity.ErrVersionConflict) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   "Work paper note has been modified, reload it and try again",
+				"details": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   "Failed to update work paper note",
 			"details": err.Error(),
@@ -380,6 +472,269 @@ func (h *WorkPaperHandler) UpdateWorkPaperNote(c *fiber.Ctx) error {
 	})
 }
 
+// BulkValidateWorkPaperNotes updates the validation of many notes of a work paper in one transaction
+// @Summary Bulk Validate Work Paper Notes
+// @Description Sets is_valid and notes on every listed note of the work paper; either all notes are updated or none are
+// @Tags desk
+// @Accept json
+// @Produce json
+// @Param id path string true "Work Paper ID"
+// @Param request body []work_paper.NoteValidationItem true "Note validations"
+// @Success 200 {object} StandardResponse{data=work_paper.BulkValidateWorkPaperNotesResponse}
+// @Failure 400 {object} StandardResponse
+// @Failure 404 {object} StandardResponse
+// @Failure 409 {object} StandardResponse
+// @Failure 422 {object} StandardResponse
+// @Failure 500 {object} StandardResponse
+// @Router /api/v1/desk/work-papers/{id}/notes/validate-bulk [put]
+func (h *WorkPaperHandler) BulkValidateWorkPaperNotes(c *fiber.Ctx) error {
+	req := work_paper.BulkValidateWorkPaperNotesRequest{WorkPaperID: c.Params("id")}
+	if err := c.BodyParser(&req.Items); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Validation failed",
+			"details": err.Error(),
+		})
+	}
+
+	ctx := context.Background()
+	response, err := h.bulkValidateUseCase.Execute(ctx, req)
+	if err != nil {
+		var notInPaperErr *work_paper.NotesNotInWorkPaperError
+		if errors.As(err, &notInPaperErr) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error":    "Some notes do not belong to this work paper",
+				"details":  err.Error(),
+				"note_ids": notInPaperErr.NoteIDs,
+			})
+		}
+		if errors.Is(err, entity.ErrWorkPaperNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error":   "Work paper not found",
+				"details": err.Error(),
+			})
+		}
+		if errors.Is(err, entity.ErrVersionConflict) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   "Work paper note has been modified, reload it and try again",
+				"details": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to update work paper notes",
+			"details": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    response,
+	})
+}
+
+// PruneInactiveWorkPaperNotes removes the notes whose master item is no longer active
+// @Summary Prune Inactive Work Paper Notes
+// @Description Deletes the notes of a draft work paper whose master item was deactivated or deleted after the paper was created
+// @Tags desk
+// @Accept json
+// @Produce json
+// @Param id path string true "Work Paper ID"
+// @Success 200 {object} StandardResponse{data=work_paper.PruneInactiveWorkPaperNotesResponse}
+// @Failure 400 {object} StandardResponse
+// @Failure 404 {object} StandardResponse
+// @Failure 409 {object} StandardResponse
+// @Failure 500 {object} StandardResponse
+// @Router /api/v1/desk/work-papers/{id}/notes/prune-inactive [post]
+func (h *WorkPaperHandler) PruneInactiveWorkPaperNotes(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Work Paper ID is required",
+		})
+	}
+
+	response, err := h.pruneNotesUseCase.Execute(c.UserContext(), id)
+	if err != nil {
+		if errors.Is(err, entity.ErrWorkPaperNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error":   "Work paper not found",
+				"details": err.Error(),
+			})
+		}
+		if errors.Is(err, entity.ErrWorkPaperNotDraft) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   "Only notes of a draft work paper can be pruned",
+				"details": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to prune work paper notes",
+			"details": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    response,
+	})
+}
+
+// ListWorkPaperNotes lists work paper notes across work papers
+// @Summary List Work Paper Notes
+// @Description Lists work paper notes of every work paper with pagination, filtering and sorting
+// @Tags desk
+// @Accept json
+// @Produce json
+// @Param work_paper_id query string false "Filter by work paper ID, e.g. \"eq <id>\""
+// @Param is_valid query string false "Filter by validity, e.g. \"eq false\""
+// @Param master_item_id query string false "Filter by master item ID, e.g. \"eq <id>\""
+// @Param sort query string false "Sort on created_at, e.g. \"created_at asc\"; newest first by default"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Limit per page" default(10)
+// @Success 200 {object} pagination.PagedResponse
+// @Failure 400 {object} StandardResponse
+// @Failure 500 {object} StandardResponse
+// @Router /api/v1/desk/work-paper-notes [get]
+func (h *WorkPaperHandler) ListWorkPaperNotes(c *fiber.Ctx) error {
+	queryParams := make(map[string]string)
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		queryParams[string(key)] = string(value)
+	})
+
+	params, err := h.queryParser.Parse(queryParams)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid query parameters: " + err.Error(),
+		})
+	}
+
+	ctx := context.Background()
+	notes, pagedResponse, err := h.listNotesUseCase.Execute(ctx, params)
+	if err != nil {
+		if errors.Is(err, pagination.ErrInvalidFilter) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid query parameters: " + err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success":     true,
+		"data":        notes,
+		"page":        pagedResponse.Page,
+		"limit":       pagedResponse.Limit,
+		"total_items": pagedResponse.TotalItems,
+		"total_pages": pagedResponse.TotalPages,
+	})
+}
+
+// GetWorkPaperNoteCheckHistory lists the LLM check history of a work paper note
+// @Summary Get Work Paper Note Check History
+// @Description Lists every LLM check performed on a work paper note, newest first
+// @Tags desk
+// @Accept json
+// @Produce json
+// @Param id path string true "Work Paper Note ID"
+// @Success 200 {object} StandardResponse{data=[]work_paper.NoteCheckHistoryResponse}
+// @Failure 400 {object} StandardResponse
+// @Failure 404 {object} StandardResponse
+// @Failure 500 {object} StandardResponse
+// @Router /api/v1/desk/work-paper-notes/{id}/check-history [get]
+func (h *WorkPaperHandler) GetWorkPaperNoteCheckHistory(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Work Paper Note ID is required",
+		})
+	}
+
+	ctx := context.Background()
+	response, err := h.checkHistoryUseCase.Execute(ctx, id)
+	if err != nil {
+		if errors.Is(err, entity.ErrWorkPaperNoteNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error":   "Work paper note not found",
+				"details": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to get work paper note check history",
+			"details": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    response,
+	})
+}
+
+// GetNotesNeedingAttention lists the notes of a work paper that still need work
+// @Summary Get Work Paper Notes Needing Attention
+// @Description Lists the notes of a work paper that have no Google Drive link or were marked invalid, in master item order
+// @Tags desk
+// @Accept json
+// @Produce json
+// @Param id path string true "Work Paper ID"
+// @Success 200 {object} StandardResponse{data=[]work_paper.NoteNeedingAttentionResponse}
+// @Failure 400 {object} StandardResponse
+// @Failure 500 {object} StandardResponse
+// @Router /api/v1/desk/work-papers/{id}/notes/attention [get]
+func (h *WorkPaperHandler) GetNotesNeedingAttention(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Work Paper ID is required",
+		})
+	}
+
+	ctx := context.Background()
+	response, err := h.attentionUseCase.Execute(ctx, id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to get work paper notes needing attention",
+			"details": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    response,
+	})
+}
+
+// GetLLMTokenUsage returns the LLM tokens spent this month against the monthly budget
+// @Summary Get LLM Token Usage
+// @Description Returns the tokens document checks spent in the current month (UTC) and the monthly budget
+// @Tags desk
+// @Produce json
+// @Success 200 {object} StandardResponse{data=service.TokenBudgetUsage}
+// @Failure 500 {object} StandardResponse
+// @Router /api/v1/desk/llm-usage [get]
+func (h *WorkPaperHandler) GetLLMTokenUsage(c *fiber.Ctx) error {
+	response, err := h.llmUsageUseCase.Execute(c.UserContext())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to get LLM token usage",
+			"details": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    response,
+	})
+}
+
 // Backward compatibility methods (deprecated)
 
 // CreatePaperWork creates a new paper work (deprecated)
@@ -433,9 +788,8 @@ func (h *WorkPaperHandler) CheckDocument(c *fiber.Ctx) error {
 		})
 	}
 
-	// Execute use case
-	ctx := context.Background()
-	response, err := h.checkDocumentUseCase.Execute(ctx, newReq)
+	// Execute use case with the request context so external calls carry its correlation ID
+	response, err := h.checkDocumentUseCase.Execute(c.UserContext(), newReq)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   "Failed to check document",
@@ -460,6 +814,8 @@ func (h *WorkPaperHandler) CheckDocument(c *fiber.Ctx) error {
 // @Success 200 {object} StandardResponse{data=service.ManageSignersResponse}
 // @Failure 400 {object} StandardResponse
 // @Failure 404 {object} StandardResponse
+// @Failure 409 {object} StandardResponse
+// @Failure 422 {object} StandardResponse
 // @Failure 500 {object} StandardResponse
 // @Router /api/v1/desk/work-papers/{id}/signers [put]
 func (h *WorkPaperHandler) ManageSigners(c *fiber.Ctx) error {
@@ -491,10 +847,24 @@ func (h *WorkPaperHandler) ManageSigners(c *fiber.Ctx) error {
 		})
 	}
 
+	setSignerActor(c, &req)
+
 	// Execute use case
 	ctx := context.Background()
 	response, err := h.manageSignersUseCase.Execute(ctx, req)
 	if err != nil {
+		if errors.Is(err, entity.ErrInsufficientSigners) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error":   "Work paper must keep the minimum number of signers",
+				"details": err.Error(),
+			})
+		}
+		if errors.Is(err, entity.ErrSignedSignatureRemoval) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   "Signed signatures cannot be removed; set force to keep them and apply the rest",
+				"details": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   "Failed to manage signers",
 			"details": err.Error(),
@@ -550,6 +920,8 @@ func (h *WorkPaperHandler) AssignSignersBulk(c *fiber.Ctx) error {
 		})
 	}
 
+	setSignerActor(c, &req)
+
 	// Execute use case
 	ctx := context.Background()
 	response, err := h.manageSignersUseCase.Execute(ctx, req)
@@ -566,12 +938,22 @@ func (h *WorkPaperHandler) AssignSignersBulk(c *fiber.Ctx) error {
 	})
 }
 
+// setSignerActor records the authenticated caller on the request for the signature audit log
+func setSignerActor(c *fiber.Ctx, req *service.ManageSignersRequest) {
+	user, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return
+	}
+	req.ActorID = user.ID
+	req.ActorName = user.GetFullName()
+}
+
 // Backward compatibility factory function (deprecated)
 func NewPaperWorkHandler(
 	createUseCase *work_paper.CreateWorkPaperUseCase,
 	checkDocumentUseCase *work_paper.CheckWorkPaperNoteUseCase,
 ) *WorkPaperHandler {
-	return NewWorkPaperHandler(createUseCase, checkDocumentUseCase, nil, nil, nil, nil, nil, nil)
+	return NewWorkPaperHandler(createUseCase, checkDocumentUseCase, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, pagination.Limits{})
 }
 
 // GenerateDocx generates a DOCX document for the work paper
@@ -605,3 +987,55 @@ func (h *WorkPaperHandler) GenerateDocx(c *fiber.Ctx) error {
 	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=work_paper_%s.docx", id))
 	return c.Send(data)
 }
+
+// DownloadDocuments streams a zip of every Google Drive file linked from the work paper's notes
+// @Summary Download Work Paper Documents
+// @Description Zips the Google Drive files of every note into a folder per master item number. Files that could not be read are listed in errors.txt inside the archive.
+// @Tags desk
+// @Produce application/zip
+// @Param id path string true "Work Paper ID"
+// @Success 200 {file} []byte
+// @Failure 400 {object} StandardResponse
+// @Failure 404 {object} StandardResponse
+// @Failure 500 {object} StandardResponse
+// @Router /api/v1/desk/work-papers/{id}/documents.zip [get]
+func (h *WorkPaperHandler) DownloadDocuments(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Work Paper ID is required",
+		})
+	}
+
+	fileName, err := h.downloadDocsUseCase.Prepare(c.UserContext(), id)
+	if err != nil {
+		if errors.Is(err, entity.ErrWorkPaperNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error":   "Work paper not found",
+				"details": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to download work paper documents",
+			"details": err.Error(),
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, fileName))
+
+	// The status and headers are sent before the first file is downloaded, so errors past this point
+	// can only be logged; the client sees a truncated archive. The stream is written after the handler
+	// returns, so only the values of the request context are kept.
+	ctx := context.WithoutCancel(c.UserContext())
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := h.downloadDocsUseCase.Execute(ctx, id, w); err != nil {
+			log.Printf("Error writing documents of work paper %s: %v", id, err)
+		}
+		if err := w.Flush(); err != nil {
+			log.Printf("Error flushing documents of work paper %s: %v", id, err)
+		}
+	})
+
+	return nil
+}
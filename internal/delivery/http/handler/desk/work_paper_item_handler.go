@@ -2,22 +2,27 @@ package desk
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 
+	"sandbox/internal/domain/entity"
 	"sandbox/internal/usecase/work_paper_item"
 	"sandbox/pkg/pagination"
 )
 
 // WorkPaperItemHandler handles HTTP requests for work paper items
 type WorkPaperItemHandler struct {
-	createUseCase *work_paper_item.CreateWorkPaperItemUseCase
-	getUseCase    *work_paper_item.GetWorkPaperItemUseCase
-	updateUseCase *work_paper_item.UpdateWorkPaperItemUseCase
-	deleteUseCase *work_paper_item.DeleteWorkPaperItemUseCase
-	listUseCase   *work_paper_item.ListWorkPaperItemsUseCase
-	validator     *validator.Validate
+	createUseCase  *work_paper_item.CreateWorkPaperItemUseCase
+	getUseCase     *work_paper_item.GetWorkPaperItemUseCase
+	updateUseCase  *work_paper_item.UpdateWorkPaperItemUseCase
+	deleteUseCase  *work_paper_item.DeleteWorkPaperItemUseCase
+	listUseCase    *work_paper_item.ListWorkPaperItemsUseCase
+	reorderUseCase *work_paper_item.ReorderWorkPaperItemsUseCase
+	queryParser    *pagination.QueryParser
+	validator      *validator.Validate
 }
 
 // NewWorkPaperItemHandler creates a new handler instance
@@ -27,14 +32,18 @@ func NewWorkPaperItemHandler(
 	updateUseCase *work_paper_item.UpdateWorkPaperItemUseCase,
 	deleteUseCase *work_paper_item.DeleteWorkPaperItemUseCase,
 	listUseCase *work_paper_item.ListWorkPaperItemsUseCase,
+	reorderUseCase *work_paper_item.ReorderWorkPaperItemsUseCase,
+	pageLimits pagination.Limits,
 ) *WorkPaperItemHandler {
 	return &WorkPaperItemHandler{
-		createUseCase: createUseCase,
-		getUseCase:    getUseCase,
-		updateUseCase: updateUseCase,
-		deleteUseCase: deleteUseCase,
-		listUseCase:   listUseCase,
-		validator:     validator.New(),
+		createUseCase:  createUseCase,
+		getUseCase:     getUseCase,
+		updateUseCase:  updateUseCase,
+		deleteUseCase:  deleteUseCase,
+		listUseCase:    listUseCase,
+		reorderUseCase: reorderUseCase,
+		queryParser:    &pagination.QueryParser{Limits: pageLimits},
+		validator:      validator.New(),
 	}
 }
 
@@ -137,7 +146,7 @@ func (h *WorkPaperItemHandler) GetWorkPaperItem(c *fiber.Ctx) error {
 // @Tags desk
 // @Accept json
 // @Produce json
-// @Param search query string false "Search term"
+// @Param search query string false "Search term matched against statement, number and explanation"
 // @Param type query string false "Filter by type (A, B, C)"
 // @Param is_active query bool false "Filter by active status"
 // @Param page query int false "Page number" default(1)
@@ -152,17 +161,30 @@ func (h *WorkPaperItemHandler) ListWorkPaperItems(c *fiber.Ctx) error {
 		queryParams[string(key)] = string(value)
 	})
 
-	queryParser := &pagination.QueryParser{}
-	params, err := queryParser.Parse(queryParams)
+	// search is a free-text term rather than an "<operator> <value>" filter
+	search := strings.TrimSpace(queryParams["search"])
+	delete(queryParams, "search")
+
+	params, err := h.queryParser.Parse(queryParams)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "Invalid query parameters: " + err.Error(),
 		})
 	}
 
+	// Search matches the statement, number or explanation
+	if search != "" {
+		params.OrGroups = append(params.OrGroups, pagination.SearchGroup(search, "statement", "number", "explanation"))
+	}
+
 	ctx := context.Background()
 	workPaperItems, pagedResponse, err := h.listUseCase.Execute(ctx, params)
 	if err != nil {
+		if errors.Is(err, pagination.ErrInvalidFilter) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid query parameters: " + err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -284,6 +306,61 @@ func (h *WorkPaperItemHandler) DeleteWorkPaperItem(c *fiber.Ctx) error {
 	})
 }
 
+// ReorderWorkPaperItems moves many work paper items at once
+// @Summary Reorder Work Paper Items
+// @Description Updates the sort order, and optionally the parent and level, of many work paper items in one transaction
+// @Tags desk
+// @Accept json
+// @Produce json
+// @Param request body work_paper_item.ReorderRequest true "Reorder Work Paper Items Request"
+// @Success 200 {object} StandardResponse{data=[]work_paper_item.ItemResponse}
+// @Failure 400 {object} StandardResponse
+// @Failure 404 {object} StandardResponse
+// @Failure 500 {object} StandardResponse
+// @Router /api/v1/desk/work-paper-items/reorder [put]
+func (h *WorkPaperItemHandler) ReorderWorkPaperItems(c *fiber.Ctx) error {
+	var req work_paper_item.ReorderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+	}
+
+	// Validate request
+	if err := h.validator.Struct(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Validation failed",
+			"details": err.Error(),
+		})
+	}
+
+	response, err := h.reorderUseCase.Execute(c.UserContext(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrWorkPaperItemNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error":   "Work paper item not found",
+				"details": err.Error(),
+			})
+		case errors.Is(err, entity.ErrWorkPaperItemHierarchyCycle), errors.Is(err, entity.ErrDuplicateWorkPaperItemPosition):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid work paper item order",
+				"details": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to reorder work paper items",
+			"details": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    response,
+	})
+}
+
 // Backward compatibility methods (deprecated)
 
 // CreateMasterLakipItem creates a new master LAKIP item (deprecated)
@@ -327,6 +404,8 @@ func NewMasterLakipItemHandler(
 	updateUseCase *work_paper_item.UpdateWorkPaperItemUseCase,
 	deleteUseCase *work_paper_item.DeleteWorkPaperItemUseCase,
 	listUseCase *work_paper_item.ListWorkPaperItemsUseCase,
+	reorderUseCase *work_paper_item.ReorderWorkPaperItemsUseCase,
+	pageLimits pagination.Limits,
 ) *WorkPaperItemHandler {
-	return NewWorkPaperItemHandler(createUseCase, getUseCase, updateUseCase, deleteUseCase, listUseCase)
+	return NewWorkPaperItemHandler(createUseCase, getUseCase, updateUseCase, deleteUseCase, listUseCase, reorderUseCase, pageLimits)
 }
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"sandbox/pkg/featureflag"
+)
+
+// FeatureFlagHandler exposes the effective feature flags
+type FeatureFlagHandler struct {
+	flags *featureflag.Service
+}
+
+// NewFeatureFlagHandler creates a new feature flag handler
+func NewFeatureFlagHandler(flags *featureflag.Service) *FeatureFlagHandler {
+	return &FeatureFlagHandler{
+		flags: flags,
+	}
+}
+
+// GetFlags returns the feature flags in effect for the caller's organization
+// @Summary Get effective feature flags
+// @Description Returns every feature flag with its value after applying the caller's organization overrides
+// @Tags feature-flags
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/flags [get]
+func (h *FeatureFlagHandler) GetFlags(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"data":    h.flags.Effective(c.UserContext()),
+	})
+}
@@ -1,8 +1,13 @@
 package handler
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"strings"
 
+	"sandbox/internal/delivery/http/middleware"
+	"sandbox/internal/domain/entity"
 	vaccineUC "sandbox/internal/usecase/vaccine"
 	"sandbox/pkg/pagination"
 
@@ -13,17 +18,35 @@ type VaccineHandler struct {
 	listMasterVaccinesUseCase    *vaccineUC.ListMasterVaccinesUseCase
 	listCountriesUseCase         *vaccineUC.ListCountriesUseCase
 	getCDCRecommendationsUseCase *vaccineUC.GetCDCRecommendationsUseCase
+	recordRecommendationUseCase  *vaccineUC.RecordCDCRecommendationUseCase
+	listAliasesUseCase           *vaccineUC.ListAliasesUseCase
+	upsertAliasUseCase           *vaccineUC.UpsertAliasUseCase
+	listHistoryUseCase           *vaccineUC.ListRecommendationHistoryUseCase
+	importUseCase                *vaccineUC.ImportMasterVaccinesUseCase
+	queryParser                  *pagination.QueryParser
 }
 
 func NewVaccineHandler(
 	listMasterVaccinesUseCase *vaccineUC.ListMasterVaccinesUseCase,
 	listCountriesUseCase *vaccineUC.ListCountriesUseCase,
 	getCDCRecommendationsUseCase *vaccineUC.GetCDCRecommendationsUseCase,
+	recordRecommendationUseCase *vaccineUC.RecordCDCRecommendationUseCase,
+	listAliasesUseCase *vaccineUC.ListAliasesUseCase,
+	upsertAliasUseCase *vaccineUC.UpsertAliasUseCase,
+	listHistoryUseCase *vaccineUC.ListRecommendationHistoryUseCase,
+	importUseCase *vaccineUC.ImportMasterVaccinesUseCase,
+	pageLimits pagination.Limits,
 ) *VaccineHandler {
 	return &VaccineHandler{
 		listMasterVaccinesUseCase:    listMasterVaccinesUseCase,
 		listCountriesUseCase:         listCountriesUseCase,
 		getCDCRecommendationsUseCase: getCDCRecommendationsUseCase,
+		recordRecommendationUseCase:  recordRecommendationUseCase,
+		listAliasesUseCase:           listAliasesUseCase,
+		upsertAliasUseCase:           upsertAliasUseCase,
+		listHistoryUseCase:           listHistoryUseCase,
+		importUseCase:                importUseCase,
+		queryParser:                  &pagination.QueryParser{Limits: pageLimits},
 	}
 }
 
@@ -35,8 +58,7 @@ func (h *VaccineHandler) ListMasterVaccines(c *fiber.Ctx) error {
 		queryParams[string(key)] = string(value)
 	})
 
-	queryParser := &pagination.QueryParser{}
-	params, err := queryParser.Parse(queryParams)
+	params, err := h.queryParser.Parse(queryParams)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "Invalid query parameters: " + err.Error(),
@@ -53,6 +75,13 @@ func (h *VaccineHandler) ListMasterVaccines(c *fiber.Ctx) error {
 
 	response, err := h.listMasterVaccinesUseCase.Execute(ctx, &req)
 	if err != nil {
+		if errors.Is(err, pagination.ErrInvalidFilter) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"success": false,
+				"message": "Invalid query parameters",
+				"error":   err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"success": false,
 			"message": "Failed to get master vaccines",
@@ -75,8 +104,7 @@ func (h *VaccineHandler) ListCountries(c *fiber.Ctx) error {
 		queryParams[string(key)] = string(value)
 	})
 
-	queryParser := &pagination.QueryParser{}
-	params, err := queryParser.Parse(queryParams)
+	params, err := h.queryParser.Parse(queryParams)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "Invalid query parameters: " + err.Error(),
@@ -88,6 +116,11 @@ func (h *VaccineHandler) ListCountries(c *fiber.Ctx) error {
 	}
 	response, err := h.listCountriesUseCase.Execute(context.Background(), &req)
 	if err != nil {
+		if errors.Is(err, pagination.ErrInvalidFilter) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid query parameters: " + err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -126,6 +159,14 @@ func (h *VaccineHandler) GetVaccineRecommendations(c *fiber.Ctx) error {
 		})
 	}
 
+	if err := req.Validate(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Invalid query parameters",
+			"error":   err.Error(),
+		})
+	}
+
 	// Get context from fiber
 	ctx := c.Context()
 
@@ -144,3 +185,243 @@ func (h *VaccineHandler) GetVaccineRecommendations(c *fiber.Ctx) error {
 		"data":    response,
 	})
 }
+
+// RecordVaccineRecommendation handles POST /api/v1/vaccine/recommendations/:countryCode/history. It
+// serves the recommendation like GetVaccineRecommendations and records it for a business trip or
+// assignee; the anonymous GET never records anything.
+func (h *VaccineHandler) RecordVaccineRecommendation(c *fiber.Ctx) error {
+	if _, err := middleware.GetAuthenticatedUser(c); err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"message": "Authentication required",
+		})
+	}
+
+	var req vaccineUC.RecordCDCRecommendationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Invalid request body",
+			"error":   err.Error(),
+		})
+	}
+	req.CountryCode = c.Params("countryCode")
+
+	if err := req.Validate(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"error":   err.Error(),
+		})
+	}
+
+	response, err := h.recordRecommendationUseCase.Execute(c.UserContext(), &req)
+	if err != nil {
+		if errors.Is(err, entity.ErrBusinessTripNotFound) || errors.Is(err, entity.ErrAssigneeNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"success": false,
+				"message": "Business trip or assignee not found",
+				"error":   err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to record vaccine recommendation",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"message": "Vaccine recommendation recorded successfully",
+		"data":    response,
+	})
+}
+
+// ListRecommendationHistory handles GET /api/v1/vaccine/recommendations/history, the recommendations
+// served for a business trip or assignee, latest first
+func (h *VaccineHandler) ListRecommendationHistory(c *fiber.Ctx) error {
+	var req vaccineUC.ListRecommendationHistoryRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Invalid query parameters",
+			"error":   err.Error(),
+		})
+	}
+	if err := req.Validate(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Invalid query parameters",
+			"error":   err.Error(),
+		})
+	}
+	req.Limit = h.queryParser.Limits.PageSize(req.Limit)
+
+	history, err := h.listHistoryUseCase.Execute(c.UserContext(), &req)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to get vaccine recommendation history",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Vaccine recommendation history retrieved successfully",
+		"data":    history,
+	})
+}
+
+// ListAliases handles GET /api/v1/vaccine/aliases/:kind
+func (h *VaccineHandler) ListAliases(c *fiber.Ctx) error {
+	user, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"message": "Authentication required",
+			"error":   err.Error(),
+		})
+	}
+	if !user.HasRole(entity.RoleAdmin) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"success": false,
+			"message": "Only administrators can manage aliases",
+		})
+	}
+
+	aliases, err := h.listAliasesUseCase.Execute(c.Context(), vaccineUC.AliasKind(c.Params("kind")))
+	if err != nil {
+		if errors.Is(err, vaccineUC.ErrUnknownAliasKind) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"success": false,
+				"message": "Unknown alias kind",
+				"error":   err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to get aliases",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": "Aliases retrieved successfully",
+		"data":    aliases,
+	})
+}
+
+// UpsertAlias handles PUT /api/v1/vaccine/aliases/:kind
+func (h *VaccineHandler) UpsertAlias(c *fiber.Ctx) error {
+	user, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"message": "Authentication required",
+			"error":   err.Error(),
+		})
+	}
+	if !user.HasRole(entity.RoleAdmin) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"success": false,
+			"message": "Only administrators can manage aliases",
+		})
+	}
+
+	var req vaccineUC.UpsertAliasRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Invalid request body",
+			"error":   err.Error(),
+		})
+	}
+	req.Kind = vaccineUC.AliasKind(c.Params("kind"))
+
+	alias, err := h.upsertAliasUseCase.Execute(c.Context(), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, vaccineUC.ErrUnknownAliasKind):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"success": false,
+				"message": "Unknown alias kind",
+				"error":   err.Error(),
+			})
+		case errors.Is(err, vaccineUC.ErrInvalidAlias):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"success": false,
+				"message": "Invalid alias",
+				"error":   err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to save alias",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": "Alias saved successfully",
+		"data":    alias,
+	})
+}
+
+// ImportMasterVaccines handles POST /api/v1/vaccine/import. The body is a JSON array of vaccines, or CSV with a
+// header row when sent as text/csv; vaccines are matched by English name, so importing one again updates it.
+func (h *VaccineHandler) ImportMasterVaccines(c *fiber.Ctx) error {
+	user, err := middleware.GetAuthenticatedUser(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"success": false,
+			"message": "Authentication required",
+			"error":   err.Error(),
+		})
+	}
+	if !user.HasRole(entity.RoleAdmin) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"success": false,
+			"message": "Only administrators can import master vaccines",
+		})
+	}
+
+	var rows []vaccineUC.ImportMasterVaccineRow
+	if strings.HasPrefix(c.Get(fiber.HeaderContentType), "text/csv") {
+		rows, err = vaccineUC.ParseMasterVaccinesCSV(bytes.NewReader(c.Body()))
+	} else {
+		err = c.BodyParser(&rows)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Invalid request body",
+			"error":   err.Error(),
+		})
+	}
+
+	response, err := h.importUseCase.Execute(c.Context(), rows)
+	if err != nil {
+		if errors.Is(err, vaccineUC.ErrInvalidImport) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"success": false,
+				"message": "Invalid import",
+				"error":   err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to import master vaccines",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": "Master vaccines imported",
+		"data":    response,
+	})
+}
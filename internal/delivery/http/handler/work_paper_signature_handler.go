@@ -1,7 +1,9 @@
 package handler
 
 import (
-	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -19,6 +21,10 @@ type WorkPaperSignatureHandler struct {
 	getWorkPaperSignaturesByWorkPaperIDUseCase *workPaperSignatureUC.GetWorkPaperSignaturesByWorkPaperIDUseCase
 	createDigitalSignatureUseCase              *workPaperSignatureUC.CreateDigitalSignatureUseCase
 	verifyDigitalSignatureUseCase              *workPaperSignatureUC.VerifyDigitalSignatureUseCase
+	getSignatureCertificateUseCase             *workPaperSignatureUC.GetSignatureCertificateUseCase
+	getDocumentSignatureUseCase                *workPaperSignatureUC.GetDocumentSignatureUseCase
+	listSigningLogUseCase                      *workPaperSignatureUC.ListSigningLogUseCase
+	queryParser                                *pagination.QueryParser
 	validation                                 *validator.Validate
 }
 
@@ -76,7 +82,7 @@ func (h *WorkPaperSignatureHandler) CreateWorkPaperSignature(c *fiber.Ctx) error
 		})
 	}
 
-	ctx := context.Background()
+	ctx := c.UserContext()
 	signature, err := h.deskService.CreateWorkPaperSignature(ctx, &req)
 	if err != nil {
 		switch err {
@@ -128,7 +134,7 @@ func (h *WorkPaperSignatureHandler) GetWorkPaperSignature(c *fiber.Ctx) error {
 		})
 	}
 
-	ctx := context.Background()
+	ctx := c.UserContext()
 	signature, err := h.deskService.GetWorkPaperSignature(ctx, signatureID)
 	if err != nil {
 		switch err {
@@ -172,7 +178,7 @@ func (h *WorkPaperSignatureHandler) GetWorkPaperSignature(c *fiber.Ctx) error {
 func (h *WorkPaperSignatureHandler) ListWorkPapersWithSignatures(c *fiber.Ctx) error {
 	// Parse query parameters
 	page := c.QueryInt("page", 1)
-	limit := c.QueryInt("limit", 20)
+	limit := h.queryParser.Limits.PageSize(c.QueryInt("limit", 0))
 	status := c.Query("status")
 	organizationID := c.Query("organizationId")
 
@@ -180,12 +186,9 @@ func (h *WorkPaperSignatureHandler) ListWorkPapersWithSignatures(c *fiber.Ctx) e
 	if page < 1 {
 		page = 1
 	}
-	if limit < 1 || limit > 100 {
-		limit = 20
-	}
 
 	// Create context with timeout
-	ctx := context.Background()
+	ctx := c.UserContext()
 
 	// Get work papers with their signatures
 	workPapers, err := h.deskService.GetWorkPapersWithSignatures(ctx, page, limit, status, organizationID)
@@ -236,23 +239,23 @@ func (h *WorkPaperSignatureHandler) SignWorkPaper(c *fiber.Ctx) error {
 		})
 	}
 
-	ctx := context.Background()
+	ctx := c.UserContext()
 	signature, err := h.deskService.SignWorkPaperWithUser(ctx, signatureID, user.ID)
 	if err != nil {
-		switch err {
-		case entity.ErrSignatureNotFound:
+		switch {
+		case errors.Is(err, entity.ErrSignatureNotFound):
 			return c.Status(fiber.StatusNotFound).JSON(ErrorResponse{
 				Error:   "Signature not found",
 				Message: err.Error(),
 				Code:    fiber.StatusNotFound,
 			})
-		case entity.ErrAlreadySigned:
+		case errors.Is(err, entity.ErrAlreadySigned):
 			return c.Status(fiber.StatusConflict).JSON(ErrorResponse{
 				Error:   "Already signed",
 				Message: "This signature has already been signed",
 				Code:    fiber.StatusConflict,
 			})
-		case entity.ErrSignatureRejected:
+		case errors.Is(err, entity.ErrSignatureRejected):
 			return c.Status(fiber.StatusConflict).JSON(ErrorResponse{
 				Error:   "Signature rejected",
 				Message: "This signature has been rejected and cannot be signed",
@@ -285,6 +288,7 @@ func (h *WorkPaperSignatureHandler) SignWorkPaper(c *fiber.Ctx) error {
 // @Success 200 {object} entity.WorkPaperSignature
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/work-paper-signatures/{id}/reject [post]
 func (h *WorkPaperSignatureHandler) RejectWorkPaperSignature(c *fiber.Ctx) error {
@@ -315,22 +319,28 @@ func (h *WorkPaperSignatureHandler) RejectWorkPaperSignature(c *fiber.Ctx) error
 		})
 	}
 
-	ctx := context.Background()
+	ctx := c.UserContext()
 	signature, err := h.deskService.RejectWorkPaperSignature(ctx, signatureID, &req)
 	if err != nil {
-		switch err {
-		case entity.ErrSignatureNotFound:
+		switch {
+		case errors.Is(err, entity.ErrSignatureNotFound):
 			return c.Status(fiber.StatusNotFound).JSON(ErrorResponse{
 				Error:   "Signature not found",
 				Message: err.Error(),
 				Code:    fiber.StatusNotFound,
 			})
-		case entity.ErrAlreadySigned:
+		case errors.Is(err, entity.ErrAlreadySigned):
 			return c.Status(fiber.StatusConflict).JSON(ErrorResponse{
 				Error:   "Already signed",
 				Message: "This signature has already been signed and cannot be rejected",
 				Code:    fiber.StatusConflict,
 			})
+		case errors.Is(err, entity.ErrSignatureRejected):
+			return c.Status(fiber.StatusConflict).JSON(ErrorResponse{
+				Error:   "Signature rejected",
+				Message: "This signature has already been rejected, reset it first",
+				Code:    fiber.StatusConflict,
+			})
 		default:
 			return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
 				Error:   "Failed to reject signature",
@@ -355,6 +365,7 @@ func (h *WorkPaperSignatureHandler) RejectWorkPaperSignature(c *fiber.Ctx) error
 // @Param id path string true "Signature ID"
 // @Success 200 {object} entity.WorkPaperSignature
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/work-paper-signatures/{id}/reset [post]
 func (h *WorkPaperSignatureHandler) ResetWorkPaperSignature(c *fiber.Ctx) error {
@@ -367,16 +378,22 @@ func (h *WorkPaperSignatureHandler) ResetWorkPaperSignature(c *fiber.Ctx) error
 		})
 	}
 
-	ctx := context.Background()
+	ctx := c.UserContext()
 	signature, err := h.deskService.ResetWorkPaperSignature(ctx, signatureID)
 	if err != nil {
-		switch err {
-		case entity.ErrSignatureNotFound:
+		switch {
+		case errors.Is(err, entity.ErrSignatureNotFound):
 			return c.Status(fiber.StatusNotFound).JSON(ErrorResponse{
 				Error:   "Signature not found",
 				Message: err.Error(),
 				Code:    fiber.StatusNotFound,
 			})
+		case errors.Is(err, entity.ErrAlreadySigned):
+			return c.Status(fiber.StatusConflict).JSON(ErrorResponse{
+				Error:   "Already signed",
+				Message: "This signature has already been signed and cannot be reset",
+				Code:    fiber.StatusConflict,
+			})
 		default:
 			return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
 				Error:   "Failed to reset signature",
@@ -413,7 +430,7 @@ func (h *WorkPaperSignatureHandler) GetWorkPaperSignaturesByUserID(c *fiber.Ctx)
 		})
 	}
 
-	ctx := context.Background()
+	ctx := c.UserContext()
 	signatures, err := h.deskService.GetWorkPaperSignaturesByUserID(ctx, userID)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
@@ -453,24 +470,28 @@ func (h *WorkPaperSignatureHandler) ListWorkPaperSignatures(c *fiber.Ctx) error
 		queryParams[string(key)] = string(value)
 	})
 
-	queryParser := &pagination.QueryParser{}
-	params, err := queryParser.Parse(queryParams)
+	params, err := h.queryParser.Parse(queryParams)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "Invalid query parameters: " + err.Error(),
 		})
 	}
 
-	workPaperSignatures, pagination, err := h.listWorkPaperSignaturesUseCase.Execute(context.Background(), params)
+	workPaperSignatures, pagedResponse, err := h.listWorkPaperSignaturesUseCase.Execute(c.UserContext(), params)
 	if err != nil {
+		if errors.Is(err, pagination.ErrInvalidFilter) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid query parameters: " + err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
-	pagination.Data = workPaperSignatures
+	pagedResponse.Data = workPaperSignatures
 
-	return c.JSON(pagination)
+	return c.JSON(pagedResponse)
 }
 
 // GetWorkPaperSignaturesByWorkPaperID gets all signatures for a specific work paper
@@ -494,7 +515,7 @@ func (h *WorkPaperSignatureHandler) GetWorkPaperSignaturesByWorkPaperID(c *fiber
 		})
 	}
 
-	ctx := context.Background()
+	ctx := c.UserContext()
 	signatures, err := h.getWorkPaperSignaturesByWorkPaperIDUseCase.Execute(ctx, workPaperID)
 	if err != nil {
 		switch err {
@@ -596,7 +617,8 @@ func (h *WorkPaperSignatureHandler) CreateDigitalSignature(c *fiber.Ctx) error {
 
 // VerifyDigitalSignature verifies a digital signature
 // @Summary Verify Digital Signature
-// @Description Verifies a certificate-based digital signature for a work paper signature
+// @Description Verifies a certificate-based digital signature for a work paper signature. When a signing
+// @Description certificate is configured, a signature made outside its validity window has the warning status.
 // @Tags work-paper-signatures
 // @Produce json
 // @Param id path string true "Signature ID"
@@ -651,6 +673,161 @@ func (h *WorkPaperSignatureHandler) VerifyDigitalSignature(c *fiber.Ctx) error {
 	})
 }
 
+// GetSignatureCertificate downloads the certificate used for a digital signature
+// @Summary Download Signature Certificate
+// @Description Returns the PEM X.509 certificate of the key used to produce a digital signature for offline verification; 404 when no certificate is configured
+// @Tags work-paper-signatures
+// @Produce application/x-pem-file
+// @Param id path string true "Signature ID"
+// @Success 200 {file} file
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/work-paper-signatures/{id}/certificate [get]
+func (h *WorkPaperSignatureHandler) GetSignatureCertificate(c *fiber.Ctx) error {
+	signatureID := c.Params("id")
+	if signatureID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Error:   "Missing signature ID",
+			Message: "Signature ID is required",
+			Code:    fiber.StatusBadRequest,
+		})
+	}
+
+	certificate, err := h.getSignatureCertificateUseCase.Execute(c.UserContext(), signatureID)
+	if err != nil {
+		switch err {
+		case workPaperSignatureUC.ErrSignatureNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(ErrorResponse{
+				Error:   "Signature not found",
+				Message: err.Error(),
+				Code:    fiber.StatusNotFound,
+			})
+		case workPaperSignatureUC.ErrNoDigitalSignature:
+			return c.Status(fiber.StatusNotFound).JSON(ErrorResponse{
+				Error:   "No digital signature found",
+				Message: err.Error(),
+				Code:    fiber.StatusNotFound,
+			})
+		case workPaperSignatureUC.ErrNoSigningCertificate:
+			return c.Status(fiber.StatusNotFound).JSON(ErrorResponse{
+				Error:   "No signing certificate configured",
+				Message: err.Error(),
+				Code:    fiber.StatusNotFound,
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
+				Error:   "Failed to get signature certificate",
+				Message: err.Error(),
+				Code:    fiber.StatusInternalServerError,
+			})
+		}
+	}
+
+	// A signed signature never changes its certificate, so clients may cache it
+	c.Set(fiber.HeaderContentType, "application/x-pem-file")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="signature-%s.pem"`, signatureID))
+	c.Set(fiber.HeaderCacheControl, "private, max-age=86400")
+	c.Set(fiber.HeaderLastModified, certificate.SignedAt.UTC().Format(time.RFC1123))
+
+	return c.Status(fiber.StatusOK).Send(certificate.PEM)
+}
+
+// GetDocumentSignature looks up the signing records of a document
+// @Summary Get Document Signature
+// @Description Returns stored signing metadata and validity for the digital signatures of a document (work paper)
+// @Tags work-paper-signatures
+// @Produce json
+// @Param docId path string true "Document (work paper) ID"
+// @Success 200 {object} workPaperSignatureUC.GetDocumentSignatureResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/desk/documents/{docId}/signature [get]
+func (h *WorkPaperSignatureHandler) GetDocumentSignature(c *fiber.Ctx) error {
+	documentID := c.Params("docId")
+	if documentID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Error:   "Missing document ID",
+			Message: "Document ID is required",
+			Code:    fiber.StatusBadRequest,
+		})
+	}
+
+	response, err := h.getDocumentSignatureUseCase.Execute(c.UserContext(), documentID)
+	if err != nil {
+		switch err {
+		case workPaperSignatureUC.ErrDocumentSignatureNotFound:
+			return c.Status(fiber.StatusNotFound).JSON(ErrorResponse{
+				Error:   "Document signature not found",
+				Message: err.Error(),
+				Code:    fiber.StatusNotFound,
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
+				Error:   "Failed to get document signature",
+				Message: err.Error(),
+				Code:    fiber.StatusInternalServerError,
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(SuccessResponse{
+		Success: true,
+		Message: "Document signature retrieved successfully",
+		Data:    response,
+	})
+}
+
+// ListSigningLog lists digital signings as an audit trail
+// @Summary List Signing Log
+// @Description Paginated log of completed digital signings, newest first. Filters: user_id, doc_id and signed_at (e.g. signed_at=between 2025-01-01,2025-01-31). Sort: signed_at, user_id, doc_id
+// @Tags work-paper-signatures
+// @Produce json
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100 unless configured otherwise)"
+// @Param sort query string false "Sort fields (default: 'signed_at desc')"
+// @Param user_id query string false "Filter by signer user ID (e.g. 'eq <id>')"
+// @Param doc_id query string false "Filter by document (work paper) ID (e.g. 'eq <id>')"
+// @Param signed_at query string false "Filter by signing time (e.g. 'gte 2025-01-01')"
+// @Success 200 {object} pagination.PagedResponse{data=[]workPaperSignatureUC.SigningLogEntry}
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/desk/signings [get]
+func (h *WorkPaperSignatureHandler) ListSigningLog(c *fiber.Ctx) error {
+	queryParams := make(map[string]string)
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		queryParams[string(key)] = string(value)
+	})
+
+	params, err := h.queryParser.Parse(queryParams)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+			Error:   "Invalid query parameters",
+			Message: err.Error(),
+			Code:    fiber.StatusBadRequest,
+		})
+	}
+
+	entries, pagedResponse, err := h.listSigningLogUseCase.Execute(c.UserContext(), params)
+	if err != nil {
+		if errors.Is(err, workPaperSignatureUC.ErrInvalidSigningLogQuery) || errors.Is(err, pagination.ErrInvalidFilter) {
+			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
+				Error:   "Invalid query parameters",
+				Message: err.Error(),
+				Code:    fiber.StatusBadRequest,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
+			Error:   "Failed to list signing log",
+			Message: err.Error(),
+			Code:    fiber.StatusInternalServerError,
+		})
+	}
+
+	pagedResponse.Data = entries
+
+	return c.JSON(pagedResponse)
+}
+
 // Updated constructor
 func NewWorkPaperSignatureHandler(
 	deskService service.DeskService,
@@ -658,6 +835,10 @@ func NewWorkPaperSignatureHandler(
 	getWorkPaperSignaturesByWorkPaperIDUseCase *workPaperSignatureUC.GetWorkPaperSignaturesByWorkPaperIDUseCase,
 	createDigitalSignatureUseCase *workPaperSignatureUC.CreateDigitalSignatureUseCase,
 	verifyDigitalSignatureUseCase *workPaperSignatureUC.VerifyDigitalSignatureUseCase,
+	getSignatureCertificateUseCase *workPaperSignatureUC.GetSignatureCertificateUseCase,
+	getDocumentSignatureUseCase *workPaperSignatureUC.GetDocumentSignatureUseCase,
+	listSigningLogUseCase *workPaperSignatureUC.ListSigningLogUseCase,
+	pageLimits pagination.Limits,
 ) *WorkPaperSignatureHandler {
 	return &WorkPaperSignatureHandler{
 		deskService:                                deskService,
@@ -665,6 +846,10 @@ func NewWorkPaperSignatureHandler(
 		getWorkPaperSignaturesByWorkPaperIDUseCase: getWorkPaperSignaturesByWorkPaperIDUseCase,
 		createDigitalSignatureUseCase:              createDigitalSignatureUseCase,
 		verifyDigitalSignatureUseCase:              verifyDigitalSignatureUseCase,
+		getSignatureCertificateUseCase:             getSignatureCertificateUseCase,
+		getDocumentSignatureUseCase:                getDocumentSignatureUseCase,
+		listSigningLogUseCase:                      listSigningLogUseCase,
+		queryParser:                                &pagination.QueryParser{Limits: pageLimits},
 		validation:                                 validator.New(),
 	}
 }
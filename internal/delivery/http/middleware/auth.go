@@ -11,6 +11,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"sandbox/internal/domain/entity"
+	"sandbox/pkg/featureflag"
 )
 
 // Role represents a user role from identity service
@@ -77,6 +78,11 @@ func AuthMiddleware() fiber.Handler {
 		// Store authenticated user in context locals
 		c.Locals("authenticatedUser", user)
 
+		// Carry the organization so feature flags resolve its overrides
+		organizationID := user.Organization.ID.String()
+		c.Locals(featureflag.OrganizationIDKey, organizationID)
+		c.SetUserContext(featureflag.WithOrganizationID(c.UserContext(), organizationID))
+
 		// Continue to next handler
 		return c.Next()
 	}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"sandbox/pkg/logging"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ConfigureCorrelationID tags each request with a correlation ID, taken from the X-Correlation-ID
+// header, or else the X-Request-ID header, when the caller sends one. The ID is echoed in the
+// response and carried by both c.Context() and c.UserContext() so downstream logs can be tied to
+// the request.
+func ConfigureCorrelationID() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		correlationID := c.Get(logging.CorrelationIDHeader)
+		if correlationID == "" {
+			correlationID = c.Get(logging.RequestIDHeader)
+		}
+		if correlationID == "" || len(correlationID) > 128 {
+			correlationID = uuid.NewString()
+		}
+
+		c.Locals(logging.CorrelationIDKey, correlationID)
+		c.SetUserContext(logging.WithCorrelationID(c.UserContext(), correlationID))
+		c.Set(logging.CorrelationIDHeader, correlationID)
+
+		return c.Next()
+	}
+}
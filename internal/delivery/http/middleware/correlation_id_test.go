@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"sandbox/pkg/logging"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestCorrelationIDReachesExternalClientLogs(t *testing.T) {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(logging.NewHandler(&buf, "text", slog.LevelDebug)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	external := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer external.Close()
+	client := logging.NewHTTPClient("notification", 0)
+
+	app := fiber.New()
+	app.Use(ConfigureCorrelationID())
+	app.Post("/notify", func(c *fiber.Ctx) error {
+		req, err := http.NewRequestWithContext(c.UserContext(), http.MethodPost, external.URL+"/send", nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return c.SendStatus(fiber.StatusAccepted)
+	})
+
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{"X-Correlation-ID", logging.CorrelationIDHeader},
+		{"X-Request-ID", logging.RequestIDHeader},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+
+			req := httptest.NewRequest(fiber.MethodPost, "/notify", nil)
+			req.Header.Set(tt.header, "req-123")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if resp.StatusCode != fiber.StatusAccepted {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusAccepted)
+			}
+
+			logs := buf.String()
+			if !strings.Contains(logs, "external request") || !strings.Contains(logs, "service=notification") {
+				t.Fatalf("logs = %q, want the external request entry", logs)
+			}
+			if !strings.Contains(logs, "correlation_id=req-123") {
+				t.Errorf("logs = %q, want the external request tagged with the incoming %s", logs, tt.header)
+			}
+		})
+	}
+}
@@ -7,16 +7,42 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/cors"
 )
 
-func ConfigureCORS(allowOrigins string) fiber.Handler {
-	log.Println(allowOrigins)
-	if allowOrigins == "" {
-		allowOrigins = "https://marvcore.com,https://www.marvcore.com,http://localhost:3000"
+const (
+	defaultCORSAllowOrigins = "https://marvcore.com,https://www.marvcore.com,http://localhost:3000"
+	defaultCORSAllowMethods = "GET,POST,PUT,DELETE,OPTIONS,PATCH,HEAD"
+	defaultCORSAllowHeaders = "Origin, Content-Type, Accept, Authorization"
+)
+
+// CORSOptions holds the CORS settings; lists are comma separated and empty lists fall back to the defaults
+type CORSOptions struct {
+	AllowOrigins     string
+	AllowMethods     string
+	AllowHeaders     string
+	ExposeHeaders    string
+	AllowCredentials bool
+}
+
+func ConfigureCORS(opts CORSOptions) fiber.Handler {
+	log.Println(opts.AllowOrigins)
+	return cors.New(corsConfig(opts))
+}
+
+func corsConfig(opts CORSOptions) cors.Config {
+	if opts.AllowOrigins == "" {
+		opts.AllowOrigins = defaultCORSAllowOrigins
+	}
+	if opts.AllowMethods == "" {
+		opts.AllowMethods = defaultCORSAllowMethods
+	}
+	if opts.AllowHeaders == "" {
+		opts.AllowHeaders = defaultCORSAllowHeaders
 	}
 
-	return cors.New(cors.Config{
-		AllowOrigins:     allowOrigins,
-		AllowMethods:     "GET,POST,PUT,DELETE,OPTIONS,PATCH,HEAD",
-		AllowHeaders:     "Origin, Content-Type, Accept, Authorization",
-		AllowCredentials: true,
-	})
+	return cors.Config{
+		AllowOrigins:     opts.AllowOrigins,
+		AllowMethods:     opts.AllowMethods,
+		AllowHeaders:     opts.AllowHeaders,
+		ExposeHeaders:    opts.ExposeHeaders,
+		AllowCredentials: opts.AllowCredentials,
+	}
 }
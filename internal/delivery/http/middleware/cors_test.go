@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestCORSConfigUsesProvidedValues(t *testing.T) {
+	opts := CORSOptions{
+		AllowOrigins:     "https://app.example.com",
+		AllowMethods:     "GET,POST",
+		AllowHeaders:     "Content-Type, Idempotency-Key",
+		ExposeHeaders:    "X-Correlation-ID, Idempotent-Replayed",
+		AllowCredentials: false,
+	}
+
+	got := corsConfig(opts)
+
+	if got.AllowOrigins != opts.AllowOrigins || got.AllowMethods != opts.AllowMethods ||
+		got.AllowHeaders != opts.AllowHeaders || got.ExposeHeaders != opts.ExposeHeaders || got.AllowCredentials {
+		t.Errorf("corsConfig() = %+v, want the provided values", got)
+	}
+}
+
+func TestCORSConfigDefaultsMatchPreviousBehavior(t *testing.T) {
+	got := corsConfig(CORSOptions{AllowCredentials: true})
+
+	if got.AllowOrigins != defaultCORSAllowOrigins || got.AllowMethods != defaultCORSAllowMethods ||
+		got.AllowHeaders != defaultCORSAllowHeaders || got.ExposeHeaders != "" || !got.AllowCredentials {
+		t.Errorf("corsConfig() = %+v, want the defaults", got)
+	}
+}
+
+func TestConfigureCORSAnswersPreflightWithConfiguredValues(t *testing.T) {
+	app := fiber.New()
+	app.Use(ConfigureCORS(CORSOptions{
+		AllowOrigins:  "https://app.example.com",
+		AllowMethods:  "GET,PATCH",
+		AllowHeaders:  "Idempotency-Key",
+		ExposeHeaders: "X-Correlation-ID",
+	}))
+	app.Get("/", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	preflight := httptest.NewRequest(fiber.MethodOptions, "/", nil)
+	preflight.Header.Set(fiber.HeaderOrigin, "https://app.example.com")
+	preflight.Header.Set(fiber.HeaderAccessControlRequestMethod, fiber.MethodPatch)
+	resp, err := app.Test(preflight)
+	if err != nil {
+		t.Fatalf("preflight failed: %v", err)
+	}
+	if got := resp.Header.Get(fiber.HeaderAccessControlAllowMethods); got != "GET,PATCH" {
+		t.Errorf("Access-Control-Allow-Methods = %q, want GET,PATCH", got)
+	}
+	if got := resp.Header.Get(fiber.HeaderAccessControlAllowHeaders); got != "Idempotency-Key" {
+		t.Errorf("Access-Control-Allow-Headers = %q, want Idempotency-Key", got)
+	}
+	if got := resp.Header.Get(fiber.HeaderAccessControlAllowCredentials); got != "" {
+		t.Errorf("Access-Control-Allow-Credentials = %q, want none", got)
+	}
+
+	request := httptest.NewRequest(fiber.MethodGet, "/", nil)
+	request.Header.Set(fiber.HeaderOrigin, "https://app.example.com")
+	resp, err = app.Test(request)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if got := resp.Header.Get(fiber.HeaderAccessControlExposeHeaders); got != "X-Correlation-ID" {
+		t.Errorf("Access-Control-Expose-Headers = %q, want X-Correlation-ID", got)
+	}
+}
@@ -1,14 +1,44 @@
 package middleware
 
 import (
+	"log/slog"
+	"time"
+
+	"sandbox/pkg/logging"
+
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/logger"
 )
 
-// ConfigureLogger returns a configured logger middleware
+// ConfigureLogger returns a middleware writing one structured access log entry per request with
+// its method, path, status, latency and correlation ID. It must run after ConfigureCorrelationID.
 func ConfigureLogger() fiber.Handler {
-	return logger.New(logger.Config{
-		Format:     "[${time}] ${status} - ${latency} ${method} ${path}\n",
-		TimeFormat: "2006-01-02 15:04:05",
-	})
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		// Let the error handler write the response now so the logged status is the one the client gets
+		if err := c.Next(); err != nil {
+			if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		status := c.Response().StatusCode()
+		level := slog.LevelInfo
+		switch {
+		case status >= fiber.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= fiber.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		ctx := c.UserContext()
+		logging.FromContext(ctx).LogAttrs(ctx, level, "http request",
+			slog.String("method", c.Method()),
+			slog.String("path", c.Path()),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+		)
+
+		return nil
+	}
 }
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+
+	"sandbox/pkg/logging"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestLoggerWritesStructuredEntryWithRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(logging.NewHandler(&buf, "json", slog.LevelInfo)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	app := fiber.New()
+	app.Use(ConfigureCorrelationID(), ConfigureLogger())
+	app.Get("/missing", func(c *fiber.Ctx) error { return fiber.ErrNotFound })
+
+	req := httptest.NewRequest(fiber.MethodGet, "/missing", nil)
+	req.Header.Set(logging.RequestIDHeader, "req-123")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+
+	if got := resp.Header.Get(logging.CorrelationIDHeader); got != "req-123" {
+		t.Errorf("%s = %q, want the incoming X-Request-ID", logging.CorrelationIDHeader, got)
+	}
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("status = %d, want %d", resp.StatusCode, fiber.StatusNotFound)
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("log entry %q is not JSON: %v", buf.String(), err)
+	}
+	want := map[string]any{
+		"msg":                    "http request",
+		"method":                 "GET",
+		"path":                   "/missing",
+		"status":                 float64(fiber.StatusNotFound),
+		"level":                  "WARN",
+		logging.CorrelationIDKey: "req-123",
+	}
+	for key, value := range want {
+		if entry[key] != value {
+			t.Errorf("log %s = %v, want %v", key, entry[key], value)
+		}
+	}
+	if _, ok := entry["latency_ms"].(float64); !ok {
+		t.Errorf("log latency_ms = %v, want a number", entry["latency_ms"])
+	}
+}
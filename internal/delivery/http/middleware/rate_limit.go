@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// rateLimitSweepInterval is how often buckets that have refilled completely are dropped
+const rateLimitSweepInterval = 10 * time.Minute
+
+// RateLimit is a token bucket: a client may send Burst requests at once, and the bucket refills at
+// RequestsPerMinute. A RequestsPerMinute of zero or less disables the limit.
+type RateLimit struct {
+	RequestsPerMinute int
+	Burst             int
+}
+
+type rateLimitBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// rateLimiter holds one bucket per client
+type rateLimiter struct {
+	limit     RateLimit
+	perSecond float64
+	now       func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*rateLimitBucket
+	lastSweep time.Time
+}
+
+func newRateLimiter(limit RateLimit) *rateLimiter {
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	return &rateLimiter{
+		limit:     limit,
+		perSecond: float64(limit.RequestsPerMinute) / 60,
+		now:       time.Now,
+		buckets:   make(map[string]*rateLimitBucket),
+	}
+}
+
+// allow takes a token from the client's bucket. When the bucket is empty it returns false and how long
+// the client has to wait for the next token.
+func (l *rateLimiter) allow(key string) (bool, time.Duration) {
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &rateLimitBucket{tokens: float64(l.limit.Burst), updated: now}
+		l.buckets[key] = bucket
+	}
+	bucket.tokens = l.refill(bucket, now)
+	bucket.updated = now
+
+	if bucket.tokens < 1 {
+		wait := time.Duration((1 - bucket.tokens) / l.perSecond * float64(time.Second))
+		return false, wait
+	}
+	bucket.tokens--
+	return true, 0
+}
+
+func (l *rateLimiter) refill(bucket *rateLimitBucket, now time.Time) float64 {
+	return math.Min(float64(l.limit.Burst), bucket.tokens+now.Sub(bucket.updated).Seconds()*l.perSecond)
+}
+
+// sweep drops the buckets of clients that have been idle long enough to be full again, so the map does
+// not grow with every client ever seen
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, bucket := range l.buckets {
+		if l.refill(bucket, now) >= float64(l.limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// RateLimiter limits how often a client may call the routes it is applied to. Clients are the
+// authenticated user when AuthMiddleware ran before it, and the client IP otherwise. Every RateLimiter
+// has its own buckets, so a route group can get a tighter limit on top of a general one. Requests over
+// the limit are answered with 429 Too Many Requests and a Retry-After header.
+func RateLimiter(limit RateLimit) fiber.Handler {
+	if limit.RequestsPerMinute <= 0 {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	limiter := newRateLimiter(limit)
+	return func(c *fiber.Ctx) error {
+		allowed, wait := limiter.allow(rateLimitKey(c))
+		if allowed {
+			return c.Next()
+		}
+
+		retryAfter := int(math.Ceil(wait.Seconds()))
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+			"error":   "Too many requests",
+			"details": fmt.Sprintf("rate limit of %d requests per minute exceeded, retry in %ds", limit.RequestsPerMinute, retryAfter),
+		})
+	}
+}
+
+// rateLimitKey identifies the client by its authenticated user ID, or by its IP before authentication
+func rateLimitKey(c *fiber.Ctx) string {
+	if user, err := GetAuthenticatedUser(c); err == nil && user.ID != "" {
+		return "user:" + user.ID
+	}
+	return "ip:" + c.IP()
+}
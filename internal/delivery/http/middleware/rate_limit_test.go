@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"sandbox/internal/domain/entity"
+)
+
+func TestRateLimiterRefillsAtConfiguredRate(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	limiter := newRateLimiter(RateLimit{RequestsPerMinute: 60, Burst: 2})
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := limiter.allow("user:1"); !ok {
+			t.Fatalf("request %d within the burst was rejected", i+1)
+		}
+	}
+	ok, wait := limiter.allow("user:1")
+	if ok || wait != time.Second {
+		t.Fatalf("allow() after the burst = %v, %v, want rejected with 1s to wait", ok, wait)
+	}
+	if ok, _ := limiter.allow("user:2"); !ok {
+		t.Error("another client was rejected by the first client's bucket")
+	}
+
+	now = now.Add(time.Second)
+	if ok, _ := limiter.allow("user:1"); !ok {
+		t.Error("request after the refill was rejected")
+	}
+}
+
+func TestRateLimiterKeysByUserAndRespondsWithRetryAfter(t *testing.T) {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		if userID := c.Get("X-User"); userID != "" {
+			c.Locals("authenticatedUser", &entity.AuthenticatedUser{ID: userID})
+		}
+		return c.Next()
+	})
+	app.Use(RateLimiter(RateLimit{RequestsPerMinute: 1, Burst: 1}))
+	app.Get("/", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	request := func(userID string) int {
+		req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		req.Header.Set("X-User", userID)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("app.Test() error = %v", err)
+		}
+		if resp.StatusCode == fiber.StatusTooManyRequests && resp.Header.Get(fiber.HeaderRetryAfter) != "60" {
+			t.Errorf("Retry-After = %q, want 60", resp.Header.Get(fiber.HeaderRetryAfter))
+		}
+		return resp.StatusCode
+	}
+
+	if got := request("alice"); got != fiber.StatusOK {
+		t.Fatalf("first request status = %d, want 200", got)
+	}
+	if got := request("alice"); got != fiber.StatusTooManyRequests {
+		t.Errorf("second request status = %d, want 429", got)
+	}
+	if got := request("bob"); got != fiber.StatusOK {
+		t.Errorf("other user status = %d, want 200 from the same IP", got)
+	}
+}
+
+func TestRateLimiterDisabledWithoutRate(t *testing.T) {
+	app := fiber.New()
+	app.Use(RateLimiter(RateLimit{}))
+	app.Get("/", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	for i := 0; i < 5; i++ {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+		if err != nil {
+			t.Fatalf("app.Test() error = %v", err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("request %d status = %d, want 200", i+1, resp.StatusCode)
+		}
+	}
+}
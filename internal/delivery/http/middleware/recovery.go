@@ -1,7 +1,7 @@
 package middleware
 
 import (
-	"log"
+	"sandbox/pkg/logging"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/recover"
@@ -12,7 +12,7 @@ func ConfigureRecovery() fiber.Handler {
 	return recover.New(recover.Config{
 		EnableStackTrace: true,
 		StackTraceHandler: func(c *fiber.Ctx, e interface{}) {
-			log.Printf("Panic recovered: %v", e)
+			logging.FromContext(c.UserContext()).ErrorContext(c.UserContext(), "panic recovered", "panic", e)
 		},
 	})
 }
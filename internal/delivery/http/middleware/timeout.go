@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequestTimeout puts a deadline on c.UserContext() so database and API calls made with it are abandoned
+// instead of holding the request open. A handler that fails because the deadline passed is answered with
+// 504 Gateway Timeout. A timeout of zero or less leaves the request unbounded.
+func RequestTimeout(timeout time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if timeout <= 0 {
+			return c.Next()
+		}
+
+		ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
+		defer cancel()
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && (err != nil || c.Response().StatusCode() >= fiber.StatusInternalServerError) {
+			return c.Status(fiber.StatusGatewayTimeout).JSON(fiber.Map{
+				"error":   "Request timed out",
+				"details": "the request did not finish within " + timeout.String(),
+			})
+		}
+		return err
+	}
+}
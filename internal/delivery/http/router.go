@@ -1,6 +1,8 @@
 package http
 
 import (
+	"time"
+
 	"sandbox/internal/delivery/http/handler"
 	deskHandler "sandbox/internal/delivery/http/handler/desk"
 	"sandbox/internal/delivery/http/middleware"
@@ -8,26 +10,69 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// RouteTimeouts bounds how long the requests of a route group may run; zero leaves them unbounded
+type RouteTimeouts struct {
+	BusinessTrips time.Duration
+	Signatures    time.Duration
+}
+
+// RouteRateLimits are the per-client request limits of the route groups. LLM covers the Gemini-backed
+// document checks and extractions, CDC the vaccine recommendations, and Default everything else.
+type RouteRateLimits struct {
+	Default middleware.RateLimit
+	LLM     middleware.RateLimit
+	CDC     middleware.RateLimit
+}
+
 // SetupRoutes configures all application routes
-func SetupRoutes(app *fiber.App, transactionHandler *handler.TransactionHandler, meetingHandler *handler.MeetingHandler, businessTripHandler *handler.BusinessTripHandler, assigneeHandler *handler.AssigneeHandler, businessTripTransactionHandler *handler.BusinessTripTransactionHandler, workPaperItemHandler *deskHandler.WorkPaperItemHandler, workPaperHandler *deskHandler.WorkPaperHandler, vaccineHandler *handler.VaccineHandler, signatureHandler *handler.WorkPaperSignatureHandler, businessTripDashboardHandler *handler.BusinessTripDashboardHandler, businessTripVerificationHandler *handler.BusinessTripVerificationHandler) {
+func SetupRoutes(app *fiber.App, transactionHandler *handler.TransactionHandler, meetingHandler *handler.MeetingHandler, businessTripHandler *handler.BusinessTripHandler, assigneeHandler *handler.AssigneeHandler, businessTripTransactionHandler *handler.BusinessTripTransactionHandler, workPaperItemHandler *deskHandler.WorkPaperItemHandler, workPaperHandler *deskHandler.WorkPaperHandler, vaccineHandler *handler.VaccineHandler, signatureHandler *handler.WorkPaperSignatureHandler, businessTripDashboardHandler *handler.BusinessTripDashboardHandler, businessTripVerificationHandler *handler.BusinessTripVerificationHandler, featureFlagHandler *handler.FeatureFlagHandler, timeouts RouteTimeouts, rateLimits RouteRateLimits) {
+	businessTripTimeout := middleware.RequestTimeout(timeouts.BusinessTrips)
+	signatureTimeout := middleware.RequestTimeout(timeouts.Signatures)
+
+	// Each limiter keeps its own buckets; it runs after AuthMiddleware so clients are counted per user
+	defaultRateLimit := middleware.RateLimiter(rateLimits.Default)
+	llmRateLimit := middleware.RateLimiter(rateLimits.LLM)
+	cdcRateLimit := middleware.RateLimiter(rateLimits.CDC)
+
 	api := app.Group("/api")
-	api.Post("/upload", middleware.AuthMiddleware(), transactionHandler.UploadAndExtract)
-	api.Post("/upload/detailed", middleware.AuthMiddleware(), transactionHandler.UploadAndExtractDetailed)
-	api.Post("/report/excel", middleware.AuthMiddleware(), transactionHandler.GenerateRecapExcel)
+	api.Post("/upload", middleware.AuthMiddleware(), llmRateLimit, transactionHandler.UploadAndExtract)
+	api.Post("/upload/detailed", middleware.AuthMiddleware(), llmRateLimit, transactionHandler.UploadAndExtractDetailed)
+	api.Post("/report/excel", middleware.AuthMiddleware(), defaultRateLimit, transactionHandler.GenerateRecapExcel)
 
-	api.Post("/meetings", middleware.AuthMiddleware(), meetingHandler.CreateMeeting)
+	api.Post("/meetings", middleware.AuthMiddleware(), defaultRateLimit, meetingHandler.CreateMeeting)
+
+	api.Get("/v1/dashboard/summary", middleware.AuthMiddleware(), defaultRateLimit, businessTripTimeout, businessTripDashboardHandler.GetDashboardSummary)
+	api.Get("/v1/dashboard/cost-by-rank", middleware.AuthMiddleware(), defaultRateLimit, businessTripTimeout, businessTripDashboardHandler.GetCostByRank)
 
 	api.Route("/v1/business-trips", func(r fiber.Router) {
 		r.Use(middleware.AuthMiddleware()) // Apply auth middleware to all business trips routes
+		r.Use(defaultRateLimit)
+		r.Use(businessTripTimeout)
 		r.Get("/dashboard", businessTripDashboardHandler.GetDashboard)
+		r.Get("/reports/cost-centers", businessTripDashboardHandler.GetCostCenterReport)
+		r.Get("/status-facets", businessTripDashboardHandler.GetStatusFacets)
 		r.Post("/", businessTripHandler.CreateBusinessTrip)
 		r.Get("/", businessTripHandler.ListBusinessTrips)
+		r.Get("/next-number", businessTripHandler.GetNextBusinessTripNumber)
+		r.Get("/export.ndjson", businessTripHandler.ExportBusinessTripsNDJSON)
+		r.Get("/by-employee/:employeeNumber", businessTripHandler.FindTripsByEmployee)
+		r.Get("/destinations", businessTripHandler.ListDestinations)
 		r.Get("/verificators", businessTripVerificationHandler.ListVerificators)
+		r.Post("/verificators/bulk", businessTripVerificationHandler.BulkUpdateVerificators)
+		r.Post("/verificators/reminders", businessTripVerificationHandler.SendVerificatorReminders)
+		r.Get("/verificators/:verificatorId/history", businessTripVerificationHandler.GetVerificatorHistory)
 		r.Get("/:tripId", businessTripHandler.GetBusinessTrip)
+		r.Get("/:tripId/transactions.csv", businessTripHandler.ExportTransactionsCSV)
+		r.Get("/:tripId/completion-document", businessTripHandler.GenerateCompletionDocument)
+		r.Get("/:tripId/pdf", businessTripHandler.GenerateBusinessTripPDF)
 		r.Put("/:tripId", businessTripHandler.UpdateBusinessTrip)
 		r.Put("/:tripId/with-assignees", businessTripHandler.UpdateBusinessTripWithAssignees)
+		r.Put("/:tripId/meeting", businessTripHandler.SetBusinessTripMeeting)
+		r.Post("/:tripId/clone", businessTripHandler.CloneBusinessTrip)
+		r.Post("/:tripId/recompute-subtotals", businessTripHandler.RecomputeTransactionSubtotals)
 		r.Delete("/:tripId", businessTripHandler.DeleteBusinessTrip)
 		r.Post("/:tripId/verify", businessTripVerificationHandler.VerifyBusinessTrip)
+		r.Get("/:tripId/verificators", businessTripVerificationHandler.ListBusinessTripVerificators)
 
 		// Dashboard endpoint
 		r.Route("/:tripId/assignees", func(r fiber.Router) {
@@ -36,23 +81,45 @@ func SetupRoutes(app *fiber.App, transactionHandler *handler.TransactionHandler,
 			r.Get("/:assigneeId", assigneeHandler.GetAssignee)
 			r.Put("/:assigneeId", assigneeHandler.UpdateAssignee)
 			r.Delete("/:assigneeId", assigneeHandler.DeleteAssignee)
+			r.Post("/:assigneeId/restore", assigneeHandler.RestoreAssignee)
 
 			r.Route("/:assigneeId/transactions", func(r fiber.Router) {
 				r.Post("/", businessTripTransactionHandler.Create)
 				r.Get("/", businessTripTransactionHandler.List)
 				r.Put("/:transactionId", businessTripTransactionHandler.Update)
+				r.Patch("/:transactionId", businessTripTransactionHandler.Patch)
 				r.Delete("/:transactionId", businessTripTransactionHandler.Delete)
+				r.Post("/:transactionId/attachments", businessTripTransactionHandler.UploadAttachments)
+				r.Get("/:transactionId/attachments", businessTripTransactionHandler.ListAttachments)
+				r.Delete("/:transactionId/attachments/:attachmentId", businessTripTransactionHandler.DeleteAttachment)
 			})
 		})
 	})
 
+	// Work waiting on the authenticated user
+	api.Route("/v1/me", func(r fiber.Router) {
+		r.Use(middleware.AuthMiddleware())
+		r.Use(defaultRateLimit)
+		r.Use(businessTripTimeout)
+		r.Get("/verifications", businessTripVerificationHandler.ListMyVerifications)
+	})
+
+	api.Route("/v1/assignees", func(r fiber.Router) {
+		r.Use(middleware.AuthMiddleware())
+		r.Use(defaultRateLimit)
+		r.Use(businessTripTimeout)
+		r.Post("/:assigneeId/transfer", assigneeHandler.TransferAssignee)
+	})
+
 	// Desk module routes
 	api.Route("/v1/desk", func(r fiber.Router) {
 		r.Use(middleware.AuthMiddleware()) // Apply auth middleware to all desk routes
+		r.Use(defaultRateLimit)
 		// Work Paper Item routes (new)
 		r.Route("/work-paper-items", func(r fiber.Router) {
 			r.Post("/", workPaperItemHandler.CreateWorkPaperItem)
 			r.Get("/", workPaperItemHandler.ListWorkPaperItems)
+			r.Put("/reorder", workPaperItemHandler.ReorderWorkPaperItems)
 			r.Get("/:id", workPaperItemHandler.GetWorkPaperItem)
 			r.Put("/:id", workPaperItemHandler.UpdateWorkPaperItem)
 			r.Delete("/:id", workPaperItemHandler.DeleteWorkPaperItem)
@@ -65,18 +132,28 @@ func SetupRoutes(app *fiber.App, transactionHandler *handler.TransactionHandler,
 			r.Get("/status-transitions", workPaperHandler.GetStatusTransitions)
 			r.Get("/:id", workPaperHandler.GetWorkPaperByID)
 			r.Put("/:id/status", workPaperHandler.UpdateWorkPaperStatus)
+			r.Put("/:id/notes/validate-bulk", workPaperHandler.BulkValidateWorkPaperNotes)
+			r.Post("/:id/notes/prune-inactive", workPaperHandler.PruneInactiveWorkPaperNotes)
+			r.Get("/:id/notes/attention", workPaperHandler.GetNotesNeedingAttention)
 			r.Put("/:id/signers", workPaperHandler.ManageSigners)
 			r.Post("/:id/assign-signers", workPaperHandler.AssignSignersBulk)
 			r.Get("/:id/docx", workPaperHandler.GenerateDocx)
-			r.Get("/:workPaperId/signatures", signatureHandler.GetWorkPaperSignaturesByWorkPaperID)
+			r.Get("/:id/documents.zip", workPaperHandler.DownloadDocuments)
+			r.Get("/:workPaperId/signatures", signatureTimeout, signatureHandler.GetWorkPaperSignaturesByWorkPaperID)
 		})
 
 		// Work Paper Note routes (new)
-		r.Post("/work-paper-notes/check", workPaperHandler.CheckWorkPaperNote)
+		r.Get("/work-paper-notes", workPaperHandler.ListWorkPaperNotes)
+		r.Post("/work-paper-notes/check", llmRateLimit, workPaperHandler.CheckWorkPaperNote)
 		r.Put("/work-paper-notes/:id", workPaperHandler.UpdateWorkPaperNote)
+		r.Get("/work-paper-notes/:id/check-history", workPaperHandler.GetWorkPaperNoteCheckHistory)
+
+		// LLM token usage of the current month against the budget
+		r.Get("/llm-usage", workPaperHandler.GetLLMTokenUsage)
 
 		// Work Paper Signature routes
 		r.Route("/work-paper-signatures", func(r fiber.Router) {
+			r.Use(signatureTimeout)
 			r.Get("/", signatureHandler.ListWorkPaperSignatures)
 			r.Post("/", signatureHandler.CreateWorkPaperSignature)
 			r.Get("/:id", signatureHandler.GetWorkPaperSignature)
@@ -85,11 +162,18 @@ func SetupRoutes(app *fiber.App, transactionHandler *handler.TransactionHandler,
 			r.Post("/:id/reset", signatureHandler.ResetWorkPaperSignature)
 			r.Post("/:id/digital-sign", signatureHandler.CreateDigitalSignature)
 			r.Post("/:id/verify", signatureHandler.VerifyDigitalSignature)
+			r.Get("/:id/certificate", signatureHandler.GetSignatureCertificate)
 		})
 
+		// Signing record lookup by document (work paper) ID
+		r.Get("/documents/:docId/signature", signatureTimeout, signatureHandler.GetDocumentSignature)
+
+		// Signing log (audit trail of digital signings)
+		r.Get("/signings", signatureTimeout, signatureHandler.ListSigningLog)
+
 		// User signatures
 		r.Route("/users/:userId", func(r fiber.Router) {
-			r.Get("/desk/work-papers", signatureHandler.ListWorkPapersWithSignatures)
+			r.Get("/desk/work-papers", signatureTimeout, signatureHandler.ListWorkPapersWithSignatures)
 		})
 
 		// Master LAKIP Item routes (deprecated - for backward compatibility)
@@ -105,14 +189,19 @@ func SetupRoutes(app *fiber.App, transactionHandler *handler.TransactionHandler,
 
 		// Paper Work Item routes (deprecated - for backward compatibility)
 		r.Route("/paper-work-items/check", func(r fiber.Router) {
-			r.Post("/", workPaperHandler.CheckDocument)
+			r.Post("/", llmRateLimit, workPaperHandler.CheckDocument)
 		})
 	})
 
+	// Scanned from the QR code of a signed SPD, so it needs no authentication
+	api.Get("/v1/public/business-trips/:tripId/verify", defaultRateLimit, businessTripTimeout, businessTripHandler.VerifyBusinessTripPDF)
+
 	// Legacy routes for backward compatibility
 	if businessTripHandler != nil {
 		businessTrips := api.Group("/business-trips")
 		businessTrips.Use(middleware.AuthMiddleware()) // Apply auth middleware to legacy business trips
+		businessTrips.Use(defaultRateLimit)
+		businessTrips.Use(businessTripTimeout)
 		businessTrips.Get("/:id/summary", businessTripHandler.GetBusinessTripSummary)
 
 		// Legacy assignee route
@@ -121,17 +210,28 @@ func SetupRoutes(app *fiber.App, transactionHandler *handler.TransactionHandler,
 		// Legacy transaction routes
 		assignees := api.Group("/assignees")
 		assignees.Use(middleware.AuthMiddleware()) // Apply auth middleware to legacy assignees
+		assignees.Use(defaultRateLimit)
+		assignees.Use(businessTripTimeout)
 		assignees.Post("/:assigneeId/transactions", businessTripHandler.AddTransaction)
 		assignees.Get("/:id/summary", businessTripHandler.GetAssigneeSummary)
 	}
 
 	// Vaccine routes
 	api.Route("/v1/vaccine", func(r fiber.Router) {
+		r.Use(defaultRateLimit)
 		r.Get("/master-vaccines", vaccineHandler.ListMasterVaccines)
+		r.Post("/import", middleware.AuthMiddleware(), vaccineHandler.ImportMasterVaccines)
 		r.Get("/countries", vaccineHandler.ListCountries)
-		r.Get("/recommendations/:countryCode", vaccineHandler.GetVaccineRecommendations)
+		r.Get("/recommendations/history", middleware.AuthMiddleware(), vaccineHandler.ListRecommendationHistory)
+		r.Get("/recommendations/:countryCode", cdcRateLimit, vaccineHandler.GetVaccineRecommendations)
+		r.Post("/recommendations/:countryCode/history", middleware.AuthMiddleware(), cdcRateLimit, vaccineHandler.RecordVaccineRecommendation)
+		r.Get("/aliases/:kind", middleware.AuthMiddleware(), vaccineHandler.ListAliases)
+		r.Put("/aliases/:kind", middleware.AuthMiddleware(), vaccineHandler.UpsertAlias)
 	})
 
+	// Feature flags
+	api.Get("/v1/flags", middleware.AuthMiddleware(), defaultRateLimit, featureFlagHandler.GetFlags)
+
 	// Health check
 	api.Get("/health", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{
@@ -142,5 +242,5 @@ func SetupRoutes(app *fiber.App, transactionHandler *handler.TransactionHandler,
 
 // Backward compatibility function (deprecated)
 func SetupRoutesLegacy(app *fiber.App, transactionHandler *handler.TransactionHandler, meetingHandler *handler.MeetingHandler, businessTripHandler *handler.BusinessTripHandler, assigneeHandler *handler.AssigneeHandler, businessTripTransactionHandler *handler.BusinessTripTransactionHandler, masterLakipItemHandler *deskHandler.WorkPaperItemHandler, paperWorkHandler *deskHandler.WorkPaperHandler) {
-	SetupRoutes(app, transactionHandler, meetingHandler, businessTripHandler, assigneeHandler, businessTripTransactionHandler, masterLakipItemHandler, paperWorkHandler, nil, nil, nil, nil)
+	SetupRoutes(app, transactionHandler, meetingHandler, businessTripHandler, assigneeHandler, businessTripTransactionHandler, masterLakipItemHandler, paperWorkHandler, nil, nil, nil, nil, nil, RouteTimeouts{}, RouteRateLimits{})
 }
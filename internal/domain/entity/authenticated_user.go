@@ -4,6 +4,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// RoleAdmin is the name of the administrator role
+const RoleAdmin = "admin"
+
 // Role represents a user role
 type Role struct {
 	ID   string `json:"id"`
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"crypto/sha256"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -40,6 +41,9 @@ type BusinessTrip struct {
 	ReturnDate         time.Time          `db:"return_date"`
 	Status             BusinessTripStatus `db:"status"`
 	DocumentLink       sql.NullString     `db:"document_link"`
+	MeetingID          sql.NullString     `db:"meeting_id"`
+	OrganizationID     sql.NullString     `db:"organization_id"`
+	Version            int                `db:"version"`
 	Assignees          []*Assignee        `db:"-"`
 	Verificators       []*Verificator     `db:"-"`
 	CreatedAt          time.Time          `db:"created_at"`
@@ -84,6 +88,16 @@ const (
 	TransactionSubtypeOther          TransactionSubtype = "other"
 )
 
+// TransactionDirection tells whether a transaction adds to the cost of a trip or offsets it
+type TransactionDirection string
+
+const (
+	// TransactionDirectionDebit is a cost paid for the trip
+	TransactionDirectionDebit TransactionDirection = "debit"
+	// TransactionDirectionCredit is money returned for a cost, e.g. the refund of a cancelled hotel booking
+	TransactionDirectionCredit TransactionDirection = "credit"
+)
+
 // Transaction represents a transaction for an assignee
 type Transaction struct {
 	ID              string             `db:"id"`
@@ -93,26 +107,35 @@ type Transaction struct {
 	Subtype         TransactionSubtype `db:"subtype"`
 	Amount          float64            `db:"amount"`
 	TotalNight      *int               `db:"total_night"`
+	TotalDays       *int               `db:"total_days"`
 	Subtotal        float64            `db:"subtotal"`
 	Description     string             `db:"description"`
 	TransportDetail string             `db:"transport_detail"`
 	CreatedAt       time.Time          `db:"created_at"`
 	UpdatedAt       time.Time          `db:"updated_at"`
+
+	// Direction is debit for a cost and credit for a refund. Amount and Subtotal are never negative;
+	// a credit's subtotal is subtracted from the totals instead.
+	Direction TransactionDirection `db:"direction"`
+
+	// Currency is the ISO 4217 code Amount was paid in and ExchangeRate converts it to the base
+	// currency, which Subtotal is always in
+	Currency     string  `db:"currency"`
+	ExchangeRate float64 `db:"exchange_rate"`
+
+	// Allocations splits the subtotal across cost centers. Nil means the transaction has not
+	// been loaded with (or, on update, should keep) its existing allocations.
+	Allocations []*TransactionAllocation `db:"-"`
+
+	// Attachments are the receipt files uploaded for the transaction
+	Attachments []*TransactionAttachment `db:"-"`
 }
 
 // NewBusinessTrip creates a new business trip with validation
 func NewBusinessTrip(startDate, endDate, spdDate, departureDate, returnDate time.Time, activityPurpose, destinationCity string) (*BusinessTrip, error) {
 	// Validation
-	if startDate.After(endDate) {
-		return nil, errors.New("start date must be before or equal to end date")
-	}
-
-	if departureDate.After(returnDate) {
-		return nil, errors.New("departure date must be before or equal to return date")
-	}
-
-	if spdDate.After(departureDate) {
-		return nil, errors.New("SPD date must be before or equal to departure date")
+	if err := validateDateOrder(startDate, endDate, spdDate, departureDate, returnDate); err != nil {
+		return nil, err
 	}
 
 	if strings.TrimSpace(activityPurpose) == "" {
@@ -135,12 +158,171 @@ func NewBusinessTrip(startDate, endDate, spdDate, departureDate, returnDate time
 		DestinationCity:    strings.TrimSpace(destinationCity),
 		Status:             BusinessTripStatusDraft,
 		DocumentLink:       sql.NullString{},
+		Version:            1,
 		Assignees:          make([]*Assignee, 0),
 		CreatedAt:          time.Now(),
 		UpdatedAt:          time.Now(),
 	}, nil
 }
 
+// ValidateDateOrder checks the ordering invariants between the trip dates.
+// It must hold after every change, not only at creation.
+func (bt *BusinessTrip) ValidateDateOrder() error {
+	return validateDateOrder(bt.StartDate, bt.EndDate, bt.SPDDate, bt.DepartureDate, bt.ReturnDate)
+}
+
+// DefaultMaxTripDays is the default cap on the number of days from departure to return
+const DefaultMaxTripDays = 365
+
+// DurationDays is the number of days from departure to return, counting both days
+func (bt *BusinessTrip) DurationDays() int {
+	return int(bt.ReturnDate.Sub(bt.DepartureDate)/(24*time.Hour)) + 1
+}
+
+// ValidateDuration rejects trips lasting more than maxDays from departure to return, which usually
+// points to a mistyped year. A maxDays of 0 or less disables the check.
+func (bt *BusinessTrip) ValidateDuration(maxDays int) error {
+	if maxDays <= 0 {
+		return nil
+	}
+	if days := bt.DurationDays(); days > maxDays {
+		return fmt.Errorf("%w: the trip lasts %d days from departure to return, the maximum is %d days", ErrTripTooLong, days, maxDays)
+	}
+	return nil
+}
+
+// DefaultMaxVerificators is the default cap on the number of verificators of a business trip
+const DefaultMaxVerificators = 10
+
+// ETag returns a weak entity tag for the trip as it is returned by the API. It covers the trip and the
+// IDs and update times of its assignees, transactions and verificators, so editing, adding or removing
+// any of them changes the tag.
+func (bt *BusinessTrip) ETag() string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s|%d|%d", bt.ID, bt.Version, bt.UpdatedAt.UnixNano())
+	for _, assignee := range bt.Assignees {
+		fmt.Fprintf(hash, "|a:%s:%d", assignee.ID, assignee.UpdatedAt.UnixNano())
+		for _, transaction := range assignee.Transactions {
+			fmt.Fprintf(hash, "|t:%s:%d", transaction.ID, transaction.UpdatedAt.UnixNano())
+		}
+	}
+	for _, verificator := range bt.Verificators {
+		fmt.Fprintf(hash, "|v:%s:%d", verificator.ID, verificator.UpdatedAt.UnixNano())
+	}
+	return fmt.Sprintf(`W/"%x"`, hash.Sum(nil)[:16])
+}
+
+func validateDateOrder(startDate, endDate, spdDate, departureDate, returnDate time.Time) error {
+	if startDate.After(endDate) {
+		return fmt.Errorf("%w: start date must be before or equal to end date", ErrDateOrderViolation)
+	}
+
+	if departureDate.After(returnDate) {
+		return fmt.Errorf("%w: departure date must be before or equal to return date", ErrDateOrderViolation)
+	}
+
+	if spdDate.After(departureDate) {
+		return fmt.Errorf("%w: SPD date must be before or equal to departure date", ErrDateOrderViolation)
+	}
+
+	return nil
+}
+
+// CloneAsDraft deep-copies the trip, its assignees, transactions and verificators into a new draft with
+// fresh IDs. The trip number and document link are cleared and verificators start over as pending.
+func (bt *BusinessTrip) CloneAsDraft() *BusinessTrip {
+	now := time.Now()
+
+	clone := &BusinessTrip{
+		ID:              uuid.NewString(),
+		StartDate:       bt.StartDate,
+		EndDate:         bt.EndDate,
+		ActivityPurpose: bt.ActivityPurpose,
+		DestinationCity: bt.DestinationCity,
+		SPDDate:         bt.SPDDate,
+		DepartureDate:   bt.DepartureDate,
+		ReturnDate:      bt.ReturnDate,
+		Status:          BusinessTripStatusDraft,
+		Version:         1,
+		Assignees:       make([]*Assignee, 0, len(bt.Assignees)),
+		Verificators:    make([]*Verificator, 0, len(bt.Verificators)),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	for _, assignee := range bt.Assignees {
+		assigneeClone := &Assignee{
+			ID:             uuid.NewString(),
+			BusinessTripID: clone.ID,
+			Name:           assignee.Name,
+			SPDNumber:      assignee.SPDNumber,
+			EmployeeID:     assignee.EmployeeID,
+			EmployeeName:   assignee.EmployeeName,
+			EmployeeNumber: assignee.EmployeeNumber,
+			Position:       assignee.Position,
+			Rank:           assignee.Rank,
+			Transactions:   make([]*Transaction, 0, len(assignee.Transactions)),
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+
+		for _, transaction := range assignee.Transactions {
+			transactionClone := *transaction
+			transactionClone.ID = uuid.NewString()
+			transactionClone.AssigneeID = assigneeClone.ID
+			transactionClone.CreatedAt = now
+			transactionClone.UpdatedAt = now
+			transactionClone.Allocations = nil
+			// Receipts belong to the original trip's expenses and are not carried over
+			transactionClone.Attachments = nil
+			for _, allocation := range transaction.Allocations {
+				transactionClone.Allocations = append(transactionClone.Allocations, &TransactionAllocation{
+					TransactionID: transactionClone.ID,
+					CostCenter:    allocation.CostCenter,
+					Percentage:    allocation.Percentage,
+				})
+			}
+			assigneeClone.Transactions = append(assigneeClone.Transactions, &transactionClone)
+		}
+
+		clone.Assignees = append(clone.Assignees, assigneeClone)
+	}
+
+	for _, verificator := range bt.Verificators {
+		clone.Verificators = append(clone.Verificators, &Verificator{
+			ID:             uuid.NewString(),
+			BusinessTripID: clone.ID,
+			UserID:         verificator.UserID,
+			UserName:       verificator.UserName,
+			EmployeeNumber: verificator.EmployeeNumber,
+			Position:       verificator.Position,
+			Status:         VerificatorStatusPending,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		})
+	}
+
+	return clone
+}
+
+// Reschedule moves the trip to a new start date, shifting the SPD, departure and return dates by the same
+// amount so the itinerary keeps its shape. A non-nil endDate then replaces the shifted end date.
+func (bt *BusinessTrip) Reschedule(startDate, endDate *time.Time) error {
+	if startDate != nil {
+		offset := startDate.Sub(bt.StartDate)
+		bt.StartDate = *startDate
+		bt.EndDate = bt.EndDate.Add(offset)
+		bt.SPDDate = bt.SPDDate.Add(offset)
+		bt.DepartureDate = bt.DepartureDate.Add(offset)
+		bt.ReturnDate = bt.ReturnDate.Add(offset)
+	}
+	if endDate != nil {
+		bt.EndDate = *endDate
+	}
+
+	return bt.ValidateDateOrder()
+}
+
 func (bt *BusinessTrip) AddAssignee(name, spdNumber, employeeID, employeeName, employeeNumber, position, rank string) (*Assignee, error) {
 	// Validation
 	if strings.TrimSpace(name) == "" {
@@ -166,7 +348,7 @@ func (bt *BusinessTrip) AddAssignee(name, spdNumber, employeeID, employeeName, e
 	// Check if SPD number already exists for this business trip
 	for _, assignee := range bt.Assignees {
 		if assignee.SPDNumber == spdNumber {
-			return nil, fmt.Errorf("SPD number %s already exists for this business trip", spdNumber)
+			return nil, fmt.Errorf("%w: %s already exists for this business trip", ErrDuplicateSPDNumber, spdNumber)
 		}
 	}
 
@@ -189,8 +371,9 @@ func (bt *BusinessTrip) AddAssignee(name, spdNumber, employeeID, employeeName, e
 	return assignee, nil
 }
 
-// AddVerificator adds a verificator to the business trip
-func (bt *BusinessTrip) AddVerificator(userID, userName, employeeNumber, position string) (*Verificator, error) {
+// AddVerificator adds a verificator to the business trip, up to maxVerificators of them, which protects
+// the approval flow from a misbehaving integration. A maxVerificators of 0 or less disables the cap.
+func (bt *BusinessTrip) AddVerificator(userID, userName, employeeNumber, position string, maxVerificators int) (*Verificator, error) {
 	// Validation
 	if strings.TrimSpace(userID) == "" {
 		return nil, errors.New("verificator user ID is required")
@@ -215,6 +398,10 @@ func (bt *BusinessTrip) AddVerificator(userID, userName, employeeNumber, positio
 		}
 	}
 
+	if maxVerificators > 0 && len(bt.Verificators) >= maxVerificators {
+		return nil, fmt.Errorf("%w: the maximum is %d", ErrTooManyVerificators, maxVerificators)
+	}
+
 	verificator := &Verificator{
 		ID:                uuid.New().String(),
 		BusinessTripID:    bt.ID,
@@ -319,8 +506,9 @@ func (bt *BusinessTrip) GetRejectedVerificatorsCount() int {
 	return count
 }
 
-// NewTransaction creates a new transaction with validation
-func NewTransaction(name string, txType TransactionType, subtype TransactionSubtype, amount, subtotal float64, totalNight *int, description, transportDetail string) (*Transaction, error) {
+// NewTransaction creates a new transaction with validation. The amount is held to the cap of its type in
+// amountCaps unless allowLargeAmount is set for a legitimately large entry.
+func NewTransaction(name string, txType TransactionType, subtype TransactionSubtype, direction TransactionDirection, amount, subtotal float64, totalNight, totalDays *int, description, transportDetail string, amountCaps TransactionAmountCaps, allowLargeAmount bool) (*Transaction, error) {
 	// Validation
 	if strings.TrimSpace(name) == "" {
 		return nil, errors.New("transaction name is required")
@@ -330,10 +518,25 @@ func NewTransaction(name string, txType TransactionType, subtype TransactionSubt
 		return nil, fmt.Errorf("invalid transaction type: %s", txType)
 	}
 
+	if err := ValidateTransactionSubtype(txType, subtype); err != nil {
+		return nil, err
+	}
+
+	direction, err := NormalizeTransactionDirection(direction, subtype)
+	if err != nil {
+		return nil, err
+	}
+
 	if amount < 0 {
 		return nil, errors.New("amount must be non-negative")
 	}
 
+	if !allowLargeAmount {
+		if err := amountCaps.Check(txType, amount); err != nil {
+			return nil, err
+		}
+	}
+
 	if subtotal < 0 {
 		return nil, errors.New("subtotal must be non-negative")
 	}
@@ -342,9 +545,19 @@ func NewTransaction(name string, txType TransactionType, subtype TransactionSubt
 		return nil, errors.New("total night must be non-negative")
 	}
 
+	if totalDays != nil && *totalDays < 0 {
+		return nil, errors.New("total days must be non-negative")
+	}
+
+	if totalNight != nil && totalDays != nil {
+		return nil, errors.New("total night and total days cannot both be set")
+	}
+
 	// Calculate subtotal if not provided
 	if txType == TransactionTypeAccommodation && totalNight != nil && *totalNight > 0 {
 		subtotal = amount * float64(*totalNight)
+	} else if subtype == TransactionSubtypeDailyAllowance && totalDays != nil {
+		subtotal = amount * float64(*totalDays)
 	} else {
 		subtotal = amount
 	}
@@ -355,16 +568,48 @@ func NewTransaction(name string, txType TransactionType, subtype TransactionSubt
 		Subtype:         subtype,
 		Amount:          amount,
 		TotalNight:      totalNight,
+		TotalDays:       totalDays,
 		Subtotal:        subtotal,
 		Description:     strings.TrimSpace(description),
 		TransportDetail: strings.TrimSpace(transportDetail),
 		CreatedAt:       time.Now(),
 		UpdatedAt:       time.Now(),
+		Direction:       direction,
+		ExchangeRate:    1,
 	}
 
 	return transaction, nil
 }
 
+// NormalizeTransactionDirection defaults an empty direction to debit and checks that a credit names the
+// subtype of the cost it offsets, so a refund can be matched against the cost it reduces
+func NormalizeTransactionDirection(direction TransactionDirection, subtype TransactionSubtype) (TransactionDirection, error) {
+	switch direction {
+	case "", TransactionDirectionDebit:
+		return TransactionDirectionDebit, nil
+	case TransactionDirectionCredit:
+		if subtype == "" {
+			return "", ErrCreditWithoutCostKind
+		}
+		return TransactionDirectionCredit, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrInvalidTransactionDirection, direction)
+	}
+}
+
+// IsCredit returns true if the transaction is a refund that reduces the trip cost
+func (t *Transaction) IsCredit() bool {
+	return t.Direction == TransactionDirectionCredit
+}
+
+// SignedSubtotal returns the subtotal as it counts towards the totals: negative for a credit
+func (t *Transaction) SignedSubtotal() float64 {
+	if t.IsCredit() {
+		return -t.Subtotal
+	}
+	return t.Subtotal
+}
+
 // AddTransaction adds a transaction to an assignee
 func (a *Assignee) AddTransaction(transaction *Transaction) error {
 	if transaction == nil {
@@ -386,15 +631,35 @@ func (bt *BusinessTrip) GetTotalCost() float64 {
 	return total
 }
 
-// GetTotalCost calculates the total cost for an assignee
+// GetTotalCost calculates the total cost for an assignee; credits reduce it
 func (a *Assignee) GetTotalCost() float64 {
 	var total float64
 	for _, transaction := range a.Transactions {
-		total += transaction.Subtotal
+		total += transaction.SignedSubtotal()
 	}
 	return total
 }
 
+// ValidateCredits checks that the credits of every kind of cost (type and subtype) do not exceed the
+// debits of that kind, so a refund always offsets a cost the assignee actually had
+func (a *Assignee) ValidateCredits() error {
+	type costKind struct {
+		txType  TransactionType
+		subtype TransactionSubtype
+	}
+	balances := make(map[costKind]float64)
+	for _, transaction := range a.Transactions {
+		balances[costKind{transaction.Type, transaction.Subtype}] += transaction.SignedSubtotal()
+	}
+	for kind, balance := range balances {
+		// Amounts have two decimals; ignore rounding noise of the float sum
+		if balance < -0.005 {
+			return fmt.Errorf("%w: %s (%s)", ErrCreditExceedsCost, kind.txType, kind.subtype)
+		}
+	}
+	return nil
+}
+
 // GetTransactionsByType returns transactions filtered by type
 func (a *Assignee) GetTransactionsByType(txType TransactionType) []*Transaction {
 	var transactions []*Transaction
@@ -424,11 +689,60 @@ func (t *Transaction) IsAllowance() bool {
 // CalculateTotal calculates the total for the transaction
 func (t *Transaction) CalculateTotal() float64 {
 	if t.TotalNight != nil && *t.TotalNight > 0 {
-		return t.Amount * float64(*t.TotalNight)
+		return t.BaseAmount() * float64(*t.TotalNight)
+	}
+	if t.Subtype == TransactionSubtypeDailyAllowance && t.TotalDays != nil {
+		return t.BaseAmount() * float64(*t.TotalDays)
 	}
 	return t.Subtotal
 }
 
+// transactionSubtypesByType lists the subtypes each transaction type accepts
+var transactionSubtypesByType = map[TransactionType][]TransactionSubtype{
+	TransactionTypeAccommodation: {TransactionSubtypeHotel},
+	TransactionTypeTransport:     {TransactionSubtypeFlight, TransactionSubtypeTrain, TransactionSubtypeTaxi, TransactionSubtypeRentalCar},
+	TransactionTypeAllowance:     {TransactionSubtypeDailyAllowance, TransactionSubtypeMeal},
+	TransactionTypeOther:         {TransactionSubtypeOther},
+}
+
+// ValidateTransactionSubtype checks that subtype belongs to txType. Every type but allowance may leave the
+// subtype empty; an allowance needs one because daily allowances are prorated and meals are not.
+func ValidateTransactionSubtype(txType TransactionType, subtype TransactionSubtype) error {
+	subtypes, ok := transactionSubtypesByType[txType]
+	if !ok {
+		return fmt.Errorf("invalid transaction type: %s", txType)
+	}
+	if subtype == "" {
+		if txType == TransactionTypeAllowance {
+			return fmt.Errorf("%w: %s requires a subtype, one of %s", ErrIncompatibleSubtype, txType, joinSubtypes(subtypes))
+		}
+		return nil
+	}
+	for _, allowed := range subtypes {
+		if subtype == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s is not a subtype of %s, use one of %s", ErrIncompatibleSubtype, subtype, txType, joinSubtypes(subtypes))
+}
+
+// ValidateSubtypeChange is ValidateTransactionSubtype for an edit of the transaction. An allowance stored
+// without a subtype, from before allowances needed one, may keep its empty subtype.
+func (t *Transaction) ValidateSubtypeChange(txType TransactionType, subtype TransactionSubtype) error {
+	if txType == TransactionTypeAllowance && subtype == "" && t.Type == TransactionTypeAllowance && t.Subtype == "" {
+		return nil
+	}
+	return ValidateTransactionSubtype(txType, subtype)
+}
+
+func joinSubtypes(subtypes []TransactionSubtype) string {
+	names := make([]string, 0, len(subtypes))
+	for _, subtype := range subtypes {
+		names = append(names, string(subtype))
+	}
+	return strings.Join(names, ", ")
+}
+
 // isValidTransactionType checks if the transaction type is valid
 func isValidTransactionType(txType TransactionType) bool {
 	switch txType {
@@ -481,6 +795,12 @@ func (bt *BusinessTrip) CanTransitionTo(targetStatus BusinessTripStatus) bool {
 	}
 }
 
+// IsLocked reports whether the business trip is completed or canceled, after which its assignees
+// and transactions must no longer change
+func (bt *BusinessTrip) IsLocked() bool {
+	return bt.Status == BusinessTripStatusCompleted || bt.Status == BusinessTripStatusCanceled
+}
+
 // UpdateStatus updates the business trip status with validation
 func (bt *BusinessTrip) UpdateStatus(newStatus BusinessTripStatus) error {
 	if !bt.CanTransitionTo(newStatus) {
@@ -533,12 +853,30 @@ func (bt *BusinessTrip) GetSPDDate() time.Time         { return bt.SPDDate }
 func (bt *BusinessTrip) GetDepartureDate() time.Time   { return bt.DepartureDate }
 func (bt *BusinessTrip) GetReturnDate() time.Time      { return bt.ReturnDate }
 func (bt *BusinessTrip) GetStatus() BusinessTripStatus { return bt.Status }
+func (bt *BusinessTrip) GetVersion() int               { return bt.Version }
 func (bt *BusinessTrip) GetDocumentLink() string {
 	if bt.DocumentLink.Valid {
 		return bt.DocumentLink.String
 	}
 	return ""
 }
+
+// GetOrganizationID returns the organization of the user who created the trip, or "" for trips created
+// before it was recorded
+func (bt *BusinessTrip) GetOrganizationID() string {
+	if bt.OrganizationID.Valid {
+		return bt.OrganizationID.String
+	}
+	return ""
+}
+
+// GetMeetingID returns the ID of the meeting the trip is made to attend, or "" when it is not linked
+func (bt *BusinessTrip) GetMeetingID() string {
+	if bt.MeetingID.Valid {
+		return bt.MeetingID.String
+	}
+	return ""
+}
 func (bt *BusinessTrip) GetAssignees() []*Assignee { return bt.Assignees }
 
 // GetVerificators returns the list of verificators
@@ -562,9 +900,22 @@ func (t *Transaction) GetType() TransactionType       { return t.Type }
 func (t *Transaction) GetSubtype() TransactionSubtype { return t.Subtype }
 func (t *Transaction) GetAmount() float64             { return t.Amount }
 func (t *Transaction) GetTotalNight() *int            { return t.TotalNight }
+func (t *Transaction) GetTotalDays() *int             { return t.TotalDays }
 func (t *Transaction) GetSubtotal() float64           { return t.Subtotal }
 func (t *Transaction) GetDescription() string         { return t.Description }
 func (t *Transaction) GetTransportDetail() string     { return t.TransportDetail }
+func (t *Transaction) GetDirection() TransactionDirection {
+	if t.Direction == "" {
+		return TransactionDirectionDebit
+	}
+	return t.Direction
+}
+
+// GetAllocations returns the cost center splits of the transaction
+func (t *Transaction) GetAllocations() []*TransactionAllocation { return t.Allocations }
+
+// GetAttachments returns the receipt files attached to the transaction
+func (t *Transaction) GetAttachments() []*TransactionAttachment { return t.Attachments }
 
 // VerificatorStatus represents verification status
 type VerificatorStatus string
@@ -727,3 +1078,11 @@ type VerificatorWithBusinessTrip struct {
 	BusinessTripStatus          BusinessTripStatus `db:"business_trip_status"`
 	BusinessTripDocumentLink    sql.NullString     `db:"document_link"`
 }
+
+// EmployeeBusinessTrip is a business trip an employee was assigned to, with the assignee that matched
+type EmployeeBusinessTrip struct {
+	BusinessTrip
+	AssigneeID   string `db:"assignee_id"`
+	AssigneeName string `db:"assignee_name"`
+	SPDNumber    string `db:"spd_number"`
+}
@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultBaseCurrency is the currency trip costs are reported in unless another one is configured
+const DefaultBaseCurrency = "IDR"
+
+// supportedCurrencies are the ISO 4217 codes transactions can be recorded in
+var supportedCurrencies = map[string]bool{
+	"AED": true, "AUD": true, "BND": true, "CAD": true, "CHF": true, "CNY": true, "EGP": true,
+	"EUR": true, "GBP": true, "HKD": true, "IDR": true, "INR": true, "JPY": true, "KRW": true,
+	"MYR": true, "NZD": true, "PHP": true, "QAR": true, "SAR": true, "SGD": true, "THB": true,
+	"TRY": true, "TWD": true, "USD": true, "VND": true,
+}
+
+// IsSupportedCurrency reports whether code is a supported ISO 4217 currency code
+func IsSupportedCurrency(code string) bool {
+	return supportedCurrencies[code]
+}
+
+// NormalizeCurrency trims and upper-cases a currency code; an empty code stays empty
+func NormalizeCurrency(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// SetCurrency records the currency the amount was paid in and its exchange rate to baseCurrency, and
+// recalculates the subtotal in the base currency. An empty currency means the base currency, whose
+// rate is always 1; any other currency needs a positive rate.
+func (t *Transaction) SetCurrency(currency string, exchangeRate float64, baseCurrency string) error {
+	currency = NormalizeCurrency(currency)
+	if currency == "" {
+		currency = baseCurrency
+	}
+	if !IsSupportedCurrency(currency) {
+		return fmt.Errorf("%w: %s", ErrUnsupportedCurrency, currency)
+	}
+
+	if currency == baseCurrency {
+		if exchangeRate != 0 && exchangeRate != 1 {
+			return fmt.Errorf("%w: the rate of the base currency %s is always 1", ErrInvalidExchangeRate, baseCurrency)
+		}
+		exchangeRate = 1
+	} else if exchangeRate <= 0 {
+		return fmt.Errorf("%w: a positive rate from %s to %s is required", ErrInvalidExchangeRate, currency, baseCurrency)
+	}
+
+	t.Currency = currency
+	t.ExchangeRate = exchangeRate
+	t.Subtotal = t.CalculateSubtotal()
+	return nil
+}
+
+// GetExchangeRate returns the rate from the transaction currency to the base currency; transactions
+// that were never given a rate are in the base currency
+func (t *Transaction) GetExchangeRate() float64 {
+	if t.ExchangeRate <= 0 {
+		return 1
+	}
+	return t.ExchangeRate
+}
+
+// BaseAmount returns the amount converted to the base currency
+func (t *Transaction) BaseAmount() float64 {
+	return t.Amount * t.GetExchangeRate()
+}
+
+// CalculateSubtotal calculates the subtotal in the base currency. Accommodation is charged per
+// night and daily allowances are prorated by the number of days.
+func (t *Transaction) CalculateSubtotal() float64 {
+	if t.Type == TransactionTypeAccommodation && t.TotalNight != nil && *t.TotalNight > 0 {
+		return t.BaseAmount() * float64(*t.TotalNight)
+	}
+	if t.Subtype == TransactionSubtypeDailyAllowance && t.TotalDays != nil {
+		return t.BaseAmount() * float64(*t.TotalDays)
+	}
+	return t.BaseAmount()
+}
@@ -15,6 +15,11 @@ type DigitalSignature struct {
 	Verified          bool       `json:"verified" db:"verified"`
 	VerifiedAt        *time.Time `json:"verified_at" db:"verified_at"`
 	VerificationError string     `json:"verification_error,omitempty" db:"verification_error"`
+
+	// RFC 3161 trusted timestamp, only present when a timestamp authority is configured
+	TimestampToken     string     `json:"timestamp_token,omitempty" db:"timestamp_token"`
+	TimestampAuthority string     `json:"timestamp_authority,omitempty" db:"timestamp_authority"`
+	TrustedTime        *time.Time `json:"trusted_time,omitempty" db:"trusted_time"`
 }
 
 // DigitalSignatureVerification represents verification request/response
@@ -51,6 +56,18 @@ func NewDigitalSignature(signature, payload, algorithm, publicKeyID, certificate
 	}
 }
 
+// AttachTimestamp stores a trusted timestamp token issued by a timestamp authority
+func (ds *DigitalSignature) AttachTimestamp(token, authority string, trustedTime time.Time) {
+	ds.TimestampToken = token
+	ds.TimestampAuthority = authority
+	ds.TrustedTime = &trustedTime
+}
+
+// HasTrustedTimestamp returns true if the signature carries a timestamp token
+func (ds *DigitalSignature) HasTrustedTimestamp() bool {
+	return ds.TimestampToken != ""
+}
+
 // MarkVerified marks the signature as verified
 func (ds *DigitalSignature) MarkVerified() {
 	now := time.Now().UTC()
@@ -6,15 +6,52 @@ var (
 	ErrBusinessTripNotFound = errors.New("business trip not found")
 	ErrAssigneeNotFound     = errors.New("assignee not found")
 	ErrTransactionNotFound  = errors.New("transaction not found")
+	ErrInvalidAllocation    = errors.New("invalid transaction allocation")
+	ErrAttachmentNotFound   = errors.New("transaction attachment not found")
+	ErrInvalidAttachment    = errors.New("invalid transaction attachment")
 	ErrInvalidDateRange     = errors.New("invalid date range")
+	ErrDateOrderViolation   = errors.New("business trip date order violated")
+	ErrStaleBusinessTrip    = errors.New("business trip was modified by another update")
+	ErrUnknownEmployee      = errors.New("employee not found in the user service")
+	ErrVerificatorsRequired = errors.New("business trip needs at least one verificator before it can be submitted for verification")
+	ErrVerificatorNotFound  = errors.New("verificator not found")
+	ErrNotTripVerificator   = errors.New("user is not a verificator of the business trip")
+	ErrVerificatorNotOnTrip = errors.New("verificator does not belong to the business trip")
+	ErrAlreadyVerified      = errors.New("verificator has already responded to the business trip")
+	ErrTripNotReadyToVerify = errors.New("business trip is not ready to verify")
 	ErrDuplicateSPDNumber   = errors.New("duplicate SPD number")
 	ErrUnauthorizedAccess   = errors.New("unauthorized access")
+	ErrBusinessTripLocked   = errors.New("business trip is completed or canceled and can no longer be changed")
+	ErrAssigneeOnSameTrip   = errors.New("assignee already belongs to the business trip")
+	ErrTripTooLong          = errors.New("business trip exceeds the maximum duration")
+	ErrTooManyVerificators  = errors.New("business trip exceeds the maximum number of verificators")
+	ErrTripNotCompleted     = errors.New("business trip is not completed yet")
+	ErrTripNotApproved      = errors.New("business trip is missing verificator approvals")
+	ErrAssigneeDetailsBlank = errors.New("assignee position and rank are required when the identity service does not provide them")
+	ErrMeetingNotFound      = errors.New("meeting not found")
+
+	// Transaction errors
+	ErrInvalidTransactionDirection = errors.New("invalid transaction direction, must be debit or credit")
+	ErrCreditWithoutCostKind       = errors.New("credit transaction must name the subtype of the cost it offsets")
+	ErrCreditExceedsCost           = errors.New("credit transaction exceeds the costs of the same kind it offsets")
+	ErrUnsupportedCurrency         = errors.New("unsupported transaction currency")
+	ErrInvalidExchangeRate         = errors.New("invalid transaction exchange rate")
+	ErrIncompatibleSubtype         = errors.New("transaction subtype does not belong to the transaction type")
+	ErrAmountExceedsCap            = errors.New("transaction amount exceeds the maximum for its type")
+	ErrNightsAndDaysBothSet        = errors.New("total night and total days cannot both be set")
+
+	// Organization policy errors
+	ErrTransactionTypeNotAllowed = errors.New("transaction type is not allowed for this organization")
 
 	// Desk module errors
 	ErrWorkPaperItemNotFound          = errors.New("work paper item not found")
 	ErrOrganizationNotFound           = errors.New("organization not found")
 	ErrWorkPaperNotFound              = errors.New("work paper not found")
 	ErrWorkPaperNoteNotFound          = errors.New("work paper note not found")
+	ErrWorkPaperNotDraft              = errors.New("work paper is no longer a draft")
+	ErrUnsupportedLLMModel            = errors.New("unsupported LLM model")
+	ErrInvalidLLMTemperature          = errors.New("invalid LLM temperature")
+	ErrTokenBudgetExceeded            = errors.New("monthly LLM token budget exceeded")
 	ErrDuplicateWorkPaper             = errors.New("duplicate work paper for organization, year, and semester")
 	ErrInvalidSemester                = errors.New("invalid semester, must be 1 or 2")
 	ErrInvalidYear                    = errors.New("invalid year")
@@ -24,6 +61,8 @@ var (
 	ErrWorkPaperItemNumberRequired    = errors.New("work paper item number is required")
 	ErrWorkPaperItemStatementRequired = errors.New("work paper item statement is required")
 	ErrInvalidWorkPaperItemType       = errors.New("invalid work paper item type, must be A, B, or C")
+	ErrWorkPaperItemHierarchyCycle    = errors.New("work paper item cannot be moved under itself or one of its descendants")
+	ErrDuplicateWorkPaperItemPosition = errors.New("work paper item appears more than once in the new order")
 	ErrOrganizationIDRequired         = errors.New("organization ID is required")
 	ErrWorkPaperIDRequired            = errors.New("work paper ID is required")
 	ErrMasterItemIDRequired           = errors.New("master item ID is required")
@@ -34,9 +73,15 @@ var (
 	ErrSignatureNotFound              = errors.New("signature not found")
 	ErrAlreadySigned                  = errors.New("signature already signed")
 	ErrSignatureRejected              = errors.New("signature already rejected")
+	ErrInvalidSignatureTransition     = errors.New("invalid signature status transition")
 	ErrDuplicateSignature             = errors.New("signature already exists for this user and work paper")
 	ErrDigitalSignatureRequired       = errors.New("digital signature is required")
 	ErrInvalidDigitalSignature        = errors.New("digital signature is invalid or not verified")
+	ErrSignaturesIncomplete           = errors.New("work paper has outstanding or rejected signatures")
+	ErrInsufficientSigners            = errors.New("work paper does not have the minimum number of signers")
+	ErrSignedSignatureRemoval         = errors.New("operation would remove a signature that is already signed")
+	ErrVersionConflict                = errors.New("record was modified by another update")
+	ErrVaccineCodeTaken               = errors.New("vaccine code is already used by another vaccine")
 
 	// Backward compatibility aliases (deprecated)
 	ErrMasterLakipItemNotFound          = ErrWorkPaperItemNotFound
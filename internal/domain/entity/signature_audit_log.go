@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SignatureAuditOutcome constants describe what a signer management action did to one signer
+const (
+	SignatureAuditOutcomeAdded     = "added"
+	SignatureAuditOutcomeRemoved   = "removed"
+	SignatureAuditOutcomePreserved = "preserved"
+)
+
+// SignatureAuditLog records the effect of one add, remove or replace action on a single signer
+type SignatureAuditLog struct {
+	ID           uuid.UUID  `db:"id"`
+	WorkPaperID  uuid.UUID  `db:"work_paper_id"`
+	SignatureID  *uuid.UUID `db:"signature_id"` // Nullable
+	SignerUserID string     `db:"signer_user_id"`
+	Action       string     `db:"action"`
+	Outcome      string     `db:"outcome"`
+	ActorID      string     `db:"actor_id"`
+	ActorName    string     `db:"actor_name"`
+	CreatedAt    time.Time  `db:"created_at"`
+}
+
+// NewSignatureAuditLog creates an audit entry for the given signature
+func NewSignatureAuditLog(signature *WorkPaperSignature, action, outcome, actorID, actorName string) *SignatureAuditLog {
+	signatureID := signature.ID
+
+	return &SignatureAuditLog{
+		ID:           uuid.New(),
+		WorkPaperID:  signature.WorkPaperID,
+		SignatureID:  &signatureID,
+		SignerUserID: signature.UserID,
+		Action:       action,
+		Outcome:      outcome,
+		ActorID:      actorID,
+		ActorName:    actorName,
+		CreatedAt:    time.Now(),
+	}
+}
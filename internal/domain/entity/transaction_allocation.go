@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// allocationTolerance absorbs floating point rounding when checking that splits add up to 100%
+const allocationTolerance = 0.01
+
+// TransactionAllocation assigns a percentage of a transaction's cost to a cost center
+type TransactionAllocation struct {
+	ID            string    `db:"id"`
+	TransactionID string    `db:"transaction_id"`
+	CostCenter    string    `db:"cost_center"`
+	Percentage    float64   `db:"percentage"`
+	CreatedAt     time.Time `db:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"`
+}
+
+// NewTransactionAllocation creates a new allocation split with validation
+func NewTransactionAllocation(costCenter string, percentage float64) (*TransactionAllocation, error) {
+	costCenter = strings.TrimSpace(costCenter)
+	if costCenter == "" {
+		return nil, fmt.Errorf("%w: cost center is required", ErrInvalidAllocation)
+	}
+
+	if percentage <= 0 || percentage > 100 {
+		return nil, fmt.Errorf("%w: percentage for cost center %s must be greater than 0 and at most 100", ErrInvalidAllocation, costCenter)
+	}
+
+	return &TransactionAllocation{
+		CostCenter: costCenter,
+		Percentage: percentage,
+	}, nil
+}
+
+// AllocatedAmount returns the share of subtotal that belongs to this allocation
+func (a *TransactionAllocation) AllocatedAmount(subtotal float64) float64 {
+	return subtotal * a.Percentage / 100
+}
+
+// ValidateAllocations checks that every cost center appears once and that the splits sum to 100%.
+// An empty list is valid and means the transaction is not allocated.
+func ValidateAllocations(allocations []*TransactionAllocation) error {
+	if len(allocations) == 0 {
+		return nil
+	}
+
+	var total float64
+	seen := make(map[string]bool, len(allocations))
+	for _, allocation := range allocations {
+		if seen[allocation.CostCenter] {
+			return fmt.Errorf("%w: duplicate cost center %s", ErrInvalidAllocation, allocation.CostCenter)
+		}
+		seen[allocation.CostCenter] = true
+		total += allocation.Percentage
+	}
+
+	if math.Abs(total-100) > allocationTolerance {
+		return fmt.Errorf("%w: percentages must sum to 100, got %.2f", ErrInvalidAllocation, total)
+	}
+
+	return nil
+}
+
+// SetAllocations validates and replaces the allocation splits of the transaction
+func (t *Transaction) SetAllocations(allocations []*TransactionAllocation) error {
+	if err := ValidateAllocations(allocations); err != nil {
+		return err
+	}
+
+	for _, allocation := range allocations {
+		allocation.TransactionID = t.ID
+	}
+	if allocations == nil {
+		allocations = []*TransactionAllocation{}
+	}
+	t.Allocations = allocations
+	return nil
+}
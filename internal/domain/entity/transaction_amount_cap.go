@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"fmt"
+)
+
+// TransactionTypes are all the transaction types, in the order they are listed to users
+var TransactionTypes = []TransactionType{
+	TransactionTypeAccommodation,
+	TransactionTypeTransport,
+	TransactionTypeOther,
+	TransactionTypeAllowance,
+}
+
+// TransactionAmountCaps is the largest amount, as entered, a transaction of each type may have. It is
+// meant to catch typos such as an extra zero. A type without a cap, or with a cap of zero, is not limited.
+type TransactionAmountCaps map[TransactionType]float64
+
+// Check rejects an amount above the cap of its transaction type; the cap itself is allowed
+func (c TransactionAmountCaps) Check(txType TransactionType, amount float64) error {
+	maxAmount := c[txType]
+	if maxAmount <= 0 || amount <= maxAmount {
+		return nil
+	}
+	return fmt.Errorf("%w: %.2f is more than the %.2f allowed for %s transactions, check the amount or ask an administrator to enter it",
+		ErrAmountExceedsCap, amount, maxAmount, txType)
+}
@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxAttachmentSize is the largest receipt file accepted for a transaction
+const MaxAttachmentSize = 10 << 20
+
+// allowedAttachmentContentTypes lists the receipt formats that can be attached to a transaction
+var allowedAttachmentContentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/webp":      true,
+}
+
+// TransactionAttachment is an uploaded receipt file kept with a transaction for audit
+type TransactionAttachment struct {
+	ID            string    `db:"id"`
+	TransactionID string    `db:"transaction_id"`
+	FileName      string    `db:"file_name"`
+	ContentType   string    `db:"content_type"`
+	FileID        string    `db:"file_id"`
+	StorageURL    string    `db:"storage_url"`
+	UploadedAt    time.Time `db:"uploaded_at"`
+}
+
+// ValidateAttachmentFile checks the name, content type and size of a receipt before it is uploaded
+func ValidateAttachmentFile(fileName, contentType string, size int) error {
+	if strings.TrimSpace(fileName) == "" {
+		return fmt.Errorf("%w: file name is required", ErrInvalidAttachment)
+	}
+	if !allowedAttachmentContentTypes[contentType] {
+		return fmt.Errorf("%w: content type %q of %s is not allowed, use PDF, JPEG, PNG or WebP", ErrInvalidAttachment, contentType, fileName)
+	}
+	if size == 0 {
+		return fmt.Errorf("%w: %s is empty", ErrInvalidAttachment, fileName)
+	}
+	if size > MaxAttachmentSize {
+		return fmt.Errorf("%w: %s exceeds the maximum size of %d MB", ErrInvalidAttachment, fileName, MaxAttachmentSize>>20)
+	}
+	return nil
+}
+
+// NewTransactionAttachment creates the record of a receipt stored under fileID
+func NewTransactionAttachment(transactionID, fileName, contentType, fileID, storageURL string) (*TransactionAttachment, error) {
+	if strings.TrimSpace(transactionID) == "" {
+		return nil, fmt.Errorf("%w: transaction ID is required", ErrInvalidAttachment)
+	}
+	if strings.TrimSpace(fileID) == "" {
+		return nil, fmt.Errorf("%w: file ID is required", ErrInvalidAttachment)
+	}
+
+	return &TransactionAttachment{
+		TransactionID: transactionID,
+		FileName:      fileName,
+		ContentType:   contentType,
+		FileID:        fileID,
+		StorageURL:    storageURL,
+		UploadedAt:    time.Now(),
+	}, nil
+}
@@ -0,0 +1,17 @@
+package entity
+
+// AllowedTransactionType is a transaction type an organization permits on its business trips.
+// An empty Subtype allows every subtype of Type.
+type AllowedTransactionType struct {
+	OrganizationID string             `db:"organization_id"`
+	Type           TransactionType    `db:"transaction_type"`
+	Subtype        TransactionSubtype `db:"transaction_subtype"`
+}
+
+// Allows reports whether a transaction of the given type and subtype matches this entry
+func (a *AllowedTransactionType) Allows(txType TransactionType, subtype TransactionSubtype) bool {
+	if a.Type != txType {
+		return false
+	}
+	return a.Subtype == "" || a.Subtype == subtype
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// VaccineRecommendationHistory records a vaccine recommendation as it was served, optionally for the
+// business trip or assignee it was looked up for
+type VaccineRecommendationHistory struct {
+	ID                    string
+	CountryCode           string
+	CountryName           string
+	Language              string
+	BusinessTripID        *string // Nullable
+	AssigneeID            *string // Nullable
+	RequiredVaccineIDs    []string
+	RecommendedVaccineIDs []string
+	ConsiderVaccineIDs    []string
+	MalariaRisk           string
+	MalariaProphylaxis    string
+	// GeneratedAt is when the recommendation was fetched from the CDC website
+	GeneratedAt time.Time
+	// CreatedAt is when the recommendation was served
+	CreatedAt time.Time
+}
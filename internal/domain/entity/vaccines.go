@@ -58,20 +58,53 @@ type CountryVaccineRequirement struct {
 	CreatedAt       time.Time       `db:"created_at"`
 }
 
+// CountryAlias maps a lowercase country code or name to the full country name used in countries
+type CountryAlias struct {
+	Alias       string    `db:"alias"`
+	CountryName string    `db:"country_name"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// VaccineAlias maps a lowercase vaccine name to the vaccine name used in master_vaccines
+type VaccineAlias struct {
+	Alias       string    `db:"alias"`
+	VaccineName string    `db:"vaccine_name"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// CDCRecommendationCache is a vaccine recommendation extracted from the CDC website for a country and language
+type CDCRecommendationCache struct {
+	CountryCode    string    `db:"country_code"`
+	Language       string    `db:"language"`
+	Recommendation []byte    `db:"recommendation"`
+	FetchedAt      time.Time `db:"fetched_at"`
+}
+
 // NewMasterVaccine creates a new master vaccine with validation
 func NewMasterVaccine(vaccineCode, vaccineNameID, vaccineNameEN string, vaccineType VaccineType, descriptionID, descriptionEN *string) (*MasterVaccine, error) {
 	// Validation
 	if strings.TrimSpace(vaccineCode) == "" {
 		return nil, errors.New("vaccine code is required")
 	}
+	if len(strings.TrimSpace(vaccineCode)) > 20 {
+		return nil, errors.New("vaccine code must be at most 20 characters")
+	}
 
 	if strings.TrimSpace(vaccineNameID) == "" {
 		return nil, errors.New("vaccine name (Indonesian) is required")
 	}
+	if len(strings.TrimSpace(vaccineNameID)) > 255 {
+		return nil, errors.New("vaccine name (Indonesian) must be at most 255 characters")
+	}
 
 	if strings.TrimSpace(vaccineNameEN) == "" {
 		return nil, errors.New("vaccine name (English) is required")
 	}
+	if len(strings.TrimSpace(vaccineNameEN)) > 255 {
+		return nil, errors.New("vaccine name (English) must be at most 255 characters")
+	}
 
 	if !isValidVaccineType(vaccineType) {
 		return nil, errors.New("invalid vaccine type")
@@ -248,16 +281,35 @@ func (mv *MasterVaccine) GetDescription(language string) *string {
 	}
 }
 
+// Display name languages reported by Country.ResolveDisplayName
+const (
+	DisplayLanguageEnglish    = "en"
+	DisplayLanguageIndonesian = "id"
+	// DisplayLanguageCode means no translation was available and the country code is shown instead
+	DisplayLanguageCode = "code"
+)
+
 // GetDisplayName returns the display name based on language preference
 func (c *Country) GetDisplayName(language string) string {
+	name, _ := c.ResolveDisplayName(language)
+	return name
+}
+
+// ResolveDisplayName returns the name in the requested language, falling back to English when that
+// translation is missing or the language is not supported, and then to the country code. The second
+// value is the language the name is actually in.
+func (c *Country) ResolveDisplayName(language string) (string, string) {
 	switch strings.ToLower(language) {
 	case "id", "indonesia":
-		return c.CountryNameID
-	case "en", "english":
-		fallthrough
-	default:
-		return c.CountryNameEN
+		if name := strings.TrimSpace(c.CountryNameID); name != "" {
+			return name, DisplayLanguageIndonesian
+		}
 	}
+
+	if name := strings.TrimSpace(c.CountryNameEN); name != "" {
+		return name, DisplayLanguageEnglish
+	}
+	return c.CountryCode, DisplayLanguageCode
 }
 
 // IsTravelVaccine checks if vaccine is for travel purposes
@@ -287,6 +339,57 @@ func (cvr *CountryVaccineRequirement) IsRecommended() bool {
 
 // Helper functions
 
+// NewCountryAlias creates a new country alias with validation
+func NewCountryAlias(alias, countryName string) (*CountryAlias, error) {
+	alias = normalizeAlias(alias)
+	if alias == "" {
+		return nil, errors.New("alias is required")
+	}
+	if len(alias) > 100 {
+		return nil, errors.New("alias must be at most 100 characters")
+	}
+
+	if strings.TrimSpace(countryName) == "" {
+		return nil, errors.New("country name is required")
+	}
+
+	now := time.Now()
+	return &CountryAlias{
+		Alias:       alias,
+		CountryName: strings.TrimSpace(countryName),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// NewVaccineAlias creates a new vaccine alias with validation
+func NewVaccineAlias(alias, vaccineName string) (*VaccineAlias, error) {
+	alias = normalizeAlias(alias)
+	if alias == "" {
+		return nil, errors.New("alias is required")
+	}
+	if len(alias) > 100 {
+		return nil, errors.New("alias must be at most 100 characters")
+	}
+
+	if strings.TrimSpace(vaccineName) == "" {
+		return nil, errors.New("vaccine name is required")
+	}
+
+	now := time.Now()
+	return &VaccineAlias{
+		Alias:       alias,
+		VaccineName: strings.TrimSpace(vaccineName),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// normalizeAlias lowercases and trims an alias so lookups are case-insensitive
+func normalizeAlias(alias string) string {
+	return strings.ToLower(strings.TrimSpace(alias))
+}
+
 // trimStringPtr trims whitespace from string pointer
 func trimStringPtr(s *string) *string {
 	if s == nil {
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// VerificatorStatusHistory records one status change of a verificator, e.g. from pending to approved
+type VerificatorStatusHistory struct {
+	ID            string            `db:"id"`
+	VerificatorID string            `db:"verificator_id"`
+	FromStatus    VerificatorStatus `db:"from_status"`
+	ToStatus      VerificatorStatus `db:"to_status"`
+	Notes         string            `db:"notes"`
+	ChangedBy     *string           `db:"changed_by"` // Nullable, user ID of whoever made the change
+	ChangedAt     time.Time         `db:"changed_at"`
+}
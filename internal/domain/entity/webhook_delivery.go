@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookEventBusinessTripStatusChanged is sent when a business trip is completed or canceled
+const WebhookEventBusinessTripStatusChanged = "business_trip.status_changed"
+
+// WebhookDelivery records one attempt to deliver a webhook event to a receiver
+type WebhookDelivery struct {
+	ID             uuid.UUID `db:"id"`
+	EventID        uuid.UUID `db:"event_id"`
+	EventType      string    `db:"event_type"`
+	BusinessTripID string    `db:"business_trip_id"`
+	URL            string    `db:"url"`
+	Payload        string    `db:"payload"`
+	Attempt        int       `db:"attempt"`
+	StatusCode     *int      `db:"status_code"` // Nullable, no response was received
+	Error          string    `db:"error"`
+	Succeeded      bool      `db:"succeeded"`
+	CreatedAt      time.Time `db:"created_at"`
+}
@@ -13,6 +13,7 @@ type WorkPaper struct {
 	Year           int        `db:"year"`
 	Semester       int        `db:"semester"` // 1 or 2
 	Status         string     `db:"status"`   // draft, ongoing, ready_to_sign, completed
+	Version        int        `db:"version"`  // optimistic locking token, incremented on every update
 	CreatedAt      time.Time  `db:"created_at"`
 	UpdatedAt      time.Time  `db:"updated_at"`
 	DeletedAt      *time.Time `db:"deleted_at"`
@@ -52,6 +53,7 @@ func NewWorkPaper(organizationID uuid.UUID, year, semester int) (*WorkPaper, err
 		Year:           year,
 		Semester:       semester,
 		Status:         WorkPaperStatusDraft,
+		Version:        1,
 		CreatedAt:      now,
 		UpdatedAt:      now,
 	}, nil
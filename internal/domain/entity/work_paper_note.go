@@ -17,6 +17,7 @@ type WorkPaperNote struct {
 	IsValid         *bool        `db:"is_valid"`          // Nullable Y/T result from LLM
 	Notes           *string      `db:"notes"`             // Nullable notes from LLM
 	LastLLMResponse *LLMResponse `db:"last_llm_response"` // Nullable raw LLM response
+	Version         int          `db:"version"`           // Optimistic locking token, incremented on every update
 	CreatedAt       time.Time    `db:"created_at"`
 	UpdatedAt       time.Time    `db:"updated_at"`
 	DeletedAt       *time.Time   `db:"deleted_at"`
@@ -29,8 +30,16 @@ type WorkPaperNote struct {
 type LLMResponse struct {
 	Note    string `json:"note"`
 	IsValid bool   `json:"isValid"`
-	Model   string `json:"model,omitempty"`
-	Usage   *Usage `json:"usage,omitempty"`
+	// Confidence is the model's confidence in the verdict from 0 to 1, or nil when it gave none
+	Confidence *float64 `json:"confidence"`
+	// MissingItems lists the requirements the model found unmet by the documents
+	MissingItems []string `json:"missingItems,omitempty"`
+	Model        string   `json:"model,omitempty"`
+	Usage        *Usage   `json:"usage,omitempty"`
+	// GDriveLink is the Google Drive link the checked documents were read from
+	GDriveLink string `json:"gdriveLink,omitempty"`
+	// Cached is set when the verdict was reused from an earlier check of the same documents and item
+	Cached bool `json:"cached,omitempty"`
 }
 
 // Usage represents token usage from LLM API
@@ -75,11 +84,27 @@ func NewWorkPaperNote(workPaperID, masterItemID uuid.UUID) (*WorkPaperNote, erro
 		ID:           uuid.New(),
 		WorkPaperID:  workPaperID,
 		MasterItemID: masterItemID,
+		Version:      1,
 		CreatedAt:    now,
 		UpdatedAt:    now,
 	}, nil
 }
 
+// LinkChangedSinceCheck reports whether the note has never been checked or its Google Drive link differs
+// from the one its last check read. Checks recorded before the link was kept count as changed.
+func (wpn *WorkPaperNote) LinkChangedSinceCheck() bool {
+	if wpn.LastLLMResponse == nil || wpn.LastLLMResponse.GDriveLink == "" {
+		return true
+	}
+	return wpn.LastLLMResponse.GDriveLink != wpn.GetGDriveLink()
+}
+
+// IsMasterItemActive reports whether the master item of the note is loaded and still active.
+// Notes whose item was deactivated or deleted after the paper was created return false.
+func (wpn *WorkPaperNote) IsMasterItemActive() bool {
+	return wpn.MasterItem != nil && wpn.MasterItem.IsActive && wpn.MasterItem.DeletedAt == nil
+}
+
 // UpdateGDriveLink updates the Google Drive link
 func (wpn *WorkPaperNote) UpdateGDriveLink(link string) {
 	if link == "" {
@@ -142,3 +167,67 @@ func (wpn *WorkPaperNote) GetLLMResponse() LLMResponse {
 	}
 	return *wpn.LastLLMResponse
 }
+
+// WorkPaperNoteCheckHistory represents a single LLM check performed on a work paper note
+type WorkPaperNoteCheckHistory struct {
+	ID               uuid.UUID `db:"id" json:"id"`
+	WorkPaperNoteID  uuid.UUID `db:"work_paper_note_id" json:"work_paper_note_id"`
+	IsValid          bool      `db:"is_valid" json:"is_valid"`
+	Notes            *string   `db:"notes" json:"notes"`
+	Model            *string   `db:"model" json:"model"`
+	PromptTokens     int       `db:"prompt_tokens" json:"prompt_tokens"`
+	CompletionTokens int       `db:"completion_tokens" json:"completion_tokens"`
+	TotalTokens      int       `db:"total_tokens" json:"total_tokens"`
+	CheckedAt        time.Time `db:"checked_at" json:"checked_at"`
+}
+
+// NewWorkPaperNoteCheckHistory creates a history entry from an LLM response
+func NewWorkPaperNoteCheckHistory(noteID uuid.UUID, response LLMResponse) *WorkPaperNoteCheckHistory {
+	history := &WorkPaperNoteCheckHistory{
+		ID:              uuid.New(),
+		WorkPaperNoteID: noteID,
+		IsValid:         response.IsValid,
+		CheckedAt:       time.Now(),
+	}
+	if response.Note != "" {
+		history.Notes = &response.Note
+	}
+	if response.Model != "" {
+		history.Model = &response.Model
+	}
+	if response.Usage != nil {
+		history.PromptTokens = response.Usage.PromptTokens
+		history.CompletionTokens = response.Usage.CompletionTokens
+		history.TotalTokens = response.Usage.TotalTokens
+	}
+	return history
+}
+
+// LLMVerdict is a cached LLM verdict for a set of documents checked against a work paper item
+type LLMVerdict struct {
+	DocumentHash string    `db:"document_hash"`
+	ItemHash     string    `db:"item_hash"`
+	IsValid      bool      `db:"is_valid"`
+	Notes        string    `db:"notes"`
+	Confidence   *float64  `db:"confidence"`
+	MissingItems []string  `db:"-"`
+	Model        string    `db:"model"`
+	CreatedAt    time.Time `db:"created_at"`
+	ExpiresAt    time.Time `db:"expires_at"`
+}
+
+// NewLLMVerdict creates a cached verdict that is reused until ttl has passed
+func NewLLMVerdict(documentHash, itemHash string, isValid bool, notes string, confidence *float64, missingItems []string, model string, ttl time.Duration) *LLMVerdict {
+	now := time.Now()
+	return &LLMVerdict{
+		DocumentHash: documentHash,
+		ItemHash:     itemHash,
+		IsValid:      isValid,
+		Notes:        notes,
+		Confidence:   confidence,
+		MissingItems: missingItems,
+		Model:        model,
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(ttl),
+	}
+}
@@ -3,6 +3,7 @@ package entity
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -137,14 +138,44 @@ func (wps *WorkPaperSignature) AddSignatureData(data SignatureData) {
 	wps.UpdatedAt = time.Now()
 }
 
-// Sign signs work paper
-func (wps *WorkPaperSignature) Sign(notes string) error {
-	if wps.Status == SignatureStatusSigned {
-		return ErrAlreadySigned
+// signatureTransitions lists the statuses each signature status can move to. A signed signature is final;
+// a rejected one has to be reset to pending before it can be signed again. Resetting a pending signature
+// is the only transition that keeps the status.
+var signatureTransitions = map[string][]string{
+	SignatureStatusPending:  {SignatureStatusPending, SignatureStatusSigned, SignatureStatusRejected},
+	SignatureStatusRejected: {SignatureStatusPending},
+	SignatureStatusSigned:   {},
+}
+
+// CanTransitionTo checks if the signature can move from its current status to targetStatus
+func (wps *WorkPaperSignature) CanTransitionTo(targetStatus string) bool {
+	return wps.ValidateTransition(targetStatus) == nil
+}
+
+// ValidateTransition returns nil when the signature can move to targetStatus. Otherwise it returns
+// ErrAlreadySigned for a signed signature, ErrSignatureRejected when signing or rejecting a rejected one,
+// and ErrInvalidSignatureTransition for any other illegal move.
+func (wps *WorkPaperSignature) ValidateTransition(targetStatus string) error {
+	for _, allowed := range signatureTransitions[wps.Status] {
+		if allowed == targetStatus {
+			return nil
+		}
 	}
 
-	if wps.Status == SignatureStatusRejected {
+	switch {
+	case wps.Status == SignatureStatusSigned:
+		return ErrAlreadySigned
+	case wps.Status == SignatureStatusRejected && (targetStatus == SignatureStatusSigned || targetStatus == SignatureStatusRejected):
 		return ErrSignatureRejected
+	default:
+		return fmt.Errorf("%w: from %q to %q", ErrInvalidSignatureTransition, wps.Status, targetStatus)
+	}
+}
+
+// Sign signs work paper
+func (wps *WorkPaperSignature) Sign(notes string) error {
+	if err := wps.ValidateTransition(SignatureStatusSigned); err != nil {
+		return err
 	}
 
 	now := time.Now()
@@ -163,8 +194,8 @@ func (wps *WorkPaperSignature) Sign(notes string) error {
 
 // Reject rejects work paper signature
 func (wps *WorkPaperSignature) Reject(notes string) error {
-	if wps.Status == SignatureStatusSigned {
-		return ErrAlreadySigned
+	if err := wps.ValidateTransition(SignatureStatusRejected); err != nil {
+		return err
 	}
 
 	wps.Status = SignatureStatusRejected
@@ -180,6 +211,10 @@ func (wps *WorkPaperSignature) Reject(notes string) error {
 
 // Reset resets signature to pending status
 func (wps *WorkPaperSignature) Reset() error {
+	if err := wps.ValidateTransition(SignatureStatusPending); err != nil {
+		return err
+	}
+
 	wps.Status = SignatureStatusPending
 	wps.SignedAt = nil
 	wps.Notes = nil
@@ -283,6 +318,11 @@ func (wps *WorkPaperSignature) SignWithDigitalSignature(digitalSig *DigitalSigna
 		return ErrInvalidDigitalSignature
 	}
 
+	// Check the transition before the digital signature is attached
+	if err := wps.ValidateTransition(SignatureStatusSigned); err != nil {
+		return err
+	}
+
 	// Add digital signature data
 	err := wps.AddDigitalSignature(digitalSig)
 	if err != nil {
@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWorkPaperSignatureTransitions(t *testing.T) {
+	tests := []struct {
+		from    string
+		to      string
+		wantErr error
+	}{
+		{SignatureStatusPending, SignatureStatusPending, nil},
+		{SignatureStatusPending, SignatureStatusSigned, nil},
+		{SignatureStatusPending, SignatureStatusRejected, nil},
+		{SignatureStatusRejected, SignatureStatusPending, nil},
+		{SignatureStatusRejected, SignatureStatusSigned, ErrSignatureRejected},
+		{SignatureStatusRejected, SignatureStatusRejected, ErrSignatureRejected},
+		{SignatureStatusSigned, SignatureStatusPending, ErrAlreadySigned},
+		{SignatureStatusSigned, SignatureStatusSigned, ErrAlreadySigned},
+		{SignatureStatusSigned, SignatureStatusRejected, ErrAlreadySigned},
+		{SignatureStatusPending, "archived", ErrInvalidSignatureTransition},
+		{"archived", SignatureStatusPending, ErrInvalidSignatureTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+"_to_"+tt.to, func(t *testing.T) {
+			signature := &WorkPaperSignature{Status: tt.from}
+
+			err := signature.ValidateTransition(tt.to)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil) != (err == nil) {
+				t.Fatalf("ValidateTransition(%q) error = %v, want %v", tt.to, err, tt.wantErr)
+			}
+			if got := signature.CanTransitionTo(tt.to); got != (tt.wantErr == nil) {
+				t.Errorf("CanTransitionTo(%q) = %v, want %v", tt.to, got, tt.wantErr == nil)
+			}
+		})
+	}
+}
+
+func TestWorkPaperSignatureActionsUseTransitionGate(t *testing.T) {
+	statuses := []string{SignatureStatusPending, SignatureStatusSigned, SignatureStatusRejected}
+	actions := []struct {
+		name   string
+		target string
+		apply  func(*WorkPaperSignature) error
+	}{
+		{"sign", SignatureStatusSigned, func(s *WorkPaperSignature) error { return s.Sign("") }},
+		{"reject", SignatureStatusRejected, func(s *WorkPaperSignature) error { return s.Reject("") }},
+		{"reset", SignatureStatusPending, func(s *WorkPaperSignature) error { return s.Reset() }},
+	}
+
+	for _, from := range statuses {
+		for _, action := range actions {
+			t.Run(action.name+"_"+from, func(t *testing.T) {
+				signature := &WorkPaperSignature{Status: from}
+				wantErr := (&WorkPaperSignature{Status: from}).ValidateTransition(action.target)
+
+				err := action.apply(signature)
+				if !errors.Is(err, wantErr) || (wantErr == nil) != (err == nil) {
+					t.Fatalf("%s from %s error = %v, want %v", action.name, from, err, wantErr)
+				}
+
+				wantStatus := action.target
+				if wantErr != nil {
+					wantStatus = from
+				}
+				if signature.Status != wantStatus {
+					t.Errorf("%s from %s left status %s, want %s", action.name, from, signature.Status, wantStatus)
+				}
+			})
+		}
+	}
+}
+
+func TestSignWithDigitalSignatureLeavesSignedSignatureUntouched(t *testing.T) {
+	signature := &WorkPaperSignature{Status: SignatureStatusSigned}
+	digitalSignature := &DigitalSignature{}
+	digitalSignature.MarkVerified()
+
+	if err := signature.SignWithDigitalSignature(digitalSignature, ""); !errors.Is(err, ErrAlreadySigned) {
+		t.Fatalf("SignWithDigitalSignature() error = %v, want ErrAlreadySigned", err)
+	}
+	if signature.GetDigitalSignature() != nil {
+		t.Error("digital signature attached to an already signed signature")
+	}
+}
@@ -13,10 +13,15 @@ type AssigneeRepository interface {
 	Create(ctx context.Context, assignee *entity.Assignee) (*entity.Assignee, error)
 	GetAssigneeByID(ctx context.Context, id string) (*entity.Assignee, error)
 	UpdateAssignee(ctx context.Context, assignee *entity.Assignee) (*entity.Assignee, error)
+	// DeleteAssignee soft deletes the assignee and its transactions
 	DeleteAssignee(ctx context.Context, id string) error
+	// RestoreAssignee restores a soft deleted assignee of the business trip and the transactions deleted with it
+	RestoreAssignee(ctx context.Context, businessTripID, id string) error
 	GetAssigneesByBusinessTripID(ctx context.Context, businessTripID string) ([]*entity.Assignee, error)
 	GetAssigneesByBusinessTripIDWithoutTransactions(ctx context.Context, businessTripID string) ([]*entity.Assignee, error)
 	DeleteAssigneesByBusinessTripID(ctx context.Context, businessTripID string) error
+	// MoveToBusinessTrip moves the assignee, with its transactions, to another business trip
+	MoveToBusinessTrip(ctx context.Context, assignee *entity.Assignee, businessTripID string) error
 
 	// Dashboard operations
 	GetTotalCount(ctx context.Context, startDate, endDate *time.Time) (int64, error)
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"sandbox/internal/domain/entity"
@@ -12,11 +13,12 @@ import (
 
 // StatusCounts represents count of business trips by status
 type StatusCounts struct {
-	Total     int64 `json:"total"`
-	Draft     int64 `json:"draft"`
-	Ongoing   int64 `json:"ongoing"`
-	Completed int64 `json:"completed"`
-	Canceled  int64 `json:"canceled"`
+	Total         int64 `json:"total"`
+	Draft         int64 `json:"draft"`
+	ReadyToVerify int64 `json:"ready_to_verify"`
+	Ongoing       int64 `json:"ongoing"`
+	Completed     int64 `json:"completed"`
+	Canceled      int64 `json:"canceled"`
 }
 
 // MonthlyData represents monthly statistics data
@@ -38,6 +40,21 @@ type DestinationData struct {
 	LastTripDate   time.Time `json:"last_trip_date"`
 }
 
+// DestinationSuggestion is a destination city with the number of business trips to it
+type DestinationSuggestion struct {
+	Destination string `json:"destination" db:"destination"`
+	TripCount   int64  `json:"trip_count" db:"trip_count"`
+}
+
+// RankCostData represents the trip spend of the assignees of one rank
+type RankCostData struct {
+	Rank                   string  `json:"rank" db:"rank"`
+	TotalCost              float64 `json:"total_cost" db:"total_cost"`
+	TripCount              int64   `json:"trip_count" db:"trip_count"`
+	AssigneeCount          int64   `json:"assignee_count" db:"assignee_count"`
+	AverageCostPerAssignee float64 `json:"average_cost_per_assignee"`
+}
+
 // RecentBusinessTripData represents recent business trip with summary
 type RecentBusinessTripData struct {
 	ID                 uuid.UUID                 `json:"id"`
@@ -59,20 +76,51 @@ type TransactionTypeData struct {
 	AverageAmount     float64 `json:"average_amount" db:"average_amount"`
 }
 
+// TransactionTypeCost represents the number and total cost of a business trip's transactions of one type
+type TransactionTypeCost struct {
+	TransactionType  string  `json:"transaction_type" db:"transaction_type"`
+	TransactionCount int64   `json:"transaction_count" db:"transaction_count"`
+	TotalAmount      float64 `json:"total_amount" db:"total_amount"`
+}
+
+// CostCenterAllocationData represents the transaction cost allocated to a cost center
+type CostCenterAllocationData struct {
+	CostCenter       string  `json:"cost_center" db:"cost_center"`
+	TransactionCount int64   `json:"transaction_count" db:"transaction_count"`
+	AllocatedAmount  float64 `json:"allocated_amount" db:"allocated_amount"`
+}
+
+// BusinessTripListSummary holds the assignee count and total cost of a business trip for list views
+type BusinessTripListSummary struct {
+	BusinessTripID string  `json:"business_trip_id" db:"business_trip_id"`
+	AssigneeCount  int     `json:"assignee_count" db:"assignee_count"`
+	TotalCost      float64 `json:"total_cost" db:"total_cost"`
+}
+
 // BusinessTripRepository defines the interface for business trip data operations
 type BusinessTripRepository interface {
 	// Business Trip operations
 	Create(ctx context.Context, bt *entity.BusinessTrip) (*entity.BusinessTrip, error)
+	PeekNextBusinessTripNumber(ctx context.Context, year int) (string, error)
 	GetByID(ctx context.Context, id string) (*entity.BusinessTrip, error)
+	// LockByID locks the business trip row until the transaction of WithTransaction ends
+	LockByID(ctx context.Context, id string) error
 	Update(ctx context.Context, bt *entity.BusinessTrip) (*entity.BusinessTrip, error)
 	Delete(ctx context.Context, id string) error
 	List(ctx context.Context, params *pagination.QueryParams) ([]*entity.BusinessTrip, int64, error)
+	FindTripsByEmployeeNumber(ctx context.Context, employeeNumber string, params *pagination.QueryParams) ([]*entity.EmployeeBusinessTrip, int64, error)
+	GetListSummaries(ctx context.Context, businessTripIDs []string) (map[string]*BusinessTripListSummary, error)
+	StreamAll(ctx context.Context, startDate, endDate *time.Time, fn func(*entity.BusinessTrip) error) error
+	ListDistinctDestinations(ctx context.Context, prefix string, limit int) ([]*DestinationSuggestion, error)
+	// SetMeeting links the business trip to a meeting, or unlinks it when meetingID is not valid
+	SetMeeting(ctx context.Context, id string, meetingID sql.NullString) error
 
 	// Dashboard operations
 	GetStatusCounts(ctx context.Context, startDate, endDate *time.Time, destination string) (*StatusCounts, error)
 	GetTotalCost(ctx context.Context, startDate, endDate *time.Time, destination string) (float64, error)
 	GetMonthlyStats(ctx context.Context, startDate, endDate time.Time, destination string) ([]*MonthlyData, error)
 	GetDestinationStats(ctx context.Context, startDate, endDate *time.Time, destination string) ([]*DestinationData, error)
+	GetCostByRank(ctx context.Context, startDate, endDate *time.Time, destination string) ([]*RankCostData, error)
 	GetUpcomingCount(ctx context.Context) (int64, error)
 	GetRecentWithSummary(ctx context.Context, limit int) ([]*RecentBusinessTripData, error)
 
@@ -89,14 +137,23 @@ type BusinessTripRepository interface {
 	DeleteTransaction(ctx context.Context, id string) error
 	GetTransactionsByAssigneeID(ctx context.Context, assigneeID string) ([]*entity.Transaction, error)
 	DeleteTransactionsByAssigneeIDs(ctx context.Context, assigneeIDs []string) error
+	GetCostBreakdownByTrip(ctx context.Context, businessTripID string) ([]*TransactionTypeCost, error)
 
 	// Verificator operations
 	CreateVerificator(ctx context.Context, verificator *entity.Verificator) (*entity.Verificator, error)
 	GetVerificatorByID(ctx context.Context, id string) (*entity.Verificator, error)
+	// GetVerificatorByIDForUpdate is GetVerificatorByID locking the row until the transaction of WithTransaction ends
+	GetVerificatorByIDForUpdate(ctx context.Context, id string) (*entity.Verificator, error)
 	ListVerificators(ctx context.Context, params *pagination.QueryParams) ([]*entity.VerificatorWithBusinessTrip, int64, error)
 	GetVerificatorsByBusinessTripID(ctx context.Context, businessTripID string) ([]*entity.Verificator, error)
 	GetVerificatorByBusinessTripIDAndUserID(ctx context.Context, businessTripID, userID string) (*entity.Verificator, error)
 	UpdateVerificator(ctx context.Context, verificator *entity.Verificator) (*entity.Verificator, error)
+	// BulkUpdateVerificators sets the status of the verificators that do not have it yet
+	BulkUpdateVerificators(ctx context.Context, ids []string, status entity.VerificatorStatus, notes string, verifiedAt time.Time) (int64, error)
 	DeleteVerificator(ctx context.Context, id string) error
 	DeleteVerificatorsByBusinessTripID(ctx context.Context, businessTripID string) error
+	GetVerificatorsDueForReminder(ctx context.Context, createdBefore, remindedBefore time.Time) ([]*entity.VerificatorWithBusinessTrip, error)
+	MarkVerificatorsReminded(ctx context.Context, ids []string, remindedAt time.Time) (int64, error)
+	CreateVerificatorStatusHistory(ctx context.Context, history []*entity.VerificatorStatusHistory) error
+	GetVerificatorHistory(ctx context.Context, verificatorID string) ([]*entity.VerificatorStatusHistory, error)
 }
@@ -12,12 +12,21 @@ type BusinessTripTransactionRepository interface {
 	// Transaction operations
 	CreateTransaction(ctx context.Context, transaction *entity.Transaction) (*entity.Transaction, error)
 	GetTransactionByID(ctx context.Context, id string) (*entity.Transaction, error)
+	// GetTransactionByIDForUpdate is GetTransactionByID locking the row until the transaction of WithTransaction ends
+	GetTransactionByIDForUpdate(ctx context.Context, id string) (*entity.Transaction, error)
 	UpdateTransaction(ctx context.Context, transaction *entity.Transaction) (*entity.Transaction, error)
+	UpdateTransactionSubtotal(ctx context.Context, id string, subtotal float64) error
 	DeleteTransaction(ctx context.Context, id string) error
 	GetTransactionsByAssigneeID(ctx context.Context, assigneeID string) ([]*entity.Transaction, error)
 	DeleteTransactionsByAssigneeIDs(ctx context.Context, assigneeIDs []string) error
 	GetTotalCount(ctx context.Context, startDate, endDate *time.Time) (int64, error)
 
+	// Attachment operations
+	AddTransactionAttachment(ctx context.Context, attachment *entity.TransactionAttachment) (*entity.TransactionAttachment, error)
+	GetTransactionAttachments(ctx context.Context, transactionID string) ([]*entity.TransactionAttachment, error)
+	DeleteTransactionAttachment(ctx context.Context, transactionID, attachmentID string) error
+
 	// Dashboard operations
 	GetTypeStats(ctx context.Context, startDate, endDate *time.Time) ([]*TransactionTypeData, error)
+	GetCostCenterAllocations(ctx context.Context, startDate, endDate *time.Time) ([]*CostCenterAllocationData, error)
 }
\ No newline at end of file
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"sandbox/internal/domain/entity"
 	"sandbox/pkg/pagination"
@@ -16,6 +17,14 @@ type WorkPaperItemRepository interface {
 	Delete(ctx context.Context, id string) error
 	List(ctx context.Context, params *pagination.QueryParams) ([]*entity.WorkPaperItem, int64, error)
 	ListActive(ctx context.Context) ([]*entity.WorkPaperItem, error)
+	// ListAll returns every work paper item that is not deleted, active or not
+	ListAll(ctx context.Context) ([]*entity.WorkPaperItem, error)
+	// ListAllForUpdate is ListAll locking the items until the transaction of WithTransaction ends
+	ListAllForUpdate(ctx context.Context) ([]*entity.WorkPaperItem, error)
+	// UpdatePositions saves the parent, level and sort order of items in a single transaction and
+	// returns ErrWorkPaperItemNotFound, saving nothing, when one of them no longer exists
+	UpdatePositions(ctx context.Context, items []*entity.WorkPaperItem) error
+	WithTransaction(tx interface{}) WorkPaperItemRepository
 }
 
 // OrganizationRepository defines the interface for organization data operations
@@ -30,8 +39,17 @@ type WorkPaperFilter struct {
 	OrganizationID string `json:"organization_id,omitempty"`
 	Year           int    `json:"year,omitempty"`
 	Semester       int    `json:"semester,omitempty"`
+	// Completion is WorkPaperCompletionComplete or WorkPaperCompletionIncomplete, derived from the notes' Drive links
+	Completion string `json:"completion,omitempty"`
 }
 
+// Work paper completion filters. A work paper is complete when it has notes and every one of them has a
+// Drive link; any other work paper, including one without notes, is incomplete.
+const (
+	WorkPaperCompletionComplete   = "complete"
+	WorkPaperCompletionIncomplete = "incomplete"
+)
+
 // WorkPaperRepository defines the interface for work paper data operations
 type WorkPaperRepository interface {
 	Create(ctx context.Context, wp *entity.WorkPaper) (*entity.WorkPaper, error)
@@ -52,10 +70,44 @@ type WorkPaperNoteRepository interface {
 	GetByWorkPaper(ctx context.Context, workPaperID string) ([]*entity.WorkPaperNote, error)
 	Update(ctx context.Context, note *entity.WorkPaperNote) (*entity.WorkPaperNote, error)
 	Delete(ctx context.Context, id string) error
-	List(ctx context.Context, params interface{}) ([]*entity.WorkPaperNote, int64, error)
+	List(ctx context.Context, params *pagination.QueryParams) ([]*entity.WorkPaperNote, int64, error)
+	// GetStats counts the notes of a work paper by link and validation state in a single query
+	GetStats(ctx context.Context, workPaperID string) (*WorkPaperNoteStats, error)
+	// GetNotesNeedingAttention returns the notes of a work paper without a Drive link or marked invalid,
+	// with their master item, ordered by the master item's sort order
+	GetNotesNeedingAttention(ctx context.Context, workPaperID string) ([]*entity.WorkPaperNote, error)
 	WithTransaction(tx interface{}) WorkPaperNoteRepository
 }
 
+// WorkPaperNoteStats holds aggregate counts over the notes of a work paper
+type WorkPaperNoteStats struct {
+	Total    int `db:"total"`
+	WithLink int `db:"with_link"`
+	Valid    int `db:"valid"`
+	Invalid  int `db:"invalid"`
+}
+
+// WorkPaperNoteCheckHistoryRepository defines the interface for work paper note check history data operations
+type WorkPaperNoteCheckHistoryRepository interface {
+	Create(ctx context.Context, history *entity.WorkPaperNoteCheckHistory) error
+	ListByNoteID(ctx context.Context, noteID string) ([]*entity.WorkPaperNoteCheckHistory, error)
+}
+
+// LLMVerdictCacheRepository defines the interface for cached LLM verdict data operations
+type LLMVerdictCacheRepository interface {
+	// Get returns the unexpired verdict for the document and item hashes, or nil when there is none
+	Get(ctx context.Context, documentHash, itemHash string) (*entity.LLMVerdict, error)
+	Upsert(ctx context.Context, verdict *entity.LLMVerdict) error
+}
+
+// LLMTokenUsageRepository defines the interface for the monthly LLM token usage
+type LLMTokenUsageRepository interface {
+	// GetTotal returns the tokens spent in the month starting at month, or 0 when none were recorded
+	GetTotal(ctx context.Context, month time.Time) (int64, error)
+	// Add atomically adds tokens to the usage of the month starting at month
+	Add(ctx context.Context, month time.Time, tokens int64) error
+}
+
 // Backward compatibility aliases (deprecated)
 type MasterLakipItemRepository = WorkPaperItemRepository
 type PaperWorkRepository = WorkPaperRepository
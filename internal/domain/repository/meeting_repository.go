@@ -7,6 +7,8 @@ import (
 
 type MeetingRepository interface {
 	CreateZoomMeeting(ctx context.Context, meeting *entity.Meeting) (*entity.Meeting, error)
+	// GetZoomMeeting returns entity.ErrMeetingNotFound when Zoom does not know the meeting
+	GetZoomMeeting(ctx context.Context, meetingID string) (*entity.Meeting, error)
 	CreateDriveFolder(ctx context.Context, parentFolderID, folderName string) (string, error)
 	DuplicateAbsenceForm(ctx context.Context, templateID, folderID string) (string, error)
 	SendNotification(ctx context.Context, opts interface{}, meetingURL string) error
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"sandbox/internal/domain/entity"
+)
+
+// SignatureAuditLogRepository stores the audit trail of signer management actions
+type SignatureAuditLogRepository interface {
+	// CreateBatch records the given audit entries
+	CreateBatch(ctx context.Context, entries []*entity.SignatureAuditLog) error
+
+	// WithTransaction returns a repository that writes in the given transaction
+	WithTransaction(tx interface{}) SignatureAuditLogRepository
+}
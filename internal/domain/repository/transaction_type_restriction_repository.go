@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"sandbox/internal/domain/entity"
+)
+
+// TransactionTypeRestrictionRepository loads the per-organization transaction type restrictions
+type TransactionTypeRestrictionRepository interface {
+	// GetAllowedTransactionTypes returns the types the organization allows, or none when it has no restriction
+	GetAllowedTransactionTypes(ctx context.Context, organizationID string) ([]*entity.AllowedTransactionType, error)
+}
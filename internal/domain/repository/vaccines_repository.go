@@ -18,6 +18,9 @@ type VaccinesRepository interface {
 	ListMasterVaccines(ctx context.Context, params *pagination.QueryParams) ([]*entity.MasterVaccine, int64, error)
 	ListActiveMasterVaccines(ctx context.Context) ([]*entity.MasterVaccine, error)
 	ListMasterVaccinesByType(ctx context.Context, vaccineType entity.VaccineType) ([]*entity.MasterVaccine, error)
+	// BulkUpsertMasterVaccines creates or updates the vaccines by English name in a single transaction. A vaccine
+	// whose code belongs to another vaccine is skipped with entity.ErrVaccineCodeTaken in its result.
+	BulkUpsertMasterVaccines(ctx context.Context, vaccines []*entity.MasterVaccine) ([]MasterVaccineUpsertResult, error)
 
 	// Country operations
 	CreateCountry(ctx context.Context, country *entity.Country) (*entity.Country, error)
@@ -27,4 +30,36 @@ type VaccinesRepository interface {
 	DeleteCountry(ctx context.Context, id string) error
 	List(ctx context.Context, params *pagination.QueryParams) ([]*entity.Country, int64, error)
 	ListActiveCountries(ctx context.Context) ([]*entity.Country, error)
+
+	// Alias operations
+	ListCountryAliases(ctx context.Context) ([]*entity.CountryAlias, error)
+	UpsertCountryAlias(ctx context.Context, alias *entity.CountryAlias) (*entity.CountryAlias, error)
+	ListVaccineAliases(ctx context.Context) ([]*entity.VaccineAlias, error)
+	UpsertVaccineAlias(ctx context.Context, alias *entity.VaccineAlias) (*entity.VaccineAlias, error)
+
+	// CDC recommendation cache operations
+	// GetCDCRecommendationCache returns the cached recommendation for the country and language, or nil when there is none
+	GetCDCRecommendationCache(ctx context.Context, countryCode, language string) (*entity.CDCRecommendationCache, error)
+	UpsertCDCRecommendationCache(ctx context.Context, cache *entity.CDCRecommendationCache) error
+
+	// Recommendation history operations
+	CreateVaccineRecommendationHistory(ctx context.Context, history *entity.VaccineRecommendationHistory) error
+	// ListVaccineRecommendationHistory returns the recommendations matching every set filter, latest first
+	ListVaccineRecommendationHistory(ctx context.Context, filter VaccineRecommendationHistoryFilter) ([]*entity.VaccineRecommendationHistory, error)
+}
+
+// MasterVaccineUpsertResult is the outcome of upserting one master vaccine
+type MasterVaccineUpsertResult struct {
+	Vaccine *entity.MasterVaccine
+	// Created is false when a vaccine with the same English name was updated
+	Created bool
+	Err     error
+}
+
+// VaccineRecommendationHistoryFilter narrows the recommendation history; empty fields do not filter
+type VaccineRecommendationHistoryFilter struct {
+	BusinessTripID string
+	AssigneeID     string
+	CountryCode    string
+	Limit          int
 }
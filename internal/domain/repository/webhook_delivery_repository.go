@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"sandbox/internal/domain/entity"
+)
+
+// WebhookDeliveryRepository stores the log of webhook delivery attempts
+type WebhookDeliveryRepository interface {
+	// Create records a single delivery attempt
+	Create(ctx context.Context, delivery *entity.WebhookDelivery) error
+}
@@ -52,6 +52,9 @@ type WorkPaperSignatureRepository interface {
 
 	// GetRecentSignatures gets recent signatures within a date range
 	GetRecentSignatures(ctx context.Context, workPaperID uuid.UUID, from, to time.Time) ([]*entity.WorkPaperSignature, error)
+
+	// WithTransaction returns a repository that runs its queries in the given transaction
+	WithTransaction(tx interface{}) WorkPaperSignatureRepository
 }
 
 // SignatureStats represents signature statistics
@@ -61,3 +64,12 @@ type SignatureStats struct {
 	Signed   int `json:"signed"`
 	Rejected int `json:"rejected"`
 }
+
+// IsComplete reports whether the signatures allow a work paper to be finalized.
+// A work paper without signers is considered complete.
+func (s *SignatureStats) IsComplete() bool {
+	if s.Total == 0 {
+		return true
+	}
+	return s.Pending == 0 && s.Rejected == 0
+}
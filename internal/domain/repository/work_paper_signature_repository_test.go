@@ -0,0 +1,24 @@
+package repository
+
+import "testing"
+
+func TestSignatureStatsIsComplete(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats SignatureStats
+		want  bool
+	}{
+		{name: "no signers", stats: SignatureStats{}, want: true},
+		{name: "all signed", stats: SignatureStats{Total: 2, Signed: 2}, want: true},
+		{name: "pending signer", stats: SignatureStats{Total: 2, Signed: 1, Pending: 1}, want: false},
+		{name: "rejected signer", stats: SignatureStats{Total: 2, Signed: 1, Rejected: 1}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.stats.IsComplete(); got != tt.want {
+				t.Errorf("IsComplete() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
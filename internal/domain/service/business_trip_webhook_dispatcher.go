@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/pkg/logging"
+	"sandbox/pkg/retry"
+)
+
+// WebhookSender posts a signed webhook payload and returns the receiver's HTTP status, or 0 without a response
+type WebhookSender interface {
+	PostWebhook(ctx context.Context, url, eventType string, body []byte) (int, error)
+}
+
+// BusinessTripStatusEvent is the JSON payload of a business trip status webhook
+type BusinessTripStatusEvent struct {
+	EventID        string                    `json:"event_id"`
+	Event          string                    `json:"event"`
+	BusinessTripID string                    `json:"business_trip_id"`
+	OldStatus      entity.BusinessTripStatus `json:"old_status"`
+	NewStatus      entity.BusinessTripStatus `json:"new_status"`
+	Timestamp      time.Time                 `json:"timestamp"`
+}
+
+// BusinessTripWebhookDispatcher notifies the configured receivers when a business trip is completed or
+// canceled. Every receiver gets the event in the background and is retried until it answers 2xx or the
+// attempts run out; each attempt is recorded in the webhook delivery log. Receivers may get an event more
+// than once and should ignore event IDs they have already processed.
+type BusinessTripWebhookDispatcher struct {
+	sender     WebhookSender
+	deliveries repository.WebhookDeliveryRepository
+	urls       []string
+	retryOpts  retry.Options
+
+	wg sync.WaitGroup
+}
+
+// NewBusinessTripWebhookDispatcher creates a dispatcher posting to urls; without urls it sends nothing
+func NewBusinessTripWebhookDispatcher(
+	sender WebhookSender,
+	deliveries repository.WebhookDeliveryRepository,
+	urls []string,
+	retryOpts retry.Options,
+) *BusinessTripWebhookDispatcher {
+	// Delivery is at least once: every failure is retried, not only the transient ones
+	retryOpts.RetryIf = func(err error) bool { return !errors.Is(err, context.Canceled) }
+
+	return &BusinessTripWebhookDispatcher{
+		sender:     sender,
+		deliveries: deliveries,
+		urls:       urls,
+		retryOpts:  retryOpts,
+	}
+}
+
+// StatusChanged dispatches a webhook when a business trip moved to completed or canceled. It returns
+// immediately; deliveries outlive ctx but keep its values. A nil dispatcher sends nothing.
+func (d *BusinessTripWebhookDispatcher) StatusChanged(ctx context.Context, businessTripID string, oldStatus, newStatus entity.BusinessTripStatus) {
+	if d == nil || len(d.urls) == 0 || oldStatus == newStatus {
+		return
+	}
+	if newStatus != entity.BusinessTripStatusCompleted && newStatus != entity.BusinessTripStatusCanceled {
+		return
+	}
+
+	event := BusinessTripStatusEvent{
+		EventID:        uuid.NewString(),
+		Event:          entity.WebhookEventBusinessTripStatusChanged,
+		BusinessTripID: businessTripID,
+		OldStatus:      oldStatus,
+		NewStatus:      newStatus,
+		Timestamp:      time.Now().UTC(),
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to encode webhook payload", "business_trip_id", businessTripID, "error", err)
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	for _, url := range d.urls {
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			d.deliver(ctx, url, event, payload)
+		}()
+	}
+}
+
+// Wait blocks until every dispatched webhook was delivered or gave up
+func (d *BusinessTripWebhookDispatcher) Wait() {
+	if d == nil {
+		return
+	}
+	d.wg.Wait()
+}
+
+func (d *BusinessTripWebhookDispatcher) deliver(ctx context.Context, url string, event BusinessTripStatusEvent, payload []byte) {
+	eventID := uuid.MustParse(event.EventID)
+	attempt := 0
+
+	_, err := retry.Do(ctx, "webhook."+event.Event, d.retryOpts, func(ctx context.Context) (struct{}, error) {
+		attempt++
+		statusCode, err := d.sender.PostWebhook(ctx, url, event.Event, payload)
+
+		delivery := &entity.WebhookDelivery{
+			ID:             uuid.New(),
+			EventID:        eventID,
+			EventType:      event.Event,
+			BusinessTripID: event.BusinessTripID,
+			URL:            url,
+			Payload:        string(payload),
+			Attempt:        attempt,
+			Succeeded:      err == nil,
+			CreatedAt:      time.Now(),
+		}
+		if statusCode != 0 {
+			delivery.StatusCode = &statusCode
+		}
+		if err != nil {
+			delivery.Error = err.Error()
+		}
+		if recordErr := d.deliveries.Create(ctx, delivery); recordErr != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "failed to record webhook delivery",
+				"event_id", event.EventID, "url", url, "error", recordErr)
+		}
+
+		return struct{}{}, err
+	})
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "webhook delivery failed",
+			"event_id", event.EventID, "business_trip_id", event.BusinessTripID, "url", url, "attempts", attempt, "error", err)
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/pkg/retry"
+)
+
+// stubWebhookSender answers with the queued results in order and succeeds once they are used up
+type stubWebhookSender struct {
+	mu       sync.Mutex
+	failures []error
+	bodies   [][]byte
+}
+
+func (s *stubWebhookSender) PostWebhook(_ context.Context, _, _ string, body []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bodies = append(s.bodies, body)
+	if len(s.failures) == 0 {
+		return 200, nil
+	}
+	err := s.failures[0]
+	s.failures = s.failures[1:]
+	var statusErr *retry.StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode, err
+	}
+	return 0, err
+}
+
+type stubWebhookDeliveryRepository struct {
+	mu         sync.Mutex
+	deliveries []*entity.WebhookDelivery
+}
+
+func (r *stubWebhookDeliveryRepository) Create(_ context.Context, delivery *entity.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deliveries = append(r.deliveries, delivery)
+	return nil
+}
+
+func newTestWebhookDispatcher(sender WebhookSender, deliveries *stubWebhookDeliveryRepository) *BusinessTripWebhookDispatcher {
+	return NewBusinessTripWebhookDispatcher(sender, deliveries, []string{"https://erp.example.com/hooks"},
+		retry.Options{MaxAttempts: 5, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+}
+
+func TestWebhookDispatcherRetriesUntilDelivered(t *testing.T) {
+	sender := &stubWebhookSender{failures: []error{
+		retry.NewStatusError(400, errors.New("bad request")),
+		errors.New("connection refused"),
+	}}
+	deliveries := &stubWebhookDeliveryRepository{}
+	dispatcher := newTestWebhookDispatcher(sender, deliveries)
+
+	dispatcher.StatusChanged(context.Background(), "trip-1", entity.BusinessTripStatusOngoing, entity.BusinessTripStatusCompleted)
+	dispatcher.Wait()
+
+	if len(deliveries.deliveries) != 3 {
+		t.Fatalf("recorded %d attempts, want 3", len(deliveries.deliveries))
+	}
+	for i, delivery := range deliveries.deliveries {
+		if delivery.Attempt != i+1 || delivery.EventID != deliveries.deliveries[0].EventID {
+			t.Errorf("attempt %d recorded as attempt %d of event %s", i+1, delivery.Attempt, delivery.EventID)
+		}
+	}
+	if first := deliveries.deliveries[0]; first.StatusCode == nil || *first.StatusCode != 400 || first.Succeeded {
+		t.Errorf("first attempt = %+v, want a failed attempt with status 400", first)
+	}
+	if second := deliveries.deliveries[1]; second.StatusCode != nil || second.Error == "" {
+		t.Errorf("second attempt = %+v, want no status and an error", second)
+	}
+	if last := deliveries.deliveries[2]; !last.Succeeded || last.Error != "" {
+		t.Errorf("last attempt = %+v, want success", last)
+	}
+
+	var event BusinessTripStatusEvent
+	if err := json.Unmarshal(sender.bodies[0], &event); err != nil {
+		t.Fatalf("payload is not JSON: %v", err)
+	}
+	if event.BusinessTripID != "trip-1" || event.OldStatus != entity.BusinessTripStatusOngoing ||
+		event.NewStatus != entity.BusinessTripStatusCompleted || event.Timestamp.IsZero() {
+		t.Errorf("payload = %+v, want trip-1 from ongoing to completed with a timestamp", event)
+	}
+}
+
+func TestWebhookDispatcherIgnoresOtherStatuses(t *testing.T) {
+	sender := &stubWebhookSender{}
+	deliveries := &stubWebhookDeliveryRepository{}
+	dispatcher := newTestWebhookDispatcher(sender, deliveries)
+
+	dispatcher.StatusChanged(context.Background(), "trip-1", entity.BusinessTripStatusReadyToVerify, entity.BusinessTripStatusOngoing)
+	dispatcher.StatusChanged(context.Background(), "trip-1", entity.BusinessTripStatusCanceled, entity.BusinessTripStatusCanceled)
+	dispatcher.Wait()
+
+	if len(sender.bodies) != 0 {
+		t.Errorf("sent %d webhooks, want none", len(sender.bodies))
+	}
+}
@@ -2,29 +2,109 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
+	"golang.org/x/sync/singleflight"
+
 	"sandbox/internal/domain/entity"
 	"sandbox/internal/domain/repository"
 	"sandbox/internal/infrastructure/cdc"
+	"sandbox/pkg/logging"
 )
 
+// CDCOptions holds cache settings for the CDC service
+type CDCOptions struct {
+	// AliasCacheTTL is how long country and vaccine aliases are cached before they are reloaded
+	// (DefaultAliasCacheTTL when not set)
+	AliasCacheTTL time.Duration
+	// RecommendationCacheTTL is how long a recommendation extracted from the CDC website is served
+	// before it is fetched again (DefaultRecommendationCacheTTL when not set)
+	RecommendationCacheTTL time.Duration
+}
+
+// DefaultAliasCacheTTL is used when CDCOptions.AliasCacheTTL is not set
+const DefaultAliasCacheTTL = 5 * time.Minute
+
+// DefaultRecommendationCacheTTL is used when CDCOptions.RecommendationCacheTTL is not set
+const DefaultRecommendationCacheTTL = 24 * time.Hour
+
 type CDCService struct {
 	vaccinesRepo     repository.VaccinesRepository
 	cdcClient        *cdc.CDCClient
 	vaccineExtractor VaccineExtractor
+	options          CDCOptions
+
+	aliasMu        sync.Mutex
+	countryAliases map[string]string
+	vaccineAliases map[string]string
+	aliasesLoaded  time.Time
+
+	// recommendationFetches makes concurrent requests for the same uncached country share one fetch
+	recommendationFetches singleflight.Group
 }
 
-func NewCDCService(vaccinesRepo repository.VaccinesRepository, cdcClient *cdc.CDCClient, vaccineExtractor VaccineExtractor) *CDCService {
+func NewCDCService(vaccinesRepo repository.VaccinesRepository, cdcClient *cdc.CDCClient, vaccineExtractor VaccineExtractor, options CDCOptions) *CDCService {
+	if options.AliasCacheTTL <= 0 {
+		options.AliasCacheTTL = DefaultAliasCacheTTL
+	}
+	if options.RecommendationCacheTTL <= 0 {
+		options.RecommendationCacheTTL = DefaultRecommendationCacheTTL
+	}
 	return &CDCService{
 		vaccinesRepo:     vaccinesRepo,
 		cdcClient:        cdcClient,
 		vaccineExtractor: vaccineExtractor,
+		options:          options,
 	}
 }
 
+// InvalidateAliases drops the cached aliases so the next lookup reloads them from the database
+func (s *CDCService) InvalidateAliases() {
+	s.aliasMu.Lock()
+	defer s.aliasMu.Unlock()
+	s.aliasesLoaded = time.Time{}
+}
+
+// aliases returns the country and vaccine alias maps, reloading them once they are older than the TTL.
+// If reloading fails the previous maps are kept, so a database error only delays picking up new aliases.
+func (s *CDCService) aliases(ctx context.Context) (countries, vaccines map[string]string) {
+	s.aliasMu.Lock()
+	defer s.aliasMu.Unlock()
+
+	if s.countryAliases != nil && time.Since(s.aliasesLoaded) < s.options.AliasCacheTTL {
+		return s.countryAliases, s.vaccineAliases
+	}
+
+	countryAliases, err := s.vaccinesRepo.ListCountryAliases(ctx)
+	if err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "failed to load country aliases", "error", err)
+		return s.countryAliases, s.vaccineAliases
+	}
+	vaccineAliases, err := s.vaccinesRepo.ListVaccineAliases(ctx)
+	if err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "failed to load vaccine aliases", "error", err)
+		return s.countryAliases, s.vaccineAliases
+	}
+
+	countries = make(map[string]string, len(countryAliases))
+	for _, alias := range countryAliases {
+		countries[alias.Alias] = alias.CountryName
+	}
+	vaccines = make(map[string]string, len(vaccineAliases))
+	for _, alias := range vaccineAliases {
+		vaccines[alias.Alias] = alias.VaccineName
+	}
+
+	s.countryAliases = countries
+	s.vaccineAliases = vaccines
+	s.aliasesLoaded = time.Now()
+	return countries, vaccines
+}
+
 type CountryVaccineRecommendation struct {
 	CountryCode         string                  `json:"country_code"`
 	CountryName         string                  `json:"country_name"`
@@ -35,11 +115,89 @@ type CountryVaccineRecommendation struct {
 	MalariaProphylaxis  string                  `json:"malaria_prophylaxis"`
 	HealthNotice        string                  `json:"health_notice"`
 	LastUpdated         time.Time               `json:"last_updated"`
+	// FetchedAt is when the recommendation was fetched from the CDC website
+	FetchedAt time.Time `json:"fetched_at"`
+	// ResolvedLanguage is the language CountryName is in: en, id, or code when no name was available
+	ResolvedLanguage string `json:"resolved_language"`
+}
+
+// GetVaccineRecommendationsByCountry returns the recommendation for a country, served from the cache while it
+// is younger than the recommendation TTL. forceRefresh fetches it from the CDC website again regardless.
+// Concurrent requests for the same country and language share a single fetch.
+func (s *CDCService) GetVaccineRecommendationsByCountry(ctx context.Context, countryCode string, language string, forceRefresh bool) (*CountryVaccineRecommendation, error) {
+	cacheKey := strings.ToLower(strings.TrimSpace(countryCode))
+
+	if !forceRefresh {
+		if recommendation := s.cachedRecommendation(ctx, cacheKey, language); recommendation != nil {
+			return recommendation, nil
+		}
+	}
+
+	result, err, _ := s.recommendationFetches.Do(cacheKey+"|"+language, func() (interface{}, error) {
+		// The fetch is shared, so one caller giving up must not cancel it for the others
+		fetchCtx := context.WithoutCancel(ctx)
+
+		recommendation, err := s.fetchRecommendation(fetchCtx, countryCode, language)
+		if err != nil {
+			return nil, err
+		}
+		s.cacheRecommendation(fetchCtx, cacheKey, language, recommendation)
+		return recommendation, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*CountryVaccineRecommendation), nil
 }
 
-func (s *CDCService) GetVaccineRecommendationsByCountry(ctx context.Context, countryCode string, language string) (*CountryVaccineRecommendation, error) {
+// cachedRecommendation returns the cached recommendation when it is younger than the TTL. Cache errors
+// are logged and treated as a miss.
+func (s *CDCService) cachedRecommendation(ctx context.Context, cacheKey, language string) *CountryVaccineRecommendation {
+	cached, err := s.vaccinesRepo.GetCDCRecommendationCache(ctx, cacheKey, language)
+	if err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "failed to read cached CDC recommendation", "country", cacheKey, "error", err)
+		return nil
+	}
+	if cached == nil || time.Since(cached.FetchedAt) >= s.options.RecommendationCacheTTL {
+		return nil
+	}
+
+	var recommendation CountryVaccineRecommendation
+	if err := json.Unmarshal(cached.Recommendation, &recommendation); err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "failed to decode cached CDC recommendation", "country", cacheKey, "error", err)
+		return nil
+	}
+	recommendation.FetchedAt = cached.FetchedAt
+	return &recommendation
+}
+
+// cacheRecommendation stores a freshly fetched recommendation; failures are logged since the
+// recommendation itself is still good
+func (s *CDCService) cacheRecommendation(ctx context.Context, cacheKey, language string, recommendation *CountryVaccineRecommendation) {
+	data, err := json.Marshal(recommendation)
+	if err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "failed to encode CDC recommendation", "country", cacheKey, "error", err)
+		return
+	}
+
+	err = s.vaccinesRepo.UpsertCDCRecommendationCache(ctx, &entity.CDCRecommendationCache{
+		CountryCode:    cacheKey,
+		Language:       language,
+		Recommendation: data,
+		FetchedAt:      recommendation.FetchedAt,
+	})
+	if err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "failed to cache CDC recommendation", "country", cacheKey, "error", err)
+	}
+}
+
+// fetchRecommendation gets the recommendation from the CDC website and extracts it with Gemini
+func (s *CDCService) fetchRecommendation(ctx context.Context, countryCode string, language string) (*CountryVaccineRecommendation, error) {
+	countryAliases, vaccineAliases := s.aliases(ctx)
+
 	// Convert country code/name to full country name for database lookup
-	fullCountryName := s.convertToFullCountryName(countryCode)
+	fullCountryName := s.convertToFullCountryName(countryCode, countryAliases)
 
 	// Get country information from our database
 	country, err := s.vaccinesRepo.GetCountryByCode(ctx, fullCountryName)
@@ -71,16 +229,19 @@ func (s *CDCService) GetVaccineRecommendationsByCountry(ctx context.Context, cou
 	}
 
 	// Convert Gemini response to our format
-	recommendation := s.convertGeminiResponseToRecommendation(vaccineData, country, language, allVaccines)
+	recommendation := s.convertGeminiResponseToRecommendation(vaccineData, country, language, allVaccines, vaccineAliases)
+	recommendation.FetchedAt = time.Now()
 
 	return recommendation, nil
 }
 
 // convertGeminiResponseToRecommendation converts Gemini response to our recommendation format
-func (s *CDCService) convertGeminiResponseToRecommendation(vaccineData map[string]interface{}, country *entity.Country, language string, allVaccines []*entity.MasterVaccine) *CountryVaccineRecommendation {
+func (s *CDCService) convertGeminiResponseToRecommendation(vaccineData map[string]interface{}, country *entity.Country, language string, allVaccines []*entity.MasterVaccine, vaccineAliases map[string]string) *CountryVaccineRecommendation {
+	countryName, resolvedLanguage := country.ResolveDisplayName(language)
 	recommendation := &CountryVaccineRecommendation{
-		CountryCode: country.CountryCode,
-		CountryName: country.GetDisplayName(language),
+		CountryCode:      country.CountryCode,
+		CountryName:      countryName,
+		ResolvedLanguage: resolvedLanguage,
 	}
 
 	// Create vaccine lookup map
@@ -90,9 +251,11 @@ func (s *CDCService) convertGeminiResponseToRecommendation(vaccineData map[strin
 		vaccineMap[strings.ToLower(vaccine.VaccineNameID)] = vaccine
 	}
 
-	// Extract country name if available
-	if countryName, ok := vaccineData["countryName"].(string); ok && countryName != "" {
+	// The name extracted from the English CDC page only replaces the country code, so a name in the
+	// requested language is never overwritten with an English one
+	if countryName, ok := vaccineData["countryName"].(string); ok && countryName != "" && resolvedLanguage == entity.DisplayLanguageCode {
 		recommendation.CountryName = countryName
+		recommendation.ResolvedLanguage = entity.DisplayLanguageEnglish
 	}
 
 	// Extract required vaccines
@@ -100,7 +263,7 @@ func (s *CDCService) convertGeminiResponseToRecommendation(vaccineData map[strin
 		for _, req := range requiredVaccines {
 			if reqMap, ok := req.(map[string]interface{}); ok {
 				if name, ok := reqMap["name"].(string); ok {
-					if vaccine := s.findVaccineByName(name, vaccineMap); vaccine != nil {
+					if vaccine := s.findVaccineByName(name, vaccineAliases, vaccineMap); vaccine != nil {
 						recommendation.RequiredVaccines = append(recommendation.RequiredVaccines, vaccine)
 					}
 				}
@@ -113,7 +276,7 @@ func (s *CDCService) convertGeminiResponseToRecommendation(vaccineData map[strin
 		for _, rec := range recommendedVaccines {
 			if recMap, ok := rec.(map[string]interface{}); ok {
 				if name, ok := recMap["name"].(string); ok {
-					if vaccine := s.findVaccineByName(name, vaccineMap); vaccine != nil {
+					if vaccine := s.findVaccineByName(name, vaccineAliases, vaccineMap); vaccine != nil {
 						recommendation.RecommendedVaccines = append(recommendation.RecommendedVaccines, vaccine)
 					}
 				}
@@ -126,7 +289,7 @@ func (s *CDCService) convertGeminiResponseToRecommendation(vaccineData map[strin
 		for _, con := range considerVaccines {
 			if conMap, ok := con.(map[string]interface{}); ok {
 				if name, ok := conMap["name"].(string); ok {
-					if vaccine := s.findVaccineByName(name, vaccineMap); vaccine != nil {
+					if vaccine := s.findVaccineByName(name, vaccineAliases, vaccineMap); vaccine != nil {
 						recommendation.ConsiderVaccines = append(recommendation.ConsiderVaccines, vaccine)
 					}
 				}
@@ -162,8 +325,8 @@ func (s *CDCService) convertGeminiResponseToRecommendation(vaccineData map[strin
 	return recommendation
 }
 
-// findVaccineByName finds vaccine by name using fuzzy matching
-func (s *CDCService) findVaccineByName(name string, vaccineMap map[string]*entity.MasterVaccine) *entity.MasterVaccine {
+// findVaccineByName finds vaccine by name, first through the vaccine aliases and then by fuzzy matching
+func (s *CDCService) findVaccineByName(name string, vaccineAliases map[string]string, vaccineMap map[string]*entity.MasterVaccine) *entity.MasterVaccine {
 	lowerName := strings.ToLower(name)
 
 	// Direct match
@@ -171,40 +334,15 @@ func (s *CDCService) findVaccineByName(name string, vaccineMap map[string]*entit
 		return vaccine
 	}
 
-	// Common vaccine name mappings
-	vaccineMappings := map[string]string{
-		"hepatitis a":           "Hepatitis A",
-		"hepatitis b":           "Hepatitis B",
-		"typhoid":               "Typhoid",
-		"yellow fever":          "Yellow Fever",
-		"rabies":                "Rabies",
-		"meningitis":            "Meningococcal",
-		"meningococcal":         "Meningococcal",
-		"cholera":               "Cholera",
-		"japanese encephalitis": "Japanese Encephalitis",
-		"influenza":             "Influenza",
-		"flu":                   "Influenza",
-		"mmr":                   "MMR",
-		"measles":               "MMR",
-		"mumps":                 "MMR",
-		"rubella":               "MMR",
-		"tetanus":               "Tetanus",
-		"diphtheria":            "Diphtheria",
-		"pertussis":             "Pertussis",
-		"polio":                 "Polio",
-		"covid":                 "COVID-19",
-		"covid-19":              "COVID-19",
-	}
-
 	// Try mapping
-	if mappedName, ok := vaccineMappings[lowerName]; ok {
+	if mappedName, ok := vaccineAliases[lowerName]; ok {
 		if vaccine, exists := vaccineMap[strings.ToLower(mappedName)]; exists {
 			return vaccine
 		}
 	}
 
 	// Try partial matching
-	for mappingKey, mappedName := range vaccineMappings {
+	for mappingKey, mappedName := range vaccineAliases {
 		if strings.Contains(lowerName, mappingKey) || strings.Contains(mappingKey, lowerName) {
 			if vaccine, exists := vaccineMap[strings.ToLower(mappedName)]; exists {
 				return vaccine
@@ -222,189 +360,18 @@ func (s *CDCService) findVaccineByName(name string, vaccineMap map[string]*entit
 	return nil
 }
 
-// convertToFullCountryName converts country codes or names to full country names
-func (s *CDCService) convertToFullCountryName(countryCode string) string {
+// convertToFullCountryName converts country codes or names to full country names using the country aliases
+func (s *CDCService) convertToFullCountryName(countryCode string, countryAliases map[string]string) string {
 	// Normalize the input
 	lowerCode := strings.ToLower(strings.TrimSpace(countryCode))
 
-	// Common country code mappings to full names
-	countryMappings := map[string]string{
-		// ISO-3 to full names
-		"jpn": "Japan",
-		"idn": "Indonesia",
-		"mys": "Malaysia",
-		"tha": "Thailand",
-		"vnm": "Vietnam",
-		"phl": "Philippines",
-		"sgp": "Singapore",
-		"chn": "China",
-		"kor": "South Korea",
-		"ind": "India",
-		"usa": "United States",
-		"gbr": "United Kingdom",
-		"deu": "Germany",
-		"fra": "France",
-		"ita": "Italy",
-		"esp": "Spain",
-		"nld": "Netherlands",
-		"aus": "Australia",
-		"can": "Canada",
-		"bra": "Brazil",
-		"arg": "Argentina",
-		"mex": "Mexico",
-		"sau": "Saudi Arabia",
-		"are": "United Arab Emirates",
-		"egy": "Egypt",
-		"zaf": "South Africa",
-		"tur": "Turkey",
-		"isr": "Israel",
-		"mmr": "Myanmar",
-		"khm": "Cambodia",
-		"lao": "Laos",
-		"brn": "Brunei Darussalam",
-		"hkg": "Hong Kong",
-		"twn": "Taiwan",
-		"pak": "Pakistan",
-		"bgd": "Bangladesh",
-		"lka": "Sri Lanka",
-		"mdv": "Maldives",
-		"qat": "Qatar",
-		"che": "Switzerland",
-		"aut": "Austria",
-		"bel": "Belgium",
-		"swe": "Sweden",
-		"chl": "Chile",
-		"per": "Peru",
-		"col": "Colombia",
-		"mar": "Morocco",
-		"ken": "Kenya",
-		"tza": "Tanzania",
-		"nzl": "New Zealand",
-		"png": "Papua New Guinea",
-		"fji": "Fiji",
-
-		// ISO-2 to full names
-		"jp": "Japan",
-		"id": "Indonesia",
-		"my": "Malaysia",
-		"th": "Thailand",
-		"vn": "Vietnam",
-		"ph": "Philippines",
-		"sg": "Singapore",
-		"cn": "China",
-		"kr": "South Korea",
-		"in": "India",
-		"us": "United States",
-		"gb": "United Kingdom",
-		"de": "Germany",
-		"fr": "France",
-		"it": "Italy",
-		"es": "Spain",
-		"nl": "Netherlands",
-		"au": "Australia",
-		"ca": "Canada",
-		"br": "Brazil",
-		"ar": "Argentina",
-		"mx": "Mexico",
-		"sa": "Saudi Arabia",
-		"ae": "United Arab Emirates",
-		"eg": "Egypt",
-		"za": "South Africa",
-		"tr": "Turkey",
-		"il": "Israel",
-		"mm": "Myanmar",
-		"kh": "Cambodia",
-		"la": "Laos",
-		"bn": "Brunei Darussalam",
-		"hk": "Hong Kong",
-		"tw": "Taiwan",
-		"pk": "Pakistan",
-		"bd": "Bangladesh",
-		"lk": "Sri Lanka",
-		"mv": "Maldives",
-		"qa": "Qatar",
-		"ch": "Switzerland",
-		"at": "Austria",
-		"be": "Belgium",
-		"se": "Sweden",
-		"cl": "Chile",
-		"pe": "Peru",
-		"co": "Colombia",
-		"ma": "Morocco",
-		"ke": "Kenya",
-		"tz": "Tanzania",
-		"nz": "New Zealand",
-		"pg": "Papua New Guinea",
-		"fj": "Fiji",
-
-		// Common name mappings
-		"japan":                "Japan",
-		"indonesia":            "Indonesia",
-		"malaysia":             "Malaysia",
-		"thailand":             "Thailand",
-		"vietnam":              "Vietnam",
-		"philippines":          "Philippines",
-		"singapore":            "Singapore",
-		"china":                "China",
-		"south korea":          "South Korea",
-		"korea":                "South Korea",
-		"india":                "India",
-		"united states":        "United States",
-		"america":              "United States",
-		"uk":                   "United Kingdom",
-		"united kingdom":       "United Kingdom",
-		"england":              "United Kingdom",
-		"germany":              "Germany",
-		"france":               "France",
-		"italy":                "Italy",
-		"spain":                "Spain",
-		"netherlands":          "Netherlands",
-		"holland":              "Netherlands",
-		"australia":            "Australia",
-		"canada":               "Canada",
-		"brazil":               "Brazil",
-		"argentina":            "Argentina",
-		"mexico":               "Mexico",
-		"saudi arabia":         "Saudi Arabia",
-		"united arab emirates": "United Arab Emirates",
-		"uae":                  "United Arab Emirates",
-		"egypt":                "Egypt",
-		"south africa":         "South Africa",
-		"turkey":               "Turkey",
-		"israel":               "Israel",
-		"myanmar":              "Myanmar",
-		"cambodia":             "Cambodia",
-		"laos":                 "Laos",
-		"brunei":               "Brunei Darussalam",
-		"hong kong":            "Hong Kong",
-		"taiwan":               "Taiwan",
-		"pakistan":             "Pakistan",
-		"bangladesh":           "Bangladesh",
-		"sri lanka":            "Sri Lanka",
-		"maldives":             "Maldives",
-		"qatar":                "Qatar",
-		"switzerland":          "Switzerland",
-		"austria":              "Austria",
-		"belgium":              "Belgium",
-		"sweden":               "Sweden",
-		"chile":                "Chile",
-		"peru":                 "Peru",
-		"colombia":             "Colombia",
-		"morocco":              "Morocco",
-		"kenya":                "Kenya",
-		"tanzania":             "Tanzania",
-		"new zealand":          "New Zealand",
-		"papua new guinea":     "Papua New Guinea",
-		"fiji":                 "Fiji",
-	}
-
 	// Look for exact match in mappings
-	if fullName, exists := countryMappings[lowerCode]; exists {
+	if fullName, exists := countryAliases[lowerCode]; exists {
 		return fullName
 	}
 
 	// Try to find partial matches for country names
-	for countryName, fullName := range countryMappings {
+	for countryName, fullName := range countryAliases {
 		if strings.Contains(lowerCode, countryName) || strings.Contains(countryName, lowerCode) {
 			return fullName
 		}
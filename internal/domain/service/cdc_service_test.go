@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+)
+
+// stubAliasRepository serves country and vaccine aliases and counts how often they are loaded
+type stubAliasRepository struct {
+	repository.VaccinesRepository
+	countries []*entity.CountryAlias
+	vaccines  []*entity.VaccineAlias
+	err       error
+	loads     int
+}
+
+func (r *stubAliasRepository) ListCountryAliases(context.Context) ([]*entity.CountryAlias, error) {
+	r.loads++
+	return r.countries, r.err
+}
+
+func (r *stubAliasRepository) ListVaccineAliases(context.Context) ([]*entity.VaccineAlias, error) {
+	return r.vaccines, r.err
+}
+
+func TestCDCServiceResolvesCountryAliasesFromRepository(t *testing.T) {
+	repo := &stubAliasRepository{
+		countries: []*entity.CountryAlias{{Alias: "jpn", CountryName: "Japan"}},
+	}
+	s := NewCDCService(repo, nil, nil, CDCOptions{AliasCacheTTL: time.Hour})
+
+	countries, _ := s.aliases(context.Background())
+	if got := s.convertToFullCountryName(" JPN ", countries); got != "Japan" {
+		t.Errorf("convertToFullCountryName(JPN) = %q, want Japan", got)
+	}
+	// Unknown inputs still fall back to title casing
+	if got := s.convertToFullCountryName("south sudan", countries); got != "South Sudan" {
+		t.Errorf("convertToFullCountryName(south sudan) = %q, want South Sudan", got)
+	}
+
+	s.aliases(context.Background())
+	if repo.loads != 1 {
+		t.Errorf("aliases loaded %d times within the TTL, want 1", repo.loads)
+	}
+
+	s.InvalidateAliases()
+	s.aliases(context.Background())
+	if repo.loads != 2 {
+		t.Errorf("aliases loaded %d times after invalidation, want 2", repo.loads)
+	}
+}
+
+func TestCDCServiceKeepsAliasesWhenReloadFails(t *testing.T) {
+	repo := &stubAliasRepository{
+		vaccines: []*entity.VaccineAlias{{Alias: "grippe", VaccineName: "Influenza"}},
+	}
+	s := NewCDCService(repo, nil, nil, CDCOptions{AliasCacheTTL: time.Hour})
+	s.aliases(context.Background())
+
+	s.InvalidateAliases()
+	repo.err = errors.New("connection refused")
+	_, vaccines := s.aliases(context.Background())
+
+	influenza := &entity.MasterVaccine{VaccineNameEN: "Influenza"}
+	vaccineMap := map[string]*entity.MasterVaccine{"influenza": influenza}
+	if got := s.findVaccineByName("Grippe", vaccines, vaccineMap); got != influenza {
+		t.Errorf("findVaccineByName(Grippe) = %v, want the influenza vaccine from the cached aliases", got)
+	}
+}
+
+// stubRecommendationCacheRepository serves one cached recommendation; the country lookup lets a cache
+// miss reach the CDC client
+type stubRecommendationCacheRepository struct {
+	stubAliasRepository
+	cached *entity.CDCRecommendationCache
+}
+
+func (r *stubRecommendationCacheRepository) GetCDCRecommendationCache(context.Context, string, string) (*entity.CDCRecommendationCache, error) {
+	return r.cached, nil
+}
+
+func (r *stubRecommendationCacheRepository) GetCountryByCode(_ context.Context, code string) (*entity.Country, error) {
+	return &entity.Country{CountryCode: code, CountryNameEN: code}, nil
+}
+
+func TestCDCServiceServesFreshCachedRecommendation(t *testing.T) {
+	data, _ := json.Marshal(CountryVaccineRecommendation{CountryCode: "Japan", MalariaRisk: "none"})
+	repo := &stubRecommendationCacheRepository{
+		cached: &entity.CDCRecommendationCache{CountryCode: "jpn", Language: "en", Recommendation: data, FetchedAt: time.Now().Add(-time.Hour)},
+	}
+	// Without a CDC client any fetch fails, so a result proves the cache was used
+	s := NewCDCService(repo, nil, nil, CDCOptions{RecommendationCacheTTL: 24 * time.Hour})
+
+	recommendation, err := s.GetVaccineRecommendationsByCountry(context.Background(), "JPN", "en", false)
+	if err != nil {
+		t.Fatalf("GetVaccineRecommendationsByCountry() error = %v", err)
+	}
+	if recommendation.MalariaRisk != "none" || !recommendation.FetchedAt.Equal(repo.cached.FetchedAt) {
+		t.Errorf("recommendation = %+v, want the cached one", recommendation)
+	}
+
+	if _, err := s.GetVaccineRecommendationsByCountry(context.Background(), "JPN", "en", true); err == nil {
+		t.Error("GetVaccineRecommendationsByCountry() with forceRefresh used the cache")
+	}
+}
+
+func TestCDCServiceRefetchesExpiredRecommendation(t *testing.T) {
+	data, _ := json.Marshal(CountryVaccineRecommendation{CountryCode: "Japan"})
+	repo := &stubRecommendationCacheRepository{
+		cached: &entity.CDCRecommendationCache{CountryCode: "jpn", Language: "en", Recommendation: data, FetchedAt: time.Now().Add(-25 * time.Hour)},
+	}
+	s := NewCDCService(repo, nil, nil, CDCOptions{RecommendationCacheTTL: 24 * time.Hour})
+
+	if _, err := s.GetVaccineRecommendationsByCountry(context.Background(), "JPN", "en", false); err == nil {
+		t.Error("GetVaccineRecommendationsByCountry() served an expired recommendation")
+	}
+}
+
+func TestRecommendationCountryNameFallsBackToCountryCode(t *testing.T) {
+	s := NewCDCService(&stubAliasRepository{}, nil, nil, CDCOptions{})
+	country := &entity.Country{CountryCode: "IDN", CountryNameID: "Indonesia"}
+
+	recommendation := s.convertGeminiResponseToRecommendation(map[string]interface{}{}, country, "fr", nil, nil)
+	if recommendation.CountryName != "IDN" || recommendation.ResolvedLanguage != entity.DisplayLanguageCode {
+		t.Errorf("country name = %q (%s), want IDN (code)", recommendation.CountryName, recommendation.ResolvedLanguage)
+	}
+
+	recommendation = s.convertGeminiResponseToRecommendation(map[string]interface{}{}, country, "id", nil, nil)
+	if recommendation.CountryName != "Indonesia" || recommendation.ResolvedLanguage != entity.DisplayLanguageIndonesian {
+		t.Errorf("country name = %q (%s), want Indonesia (id)", recommendation.CountryName, recommendation.ResolvedLanguage)
+	}
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+)
+
+// stubBatchNoteRepository serves the notes of one work paper and records every saved note
+type stubBatchNoteRepository struct {
+	repository.WorkPaperNoteRepository
+	notes []*entity.WorkPaperNote
+
+	mu    sync.Mutex
+	saved map[uuid.UUID]*entity.WorkPaperNote
+}
+
+func (r *stubBatchNoteRepository) GetByWorkPaper(_ context.Context, _ string) ([]*entity.WorkPaperNote, error) {
+	return r.notes, nil
+}
+
+func (r *stubBatchNoteRepository) GetByID(_ context.Context, id string) (*entity.WorkPaperNote, error) {
+	for _, note := range r.notes {
+		if note.ID.String() == id {
+			return note, nil
+		}
+	}
+	return nil, entity.ErrWorkPaperNoteNotFound
+}
+
+func (r *stubBatchNoteRepository) Update(_ context.Context, note *entity.WorkPaperNote) (*entity.WorkPaperNote, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.saved[note.ID] = note
+	return note, nil
+}
+
+type stubBatchItemRepository struct {
+	repository.WorkPaperItemRepository
+}
+
+func (r *stubBatchItemRepository) GetByID(_ context.Context, id string) (*entity.WorkPaperItem, error) {
+	return &entity.WorkPaperItem{ID: uuid.MustParse(id), Number: "1.1", Statement: id}, nil
+}
+
+type stubBatchHistoryRepository struct {
+	repository.WorkPaperNoteCheckHistoryRepository
+}
+
+func (r *stubBatchHistoryRepository) Create(context.Context, *entity.WorkPaperNoteCheckHistory) error {
+	return nil
+}
+
+type stubEmptyDriveService struct {
+	DriveService
+}
+
+func (d *stubEmptyDriveService) GetFilesFromFolder(context.Context, string) ([]*DriveFile, error) {
+	return nil, nil
+}
+
+// stubBatchLLMService marks every check valid and charges 10 prompt and 5 completion tokens, except for
+// the master item failItemID, whose check fails. The item stub uses the item ID as the statement.
+type stubBatchLLMService struct {
+	failItemID string
+}
+
+func (l *stubBatchLLMService) CheckDocument(_ context.Context, req *DocumentCheckRequest) (*DocumentCheckResponse, error) {
+	if req.Statement == l.failItemID {
+		return nil, errors.New("llm unavailable")
+	}
+	return &DocumentCheckResponse{
+		IsValid: true,
+		Notes:   "complete",
+		Model:   "test-model",
+		Usage:   &TokenUsage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15},
+	}, nil
+}
+
+func noteWithLink(link, checkedLink string) *entity.WorkPaperNote {
+	note := &entity.WorkPaperNote{ID: uuid.New(), WorkPaperID: uuid.New(), MasterItemID: uuid.New()}
+	if link != "" {
+		note.GDriveLink = &link
+	}
+	if checkedLink != "" {
+		note.LastLLMResponse = &entity.LLMResponse{IsValid: false, GDriveLink: checkedLink}
+	}
+	return note
+}
+
+func newBatchCheckDesk(notes []*entity.WorkPaperNote, llm LLMService) (DeskService, *stubBatchNoteRepository) {
+	noteRepo := &stubBatchNoteRepository{notes: notes, saved: make(map[uuid.UUID]*entity.WorkPaperNote)}
+	desk := NewDeskService(&stubBatchItemRepository{}, nil, nil, noteRepo, nil, &stubBatchHistoryRepository{}, nil, nil, nil,
+		&stubEmptyDriveService{}, llm, DeskOptions{CheckConcurrency: 2})
+	return desk, noteRepo
+}
+
+func TestCheckWorkPaperDocumentsOnlyChanged(t *testing.T) {
+	changed := noteWithLink("https://drive.google.com/drive/folders/new", "https://drive.google.com/drive/folders/old")
+	unchanged := noteWithLink("https://drive.google.com/drive/folders/same", "https://drive.google.com/drive/folders/same")
+	neverChecked := noteWithLink("https://drive.google.com/drive/folders/fresh", "")
+	withoutLink := noteWithLink("", "")
+
+	desk, noteRepo := newBatchCheckDesk([]*entity.WorkPaperNote{changed, unchanged, neverChecked, withoutLink}, &stubBatchLLMService{})
+
+	resp, err := desk.CheckWorkPaperDocuments(context.Background(), uuid.NewString(), true, false)
+	if err != nil {
+		t.Fatalf("CheckWorkPaperDocuments() error = %v", err)
+	}
+
+	if resp.Checked != 2 || resp.Skipped != 2 || resp.Failed != 0 {
+		t.Errorf("checked/skipped/failed = %d/%d/%d, want 2/2/0", resp.Checked, resp.Skipped, resp.Failed)
+	}
+	if _, ok := noteRepo.saved[unchanged.ID]; ok {
+		t.Error("note with an unchanged link was checked again")
+	}
+	if _, ok := noteRepo.saved[changed.ID]; !ok {
+		t.Error("note with a changed link was not saved")
+	}
+	if got := changed.LastLLMResponse.GDriveLink; got != "https://drive.google.com/drive/folders/new" {
+		t.Errorf("checked link = %q, want the new link", got)
+	}
+
+	want := TokenUsage{PromptTokens: 20, CompletionTokens: 10, TotalTokens: 30}
+	if resp.Usage != want {
+		t.Errorf("usage = %+v, want %+v", resp.Usage, want)
+	}
+}
+
+func TestCheckWorkPaperDocumentsKeepsProgressOnFailure(t *testing.T) {
+	first := noteWithLink("https://drive.google.com/drive/folders/a", "")
+	failing := noteWithLink("https://drive.google.com/drive/folders/b", "")
+	last := noteWithLink("https://drive.google.com/drive/folders/c", "")
+
+	desk, noteRepo := newBatchCheckDesk([]*entity.WorkPaperNote{first, failing, last}, &stubBatchLLMService{failItemID: failing.MasterItemID.String()})
+
+	resp, err := desk.CheckWorkPaperDocuments(context.Background(), uuid.NewString(), false, false)
+	if err != nil {
+		t.Fatalf("CheckWorkPaperDocuments() error = %v", err)
+	}
+
+	if resp.Checked != 2 || resp.Failed != 1 {
+		t.Errorf("checked/failed = %d/%d, want 2/1", resp.Checked, resp.Failed)
+	}
+	if resp.Results[1].Error == "" {
+		t.Error("failed note has no error in its result")
+	}
+	for _, note := range []*entity.WorkPaperNote{first, last} {
+		if _, ok := noteRepo.saved[note.ID]; !ok {
+			t.Errorf("note %s checked next to a failure was not saved", note.ID)
+		}
+	}
+	if _, ok := noteRepo.saved[failing.ID]; ok {
+		t.Error("failed note was saved")
+	}
+}
+
+func TestGetWorkPaperNoteCheckHistoryUnknownNote(t *testing.T) {
+	desk := NewDeskService(nil, nil, nil, &stubBatchNoteRepository{}, nil, &stubBatchHistoryRepository{}, nil, nil, nil, nil, nil, DeskOptions{})
+
+	for _, noteID := range []string{uuid.NewString(), "not-a-uuid"} {
+		if _, err := desk.GetWorkPaperNoteCheckHistory(context.Background(), noteID); !errors.Is(err, entity.ErrWorkPaperNoteNotFound) {
+			t.Errorf("GetWorkPaperNoteCheckHistory(%q) error = %v, want ErrWorkPaperNoteNotFound", noteID, err)
+		}
+	}
+}
@@ -2,6 +2,11 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
 
 	"sandbox/internal/domain/entity"
 
@@ -13,6 +18,8 @@ import (
 type DriveService interface {
 	GetFilesFromFolder(ctx context.Context, folderLink string) ([]*DriveFile, error)
 	DownloadFile(ctx context.Context, fileID string) ([]byte, error)
+	// UploadFile stores content as a new file in folderID, or in the drive root when folderID is empty
+	UploadFile(ctx context.Context, folderID, name, mimeType string, content []byte) (*DriveFile, error)
 }
 
 // DriveFile represents a file from Google Drive
@@ -28,6 +35,12 @@ type LLMService interface {
 	CheckDocument(ctx context.Context, req *DocumentCheckRequest) (*DocumentCheckResponse, error)
 }
 
+// PromptTemplateHasher is implemented by LLM services whose prompt depends on the work paper item type.
+// The hash identifies the resolved prompt template, so cached verdicts follow template changes.
+type PromptTemplateHasher interface {
+	PromptTemplateHash(itemType string) string
+}
+
 // DocumentCheckRequest represents the request for document checking
 type DocumentCheckRequest struct {
 	Number       string         `json:"number"`
@@ -35,6 +48,38 @@ type DocumentCheckRequest struct {
 	Explanation  string         `json:"explanation"`
 	FillingGuide string         `json:"filling_guide"`
 	Documents    []DocumentFile `json:"documents"`
+	// ItemType is the type of the work paper item (A, B or C); it selects the prompt template
+	ItemType string `json:"item_type,omitempty"`
+	// Model overrides the LLM service default for this check when set
+	Model string `json:"model,omitempty"`
+	// Temperature overrides the model's default sampling temperature when set
+	Temperature *float64 `json:"temperature,omitempty"`
+}
+
+// SupportedLLMModels lists the models a document check may ask for instead of the LLM service default
+var SupportedLLMModels = []string{"gemini-2.5-flash", "gemini-2.5-flash-lite", "gemini-2.5-pro"}
+
+// Bounds of the sampling temperature a document check may ask for
+const (
+	MinLLMTemperature = 0.0
+	MaxLLMTemperature = 2.0
+)
+
+// LLMOptions overrides the LLM defaults for a single document check; unset fields keep the defaults
+type LLMOptions struct {
+	Model       string
+	Temperature *float64
+}
+
+// Validate checks the model against SupportedLLMModels and the temperature against its bounds
+func (o LLMOptions) Validate() error {
+	if o.Model != "" && !slices.Contains(SupportedLLMModels, o.Model) {
+		return fmt.Errorf("%w: %q (supported: %s)", entity.ErrUnsupportedLLMModel, o.Model, strings.Join(SupportedLLMModels, ", "))
+	}
+	if o.Temperature != nil && (*o.Temperature < MinLLMTemperature || *o.Temperature > MaxLLMTemperature) {
+		return fmt.Errorf("%w: %v is not between %v and %v", entity.ErrInvalidLLMTemperature, *o.Temperature, MinLLMTemperature, MaxLLMTemperature)
+	}
+	return nil
 }
 
 // DocumentFile represents a document file for LLM processing
@@ -46,10 +91,18 @@ type DocumentFile struct {
 
 // DocumentCheckResponse represents the response from document checking
 type DocumentCheckResponse struct {
-	IsValid bool        `json:"isValid"`
-	Notes   string      `json:"notes"`
-	Model   string      `json:"model"`
-	Usage   *TokenUsage `json:"usage,omitempty"`
+	IsValid bool   `json:"isValid"`
+	Notes   string `json:"notes"`
+	// Confidence is the model's confidence in the verdict from 0 to 1, or nil when its answer was not
+	// the requested JSON
+	Confidence *float64 `json:"confidence"`
+	// MissingItems lists the requirements the model found unmet by the documents
+	MissingItems []string    `json:"missingItems,omitempty"`
+	Model        string      `json:"model"`
+	Usage        *TokenUsage `json:"usage,omitempty"`
+	// Unstructured is set when the answer was not the requested JSON; it is then kept as the notes,
+	// the documents are not valid and the verdict is not cached
+	Unstructured bool `json:"-"`
 }
 
 // TokenUsage represents token usage information
@@ -59,6 +112,46 @@ type TokenUsage struct {
 	TotalTokens      int `json:"totalTokens"`
 }
 
+// DeskOptions holds policy settings for the desk service
+type DeskOptions struct {
+	// RequireSignaturesForCompletion blocks completing a work paper while signatures are pending or rejected
+	RequireSignaturesForCompletion bool
+	// MinSigners is the minimum number of signers a work paper must keep (0 disables the check)
+	MinSigners int
+	// DownloadConcurrency is the number of Google Drive files downloaded in parallel when checking
+	// a document (DefaultDownloadConcurrency when not set)
+	DownloadConcurrency int
+	// CheckConcurrency is the number of notes checked in parallel when checking all documents of
+	// a work paper (DefaultCheckConcurrency when not set)
+	CheckConcurrency int
+	// VerdictCacheTTL is how long an LLM verdict is reused for the same documents and work paper item
+	// (DefaultVerdictCacheTTL when not set)
+	VerdictCacheTTL time.Duration
+}
+
+// DefaultDownloadConcurrency is used when DeskOptions.DownloadConcurrency is not set
+const DefaultDownloadConcurrency = 5
+
+// DefaultCheckConcurrency is used when DeskOptions.CheckConcurrency is not set
+const DefaultCheckConcurrency = 3
+
+// DefaultVerdictCacheTTL is used when DeskOptions.VerdictCacheTTL is not set
+const DefaultVerdictCacheTTL = 7 * 24 * time.Hour
+
+// SignaturesIncompleteError is returned when a work paper cannot be completed because
+// some of its signers have not signed yet or have rejected it
+type SignaturesIncompleteError struct {
+	Outstanding []*entity.WorkPaperSignature
+}
+
+func (e *SignaturesIncompleteError) Error() string {
+	return fmt.Sprintf("%s: %d signer(s) outstanding", entity.ErrSignaturesIncomplete.Error(), len(e.Outstanding))
+}
+
+func (e *SignaturesIncompleteError) Unwrap() error {
+	return entity.ErrSignaturesIncomplete
+}
+
 // DeskService defines the interface for desk operations
 type DeskService interface {
 	// Work Paper Item operations
@@ -70,6 +163,7 @@ type DeskService interface {
 	ActivateWorkPaperItem(ctx context.Context, id string) error
 	ListWorkPaperItems(ctx context.Context, params *entity.PaginationParams) (*entity.WorkPaperItemListResponse, error)
 	GetActiveWorkPaperItems(ctx context.Context) ([]*entity.WorkPaperItem, error)
+	ReorderWorkPaperItems(ctx context.Context, positions []WorkPaperItemPosition) ([]*entity.WorkPaperItem, error)
 
 	// Organization operations
 	GetOrganizations(ctx context.Context, page, limit int, sort string) (*entity.OrganizationListResponse, error)
@@ -79,16 +173,24 @@ type DeskService interface {
 	CreateWorkPaper(ctx context.Context, req *CreateWorkPaperRequest) (*entity.WorkPaper, error)
 	GetWorkPaper(ctx context.Context, id string) (*entity.WorkPaper, error)
 	GetWorkPaperByOrganizationYearSemester(ctx context.Context, organizationID string, year, semester int) (*entity.WorkPaper, error)
-	UpdateWorkPaperStatus(ctx context.Context, id string, status string) error
+	UpdateWorkPaperStatus(ctx context.Context, id string, status string, expectedVersion *int) error
 	ListWorkPapers(ctx context.Context, params *ListWorkPapersRequest) ([]*entity.WorkPaper, int64, error)
 	ListWorkPapersByOrganization(ctx context.Context, organizationID string) ([]*entity.WorkPaper, error)
 
 	// Work Paper Note operations
 	GetWorkPaperNotes(ctx context.Context, workPaperID string) ([]*entity.WorkPaperNote, error)
+	EnsureWorkPaperNotes(ctx context.Context, workPaperID string) ([]*entity.WorkPaperNote, error)
+	PruneInactiveWorkPaperNotes(ctx context.Context, workPaperID string) ([]*entity.WorkPaperNote, error)
+	GetFilteredWorkPaperNotes(ctx context.Context, workPaperID string, filter *WorkPaperNoteFilter) ([]*entity.WorkPaperNote, error)
+	GetWorkPaperProgress(ctx context.Context, workPaperID string) (*WorkPaperNoteProgress, error)
+	GetWorkPaperNotesNeedingAttention(ctx context.Context, workPaperID string) ([]*entity.WorkPaperNote, error)
 	GetWorkPaperNoteByID(ctx context.Context, noteID string) (*entity.WorkPaperNote, error)
-	UpdateWorkPaperNoteLink(ctx context.Context, noteID string, driveLink string) (*entity.WorkPaperNote, error)
-	CheckDocument(ctx context.Context, noteID string) (*CheckDocumentResponse, error)
-	UpdateWorkPaperNoteValidation(ctx context.Context, noteID string, isValid *bool, notes string) (*entity.WorkPaperNote, error)
+	UpdateWorkPaperNoteLink(ctx context.Context, noteID string, driveLink string, expectedVersion *int) (*entity.WorkPaperNote, error)
+	CheckDocument(ctx context.Context, noteID string, skipCache bool, llmOpts LLMOptions) (*CheckDocumentResponse, error)
+	CheckWorkPaperDocuments(ctx context.Context, workPaperID string, onlyChanged, skipCache bool) (*CheckWorkPaperDocumentsResponse, error)
+	UpdateWorkPaperNoteValidation(ctx context.Context, noteID string, isValid *bool, notes string, expectedVersion *int) (*entity.WorkPaperNote, error)
+	GetWorkPaperNoteCheckHistory(ctx context.Context, noteID string) ([]*entity.WorkPaperNoteCheckHistory, error)
+	WriteWorkPaperDocumentsZip(ctx context.Context, workPaperID string, w io.Writer) error
 
 	// Work Paper Signature operations
 	CreateWorkPaperSignature(ctx context.Context, req *CreateWorkPaperSignatureRequest) (*entity.WorkPaperSignature, error)
@@ -149,6 +251,17 @@ type UpdateWorkPaperItemRequest struct {
 	IsActive     *bool      `json:"is_active"`
 }
 
+// WorkPaperItemPosition is the new place of a work paper item in the hierarchy
+type WorkPaperItemPosition struct {
+	ID        uuid.UUID
+	SortOrder int
+	// MoveParent moves the item under ParentID, or to the top level when ParentID is nil
+	MoveParent bool
+	ParentID   *uuid.UUID
+	// Level overrides the hierarchy level; when the parent moves it defaults to one below the new parent
+	Level *int
+}
+
 type ListWorkPaperItemsRequest struct {
 	Search   string `json:"search"`
 	IsActive *bool  `json:"is_active"`
@@ -165,12 +278,59 @@ type ListWorkPapersRequest struct {
 	Year           *int   `json:"year"`
 	Semester       *int   `json:"semester"`
 	Status         string `json:"status"`
+	Completion     string `json:"completion"`
+	Page           int    `json:"page"`
+	PageSize       int    `json:"page_size"`
+}
+
+// WorkPaperNoteFilter narrows the notes returned for a work paper by validation state.
+// When both flags are set, notes matching either condition are returned.
+type WorkPaperNoteFilter struct {
+	OnlyInvalid bool `json:"only_invalid"`
+	Unchecked   bool `json:"unchecked"`
+}
+
+// WorkPaperNoteProgress summarizes the link and validation state of all notes in a work paper
+type WorkPaperNoteProgress struct {
+	Total     int `json:"total"`
+	WithLink  int `json:"with_link"`
+	Valid     int `json:"valid"`
+	Invalid   int `json:"invalid"`
+	Unchecked int `json:"unchecked"`
+	// Percentage is the share of notes marked valid, from 0 to 100
+	Percentage float64 `json:"percentage"`
 }
 
 type CheckDocumentResponse struct {
-	IsValid bool   `json:"isValid"`
-	Notes   string `json:"notes"`
-	Model   string `json:"model"`
+	IsValid      bool        `json:"isValid"`
+	Notes        string      `json:"notes"`
+	Confidence   *float64    `json:"confidence"`
+	MissingItems []string    `json:"missingItems"`
+	Model        string      `json:"model"`
+	Usage        *TokenUsage `json:"usage,omitempty"`
+	// Cached is set when the verdict was reused from an earlier check instead of asking the LLM
+	Cached bool `json:"cached"`
+}
+
+// NoteCheckResult is the outcome of checking one note in a work paper batch check
+type NoteCheckResult struct {
+	NoteID  string `json:"noteId"`
+	Skipped bool   `json:"skipped"`
+	IsValid *bool  `json:"isValid,omitempty"`
+	Notes   string `json:"notes,omitempty"`
+	Model   string `json:"model,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// CheckWorkPaperDocumentsResponse summarizes a batch check of a work paper's notes.
+// Usage adds up the tokens of every note that was checked.
+type CheckWorkPaperDocumentsResponse struct {
+	WorkPaperID string             `json:"workPaperId"`
+	Checked     int                `json:"checked"`
+	Skipped     int                `json:"skipped"`
+	Failed      int                `json:"failed"`
+	Usage       TokenUsage         `json:"usage"`
+	Results     []*NoteCheckResult `json:"results"`
 }
 
 // Work Paper Signature DTOs
@@ -211,6 +371,13 @@ type ManageSignersRequest struct {
 	WorkPaperID string             `json:"work_paper_id" validate:"required"`
 	Action      string             `json:"action" validate:"required,oneof=add remove replace"`
 	Signers     []CreateSignerData `json:"signers" validate:"required"`
+	// Force lets remove and replace go ahead when they target signed signatures; those are kept
+	// and reported as preserved instead of failing with entity.ErrSignedSignatureRemoval
+	Force bool `json:"force"`
+
+	// ActorID and ActorName identify the caller in the signature audit log
+	ActorID   string `json:"-"`
+	ActorName string `json:"-"`
 }
 
 // CreateSignerData represents signer data for management operations
@@ -227,6 +394,8 @@ type ManageSignersResponse struct {
 	WorkPaperID string           `json:"work_paper_id"`
 	Action      string           `json:"action"`
 	Signers     []SignerResponse `json:"signers"`
+	Preserved   []SignerResponse `json:"preserved"`
+	Removed     []SignerResponse `json:"removed"`
 	Message     string           `json:"message"`
 }
 
@@ -306,19 +475,6 @@ func (req *ManageSignersRequest) Validate() error {
 	return validation.ValidateStruct(req)
 }
 
-func (req *ListWorkPaperSignaturesRequest) Validate() error {
-	if req.Page < 1 {
-		return validation.NewError("page", "Page must be at least 1")
-	}
-	if req.Limit < 1 || req.Limit > 100 {
-		return validation.NewError("limit", "Limit must be between 1 and 100")
-	}
-	if req.SortDirection != "" && req.SortDirection != "asc" && req.SortDirection != "desc" {
-		return validation.NewError("sort_direction", "Sort direction must be 'asc' or 'desc'")
-	}
-	return validation.ValidateStruct(req)
-}
-
 // Backward compatibility aliases (deprecated)
 type (
 	CreateMasterLakipItemRequest = CreateWorkPaperItemRequest
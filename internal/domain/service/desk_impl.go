@@ -4,13 +4,17 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 
 	"sandbox/internal/domain/entity"
 	"sandbox/internal/domain/repository"
+	"sandbox/pkg/database"
 	"sandbox/pkg/pagination"
 )
 
@@ -21,8 +25,14 @@ type deskService struct {
 	workPaperRepo     repository.WorkPaperRepository
 	workPaperNoteRepo repository.WorkPaperNoteRepository
 	signatureRepo     repository.WorkPaperSignatureRepository
+	checkHistoryRepo  repository.WorkPaperNoteCheckHistoryRepository
 	driveService      DriveService
 	llmService        LLMService
+	db                database.TxBeginner
+	options           DeskOptions
+
+	signatureAuditRepo repository.SignatureAuditLogRepository
+	verdictCacheRepo   repository.LLMVerdictCacheRepository
 }
 
 // NewDeskService creates a new desk service instance
@@ -32,8 +42,13 @@ func NewDeskService(
 	workPaperRepo repository.WorkPaperRepository,
 	workPaperNoteRepo repository.WorkPaperNoteRepository,
 	signatureRepo repository.WorkPaperSignatureRepository,
+	checkHistoryRepo repository.WorkPaperNoteCheckHistoryRepository,
+	signatureAuditRepo repository.SignatureAuditLogRepository,
+	verdictCacheRepo repository.LLMVerdictCacheRepository,
+	db database.TxBeginner,
 	driveService DriveService,
 	llmService LLMService,
+	options DeskOptions,
 ) DeskService {
 	return &deskService{
 		workPaperItemRepo: workPaperItemRepo,
@@ -41,8 +56,14 @@ func NewDeskService(
 		workPaperRepo:     workPaperRepo,
 		workPaperNoteRepo: workPaperNoteRepo,
 		signatureRepo:     signatureRepo,
+		checkHistoryRepo:  checkHistoryRepo,
 		driveService:      driveService,
 		llmService:        llmService,
+		db:                db,
+		options:           options,
+
+		signatureAuditRepo: signatureAuditRepo,
+		verdictCacheRepo:   verdictCacheRepo,
 	}
 }
 
@@ -168,14 +189,10 @@ func (s *deskService) ListWorkPaperItems(ctx context.Context, params *entity.Pag
 		Sorts:   []pagination.Sort{},
 	}
 
-	// Note: Search functionality with multiple fields needs custom query building
-	// For now, we'll search by statement only when search is provided
+	// Search matches the statement, number or explanation
 	if params.Search != "" {
-		queryParams.Filters = append(queryParams.Filters, pagination.Filter{
-			Field:    "statement",
-			Operator: "ilike",
-			Value:    params.Search,
-		})
+		queryParams.OrGroups = append(queryParams.OrGroups,
+			pagination.SearchGroup(params.Search, "statement", "number", "explanation"))
 	}
 
 	// Add type filter if provided
@@ -230,18 +247,17 @@ func (s *deskService) ListWorkPaperItems(ctx context.Context, params *entity.Pag
 	}
 
 	// Calculate pagination metadata
-	totalPages := (int(totalCount) + params.PageSize - 1) / params.PageSize
-	metadata := entity.WorkPaperItemMetadata{
-		Count:       len(responseItems),
-		TotalCount:  int(totalCount),
-		CurrentPage: params.Page,
-		TotalPage:   totalPages,
-		PageSize:    params.PageSize,
-	}
+	page := pagination.BuildMetadata(totalCount, params.Page, params.PageSize)
 
 	return &entity.WorkPaperItemListResponse{
-		Data:     responseItems,
-		Metadata: metadata,
+		Data: responseItems,
+		Metadata: entity.WorkPaperItemMetadata{
+			Count:       len(responseItems),
+			TotalCount:  int(page.TotalCount),
+			CurrentPage: page.CurrentPage,
+			TotalPage:   page.TotalPage,
+			PageSize:    page.PageSize,
+		},
 	}, nil
 }
 
@@ -254,6 +270,127 @@ func (s *deskService) GetActiveWorkPaperItems(ctx context.Context) ([]*entity.Wo
 	return items, nil
 }
 
+// ReorderWorkPaperItems moves many work paper items at once and returns them, and the descendants whose
+// level followed, in their new order. All items must exist, each may appear only once, and no item may end
+// up under its own descendant. The items are read and locked in the transaction that saves the new
+// positions, so concurrent reorders cannot build on each other's stale tree.
+func (s *deskService) ReorderWorkPaperItems(ctx context.Context, positions []WorkPaperItemPosition) ([]*entity.WorkPaperItem, error) {
+	var changed []*entity.WorkPaperItem
+	err := database.WithinTx(ctx, s.db, func(tx database.DBTx) error {
+		itemRepo := s.workPaperItemRepo.WithTransaction(tx)
+
+		allItems, err := itemRepo.ListAllForUpdate(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get work paper items: %w", err)
+		}
+
+		changed, err = repositionWorkPaperItems(allItems, positions)
+		if err != nil {
+			return err
+		}
+
+		if err := itemRepo.UpdatePositions(ctx, changed); err != nil {
+			return fmt.Errorf("failed to reorder work paper items: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(changed, func(i, j int) bool {
+		if changed[i].Level != changed[j].Level {
+			return changed[i].Level < changed[j].Level
+		}
+		return changed[i].SortOrder < changed[j].SortOrder
+	})
+	return changed, nil
+}
+
+// repositionWorkPaperItems applies positions to allItems and returns the items to save: the moved ones and
+// every descendant whose level changed with them. An explicit level in a position is kept as given.
+func repositionWorkPaperItems(allItems []*entity.WorkPaperItem, positions []WorkPaperItemPosition) ([]*entity.WorkPaperItem, error) {
+	itemsByID := make(map[uuid.UUID]*entity.WorkPaperItem, len(allItems))
+	for _, item := range allItems {
+		itemsByID[item.ID] = item
+	}
+
+	moved := make([]*entity.WorkPaperItem, 0, len(positions))
+	seen := make(map[uuid.UUID]bool, len(positions))
+	explicitLevel := make(map[uuid.UUID]bool)
+	for _, position := range positions {
+		if seen[position.ID] {
+			return nil, fmt.Errorf("%w: %s", entity.ErrDuplicateWorkPaperItemPosition, position.ID)
+		}
+		seen[position.ID] = true
+
+		item, ok := itemsByID[position.ID]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", entity.ErrWorkPaperItemNotFound, position.ID)
+		}
+
+		item.SortOrder = position.SortOrder
+		if position.MoveParent {
+			item.ParentID = position.ParentID
+			item.Level = 1
+			if position.ParentID != nil {
+				parent, ok := itemsByID[*position.ParentID]
+				if !ok {
+					return nil, fmt.Errorf("%w: parent %s", entity.ErrWorkPaperItemNotFound, *position.ParentID)
+				}
+				item.Level = parent.Level + 1
+			}
+		}
+		if position.Level != nil {
+			item.Level = *position.Level
+			explicitLevel[item.ID] = true
+		}
+		moved = append(moved, item)
+	}
+
+	// Walk up from every moved item; coming back to it, or walking longer than there are items, means a cycle
+	for _, item := range moved {
+		parentID := item.ParentID
+		for steps := 0; parentID != nil; steps++ {
+			if *parentID == item.ID || steps > len(allItems) {
+				return nil, fmt.Errorf("%w: %s", entity.ErrWorkPaperItemHierarchyCycle, item.ID)
+			}
+			parent, ok := itemsByID[*parentID]
+			if !ok {
+				break
+			}
+			parentID = parent.ParentID
+		}
+	}
+
+	// The descendants of a moved item stay one level below their parent
+	children := make(map[uuid.UUID][]*entity.WorkPaperItem)
+	for _, item := range allItems {
+		if item.ParentID != nil {
+			children[*item.ParentID] = append(children[*item.ParentID], item)
+		}
+	}
+	changed := append([]*entity.WorkPaperItem{}, moved...)
+	queue := append([]*entity.WorkPaperItem{}, moved...)
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		for _, child := range children[parent.ID] {
+			if explicitLevel[child.ID] || child.Level == parent.Level+1 {
+				continue
+			}
+			child.Level = parent.Level + 1
+			if !seen[child.ID] {
+				seen[child.ID] = true
+				changed = append(changed, child)
+			}
+			queue = append(queue, child)
+		}
+	}
+
+	return changed, nil
+}
+
 // Organization operations
 
 func (s *deskService) GetOrganizations(ctx context.Context, page, limit int, sort string) (*entity.OrganizationListResponse, error) {
@@ -364,11 +501,27 @@ func (s *deskService) GetWorkPaperByOrganizationYearSemester(ctx context.Context
 	return workPaper, nil
 }
 
-func (s *deskService) UpdateWorkPaperStatus(ctx context.Context, id string, status string) error {
+// UpdateWorkPaperStatus changes the status of a work paper. When expectedVersion is set, the update
+// fails with entity.ErrVersionConflict if the work paper has changed since the caller read it.
+func (s *deskService) UpdateWorkPaperStatus(ctx context.Context, id string, status string, expectedVersion *int) error {
 	workPaper, err := s.workPaperRepo.GetByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to get work paper: %w", err)
 	}
+	if expectedVersion != nil && *expectedVersion != workPaper.Version {
+		return entity.ErrVersionConflict
+	}
+
+	if status == entity.WorkPaperStatusCompleted {
+		if err := s.ensureMinSigners(ctx, workPaper.ID); err != nil {
+			return err
+		}
+		if s.options.RequireSignaturesForCompletion {
+			if err := s.ensureSignaturesComplete(ctx, workPaper.ID); err != nil {
+				return err
+			}
+		}
+	}
 
 	err = workPaper.UpdateStatus(status)
 	if err != nil {
@@ -384,32 +537,21 @@ func (s *deskService) UpdateWorkPaperStatus(ctx context.Context, id string, stat
 }
 
 func (s *deskService) ListWorkPapers(ctx context.Context, req *ListWorkPapersRequest) ([]*entity.WorkPaper, int64, error) {
-	// Simplified implementation for now
-	workPapers, total, err := s.workPaperRepo.List(ctx, nil)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to list work papers: %w", err)
+	filter := &repository.WorkPaperFilter{
+		OrganizationID: req.OrganizationID,
+		Status:         req.Status,
+		Completion:     req.Completion,
+	}
+	if req.Year != nil {
+		filter.Year = *req.Year
+	}
+	if req.Semester != nil {
+		filter.Semester = *req.Semester
 	}
 
-	// Apply filters
-	if req.OrganizationID != "" || req.Year != nil || req.Semester != nil || req.Status != "" {
-		var filteredWorkPapers []*entity.WorkPaper
-		for _, workPaper := range workPapers {
-			if req.OrganizationID != "" && workPaper.OrganizationID.String() != req.OrganizationID {
-				continue
-			}
-			if req.Year != nil && workPaper.Year != *req.Year {
-				continue
-			}
-			if req.Semester != nil && workPaper.Semester != *req.Semester {
-				continue
-			}
-			if req.Status != "" && workPaper.Status != req.Status {
-				continue
-			}
-			filteredWorkPapers = append(filteredWorkPapers, workPaper)
-		}
-		workPapers = filteredWorkPapers
-		total = int64(len(filteredWorkPapers))
+	workPapers, total, err := s.workPaperRepo.GetByFilter(ctx, filter, req.Page, req.PageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list work papers: %w", err)
 	}
 
 	// Fetch organization data for each work paper
@@ -445,6 +587,169 @@ func (s *deskService) GetWorkPaperNotes(ctx context.Context, workPaperID string)
 	return notes, nil
 }
 
+// EnsureWorkPaperNotes creates notes for active master items that the work paper is missing.
+// Completed papers are left untouched. It returns the notes that were created.
+func (s *deskService) EnsureWorkPaperNotes(ctx context.Context, workPaperID string) ([]*entity.WorkPaperNote, error) {
+	workPaper, err := s.workPaperRepo.GetByID(ctx, workPaperID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work paper: %w", err)
+	}
+
+	if workPaper.Status == entity.WorkPaperStatusCompleted {
+		return nil, nil
+	}
+
+	masterItems, err := s.workPaperItemRepo.ListActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get master items: %w", err)
+	}
+
+	notes, err := s.workPaperNoteRepo.GetByWorkPaper(ctx, workPaperID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work paper notes: %w", err)
+	}
+
+	if len(notes) >= len(masterItems) {
+		return nil, nil
+	}
+
+	existing := make(map[uuid.UUID]bool, len(notes))
+	for _, note := range notes {
+		existing[note.MasterItemID] = true
+	}
+
+	var missing []*entity.WorkPaperNote
+	for _, masterItem := range masterItems {
+		if existing[masterItem.ID] {
+			continue
+		}
+		note, err := entity.NewWorkPaperNote(workPaper.ID, masterItem.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create work paper note: %w", err)
+		}
+		missing = append(missing, note)
+	}
+
+	if len(missing) == 0 {
+		return nil, nil
+	}
+
+	createdNotes, err := s.workPaperNoteRepo.CreateBatch(ctx, missing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create missing work paper notes: %w", err)
+	}
+
+	for _, note := range createdNotes {
+		log.Printf("Created missing work paper note %s (master item %s) for work paper %s", note.ID, note.MasterItemID, workPaperID)
+	}
+
+	return createdNotes, nil
+}
+
+// PruneInactiveWorkPaperNotes deletes the notes of a draft work paper whose master item was deactivated or
+// deleted since the paper was created. Papers past draft keep all their notes and return ErrWorkPaperNotDraft.
+// It returns the notes that were deleted.
+func (s *deskService) PruneInactiveWorkPaperNotes(ctx context.Context, workPaperID string) ([]*entity.WorkPaperNote, error) {
+	workPaper, err := s.workPaperRepo.GetByID(ctx, workPaperID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work paper: %w", err)
+	}
+
+	if workPaper.Status != entity.WorkPaperStatusDraft {
+		return nil, fmt.Errorf("%w: status is %s", entity.ErrWorkPaperNotDraft, workPaper.Status)
+	}
+
+	// Read the master items directly rather than trusting note.MasterItem, which is also nil when loading it failed
+	masterItems, err := s.workPaperItemRepo.ListActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get master items: %w", err)
+	}
+
+	active := make(map[uuid.UUID]bool, len(masterItems))
+	for _, masterItem := range masterItems {
+		active[masterItem.ID] = true
+	}
+
+	notes, err := s.workPaperNoteRepo.GetByWorkPaper(ctx, workPaperID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work paper notes: %w", err)
+	}
+
+	var pruned []*entity.WorkPaperNote
+	for _, note := range notes {
+		if active[note.MasterItemID] {
+			continue
+		}
+		if err := s.workPaperNoteRepo.Delete(ctx, note.ID.String()); err != nil {
+			return nil, fmt.Errorf("failed to delete work paper note %s: %w", note.ID, err)
+		}
+		log.Printf("Pruned work paper note %s (inactive master item %s) from work paper %s", note.ID, note.MasterItemID, workPaperID)
+		pruned = append(pruned, note)
+	}
+
+	return pruned, nil
+}
+
+func (s *deskService) GetFilteredWorkPaperNotes(ctx context.Context, workPaperID string, filter *WorkPaperNoteFilter) ([]*entity.WorkPaperNote, error) {
+	notes, err := s.workPaperNoteRepo.GetByWorkPaper(ctx, workPaperID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work paper notes: %w", err)
+	}
+
+	if filter == nil || (!filter.OnlyInvalid && !filter.Unchecked) {
+		return notes, nil
+	}
+
+	filtered := make([]*entity.WorkPaperNote, 0, len(notes))
+	for _, note := range notes {
+		if filter.Unchecked && note.IsValid == nil {
+			filtered = append(filtered, note)
+			continue
+		}
+		if filter.OnlyInvalid && note.IsValid != nil && !*note.IsValid {
+			filtered = append(filtered, note)
+		}
+	}
+
+	return filtered, nil
+}
+
+// GetWorkPaperProgress reports how complete a work paper is, counted in the database without loading the notes
+func (s *deskService) GetWorkPaperProgress(ctx context.Context, workPaperID string) (*WorkPaperNoteProgress, error) {
+	stats, err := s.workPaperNoteRepo.GetStats(ctx, workPaperID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work paper progress: %w", err)
+	}
+
+	progress := &WorkPaperNoteProgress{
+		Total:     stats.Total,
+		WithLink:  stats.WithLink,
+		Valid:     stats.Valid,
+		Invalid:   stats.Invalid,
+		Unchecked: stats.Total - stats.Valid - stats.Invalid,
+	}
+	if stats.Total > 0 {
+		progress.Percentage = math.Round(float64(stats.Valid)/float64(stats.Total)*10000) / 100
+	}
+
+	return progress, nil
+}
+
+// GetWorkPaperNotesNeedingAttention lists the notes of a work paper that still lack a Drive link or were
+// marked invalid, in the order of their master items
+func (s *deskService) GetWorkPaperNotesNeedingAttention(ctx context.Context, workPaperID string) ([]*entity.WorkPaperNote, error) {
+	if _, err := s.workPaperRepo.GetByID(ctx, workPaperID); err != nil {
+		return nil, fmt.Errorf("failed to get work paper: %w", err)
+	}
+
+	notes, err := s.workPaperNoteRepo.GetNotesNeedingAttention(ctx, workPaperID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work paper notes needing attention: %w", err)
+	}
+
+	return notes, nil
+}
+
 func (s *deskService) GetWorkPaperNoteByID(ctx context.Context, noteID string) (*entity.WorkPaperNote, error) {
 	note, err := s.workPaperNoteRepo.GetByID(ctx, noteID)
 	if err != nil {
@@ -453,11 +758,16 @@ func (s *deskService) GetWorkPaperNoteByID(ctx context.Context, noteID string) (
 	return note, nil
 }
 
-func (s *deskService) UpdateWorkPaperNoteLink(ctx context.Context, noteID string, driveLink string) (*entity.WorkPaperNote, error) {
+// UpdateWorkPaperNoteLink sets the Google Drive link of a note. When expectedVersion is set, the update
+// fails with entity.ErrVersionConflict if the note has changed since the caller read it.
+func (s *deskService) UpdateWorkPaperNoteLink(ctx context.Context, noteID string, driveLink string, expectedVersion *int) (*entity.WorkPaperNote, error) {
 	note, err := s.workPaperNoteRepo.GetByID(ctx, noteID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get work paper note: %w", err)
 	}
+	if expectedVersion != nil && *expectedVersion != note.Version {
+		return nil, entity.ErrVersionConflict
+	}
 
 	note.UpdateGDriveLink(driveLink)
 
@@ -469,12 +779,24 @@ func (s *deskService) UpdateWorkPaperNoteLink(ctx context.Context, noteID string
 	return updatedNote, nil
 }
 
-func (s *deskService) CheckDocument(ctx context.Context, noteID string) (*CheckDocumentResponse, error) {
+// CheckDocument checks the documents of a note. A verdict cached for the same documents and work paper
+// item is reused unless skipCache is set. llmOpts overrides the model and temperature for this check only.
+func (s *deskService) CheckDocument(ctx context.Context, noteID string, skipCache bool, llmOpts LLMOptions) (*CheckDocumentResponse, error) {
+	if err := llmOpts.Validate(); err != nil {
+		return nil, err
+	}
+
 	note, err := s.workPaperNoteRepo.GetByID(ctx, noteID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get work paper note: %w", err)
 	}
 
+	return s.checkNote(ctx, note, skipCache, llmOpts)
+}
+
+// checkNote runs the LLM check over the documents behind the note's Google Drive link and saves the
+// verdict on the note, keeping the previous one in the check history
+func (s *deskService) checkNote(ctx context.Context, note *entity.WorkPaperNote, skipCache bool, llmOpts LLMOptions) (*CheckDocumentResponse, error) {
 	masterItem, err := s.workPaperItemRepo.GetByID(ctx, note.MasterItemID.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get master item: %w", err)
@@ -490,23 +812,9 @@ func (s *deskService) CheckDocument(ctx context.Context, noteID string) (*CheckD
 		}
 
 		log.Printf("Found %d files from Google Drive", len(files))
-		for _, file := range files {
-			log.Printf("Downloading file: %s (ID: %s, Type: %s)", file.Name, file.ID, file.Type)
-			data, err := s.driveService.DownloadFile(ctx, file.ID)
-			if err != nil {
-				log.Printf("Failed to download file %s: %v", file.Name, err)
-				// Log error but continue with other files
-				continue
-			}
-			log.Printf("Successfully downloaded file %s (%d bytes)", file.Name, len(data))
-			documents = append(documents, DocumentFile{
-				Name: file.Name,
-				Data: data,
-				Type: file.Type,
-			})
-		}
+		documents = s.downloadDocuments(ctx, files)
 	} else {
-		log.Printf("No Google Drive link found for note ID: %s", noteID)
+		log.Printf("No Google Drive link found for note ID: %s", note.ID)
 	}
 
 	log.Printf("Total documents prepared for LLM: %d", len(documents))
@@ -517,10 +825,13 @@ func (s *deskService) CheckDocument(ctx context.Context, noteID string) (*CheckD
 		Statement:    masterItem.Statement,
 		Explanation:  masterItem.Explanation,
 		FillingGuide: masterItem.FillingGuide,
+		ItemType:     masterItem.Type,
 		Documents:    documents,
+		Model:        llmOpts.Model,
+		Temperature:  llmOpts.Temperature,
 	}
 
-	llmResp, err := s.llmService.CheckDocument(ctx, llmReq)
+	llmResp, cached, err := s.checkDocumentsCached(ctx, llmReq, masterItem, skipCache)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check document: %w", err)
 	}
@@ -536,10 +847,14 @@ func (s *deskService) CheckDocument(ctx context.Context, noteID string) (*CheckD
 	}
 
 	llmResponseData := entity.LLMResponse{
-		Note:    llmResp.Notes,
-		IsValid: llmResp.IsValid,
-		Model:   llmResp.Model,
-		Usage:   usage,
+		Note:         llmResp.Notes,
+		IsValid:      llmResp.IsValid,
+		Confidence:   llmResp.Confidence,
+		MissingItems: llmResp.MissingItems,
+		Model:        llmResp.Model,
+		Usage:        usage,
+		GDriveLink:   note.GetGDriveLink(),
+		Cached:       cached,
 	}
 
 	note.UpdateLLMResult(llmResp.IsValid, llmResp.Notes, llmResponseData)
@@ -549,18 +864,172 @@ func (s *deskService) CheckDocument(ctx context.Context, noteID string) (*CheckD
 		return nil, fmt.Errorf("failed to update work paper note: %w", err)
 	}
 
+	// Keep a history entry so earlier verdicts survive subsequent checks
+	history := entity.NewWorkPaperNoteCheckHistory(note.ID, llmResponseData)
+	if err := s.checkHistoryRepo.Create(ctx, history); err != nil {
+		return nil, fmt.Errorf("failed to record check history: %w", err)
+	}
+
+	missingItems := llmResp.MissingItems
+	if missingItems == nil {
+		missingItems = []string{}
+	}
+
 	return &CheckDocumentResponse{
-		IsValid: llmResp.IsValid,
-		Notes:   llmResp.Notes,
-		Model:   llmResp.Model,
+		IsValid:      llmResp.IsValid,
+		Notes:        llmResp.Notes,
+		Confidence:   llmResp.Confidence,
+		MissingItems: missingItems,
+		Model:        llmResp.Model,
+		Usage:        llmResp.Usage,
+		Cached:       cached,
 	}, nil
 }
 
-func (s *deskService) UpdateWorkPaperNoteValidation(ctx context.Context, noteID string, isValid *bool, notes string) (*entity.WorkPaperNote, error) {
+// CheckWorkPaperDocuments checks every note of a work paper that has a Google Drive link, with at most
+// options.CheckConcurrency checks in flight. When onlyChanged is set, notes whose link is the one their
+// last check read are skipped. Each note is saved as soon as its own check finishes, so one failed check
+// does not lose the others; failures are reported per note and no new check is started once ctx is done.
+// Cached verdicts are reused unless skipCache is set.
+func (s *deskService) CheckWorkPaperDocuments(ctx context.Context, workPaperID string, onlyChanged, skipCache bool) (*CheckWorkPaperDocumentsResponse, error) {
+	notes, err := s.workPaperNoteRepo.GetByWorkPaper(ctx, workPaperID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get work paper notes: %w", err)
+	}
+
+	concurrency := s.options.CheckConcurrency
+	if concurrency <= 0 {
+		concurrency = DefaultCheckConcurrency
+	}
+
+	results := make([]*NoteCheckResult, len(notes))
+	usages := make([]*TokenUsage, len(notes))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, note := range notes {
+		results[i] = &NoteCheckResult{NoteID: note.ID.String()}
+		if note.GetGDriveLink() == "" || (onlyChanged && !note.LinkChangedSinceCheck()) {
+			results[i].Skipped = true
+			continue
+		}
+
+		// Wait for a free slot; once the request is cancelled the remaining notes fail without being checked
+		select {
+		case <-ctx.Done():
+			results[i].Error = ctx.Err().Error()
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int, note *entity.WorkPaperNote) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			checkResp, err := s.checkNote(ctx, note, skipCache, LLMOptions{})
+			if err != nil {
+				log.Printf("Failed to check work paper note %s: %v", note.ID, err)
+				results[i].Error = err.Error()
+				return
+			}
+			results[i].IsValid = &checkResp.IsValid
+			results[i].Notes = checkResp.Notes
+			results[i].Model = checkResp.Model
+			usages[i] = checkResp.Usage
+		}(i, note)
+	}
+
+	wg.Wait()
+
+	response := &CheckWorkPaperDocumentsResponse{
+		WorkPaperID: workPaperID,
+		Results:     results,
+	}
+	for i, result := range results {
+		switch {
+		case result.Skipped:
+			response.Skipped++
+		case result.Error != "":
+			response.Failed++
+		default:
+			response.Checked++
+		}
+		if usage := usages[i]; usage != nil {
+			response.Usage.PromptTokens += usage.PromptTokens
+			response.Usage.CompletionTokens += usage.CompletionTokens
+			response.Usage.TotalTokens += usage.TotalTokens
+		}
+	}
+
+	return response, nil
+}
+
+// downloadDocuments downloads the files with at most options.DownloadConcurrency downloads in flight,
+// keeping the order of files. Failed downloads are logged and skipped, and no new download is started
+// once ctx is done.
+func (s *deskService) downloadDocuments(ctx context.Context, files []*DriveFile) []DocumentFile {
+	concurrency := s.options.DownloadConcurrency
+	if concurrency <= 0 {
+		concurrency = DefaultDownloadConcurrency
+	}
+
+	results := make([]*DocumentFile, len(files))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+downloads:
+	for i, file := range files {
+		// Wait for a free slot, giving up on the remaining files once the request is cancelled
+		select {
+		case <-ctx.Done():
+			log.Printf("Stopping downloads, %d of %d files not started: %v", len(files)-i, len(files), ctx.Err())
+			break downloads
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int, file *DriveFile) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			log.Printf("Downloading file: %s (ID: %s, Type: %s)", file.Name, file.ID, file.Type)
+			data, err := s.driveService.DownloadFile(ctx, file.ID)
+			if err != nil {
+				// Log error but continue with other files
+				log.Printf("Failed to download file %s: %v", file.Name, err)
+				return
+			}
+			log.Printf("Successfully downloaded file %s (%d bytes)", file.Name, len(data))
+			results[i] = &DocumentFile{
+				Name: file.Name,
+				Data: data,
+				Type: file.Type,
+			}
+		}(i, file)
+	}
+
+	wg.Wait()
+
+	documents := make([]DocumentFile, 0, len(files))
+	for _, document := range results {
+		if document != nil {
+			documents = append(documents, *document)
+		}
+	}
+	return documents
+}
+
+// UpdateWorkPaperNoteValidation sets the validation result of a note. When expectedVersion is set, the
+// update fails with entity.ErrVersionConflict if the note has changed since the caller read it.
+func (s *deskService) UpdateWorkPaperNoteValidation(ctx context.Context, noteID string, isValid *bool, notes string, expectedVersion *int) (*entity.WorkPaperNote, error) {
 	note, err := s.workPaperNoteRepo.GetByID(ctx, noteID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get work paper note: %w", err)
 	}
+	if expectedVersion != nil && *expectedVersion != note.Version {
+		return nil, entity.ErrVersionConflict
+	}
 
 	note.UpdateValidation(isValid, notes)
 
@@ -572,6 +1041,23 @@ func (s *deskService) UpdateWorkPaperNoteValidation(ctx context.Context, noteID
 	return updatedNote, nil
 }
 
+func (s *deskService) GetWorkPaperNoteCheckHistory(ctx context.Context, noteID string) ([]*entity.WorkPaperNoteCheckHistory, error) {
+	// Ensure the note exists before listing its history
+	if _, err := uuid.Parse(noteID); err != nil {
+		return nil, fmt.Errorf("%w: %s", entity.ErrWorkPaperNoteNotFound, noteID)
+	}
+	if _, err := s.workPaperNoteRepo.GetByID(ctx, noteID); err != nil {
+		return nil, fmt.Errorf("failed to get work paper note: %w", err)
+	}
+
+	history, err := s.checkHistoryRepo.ListByNoteID(ctx, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get check history: %w", err)
+	}
+
+	return history, nil
+}
+
 // Backward compatibility methods (deprecated)
 // Note: These methods are provided for backward compatibility but will be removed in future versions
 // Please use the new WorkPaper* methods instead
@@ -651,6 +1137,17 @@ func (s *deskService) UpdatePaperWorkStatus(ctx context.Context, id string, stat
 		return fmt.Errorf("failed to get work paper: %w", err)
 	}
 
+	if status == entity.WorkPaperStatusCompleted {
+		if err := s.ensureMinSigners(ctx, workPaper.ID); err != nil {
+			return err
+		}
+		if s.options.RequireSignaturesForCompletion {
+			if err := s.ensureSignaturesComplete(ctx, workPaper.ID); err != nil {
+				return err
+			}
+		}
+	}
+
 	err = workPaper.UpdateStatus(status)
 	if err != nil {
 		return fmt.Errorf("failed to update work paper status: %w", err)
@@ -1004,6 +1501,11 @@ func (s *deskService) GetWorkPapersWithSignatures(ctx context.Context, page, lim
 	return workPapersWithSignatures, nil
 }
 
+// ManageSigners adds, removes or replaces the signers of a work paper. Signers that already have a
+// signature are left as they are, so repeating a request changes nothing. Signed signatures are never
+// deleted: remove and replace fail with entity.ErrSignedSignatureRemoval when they would drop one, unless
+// req.Force is set, in which case the signed signature is kept and reported as preserved.
+// The changes and their signature audit log entries are saved in one transaction.
 func (s *deskService) ManageSigners(ctx context.Context, req *ManageSignersRequest) (*ManageSignersResponse, error) {
 	// Parse work paper ID
 	workPaperID, err := uuid.Parse(req.WorkPaperID)
@@ -1017,115 +1519,197 @@ func (s *deskService) ManageSigners(ctx context.Context, req *ManageSignersReque
 		return nil, fmt.Errorf("work paper not found: %w", err)
 	}
 
-	var signerResponses []SignerResponse
+	var added []*entity.WorkPaperSignature
+	var changes *signerChanges
+	err = database.WithinTx(ctx, s.db, func(tx database.DBTx) error {
+		signatureRepo := s.signatureRepo.WithTransaction(tx)
 
-	switch req.Action {
-	case "add":
-		for _, signerData := range req.Signers {
-			// Check if signature already exists
-			_, err := s.signatureRepo.GetByWorkPaperIDAndUserID(ctx, workPaperID, signerData.UserID)
-			if err == nil {
-				// Signature already exists, skip
-				continue
-			} else if err != entity.ErrSignatureNotFound {
-				return nil, fmt.Errorf("failed to check existing signature: %w", err)
+		existingSignatures, err := signatureRepo.GetByWorkPaperID(ctx, workPaperID)
+		if err != nil {
+			return fmt.Errorf("failed to get existing signatures: %w", err)
+		}
+
+		changes, err = planSignerChanges(req, existingSignatures)
+		if err != nil {
+			return err
+		}
+
+		// Make sure the change does not leave fewer signers than the policy requires
+		if req.Action != "add" {
+			if err := s.checkMinSigners(changes.remaining); err != nil {
+				return err
 			}
+		}
 
-			// Create new signature
+		for _, signature := range changes.remove {
+			if err := signatureRepo.Delete(ctx, signature.ID); err != nil {
+				return fmt.Errorf("failed to delete signature: %w", err)
+			}
+		}
+
+		added = make([]*entity.WorkPaperSignature, 0, len(changes.add))
+		for _, signerData := range changes.add {
 			signature, err := entity.NewWorkPaperSignature(workPaperID, signerData.UserID, signerData.UserName, signerData.SignatureType)
 			if err != nil {
-				return nil, fmt.Errorf("failed to create signature: %w", err)
+				return fmt.Errorf("failed to create signature: %w", err)
 			}
 
-			// Set user details
 			signature.SetUserDetails(signerData.UserEmail, signerData.UserRole)
 
-			// Save to database
-			err = s.signatureRepo.Create(ctx, signature)
-			if err != nil {
-				return nil, fmt.Errorf("failed to create signature: %w", err)
+			if err := signatureRepo.Create(ctx, signature); err != nil {
+				return fmt.Errorf("failed to create signature: %w", err)
 			}
+			added = append(added, signature)
+		}
+
+		return s.recordSignerAudit(ctx, tx, req, added, changes.remove, changes.preserve)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ManageSignersResponse{
+		WorkPaperID: req.WorkPaperID,
+		Action:      req.Action,
+		Signers:     toSignerResponses(added),
+		Preserved:   toSignerResponses(changes.preserve),
+		Removed:     toSignerResponses(changes.remove),
+		Message:     fmt.Sprintf("Successfully completed %s action on signers", req.Action),
+	}, nil
+}
 
-			signerResponses = append(signerResponses, SignerResponse{
-				SignatureID:   signature.ID.String(),
-				UserID:        signature.UserID,
-				UserName:      signature.UserName,
-				UserEmail:     signature.GetUserEmail(),
-				UserRole:      signature.GetUserRole(),
-				SignatureType: signature.SignatureType,
-				Status:        signature.Status,
-				CreatedAt:     signature.CreatedAt.Format("2006-01-02T15:04:05Z"),
-			})
+// signerChanges is what a signer management request does to the existing signatures of a work paper
+type signerChanges struct {
+	add      []CreateSignerData
+	remove   []*entity.WorkPaperSignature
+	preserve []*entity.WorkPaperSignature
+	// remaining is the number of pending and signed signatures left once the changes are applied
+	remaining int
+}
+
+// planSignerChanges works out which signers to add, remove and keep without touching the database
+func planSignerChanges(req *ManageSignersRequest, existing []*entity.WorkPaperSignature) (*signerChanges, error) {
+	byUser := make(map[string]*entity.WorkPaperSignature, len(existing))
+	for _, signature := range existing {
+		byUser[signature.UserID] = signature
+	}
+
+	changes := &signerChanges{}
+
+	// drop queues a signature for removal, unless it is signed
+	drop := func(signature *entity.WorkPaperSignature) error {
+		if signature.Status != entity.SignatureStatusSigned {
+			changes.remove = append(changes.remove, signature)
+			return nil
+		}
+		if !req.Force {
+			return fmt.Errorf("%w: %s (%s)", entity.ErrSignedSignatureRemoval, signature.UserName, signature.UserID)
 		}
+		changes.preserve = append(changes.preserve, signature)
+		return nil
+	}
 
-	case "remove":
+	requested := make(map[string]bool, len(req.Signers))
+	switch req.Action {
+	case "add", "replace":
 		for _, signerData := range req.Signers {
-			// Get existing signature
-			signature, err := s.signatureRepo.GetByWorkPaperIDAndUserID(ctx, workPaperID, signerData.UserID)
-			if err != nil {
-				if err == entity.ErrSignatureNotFound {
-					continue // Skip if not found
-				}
-				return nil, fmt.Errorf("failed to get signature: %w", err)
+			if requested[signerData.UserID] {
+				continue
 			}
+			requested[signerData.UserID] = true
 
-			// Delete signature
-			err = s.signatureRepo.Delete(ctx, signature.ID)
-			if err != nil {
-				return nil, fmt.Errorf("failed to delete signature: %w", err)
+			if signature, ok := byUser[signerData.UserID]; ok {
+				changes.preserve = append(changes.preserve, signature)
+				continue
 			}
+			changes.add = append(changes.add, signerData)
 		}
 
-	case "replace":
-		// First, remove all existing signatures for the work paper
-		existingSignatures, err := s.signatureRepo.GetByWorkPaperID(ctx, workPaperID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get existing signatures: %w", err)
-		}
-
-		for _, existingSig := range existingSignatures {
-			err = s.signatureRepo.Delete(ctx, existingSig.ID)
-			if err != nil {
-				return nil, fmt.Errorf("failed to delete existing signature: %w", err)
+		if req.Action == "replace" {
+			for _, signature := range existing {
+				if requested[signature.UserID] {
+					continue
+				}
+				if err := drop(signature); err != nil {
+					return nil, err
+				}
 			}
 		}
 
-		// Then add new signatures
+	case "remove":
 		for _, signerData := range req.Signers {
-			signature, err := entity.NewWorkPaperSignature(workPaperID, signerData.UserID, signerData.UserName, signerData.SignatureType)
-			if err != nil {
-				return nil, fmt.Errorf("failed to create signature: %w", err)
+			signature, ok := byUser[signerData.UserID]
+			if !ok || requested[signerData.UserID] {
+				continue
 			}
+			requested[signerData.UserID] = true
 
-			signature.SetUserDetails(signerData.UserEmail, signerData.UserRole)
-
-			err = s.signatureRepo.Create(ctx, signature)
-			if err != nil {
-				return nil, fmt.Errorf("failed to create signature: %w", err)
+			if err := drop(signature); err != nil {
+				return nil, err
 			}
-
-			signerResponses = append(signerResponses, SignerResponse{
-				SignatureID:   signature.ID.String(),
-				UserID:        signature.UserID,
-				UserName:      signature.UserName,
-				UserEmail:     signature.GetUserEmail(),
-				UserRole:      signature.GetUserRole(),
-				SignatureType: signature.SignatureType,
-				Status:        signature.Status,
-				CreatedAt:     signature.CreatedAt.Format("2006-01-02T15:04:05Z"),
-			})
 		}
 
 	default:
 		return nil, fmt.Errorf("invalid action: %s", req.Action)
 	}
 
-	return &ManageSignersResponse{
-		WorkPaperID: req.WorkPaperID,
-		Action:      req.Action,
-		Signers:     signerResponses,
-		Message:     fmt.Sprintf("Successfully completed %s action on signers", req.Action),
-	}, nil
+	changes.remaining = len(changes.add)
+	for _, signature := range existing {
+		if signature.Status != entity.SignatureStatusRejected {
+			changes.remaining++
+		}
+	}
+	for _, signature := range changes.remove {
+		if signature.Status != entity.SignatureStatusRejected {
+			changes.remaining--
+		}
+	}
+
+	return changes, nil
+}
+
+// recordSignerAudit writes one audit entry per signer touched by a signer management request.
+// It runs in the signer management transaction, so a failed audit write rolls the signer changes back.
+func (s *deskService) recordSignerAudit(ctx context.Context, tx database.DBTx, req *ManageSignersRequest, added, removed, preserved []*entity.WorkPaperSignature) error {
+	if s.signatureAuditRepo == nil {
+		return nil
+	}
+
+	entries := make([]*entity.SignatureAuditLog, 0, len(added)+len(removed)+len(preserved))
+	for _, group := range []struct {
+		outcome    string
+		signatures []*entity.WorkPaperSignature
+	}{
+		{entity.SignatureAuditOutcomeAdded, added},
+		{entity.SignatureAuditOutcomeRemoved, removed},
+		{entity.SignatureAuditOutcomePreserved, preserved},
+	} {
+		for _, signature := range group.signatures {
+			entries = append(entries, entity.NewSignatureAuditLog(signature, req.Action, group.outcome, req.ActorID, req.ActorName))
+		}
+	}
+
+	if err := s.signatureAuditRepo.WithTransaction(tx).CreateBatch(ctx, entries); err != nil {
+		return fmt.Errorf("failed to write signature audit log: %w", err)
+	}
+	return nil
+}
+
+func toSignerResponses(signatures []*entity.WorkPaperSignature) []SignerResponse {
+	responses := make([]SignerResponse, 0, len(signatures))
+	for _, signature := range signatures {
+		responses = append(responses, SignerResponse{
+			SignatureID:   signature.ID.String(),
+			UserID:        signature.UserID,
+			UserName:      signature.UserName,
+			UserEmail:     signature.GetUserEmail(),
+			UserRole:      signature.GetUserRole(),
+			SignatureType: signature.SignatureType,
+			Status:        signature.Status,
+			CreatedAt:     signature.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return responses
 }
 
 func (s *deskService) SignWorkPaperWithUser(ctx context.Context, signatureID string, userID string) (*entity.WorkPaperSignature, error) {
@@ -1224,15 +1808,64 @@ func (s *deskService) ListWorkPaperSignatures(ctx context.Context, req *ListWork
 	}
 
 	// Calculate pagination metadata
-	totalPages := int((total + int64(req.Limit) - 1) / int64(req.Limit))
+	metadata := pagination.BuildMetadata(total, req.Page, req.Limit)
 
 	return &ListWorkPaperSignaturesResponse{
 		Signatures:  signatures,
 		TotalItems:  total,
-		TotalPages:  totalPages,
+		TotalPages:  metadata.TotalPage,
 		CurrentPage: req.Page,
 		Limit:       req.Limit,
 	}, nil
 }
 
 // Helper functions
+
+// checkMinSigners returns ErrInsufficientSigners when count is below the configured minimum
+func (s *deskService) checkMinSigners(count int) error {
+	if s.options.MinSigners > 0 && count < s.options.MinSigners {
+		return fmt.Errorf("%w: at least %d required, got %d", entity.ErrInsufficientSigners, s.options.MinSigners, count)
+	}
+	return nil
+}
+
+// ensureMinSigners checks the work paper's current signer count against the configured minimum
+func (s *deskService) ensureMinSigners(ctx context.Context, workPaperID uuid.UUID) error {
+	if s.options.MinSigners <= 0 {
+		return nil
+	}
+
+	stats, err := s.signatureRepo.GetSignatureStats(ctx, workPaperID)
+	if err != nil {
+		return fmt.Errorf("failed to get signature statistics: %w", err)
+	}
+
+	return s.checkMinSigners(stats.Total)
+}
+
+// ensureSignaturesComplete returns a SignaturesIncompleteError listing the signers that
+// still have to sign when the work paper has pending or rejected signatures
+func (s *deskService) ensureSignaturesComplete(ctx context.Context, workPaperID uuid.UUID) error {
+	stats, err := s.signatureRepo.GetSignatureStats(ctx, workPaperID)
+	if err != nil {
+		return fmt.Errorf("failed to get signature statistics: %w", err)
+	}
+
+	if stats.IsComplete() {
+		return nil
+	}
+
+	signatures, err := s.signatureRepo.GetByWorkPaperID(ctx, workPaperID)
+	if err != nil {
+		return fmt.Errorf("failed to get signatures: %w", err)
+	}
+
+	var outstanding []*entity.WorkPaperSignature
+	for _, signature := range signatures {
+		if signature.Status != entity.SignatureStatusSigned {
+			outstanding = append(outstanding, signature)
+		}
+	}
+
+	return &SignaturesIncompleteError{Outstanding: outstanding}
+}
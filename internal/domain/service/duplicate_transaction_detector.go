@@ -0,0 +1,174 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"sandbox/internal/domain/entity"
+)
+
+// Fields a duplicate transaction can be matched on
+const (
+	DuplicateMatchType        = "type"
+	DuplicateMatchSubtype     = "subtype"
+	DuplicateMatchAmount      = "amount"
+	DuplicateMatchName        = "name"
+	DuplicateMatchDescription = "description"
+)
+
+// DefaultDuplicateMatchFields flags transactions of the same type and amount with a similar name
+var DefaultDuplicateMatchFields = []string{DuplicateMatchType, DuplicateMatchAmount, DuplicateMatchName}
+
+// DuplicateTransactionConfig controls when two transactions of an assignee are reported as duplicates
+type DuplicateTransactionConfig struct {
+	// MatchFields lists the fields that must all match; empty uses DefaultDuplicateMatchFields
+	MatchFields []string
+	// AmountTolerance is the largest absolute amount difference still treated as the same amount
+	AmountTolerance float64
+	// TextSimilarity is the minimum similarity, from 0 to 1, for names and descriptions to match
+	TextSimilarity float64
+}
+
+// DuplicateTransactionWarning reports a transaction that looks like a re-entry of an earlier one
+// of the same assignee. Indexes refer to the order of the assignee's transactions in the request.
+type DuplicateTransactionWarning struct {
+	AssigneeName     string   `json:"assignee_name"`
+	EmployeeNumber   string   `json:"employee_number"`
+	TransactionIndex int      `json:"transaction_index"`
+	DuplicateOfIndex int      `json:"duplicate_of_index"`
+	Name             string   `json:"name"`
+	Type             string   `json:"type"`
+	Amount           float64  `json:"amount"`
+	MatchedFields    []string `json:"matched_fields"`
+	Message          string   `json:"message"`
+}
+
+// DuplicateTransactionDetector flags likely duplicate transactions without rejecting them
+type DuplicateTransactionDetector struct {
+	config DuplicateTransactionConfig
+}
+
+// ValidateDuplicateMatchFields checks that every field is one the detector can compare
+func ValidateDuplicateMatchFields(fields []string) error {
+	for _, field := range fields {
+		switch field {
+		case DuplicateMatchType, DuplicateMatchSubtype, DuplicateMatchAmount, DuplicateMatchName, DuplicateMatchDescription:
+		default:
+			return fmt.Errorf("unknown duplicate transaction match field %q", field)
+		}
+	}
+	return nil
+}
+
+// NewDuplicateTransactionDetector creates a new duplicate transaction detector
+func NewDuplicateTransactionDetector(config DuplicateTransactionConfig) *DuplicateTransactionDetector {
+	if len(config.MatchFields) == 0 {
+		config.MatchFields = DefaultDuplicateMatchFields
+	}
+	return &DuplicateTransactionDetector{config: config}
+}
+
+// DetectInAssignees compares the transactions of each assignee with each other and returns a warning for
+// every transaction that matches an earlier one. Transactions of different assignees are never compared.
+func (d *DuplicateTransactionDetector) DetectInAssignees(assignees []*entity.Assignee) []DuplicateTransactionWarning {
+	var warnings []DuplicateTransactionWarning
+	for _, assignee := range assignees {
+		warnings = append(warnings, d.DetectInAssignee(assignee)...)
+	}
+	return warnings
+}
+
+// DetectInAssignee returns a warning for every transaction of the assignee that matches an earlier one
+func (d *DuplicateTransactionDetector) DetectInAssignee(assignee *entity.Assignee) []DuplicateTransactionWarning {
+	var warnings []DuplicateTransactionWarning
+	for i, transaction := range assignee.Transactions {
+		for j := 0; j < i; j++ {
+			if !d.matches(assignee.Transactions[j], transaction) {
+				continue
+			}
+
+			warnings = append(warnings, DuplicateTransactionWarning{
+				AssigneeName:     assignee.Name,
+				EmployeeNumber:   assignee.EmployeeNumber,
+				TransactionIndex: i,
+				DuplicateOfIndex: j,
+				Name:             transaction.Name,
+				Type:             string(transaction.Type),
+				Amount:           transaction.Amount,
+				MatchedFields:    d.config.MatchFields,
+				Message: fmt.Sprintf("transaction %d (%s) of %s looks like a duplicate of transaction %d",
+					i, transaction.Name, assignee.Name, j),
+			})
+			// One warning per transaction is enough for the user to review it
+			break
+		}
+	}
+	return warnings
+}
+
+func (d *DuplicateTransactionDetector) matches(a, b *entity.Transaction) bool {
+	for _, field := range d.config.MatchFields {
+		switch field {
+		case DuplicateMatchType:
+			if a.Type != b.Type {
+				return false
+			}
+		case DuplicateMatchSubtype:
+			if a.Subtype != b.Subtype {
+				return false
+			}
+		case DuplicateMatchAmount:
+			if math.Abs(a.Amount-b.Amount) > d.config.AmountTolerance {
+				return false
+			}
+		case DuplicateMatchName:
+			if textSimilarity(a.Name, b.Name) < d.config.TextSimilarity {
+				return false
+			}
+		case DuplicateMatchDescription:
+			if textSimilarity(a.Description, b.Description) < d.config.TextSimilarity {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// textSimilarity returns 1 minus the edit distance between the normalized texts divided by the longer length
+func textSimilarity(a, b string) float64 {
+	ra := []rune(strings.Join(strings.Fields(strings.ToLower(a)), " "))
+	rb := []rune(strings.Join(strings.Fields(strings.ToLower(b)), " "))
+
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"testing"
+
+	"sandbox/internal/domain/entity"
+)
+
+func testTransaction(txType entity.TransactionType, name string, amount float64) *entity.Transaction {
+	return &entity.Transaction{Type: txType, Name: name, Amount: amount}
+}
+
+func TestDetectInAssigneeFlagsSimilarTransactions(t *testing.T) {
+	detector := NewDuplicateTransactionDetector(DuplicateTransactionConfig{AmountTolerance: 1, TextSimilarity: 0.8})
+
+	assignee := &entity.Assignee{
+		Name: "Budi",
+		Transactions: []*entity.Transaction{
+			testTransaction(entity.TransactionTypeTransport, "Taxi to airport", 150000),
+			testTransaction(entity.TransactionTypeAccommodation, "Hotel Santika", 800000),
+			testTransaction(entity.TransactionTypeTransport, "taxi  to Airport.", 150000.5),
+			testTransaction(entity.TransactionTypeTransport, "Taxi to airport", 175000),
+		},
+	}
+
+	warnings := detector.DetectInAssignee(assignee)
+	if len(warnings) != 1 {
+		t.Fatalf("warnings = %d, want 1: %+v", len(warnings), warnings)
+	}
+	if warnings[0].TransactionIndex != 2 || warnings[0].DuplicateOfIndex != 0 {
+		t.Errorf("warning = %d duplicates %d, want 2 duplicates 0", warnings[0].TransactionIndex, warnings[0].DuplicateOfIndex)
+	}
+}
+
+func TestDetectInAssigneesDoesNotCompareAcrossAssignees(t *testing.T) {
+	detector := NewDuplicateTransactionDetector(DuplicateTransactionConfig{TextSimilarity: 0.9})
+
+	assignees := []*entity.Assignee{
+		{Name: "Budi", Transactions: []*entity.Transaction{testTransaction(entity.TransactionTypeAllowance, "Daily allowance", 300000)}},
+		{Name: "Sari", Transactions: []*entity.Transaction{testTransaction(entity.TransactionTypeAllowance, "Daily allowance", 300000)}},
+	}
+
+	if warnings := detector.DetectInAssignees(assignees); len(warnings) != 0 {
+		t.Errorf("warnings = %+v, want none", warnings)
+	}
+}
+
+func TestDetectInAssigneeUsesConfiguredFields(t *testing.T) {
+	detector := NewDuplicateTransactionDetector(DuplicateTransactionConfig{
+		MatchFields:    []string{DuplicateMatchAmount},
+		TextSimilarity: 1,
+	})
+
+	assignee := &entity.Assignee{
+		Transactions: []*entity.Transaction{
+			testTransaction(entity.TransactionTypeTransport, "Train", 250000),
+			testTransaction(entity.TransactionTypeOther, "Meeting room", 250000),
+		},
+	}
+
+	if warnings := detector.DetectInAssignee(assignee); len(warnings) != 1 {
+		t.Errorf("warnings = %d, want 1 when only the amount is matched", len(warnings))
+	}
+}
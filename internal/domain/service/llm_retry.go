@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+
+	"sandbox/pkg/retry"
+)
+
+// retryingLLMService retries document checks that fail with a timeout, rate limit or server error
+type retryingLLMService struct {
+	next LLMService
+	opts retry.Options
+}
+
+// NewRetryingLLMService wraps next so transient Gemini failures are retried with backoff
+func NewRetryingLLMService(next LLMService, opts retry.Options) LLMService {
+	return &retryingLLMService{next: next, opts: opts}
+}
+
+func (s *retryingLLMService) CheckDocument(ctx context.Context, req *DocumentCheckRequest) (*DocumentCheckResponse, error) {
+	return retry.Do(ctx, "llm.CheckDocument", s.opts, func(ctx context.Context) (*DocumentCheckResponse, error) {
+		return s.next.CheckDocument(ctx, req)
+	})
+}
+
+// retryingVaccineExtractor retries vaccine extractions that fail with a timeout, rate limit or server error
+type retryingVaccineExtractor struct {
+	next VaccineExtractor
+	opts retry.Options
+}
+
+// NewRetryingVaccineExtractor wraps next so transient Gemini failures are retried with backoff
+func NewRetryingVaccineExtractor(next VaccineExtractor, opts retry.Options) VaccineExtractor {
+	return &retryingVaccineExtractor{next: next, opts: opts}
+}
+
+func (e *retryingVaccineExtractor) ExtractVaccineRecommendations(ctx context.Context, htmlContent string) (map[string]interface{}, error) {
+	return retry.Do(ctx, "gemini.ExtractVaccineRecommendations", e.opts, func(ctx context.Context) (map[string]interface{}, error) {
+		return e.next.ExtractVaccineRecommendations(ctx, htmlContent)
+	})
+}
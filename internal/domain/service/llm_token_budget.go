@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+)
+
+// TokenBudget accounts the LLM tokens spent per calendar month (UTC) and enforces a monthly limit
+type TokenBudget struct {
+	usageRepo    repository.LLMTokenUsageRepository
+	monthlyLimit int64
+	now          func() time.Time
+}
+
+// TokenBudgetUsage is the token usage of the current month compared to the budget
+type TokenBudgetUsage struct {
+	Month      string `json:"month"` // YYYY-MM
+	UsedTokens int64  `json:"used_tokens"`
+	// BudgetTokens is 0 when no budget is enforced
+	BudgetTokens    int64 `json:"budget_tokens"`
+	RemainingTokens int64 `json:"remaining_tokens"`
+	Exceeded        bool  `json:"exceeded"`
+}
+
+// NewTokenBudget creates a token budget of monthlyLimit tokens; 0 only tracks the usage
+func NewTokenBudget(usageRepo repository.LLMTokenUsageRepository, monthlyLimit int64) *TokenBudget {
+	return &TokenBudget{
+		usageRepo:    usageRepo,
+		monthlyLimit: monthlyLimit,
+		now:          time.Now,
+	}
+}
+
+// currentMonth returns the first day of the current month in UTC
+func (b *TokenBudget) currentMonth() time.Time {
+	now := b.now().UTC()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// Usage returns the token usage of the current month
+func (b *TokenBudget) Usage(ctx context.Context) (*TokenBudgetUsage, error) {
+	month := b.currentMonth()
+	used, err := b.usageRepo.GetTotal(ctx, month)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &TokenBudgetUsage{
+		Month:        month.Format("2006-01"),
+		UsedTokens:   used,
+		BudgetTokens: b.monthlyLimit,
+	}
+	if b.monthlyLimit > 0 {
+		usage.RemainingTokens = max(b.monthlyLimit-used, 0)
+		usage.Exceeded = used >= b.monthlyLimit
+	}
+	return usage, nil
+}
+
+// Check returns ErrTokenBudgetExceeded once the tokens spent this month reach the budget
+func (b *TokenBudget) Check(ctx context.Context) error {
+	if b.monthlyLimit <= 0 {
+		return nil
+	}
+
+	usage, err := b.Usage(ctx)
+	if err != nil {
+		return err
+	}
+	if usage.Exceeded {
+		return fmt.Errorf("%w: %d of %d tokens used in %s", entity.ErrTokenBudgetExceeded, usage.UsedTokens, usage.BudgetTokens, usage.Month)
+	}
+	return nil
+}
+
+// Record adds the tokens of an LLM response to the usage of the current month
+func (b *TokenBudget) Record(ctx context.Context, usage *TokenUsage) error {
+	if usage == nil || usage.TotalTokens <= 0 {
+		return nil
+	}
+	return b.usageRepo.Add(ctx, b.currentMonth(), int64(usage.TotalTokens))
+}
+
+// budgetedLLMService refuses document checks once the monthly token budget is spent
+type budgetedLLMService struct {
+	next   LLMService
+	budget *TokenBudget
+}
+
+// NewBudgetedLLMService wraps next so every successful check is counted against budget. Checks are
+// refused with ErrTokenBudgetExceeded once it is spent; checks already running may overshoot it.
+func NewBudgetedLLMService(next LLMService, budget *TokenBudget) LLMService {
+	return &budgetedLLMService{next: next, budget: budget}
+}
+
+func (s *budgetedLLMService) CheckDocument(ctx context.Context, req *DocumentCheckRequest) (*DocumentCheckResponse, error) {
+	if err := s.budget.Check(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.next.CheckDocument(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The tokens are already spent, so a failure to count them must not fail the check
+	if err := s.budget.Record(ctx, resp.Usage); err != nil {
+		log.Printf("Failed to record LLM token usage: %v", err)
+	}
+	return resp, nil
+}
+
+// PromptTemplateHash forwards to the wrapped service, or returns "" when it does not hash its prompts
+func (s *budgetedLLMService) PromptTemplateHash(itemType string) string {
+	if hasher, ok := s.next.(PromptTemplateHasher); ok {
+		return hasher.PromptTemplateHash(itemType)
+	}
+	return ""
+}
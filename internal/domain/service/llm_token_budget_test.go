@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"sandbox/internal/domain/entity"
+)
+
+type stubTokenUsageRepository struct {
+	totals map[time.Time]int64
+}
+
+func (r *stubTokenUsageRepository) GetTotal(_ context.Context, month time.Time) (int64, error) {
+	return r.totals[month], nil
+}
+
+func (r *stubTokenUsageRepository) Add(_ context.Context, month time.Time, tokens int64) error {
+	r.totals[month] += tokens
+	return nil
+}
+
+// tokenSpendingLLMService spends the same number of tokens on every check
+type tokenSpendingLLMService struct {
+	calls  int
+	tokens int
+}
+
+func (l *tokenSpendingLLMService) CheckDocument(context.Context, *DocumentCheckRequest) (*DocumentCheckResponse, error) {
+	l.calls++
+	return &DocumentCheckResponse{IsValid: true, Usage: &TokenUsage{TotalTokens: l.tokens}}, nil
+}
+
+func TestBudgetedLLMServiceRefusesChecksOnceBudgetIsSpent(t *testing.T) {
+	repo := &stubTokenUsageRepository{totals: make(map[time.Time]int64)}
+	budget := NewTokenBudget(repo, 100)
+	now := time.Date(2026, time.March, 31, 23, 0, 0, 0, time.UTC)
+	budget.now = func() time.Time { return now }
+
+	next := &tokenSpendingLLMService{tokens: 60}
+	llm := NewBudgetedLLMService(next, budget)
+
+	// The second check may overshoot the budget, since it was not spent yet when the check started
+	for i := 0; i < 2; i++ {
+		if _, err := llm.CheckDocument(context.Background(), &DocumentCheckRequest{}); err != nil {
+			t.Fatalf("check %d error = %v", i+1, err)
+		}
+	}
+	if _, err := llm.CheckDocument(context.Background(), &DocumentCheckRequest{}); !errors.Is(err, entity.ErrTokenBudgetExceeded) {
+		t.Fatalf("third check error = %v, want %v", err, entity.ErrTokenBudgetExceeded)
+	}
+	if next.calls != 2 {
+		t.Errorf("LLM calls = %d, want 2", next.calls)
+	}
+
+	usage, err := budget.Usage(context.Background())
+	if err != nil {
+		t.Fatalf("Usage() error = %v", err)
+	}
+	if usage.Month != "2026-03" || usage.UsedTokens != 120 || usage.RemainingTokens != 0 || !usage.Exceeded {
+		t.Errorf("usage = %+v, want 120 of 100 tokens used in 2026-03", usage)
+	}
+
+	// The budget starts over with the next month
+	now = now.Add(2 * time.Hour)
+	if _, err := llm.CheckDocument(context.Background(), &DocumentCheckRequest{}); err != nil {
+		t.Fatalf("check in the next month error = %v", err)
+	}
+	usage, _ = budget.Usage(context.Background())
+	if usage.Month != "2026-04" || usage.UsedTokens != 60 || usage.RemainingTokens != 40 || usage.Exceeded {
+		t.Errorf("usage = %+v, want 60 of 100 tokens used in 2026-04", usage)
+	}
+}
+
+func TestTokenBudgetWithoutLimitOnlyTracksUsage(t *testing.T) {
+	repo := &stubTokenUsageRepository{totals: make(map[time.Time]int64)}
+	next := &tokenSpendingLLMService{tokens: 1000}
+	budget := NewTokenBudget(repo, 0)
+	llm := NewBudgetedLLMService(next, budget)
+
+	for i := 0; i < 3; i++ {
+		if _, err := llm.CheckDocument(context.Background(), &DocumentCheckRequest{}); err != nil {
+			t.Fatalf("check %d error = %v", i+1, err)
+		}
+	}
+
+	usage, err := budget.Usage(context.Background())
+	if err != nil {
+		t.Fatalf("Usage() error = %v", err)
+	}
+	if usage.UsedTokens != 3000 || usage.BudgetTokens != 0 || usage.Exceeded {
+		t.Errorf("usage = %+v, want 3000 tokens tracked without a budget", usage)
+	}
+}
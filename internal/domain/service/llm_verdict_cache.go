@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sandbox/internal/domain/entity"
+)
+
+// checkDocumentsCached returns the cached verdict for the same documents checked against the same
+// work paper item when there is one, and otherwise asks the LLM and caches its verdict. The cache is
+// not read when skipCache is set, and cache errors are logged without failing the check.
+// Verdicts are cached per prompt template and per model and temperature override in req, so a verdict given under an override
+// is never reused for a check with other settings, and an unstructured answer is never cached.
+// The second return value reports whether the verdict came from the cache.
+func (s *deskService) checkDocumentsCached(ctx context.Context, req *DocumentCheckRequest, item *entity.WorkPaperItem, skipCache bool) (*DocumentCheckResponse, bool, error) {
+	// Without documents there is nothing to deduplicate, and an empty set is often a failed download
+	if s.verdictCacheRepo == nil || len(req.Documents) == 0 {
+		resp, err := s.llmService.CheckDocument(ctx, req)
+		return resp, false, err
+	}
+
+	documentHash := hashDocuments(req.Documents)
+	itemHash := hashWorkPaperItem(item, s.promptTemplateHash(req.ItemType), req.Model, req.Temperature)
+
+	if !skipCache {
+		verdict, err := s.verdictCacheRepo.Get(ctx, documentHash, itemHash)
+		if err != nil {
+			log.Printf("Failed to read cached LLM verdict for item %s: %v", item.ID, err)
+		} else if verdict != nil {
+			log.Printf("Reusing cached LLM verdict for item %s (documents %s)", item.ID, documentHash)
+			return &DocumentCheckResponse{
+				IsValid:      verdict.IsValid,
+				Notes:        verdict.Notes,
+				Confidence:   verdict.Confidence,
+				MissingItems: verdict.MissingItems,
+				Model:        verdict.Model,
+			}, true, nil
+		}
+	}
+
+	resp, err := s.llmService.CheckDocument(ctx, req)
+	if err != nil {
+		return nil, false, err
+	}
+	if resp.Unstructured {
+		return resp, false, nil
+	}
+
+	ttl := s.options.VerdictCacheTTL
+	if ttl <= 0 {
+		ttl = DefaultVerdictCacheTTL
+	}
+	verdict := entity.NewLLMVerdict(documentHash, itemHash, resp.IsValid, resp.Notes, resp.Confidence, resp.MissingItems, resp.Model, ttl)
+	if err := s.verdictCacheRepo.Upsert(ctx, verdict); err != nil {
+		log.Printf("Failed to cache LLM verdict for item %s: %v", item.ID, err)
+	}
+
+	return resp, false, nil
+}
+
+// hashDocuments hashes the contents of the documents regardless of their names and order, so the
+// same evidence shared by several notes or work papers gets the same hash
+func hashDocuments(documents []DocumentFile) string {
+	hashes := make([]string, 0, len(documents))
+	for _, document := range documents {
+		sum := sha256.Sum256(document.Data)
+		hashes = append(hashes, hex.EncodeToString(sum[:]))
+	}
+	sort.Strings(hashes)
+
+	sum := sha256.Sum256([]byte(strings.Join(hashes, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// promptTemplateHash returns the hash of the prompt template a check of the item type is sent with,
+// or "" when the LLM service does not tell
+func (s *deskService) promptTemplateHash(itemType string) string {
+	if hasher, ok := s.llmService.(PromptTemplateHasher); ok {
+		return hasher.PromptTemplateHash(itemType)
+	}
+	return ""
+}
+
+// hashWorkPaperItem hashes the parts of a work paper item that are sent to the LLM, so editing the
+// criterion or its prompt template invalidates the verdicts cached for it. The model and temperature
+// overrides of the check are part of the hash; empty ones stand for the LLM's defaults.
+func hashWorkPaperItem(item *entity.WorkPaperItem, promptHash, model string, temperature *float64) string {
+	var temperatureText string
+	if temperature != nil {
+		temperatureText = strconv.FormatFloat(*temperature, 'g', -1, 64)
+	}
+
+	parts := []string{item.Number, item.Statement, item.Explanation, item.FillingGuide, promptHash, model, temperatureText}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
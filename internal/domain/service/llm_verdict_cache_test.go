@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"sandbox/internal/domain/entity"
+)
+
+// stubSharedDriveService serves the same evidence file for every folder, like a document shared across papers
+type stubSharedDriveService struct {
+	DriveService
+}
+
+func (d *stubSharedDriveService) GetFilesFromFolder(_ context.Context, folderLink string) ([]*DriveFile, error) {
+	return []*DriveFile{{ID: folderLink, Name: "evidence-" + folderLink + ".pdf", Type: "pdf"}}, nil
+}
+
+func (d *stubSharedDriveService) DownloadFile(context.Context, string) ([]byte, error) {
+	return []byte("signed performance agreement"), nil
+}
+
+type stubVerdictCacheRepository struct {
+	verdicts map[string]*entity.LLMVerdict
+}
+
+func (r *stubVerdictCacheRepository) Get(_ context.Context, documentHash, itemHash string) (*entity.LLMVerdict, error) {
+	return r.verdicts[documentHash+itemHash], nil
+}
+
+func (r *stubVerdictCacheRepository) Upsert(_ context.Context, verdict *entity.LLMVerdict) error {
+	r.verdicts[verdict.DocumentHash+verdict.ItemHash] = verdict
+	return nil
+}
+
+// countingLLMService counts the checks that reach the LLM
+type countingLLMService struct {
+	calls int
+}
+
+func (l *countingLLMService) CheckDocument(context.Context, *DocumentCheckRequest) (*DocumentCheckResponse, error) {
+	l.calls++
+	return &DocumentCheckResponse{IsValid: true, Notes: "complete", Model: "test-model"}, nil
+}
+
+func newVerdictCacheDesk(notes []*entity.WorkPaperNote, llm LLMService) DeskService {
+	noteRepo := &stubBatchNoteRepository{notes: notes, saved: make(map[uuid.UUID]*entity.WorkPaperNote)}
+	cacheRepo := &stubVerdictCacheRepository{verdicts: make(map[string]*entity.LLMVerdict)}
+	return NewDeskService(&stubBatchItemRepository{}, nil, nil, noteRepo, nil, &stubBatchHistoryRepository{}, nil, cacheRepo, nil,
+		&stubSharedDriveService{}, llm, DeskOptions{CheckConcurrency: 1})
+}
+
+func TestCheckWorkPaperDocumentsReusesVerdictForSharedDocument(t *testing.T) {
+	itemID := uuid.New()
+	first := noteWithLink("https://drive.google.com/drive/folders/a", "")
+	second := noteWithLink("https://drive.google.com/drive/folders/b", "")
+	first.MasterItemID, second.MasterItemID = itemID, itemID
+
+	llm := &countingLLMService{}
+	desk := newVerdictCacheDesk([]*entity.WorkPaperNote{first, second}, llm)
+
+	resp, err := desk.CheckWorkPaperDocuments(context.Background(), uuid.NewString(), false, false)
+	if err != nil {
+		t.Fatalf("CheckWorkPaperDocuments() error = %v", err)
+	}
+	if llm.calls != 1 {
+		t.Errorf("LLM calls = %d, want 1 for the same document and item", llm.calls)
+	}
+	if resp.Checked != 2 {
+		t.Errorf("checked = %d, want 2", resp.Checked)
+	}
+	if !second.GetLLMResponse().Cached || !second.GetLLMResponse().IsValid {
+		t.Errorf("second note verdict = %+v, want the cached valid verdict", second.GetLLMResponse())
+	}
+
+	if _, err := desk.CheckWorkPaperDocuments(context.Background(), uuid.NewString(), false, true); err != nil {
+		t.Fatalf("CheckWorkPaperDocuments() with skipCache error = %v", err)
+	}
+	if llm.calls != 3 {
+		t.Errorf("LLM calls = %d after skipping the cache, want 3", llm.calls)
+	}
+}
+
+func TestCheckWorkPaperDocumentsDoesNotShareVerdictAcrossItems(t *testing.T) {
+	first := noteWithLink("https://drive.google.com/drive/folders/a", "")
+	second := noteWithLink("https://drive.google.com/drive/folders/a", "")
+
+	llm := &countingLLMService{}
+	desk := newVerdictCacheDesk([]*entity.WorkPaperNote{first, second}, llm)
+
+	if _, err := desk.CheckWorkPaperDocuments(context.Background(), uuid.NewString(), false, false); err != nil {
+		t.Fatalf("CheckWorkPaperDocuments() error = %v", err)
+	}
+	if llm.calls != 2 {
+		t.Errorf("LLM calls = %d, want 2 for the same document checked against different items", llm.calls)
+	}
+}
+
+// promptHashingLLMService reports prompt as the hash of its prompt template
+type promptHashingLLMService struct {
+	countingLLMService
+	prompt string
+}
+
+func (l *promptHashingLLMService) PromptTemplateHash(string) string {
+	return l.prompt
+}
+
+func TestCheckWorkPaperDocumentsDoesNotReuseVerdictAcrossPromptTemplates(t *testing.T) {
+	note := noteWithLink("https://drive.google.com/drive/folders/a", "")
+
+	llm := &promptHashingLLMService{prompt: "v1"}
+	desk := newVerdictCacheDesk([]*entity.WorkPaperNote{note}, llm)
+
+	for _, prompt := range []string{"v1", "v1", "v2"} {
+		llm.prompt = prompt
+		if _, err := desk.CheckWorkPaperDocuments(context.Background(), uuid.NewString(), false, false); err != nil {
+			t.Fatalf("CheckWorkPaperDocuments() with prompt %s error = %v", prompt, err)
+		}
+	}
+	if llm.calls != 2 {
+		t.Errorf("LLM calls = %d, want 2 for two prompt templates", llm.calls)
+	}
+}
+
+// modelEchoLLMService answers with the requested model, or with its default model when none is requested
+type modelEchoLLMService struct {
+	calls       int
+	temperature *float64
+}
+
+func (l *modelEchoLLMService) CheckDocument(_ context.Context, req *DocumentCheckRequest) (*DocumentCheckResponse, error) {
+	l.calls++
+	l.temperature = req.Temperature
+	model := "gemini-2.5-flash"
+	if req.Model != "" {
+		model = req.Model
+	}
+	return &DocumentCheckResponse{IsValid: true, Notes: "complete", Model: model}, nil
+}
+
+func TestCheckDocumentWithLLMOptions(t *testing.T) {
+	note := noteWithLink("https://drive.google.com/drive/folders/a", "")
+	llm := &modelEchoLLMService{}
+	desk := newVerdictCacheDesk([]*entity.WorkPaperNote{note}, llm)
+	temperature, tooHot := 0.2, 2.5
+
+	tests := []struct {
+		name            string
+		opts            LLMOptions
+		wantErr         error
+		wantModel       string
+		wantCached      bool
+		wantCalls       int
+		wantTemperature *float64
+	}{
+		{name: "default model", wantModel: "gemini-2.5-flash", wantCalls: 1},
+		{name: "other model skips the cached verdict", opts: LLMOptions{Model: "gemini-2.5-pro", Temperature: &temperature},
+			wantModel: "gemini-2.5-pro", wantCalls: 2, wantTemperature: &temperature},
+		{name: "same model and temperature reuse their verdict", opts: LLMOptions{Model: "gemini-2.5-pro", Temperature: &temperature},
+			wantModel: "gemini-2.5-pro", wantCached: true, wantCalls: 2},
+		{name: "same model at the default temperature skips the cached verdict", opts: LLMOptions{Model: "gemini-2.5-pro"},
+			wantModel: "gemini-2.5-pro", wantCalls: 3},
+		{name: "default check does not reuse an override verdict", wantModel: "gemini-2.5-flash", wantCached: true, wantCalls: 3},
+		{name: "unsupported model", opts: LLMOptions{Model: "gpt-4o"}, wantErr: entity.ErrUnsupportedLLMModel, wantCalls: 3},
+		{name: "temperature out of range", opts: LLMOptions{Temperature: &tooHot},
+			wantErr: entity.ErrInvalidLLMTemperature, wantCalls: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := desk.CheckDocument(context.Background(), note.ID.String(), false, tt.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CheckDocument() error = %v, want %v", err, tt.wantErr)
+			}
+			if llm.calls != tt.wantCalls {
+				t.Errorf("LLM calls = %d, want %d", llm.calls, tt.wantCalls)
+			}
+			if err != nil {
+				return
+			}
+			if resp.Model != tt.wantModel || resp.Cached != tt.wantCached {
+				t.Errorf("verdict model = %s cached = %v, want %s cached = %v", resp.Model, resp.Cached, tt.wantModel, tt.wantCached)
+			}
+			if !tt.wantCached && llm.temperature != tt.wantTemperature {
+				t.Errorf("LLM temperature = %v, want %v", llm.temperature, tt.wantTemperature)
+			}
+		})
+	}
+}
+
+// unstructuredLLMService answers with text that is not the requested JSON
+type unstructuredLLMService struct {
+	calls int
+}
+
+func (l *unstructuredLLMService) CheckDocument(context.Context, *DocumentCheckRequest) (*DocumentCheckResponse, error) {
+	l.calls++
+	return &DocumentCheckResponse{Notes: "The response was blocked", Model: "test-model", Unstructured: true}, nil
+}
+
+func TestCheckDocumentDoesNotCacheUnstructuredAnswer(t *testing.T) {
+	note := noteWithLink("https://drive.google.com/drive/folders/a", "")
+	llm := &unstructuredLLMService{}
+	desk := newVerdictCacheDesk([]*entity.WorkPaperNote{note}, llm)
+
+	for i := 0; i < 2; i++ {
+		resp, err := desk.CheckDocument(context.Background(), note.ID.String(), false, LLMOptions{})
+		if err != nil {
+			t.Fatalf("CheckDocument() error = %v", err)
+		}
+		if resp.IsValid || resp.Cached {
+			t.Errorf("verdict valid = %v cached = %v, want an uncached invalid verdict", resp.IsValid, resp.Cached)
+		}
+	}
+	if llm.calls != 2 {
+		t.Errorf("LLM calls = %d, want 2 since unstructured answers are not cached", llm.calls)
+	}
+}
@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/pkg/database"
+)
+
+type stubSignerWorkPaperRepository struct {
+	repository.WorkPaperRepository
+}
+
+func (r *stubSignerWorkPaperRepository) GetByID(_ context.Context, id string) (*entity.WorkPaper, error) {
+	return &entity.WorkPaper{ID: uuid.MustParse(id)}, nil
+}
+
+// stubSignatureRepository keeps signatures in memory and records which ones were deleted
+type stubSignatureRepository struct {
+	repository.WorkPaperSignatureRepository
+	signatures []*entity.WorkPaperSignature
+	deleted    []uuid.UUID
+}
+
+func (r *stubSignatureRepository) GetByWorkPaperID(_ context.Context, _ uuid.UUID) ([]*entity.WorkPaperSignature, error) {
+	return r.signatures, nil
+}
+
+func (r *stubSignatureRepository) Create(_ context.Context, signature *entity.WorkPaperSignature) error {
+	r.signatures = append(r.signatures, signature)
+	return nil
+}
+
+func (r *stubSignatureRepository) Delete(_ context.Context, id uuid.UUID) error {
+	r.deleted = append(r.deleted, id)
+	kept := r.signatures[:0]
+	for _, signature := range r.signatures {
+		if signature.ID != id {
+			kept = append(kept, signature)
+		}
+	}
+	r.signatures = kept
+	return nil
+}
+
+func (r *stubSignatureRepository) WithTransaction(interface{}) repository.WorkPaperSignatureRepository {
+	return r
+}
+
+type stubSignatureAuditLogRepository struct {
+	entries []*entity.SignatureAuditLog
+	err     error
+}
+
+func (r *stubSignatureAuditLogRepository) CreateBatch(_ context.Context, entries []*entity.SignatureAuditLog) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.entries = append(r.entries, entries...)
+	return nil
+}
+
+func (r *stubSignatureAuditLogRepository) WithTransaction(interface{}) repository.SignatureAuditLogRepository {
+	return r
+}
+
+// stubTxDB hands out a single transaction and records how it ended
+type stubTxDB struct {
+	committed, rolledBack bool
+}
+
+func (db *stubTxDB) BeginTx(context.Context, *sql.TxOptions) (database.DBTx, error) {
+	return &stubTx{db: db}, nil
+}
+
+type stubTx struct {
+	database.DBTx
+	db *stubTxDB
+}
+
+func (tx *stubTx) Commit() error {
+	tx.db.committed = true
+	return nil
+}
+
+func (tx *stubTx) Rollback() error {
+	tx.db.rolledBack = true
+	return nil
+}
+
+// newMixedSignatureDesk returns a desk service for a work paper signed by alice and still pending for bob
+func newMixedSignatureDesk() (DeskService, *stubSignatureRepository, *stubSignatureAuditLogRepository, uuid.UUID) {
+	workPaperID := uuid.New()
+	signed := &entity.WorkPaperSignature{ID: uuid.New(), WorkPaperID: workPaperID, UserID: "alice", UserName: "Alice", Status: entity.SignatureStatusSigned}
+	pending := &entity.WorkPaperSignature{ID: uuid.New(), WorkPaperID: workPaperID, UserID: "bob", UserName: "Bob", Status: entity.SignatureStatusPending}
+
+	signatureRepo := &stubSignatureRepository{signatures: []*entity.WorkPaperSignature{signed, pending}}
+	auditRepo := &stubSignatureAuditLogRepository{}
+	desk := NewDeskService(nil, nil, &stubSignerWorkPaperRepository{}, nil, signatureRepo, nil, auditRepo, nil, &stubTxDB{}, nil, nil, DeskOptions{})
+
+	return desk, signatureRepo, auditRepo, workPaperID
+}
+
+func signer(userID string) CreateSignerData {
+	return CreateSignerData{UserID: userID, UserName: userID, SignatureType: entity.SignatureTypeDigital}
+}
+
+func signerUserIDs(signers []SignerResponse) []string {
+	ids := make([]string, 0, len(signers))
+	for _, s := range signers {
+		ids = append(ids, s.UserID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func auditOutcomes(entries []*entity.SignatureAuditLog) map[string]string {
+	outcomes := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		outcomes[entry.SignerUserID] = entry.Outcome
+	}
+	return outcomes
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestManageSignersReplaceRejectsDroppingSignedSignature(t *testing.T) {
+	desk, signatureRepo, auditRepo, workPaperID := newMixedSignatureDesk()
+
+	_, err := desk.ManageSigners(context.Background(), &ManageSignersRequest{
+		WorkPaperID: workPaperID.String(),
+		Action:      "replace",
+		Signers:     []CreateSignerData{signer("carol")},
+	})
+	if !errors.Is(err, entity.ErrSignedSignatureRemoval) {
+		t.Fatalf("ManageSigners() error = %v, want ErrSignedSignatureRemoval", err)
+	}
+
+	if len(signatureRepo.deleted) != 0 || len(signatureRepo.signatures) != 2 {
+		t.Errorf("rejected replace changed signatures: deleted %v, %d left", signatureRepo.deleted, len(signatureRepo.signatures))
+	}
+	if len(auditRepo.entries) != 0 {
+		t.Errorf("rejected replace wrote %d audit entries", len(auditRepo.entries))
+	}
+}
+
+func TestManageSignersForcedReplacePreservesSignedSignature(t *testing.T) {
+	desk, signatureRepo, auditRepo, workPaperID := newMixedSignatureDesk()
+
+	resp, err := desk.ManageSigners(context.Background(), &ManageSignersRequest{
+		WorkPaperID: workPaperID.String(),
+		Action:      "replace",
+		Signers:     []CreateSignerData{signer("carol")},
+		Force:       true,
+		ActorID:     "admin-1",
+		ActorName:   "Admin",
+	})
+	if err != nil {
+		t.Fatalf("ManageSigners() error = %v", err)
+	}
+
+	if got := signerUserIDs(resp.Signers); !equalStrings(got, []string{"carol"}) {
+		t.Errorf("added = %v, want [carol]", got)
+	}
+	if got := signerUserIDs(resp.Preserved); !equalStrings(got, []string{"alice"}) {
+		t.Errorf("preserved = %v, want [alice]", got)
+	}
+	if got := signerUserIDs(resp.Removed); !equalStrings(got, []string{"bob"}) {
+		t.Errorf("removed = %v, want [bob]", got)
+	}
+
+	var remaining []string
+	for _, signature := range signatureRepo.signatures {
+		remaining = append(remaining, signature.UserID)
+	}
+	sort.Strings(remaining)
+	if !equalStrings(remaining, []string{"alice", "carol"}) {
+		t.Errorf("remaining signers = %v, want [alice carol]", remaining)
+	}
+
+	want := map[string]string{
+		"alice": entity.SignatureAuditOutcomePreserved,
+		"bob":   entity.SignatureAuditOutcomeRemoved,
+		"carol": entity.SignatureAuditOutcomeAdded,
+	}
+	got := auditOutcomes(auditRepo.entries)
+	if len(auditRepo.entries) != len(want) {
+		t.Fatalf("audit entries = %d, want %d", len(auditRepo.entries), len(want))
+	}
+	for userID, outcome := range want {
+		if got[userID] != outcome {
+			t.Errorf("audit outcome for %s = %q, want %q", userID, got[userID], outcome)
+		}
+	}
+	for _, entry := range auditRepo.entries {
+		if entry.Action != "replace" || entry.ActorID != "admin-1" || entry.ActorName != "Admin" {
+			t.Errorf("audit entry = %+v, want replace by admin-1", entry)
+		}
+	}
+}
+
+func TestManageSignersReplaceKeepsExistingSigners(t *testing.T) {
+	desk, signatureRepo, _, workPaperID := newMixedSignatureDesk()
+
+	resp, err := desk.ManageSigners(context.Background(), &ManageSignersRequest{
+		WorkPaperID: workPaperID.String(),
+		Action:      "replace",
+		Signers:     []CreateSignerData{signer("alice"), signer("bob"), signer("carol")},
+	})
+	if err != nil {
+		t.Fatalf("ManageSigners() error = %v", err)
+	}
+
+	if got := signerUserIDs(resp.Preserved); !equalStrings(got, []string{"alice", "bob"}) {
+		t.Errorf("preserved = %v, want [alice bob]", got)
+	}
+	if got := signerUserIDs(resp.Signers); !equalStrings(got, []string{"carol"}) {
+		t.Errorf("added = %v, want [carol]", got)
+	}
+	if len(resp.Removed) != 0 || len(signatureRepo.deleted) != 0 {
+		t.Errorf("replace with existing signers removed %v", signatureRepo.deleted)
+	}
+}
+
+func TestManageSignersRemoveSignedSignature(t *testing.T) {
+	desk, signatureRepo, _, workPaperID := newMixedSignatureDesk()
+	req := &ManageSignersRequest{
+		WorkPaperID: workPaperID.String(),
+		Action:      "remove",
+		Signers:     []CreateSignerData{signer("alice"), signer("bob")},
+	}
+
+	if _, err := desk.ManageSigners(context.Background(), req); !errors.Is(err, entity.ErrSignedSignatureRemoval) {
+		t.Fatalf("ManageSigners() error = %v, want ErrSignedSignatureRemoval", err)
+	}
+
+	req.Force = true
+	resp, err := desk.ManageSigners(context.Background(), req)
+	if err != nil {
+		t.Fatalf("ManageSigners() with force error = %v", err)
+	}
+	if got := signerUserIDs(resp.Preserved); !equalStrings(got, []string{"alice"}) {
+		t.Errorf("preserved = %v, want [alice]", got)
+	}
+	if got := signerUserIDs(resp.Removed); !equalStrings(got, []string{"bob"}) {
+		t.Errorf("removed = %v, want [bob]", got)
+	}
+	if len(signatureRepo.signatures) != 1 || signatureRepo.signatures[0].UserID != "alice" {
+		t.Errorf("remaining signatures = %v, want only alice", signatureRepo.signatures)
+	}
+}
+
+func TestManageSignersAddIsIdempotent(t *testing.T) {
+	desk, signatureRepo, _, workPaperID := newMixedSignatureDesk()
+	req := &ManageSignersRequest{
+		WorkPaperID: workPaperID.String(),
+		Action:      "add",
+		Signers:     []CreateSignerData{signer("bob"), signer("carol")},
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := desk.ManageSigners(context.Background(), req); err != nil {
+			t.Fatalf("ManageSigners() call %d error = %v", i+1, err)
+		}
+	}
+
+	if len(signatureRepo.signatures) != 3 {
+		t.Errorf("signatures = %d after adding twice, want 3", len(signatureRepo.signatures))
+	}
+}
+
+func TestManageSignersFailsWhenAuditLogFails(t *testing.T) {
+	workPaperID := uuid.New()
+	pending := &entity.WorkPaperSignature{ID: uuid.New(), WorkPaperID: workPaperID, UserID: "bob", UserName: "Bob", Status: entity.SignatureStatusPending}
+	auditErr := errors.New("audit log unavailable")
+
+	signatureRepo := &stubSignatureRepository{signatures: []*entity.WorkPaperSignature{pending}}
+	db := &stubTxDB{}
+	desk := NewDeskService(nil, nil, &stubSignerWorkPaperRepository{}, nil, signatureRepo, nil,
+		&stubSignatureAuditLogRepository{err: auditErr}, nil, db, nil, nil, DeskOptions{})
+
+	_, err := desk.ManageSigners(context.Background(), &ManageSignersRequest{
+		WorkPaperID: workPaperID.String(),
+		Action:      "add",
+		Signers:     []CreateSignerData{signer("carol")},
+	})
+	if !errors.Is(err, auditErr) {
+		t.Fatalf("ManageSigners() error = %v, want the audit log error", err)
+	}
+	if db.committed || !db.rolledBack {
+		t.Errorf("transaction committed = %v, rolled back = %v, want a rollback", db.committed, db.rolledBack)
+	}
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+)
+
+type stubActiveItemRepository struct {
+	repository.WorkPaperItemRepository
+	active []*entity.WorkPaperItem
+}
+
+func (r *stubActiveItemRepository) ListActive(context.Context) ([]*entity.WorkPaperItem, error) {
+	return r.active, nil
+}
+
+type stubPruneNoteRepository struct {
+	repository.WorkPaperNoteRepository
+	notes   []*entity.WorkPaperNote
+	deleted []string
+}
+
+func (r *stubPruneNoteRepository) GetByWorkPaper(context.Context, string) ([]*entity.WorkPaperNote, error) {
+	return r.notes, nil
+}
+
+func (r *stubPruneNoteRepository) Delete(_ context.Context, id string) error {
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+type stubStatusWorkPaperRepository struct {
+	repository.WorkPaperRepository
+	status string
+}
+
+func (r *stubStatusWorkPaperRepository) GetByID(_ context.Context, id string) (*entity.WorkPaper, error) {
+	return &entity.WorkPaper{ID: uuid.MustParse(id), Status: r.status}, nil
+}
+
+func TestPruneInactiveWorkPaperNotes(t *testing.T) {
+	workPaperID := uuid.New()
+	activeItem := &entity.WorkPaperItem{ID: uuid.New(), IsActive: true}
+	keptNote := &entity.WorkPaperNote{ID: uuid.New(), WorkPaperID: workPaperID, MasterItemID: activeItem.ID, MasterItem: activeItem}
+	// The master item of this note failed to load but is still active, so the note must be kept
+	unloadedNote := &entity.WorkPaperNote{ID: uuid.New(), WorkPaperID: workPaperID, MasterItemID: activeItem.ID}
+	deactivatedNote := &entity.WorkPaperNote{ID: uuid.New(), WorkPaperID: workPaperID, MasterItemID: uuid.New(),
+		MasterItem: &entity.WorkPaperItem{IsActive: false}}
+
+	tests := []struct {
+		name        string
+		status      string
+		wantErr     error
+		wantDeleted []string
+	}{
+		{name: "draft", status: entity.WorkPaperStatusDraft, wantDeleted: []string{deactivatedNote.ID.String()}},
+		{name: "ongoing", status: entity.WorkPaperStatusOngoing, wantErr: entity.ErrWorkPaperNotDraft},
+		{name: "completed", status: entity.WorkPaperStatusCompleted, wantErr: entity.ErrWorkPaperNotDraft},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			noteRepo := &stubPruneNoteRepository{notes: []*entity.WorkPaperNote{keptNote, unloadedNote, deactivatedNote}}
+			desk := NewDeskService(&stubActiveItemRepository{active: []*entity.WorkPaperItem{activeItem}}, nil,
+				&stubStatusWorkPaperRepository{status: tt.status}, noteRepo, nil, nil, nil, nil, nil, nil, nil, DeskOptions{})
+
+			pruned, err := desk.PruneInactiveWorkPaperNotes(context.Background(), workPaperID.String())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PruneInactiveWorkPaperNotes() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(noteRepo.deleted) != len(tt.wantDeleted) || len(pruned) != len(tt.wantDeleted) {
+				t.Fatalf("deleted %v and returned %d notes, want %v", noteRepo.deleted, len(pruned), tt.wantDeleted)
+			}
+			for i, id := range tt.wantDeleted {
+				if noteRepo.deleted[i] != id || pruned[i].ID.String() != id {
+					t.Errorf("pruned note %d = %s, want %s", i, noteRepo.deleted[i], id)
+				}
+			}
+		})
+	}
+}
+
+func TestWorkPaperNoteIsMasterItemActive(t *testing.T) {
+	tests := []struct {
+		name       string
+		masterItem *entity.WorkPaperItem
+		want       bool
+	}{
+		{name: "active", masterItem: &entity.WorkPaperItem{IsActive: true}, want: true},
+		{name: "deactivated", masterItem: &entity.WorkPaperItem{IsActive: false}, want: false},
+		{name: "deleted", masterItem: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			note := &entity.WorkPaperNote{MasterItem: tt.masterItem}
+			if got := note.IsMasterItemActive(); got != tt.want {
+				t.Errorf("IsMasterItemActive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+)
+
+type stubReorderItemRepository struct {
+	repository.WorkPaperItemRepository
+	items []*entity.WorkPaperItem
+	saved []*entity.WorkPaperItem
+}
+
+func (r *stubReorderItemRepository) ListAll(context.Context) ([]*entity.WorkPaperItem, error) {
+	return r.items, nil
+}
+
+func (r *stubReorderItemRepository) ListAllForUpdate(ctx context.Context) ([]*entity.WorkPaperItem, error) {
+	return r.ListAll(ctx)
+}
+
+func (r *stubReorderItemRepository) WithTransaction(interface{}) repository.WorkPaperItemRepository {
+	return r
+}
+
+func (r *stubReorderItemRepository) UpdatePositions(_ context.Context, items []*entity.WorkPaperItem) error {
+	r.saved = items
+	return nil
+}
+
+// newReorderDesk builds a tree of root -> child -> grandchild next to a second root
+func newReorderDesk() (DeskService, *stubReorderItemRepository, map[string]uuid.UUID, *stubTxDB) {
+	ids := map[string]uuid.UUID{"root": uuid.New(), "child": uuid.New(), "grandchild": uuid.New(), "other": uuid.New()}
+	root, child := ids["root"], ids["child"]
+	repo := &stubReorderItemRepository{items: []*entity.WorkPaperItem{
+		{ID: ids["root"], Level: 1, SortOrder: 1},
+		{ID: ids["other"], Level: 1, SortOrder: 2},
+		{ID: ids["child"], ParentID: &root, Level: 2, SortOrder: 1},
+		{ID: ids["grandchild"], ParentID: &child, Level: 3, SortOrder: 1},
+	}}
+	db := &stubTxDB{}
+	return NewDeskService(repo, nil, nil, nil, nil, nil, nil, nil, db, nil, nil, DeskOptions{}), repo, ids, db
+}
+
+func TestReorderWorkPaperItemsMovesItems(t *testing.T) {
+	desk, repo, ids, db := newReorderDesk()
+	other := ids["other"]
+
+	items, err := desk.ReorderWorkPaperItems(context.Background(), []WorkPaperItemPosition{
+		{ID: ids["other"], SortOrder: 1},
+		{ID: ids["root"], SortOrder: 2},
+		{ID: ids["child"], SortOrder: 5, MoveParent: true, ParentID: &other},
+		{ID: ids["grandchild"], SortOrder: 3, MoveParent: true},
+	})
+	if err != nil {
+		t.Fatalf("ReorderWorkPaperItems() error = %v", err)
+	}
+	if len(repo.saved) != 4 {
+		t.Fatalf("saved %d items, want 4", len(repo.saved))
+	}
+	if !db.committed {
+		t.Error("reorder was not committed")
+	}
+
+	want := []struct {
+		id        uuid.UUID
+		level     int
+		sortOrder int
+	}{
+		{ids["other"], 1, 1},
+		{ids["root"], 1, 2},
+		{ids["grandchild"], 1, 3},
+		{ids["child"], 2, 5},
+	}
+	for i, w := range want {
+		if items[i].ID != w.id || items[i].Level != w.level || items[i].SortOrder != w.sortOrder {
+			t.Errorf("item %d = %s level %d sort %d, want %s level %d sort %d",
+				i, items[i].ID, items[i].Level, items[i].SortOrder, w.id, w.level, w.sortOrder)
+		}
+	}
+	if items[2].ParentID != nil {
+		t.Errorf("grandchild parent = %v, want the top level", items[2].ParentID)
+	}
+	if items[3].ParentID == nil || *items[3].ParentID != other {
+		t.Errorf("child parent = %v, want %s", items[3].ParentID, other)
+	}
+}
+
+func TestReorderWorkPaperItemsCascadesLevels(t *testing.T) {
+	desk, repo, ids, _ := newReorderDesk()
+
+	items, err := desk.ReorderWorkPaperItems(context.Background(), []WorkPaperItemPosition{
+		{ID: ids["child"], SortOrder: 3, MoveParent: true},
+	})
+	if err != nil {
+		t.Fatalf("ReorderWorkPaperItems() error = %v", err)
+	}
+	if len(repo.saved) != 2 {
+		t.Fatalf("saved %d items, want the child and its grandchild", len(repo.saved))
+	}
+
+	want := []struct {
+		id    uuid.UUID
+		level int
+	}{
+		{ids["child"], 1},
+		{ids["grandchild"], 2},
+	}
+	for i, w := range want {
+		if items[i].ID != w.id || items[i].Level != w.level {
+			t.Errorf("item %d = %s level %d, want %s level %d", i, items[i].ID, items[i].Level, w.id, w.level)
+		}
+	}
+}
+
+func TestReorderWorkPaperItemsRejectsInvalidOrders(t *testing.T) {
+	unknown := uuid.New()
+
+	tests := []struct {
+		name      string
+		positions func(ids map[string]uuid.UUID) []WorkPaperItemPosition
+		wantErr   error
+	}{
+		{
+			name: "unknown item",
+			positions: func(map[string]uuid.UUID) []WorkPaperItemPosition {
+				return []WorkPaperItemPosition{{ID: unknown}}
+			},
+			wantErr: entity.ErrWorkPaperItemNotFound,
+		},
+		{
+			name: "unknown parent",
+			positions: func(ids map[string]uuid.UUID) []WorkPaperItemPosition {
+				return []WorkPaperItemPosition{{ID: ids["child"], MoveParent: true, ParentID: &unknown}}
+			},
+			wantErr: entity.ErrWorkPaperItemNotFound,
+		},
+		{
+			name: "duplicate item",
+			positions: func(ids map[string]uuid.UUID) []WorkPaperItemPosition {
+				return []WorkPaperItemPosition{{ID: ids["root"], SortOrder: 1}, {ID: ids["root"], SortOrder: 2}}
+			},
+			wantErr: entity.ErrDuplicateWorkPaperItemPosition,
+		},
+		{
+			name: "under itself",
+			positions: func(ids map[string]uuid.UUID) []WorkPaperItemPosition {
+				root := ids["root"]
+				return []WorkPaperItemPosition{{ID: root, MoveParent: true, ParentID: &root}}
+			},
+			wantErr: entity.ErrWorkPaperItemHierarchyCycle,
+		},
+		{
+			name: "under its descendant",
+			positions: func(ids map[string]uuid.UUID) []WorkPaperItemPosition {
+				grandchild := ids["grandchild"]
+				return []WorkPaperItemPosition{{ID: ids["root"], MoveParent: true, ParentID: &grandchild}}
+			},
+			wantErr: entity.ErrWorkPaperItemHierarchyCycle,
+		},
+		{
+			name: "swapped parent and child",
+			positions: func(ids map[string]uuid.UUID) []WorkPaperItemPosition {
+				other, child := ids["other"], ids["child"]
+				return []WorkPaperItemPosition{
+					{ID: ids["child"], MoveParent: true, ParentID: &other},
+					{ID: ids["other"], MoveParent: true, ParentID: &child},
+				}
+			},
+			wantErr: entity.ErrWorkPaperItemHierarchyCycle,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desk, repo, ids, db := newReorderDesk()
+
+			_, err := desk.ReorderWorkPaperItems(context.Background(), tt.positions(ids))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReorderWorkPaperItems() error = %v, want %v", err, tt.wantErr)
+			}
+			if repo.saved != nil {
+				t.Errorf("saved %d items, want none", len(repo.saved))
+			}
+			if !db.rolledBack {
+				t.Error("invalid reorder was not rolled back")
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/pkg/featureflag"
+)
+
+// TransactionTypePolicy enforces the transaction types an organization allows on its business trips.
+// Organizations without restrictions accept every type of the global catalog. A trip is checked against
+// the organization that created it, and trips created before that was recorded against the caller's.
+type TransactionTypePolicy struct {
+	restrictionRepo repository.TransactionTypeRestrictionRepository
+}
+
+// NewTransactionTypePolicy creates a transaction type policy. restrictionRepo may be nil to accept
+// every type for all organizations.
+func NewTransactionTypePolicy(restrictionRepo repository.TransactionTypeRestrictionRepository) *TransactionTypePolicy {
+	return &TransactionTypePolicy{
+		restrictionRepo: restrictionRepo,
+	}
+}
+
+// CheckTransactions returns entity.ErrTransactionTypeNotAllowed for the first transaction whose type
+// the organization of trip does not allow
+func (p *TransactionTypePolicy) CheckTransactions(ctx context.Context, trip *entity.BusinessTrip, transactions ...*entity.Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+
+	organizationID := tripOrganizationID(ctx, trip)
+	if p.restrictionRepo == nil || organizationID == "" || organizationID == uuid.Nil.String() {
+		return nil
+	}
+
+	allowed, err := p.restrictionRepo.GetAllowedTransactionTypes(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	for _, transaction := range transactions {
+		if !isTransactionTypeAllowed(allowed, transaction.Type, transaction.Subtype) {
+			return fmt.Errorf("%w: %s", entity.ErrTransactionTypeNotAllowed, describeTransactionType(transaction.Type, transaction.Subtype))
+		}
+	}
+
+	return nil
+}
+
+// CheckAssignees checks the transactions of every assignee of trip
+func (p *TransactionTypePolicy) CheckAssignees(ctx context.Context, trip *entity.BusinessTrip, assignees []*entity.Assignee) error {
+	var transactions []*entity.Transaction
+	for _, assignee := range assignees {
+		transactions = append(transactions, assignee.Transactions...)
+	}
+	return p.CheckTransactions(ctx, trip, transactions...)
+}
+
+// tripOrganizationID returns the organization that created trip, or the caller's in ctx when it was not
+// recorded
+func tripOrganizationID(ctx context.Context, trip *entity.BusinessTrip) string {
+	if trip != nil {
+		if organizationID := trip.GetOrganizationID(); organizationID != "" {
+			return organizationID
+		}
+	}
+	return featureflag.OrganizationID(ctx)
+}
+
+func isTransactionTypeAllowed(allowed []*entity.AllowedTransactionType, txType entity.TransactionType, subtype entity.TransactionSubtype) bool {
+	for _, entry := range allowed {
+		if entry.Allows(txType, subtype) {
+			return true
+		}
+	}
+	return false
+}
+
+func describeTransactionType(txType entity.TransactionType, subtype entity.TransactionSubtype) string {
+	if subtype == "" {
+		return string(txType)
+	}
+	return fmt.Sprintf("%s (%s)", txType, subtype)
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/pkg/featureflag"
+)
+
+// stubRestrictionRepository serves fixed restrictions per organization
+type stubRestrictionRepository struct {
+	repository.TransactionTypeRestrictionRepository
+	allowed map[string][]*entity.AllowedTransactionType
+}
+
+func (r *stubRestrictionRepository) GetAllowedTransactionTypes(_ context.Context, organizationID string) ([]*entity.AllowedTransactionType, error) {
+	return r.allowed[organizationID], nil
+}
+
+func TestTransactionTypePolicy(t *testing.T) {
+	policy := NewTransactionTypePolicy(&stubRestrictionRepository{allowed: map[string][]*entity.AllowedTransactionType{
+		"org-restricted": {
+			{Type: entity.TransactionTypeAccommodation},
+			{Type: entity.TransactionTypeTransport, Subtype: entity.TransactionSubtypeTaxi},
+			{Type: entity.TransactionTypeTransport, Subtype: entity.TransactionSubtypeFlight},
+		},
+	}})
+
+	tests := []struct {
+		name           string
+		organizationID string
+		txType         entity.TransactionType
+		subtype        entity.TransactionSubtype
+		wantAllowed    bool
+	}{
+		{"whole type allowed", "org-restricted", entity.TransactionTypeAccommodation, entity.TransactionSubtypeHotel, true},
+		{"allowed subtype", "org-restricted", entity.TransactionTypeTransport, entity.TransactionSubtypeTaxi, true},
+		{"disallowed subtype", "org-restricted", entity.TransactionTypeTransport, entity.TransactionSubtypeRentalCar, false},
+		{"disallowed type", "org-restricted", entity.TransactionTypeAllowance, entity.TransactionSubtypeDailyAllowance, false},
+		{"organization without restrictions", "org-open", entity.TransactionTypeTransport, entity.TransactionSubtypeRentalCar, true},
+		{"no organization", "", entity.TransactionTypeTransport, entity.TransactionSubtypeRentalCar, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := featureflag.WithOrganizationID(context.Background(), tt.organizationID)
+			transaction := &entity.Transaction{Name: "Trip", Type: tt.txType, Subtype: tt.subtype}
+
+			// A legacy trip without an organization is checked against the caller's
+			err := policy.CheckTransactions(ctx, &entity.BusinessTrip{}, transaction)
+			if tt.wantAllowed && err != nil {
+				t.Errorf("CheckTransactions() error = %v, want nil", err)
+			}
+			if !tt.wantAllowed && !errors.Is(err, entity.ErrTransactionTypeNotAllowed) {
+				t.Errorf("CheckTransactions() error = %v, want ErrTransactionTypeNotAllowed", err)
+			}
+		})
+	}
+}
+
+func TestTransactionTypePolicyChecksEveryAssignee(t *testing.T) {
+	policy := NewTransactionTypePolicy(&stubRestrictionRepository{allowed: map[string][]*entity.AllowedTransactionType{
+		"org-restricted": {{Type: entity.TransactionTypeAccommodation}},
+	}})
+	ctx := featureflag.WithOrganizationID(context.Background(), "org-restricted")
+
+	assignees := []*entity.Assignee{
+		{Transactions: []*entity.Transaction{{Type: entity.TransactionTypeAccommodation}}},
+		{Transactions: []*entity.Transaction{{Type: entity.TransactionTypeTransport, Subtype: entity.TransactionSubtypeRentalCar}}},
+	}
+
+	if err := policy.CheckAssignees(ctx, nil, assignees); !errors.Is(err, entity.ErrTransactionTypeNotAllowed) {
+		t.Errorf("CheckAssignees() error = %v, want ErrTransactionTypeNotAllowed", err)
+	}
+}
+
+func TestTransactionTypePolicyUsesTripOrganization(t *testing.T) {
+	policy := NewTransactionTypePolicy(&stubRestrictionRepository{allowed: map[string][]*entity.AllowedTransactionType{
+		"org-restricted": {{Type: entity.TransactionTypeAccommodation}},
+	}})
+	rentalCar := &entity.Transaction{Type: entity.TransactionTypeTransport, Subtype: entity.TransactionSubtypeRentalCar}
+	restrictedTrip := &entity.BusinessTrip{OrganizationID: sql.NullString{String: "org-restricted", Valid: true}}
+	openTrip := &entity.BusinessTrip{OrganizationID: sql.NullString{String: "org-open", Valid: true}}
+
+	// A caller from another organization cannot bypass the restrictions of the trip's organization
+	ctx := featureflag.WithOrganizationID(context.Background(), "org-open")
+	if err := policy.CheckTransactions(ctx, restrictedTrip, rentalCar); !errors.Is(err, entity.ErrTransactionTypeNotAllowed) {
+		t.Errorf("CheckTransactions() on a restricted trip error = %v, want ErrTransactionTypeNotAllowed", err)
+	}
+
+	// Nor do the caller's restrictions apply to another organization's trip
+	ctx = featureflag.WithOrganizationID(context.Background(), "org-restricted")
+	if err := policy.CheckTransactions(ctx, openTrip, rentalCar); err != nil {
+		t.Errorf("CheckTransactions() on an unrestricted trip error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"strings"
+	"time"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+)
+
+// reminderCooldown is the minimum time between two reminders to the same verificator
+const reminderCooldown = 24 * time.Hour
+
+// EmailSender sends an email to one or more recipients
+type EmailSender interface {
+	SendEmail(ctx context.Context, to []string, subject, body string) error
+}
+
+// VerificatorReminderResult summarizes a reminder run
+type VerificatorReminderResult struct {
+	UsersNotified        int      `json:"users_notified"`
+	VerificatorsReminded int      `json:"verificators_reminded"`
+	FailedUserIDs        []string `json:"failed_user_ids,omitempty"`
+}
+
+// VerificatorReminderService reminds verificators about business trips waiting for their verification
+type VerificatorReminderService struct {
+	businessTripRepo repository.BusinessTripRepository
+	userService      *UserService
+	emailSender      EmailSender
+	threshold        time.Duration
+}
+
+// NewVerificatorReminderService creates a new verificator reminder service.
+// threshold is how long a verificator must have been pending before they are reminded.
+func NewVerificatorReminderService(
+	businessTripRepo repository.BusinessTripRepository,
+	userService *UserService,
+	emailSender EmailSender,
+	threshold time.Duration,
+) *VerificatorReminderService {
+	return &VerificatorReminderService{
+		businessTripRepo: businessTripRepo,
+		userService:      userService,
+		emailSender:      emailSender,
+		threshold:        threshold,
+	}
+}
+
+// SendPendingVerificatorReminders sends one email per user listing every business trip still awaiting
+// their verification, then records the reminder so nobody is notified more than once per day
+func (s *VerificatorReminderService) SendPendingVerificatorReminders(ctx context.Context) (*VerificatorReminderResult, error) {
+	now := time.Now()
+
+	verificators, err := s.businessTripRepo.GetVerificatorsDueForReminder(ctx, now.Add(-s.threshold), now.Add(-reminderCooldown))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &VerificatorReminderResult{}
+	if len(verificators) == 0 {
+		return result, nil
+	}
+
+	// Batch by user so each verificator gets a single email
+	var userIDs []string
+	byUser := make(map[string][]*entity.VerificatorWithBusinessTrip)
+	var employeeNumbers []string
+	for _, v := range verificators {
+		if _, ok := byUser[v.UserID]; !ok {
+			userIDs = append(userIDs, v.UserID)
+			employeeNumbers = append(employeeNumbers, v.EmployeeNumber)
+		}
+		byUser[v.UserID] = append(byUser[v.UserID], v)
+	}
+
+	userDataMap, err := s.userService.GetUserDataByEmployeeIDs(ctx, employeeNumbers)
+	if err != nil {
+		return nil, err
+	}
+
+	var remindedIDs []string
+	for _, userID := range userIDs {
+		pending := byUser[userID]
+
+		userData, ok := userDataMap[pending[0].EmployeeNumber]
+		if !ok || userData.Email == "" {
+			log.Printf("Skipping verificator reminder for user %s: no email address found", userID)
+			result.FailedUserIDs = append(result.FailedUserIDs, userID)
+			continue
+		}
+
+		subject, body := buildVerificatorReminderEmail(pending)
+		if err := s.emailSender.SendEmail(ctx, []string{userData.Email}, subject, body); err != nil {
+			log.Printf("Failed to send verificator reminder to user %s: %v", userID, err)
+			result.FailedUserIDs = append(result.FailedUserIDs, userID)
+			continue
+		}
+
+		result.UsersNotified++
+		for _, v := range pending {
+			remindedIDs = append(remindedIDs, v.ID)
+		}
+	}
+
+	reminded, err := s.businessTripRepo.MarkVerificatorsReminded(ctx, remindedIDs, now)
+	if err != nil {
+		return nil, err
+	}
+	result.VerificatorsReminded = int(reminded)
+
+	return result, nil
+}
+
+// Run calls SendPendingVerificatorReminders every interval until ctx is cancelled
+func (s *VerificatorReminderService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result, err := s.SendPendingVerificatorReminders(ctx)
+			if err != nil {
+				log.Printf("Verificator reminder run failed: %v", err)
+				continue
+			}
+			log.Printf("Verificator reminders sent to %d users (%d verifications)", result.UsersNotified, result.VerificatorsReminded)
+		}
+	}
+}
+
+func buildVerificatorReminderEmail(pending []*entity.VerificatorWithBusinessTrip) (string, string) {
+	subject := fmt.Sprintf("Reminder: %d business trip(s) awaiting your verification", len(pending))
+
+	var body strings.Builder
+	body.WriteString(fmt.Sprintf("<p>Dear %s,</p>", html.EscapeString(pending[0].UserName)))
+	body.WriteString("<p>The following business trips are still waiting for your verification:</p><ul>")
+	for _, v := range pending {
+		number := v.BusinessTripNumber.String
+		if number == "" {
+			number = v.BusinessTripID
+		}
+		body.WriteString(fmt.Sprintf("<li><strong>%s</strong> - %s, %s (%s to %s)</li>",
+			html.EscapeString(number),
+			html.EscapeString(v.BusinessTripActivityPurpose),
+			html.EscapeString(v.BusinessTripDestinationCity),
+			v.BusinessTripStartDate.Format("2006-01-02"),
+			v.BusinessTripEndDate.Format("2006-01-02"),
+		))
+	}
+	body.WriteString("</ul>")
+
+	return subject, body.String()
+}
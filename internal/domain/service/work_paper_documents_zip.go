@@ -0,0 +1,141 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"sandbox/internal/domain/entity"
+)
+
+// WorkPaperDocumentsErrorsFile is the name of the manifest listing the files that could not be added to
+// a work paper documents zip
+const WorkPaperDocumentsErrorsFile = "errors.txt"
+
+// WriteWorkPaperDocumentsZip writes a zip of the Google Drive files behind the links of a work paper's
+// notes to w, with a folder per note named after its master item number. Files are downloaded one at a
+// time and written as they arrive, so only one file is held in memory. Folders or files that could not
+// be read are listed in errors.txt instead of failing the download; an error is only returned when
+// writing to w fails or ctx is done.
+func (s *deskService) WriteWorkPaperDocumentsZip(ctx context.Context, workPaperID string, w io.Writer) error {
+	notes, err := s.workPaperNoteRepo.GetByWorkPaper(ctx, workPaperID)
+	if err != nil {
+		return fmt.Errorf("failed to get work paper notes: %w", err)
+	}
+
+	archive := zip.NewWriter(w)
+	folders := make(map[string]bool)
+	var failures []string
+
+	for _, note := range notes {
+		if note.GetGDriveLink() == "" {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		folder := uniqueZipName(noteFolderName(note), folders)
+
+		files, err := s.driveService.GetFilesFromFolder(ctx, note.GetGDriveLink())
+		if err != nil {
+			log.Printf("Failed to list Google Drive files of note %s: %v", note.ID, err)
+			failures = append(failures, fmt.Sprintf("%s/: failed to list %s: %v", folder, note.GetGDriveLink(), err))
+			continue
+		}
+
+		names := make(map[string]bool)
+		for _, file := range files {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			data, err := s.driveService.DownloadFile(ctx, file.ID)
+			if err != nil {
+				log.Printf("Failed to download file %s of note %s: %v", file.Name, note.ID, err)
+				failures = append(failures, fmt.Sprintf("%s/%s: failed to download: %v", folder, file.Name, err))
+				continue
+			}
+
+			name := uniqueZipName(zipFileName(file.Name, data), names)
+			if err := writeZipEntry(archive, folder+"/"+name, data); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(failures) > 0 {
+		manifest := "The following documents could not be added to this archive:\n\n" + strings.Join(failures, "\n") + "\n"
+		if err := writeZipEntry(archive, WorkPaperDocumentsErrorsFile, []byte(manifest)); err != nil {
+			return err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to finish zip archive: %w", err)
+	}
+	return nil
+}
+
+// writeZipEntry adds a compressed file to the archive
+func writeZipEntry(archive *zip.Writer, name string, data []byte) error {
+	entry, err := archive.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add %s to zip archive: %w", name, err)
+	}
+	if _, err := entry.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s to zip archive: %w", name, err)
+	}
+	return nil
+}
+
+// noteFolderName names the folder of a note after its master item number, or its ID when the master
+// item could not be loaded
+func noteFolderName(note *entity.WorkPaperNote) string {
+	if note.MasterItem != nil {
+		if name := sanitizeZipName(note.MasterItem.Number); name != "" {
+			return name
+		}
+	}
+	return note.ID.String()
+}
+
+// zipFileName sanitizes a Drive file name. Google Docs have no extension and are exported as PDF, so
+// PDF content without an extension gets one.
+func zipFileName(name string, data []byte) string {
+	name = sanitizeZipName(name)
+	if name == "" {
+		name = "document"
+	}
+	if path.Ext(name) == "" && http.DetectContentType(data) == "application/pdf" {
+		name += ".pdf"
+	}
+	return name
+}
+
+// sanitizeZipName keeps a name from creating folders or escaping the archive
+func sanitizeZipName(name string) string {
+	name = strings.NewReplacer("/", "_", "\\", "_").Replace(strings.TrimSpace(name))
+	return strings.Trim(name, ".")
+}
+
+// uniqueZipName returns name, or name with a counter before its extension when it was already used
+func uniqueZipName(name string, used map[string]bool) string {
+	unique := name
+	ext := path.Ext(name)
+	for i := 2; used[unique]; i++ {
+		unique = fmt.Sprintf("%s (%d)%s", strings.TrimSuffix(name, ext), i, ext)
+	}
+	used[unique] = true
+	return unique
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"sandbox/internal/domain/entity"
+)
+
+// stubFolderDriveService serves the files of each folder link and fails the listed folders and files
+type stubFolderDriveService struct {
+	DriveService
+	folders     map[string][]*DriveFile
+	failFolders map[string]bool
+	failFiles   map[string]bool
+}
+
+func (d *stubFolderDriveService) GetFilesFromFolder(_ context.Context, folderLink string) ([]*DriveFile, error) {
+	if d.failFolders[folderLink] {
+		return nil, errors.New("access denied")
+	}
+	return d.folders[folderLink], nil
+}
+
+func (d *stubFolderDriveService) DownloadFile(_ context.Context, fileID string) ([]byte, error) {
+	if d.failFiles[fileID] {
+		return nil, errors.New("download quota exceeded")
+	}
+	if fileID == "doc" {
+		return []byte("%PDF-1.7 exported google doc"), nil
+	}
+	return []byte("content of " + fileID), nil
+}
+
+func TestWriteWorkPaperDocumentsZip(t *testing.T) {
+	noteFor := func(number, link string) *entity.WorkPaperNote {
+		note := noteWithLink(link, "")
+		note.MasterItem = &entity.WorkPaperItem{Number: number}
+		return note
+	}
+	notes := []*entity.WorkPaperNote{
+		noteFor("1.1", "folder-a"),
+		noteFor("1.2", "folder-b"),
+		noteFor("1.3", ""),
+		noteFor("1.4", "folder-denied"),
+	}
+	drive := &stubFolderDriveService{
+		folders: map[string][]*DriveFile{
+			"folder-a": {
+				{ID: "sk", Name: "SK Tim.pdf"},
+				{ID: "sk-copy", Name: "SK Tim.pdf"},
+				{ID: "doc", Name: "Notulen"},
+			},
+			"folder-b": {
+				{ID: "missing", Name: "Laporan.xlsx"},
+				{ID: "nested", Name: "../Rekap/2025.pdf"},
+			},
+		},
+		failFolders: map[string]bool{"folder-denied": true},
+		failFiles:   map[string]bool{"missing": true},
+	}
+	noteRepo := &stubBatchNoteRepository{notes: notes, saved: make(map[uuid.UUID]*entity.WorkPaperNote)}
+	desk := NewDeskService(nil, nil, nil, noteRepo, nil, nil, nil, nil, nil, drive, nil, DeskOptions{})
+
+	var buf bytes.Buffer
+	if err := desk.WriteWorkPaperDocumentsZip(context.Background(), uuid.NewString(), &buf); err != nil {
+		t.Fatalf("WriteWorkPaperDocumentsZip() error = %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("invalid zip archive: %v", err)
+	}
+	contents := make(map[string]string)
+	var names []string
+	for _, file := range archive.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", file.Name, err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		contents[file.Name] = string(data)
+		names = append(names, file.Name)
+	}
+	sort.Strings(names)
+
+	want := []string{"1.1/Notulen.pdf", "1.1/SK Tim (2).pdf", "1.1/SK Tim.pdf", "1.2/_Rekap_2025.pdf", WorkPaperDocumentsErrorsFile}
+	if strings.Join(names, "|") != strings.Join(want, "|") {
+		t.Fatalf("archive files = %q, want %q", names, want)
+	}
+	if contents["1.1/SK Tim (2).pdf"] != "content of sk-copy" {
+		t.Errorf("renamed duplicate = %q, want the second file", contents["1.1/SK Tim (2).pdf"])
+	}
+
+	manifest := contents[WorkPaperDocumentsErrorsFile]
+	for _, failure := range []string{"1.2/Laporan.xlsx: failed to download", "1.4/: failed to list folder-denied"} {
+		if !strings.Contains(manifest, failure) {
+			t.Errorf("errors.txt = %q, want it to mention %q", manifest, failure)
+		}
+	}
+}
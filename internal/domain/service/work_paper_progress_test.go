@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"sandbox/internal/domain/repository"
+)
+
+type stubNoteStatsRepository struct {
+	repository.WorkPaperNoteRepository
+	stats *repository.WorkPaperNoteStats
+}
+
+func (r *stubNoteStatsRepository) GetStats(context.Context, string) (*repository.WorkPaperNoteStats, error) {
+	return r.stats, nil
+}
+
+func TestGetWorkPaperProgress(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats repository.WorkPaperNoteStats
+		want  WorkPaperNoteProgress
+	}{
+		{
+			name:  "partially complete",
+			stats: repository.WorkPaperNoteStats{Total: 3, WithLink: 2, Valid: 1, Invalid: 1},
+			want:  WorkPaperNoteProgress{Total: 3, WithLink: 2, Valid: 1, Invalid: 1, Unchecked: 1, Percentage: 33.33},
+		},
+		{
+			name:  "complete",
+			stats: repository.WorkPaperNoteStats{Total: 4, WithLink: 4, Valid: 4},
+			want:  WorkPaperNoteProgress{Total: 4, WithLink: 4, Valid: 4, Percentage: 100},
+		},
+		{
+			name:  "no notes",
+			stats: repository.WorkPaperNoteStats{},
+			want:  WorkPaperNoteProgress{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := tt.stats
+			desk := NewDeskService(nil, nil, nil, &stubNoteStatsRepository{stats: &stats}, nil, nil, nil, nil, nil, nil, nil, DeskOptions{})
+
+			got, err := desk.GetWorkPaperProgress(context.Background(), "wp-1")
+			if err != nil {
+				t.Fatalf("GetWorkPaperProgress() error = %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("GetWorkPaperProgress() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
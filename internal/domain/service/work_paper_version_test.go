@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+)
+
+// stubVersionedNoteRepository holds a single note and rejects updates made with a stale version,
+// like the postgres repository does
+type stubVersionedNoteRepository struct {
+	repository.WorkPaperNoteRepository
+	note    entity.WorkPaperNote
+	updates int
+}
+
+func (r *stubVersionedNoteRepository) GetByID(_ context.Context, _ string) (*entity.WorkPaperNote, error) {
+	note := r.note
+	return &note, nil
+}
+
+func (r *stubVersionedNoteRepository) Update(_ context.Context, note *entity.WorkPaperNote) (*entity.WorkPaperNote, error) {
+	if note.Version != r.note.Version {
+		return nil, entity.ErrVersionConflict
+	}
+	note.Version++
+	r.note = *note
+	r.updates++
+	return note, nil
+}
+
+func newVersionedNoteDesk(version int) (DeskService, *stubVersionedNoteRepository) {
+	noteRepo := &stubVersionedNoteRepository{
+		note: entity.WorkPaperNote{ID: uuid.New(), WorkPaperID: uuid.New(), MasterItemID: uuid.New(), Version: version},
+	}
+	return NewDeskService(nil, nil, nil, noteRepo, nil, nil, nil, nil, nil, nil, nil, DeskOptions{}), noteRepo
+}
+
+func TestUpdateWorkPaperNoteValidationRejectsStaleVersion(t *testing.T) {
+	desk, noteRepo := newVersionedNoteDesk(3)
+	valid := true
+	stale := 2
+
+	_, err := desk.UpdateWorkPaperNoteValidation(context.Background(), noteRepo.note.ID.String(), &valid, "ok", &stale)
+	if !errors.Is(err, entity.ErrVersionConflict) {
+		t.Fatalf("UpdateWorkPaperNoteValidation() error = %v, want ErrVersionConflict", err)
+	}
+	if noteRepo.updates != 0 || noteRepo.note.IsValid != nil {
+		t.Errorf("stale update was saved: %d updates, is_valid %v", noteRepo.updates, noteRepo.note.IsValid)
+	}
+}
+
+func TestUpdateWorkPaperNoteLinkWithCurrentVersion(t *testing.T) {
+	desk, noteRepo := newVersionedNoteDesk(3)
+	current := 3
+
+	note, err := desk.UpdateWorkPaperNoteLink(context.Background(), noteRepo.note.ID.String(), "https://drive.google.com/drive/folders/abc", &current)
+	if err != nil {
+		t.Fatalf("UpdateWorkPaperNoteLink() error = %v", err)
+	}
+	if note.Version != 4 {
+		t.Errorf("version after update = %d, want 4", note.Version)
+	}
+
+	// A second writer still holding version 3 loses
+	if _, err := desk.UpdateWorkPaperNoteLink(context.Background(), noteRepo.note.ID.String(), "https://drive.google.com/drive/folders/xyz", &current); !errors.Is(err, entity.ErrVersionConflict) {
+		t.Fatalf("second UpdateWorkPaperNoteLink() error = %v, want ErrVersionConflict", err)
+	}
+	if got := noteRepo.note.GetGDriveLink(); got != "https://drive.google.com/drive/folders/abc" {
+		t.Errorf("gdrive link = %q, want the first writer's link", got)
+	}
+}
+
+// stubVersionedWorkPaperRepository returns a work paper that another reviewer saves between the read and the write
+type stubVersionedWorkPaperRepository struct {
+	repository.WorkPaperRepository
+	workPaper entity.WorkPaper
+}
+
+func (r *stubVersionedWorkPaperRepository) GetByID(_ context.Context, _ string) (*entity.WorkPaper, error) {
+	workPaper := r.workPaper
+	return &workPaper, nil
+}
+
+func (r *stubVersionedWorkPaperRepository) Update(_ context.Context, _ *entity.WorkPaper) (*entity.WorkPaper, error) {
+	return nil, entity.ErrVersionConflict
+}
+
+func TestUpdateWorkPaperStatusReportsConcurrentUpdate(t *testing.T) {
+	workPaperRepo := &stubVersionedWorkPaperRepository{
+		workPaper: entity.WorkPaper{ID: uuid.New(), Status: entity.WorkPaperStatusDraft, Version: 1},
+	}
+	desk := NewDeskService(nil, nil, workPaperRepo, nil, nil, nil, nil, nil, nil, nil, nil, DeskOptions{})
+
+	err := desk.UpdateWorkPaperStatus(context.Background(), workPaperRepo.workPaper.ID.String(), entity.WorkPaperStatusOngoing, nil)
+	if !errors.Is(err, entity.ErrVersionConflict) {
+		t.Fatalf("UpdateWorkPaperStatus() error = %v, want ErrVersionConflict", err)
+	}
+}
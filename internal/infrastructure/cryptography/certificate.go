@@ -0,0 +1,78 @@
+package cryptography
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"time"
+)
+
+// CertificateInfo holds the metadata of the X.509 certificate issued for the signing key
+type CertificateInfo struct {
+	Subject      string    `json:"subject"`
+	Issuer       string    `json:"issuer"`
+	SerialNumber string    `json:"serial_number"`
+	NotBefore    time.Time `json:"not_before"`
+	NotAfter     time.Time `json:"not_after"`
+
+	// PEM is the PEM encoded certificate itself
+	PEM []byte `json:"-"`
+}
+
+// ValidAt reports whether t falls within the certificate's validity window
+func (c *CertificateInfo) ValidAt(t time.Time) bool {
+	return !t.Before(c.NotBefore) && !t.After(c.NotAfter)
+}
+
+// SetCertificatePath sets the PEM encoded X.509 certificate of the signing key.
+// An empty path means signatures are only backed by the bare public key.
+func (s *DigitalSignatureService) SetCertificatePath(path string) {
+	s.certificatePath = path
+}
+
+// HasCertificate reports whether a signing certificate is configured
+func (s *DigitalSignatureService) HasCertificate() bool {
+	return s.certificatePath != ""
+}
+
+// Certificate loads the metadata of the signing certificate. It returns nil when no certificate is
+// configured, and an error when the certificate does not belong to the configured public key.
+func (s *DigitalSignatureService) Certificate() (*CertificateInfo, error) {
+	if s.certificatePath == "" {
+		return nil, nil
+	}
+
+	certificateBytes, err := os.ReadFile(s.certificatePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load certificate: %w", err)
+	}
+
+	block, _ := pem.Decode(certificateBytes)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("failed to decode PEM block containing certificate")
+	}
+
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse certificate: %w", err)
+	}
+
+	publicKey, err := s.loadPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	if certificatePublicKey, ok := certificate.PublicKey.(*rsa.PublicKey); !ok || !certificatePublicKey.Equal(publicKey) {
+		return nil, fmt.Errorf("certificate does not match the signing public key")
+	}
+
+	return &CertificateInfo{
+		Subject:      certificate.Subject.String(),
+		Issuer:       certificate.Issuer.String(),
+		SerialNumber: certificate.SerialNumber.String(),
+		NotBefore:    certificate.NotBefore,
+		NotAfter:     certificate.NotAfter,
+		PEM:          pem.EncodeToMemory(block),
+	}, nil
+}
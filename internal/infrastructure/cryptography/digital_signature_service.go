@@ -1,12 +1,12 @@
 package cryptography
 
 import (
-	"crypto"
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
-	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
@@ -17,8 +17,13 @@ import (
 
 // DigitalSignatureService handles certificate-based digital signatures
 type DigitalSignatureService struct {
-	privateKeyPath string
-	publicKeyPath  string
+	privateKeyPath        string
+	publicKeyPath         string
+	certificatePath       string
+	timestampAuthority    *TimestampAuthorityClient
+	timestampRoots        *x509.CertPool
+	hashAlgorithm         HashAlgorithm
+	allowedHashAlgorithms map[HashAlgorithm]bool
 }
 
 // NewDigitalSignatureService creates a new instance of DigitalSignatureService
@@ -26,15 +31,130 @@ func NewDigitalSignatureService(privateKeyPath, publicKeyPath string) *DigitalSi
 	return &DigitalSignatureService{
 		privateKeyPath: privateKeyPath,
 		publicKeyPath:  publicKeyPath,
+		hashAlgorithm:  DefaultHashAlgorithm,
 	}
 }
 
+// SetHashAlgorithm selects the hash used for new signatures. An empty name keeps SHA256.
+func (s *DigitalSignatureService) SetHashAlgorithm(name string) error {
+	algorithm, err := ParseHashAlgorithm(name)
+	if err != nil {
+		return err
+	}
+	if !s.isHashAlgorithmAllowed(algorithm) {
+		return fmt.Errorf("hash algorithm %s is disabled", algorithm)
+	}
+	s.hashAlgorithm = algorithm
+	return nil
+}
+
+// SetAllowedHashAlgorithms restricts which algorithms are accepted when signing and verifying.
+// An empty list allows every supported algorithm.
+func (s *DigitalSignatureService) SetAllowedHashAlgorithms(names []string) error {
+	if len(names) == 0 {
+		s.allowedHashAlgorithms = nil
+		return nil
+	}
+
+	allowed := make(map[HashAlgorithm]bool, len(names))
+	for _, name := range names {
+		algorithm, err := ParseHashAlgorithm(name)
+		if err != nil {
+			return err
+		}
+		allowed[algorithm] = true
+	}
+
+	if !allowed[s.hashAlgorithm] {
+		return fmt.Errorf("signing hash algorithm %s is not in the allowed list", s.hashAlgorithm)
+	}
+
+	s.allowedHashAlgorithms = allowed
+	return nil
+}
+
+// HashAlgorithm returns the hash used for new signatures
+func (s *DigitalSignatureService) HashAlgorithm() HashAlgorithm {
+	return s.hashAlgorithm
+}
+
+func (s *DigitalSignatureService) isHashAlgorithmAllowed(algorithm HashAlgorithm) bool {
+	if s.allowedHashAlgorithms == nil {
+		return true
+	}
+	return s.allowedHashAlgorithms[algorithm]
+}
+
+// SetTimestampAuthority enables RFC 3161 timestamping of signatures using the given TSA URL. Tokens
+// are trusted only when signed by a certificate chaining to the PEM certificates at certificatePath,
+// which is required with a URL. An empty URL disables timestamping and signatures fall back to server
+// time, while tokens stored earlier can still be verified against certificatePath.
+func (s *DigitalSignatureService) SetTimestampAuthority(url, certificatePath string) error {
+	s.timestampAuthority = nil
+	s.timestampRoots = nil
+	if certificatePath == "" {
+		if url != "" {
+			return fmt.Errorf("a timestamp authority certificate is required with timestamp authority %s", url)
+		}
+		return nil
+	}
+
+	roots, err := LoadTimestampAuthorityCertificates(certificatePath)
+	if err != nil {
+		return err
+	}
+	s.timestampRoots = roots
+	if url != "" {
+		s.timestampAuthority = NewTimestampAuthorityClient(url, roots)
+	}
+	return nil
+}
+
+// HasTimestampAuthority reports whether a timestamp authority is configured
+func (s *DigitalSignatureService) HasTimestampAuthority() bool {
+	return s.timestampAuthority != nil
+}
+
+// TimestampSignature obtains a trusted timestamp token over the signature value.
+// It returns nil when no timestamp authority is configured.
+func (s *DigitalSignatureService) TimestampSignature(ctx context.Context, signature string) (*TimestampToken, error) {
+	if s.timestampAuthority == nil {
+		return nil, nil
+	}
+	return s.timestampAuthority.RequestTimestamp(ctx, []byte(signature))
+}
+
+// VerifySignatureTimestamp validates a stored timestamp token against the signature value
+// and returns the trusted time it asserts
+func (s *DigitalSignatureService) VerifySignatureTimestamp(token, signature string) (time.Time, error) {
+	return (&TimestampAuthorityClient{roots: s.timestampRoots}).VerifyTimestamp(token, []byte(signature))
+}
+
 // SignaturePayload represents the data to be signed
 type SignaturePayload struct {
 	UserID               string    `json:"user_id"`
 	WorkPaperID          string    `json:"work_paper_id"`
 	WorkPaperSignatureID string    `json:"work_paper_signature_id"`
 	Timestamp            time.Time `json:"timestamp"`
+
+	// HashAlgorithm is omitted for SHA256 so payloads signed before it existed still verify
+	HashAlgorithm HashAlgorithm `json:"hash_algorithm,omitempty"`
+}
+
+// SetHashAlgorithm embeds the hash algorithm in the payload, leaving it empty for the default
+func (p *SignaturePayload) SetHashAlgorithm(algorithm HashAlgorithm) {
+	if algorithm == DefaultHashAlgorithm {
+		algorithm = ""
+	}
+	p.HashAlgorithm = algorithm
+}
+
+// GetHashAlgorithm returns the hash algorithm the payload was signed with
+func (p *SignaturePayload) GetHashAlgorithm() HashAlgorithm {
+	if p.HashAlgorithm == "" {
+		return DefaultHashAlgorithm
+	}
+	return p.HashAlgorithm
 }
 
 // SignatureResult contains the signature data
@@ -114,6 +234,10 @@ func (s *DigitalSignatureService) SignPayload(payload *SignaturePayload) (*Signa
 		payload.Timestamp = time.Now().UTC()
 	}
 
+	// Embed the configured hash algorithm so verification picks the same one
+	payload.SetHashAlgorithm(s.hashAlgorithm)
+	algorithm := payload.GetHashAlgorithm()
+
 	// Serialize payload to JSON
 	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
@@ -121,7 +245,10 @@ func (s *DigitalSignatureService) SignPayload(payload *SignaturePayload) (*Signa
 	}
 
 	// Hash the payload
-	hash := sha256.Sum256(payloadBytes)
+	hashFunc, hash, err := algorithm.digest(payloadBytes)
+	if err != nil {
+		return nil, err
+	}
 
 	// Load private key
 	privateKey, err := s.loadPrivateKey()
@@ -130,7 +257,7 @@ func (s *DigitalSignatureService) SignPayload(payload *SignaturePayload) (*Signa
 	}
 
 	// Sign the hash with private key using PSS
-	signatureRaw, err := rsa.SignPSS(rand.Reader, privateKey, crypto.SHA256, hash[:], nil)
+	signatureRaw, err := rsa.SignPSS(rand.Reader, privateKey, hashFunc, hash, nil)
 	if err != nil {
 		return nil, fmt.Errorf("signing error: %w", err)
 	}
@@ -142,12 +269,21 @@ func (s *DigitalSignatureService) SignPayload(payload *SignaturePayload) (*Signa
 		Signature: signature,
 		Payload:   base64.StdEncoding.EncodeToString(payloadBytes),
 		Timestamp: payload.Timestamp,
-		Algorithm: "RSA-PSS-SHA256",
+		Algorithm: algorithm.SignatureAlgorithm(),
 	}, nil
 }
 
 // VerifySignature verifies a digital signature
 func (s *DigitalSignatureService) VerifySignature(signature string, payload *SignaturePayload) error {
+	// Use the hash algorithm embedded in the payload, rejecting unknown or disabled ones
+	algorithm := payload.GetHashAlgorithm()
+	if _, ok := supportedHashAlgorithms[algorithm]; !ok {
+		return fmt.Errorf("unsupported hash algorithm: %s", algorithm)
+	}
+	if !s.isHashAlgorithmAllowed(algorithm) {
+		return fmt.Errorf("hash algorithm %s is disabled", algorithm)
+	}
+
 	// Serialize payload to JSON
 	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
@@ -155,7 +291,10 @@ func (s *DigitalSignatureService) VerifySignature(signature string, payload *Sig
 	}
 
 	// Hash the payload
-	hash := sha256.Sum256(payloadBytes)
+	hashFunc, hash, err := algorithm.digest(payloadBytes)
+	if err != nil {
+		return err
+	}
 
 	// Load public key
 	publicKey, err := s.loadPublicKey()
@@ -170,7 +309,7 @@ func (s *DigitalSignatureService) VerifySignature(signature string, payload *Sig
 	}
 
 	// Verify the signature with the public key using PSS
-	err = rsa.VerifyPSS(publicKey, crypto.SHA256, hash[:], signatureDecoded, nil)
+	err = rsa.VerifyPSS(publicKey, hashFunc, hash, signatureDecoded, nil)
 	if err != nil {
 		return fmt.Errorf("invalid signature: %w", err)
 	}
@@ -196,6 +335,65 @@ func (s *DigitalSignatureService) VerifySignatureFromBase64Payload(signature, ba
 	return s.VerifySignature(signature, &payload)
 }
 
+// SignDocument signs the contents of a generated document with the configured hash algorithm. The
+// result's Payload is the hex digest of content, so a printed copy can be matched against a fresh
+// rendering of the same document.
+func (s *DigitalSignatureService) SignDocument(content []byte) (*SignatureResult, error) {
+	hashFunc, hash, err := s.hashAlgorithm.digest(content)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := s.loadPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	signatureRaw, err := rsa.SignPSS(rand.Reader, privateKey, hashFunc, hash, nil)
+	if err != nil {
+		return nil, fmt.Errorf("signing error: %w", err)
+	}
+
+	return &SignatureResult{
+		Signature: base64.StdEncoding.EncodeToString(signatureRaw),
+		Payload:   hex.EncodeToString(hash),
+		Timestamp: time.Now().UTC(),
+		Algorithm: s.hashAlgorithm.SignatureAlgorithm(),
+	}, nil
+}
+
+// VerifyDocument checks a signature produced by SignDocument against the document contents
+func (s *DigitalSignatureService) VerifyDocument(content []byte, signature, signatureAlgorithm string) error {
+	algorithm, err := HashAlgorithmFromSignatureAlgorithm(signatureAlgorithm)
+	if err != nil {
+		return err
+	}
+	if !s.isHashAlgorithmAllowed(algorithm) {
+		return fmt.Errorf("hash algorithm %s is disabled", algorithm)
+	}
+
+	hashFunc, hash, err := algorithm.digest(content)
+	if err != nil {
+		return err
+	}
+
+	publicKey, err := s.loadPublicKey()
+	if err != nil {
+		return err
+	}
+
+	signatureDecoded, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("decode signature: %w", err)
+	}
+
+	if err := rsa.VerifyPSS(publicKey, hashFunc, hash, signatureDecoded, nil); err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+
+	return nil
+}
+
 // CreatePayloadFromData creates a SignaturePayload from individual fields
 func CreatePayloadFromData(userID, workPaperID, workPaperSignatureID string) *SignaturePayload {
 	return &SignaturePayload{
@@ -0,0 +1,177 @@
+package cryptography
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestService writes a fresh RSA key pair to a temp dir and returns a service using it
+func newTestService(t *testing.T) *DigitalSignatureService {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal public key: %v", err)
+	}
+
+	dir := t.TempDir()
+	privatePath := filepath.Join(dir, "private.pem")
+	publicPath := filepath.Join(dir, "public.pem")
+
+	privatePEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := os.WriteFile(privatePath, privatePEM, 0o600); err != nil {
+		t.Fatalf("write private key: %v", err)
+	}
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+	if err := os.WriteFile(publicPath, publicPEM, 0o600); err != nil {
+		t.Fatalf("write public key: %v", err)
+	}
+
+	return NewDigitalSignatureService(privatePath, publicPath)
+}
+
+func TestSignAndVerifyRoundTrip(t *testing.T) {
+	service := newTestService(t)
+
+	for _, algorithm := range SupportedHashAlgorithms() {
+		t.Run(string(algorithm), func(t *testing.T) {
+			if err := service.SetHashAlgorithm(string(algorithm)); err != nil {
+				t.Fatalf("SetHashAlgorithm() error = %v", err)
+			}
+
+			payload := CreatePayloadFromData("user-1", "paper-1", "signature-1")
+			result, err := service.SignPayload(payload)
+			if err != nil {
+				t.Fatalf("SignPayload() error = %v", err)
+			}
+
+			if result.Algorithm != algorithm.SignatureAlgorithm() {
+				t.Errorf("Algorithm = %s, want %s", result.Algorithm, algorithm.SignatureAlgorithm())
+			}
+
+			if err := service.VerifySignatureFromBase64Payload(result.Signature, result.Payload); err != nil {
+				t.Errorf("VerifySignatureFromBase64Payload() error = %v", err)
+			}
+
+			// Rebuild the payload the way the verify use case does, from stored fields
+			rebuilt := &SignaturePayload{
+				UserID:               payload.UserID,
+				WorkPaperID:          payload.WorkPaperID,
+				WorkPaperSignatureID: payload.WorkPaperSignatureID,
+				Timestamp:            payload.Timestamp,
+			}
+			stored, err := HashAlgorithmFromSignatureAlgorithm(result.Algorithm)
+			if err != nil {
+				t.Fatalf("HashAlgorithmFromSignatureAlgorithm() error = %v", err)
+			}
+			rebuilt.SetHashAlgorithm(stored)
+			if err := service.VerifySignature(result.Signature, rebuilt); err != nil {
+				t.Errorf("VerifySignature() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestSignAndVerifyDocument(t *testing.T) {
+	service := newTestService(t)
+	if err := service.SetHashAlgorithm(string(HashSHA512)); err != nil {
+		t.Fatalf("SetHashAlgorithm() error = %v", err)
+	}
+
+	content := []byte("SPD BT-000123")
+	result, err := service.SignDocument(content)
+	if err != nil {
+		t.Fatalf("SignDocument() error = %v", err)
+	}
+	if result.Algorithm != HashSHA512.SignatureAlgorithm() {
+		t.Errorf("Algorithm = %s, want %s", result.Algorithm, HashSHA512.SignatureAlgorithm())
+	}
+
+	if err := service.VerifyDocument(content, result.Signature, result.Algorithm); err != nil {
+		t.Errorf("VerifyDocument() error = %v", err)
+	}
+	if err := service.VerifyDocument([]byte("SPD BT-000124"), result.Signature, result.Algorithm); err == nil {
+		t.Error("VerifyDocument() accepted a signature of different contents")
+	}
+}
+
+func TestVerifyRejectsMismatchedAlgorithm(t *testing.T) {
+	service := newTestService(t)
+
+	if err := service.SetHashAlgorithm("SHA512"); err != nil {
+		t.Fatalf("SetHashAlgorithm() error = %v", err)
+	}
+	payload := CreatePayloadFromData("user-1", "paper-1", "signature-1")
+	result, err := service.SignPayload(payload)
+	if err != nil {
+		t.Fatalf("SignPayload() error = %v", err)
+	}
+
+	payload.SetHashAlgorithm(HashSHA256)
+	if err := service.VerifySignature(result.Signature, payload); err == nil {
+		t.Error("VerifySignature() with a different hash algorithm should fail")
+	}
+}
+
+func TestVerifyRejectsUnsupportedOrDisabledAlgorithm(t *testing.T) {
+	service := newTestService(t)
+
+	payload := CreatePayloadFromData("user-1", "paper-1", "signature-1")
+	result, err := service.SignPayload(payload)
+	if err != nil {
+		t.Fatalf("SignPayload() error = %v", err)
+	}
+
+	unsupported := *payload
+	unsupported.HashAlgorithm = "MD5"
+	if err := service.VerifySignature(result.Signature, &unsupported); err == nil {
+		t.Error("VerifySignature() should reject an unsupported hash algorithm")
+	}
+
+	if err := service.SetHashAlgorithm("SHA512"); err != nil {
+		t.Fatalf("SetHashAlgorithm() error = %v", err)
+	}
+	if err := service.SetAllowedHashAlgorithms([]string{"SHA512"}); err != nil {
+		t.Fatalf("SetAllowedHashAlgorithms() error = %v", err)
+	}
+	if err := service.VerifySignature(result.Signature, payload); err == nil {
+		t.Error("VerifySignature() should reject a disabled hash algorithm")
+	}
+}
+
+func TestParseHashAlgorithm(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    HashAlgorithm
+		wantErr bool
+	}{
+		{name: "", want: HashSHA256},
+		{name: "sha256", want: HashSHA256},
+		{name: "SHA512", want: HashSHA512},
+		{name: "sha3-256", want: HashSHA3_256},
+		{name: "SHA3-512", want: HashSHA3_512},
+		{name: "MD5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseHashAlgorithm(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseHashAlgorithm() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseHashAlgorithm() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package cryptography
+
+import (
+	"crypto"
+	_ "crypto/sha256" // register SHA-256 with crypto.Hash
+	_ "crypto/sha3"   // register SHA3 with crypto.Hash
+	_ "crypto/sha512" // register SHA-512 with crypto.Hash
+	"fmt"
+	"strings"
+)
+
+// HashAlgorithm identifies the digest used when signing a payload
+type HashAlgorithm string
+
+const (
+	HashSHA256   HashAlgorithm = "SHA256"
+	HashSHA512   HashAlgorithm = "SHA512"
+	HashSHA3_256 HashAlgorithm = "SHA3-256"
+	HashSHA3_512 HashAlgorithm = "SHA3-512"
+
+	// DefaultHashAlgorithm is used when nothing is configured and for payloads signed
+	// before the algorithm was embedded in them
+	DefaultHashAlgorithm = HashSHA256
+
+	signatureAlgorithmPrefix = "RSA-PSS-"
+)
+
+var supportedHashAlgorithms = map[HashAlgorithm]crypto.Hash{
+	HashSHA256:   crypto.SHA256,
+	HashSHA512:   crypto.SHA512,
+	HashSHA3_256: crypto.SHA3_256,
+	HashSHA3_512: crypto.SHA3_512,
+}
+
+// SupportedHashAlgorithms lists every algorithm the service can sign and verify with
+func SupportedHashAlgorithms() []HashAlgorithm {
+	return []HashAlgorithm{HashSHA256, HashSHA512, HashSHA3_256, HashSHA3_512}
+}
+
+// ParseHashAlgorithm converts a name such as "sha512" or "SHA3-256" into a HashAlgorithm
+func ParseHashAlgorithm(name string) (HashAlgorithm, error) {
+	if strings.TrimSpace(name) == "" {
+		return DefaultHashAlgorithm, nil
+	}
+
+	algorithm := HashAlgorithm(strings.ToUpper(strings.TrimSpace(name)))
+	if _, ok := supportedHashAlgorithms[algorithm]; !ok {
+		return "", fmt.Errorf("unsupported hash algorithm: %s", name)
+	}
+	return algorithm, nil
+}
+
+// HashAlgorithmFromSignatureAlgorithm extracts the hash from a stored algorithm name like "RSA-PSS-SHA512"
+func HashAlgorithmFromSignatureAlgorithm(signatureAlgorithm string) (HashAlgorithm, error) {
+	return ParseHashAlgorithm(strings.TrimPrefix(signatureAlgorithm, signatureAlgorithmPrefix))
+}
+
+// SignatureAlgorithm returns the full signature algorithm name stored alongside signatures
+func (a HashAlgorithm) SignatureAlgorithm() string {
+	return signatureAlgorithmPrefix + string(a)
+}
+
+// Sum returns the digest of data using the algorithm
+func (a HashAlgorithm) Sum(data []byte) ([]byte, error) {
+	_, sum, err := a.digest(data)
+	return sum, err
+}
+
+// cryptoHash returns the crypto.Hash for the algorithm
+func (a HashAlgorithm) cryptoHash() (crypto.Hash, error) {
+	h, ok := supportedHashAlgorithms[a]
+	if !ok || !h.Available() {
+		return 0, fmt.Errorf("unsupported hash algorithm: %s", a)
+	}
+	return h, nil
+}
+
+// digest hashes data with the algorithm
+func (a HashAlgorithm) digest(data []byte) (crypto.Hash, []byte, error) {
+	h, err := a.cryptoHash()
+	if err != nil {
+		return 0, nil, err
+	}
+	hasher := h.New()
+	hasher.Write(data)
+	return h, hasher.Sum(nil), nil
+}
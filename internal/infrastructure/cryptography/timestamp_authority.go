@@ -0,0 +1,481 @@
+package cryptography
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	_ "crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"time"
+)
+
+var (
+	oidSHA256        = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidSHA384        = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 2}
+	oidSHA512        = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 3}
+	oidSignedData    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidTSTInfo       = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 1, 4}
+	oidContentType   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidMessageDigest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidRSASSAPSS     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 10}
+)
+
+// ErrNoTimestampAuthorityCertificate is returned when a timestamp token has to be verified but no
+// certificate of the timestamp authority is configured to verify it against
+var ErrNoTimestampAuthorityCertificate = errors.New("no timestamp authority certificate configured")
+
+// PKIStatus values that indicate the TSA granted the request (RFC 3161 section 2.4.2)
+const (
+	pkiStatusGranted         = 0
+	pkiStatusGrantedWithMods = 1
+)
+
+// TimestampToken is a trusted timestamp issued by an RFC 3161 timestamp authority
+type TimestampToken struct {
+	Token       string    `json:"token"` // Base64 encoded DER TimeStampToken
+	TrustedTime time.Time `json:"trusted_time"`
+	Authority   string    `json:"authority"`
+}
+
+// TimestampAuthorityClient requests and validates RFC 3161 timestamp tokens. Tokens are only
+// trusted when they are signed by a certificate that chains to one of roots.
+type TimestampAuthorityClient struct {
+	url        string
+	roots      *x509.CertPool
+	httpClient *http.Client
+}
+
+// NewTimestampAuthorityClient creates a new RFC 3161 client for the given TSA URL whose tokens are
+// verified against roots
+func NewTimestampAuthorityClient(url string, roots *x509.CertPool) *TimestampAuthorityClient {
+	return &TimestampAuthorityClient{
+		url:   url,
+		roots: roots,
+		httpClient: &http.Client{
+			Timeout: 15 * time.Second,
+		},
+	}
+}
+
+// LoadTimestampAuthorityCertificates reads the PEM encoded certificates a timestamp token may chain
+// to: the certificate of the TSA itself or of the CA that issued it
+func LoadTimestampAuthorityCertificates(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load timestamp authority certificate: %w", err)
+	}
+
+	roots := x509.NewCertPool()
+	found := false
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse timestamp authority certificate: %w", err)
+		}
+		roots.AddCert(certificate)
+		found = true
+	}
+	if !found {
+		return nil, fmt.Errorf("no PEM certificate found in %s", path)
+	}
+	return roots, nil
+}
+
+type messageImprint struct {
+	HashAlgorithm pkix.AlgorithmIdentifier
+	HashedMessage []byte
+}
+
+type timeStampReq struct {
+	Version        int
+	MessageImprint messageImprint
+	Nonce          *big.Int `asn1:"optional"`
+	CertReq        bool     `asn1:"optional"`
+}
+
+type pkiStatusInfo struct {
+	Status       int
+	StatusString asn1.RawValue  `asn1:"optional"`
+	FailInfo     asn1.BitString `asn1:"optional"`
+}
+
+type timeStampResp struct {
+	Status         pkiStatusInfo
+	TimeStampToken asn1.RawValue `asn1:"optional"`
+}
+
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,tag:0"`
+}
+
+type encapsulatedContentInfo struct {
+	EContentType asn1.ObjectIdentifier
+	EContent     []byte `asn1:"explicit,optional,tag:0"`
+}
+
+type signedData struct {
+	Version          int
+	DigestAlgorithms asn1.RawValue
+	EncapContentInfo encapsulatedContentInfo
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue `asn1:"optional,tag:1"`
+	SignerInfos      asn1.RawValue
+}
+
+type signerInfo struct {
+	Version            int
+	SID                asn1.RawValue
+	DigestAlgorithm    pkix.AlgorithmIdentifier
+	SignedAttrs        asn1.RawValue `asn1:"optional,tag:0"`
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          []byte
+	UnsignedAttrs      asn1.RawValue `asn1:"optional,tag:1"`
+}
+
+type issuerAndSerialNumber struct {
+	Issuer       asn1.RawValue
+	SerialNumber *big.Int
+}
+
+type attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values asn1.RawValue
+}
+
+type tstAccuracy struct {
+	Seconds int `asn1:"optional"`
+	Millis  int `asn1:"optional,tag:0"`
+	Micros  int `asn1:"optional,tag:1"`
+}
+
+type tstInfo struct {
+	Version        int
+	Policy         asn1.ObjectIdentifier
+	MessageImprint messageImprint
+	SerialNumber   *big.Int
+	GenTime        time.Time     `asn1:"generalized"`
+	Accuracy       tstAccuracy   `asn1:"optional"`
+	Ordering       bool          `asn1:"optional"`
+	Nonce          *big.Int      `asn1:"optional"`
+	TSA            asn1.RawValue `asn1:"optional,tag:0"`
+	Extensions     asn1.RawValue `asn1:"optional,tag:1"`
+}
+
+// RequestTimestamp asks the TSA to timestamp the SHA-256 digest of data
+func (c *TimestampAuthorityClient) RequestTimestamp(ctx context.Context, data []byte) (*TimestampToken, error) {
+	digest := sha256.Sum256(data)
+
+	nonce, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	reqBytes, err := asn1.Marshal(timeStampReq{
+		Version: 1,
+		MessageImprint: messageImprint{
+			HashAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidSHA256},
+			HashedMessage: digest[:],
+		},
+		Nonce:   nonce,
+		CertReq: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode timestamp request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(reqBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create timestamp request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/timestamp-query")
+	httpReq.Header.Set("Accept", "application/timestamp-reply")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call timestamp authority: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read timestamp response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("timestamp authority returned status %d", resp.StatusCode)
+	}
+
+	var tsResp timeStampResp
+	if _, err := asn1.Unmarshal(body, &tsResp); err != nil {
+		return nil, fmt.Errorf("failed to parse timestamp response: %w", err)
+	}
+
+	if tsResp.Status.Status != pkiStatusGranted && tsResp.Status.Status != pkiStatusGrantedWithMods {
+		return nil, fmt.Errorf("timestamp authority rejected request with status %d", tsResp.Status.Status)
+	}
+
+	info, err := c.verifyTimestampToken(tsResp.TimeStampToken.FullBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Nonce == nil || info.Nonce.Cmp(nonce) != 0 {
+		return nil, fmt.Errorf("timestamp token nonce does not match request")
+	}
+
+	if !bytes.Equal(info.MessageImprint.HashedMessage, digest[:]) {
+		return nil, fmt.Errorf("timestamp token does not match signed data")
+	}
+
+	return &TimestampToken{
+		Token:       base64.StdEncoding.EncodeToString(tsResp.TimeStampToken.FullBytes),
+		TrustedTime: info.GenTime.UTC(),
+		Authority:   c.url,
+	}, nil
+}
+
+// VerifyTimestamp checks that a stored token was signed by the timestamp authority and issued for
+// data, and returns its trusted time
+func (c *TimestampAuthorityClient) VerifyTimestamp(token string, data []byte) (time.Time, error) {
+	tokenBytes, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("decode timestamp token: %w", err)
+	}
+
+	info, err := c.verifyTimestampToken(tokenBytes)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if !info.MessageImprint.HashAlgorithm.Algorithm.Equal(oidSHA256) {
+		return time.Time{}, fmt.Errorf("unsupported timestamp hash algorithm %s", info.MessageImprint.HashAlgorithm.Algorithm)
+	}
+
+	digest := sha256.Sum256(data)
+	if !bytes.Equal(info.MessageImprint.HashedMessage, digest[:]) {
+		return time.Time{}, fmt.Errorf("timestamp token does not match signed data")
+	}
+
+	return info.GenTime.UTC(), nil
+}
+
+// verifyTimestampToken parses a DER encoded TimeStampToken and checks its CMS signature: the signed
+// attributes must match the TSTInfo, be signed by the signer's certificate, and that certificate must
+// be a timestamping certificate chaining to the configured roots at the time the token was issued.
+func (c *TimestampAuthorityClient) verifyTimestampToken(der []byte) (*tstInfo, error) {
+	if c.roots == nil {
+		return nil, ErrNoTimestampAuthorityCertificate
+	}
+
+	sd, info, err := parseTimestampToken(der)
+	if err != nil {
+		return nil, err
+	}
+
+	var signer signerInfo
+	rest, err := asn1.Unmarshal(sd.SignerInfos.Bytes, &signer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse timestamp signer: %w", err)
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("timestamp token has more than one signer")
+	}
+
+	hash, err := digestHash(signer.DigestAlgorithm.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	// RFC 3161 requires signed attributes, so the signature covers them instead of the content
+	if len(signer.SignedAttrs.FullBytes) == 0 {
+		return nil, fmt.Errorf("timestamp token has no signed attributes")
+	}
+	if err := checkSignedAttributes(signer.SignedAttrs.Bytes, hash, sd.EncapContentInfo.EContent); err != nil {
+		return nil, err
+	}
+
+	var embedded []*x509.Certificate
+	if len(sd.Certificates.Bytes) > 0 {
+		embedded, err = x509.ParseCertificates(sd.Certificates.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse timestamp certificates: %w", err)
+		}
+	}
+	certificate, err := findSignerCertificate(signer.SID, embedded)
+	if err != nil {
+		return nil, err
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range embedded {
+		intermediates.AddCert(cert)
+	}
+	if _, err := certificate.Verify(x509.VerifyOptions{
+		Roots:         c.roots,
+		Intermediates: intermediates,
+		CurrentTime:   info.GenTime,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping},
+	}); err != nil {
+		return nil, fmt.Errorf("timestamp authority certificate is not trusted: %w", err)
+	}
+
+	// The signature is computed over the DER SET OF the attributes, not their implicit [0] tag
+	signedAttrs := append([]byte{0x31}, signer.SignedAttrs.FullBytes[1:]...)
+	if err := verifySignerSignature(certificate, signer.SignatureAlgorithm.Algorithm, hash, signedAttrs, signer.Signature); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// checkSignedAttributes checks that the content type and message digest attributes match the TSTInfo
+func checkSignedAttributes(attrs []byte, hash crypto.Hash, content []byte) error {
+	var contentTypeOK, digestOK bool
+	for len(attrs) > 0 {
+		var attr attribute
+		var err error
+		attrs, err = asn1.Unmarshal(attrs, &attr)
+		if err != nil {
+			return fmt.Errorf("failed to parse timestamp signed attribute: %w", err)
+		}
+
+		switch {
+		case attr.Type.Equal(oidContentType):
+			var contentType asn1.ObjectIdentifier
+			if _, err := asn1.Unmarshal(attr.Values.Bytes, &contentType); err != nil {
+				return fmt.Errorf("failed to parse timestamp content type: %w", err)
+			}
+			contentTypeOK = contentType.Equal(oidTSTInfo)
+		case attr.Type.Equal(oidMessageDigest):
+			var digest []byte
+			if _, err := asn1.Unmarshal(attr.Values.Bytes, &digest); err != nil {
+				return fmt.Errorf("failed to parse timestamp message digest: %w", err)
+			}
+			h := hash.New()
+			h.Write(content)
+			digestOK = bytes.Equal(digest, h.Sum(nil))
+		}
+	}
+
+	if !contentTypeOK {
+		return fmt.Errorf("timestamp signed attributes do not name TSTInfo content")
+	}
+	if !digestOK {
+		return fmt.Errorf("timestamp signature does not cover its TSTInfo")
+	}
+	return nil
+}
+
+// findSignerCertificate returns the embedded certificate identified by the signer's issuer and serial
+// number or subject key identifier
+func findSignerCertificate(sid asn1.RawValue, certificates []*x509.Certificate) (*x509.Certificate, error) {
+	if sid.Class == asn1.ClassContextSpecific && sid.Tag == 0 {
+		for _, cert := range certificates {
+			if len(cert.SubjectKeyId) > 0 && bytes.Equal(cert.SubjectKeyId, sid.Bytes) {
+				return cert, nil
+			}
+		}
+		return nil, fmt.Errorf("timestamp token does not contain its signer certificate")
+	}
+
+	var id issuerAndSerialNumber
+	if _, err := asn1.Unmarshal(sid.FullBytes, &id); err != nil {
+		return nil, fmt.Errorf("failed to parse timestamp signer identifier: %w", err)
+	}
+	for _, cert := range certificates {
+		if bytes.Equal(cert.RawIssuer, id.Issuer.FullBytes) && cert.SerialNumber.Cmp(id.SerialNumber) == 0 {
+			return cert, nil
+		}
+	}
+	return nil, fmt.Errorf("timestamp token does not contain its signer certificate")
+}
+
+// verifySignerSignature checks an RSA PKCS #1 v1.5 or ECDSA signature over the signed attributes
+func verifySignerSignature(certificate *x509.Certificate, algorithm asn1.ObjectIdentifier, hash crypto.Hash, signed, signature []byte) error {
+	h := hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	switch publicKey := certificate.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if algorithm.Equal(oidRSASSAPSS) {
+			return fmt.Errorf("unsupported timestamp signature algorithm %s", algorithm)
+		}
+		if err := rsa.VerifyPKCS1v15(publicKey, hash, digest, signature); err != nil {
+			return fmt.Errorf("timestamp token signature is invalid: %w", err)
+		}
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(publicKey, digest, signature) {
+			return fmt.Errorf("timestamp token signature is invalid")
+		}
+	default:
+		return fmt.Errorf("unsupported timestamp authority key type %T", certificate.PublicKey)
+	}
+	return nil
+}
+
+// digestHash maps a CMS digest algorithm to its hash
+func digestHash(algorithm asn1.ObjectIdentifier) (crypto.Hash, error) {
+	switch {
+	case algorithm.Equal(oidSHA256):
+		return crypto.SHA256, nil
+	case algorithm.Equal(oidSHA384):
+		return crypto.SHA384, nil
+	case algorithm.Equal(oidSHA512):
+		return crypto.SHA512, nil
+	}
+	return 0, fmt.Errorf("unsupported timestamp digest algorithm %s", algorithm)
+}
+
+// parseTimestampToken extracts the SignedData and its TSTInfo from a DER encoded TimeStampToken
+func parseTimestampToken(der []byte) (*signedData, *tstInfo, error) {
+	if len(der) == 0 {
+		return nil, nil, fmt.Errorf("timestamp response does not contain a token")
+	}
+
+	var ci contentInfo
+	if _, err := asn1.Unmarshal(der, &ci); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse timestamp token: %w", err)
+	}
+	if !ci.ContentType.Equal(oidSignedData) {
+		return nil, nil, fmt.Errorf("timestamp token is not CMS signed data")
+	}
+
+	var sd signedData
+	if _, err := asn1.Unmarshal(ci.Content.Bytes, &sd); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse timestamp signed data: %w", err)
+	}
+	if !sd.EncapContentInfo.EContentType.Equal(oidTSTInfo) {
+		return nil, nil, fmt.Errorf("timestamp token does not contain TSTInfo")
+	}
+
+	var info tstInfo
+	if _, err := asn1.Unmarshal(sd.EncapContentInfo.EContent, &info); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse TSTInfo: %w", err)
+	}
+
+	return &sd, &info, nil
+}
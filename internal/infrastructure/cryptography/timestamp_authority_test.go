@@ -0,0 +1,186 @@
+package cryptography
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"math/big"
+	"testing"
+	"time"
+)
+
+var oidECDSAWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}
+
+// testTSA is a CA and a timestamping certificate it issued
+type testTSA struct {
+	roots *x509.CertPool
+	key   *ecdsa.PrivateKey
+	cert  *x509.Certificate
+}
+
+func newTestTSA(t *testing.T) *testTSA {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate CA key: %v", err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test TSA Root"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("create CA certificate: %v", err)
+	}
+	caCert, _ := x509.ParseCertificate(caDER)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate TSA key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "Test TSA"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("create TSA certificate: %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+	return &testTSA{roots: roots, key: key, cert: cert}
+}
+
+// set wraps DER elements in a SET
+func set(t *testing.T, elements ...[]byte) []byte {
+	t.Helper()
+	var content []byte
+	for _, element := range elements {
+		content = append(content, element...)
+	}
+	der, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: content})
+	if err != nil {
+		t.Fatalf("marshal set: %v", err)
+	}
+	return der
+}
+
+func marshal(t *testing.T, v interface{}) []byte {
+	t.Helper()
+	der, err := asn1.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal %T: %v", v, err)
+	}
+	return der
+}
+
+// token issues a base64 timestamp token over data. tamper may change the TSTInfo after it is signed.
+func (tsa *testTSA) token(t *testing.T, data []byte, tamper func(*tstInfo)) string {
+	t.Helper()
+
+	digest := sha256.Sum256(data)
+	info := tstInfo{
+		Version:        1,
+		Policy:         asn1.ObjectIdentifier{1, 2, 3, 4},
+		MessageImprint: messageImprint{HashAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidSHA256}, HashedMessage: digest[:]},
+		SerialNumber:   big.NewInt(42),
+		GenTime:        time.Now().UTC().Truncate(time.Second),
+	}
+	content := marshal(t, info)
+	contentDigest := sha256.Sum256(content)
+
+	signedAttrs := set(t,
+		marshal(t, attribute{Type: oidContentType, Values: asn1.RawValue{FullBytes: set(t, marshal(t, oidTSTInfo))}}),
+		marshal(t, attribute{Type: oidMessageDigest, Values: asn1.RawValue{FullBytes: set(t, marshal(t, contentDigest[:]))}}),
+	)
+	attrsDigest := sha256.Sum256(signedAttrs)
+	signature, err := ecdsa.SignASN1(rand.Reader, tsa.key, attrsDigest[:])
+	if err != nil {
+		t.Fatalf("sign attributes: %v", err)
+	}
+
+	if tamper != nil {
+		tamper(&info)
+		content = marshal(t, info)
+	}
+
+	implicitAttrs := append([]byte{0xA0}, signedAttrs[1:]...)
+	signer := marshal(t, signerInfo{
+		Version: 1,
+		SID: asn1.RawValue{FullBytes: marshal(t, issuerAndSerialNumber{
+			Issuer:       asn1.RawValue{FullBytes: tsa.cert.RawIssuer},
+			SerialNumber: tsa.cert.SerialNumber,
+		})},
+		DigestAlgorithm:    pkix.AlgorithmIdentifier{Algorithm: oidSHA256},
+		SignedAttrs:        asn1.RawValue{FullBytes: implicitAttrs},
+		SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidECDSAWithSHA256},
+		Signature:          signature,
+	})
+	certificates := marshal(t, asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: tsa.cert.Raw})
+
+	sd := marshal(t, signedData{
+		Version:          3,
+		DigestAlgorithms: asn1.RawValue{FullBytes: set(t, marshal(t, pkix.AlgorithmIdentifier{Algorithm: oidSHA256}))},
+		EncapContentInfo: encapsulatedContentInfo{EContentType: oidTSTInfo, EContent: content},
+		Certificates:     asn1.RawValue{FullBytes: certificates},
+		SignerInfos:      asn1.RawValue{FullBytes: set(t, signer)},
+	})
+	// Marshal writes a RawValue with FullBytes verbatim, so the explicit [0] of the content is spelled out
+	token := marshal(t, struct {
+		ContentType asn1.ObjectIdentifier
+		Content     asn1.RawValue
+	}{oidSignedData, asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: sd}})
+	return base64.StdEncoding.EncodeToString(token)
+}
+
+func TestVerifyTimestamp(t *testing.T) {
+	tsa := newTestTSA(t)
+	data := []byte("signature value")
+
+	token := tsa.token(t, data, nil)
+	got, err := NewTimestampAuthorityClient("", tsa.roots).VerifyTimestamp(token, data)
+	if err != nil {
+		t.Fatalf("VerifyTimestamp() error = %v", err)
+	}
+	if time.Since(got) > time.Minute {
+		t.Errorf("trusted time = %v, want the time the token was issued", got)
+	}
+
+	tests := []struct {
+		name   string
+		token  string
+		data   []byte
+		client *TimestampAuthorityClient
+	}{
+		{name: "other data", token: token, data: []byte("other signature"), client: NewTimestampAuthorityClient("", tsa.roots)},
+		{name: "tampered time", token: tsa.token(t, data, func(info *tstInfo) {
+			info.GenTime = info.GenTime.Add(-24 * time.Hour)
+		}), data: data, client: NewTimestampAuthorityClient("", tsa.roots)},
+		{name: "untrusted authority", token: token, data: data, client: NewTimestampAuthorityClient("", newTestTSA(t).roots)},
+		{name: "no certificate configured", token: token, data: data, client: NewTimestampAuthorityClient("", nil)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.client.VerifyTimestamp(tt.token, tt.data); err == nil {
+				t.Error("VerifyTimestamp() error = nil, want the token rejected")
+			}
+		})
+	}
+}
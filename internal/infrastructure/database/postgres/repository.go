@@ -34,6 +34,10 @@ func (r *Repository) CreateZoomMeeting(ctx context.Context, meeting *entity.Meet
 	return r.zoomClient.CreateZoomMeeting(ctx, *meeting)
 }
 
+func (r *Repository) GetZoomMeeting(ctx context.Context, meetingID string) (*entity.Meeting, error) {
+	return r.zoomClient.GetZoomMeeting(ctx, meetingID)
+}
+
 func (r *Repository) CreateDriveFolder(ctx context.Context, parentFolderID, folderName string) (string, error) {
 	return r.driveClient.CreateFolder(ctx, parentFolderID, folderName)
 }
@@ -8,6 +8,8 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	"sandbox/pkg/logging"
 )
 
 type Client struct {
@@ -34,11 +36,9 @@ type FileResponse struct {
 
 func NewClient(apiKey string) *Client {
 	return &Client{
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		apiKey:  apiKey,
-		baseURL: "https://www.googleapis.com/drive/v3",
+		httpClient: logging.NewHTTPClient("google_drive", 30*time.Second),
+		apiKey:     apiKey,
+		baseURL:    "https://www.googleapis.com/drive/v3",
 	}
 }
 
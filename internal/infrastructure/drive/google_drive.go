@@ -1,6 +1,7 @@
 package drive
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -11,6 +12,7 @@ import (
 	"time"
 
 	"sandbox/internal/domain/service"
+	"sandbox/pkg/logging"
 
 	"google.golang.org/api/drive/v3"
 	"google.golang.org/api/option"
@@ -73,14 +75,20 @@ func (g *GoogleDriveService) extractFolderID(link string) (string, error) {
 
 // GetFilesFromFolder retrieves files from a Google Drive folder
 func (g *GoogleDriveService) GetFilesFromFolder(ctx context.Context, folderLink string) ([]*service.DriveFile, error) {
+	start := time.Now()
+	files, err := g.getFilesFromFolder(ctx, folderLink)
+	logging.LogCall(ctx, "google_drive", "list_folder", start, err)
+	return files, err
+}
+
+func (g *GoogleDriveService) getFilesFromFolder(ctx context.Context, folderLink string) ([]*service.DriveFile, error) {
 	folderID, err := g.extractFolderID(folderLink)
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract folder ID: %w", err)
 	}
 
-	log.Println("Extracted folder ID:", folderID)
-	deadline, hasDeadline := ctx.Deadline()
-	log.Printf("Context type: %T, Context deadline: %v (has: %v)", ctx, deadline, hasDeadline)
+	logger := logging.FromContext(ctx)
+	logger.DebugContext(ctx, "listing Google Drive folder", "folder_id", folderID)
 
 	// First, get folder details to ensure it exists
 	folder, err := g.service.Files.Get(folderID).Fields("name", "id", "mimeType").Context(ctx).Do()
@@ -88,7 +96,7 @@ func (g *GoogleDriveService) GetFilesFromFolder(ctx context.Context, folderLink
 		return nil, fmt.Errorf("failed to access folder: %w", err)
 	}
 
-	log.Printf("Found folder: %s (%s)", folder.Name, folder.Id)
+	logger.DebugContext(ctx, "found Google Drive folder", "folder_name", folder.Name, "folder_id", folder.Id)
 
 	// Now get files in the folder
 	query := fmt.Sprintf("'%s' in parents and trashed=false", folderID)
@@ -123,12 +131,19 @@ func (g *GoogleDriveService) GetFilesFromFolder(ctx context.Context, folderLink
 		driveFiles = append(driveFiles, driveFile)
 	}
 
-	log.Printf("Found %d relevant files in folder", len(driveFiles))
+	logger.DebugContext(ctx, "found relevant files in Google Drive folder", "count", len(driveFiles))
 	return driveFiles, nil
 }
 
 // DownloadFile downloads file content from Google Drive
 func (g *GoogleDriveService) DownloadFile(ctx context.Context, fileID string) ([]byte, error) {
+	start := time.Now()
+	content, err := g.downloadFile(ctx, fileID)
+	logging.LogCall(ctx, "google_drive", "download_file", start, err)
+	return content, err
+}
+
+func (g *GoogleDriveService) downloadFile(ctx context.Context, fileID string) ([]byte, error) {
 	if fileID == "" {
 		return nil, fmt.Errorf("file ID is required")
 	}
@@ -139,7 +154,8 @@ func (g *GoogleDriveService) DownloadFile(ctx context.Context, fileID string) ([
 		return nil, fmt.Errorf("file not found or access denied: %w", err)
 	}
 
-	log.Printf("Downloading file: %s (%s)", file.Name, file.Id)
+	logger := logging.FromContext(ctx)
+	logger.DebugContext(ctx, "downloading Google Drive file", "file_name", file.Name, "file_id", fileID)
 
 	var resp *http.Response
 	if strings.HasPrefix(file.MimeType, "application/vnd.google-apps") {
@@ -163,10 +179,46 @@ func (g *GoogleDriveService) DownloadFile(ctx context.Context, fileID string) ([
 		return nil, fmt.Errorf("failed to read file content: %w", err)
 	}
 
-	log.Printf("Successfully downloaded file: %s (%d bytes)", file.Name, len(content))
+	logger.DebugContext(ctx, "downloaded Google Drive file", "file_name", file.Name, "bytes", len(content))
 	return content, nil
 }
 
+// UploadFile uploads content as a new file in the given folder
+func (g *GoogleDriveService) UploadFile(ctx context.Context, folderID, name, mimeType string, content []byte) (*service.DriveFile, error) {
+	start := time.Now()
+	file, err := g.uploadFile(ctx, folderID, name, mimeType, content)
+	logging.LogCall(ctx, "google_drive", "upload_file", start, err)
+	return file, err
+}
+
+func (g *GoogleDriveService) uploadFile(ctx context.Context, folderID, name, mimeType string, content []byte) (*service.DriveFile, error) {
+	metadata := &drive.File{
+		Name:     name,
+		MimeType: mimeType,
+	}
+	if folderID != "" {
+		metadata.Parents = []string{folderID}
+	}
+
+	file, err := g.service.Files.Create(metadata).
+		Media(bytes.NewReader(content)).
+		Fields("id", "name", "mimeType", "webViewLink").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	logging.FromContext(ctx).DebugContext(ctx, "uploaded Google Drive file", "file_name", file.Name, "file_id", file.Id, "bytes", len(content))
+
+	return &service.DriveFile{
+		ID:   file.Id,
+		Name: file.Name,
+		Type: g.getFileType(file.MimeType),
+		URL:  file.WebViewLink,
+	}, nil
+}
+
 // isRelevantFileType checks if the file type is relevant for LAKIP checking
 func (g *GoogleDriveService) isRelevantFileType(mimeType string) bool {
 	relevantTypes := []string{
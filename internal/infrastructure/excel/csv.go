@@ -0,0 +1,65 @@
+package excel
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// TransactionCSVRow is a single transaction line in the transactions CSV export
+type TransactionCSVRow struct {
+	AssigneeName string
+	SpdNumber    string
+	Type         string
+	Subtype      string
+	Direction    string
+	Amount       float64
+	TotalNight   *int
+	Subtotal     float64
+}
+
+var transactionCSVHeader = []string{
+	"assignee_name",
+	"spd_number",
+	"type",
+	"subtype",
+	"direction",
+	"amount",
+	"total_night",
+	"subtotal",
+}
+
+// GenerateTransactionsCSV streams the given transactions as CSV to w.
+// The header row is always written, so an empty slice yields a header-only file.
+func (g *Generator) GenerateTransactionsCSV(w io.Writer, rows []TransactionCSVRow) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(transactionCSVHeader); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, row := range rows {
+		totalNight := ""
+		if row.TotalNight != nil {
+			totalNight = strconv.Itoa(*row.TotalNight)
+		}
+
+		record := []string{
+			row.AssigneeName,
+			row.SpdNumber,
+			row.Type,
+			row.Subtype,
+			row.Direction,
+			strconv.FormatFloat(row.Amount, 'f', -1, 64),
+			totalNight,
+			strconv.FormatFloat(row.Subtotal, 'f', -1, 64),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -14,6 +14,8 @@ import (
 
 	"sandbox/internal/domain/repository"
 	transactionDTO "sandbox/internal/usecase/transaction"
+	"sandbox/pkg/logging"
+	"sandbox/pkg/retry"
 )
 
 const (
@@ -27,10 +29,8 @@ type Client struct {
 
 func NewClient(apiKey string) *Client {
 	return &Client{
-		apiKey: apiKey,
-		httpClient: &http.Client{
-			Timeout: 300 * time.Second,
-		},
+		apiKey:     apiKey,
+		httpClient: logging.NewHTTPClient("gemini", 300*time.Second),
 	}
 }
 
@@ -75,12 +75,13 @@ func (c *Client) ExtractFromDocuments(ctx context.Context, documents []repositor
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", c.getAPIURL(), bytes.NewBuffer(jsonBody))
+	req, err := http.NewRequestWithContext(ctx, "POST", geminiAPIURL, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-goog-api-key", c.apiKey)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -103,10 +104,6 @@ func (c *Client) ExtractFromDocuments(ctx context.Context, documents []repositor
 	return c.parseResponse(bodyResp)
 }
 
-func (c *Client) getAPIURL() string {
-	return fmt.Sprintf("%s?key=%s", geminiAPIURL, c.apiKey)
-}
-
 func (c *Client) definePrompt(promptType string) string {
 	if promptType == "scanAssigneeTransaction" {
 		return c.scanAssigneeTransaction()
@@ -276,12 +273,13 @@ func (c *Client) ExtractVaccineRecommendations(ctx context.Context, htmlContent
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", c.getAPIURL(), bytes.NewBuffer(jsonBody))
+	req, err := http.NewRequestWithContext(ctx, "POST", geminiAPIURL, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-goog-api-key", c.apiKey)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -295,7 +293,7 @@ func (c *Client) ExtractVaccineRecommendations(ctx context.Context, htmlContent
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("gemini api error (status %d): %s", resp.StatusCode, string(bodyResp))
+		return nil, retry.NewStatusError(resp.StatusCode, fmt.Errorf("gemini api error (status %d): %s", resp.StatusCode, string(bodyResp)))
 	}
 
 	return c.parseVaccineResponse(bodyResp)
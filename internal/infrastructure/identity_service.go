@@ -21,6 +21,8 @@ type User struct {
 	LastName     string           `json:"last_name"`
 	Email        *string          `json:"email"`
 	PhoneNumber  string           `json:"phone_number"`
+	Position     string           `json:"position"`
+	Rank         string           `json:"rank"`
 	IsActive     bool             `json:"is_active"`
 	Organization UserOrganization `json:"organization"`
 	Roles        []Role           `json:"roles"`
@@ -60,6 +62,8 @@ type CreateUserData struct {
 	LastName       string
 	Email          string
 	PhoneNumber    string
+	Position       string
+	Rank           string
 	Organization   string
 }
 
@@ -87,6 +91,8 @@ func (u *User) ExtractCreateUserData() *CreateUserData {
 		LastName:       u.LastName,
 		Email:          email,
 		PhoneNumber:    u.PhoneNumber,
+		Position:       u.Position,
+		Rank:           u.Rank,
 		Organization:   u.Organization.Name,
 	}
 }
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"sandbox/internal/domain/service"
+	"sandbox/pkg/retry"
 )
 
 // GeminiService implements LLMService interface using Google Gemini API
@@ -20,11 +21,23 @@ type GeminiService struct {
 	apiKey     string
 	httpClient *http.Client
 	model      string
+	prompts    *PromptTemplates
+}
+
+// PromptTemplateHash returns the hash of the prompt template used for checks of the work paper item type
+func (g *GeminiService) PromptTemplateHash(itemType string) string {
+	return g.prompts.ForItemType(itemType).Hash()
 }
 
 // geminiRequest represents the request structure for Gemini API
 type geminiRequest struct {
-	Contents []geminiContent `json:"contents"`
+	Contents         []geminiContent         `json:"contents"`
+	GenerationConfig *geminiGenerationConfig `json:"generationConfig,omitempty"`
+}
+
+// geminiGenerationConfig represents the sampling settings of a Gemini API request
+type geminiGenerationConfig struct {
+	Temperature *float64 `json:"temperature,omitempty"`
 }
 
 // geminiContent represents content structure for Gemini API
@@ -54,18 +67,25 @@ type geminiCandidate struct {
 	Content geminiContent `json:"content"`
 }
 
-// NewGeminiService creates a new Gemini service instance
-func NewGeminiService(apiKey string) (service.LLMService, error) {
+// NewGeminiService creates a new Gemini service instance. Without prompts every check uses DefaultPromptTemplate.
+func NewGeminiService(apiKey string, prompts *PromptTemplates) (service.LLMService, error) {
 	if apiKey == "" {
 		return nil, fmt.Errorf("Gemini API key is required")
 	}
+	if prompts == nil {
+		var err error
+		if prompts, err = LoadPromptTemplates("", nil); err != nil {
+			return nil, err
+		}
+	}
 
 	return &GeminiService{
 		apiKey: apiKey,
 		httpClient: &http.Client{
 			Timeout: 2000 * time.Second, // Longer timeout for document processing
 		},
-		model: "gemini-2.5-flash", // Using flash model for better multimodal capabilities
+		model:   "gemini-2.5-flash", // Using flash model for better multimodal capabilities
+		prompts: prompts,
 	}, nil
 }
 
@@ -80,15 +100,9 @@ func (g *GeminiService) CheckDocument(ctx context.Context, req *service.Document
 		log.Printf("Document %d: Name='%s', Type='%s', Size=%d bytes", i, doc.Name, doc.Type, len(doc.Data))
 	}
 
-	// Build the prompt
-	prompt := g.buildPrompt(req.Number, req.Statement, req.Explanation, req.FillingGuide)
-
-	// Prepare parts for the request
-	parts := []geminiPart{
-		{Text: prompt},
-	}
-
 	// Add documents as inline data (limit to reasonable number/size)
+	var parts []geminiPart
+	var attached []service.DocumentFile
 	maxDocuments := 5              // Limit number of documents to avoid exceeding token limits
 	maxDocSize := 10 * 1024 * 1024 // 10MB per document
 
@@ -117,10 +131,21 @@ func (g *GeminiService) CheckDocument(ctx context.Context, req *service.Document
 				Data:     data,
 			},
 		})
+		attached = append(attached, doc)
 	}
 
+	// The prompt goes first and names the documents that were attached
+	prompt := g.prompts.ForItemType(req.ItemType).Render(req, attached)
+	parts = append([]geminiPart{{Text: prompt}}, parts...)
+
 	log.Printf("Total parts being sent to Gemini API: %d (1 text + %d documents)", len(parts), len(parts)-1)
 
+	// The request may ask for another model or temperature than the defaults
+	model := g.model
+	if req.Model != "" {
+		model = req.Model
+	}
+
 	// Build the request
 	geminiReq := geminiRequest{
 		Contents: []geminiContent{
@@ -129,6 +154,9 @@ func (g *GeminiService) CheckDocument(ctx context.Context, req *service.Document
 			},
 		},
 	}
+	if req.Temperature != nil {
+		geminiReq.GenerationConfig = &geminiGenerationConfig{Temperature: req.Temperature}
+	}
 
 	// Serialize request
 	jsonBody, err := json.Marshal(geminiReq)
@@ -136,8 +164,8 @@ func (g *GeminiService) CheckDocument(ctx context.Context, req *service.Document
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	// Make API call
-	apiURL := fmt.Sprintf("https://generativelanguage.googleapis.com/v1beta/models/%s:generateContent?key=%s", g.model, g.apiKey)
+	// Make API call. The key is sent as a header so it never appears in the URL of a logged error.
+	apiURL := fmt.Sprintf("https://generativelanguage.googleapis.com/v1beta/models/%s:generateContent", model)
 
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(jsonBody))
 	if err != nil {
@@ -145,6 +173,7 @@ func (g *GeminiService) CheckDocument(ctx context.Context, req *service.Document
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("x-goog-api-key", g.apiKey)
 
 	resp, err := g.httpClient.Do(httpReq)
 	if err != nil {
@@ -158,7 +187,7 @@ func (g *GeminiService) CheckDocument(ctx context.Context, req *service.Document
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Gemini API error (status %d): %s", resp.StatusCode, string(body))
+		return nil, retry.NewStatusError(resp.StatusCode, fmt.Errorf("Gemini API error (status %d): %s", resp.StatusCode, string(body)))
 	}
 
 	// Parse response
@@ -180,43 +209,24 @@ func (g *GeminiService) CheckDocument(ctx context.Context, req *service.Document
 		return nil, fmt.Errorf("failed to parse LLM response: %w", err)
 	}
 
-	result.Model = g.model
+	result.Model = model
 
 	return result, nil
 }
 
-// buildPrompt constructs the prompt for LAKIP document checking
-func (g *GeminiService) buildPrompt(number, statement, explanation, fillingGuide string) string {
-	prompt := fmt.Sprintf(`Periksa dokumen yang diberikan sesuai dengan poin kertas kerja LAKIP berikut:
-
-Nomor: %s
-Pernyataan: %s
-Penjelasan: %s
-Petunjuk Pengisian: %s
-
-Tugas Anda:
-1. Analisis semua dokumen yang disediakan
-2. Periksa apakah dokumen memenuhi persyaratan yang disebutkan dalam pernyataan dan penjelasan
-3. Pertimbangkan petunjuk pengisian dalam evaluasi Anda
-4. Berikan penilaian objektif tentang kelengkapan dan kepatuhan dokumen
-
-Jawab dengan format JSON berikut:
-{
-  "isValid": true/false,
-  "note": "Penjelasan tentang temuan, rekomendasi, atau alasan penilaian (maksimal 5-6 kalimat)"
+// llmAnswer is the JSON answer the prompt asks for. The isValid and note keys of the older prompt
+// are still read so custom templates written for it keep working.
+type llmAnswer struct {
+	IsValid       *bool    `json:"is_valid"`
+	Confidence    *float64 `json:"confidence"`
+	MissingItems  []string `json:"missing_items"`
+	Notes         string   `json:"notes"`
+	LegacyIsValid *bool    `json:"isValid"`
+	LegacyNote    string   `json:"note"`
 }
 
-Kriteria penilaian:
-- isValid: true jika dokumen lengkap dan memenuhi persyaratan
-- isValid: false jika dokumen tidak lengkap, tidak memenuhi persyaratan, atau ada masalah signifikan
-- note: berikan penjelasan tentang temuan Anda
-
-Dokumen yang akan dianalisis:`, number, statement, explanation, fillingGuide)
-
-	return prompt
-}
-
-// parseLLMResponse parses the structured response from LLM
+// parseLLMResponse parses the structured response from LLM. An answer that is not the requested JSON
+// is kept whole as the notes, without a confidence.
 func (g *GeminiService) parseLLMResponse(rawText string) (*service.DocumentCheckResponse, error) {
 	// Clean the response text
 	cleanText := strings.TrimSpace(rawText)
@@ -241,50 +251,60 @@ func (g *GeminiService) parseLLMResponse(rawText string) (*service.DocumentCheck
 	jsonEnd := strings.LastIndex(cleanText, "}")
 
 	if jsonStart == -1 || jsonEnd == -1 || jsonStart >= jsonEnd {
-		return nil, fmt.Errorf("no valid JSON found in response: %s", cleanText)
+		log.Printf("No JSON found in LLM response, keeping it as notes")
+		return g.parseFallbackResponse(strings.TrimSpace(rawText))
 	}
 
 	jsonStr := cleanText[jsonStart : jsonEnd+1]
 
-	// Parse JSON
-	var response struct {
-		IsValid bool   `json:"isValid"`
-		Note    string `json:"note"`
+	var answer llmAnswer
+	if err := json.Unmarshal([]byte(jsonStr), &answer); err != nil {
+		log.Printf("Malformed JSON in LLM response, keeping it as notes: %v", err)
+		return g.parseFallbackResponse(strings.TrimSpace(rawText))
 	}
 
-	if err := json.Unmarshal([]byte(jsonStr), &response); err != nil {
-		// Fallback: try to extract boolean and note from text
-		return g.parseFallbackResponse(cleanText)
+	isValid := answer.IsValid
+	if isValid == nil {
+		isValid = answer.LegacyIsValid
+	}
+	if isValid == nil {
+		log.Printf("LLM response has no is_valid, keeping it as notes")
+		return g.parseFallbackResponse(strings.TrimSpace(rawText))
 	}
 
-	return &service.DocumentCheckResponse{
-		IsValid: response.IsValid,
-		Notes:   response.Note,
-	}, nil
-}
+	notes := answer.Notes
+	if notes == "" {
+		notes = answer.LegacyNote
+	}
 
-// parseFallbackResponse attempts to extract meaning when JSON parsing fails
-func (g *GeminiService) parseFallbackResponse(text string) (*service.DocumentCheckResponse, error) {
-	lowerText := strings.ToLower(text)
-
-	isValid := true
-	if strings.Contains(lowerText, "tidak memenuhi") ||
-		strings.Contains(lowerText, "tidak lengkap") ||
-		strings.Contains(lowerText, "belum ada") ||
-		strings.Contains(lowerText, "perlu perbaikan") ||
-		strings.Contains(lowerText, "invalid") ||
-		strings.Contains(lowerText, "false") {
-		isValid = false
+	// A confidence outside 0 to 1 is not one the prompt asked for
+	confidence := answer.Confidence
+	if confidence != nil && (*confidence < 0 || *confidence > 1) {
+		confidence = nil
 	}
 
-	notes := text
-	if len(notes) > 1000 {
-		notes = notes[:1000] + "..."
+	missingItems := make([]string, 0, len(answer.MissingItems))
+	for _, item := range answer.MissingItems {
+		if item = strings.TrimSpace(item); item != "" {
+			missingItems = append(missingItems, item)
+		}
 	}
 
 	return &service.DocumentCheckResponse{
-		IsValid: isValid,
-		Notes:   notes,
+		IsValid:      *isValid,
+		Notes:        notes,
+		Confidence:   confidence,
+		MissingItems: missingItems,
+	}, nil
+}
+
+// parseFallbackResponse keeps an answer that is not the requested JSON as the notes. Such an answer
+// may be truncated or blocked, so the documents are not treated as valid and the confidence is unset.
+func (g *GeminiService) parseFallbackResponse(text string) (*service.DocumentCheckResponse, error) {
+	return &service.DocumentCheckResponse{
+		IsValid:      false,
+		Notes:        text,
+		Unstructured: true,
 	}, nil
 }
 
@@ -0,0 +1,90 @@
+package llm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseLLMResponse(t *testing.T) {
+	tests := []struct {
+		name             string
+		raw              string
+		wantValid        bool
+		wantConfidence   *float64
+		wantMissingItems []string
+		wantNotes        string
+		wantUnstructured bool
+	}{
+		{
+			name:             "structured answer",
+			raw:              `{"is_valid": false, "confidence": 0.82, "missing_items": ["SK tim", " ", "Notulen rapat"], "notes": "SK tim belum ada."}`,
+			wantConfidence:   ptr(0.82),
+			wantMissingItems: []string{"SK tim", "Notulen rapat"},
+			wantNotes:        "SK tim belum ada.",
+		},
+		{
+			name:           "code block",
+			raw:            "```json\n{\"is_valid\": true, \"confidence\": 1, \"missing_items\": [], \"notes\": \"Lengkap.\"}\n```",
+			wantValid:      true,
+			wantConfidence: ptr(1),
+			wantNotes:      "Lengkap.",
+		},
+		{
+			name:      "older prompt answer",
+			raw:       `{"isValid": true, "note": "Dokumen lengkap."}`,
+			wantValid: true,
+			wantNotes: "Dokumen lengkap.",
+		},
+		{
+			name:      "confidence out of range",
+			raw:       `{"is_valid": true, "confidence": 85, "notes": "Lengkap."}`,
+			wantValid: true,
+			wantNotes: "Lengkap.",
+		},
+		{
+			name:             "malformed JSON",
+			raw:              `{"is_valid": false, "notes": "Dokumen tidak lengkap"`,
+			wantNotes:        `{"is_valid": false, "notes": "Dokumen tidak lengkap"`,
+			wantUnstructured: true,
+		},
+		{
+			name:             "plain text",
+			raw:              "Dokumen sudah sesuai dengan persyaratan.",
+			wantNotes:        "Dokumen sudah sesuai dengan persyaratan.",
+			wantUnstructured: true,
+		},
+		{
+			name:             "missing is_valid",
+			raw:              `{"notes": "Dokumen lengkap."}`,
+			wantNotes:        `{"notes": "Dokumen lengkap."}`,
+			wantUnstructured: true,
+		},
+	}
+
+	g := &GeminiService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := g.parseLLMResponse(tt.raw)
+			if err != nil {
+				t.Fatalf("parseLLMResponse() error = %v", err)
+			}
+			if got.IsValid != tt.wantValid || got.Notes != tt.wantNotes {
+				t.Errorf("parseLLMResponse() = (%v, %q), want (%v, %q)", got.IsValid, got.Notes, tt.wantValid, tt.wantNotes)
+			}
+			if got.Unstructured != tt.wantUnstructured {
+				t.Errorf("Unstructured = %v, want %v", got.Unstructured, tt.wantUnstructured)
+			}
+			if (got.Confidence == nil) != (tt.wantConfidence == nil) ||
+				(got.Confidence != nil && *got.Confidence != *tt.wantConfidence) {
+				t.Errorf("Confidence = %v, want %v", got.Confidence, tt.wantConfidence)
+			}
+			if strings.Join(got.MissingItems, "|") != strings.Join(tt.wantMissingItems, "|") {
+				t.Errorf("MissingItems = %q, want %q", got.MissingItems, tt.wantMissingItems)
+			}
+		})
+	}
+}
+
+func ptr(v float64) *float64 {
+	return &v
+}
@@ -0,0 +1,187 @@
+package llm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"sandbox/internal/domain/service"
+)
+
+// Placeholders of a document check prompt template, written as {{name}}. Every template must use all of them.
+const (
+	PlaceholderNumber       = "number"
+	PlaceholderStatement    = "statement"
+	PlaceholderExplanation  = "explanation"
+	PlaceholderFillingGuide = "filling_guide"
+	// PlaceholderDocuments lists the names and types of the documents attached to the check
+	PlaceholderDocuments = "documents"
+)
+
+var promptPlaceholders = []string{
+	PlaceholderNumber,
+	PlaceholderStatement,
+	PlaceholderExplanation,
+	PlaceholderFillingGuide,
+	PlaceholderDocuments,
+}
+
+var placeholderPattern = regexp.MustCompile(`{{\s*([a-zA-Z_]+)\s*}}`)
+
+// DefaultPromptTemplate is the document check prompt used when no template file is configured
+const DefaultPromptTemplate = `Periksa dokumen yang diberikan sesuai dengan poin kertas kerja LAKIP berikut:
+
+Nomor: {{number}}
+Pernyataan: {{statement}}
+Penjelasan: {{explanation}}
+Petunjuk Pengisian: {{filling_guide}}
+
+Tugas Anda:
+1. Analisis semua dokumen yang disediakan
+2. Periksa apakah dokumen memenuhi persyaratan yang disebutkan dalam pernyataan dan penjelasan
+3. Pertimbangkan petunjuk pengisian dalam evaluasi Anda
+4. Berikan penilaian objektif tentang kelengkapan dan kepatuhan dokumen
+
+Jawab hanya dengan JSON berikut, tanpa teks lain:
+{
+  "is_valid": true/false,
+  "confidence": 0.0-1.0,
+  "missing_items": ["Persyaratan yang belum dipenuhi dokumen"],
+  "notes": "Penjelasan tentang temuan, rekomendasi, atau alasan penilaian (maksimal 5-6 kalimat)"
+}
+
+Kriteria penilaian:
+- is_valid: true jika dokumen lengkap dan memenuhi persyaratan
+- is_valid: false jika dokumen tidak lengkap, tidak memenuhi persyaratan, atau ada masalah signifikan
+- confidence: tingkat keyakinan Anda atas penilaian, dari 0 (tidak yakin) sampai 1 (sangat yakin)
+- missing_items: daftar persyaratan spesifik yang tidak dipenuhi dokumen, kosong ([]) jika semua terpenuhi
+- notes: berikan penjelasan tentang temuan Anda
+
+Dokumen yang akan dianalisis:
+{{documents}}`
+
+// PromptTemplate is a validated document check prompt
+type PromptTemplate struct {
+	text string
+	hash string
+}
+
+// ParsePromptTemplate checks that the template uses every placeholder and no unknown one
+func ParsePromptTemplate(text string) (*PromptTemplate, error) {
+	used := make(map[string]bool)
+	for _, match := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+		name := match[1]
+		if !isPromptPlaceholder(name) {
+			return nil, fmt.Errorf("unknown placeholder {{%s}}, expected one of %s", name, strings.Join(promptPlaceholders, ", "))
+		}
+		used[name] = true
+	}
+
+	var missing []string
+	for _, name := range promptPlaceholders {
+		if !used[name] {
+			missing = append(missing, "{{"+name+"}}")
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing placeholders %s", strings.Join(missing, ", "))
+	}
+
+	sum := sha256.Sum256([]byte(text))
+	return &PromptTemplate{text: text, hash: hex.EncodeToString(sum[:])}, nil
+}
+
+// Hash identifies the template text, so verdicts cached under another prompt are not reused
+func (t *PromptTemplate) Hash() string {
+	return t.hash
+}
+
+// Render fills in the work paper item of the request and the names of the documents sent along with the prompt
+func (t *PromptTemplate) Render(req *service.DocumentCheckRequest, documents []service.DocumentFile) string {
+	names := make([]string, 0, len(documents))
+	for i, doc := range documents {
+		names = append(names, fmt.Sprintf("%d. %s (%s)", i+1, doc.Name, doc.Type))
+	}
+
+	values := map[string]string{
+		PlaceholderNumber:       req.Number,
+		PlaceholderStatement:    req.Statement,
+		PlaceholderExplanation:  req.Explanation,
+		PlaceholderFillingGuide: req.FillingGuide,
+		PlaceholderDocuments:    strings.Join(names, "\n"),
+	}
+	return placeholderPattern.ReplaceAllStringFunc(t.text, func(placeholder string) string {
+		return values[placeholderPattern.FindStringSubmatch(placeholder)[1]]
+	})
+}
+
+// PromptTemplates picks the prompt of a document check by the type of its work paper item
+type PromptTemplates struct {
+	defaultTemplate *PromptTemplate
+	byItemType      map[string]*PromptTemplate
+}
+
+// LoadPromptTemplates reads the default template from defaultPath, or uses DefaultPromptTemplate when it is
+// empty, and the templates of single work paper item types (A, B or C) from byItemType. Every template is
+// validated, so a broken one fails at startup instead of on the first check.
+func LoadPromptTemplates(defaultPath string, byItemType map[string]string) (*PromptTemplates, error) {
+	defaultText := DefaultPromptTemplate
+	if defaultPath != "" {
+		data, err := os.ReadFile(defaultPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read prompt template: %w", err)
+		}
+		defaultText = string(data)
+	}
+	defaultTemplate, err := ParsePromptTemplate(defaultText)
+	if err != nil {
+		return nil, fmt.Errorf("prompt template %s: %w", defaultPath, err)
+	}
+
+	templates := &PromptTemplates{defaultTemplate: defaultTemplate, byItemType: make(map[string]*PromptTemplate)}
+
+	itemTypes := make([]string, 0, len(byItemType))
+	for itemType := range byItemType {
+		itemTypes = append(itemTypes, itemType)
+	}
+	sort.Strings(itemTypes)
+
+	for _, itemType := range itemTypes {
+		path := byItemType[itemType]
+		if path == "" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read prompt template of item type %s: %w", itemType, err)
+		}
+		template, err := ParsePromptTemplate(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("prompt template of item type %s (%s): %w", itemType, path, err)
+		}
+		templates.byItemType[strings.ToUpper(itemType)] = template
+	}
+
+	return templates, nil
+}
+
+// ForItemType returns the template of the work paper item type, or the default one
+func (t *PromptTemplates) ForItemType(itemType string) *PromptTemplate {
+	if template, ok := t.byItemType[strings.ToUpper(itemType)]; ok {
+		return template
+	}
+	return t.defaultTemplate
+}
+
+func isPromptPlaceholder(name string) bool {
+	for _, placeholder := range promptPlaceholders {
+		if name == placeholder {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package llm
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"sandbox/internal/domain/service"
+)
+
+func TestParsePromptTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		wantErr string
+	}{
+		{"built-in", DefaultPromptTemplate, ""},
+		{"every placeholder", "{{number}} {{ statement }} {{explanation}} {{filling_guide}} {{documents}}", ""},
+		{"missing placeholders", "{{number}} {{statement}} {{documents}}", "missing placeholders {{explanation}}, {{filling_guide}}"},
+		{"unknown placeholder", "{{number}} {{statement}} {{explanation}} {{filing_guide}} {{documents}}", "unknown placeholder {{filing_guide}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePromptTemplate(tt.text)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("ParsePromptTemplate() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("ParsePromptTemplate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPromptTemplatesPerItemType(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "type-c.txt")
+	text := "Kegiatan penunjang {{number}}: {{statement}} / {{explanation}} / {{filling_guide}}\n{{documents}}"
+	if err := os.WriteFile(path, []byte(text), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	templates, err := LoadPromptTemplates("", map[string]string{"c": path})
+	if err != nil {
+		t.Fatalf("LoadPromptTemplates() error = %v", err)
+	}
+
+	req := &service.DocumentCheckRequest{Number: "1.2", Statement: "Rencana strategis", Explanation: "Renstra", FillingGuide: "Unggah renstra"}
+	documents := []service.DocumentFile{{Name: "renstra.pdf", Type: "pdf"}}
+
+	got := templates.ForItemType("C").Render(req, documents)
+	if want := "Kegiatan penunjang 1.2: Rencana strategis / Renstra / Unggah renstra\n1. renstra.pdf (pdf)"; got != want {
+		t.Errorf("Render() for type C = %q, want %q", got, want)
+	}
+	if got := templates.ForItemType("A").Render(req, documents); !strings.HasPrefix(got, "Periksa dokumen") || !strings.Contains(got, "Nomor: 1.2") {
+		t.Errorf("Render() for type A = %q, want the built-in prompt", got)
+	}
+	if templates.ForItemType("C").Hash() == templates.ForItemType("A").Hash() {
+		t.Error("Hash() of the type C template equals the built-in one, want them to differ")
+	}
+
+	if err := os.WriteFile(path, []byte("{{number}} {{statement}}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadPromptTemplates("", map[string]string{"C": path}); err == nil || !strings.Contains(err.Error(), "item type C") {
+		t.Errorf("LoadPromptTemplates() with an incomplete template error = %v, want it to name item type C", err)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"sandbox/internal/domain/entity"
+	"sandbox/pkg/logging"
 )
 
 type Client struct {
@@ -32,11 +33,9 @@ type EmailResponse struct {
 
 func NewClient(apiKey string) *Client {
 	return &Client{
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		apiKey:  apiKey,
-		baseURL: "https://api.notification-service.com/v1", // Example service
+		httpClient: logging.NewHTTPClient("notification", 30*time.Second),
+		apiKey:     apiKey,
+		baseURL:    "https://api.notification-service.com/v1", // Example service
 	}
 }
 
@@ -0,0 +1,70 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"sandbox/pkg/logging"
+	"sandbox/pkg/retry"
+)
+
+const (
+	// WebhookSignatureHeader carries "sha256=" followed by the hex HMAC-SHA256 of the request body
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	// WebhookEventHeader carries the event type, e.g. business_trip.status_changed
+	WebhookEventHeader = "X-Webhook-Event"
+)
+
+// WebhookClient posts signed JSON payloads to webhook receivers
+type WebhookClient struct {
+	httpClient *http.Client
+	secret     []byte
+}
+
+func NewWebhookClient(secret string) *WebhookClient {
+	return &WebhookClient{
+		httpClient: logging.NewHTTPClient("webhook", 10*time.Second),
+		secret:     []byte(secret),
+	}
+}
+
+// SignWebhookPayload returns the value of the signature header for body
+func SignWebhookPayload(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// PostWebhook sends body to url and returns the status code of the response, or 0 when none was
+// received. A non-2xx response is returned as a *retry.StatusError.
+func (c *WebhookClient) PostWebhook(ctx context.Context, url, eventType string, body []byte) (int, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set(WebhookEventHeader, eventType)
+	httpReq.Header.Set(WebhookSignatureHeader, SignWebhookPayload(c.secret, body))
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return 0, fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return resp.StatusCode, retry.NewStatusError(resp.StatusCode,
+			fmt.Errorf("webhook receiver returned status %d: %s", resp.StatusCode, string(respBody)))
+	}
+
+	return resp.StatusCode, nil
+}
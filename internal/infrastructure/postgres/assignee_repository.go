@@ -50,6 +50,40 @@ const (
 		WHERE business_trip_id = $1
 	`
 
+	// An assignee's transactions are soft deleted together with it and share its deleted_at, so
+	// aggregates over live transactions stop counting the assignee's cost
+	deleteAssigneeWithTransactionsQuery = `
+		WITH deleted_transactions AS (
+			UPDATE assignee_transactions
+			SET deleted_at = $1
+			WHERE assignee_id = $2 AND deleted_at IS NULL
+				AND EXISTS (SELECT 1 FROM assignees WHERE id = $2 AND deleted_at IS NULL)
+		)
+		UPDATE assignees
+		SET deleted_at = $1
+		WHERE id = $2 AND deleted_at IS NULL
+	`
+
+	// Only the transactions deleted together with the assignee are restored; transactions that were
+	// deleted on their own before stay deleted
+	restoreAssigneeWithTransactionsQuery = `
+		WITH target AS (
+			SELECT id, deleted_at
+			FROM assignees
+			WHERE id = $1 AND business_trip_id = $2 AND deleted_at IS NOT NULL
+			FOR UPDATE
+		), restored_transactions AS (
+			UPDATE assignee_transactions t
+			SET deleted_at = NULL
+			FROM target
+			WHERE t.assignee_id = target.id AND t.deleted_at = target.deleted_at
+		)
+		UPDATE assignees a
+		SET deleted_at = NULL, updated_at = $3
+		FROM target
+		WHERE a.id = target.id
+	`
+
 	getAssigneeTotalCountQuery = `
 		SELECT COUNT(*)
 		FROM assignees a
@@ -116,7 +150,7 @@ func (r *assigneeRepository) Create(ctx context.Context, assignee *entity.Assign
 		now,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create assignee: %w", err)
+		return nil, assigneeWriteError(err, assignee.SPDNumber, "create")
 	}
 
 	if returnedID != assignee.ID {
@@ -169,7 +203,7 @@ func (r *assigneeRepository) UpdateAssignee(ctx context.Context, assignee *entit
 		now,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update assignee: %w", err)
+		return nil, assigneeWriteError(err, assignee.SPDNumber, "update")
 	}
 
 	rowAffected, err := res.RowsAffected()
@@ -186,17 +220,41 @@ func (r *assigneeRepository) UpdateAssignee(ctx context.Context, assignee *entit
 	return assignee, nil
 }
 
-// DeleteAssignee soft deletes an assignee
-func (r *assigneeRepository) DeleteAssignee(ctx context.Context, id string) error {
+// MoveToBusinessTrip moves an assignee to another business trip; its transactions keep pointing to it
+func (r *assigneeRepository) MoveToBusinessTrip(ctx context.Context, assignee *entity.Assignee, businessTripID string) error {
 	now := time.Now()
 
 	query := `
 		UPDATE assignees
-		SET deleted_at = $1
-		WHERE id = $2 AND deleted_at IS NULL
+		SET business_trip_id = $2, updated_at = $3
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
-	res, err := r.db.ExecContext(ctx, query, now, id)
+	res, err := r.db.ExecContext(ctx, query, assignee.ID, businessTripID, now)
+	if err != nil {
+		return assigneeWriteError(err, assignee.SPDNumber, "move")
+	}
+
+	rowAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowAffected == 0 {
+		return fmt.Errorf("%w: %s", entity.ErrAssigneeNotFound, assignee.ID)
+	}
+
+	assignee.BusinessTripID = businessTripID
+	assignee.UpdatedAt = now
+
+	return nil
+}
+
+// DeleteAssignee soft deletes an assignee together with its transactions
+func (r *assigneeRepository) DeleteAssignee(ctx context.Context, id string) error {
+	now := time.Now()
+
+	res, err := r.db.ExecContext(ctx, deleteAssigneeWithTransactionsQuery, now, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete assignee: %w", err)
 	}
@@ -213,6 +271,29 @@ func (r *assigneeRepository) DeleteAssignee(ctx context.Context, id string) erro
 	return nil
 }
 
+// RestoreAssignee undoes the soft delete of an assignee of a business trip and of the transactions
+// deleted with it
+func (r *assigneeRepository) RestoreAssignee(ctx context.Context, businessTripID, id string) error {
+	res, err := r.db.ExecContext(ctx, restoreAssigneeWithTransactionsQuery, id, businessTripID, time.Now())
+	if err != nil {
+		if isUniqueViolation(err, assigneeSPDNumberIndex) {
+			return fmt.Errorf("%w: another assignee of the business trip uses the SPD number of assignee %s", entity.ErrDuplicateSPDNumber, id)
+		}
+		return fmt.Errorf("failed to restore assignee: %w", err)
+	}
+
+	rowAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowAffected == 0 {
+		return fmt.Errorf("%w: no deleted assignee %s on business trip %s", entity.ErrAssigneeNotFound, id, businessTripID)
+	}
+
+	return nil
+}
+
 // GetAssigneesByBusinessTripID retrieves all assignees for a business trip
 func (r *assigneeRepository) GetAssigneesByBusinessTripID(ctx context.Context, businessTripID string) ([]*entity.Assignee, error) {
 	rows, err := r.db.QueryxContext(ctx, getAssigneesByBusinessTripIDQuery, businessTripID)
@@ -0,0 +1,182 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/pkg/business_trip_number"
+	"sandbox/pkg/database"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+func TestCreateAssigneeMapsDuplicateSPDNumber(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantDup bool
+	}{
+		// Names as created by migrations/041_add_unique_spd_number_per_trip.up.sql and by Postgres
+		{"spd number index", &pq.Error{Code: uniqueViolation, Constraint: "uq_assignees_business_trip_spd_number"}, true},
+		{"other unique index", &pq.Error{Code: uniqueViolation, Constraint: "assignees_pkey"}, false},
+		{"other error", &pq.Error{Code: "23503", Constraint: "uq_assignees_business_trip_spd_number"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := sqlx.NewDb(sql.OpenDB(&recordingDriver{err: tt.err}), "postgres")
+			defer db.Close()
+			wrapped := database.NewDB(db)
+
+			tripRepo := NewBusinessTripRepository(wrapped, business_trip_number.ScopeGlobal, business_trip_number.DefaultFormat).(*businessTripRepository)
+
+			creators := map[string]func(context.Context, *entity.Assignee) (*entity.Assignee, error){
+				"AssigneeRepository":     NewAssigneeRepository(wrapped).Create,
+				"BusinessTripRepository": tripRepo.CreateAssignee,
+			}
+			for repo, create := range creators {
+				_, err := create(context.Background(), &entity.Assignee{BusinessTripID: "trip-1", SPDNumber: "SPD-001"})
+				if err == nil {
+					t.Fatalf("%s: Create() error = nil, want an error", repo)
+				}
+				if got := errors.Is(err, entity.ErrDuplicateSPDNumber); got != tt.wantDup {
+					t.Errorf("%s: errors.Is(%v, ErrDuplicateSPDNumber) = %v, want %v", repo, err, got, tt.wantDup)
+				}
+			}
+		})
+	}
+}
+
+// softDeleteDriver keeps the assignees and transactions of one business trip in memory. It applies the
+// soft deletes of the assignee queries and sums the subtotals for the total cost query, skipping deleted
+// rows only where the query filters on their deleted_at.
+type softDeleteDriver struct {
+	assignees    map[string]*time.Time
+	transactions []*softDeletedTransaction
+}
+
+type softDeletedTransaction struct {
+	assigneeID string
+	subtotal   float64
+	deletedAt  *time.Time
+}
+
+func (d *softDeleteDriver) Open(string) (driver.Conn, error)             { return d, nil }
+func (d *softDeleteDriver) Connect(context.Context) (driver.Conn, error) { return d, nil }
+func (d *softDeleteDriver) Driver() driver.Driver                        { return d }
+func (d *softDeleteDriver) Prepare(string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("prepare not supported")
+}
+func (d *softDeleteDriver) Close() error { return nil }
+func (d *softDeleteDriver) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("transactions not supported")
+}
+
+func (d *softDeleteDriver) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	switch query {
+	case deleteAssigneeWithTransactionsQuery:
+		now, id := args[0].Value.(time.Time), args[1].Value.(string)
+		deletedAt, ok := d.assignees[id]
+		if !ok || deletedAt != nil {
+			return driver.RowsAffected(0), nil
+		}
+		for _, tx := range d.transactions {
+			if tx.assigneeID == id && tx.deletedAt == nil {
+				tx.deletedAt = &now
+			}
+		}
+		d.assignees[id] = &now
+		return driver.RowsAffected(1), nil
+	case restoreAssigneeWithTransactionsQuery:
+		id := args[0].Value.(string)
+		deletedAt := d.assignees[id]
+		if deletedAt == nil {
+			return driver.RowsAffected(0), nil
+		}
+		for _, tx := range d.transactions {
+			if tx.assigneeID == id && tx.deletedAt != nil && tx.deletedAt.Equal(*deletedAt) {
+				tx.deletedAt = nil
+			}
+		}
+		d.assignees[id] = nil
+		return driver.RowsAffected(1), nil
+	}
+	return nil, fmt.Errorf("unexpected query %q", query)
+}
+
+func (d *softDeleteDriver) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if !strings.Contains(query, "SUM(") {
+		return nil, fmt.Errorf("unexpected query %q", query)
+	}
+	var total float64
+	for _, tx := range d.transactions {
+		if tx.deletedAt != nil && strings.Contains(query, "t.deleted_at IS NULL") {
+			continue
+		}
+		if d.assignees[tx.assigneeID] != nil && strings.Contains(query, "a.deleted_at IS NULL") {
+			continue
+		}
+		total += tx.subtotal
+	}
+	return &recordingRows{columns: []string{"total_cost"}, rows: [][]driver.Value{{total}}}, nil
+}
+
+func TestDeleteAssigneeRemovesItsCostFromTotalCost(t *testing.T) {
+	earlier := time.Now().Add(-time.Hour)
+	fake := &softDeleteDriver{
+		assignees: map[string]*time.Time{"assignee-1": nil, "assignee-2": nil},
+		transactions: []*softDeletedTransaction{
+			{assigneeID: "assignee-1", subtotal: 1_500_000},
+			{assigneeID: "assignee-1", subtotal: 500_000},
+			{assigneeID: "assignee-1", subtotal: 250_000, deletedAt: &earlier},
+			{assigneeID: "assignee-2", subtotal: 750_000},
+		},
+	}
+	db := sqlx.NewDb(sql.OpenDB(fake), "postgres")
+	defer db.Close()
+	wrapped := database.NewDB(db)
+
+	assigneeRepo := NewAssigneeRepository(wrapped)
+	tripRepo := NewBusinessTripRepository(wrapped, business_trip_number.ScopeGlobal, business_trip_number.DefaultFormat)
+	ctx := context.Background()
+
+	assertTotalCost := func(want float64) {
+		t.Helper()
+		got, err := tripRepo.GetTotalCost(ctx, nil, nil, "")
+		if err != nil {
+			t.Fatalf("GetTotalCost() error = %v", err)
+		}
+		if got != want {
+			t.Errorf("GetTotalCost() = %v, want %v", got, want)
+		}
+	}
+
+	assertTotalCost(2_750_000)
+
+	if err := assigneeRepo.DeleteAssignee(ctx, "assignee-1"); err != nil {
+		t.Fatalf("DeleteAssignee() error = %v", err)
+	}
+	assertTotalCost(750_000)
+	for _, tx := range fake.transactions[:2] {
+		if tx.deletedAt == nil {
+			t.Errorf("transaction of the deleted assignee was not soft deleted")
+		}
+	}
+
+	if err := assigneeRepo.RestoreAssignee(ctx, "trip-1", "assignee-1"); err != nil {
+		t.Fatalf("RestoreAssignee() error = %v", err)
+	}
+	assertTotalCost(2_750_000)
+
+	if err := assigneeRepo.RestoreAssignee(ctx, "trip-1", "assignee-1"); !errors.Is(err, entity.ErrAssigneeNotFound) {
+		t.Errorf("RestoreAssignee() of a live assignee error = %v, want ErrAssigneeNotFound", err)
+	}
+}
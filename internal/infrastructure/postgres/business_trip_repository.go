@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 
 	"sandbox/internal/domain/entity"
 	"sandbox/internal/domain/repository"
@@ -22,26 +23,38 @@ const (
 	insertBusinessTrip = `
 		INSERT INTO business_trips (
 			id, business_trip_number, start_date, end_date, activity_purpose, destination_city,
-			spd_date, departure_date, return_date, status, document_link, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			spd_date, departure_date, return_date, status, document_link, organization_id, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 		RETURNING id
 	`
 
 	updateBusinessTrip = `
 		UPDATE business_trips
 		SET start_date = $2, end_date = $3, activity_purpose = $4, destination_city = $5,
-			spd_date = $6, departure_date = $7, return_date = $8, status = $9, document_link = $10, updated_at = $11
-		WHERE id = $1
+			spd_date = $6, departure_date = $7, return_date = $8, status = $9, document_link = $10, updated_at = $11,
+			version = version + 1
+		WHERE id = $1 AND version = $12
+	`
+
+	businessTripExists = `
+		SELECT EXISTS(SELECT 1 FROM business_trips WHERE id = $1 AND deleted_at IS NULL)
 	`
 
 	findBusinessTripByID = `
 		SELECT
 			bt.id, bt.business_trip_number, bt.start_date, bt.end_date, bt.activity_purpose, bt.destination_city,
-			bt.spd_date, bt.departure_date, bt.return_date, bt.status, bt.document_link, bt.created_at, bt.updated_at
+			bt.spd_date, bt.departure_date, bt.return_date, bt.status, bt.document_link, bt.meeting_id, bt.organization_id,
+			bt.version, bt.created_at, bt.updated_at
 		FROM business_trips bt
 		WHERE bt.id = $1 AND bt.deleted_at IS NULL
 	`
 
+	setBusinessTripMeeting = `
+		UPDATE business_trips
+		SET meeting_id = $2, updated_at = $3, version = version + 1
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
 	deleteBusinessTrip = `
 		UPDATE business_trips
 		SET deleted_at = $1
@@ -78,39 +91,48 @@ const (
 		ORDER BY a.created_at
 	`
 
-	deleteAssignee = `
-		UPDATE assignees
-		SET deleted_at = $1
-		WHERE id = $2
+	findAssigneesWithTransactionsByBusinessTripID = `
+		SELECT
+			a.id, a.business_trip_id, a.name, a.spd_number, a.employee_id, a.position, a.rank, a.employee_name, a.employee_number,
+			a.created_at, a.updated_at,
+			t.id AS tx_id, t.name AS tx_name, t.type AS tx_type, t.subtype AS tx_subtype, t.amount AS tx_amount,
+			t.total_night AS tx_total_night, t.total_days AS tx_total_days, t.subtotal AS tx_subtotal,
+			t.description AS tx_description, t.transport_detail AS tx_transport_detail, t.direction AS tx_direction,
+			t.currency AS tx_currency, t.exchange_rate AS tx_exchange_rate,
+			t.created_at AS tx_created_at, t.updated_at AS tx_updated_at
+		FROM assignees a
+		LEFT JOIN assignee_transactions t ON t.assignee_id = a.id AND t.deleted_at IS NULL
+		WHERE a.business_trip_id = $1 AND a.deleted_at IS NULL
+		ORDER BY a.created_at, a.id, t.created_at
 	`
 
 	insertTransaction = `
 		INSERT INTO assignee_transactions (
-			id, assignee_id, name, type, subtype, amount, total_night, subtotal,
-			description, transport_detail, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			id, assignee_id, name, type, subtype, amount, total_night, total_days, subtotal,
+			description, transport_detail, created_at, updated_at, direction, currency, exchange_rate
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 		RETURNING id
 	`
 
 	updateTransaction = `
 		UPDATE assignee_transactions
-		SET name = $2, type = $3, subtype = $4, amount = $5, total_night = $6, subtotal = $7,
-			description = $8, transport_detail = $9, updated_at = $10
+		SET name = $2, type = $3, subtype = $4, amount = $5, total_night = $6, total_days = $7, subtotal = $8,
+			description = $9, transport_detail = $10, updated_at = $11, direction = $12, currency = $13, exchange_rate = $14
 		WHERE id = $1
 	`
 
 	findTransactionByID = `
 		SELECT
-			t.id, t.assignee_id, t.name, t.type, t.subtype, t.amount, t.total_night, t.subtotal,
-			t.description, t.transport_detail, t.created_at, t.updated_at
+			t.id, t.assignee_id, t.name, t.type, t.subtype, t.amount, t.total_night, t.total_days, t.subtotal,
+			t.description, t.transport_detail, t.created_at, t.updated_at, t.direction, t.currency, t.exchange_rate
 		FROM assignee_transactions t
 		WHERE t.id = $1 AND t.deleted_at IS NULL
 	`
 
 	findTransactionsByAssigneeID = `
 		SELECT
-			t.id, t.assignee_id, t.name, t.type, t.subtype, t.amount, t.total_night, t.subtotal,
-			t.description, t.transport_detail, t.created_at, t.updated_at
+			t.id, t.assignee_id, t.name, t.type, t.subtype, t.amount, t.total_night, t.total_days, t.subtotal,
+			t.description, t.transport_detail, t.created_at, t.updated_at, t.direction, t.currency, t.exchange_rate
 		FROM assignee_transactions t
 		WHERE t.assignee_id = $1 AND t.deleted_at IS NULL
 		ORDER BY t.created_at
@@ -164,6 +186,16 @@ const (
 		LEFT JOIN business_trips bt ON v.business_trip_id = bt.id
 	`
 
+	findTripsByEmployeeNumber = `
+		SELECT
+			bt.id, bt.business_trip_number, bt.start_date, bt.end_date, bt.activity_purpose, bt.destination_city,
+			bt.spd_date, bt.departure_date, bt.return_date, bt.status, bt.document_link, bt.version,
+			bt.created_at, bt.updated_at,
+			a.id AS assignee_id, a.name AS assignee_name, a.spd_number
+		FROM assignees a
+		INNER JOIN business_trips bt ON bt.id = a.business_trip_id
+	`
+
 	findVerificatorByBusinessTripIDAndUserID = `
 		SELECT
 			v.id, v.business_trip_id, v.user_id, v.user_name, v.employee_number, v.position,
@@ -180,6 +212,24 @@ const (
 		WHERE id = $1
 	`
 
+	bulkUpdateVerificators = `
+		UPDATE business_trip_verificators
+		SET status = $2, verification_notes = $3, verified_at = $4, updated_at = $4
+		WHERE id = ANY($1) AND status <> $2 AND deleted_at IS NULL
+	`
+
+	insertVerificatorStatusHistory = `
+		INSERT INTO verificator_status_history (id, verificator_id, from_status, to_status, notes, changed_by, changed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	findVerificatorHistory = `
+		SELECT id, verificator_id, from_status, to_status, notes, changed_by, changed_at
+		FROM verificator_status_history
+		WHERE verificator_id = $1
+		ORDER BY changed_at, id
+	`
+
 	deleteVerificator = `
 		UPDATE business_trip_verificators
 		SET deleted_at = $1
@@ -191,16 +241,86 @@ const (
 		SET deleted_at = $1
 		WHERE business_trip_id = $2
 	`
+
+	findVerificatorsDueForReminder = `
+		SELECT
+			v.id, v.business_trip_id, v.user_id, v.user_name, v.employee_number, v.position,
+			v.status, v.verified_at, v.verification_notes, v.created_at, v.updated_at,
+			bt.business_trip_number, bt.start_date, bt.end_date, bt.activity_purpose,
+			bt.destination_city, bt.spd_date, bt.departure_date, bt.return_date,
+			bt.status as business_trip_status, bt.document_link
+		FROM business_trip_verificators v
+		INNER JOIN business_trips bt ON v.business_trip_id = bt.id
+		WHERE v.status = $1
+			AND bt.status = $2
+			AND v.created_at < $3
+			AND (v.last_reminded_at IS NULL OR v.last_reminded_at < $4)
+			AND v.deleted_at IS NULL
+			AND bt.deleted_at IS NULL
+		ORDER BY v.user_id, v.created_at
+	`
+
+	markVerificatorsReminded = `
+		UPDATE business_trip_verificators
+		SET last_reminded_at = $2
+		WHERE id = ANY($1) AND deleted_at IS NULL
+	`
+
+	findCostBreakdownByTrip = `
+		SELECT
+			t.type as transaction_type,
+			COUNT(*) as transaction_count,
+			COALESCE(SUM(CASE WHEN t.direction = 'credit' THEN -t.subtotal ELSE t.subtotal END), 0) as total_amount
+		FROM assignee_transactions t
+		INNER JOIN assignees a ON t.assignee_id = a.id
+		INNER JOIN business_trips bt ON a.business_trip_id = bt.id
+		WHERE bt.id = $1
+			AND t.deleted_at IS NULL
+			AND a.deleted_at IS NULL
+			AND bt.deleted_at IS NULL
+		GROUP BY t.type
+		ORDER BY total_amount DESC
+	`
+
+	findListSummaries = `
+		SELECT
+			a.business_trip_id,
+			COUNT(DISTINCT a.id) as assignee_count,
+			COALESCE(SUM(CASE WHEN t.direction = 'credit' THEN -t.subtotal ELSE t.subtotal END), 0) as total_cost
+		FROM assignees a
+		LEFT JOIN assignee_transactions t ON t.assignee_id = a.id AND t.deleted_at IS NULL
+		WHERE a.business_trip_id = ANY($1)
+			AND a.deleted_at IS NULL
+		GROUP BY a.business_trip_id
+	`
+
+	// Spellings that differ only in case or surrounding spaces are one destination, shown as its most
+	// common spelling
+	findDistinctDestinations = `
+		SELECT
+			MODE() WITHIN GROUP (ORDER BY TRIM(destination_city)) as destination,
+			COUNT(*) as trip_count
+		FROM business_trips
+		WHERE deleted_at IS NULL
+			AND TRIM(destination_city) <> ''
+			AND LOWER(TRIM(destination_city)) LIKE $1 ESCAPE '\'
+		GROUP BY LOWER(TRIM(destination_city))
+		ORDER BY trip_count DESC, LOWER(TRIM(destination_city))
+		LIMIT $2
+	`
 )
 
-// NewBusinessTripRepository creates a new instance of BusinessTripRepository
-func NewBusinessTripRepository(db database.Queryer) repository.BusinessTripRepository {
+// NewBusinessTripRepository creates a new instance of BusinessTripRepository.
+// numberScope selects whether generated trip numbers are unique globally or per year and
+// numberFormat how they are rendered.
+func NewBusinessTripRepository(db database.Queryer, numberScope business_trip_number.Scope, numberFormat business_trip_number.Format) repository.BusinessTripRepository {
 	// Try to access the underlying *sql.DB if db implements the DB interface
 	if dbInterface, ok := db.(database.DB); ok {
 		sqlDB := dbInterface.UnderlyingDB()
 		return &businessTripRepository{
 			db:              db,
-			numberGenerator: business_trip_number.NewGenerator(sqlDB),
+			numberGenerator: business_trip_number.NewGenerator(sqlDB, numberScope, numberFormat),
+			numberFormat:    numberFormat,
 		}
 	}
 
@@ -209,12 +329,14 @@ func NewBusinessTripRepository(db database.Queryer) repository.BusinessTripRepos
 	return &businessTripRepository{
 		db:              db,
 		numberGenerator: nil,
+		numberFormat:    numberFormat,
 	}
 }
 
 type businessTripRepository struct {
 	db              database.Queryer
 	numberGenerator *business_trip_number.Generator
+	numberFormat    business_trip_number.Format
 }
 
 // WithTransaction returns a new repository instance with the given transaction
@@ -222,6 +344,7 @@ func (r *businessTripRepository) WithTransaction(tx database.DBTx) repository.Bu
 	return &businessTripRepository{
 		db:              tx,
 		numberGenerator: r.numberGenerator, // Preserve the number generator from parent
+		numberFormat:    r.numberFormat,
 	}
 }
 
@@ -240,8 +363,11 @@ func (r *businessTripRepository) Create(ctx context.Context, bt *entity.Business
 		bt.SetBusinessTripNumber(businessTripNumber)
 	} else {
 		// Fallback: use UUID-based number if generator is not available
-		// Use only 6 characters from UUID to fit within VARCHAR(10) (BT-XXXXXX)
-		bt.SetBusinessTripNumber("BT-" + uuid.New().String()[:6])
+		businessTripNumber, err := r.numberFormat.RenderRandom(time.Now().Year())
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate business trip number: %w", err)
+		}
+		bt.SetBusinessTripNumber(businessTripNumber)
 	}
 
 	var returnedID string
@@ -259,6 +385,7 @@ func (r *businessTripRepository) Create(ctx context.Context, bt *entity.Business
 		bt.ReturnDate,
 		bt.Status,
 		bt.DocumentLink,
+		bt.OrganizationID,
 		now,
 		now,
 	)
@@ -275,6 +402,16 @@ func (r *businessTripRepository) Create(ctx context.Context, bt *entity.Business
 	return bt, nil
 }
 
+// PeekNextBusinessTripNumber returns the number the generator would assign to a trip created in year
+// without reserving it
+func (r *businessTripRepository) PeekNextBusinessTripNumber(ctx context.Context, year int) (string, error) {
+	if r.numberGenerator == nil {
+		return "", fmt.Errorf("business trip number generator is not available")
+	}
+
+	return r.numberGenerator.PeekNextNumber(ctx, year)
+}
+
 // GetByID retrieves a business trip by ID with all its related data
 func (r *businessTripRepository) GetByID(ctx context.Context, id string) (*entity.BusinessTrip, error) {
 	// Get business trip
@@ -287,8 +424,8 @@ func (r *businessTripRepository) GetByID(ctx context.Context, id string) (*entit
 		return nil, fmt.Errorf("failed to get business trip: %w", err)
 	}
 
-	// Get assignees
-	assignees, err := r.GetAssigneesByBusinessTripID(ctx, id)
+	// Get assignees with their transactions in a single query
+	assignees, err := r.GetAssigneesWithTransactionsByBusinessTripID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get assignees: %w", err)
 	}
@@ -306,6 +443,22 @@ func (r *businessTripRepository) GetByID(ctx context.Context, id string) (*entit
 	return &bt, nil
 }
 
+// LockByID locks the business trip row with SELECT ... FOR UPDATE, so writers of the trip wait until the
+// surrounding transaction ends
+func (r *businessTripRepository) LockByID(ctx context.Context, id string) error {
+	query := `SELECT id FROM business_trips WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
+
+	var lockedID string
+	if err := r.db.GetContext(ctx, &lockedID, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.ErrBusinessTripNotFound
+		}
+		return fmt.Errorf("failed to lock business trip: %w", err)
+	}
+
+	return nil
+}
+
 // Update updates a business trip
 func (r *businessTripRepository) Update(ctx context.Context, bt *entity.BusinessTrip) (*entity.BusinessTrip, error) {
 	now := time.Now()
@@ -322,6 +475,7 @@ func (r *businessTripRepository) Update(ctx context.Context, bt *entity.Business
 		bt.Status,
 		bt.DocumentLink,
 		now,
+		bt.Version,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update business trip: %w", err)
@@ -333,9 +487,18 @@ func (r *businessTripRepository) Update(ctx context.Context, bt *entity.Business
 	}
 
 	if rowAffected == 0 {
+		// Tell a version mismatch apart from a missing trip
+		var exists bool
+		if err := r.db.GetContext(ctx, &exists, businessTripExists, bt.ID); err != nil {
+			return nil, fmt.Errorf("failed to check business trip existence: %w", err)
+		}
+		if exists {
+			return nil, entity.ErrStaleBusinessTrip
+		}
 		return nil, fmt.Errorf("business trip with ID %s not found", bt.ID)
 	}
 
+	bt.Version++
 	bt.UpdatedAt = now
 
 	return bt, nil
@@ -362,6 +525,25 @@ func (r *businessTripRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+// SetMeeting links the business trip to a meeting, or unlinks it when meetingID is not valid
+func (r *businessTripRepository) SetMeeting(ctx context.Context, id string, meetingID sql.NullString) error {
+	res, err := r.db.ExecContext(ctx, setBusinessTripMeeting, id, meetingID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to set business trip meeting: %w", err)
+	}
+
+	rowAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowAffected == 0 {
+		return fmt.Errorf("%w: %s", entity.ErrBusinessTripNotFound, id)
+	}
+
+	return nil
+}
+
 // List retrieves business trips with filtering and pagination using pagination package
 func (r *businessTripRepository) List(ctx context.Context, params *pagination.QueryParams) ([]*entity.BusinessTrip, int64, error) {
 	// Build count query
@@ -391,7 +573,7 @@ func (r *businessTripRepository) List(ctx context.Context, params *pagination.Qu
 	queryBuilder := pagination.NewQueryBuilder(`
 		SELECT
 			id, business_trip_number, start_date, end_date, activity_purpose, destination_city,
-			spd_date, departure_date, return_date, status, document_link, created_at, updated_at
+			spd_date, departure_date, return_date, status, document_link, version, created_at, updated_at
 		FROM business_trips`)
 
 	for _, filter := range params.Filters {
@@ -441,6 +623,182 @@ func (r *businessTripRepository) List(ctx context.Context, params *pagination.Qu
 	return businessTrips, totalCount, nil
 }
 
+// FindTripsByEmployeeNumber returns one row per assignment of the employee to a non-deleted business trip,
+// latest start date first unless params sorts otherwise. Unqualified filter and sort fields refer to the trip.
+func (r *businessTripRepository) FindTripsByEmployeeNumber(ctx context.Context, employeeNumber string, params *pagination.QueryParams) ([]*entity.EmployeeBusinessTrip, int64, error) {
+	filters := []pagination.Filter{
+		{Field: "a.employee_number", Operator: "eq", Value: employeeNumber},
+		{Field: "a.deleted_at", Operator: "is", Value: nil},
+		{Field: "bt.deleted_at", Operator: "is", Value: nil},
+	}
+	for _, filter := range params.Filters {
+		filter.Field = qualifyTripField(filter.Field)
+		filters = append(filters, filter)
+	}
+
+	// Build count query
+	countBuilder := pagination.NewQueryBuilder("SELECT COUNT(*) FROM assignees a INNER JOIN business_trips bt ON bt.id = a.business_trip_id")
+	for _, filter := range filters {
+		if err := countBuilder.AddFilter(filter); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	countQuery, countArgs := countBuilder.Build()
+
+	var totalCount int64
+	if err := r.db.GetContext(ctx, &totalCount, countQuery, countArgs...); err != nil {
+		return nil, 0, err
+	}
+
+	// Build main query
+	queryBuilder := pagination.NewQueryBuilder(findTripsByEmployeeNumber)
+	for _, filter := range filters {
+		if err := queryBuilder.AddFilter(filter); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	sorts := params.Sorts
+	if len(sorts) == 0 {
+		sorts = []pagination.Sort{{Field: "start_date", Order: "desc"}}
+	}
+	for _, sort := range sorts {
+		sort.Field = qualifyTripField(sort.Field)
+		if err := queryBuilder.AddSort(sort); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	query, args := queryBuilder.Build()
+
+	// Add pagination
+	offset := (params.Pagination.Page - 1) * params.Pagination.Limit
+	query += fmt.Sprintf(" LIMIT %d OFFSET %d", params.Pagination.Limit, offset)
+
+	var trips []*entity.EmployeeBusinessTrip
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var trip entity.EmployeeBusinessTrip
+		if err := rows.StructScan(&trip); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan business trip: %w", err)
+		}
+		trips = append(trips, &trip)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return trips, totalCount, nil
+}
+
+// qualifyTripField prefixes a field that has no table alias with the business trip alias, so it is not
+// ambiguous next to the joined assignee columns
+func qualifyTripField(field string) string {
+	if strings.Contains(field, ".") {
+		return field
+	}
+	return "bt." + field
+}
+
+// GetListSummaries returns the assignee count and total cost of the given business trips, keyed by trip ID,
+// in one grouped query. Trips without assignees are missing from the map.
+func (r *businessTripRepository) GetListSummaries(ctx context.Context, businessTripIDs []string) (map[string]*repository.BusinessTripListSummary, error) {
+	summaries := make(map[string]*repository.BusinessTripListSummary, len(businessTripIDs))
+	if len(businessTripIDs) == 0 {
+		return summaries, nil
+	}
+
+	var rows []*repository.BusinessTripListSummary
+	if err := r.db.SelectContext(ctx, &rows, findListSummaries, pq.Array(businessTripIDs)); err != nil {
+		return nil, fmt.Errorf("failed to get business trip list summaries: %w", err)
+	}
+
+	for _, row := range rows {
+		summaries[row.BusinessTripID] = row
+	}
+	return summaries, nil
+}
+
+// likeEscaper escapes the LIKE wildcards in user input, so a prefix like "50%" is matched literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ListDistinctDestinations returns up to limit destination cities starting with prefix, ignoring case,
+// the most visited first and then alphabetically. An empty prefix matches every destination.
+func (r *businessTripRepository) ListDistinctDestinations(ctx context.Context, prefix string, limit int) ([]*repository.DestinationSuggestion, error) {
+	pattern := likeEscaper.Replace(strings.ToLower(strings.TrimSpace(prefix))) + "%"
+
+	destinations := []*repository.DestinationSuggestion{}
+	if err := r.db.SelectContext(ctx, &destinations, findDistinctDestinations, pattern, limit); err != nil {
+		return nil, fmt.Errorf("failed to list destinations: %w", err)
+	}
+	return destinations, nil
+}
+
+// StreamAll calls fn for every non-deleted business trip whose start date falls in the optional range,
+// oldest first. Rows are read one at a time from the open cursor, so memory use does not grow with the
+// number of trips. Returning an error from fn stops the iteration and returns that error.
+func (r *businessTripRepository) StreamAll(ctx context.Context, startDate, endDate *time.Time, fn func(*entity.BusinessTrip) error) error {
+	queryBuilder := pagination.NewQueryBuilder(`
+		SELECT
+			id, business_trip_number, start_date, end_date, activity_purpose, destination_city,
+			spd_date, departure_date, return_date, status, document_link, version, created_at, updated_at
+		FROM business_trips`)
+
+	queryBuilder.AddFilter(pagination.Filter{
+		Field:    "deleted_at",
+		Operator: "is",
+		Value:    nil,
+	})
+	if startDate != nil {
+		queryBuilder.AddFilter(pagination.Filter{
+			Field:    "start_date",
+			Operator: "gte",
+			Value:    *startDate,
+		})
+	}
+	if endDate != nil {
+		queryBuilder.AddFilter(pagination.Filter{
+			Field:    "start_date",
+			Operator: "lte",
+			Value:    *endDate,
+		})
+	}
+
+	queryBuilder.AddSort(pagination.Sort{Field: "created_at", Order: "asc"})
+	queryBuilder.AddSort(pagination.Sort{Field: "id", Order: "asc"})
+
+	query, args := queryBuilder.Build()
+
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query business trips: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bt entity.BusinessTrip
+		if err := rows.StructScan(&bt); err != nil {
+			return fmt.Errorf("failed to scan business trip: %w", err)
+		}
+		if err := fn(&bt); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return nil
+}
+
 // CreateAssignee creates a new assignee
 func (r *businessTripRepository) CreateAssignee(ctx context.Context, assignee *entity.Assignee) (*entity.Assignee, error) {
 	if assignee.ID == "" {
@@ -464,7 +822,7 @@ func (r *businessTripRepository) CreateAssignee(ctx context.Context, assignee *e
 		now,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create assignee: %w", err)
+		return nil, assigneeWriteError(err, assignee.SPDNumber, "create")
 	}
 
 	if returnedID != assignee.ID {
@@ -516,7 +874,7 @@ func (r *businessTripRepository) UpdateAssignee(ctx context.Context, assignee *e
 		now,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update assignee: %w", err)
+		return nil, assigneeWriteError(err, assignee.SPDNumber, "update")
 	}
 
 	rowAffected, err := res.RowsAffected()
@@ -533,11 +891,11 @@ func (r *businessTripRepository) UpdateAssignee(ctx context.Context, assignee *e
 	return assignee, nil
 }
 
-// DeleteAssignee soft deletes an assignee
+// DeleteAssignee soft deletes an assignee together with its transactions
 func (r *businessTripRepository) DeleteAssignee(ctx context.Context, id string) error {
 	now := time.Now()
 
-	res, err := r.db.ExecContext(ctx, deleteAssignee, now, id)
+	res, err := r.db.ExecContext(ctx, deleteAssigneeWithTransactionsQuery, now, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete assignee: %w", err)
 	}
@@ -592,6 +950,84 @@ func (r *businessTripRepository) GetAssigneesByBusinessTripID(ctx context.Contex
 	return assignees, nil
 }
 
+// assigneeTransactionRow is one row of the assignee/transaction join; transaction columns
+// are NULL for assignees without transactions
+type assigneeTransactionRow struct {
+	entity.Assignee
+	TxID              sql.NullString  `db:"tx_id"`
+	TxName            sql.NullString  `db:"tx_name"`
+	TxType            sql.NullString  `db:"tx_type"`
+	TxSubtype         sql.NullString  `db:"tx_subtype"`
+	TxAmount          sql.NullFloat64 `db:"tx_amount"`
+	TxTotalNight      *int            `db:"tx_total_night"`
+	TxTotalDays       *int            `db:"tx_total_days"`
+	TxSubtotal        sql.NullFloat64 `db:"tx_subtotal"`
+	TxDescription     sql.NullString  `db:"tx_description"`
+	TxTransportDetail sql.NullString  `db:"tx_transport_detail"`
+	TxDirection       sql.NullString  `db:"tx_direction"`
+	TxCurrency        sql.NullString  `db:"tx_currency"`
+	TxExchangeRate    sql.NullFloat64 `db:"tx_exchange_rate"`
+	TxCreatedAt       sql.NullTime    `db:"tx_created_at"`
+	TxUpdatedAt       sql.NullTime    `db:"tx_updated_at"`
+}
+
+// GetAssigneesWithTransactionsByBusinessTripID retrieves all assignees for a business trip with their
+// transactions using a single joined query instead of one transaction query per assignee
+func (r *businessTripRepository) GetAssigneesWithTransactionsByBusinessTripID(ctx context.Context, businessTripID string) ([]*entity.Assignee, error) {
+	rows, err := r.db.QueryxContext(ctx, findAssigneesWithTransactionsByBusinessTripID, businessTripID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query assignees with transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var assignees []*entity.Assignee
+	assigneesByID := make(map[string]*entity.Assignee)
+	for rows.Next() {
+		var row assigneeTransactionRow
+		if err := rows.StructScan(&row); err != nil {
+			return nil, fmt.Errorf("failed to scan assignee with transaction: %w", err)
+		}
+
+		assignee, ok := assigneesByID[row.ID]
+		if !ok {
+			assignee = &entity.Assignee{}
+			*assignee = row.Assignee
+			assignee.Transactions = make([]*entity.Transaction, 0)
+			assigneesByID[row.ID] = assignee
+			assignees = append(assignees, assignee)
+		}
+
+		if !row.TxID.Valid {
+			continue
+		}
+
+		assignee.Transactions = append(assignee.Transactions, &entity.Transaction{
+			ID:              row.TxID.String,
+			AssigneeID:      row.ID,
+			Name:            row.TxName.String,
+			Type:            entity.TransactionType(row.TxType.String),
+			Subtype:         entity.TransactionSubtype(row.TxSubtype.String),
+			Amount:          row.TxAmount.Float64,
+			TotalNight:      row.TxTotalNight,
+			TotalDays:       row.TxTotalDays,
+			Subtotal:        row.TxSubtotal.Float64,
+			Description:     row.TxDescription.String,
+			TransportDetail: row.TxTransportDetail.String,
+			CreatedAt:       row.TxCreatedAt.Time,
+			UpdatedAt:       row.TxUpdatedAt.Time,
+			Direction:       entity.TransactionDirection(row.TxDirection.String),
+			Currency:        row.TxCurrency.String,
+			ExchangeRate:    row.TxExchangeRate.Float64,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return assignees, nil
+}
+
 // GetAssigneesByBusinessTripIDWithoutTransactions retrieves all assignees for a business trip without loading their transactions
 func (r *businessTripRepository) GetAssigneesByBusinessTripIDWithoutTransactions(ctx context.Context, businessTripID string) ([]*entity.Assignee, error) {
 	rows, err := r.db.QueryxContext(ctx, findAssigneesByBusinessTripID, businessTripID)
@@ -637,11 +1073,15 @@ func (r *businessTripRepository) CreateTransaction(ctx context.Context, transact
 		transaction.Subtype,
 		transaction.Amount,
 		transaction.TotalNight,
+		transaction.TotalDays,
 		transaction.Subtotal,
 		transaction.Description,
 		transaction.TransportDetail,
 		now,
 		now,
+		transaction.GetDirection(),
+		transaction.Currency,
+		transaction.GetExchangeRate(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create transaction: %w", err)
@@ -651,6 +1091,10 @@ func (r *businessTripRepository) CreateTransaction(ctx context.Context, transact
 		return nil, fmt.Errorf("returned ID %s does not match expected ID %s", returnedID, transaction.ID)
 	}
 
+	if err := saveTransactionAllocations(ctx, r.db, transaction); err != nil {
+		return nil, err
+	}
+
 	// Set timestamps
 	transaction.CreatedAt = now
 	transaction.UpdatedAt = now
@@ -669,6 +1113,13 @@ func (r *businessTripRepository) GetTransactionByID(ctx context.Context, id stri
 		return nil, fmt.Errorf("failed to get transaction: %w", err)
 	}
 
+	if err := loadTransactionAllocations(ctx, r.db, []*entity.Transaction{&transaction}); err != nil {
+		return nil, err
+	}
+	if err := loadTransactionAttachments(ctx, r.db, []*entity.Transaction{&transaction}); err != nil {
+		return nil, err
+	}
+
 	return &transaction, nil
 }
 
@@ -683,10 +1134,14 @@ func (r *businessTripRepository) UpdateTransaction(ctx context.Context, transact
 		transaction.Subtype,
 		transaction.Amount,
 		transaction.TotalNight,
+		transaction.TotalDays,
 		transaction.Subtotal,
 		transaction.Description,
 		transaction.TransportDetail,
 		now,
+		transaction.GetDirection(),
+		transaction.Currency,
+		transaction.GetExchangeRate(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update transaction: %w", err)
@@ -701,6 +1156,10 @@ func (r *businessTripRepository) UpdateTransaction(ctx context.Context, transact
 		return nil, fmt.Errorf("transaction with ID %s not found", transaction.ID)
 	}
 
+	if err := saveTransactionAllocations(ctx, r.db, transaction); err != nil {
+		return nil, err
+	}
+
 	transaction.UpdatedAt = now
 
 	return transaction, nil
@@ -749,6 +1208,13 @@ func (r *businessTripRepository) GetTransactionsByAssigneeID(ctx context.Context
 		return nil, fmt.Errorf("error iterating rows: %w", err)
 	}
 
+	if err := loadTransactionAllocations(ctx, r.db, transactions); err != nil {
+		return nil, err
+	}
+	if err := loadTransactionAttachments(ctx, r.db, transactions); err != nil {
+		return nil, err
+	}
+
 	return transactions, nil
 }
 
@@ -792,7 +1258,7 @@ func (r *businessTripRepository) GetDestinationStats(ctx context.Context, startD
 			bt.destination_city,
 			COUNT(*) as total_trips,
 			COUNT(*) FILTER (WHERE bt.status = 'completed') as completed_trips,
-			COALESCE(SUM(t.subtotal), 0) as total_cost,
+			COALESCE(SUM(CASE WHEN t.direction = 'credit' THEN -t.subtotal ELSE t.subtotal END), 0) as total_cost,
 			MAX(bt.start_date) as last_trip_date
 		FROM business_trips bt
 		LEFT JOIN assignees a ON bt.id = a.business_trip_id AND a.deleted_at IS NULL
@@ -848,6 +1314,75 @@ func (r *businessTripRepository) GetDestinationStats(ctx context.Context, startD
 	return stats, nil
 }
 
+// GetCostByRank sums the trip spend per assignee rank. Every assignee on a trip in the range counts
+// towards the average, including assignees without transactions.
+func (r *businessTripRepository) GetCostByRank(ctx context.Context, startDate, endDate *time.Time, destination string) ([]*repository.RankCostData, error) {
+	query := `
+		SELECT
+			a.rank,
+			COALESCE(SUM(CASE WHEN t.direction = 'credit' THEN -t.subtotal ELSE t.subtotal END), 0) as total_cost,
+			COUNT(DISTINCT bt.id) as trip_count,
+			COUNT(DISTINCT a.id) as assignee_count
+		FROM business_trips bt
+		INNER JOIN assignees a ON bt.id = a.business_trip_id AND a.deleted_at IS NULL
+		LEFT JOIN assignee_transactions t ON a.id = t.assignee_id AND t.deleted_at IS NULL
+		WHERE bt.deleted_at IS NULL
+	`
+
+	args := []interface{}{}
+	argIndex := 1
+
+	if startDate != nil {
+		query += fmt.Sprintf(" AND bt.start_date >= $%d", argIndex)
+		args = append(args, *startDate)
+		argIndex++
+	}
+
+	if endDate != nil {
+		query += fmt.Sprintf(" AND bt.end_date <= $%d", argIndex)
+		args = append(args, *endDate)
+		argIndex++
+	}
+
+	if destination != "" {
+		query += fmt.Sprintf(" AND bt.destination_city ILIKE $%d", argIndex)
+		args = append(args, "%"+destination+"%")
+		argIndex++
+	}
+
+	query += " GROUP BY a.rank ORDER BY total_cost DESC, a.rank"
+
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cost by rank: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make([]*repository.RankCostData, 0)
+	for rows.Next() {
+		var stat repository.RankCostData
+		err := rows.Scan(
+			&stat.Rank,
+			&stat.TotalCost,
+			&stat.TripCount,
+			&stat.AssigneeCount,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan cost by rank: %w", err)
+		}
+		if stat.AssigneeCount > 0 {
+			stat.AverageCostPerAssignee = stat.TotalCost / float64(stat.AssigneeCount)
+		}
+		stats = append(stats, &stat)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return stats, nil
+}
+
 // GetMonthlyStats gets monthly statistics for the dashboard
 func (r *businessTripRepository) GetMonthlyStats(ctx context.Context, startDate, endDate time.Time, destination string) ([]*repository.MonthlyData, error) {
 	query := `
@@ -856,7 +1391,7 @@ func (r *businessTripRepository) GetMonthlyStats(ctx context.Context, startDate,
 			EXTRACT(YEAR FROM bt.start_date) as year,
 			COUNT(*) as total_trips,
 			COUNT(*) FILTER (WHERE bt.status = 'completed') as completed_trips,
-			COALESCE(SUM(t.subtotal), 0) as total_cost,
+			COALESCE(SUM(CASE WHEN t.direction = 'credit' THEN -t.subtotal ELSE t.subtotal END), 0) as total_cost,
 			MODE() WITHIN GROUP (ORDER BY bt.destination_city) as top_destination
 		FROM business_trips bt
 		LEFT JOIN assignees a ON bt.id = a.business_trip_id AND a.deleted_at IS NULL
@@ -914,7 +1449,7 @@ func (r *businessTripRepository) GetRecentWithSummary(ctx context.Context, limit
 			bt.end_date,
 			bt.status,
 			COUNT(a.id) as assignee_count,
-			COALESCE(SUM(t.subtotal), 0) as total_cost
+			COALESCE(SUM(CASE WHEN t.direction = 'credit' THEN -t.subtotal ELSE t.subtotal END), 0) as total_cost
 		FROM business_trips bt
 		LEFT JOIN assignees a ON bt.id = a.business_trip_id AND a.deleted_at IS NULL
 		LEFT JOIN assignee_transactions t ON a.id = t.assignee_id AND t.deleted_at IS NULL
@@ -960,6 +1495,7 @@ func (r *businessTripRepository) GetStatusCounts(ctx context.Context, startDate,
 		SELECT
 			COUNT(*) as total,
 			COUNT(*) FILTER (WHERE status = 'draft') as draft,
+			COUNT(*) FILTER (WHERE status = 'ready_to_verify') as ready_to_verify,
 			COUNT(*) FILTER (WHERE status = 'ongoing') as ongoing,
 			COUNT(*) FILTER (WHERE status = 'completed') as completed,
 			COUNT(*) FILTER (WHERE status = 'canceled') as canceled
@@ -992,6 +1528,7 @@ func (r *businessTripRepository) GetStatusCounts(ctx context.Context, startDate,
 	err := r.db.QueryRowxContext(ctx, query, args...).Scan(
 		&counts.Total,
 		&counts.Draft,
+		&counts.ReadyToVerify,
 		&counts.Ongoing,
 		&counts.Completed,
 		&counts.Canceled,
@@ -1006,7 +1543,7 @@ func (r *businessTripRepository) GetStatusCounts(ctx context.Context, startDate,
 // GetTotalCost gets total cost for the dashboard
 func (r *businessTripRepository) GetTotalCost(ctx context.Context, startDate, endDate *time.Time, destination string) (float64, error) {
 	query := `
-		SELECT COALESCE(SUM(t.subtotal), 0) as total_cost
+		SELECT COALESCE(SUM(CASE WHEN t.direction = 'credit' THEN -t.subtotal ELSE t.subtotal END), 0) as total_cost
 		FROM business_trips bt
 		LEFT JOIN assignees a ON bt.id = a.business_trip_id AND a.deleted_at IS NULL
 		LEFT JOIN assignee_transactions t ON a.id = t.assignee_id AND t.deleted_at IS NULL
@@ -1082,8 +1619,8 @@ func (r *businessTripRepository) GetTypeStats(ctx context.Context, startDate, en
 		SELECT
 			t.type as transaction_type,
 			COUNT(*) as total_transactions,
-			COALESCE(SUM(t.subtotal), 0) as total_amount,
-			COALESCE(AVG(t.subtotal), 0) as average_amount
+			COALESCE(SUM(CASE WHEN t.direction = 'credit' THEN -t.subtotal ELSE t.subtotal END), 0) as total_amount,
+			COALESCE(AVG(CASE WHEN t.direction = 'credit' THEN -t.subtotal ELSE t.subtotal END), 0) as average_amount
 		FROM business_trips bt
 		LEFT JOIN assignees a ON bt.id = a.business_trip_id AND a.deleted_at IS NULL
 		LEFT JOIN assignee_transactions t ON a.id = t.assignee_id AND t.deleted_at IS NULL
@@ -1126,18 +1663,32 @@ func (r *businessTripRepository) GetTypeStats(ctx context.Context, startDate, en
 	return stats, nil
 }
 
+// GetCostBreakdownByTrip returns the transaction count and total cost per transaction type of a business trip
+func (r *businessTripRepository) GetCostBreakdownByTrip(ctx context.Context, businessTripID string) ([]*repository.TransactionTypeCost, error) {
+	var breakdown []*repository.TransactionTypeCost
+	if err := r.db.SelectContext(ctx, &breakdown, findCostBreakdownByTrip, businessTripID); err != nil {
+		return nil, fmt.Errorf("failed to get cost breakdown: %w", err)
+	}
+
+	return breakdown, nil
+}
+
 // GetUpcomingCount gets upcoming business trips count for the dashboard
 func (r *businessTripRepository) GetUpcomingCount(ctx context.Context) (int64, error) {
-	query := `
-		SELECT COUNT(*) as upcoming_count
-		FROM business_trips
-		WHERE deleted_at IS NULL
-		AND status IN ('draft', 'ongoing')
-		AND start_date > NOW()
-	`
+	queryBuilder := pagination.NewQueryBuilder("SELECT COUNT(*) as upcoming_count FROM business_trips")
+	for _, filter := range []pagination.Filter{
+		{Field: "deleted_at", Operator: "is", Value: nil},
+		{Field: "status", Operator: "in", Value: []entity.BusinessTripStatus{entity.BusinessTripStatusDraft, entity.BusinessTripStatusOngoing}},
+		{Field: "start_date", Operator: "gt", Value: time.Now()},
+	} {
+		if err := queryBuilder.AddFilter(filter); err != nil {
+			return 0, err
+		}
+	}
+	query, args := queryBuilder.Build()
 
 	var count int64
-	err := r.db.QueryRowxContext(ctx, query).Scan(&count)
+	err := r.db.QueryRowxContext(ctx, query, args...).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get upcoming count: %w", err)
 	}
@@ -1182,8 +1733,17 @@ func (r *businessTripRepository) CreateVerificator(ctx context.Context, verifica
 
 // GetVerificatorByID retrieves a verificator by ID
 func (r *businessTripRepository) GetVerificatorByID(ctx context.Context, id string) (*entity.Verificator, error) {
+	return r.getVerificatorByID(ctx, findVerificatorByID, id)
+}
+
+// GetVerificatorByIDForUpdate retrieves a verificator by ID and locks its row until the transaction ends
+func (r *businessTripRepository) GetVerificatorByIDForUpdate(ctx context.Context, id string) (*entity.Verificator, error) {
+	return r.getVerificatorByID(ctx, findVerificatorByID+" FOR UPDATE", id)
+}
+
+func (r *businessTripRepository) getVerificatorByID(ctx context.Context, query, id string) (*entity.Verificator, error) {
 	var verificator entity.Verificator
-	err := r.db.GetContext(ctx, &verificator, findVerificatorByID, id)
+	err := r.db.GetContext(ctx, &verificator, query, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -1262,6 +1822,104 @@ func (r *businessTripRepository) UpdateVerificator(ctx context.Context, verifica
 	return verificator, nil
 }
 
+// BulkUpdateVerificators sets the same status and notes on multiple verificators in a single statement
+func (r *businessTripRepository) BulkUpdateVerificators(ctx context.Context, ids []string, status entity.VerificatorStatus, notes string, verifiedAt time.Time) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	res, err := r.db.ExecContext(ctx, bulkUpdateVerificators, pq.Array(ids), status, notes, verifiedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to bulk update verificators: %w", err)
+	}
+
+	rowAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowAffected, nil
+}
+
+// GetVerificatorsDueForReminder retrieves pending verificators of trips awaiting verification that were
+// assigned before createdBefore and have not been reminded since remindedBefore
+func (r *businessTripRepository) GetVerificatorsDueForReminder(ctx context.Context, createdBefore, remindedBefore time.Time) ([]*entity.VerificatorWithBusinessTrip, error) {
+	rows, err := r.db.QueryxContext(ctx, findVerificatorsDueForReminder,
+		entity.VerificatorStatusPending, entity.BusinessTripStatusReadyToVerify, createdBefore, remindedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query verificators due for reminder: %w", err)
+	}
+	defer rows.Close()
+
+	var verificators []*entity.VerificatorWithBusinessTrip
+	for rows.Next() {
+		var verificator entity.VerificatorWithBusinessTrip
+		if err := rows.StructScan(&verificator); err != nil {
+			return nil, fmt.Errorf("failed to scan verificator: %w", err)
+		}
+		verificators = append(verificators, &verificator)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return verificators, nil
+}
+
+// MarkVerificatorsReminded records the time a reminder was sent to the given verificators
+func (r *businessTripRepository) MarkVerificatorsReminded(ctx context.Context, ids []string, remindedAt time.Time) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	res, err := r.db.ExecContext(ctx, markVerificatorsReminded, pq.Array(ids), remindedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark verificators reminded: %w", err)
+	}
+
+	rowAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowAffected, nil
+}
+
+// CreateVerificatorStatusHistory records verificator status changes; run it in the transaction that changes them
+func (r *businessTripRepository) CreateVerificatorStatusHistory(ctx context.Context, history []*entity.VerificatorStatusHistory) error {
+	for _, entry := range history {
+		if entry.ID == "" {
+			entry.ID = uuid.New().String()
+		}
+
+		_, err := r.db.ExecContext(ctx, insertVerificatorStatusHistory,
+			entry.ID,
+			entry.VerificatorID,
+			entry.FromStatus,
+			entry.ToStatus,
+			entry.Notes,
+			entry.ChangedBy,
+			entry.ChangedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to record verificator status history: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetVerificatorHistory retrieves the status changes of a verificator, oldest first
+func (r *businessTripRepository) GetVerificatorHistory(ctx context.Context, verificatorID string) ([]*entity.VerificatorStatusHistory, error) {
+	history := []*entity.VerificatorStatusHistory{}
+	if err := r.db.SelectContext(ctx, &history, findVerificatorHistory, verificatorID); err != nil {
+		return nil, fmt.Errorf("failed to get verificator history: %w", err)
+	}
+
+	return history, nil
+}
+
 // DeleteVerificator soft deletes a verificator
 func (r *businessTripRepository) DeleteVerificator(ctx context.Context, id string) error {
 	now := time.Now()
@@ -1306,11 +1964,13 @@ func (r *businessTripRepository) ListVerificators(ctx context.Context, params *p
 	}
 
 	// Always include deleted_at filter
-	countBuilder.AddFilter(pagination.Filter{
+	if err := countBuilder.AddFilter(pagination.Filter{
 		Field:    "v.deleted_at",
 		Operator: "is",
 		Value:    nil,
-	})
+	}); err != nil {
+		return nil, 0, err
+	}
 
 	countQuery, countArgs := countBuilder.Build()
 
@@ -1330,11 +1990,13 @@ func (r *businessTripRepository) ListVerificators(ctx context.Context, params *p
 	}
 
 	// Always include deleted_at filter
-	queryBuilder.AddFilter(pagination.Filter{
+	if err := queryBuilder.AddFilter(pagination.Filter{
 		Field:    "v.deleted_at",
 		Operator: "is",
 		Value:    nil,
-	})
+	}); err != nil {
+		return nil, 0, err
+	}
 
 	for _, sort := range params.Sorts {
 		if err := queryBuilder.AddSort(sort); err != nil {
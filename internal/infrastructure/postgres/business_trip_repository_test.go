@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"sandbox/pkg/business_trip_number"
+	"sandbox/pkg/database"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// recordingDriver is a minimal database/sql driver that records every query and
+// answers each one with the same canned result set, or with err when it is set
+type recordingDriver struct {
+	mu      sync.Mutex
+	queries []string
+	columns []string
+	rows    [][]driver.Value
+	err     error
+}
+
+func (d *recordingDriver) Open(string) (driver.Conn, error) { return &recordingConn{driver: d}, nil }
+
+// Connect and Driver let a recordingDriver be passed to sql.OpenDB without registering it
+func (d *recordingDriver) Connect(context.Context) (driver.Conn, error) { return d.Open("") }
+func (d *recordingDriver) Driver() driver.Driver                        { return d }
+
+func (d *recordingDriver) countQueries(substr string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	count := 0
+	for _, q := range d.queries {
+		if strings.Contains(q, substr) {
+			count++
+		}
+	}
+	return count
+}
+
+type recordingConn struct{ driver *recordingDriver }
+
+func (c *recordingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("prepare not supported")
+}
+func (c *recordingConn) Close() error { return nil }
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("transactions not supported")
+}
+
+func (c *recordingConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.driver.mu.Lock()
+	defer c.driver.mu.Unlock()
+	c.driver.queries = append(c.driver.queries, query)
+	if c.driver.err != nil {
+		return nil, c.driver.err
+	}
+	return &recordingRows{columns: c.driver.columns, rows: c.driver.rows}, nil
+}
+
+type recordingRows struct {
+	columns []string
+	rows    [][]driver.Value
+	pos     int
+}
+
+func (r *recordingRows) Columns() []string { return r.columns }
+func (r *recordingRows) Close() error      { return nil }
+
+func (r *recordingRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.pos])
+	r.pos++
+	return nil
+}
+
+var registerOnce sync.Once
+var testDriver = &recordingDriver{}
+
+func TestGetAssigneesWithTransactionsByBusinessTripIDUsesSingleQuery(t *testing.T) {
+	registerOnce.Do(func() { sql.Register("recording", testDriver) })
+
+	const assigneeCount = 50
+	now := time.Now()
+
+	testDriver.columns = []string{
+		"id", "business_trip_id", "name", "spd_number", "employee_id", "position", "rank", "employee_name", "employee_number",
+		"created_at", "updated_at",
+		"tx_id", "tx_name", "tx_type", "tx_subtype", "tx_amount", "tx_total_night", "tx_total_days", "tx_subtotal",
+		"tx_description", "tx_transport_detail", "tx_created_at", "tx_updated_at",
+	}
+	testDriver.rows = nil
+	for i := 0; i < assigneeCount; i++ {
+		assignee := []driver.Value{
+			fmt.Sprintf("assignee-%d", i), "trip-1", "Name", "SPD", "EMP", "Staff", "III/a", "Employee", "123",
+			now, now,
+		}
+
+		// The last assignee has no transactions, which the LEFT JOIN returns as NULL columns
+		if i == assigneeCount-1 {
+			testDriver.rows = append(testDriver.rows, append(assignee,
+				nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil))
+			continue
+		}
+
+		for j := 0; j < 2; j++ {
+			row := append(append([]driver.Value{}, assignee...),
+				fmt.Sprintf("tx-%d-%d", i, j), "Hotel", "accommodation", "hotel", 100.0, int64(2), nil, 200.0,
+				"", "", now, now)
+			testDriver.rows = append(testDriver.rows, row)
+		}
+	}
+
+	db, err := sql.Open("recording", "")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewBusinessTripRepository(database.NewDB(sqlx.NewDb(db, "postgres")), business_trip_number.ScopeGlobal, business_trip_number.DefaultFormat).(*businessTripRepository)
+
+	assignees, err := repo.GetAssigneesWithTransactionsByBusinessTripID(context.Background(), "trip-1")
+	if err != nil {
+		t.Fatalf("GetAssigneesWithTransactionsByBusinessTripID() error = %v", err)
+	}
+
+	if got := testDriver.countQueries("assignee_transactions"); got != 1 {
+		t.Errorf("transaction queries = %d, want 1", got)
+	}
+
+	if len(assignees) != assigneeCount {
+		t.Fatalf("assignees = %d, want %d", len(assignees), assigneeCount)
+	}
+	for i, assignee := range assignees {
+		want := 2
+		if i == assigneeCount-1 {
+			want = 0
+		}
+		if len(assignee.Transactions) != want {
+			t.Errorf("assignee %s transactions = %d, want %d", assignee.ID, len(assignee.Transactions), want)
+		}
+		for _, tx := range assignee.Transactions {
+			if tx.AssigneeID != assignee.ID {
+				t.Errorf("transaction %s assignee = %s, want %s", tx.ID, tx.AssigneeID, assignee.ID)
+			}
+		}
+	}
+}
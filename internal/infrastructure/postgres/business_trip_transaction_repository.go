@@ -20,14 +20,14 @@ import (
 const (
 	getTransactionByIDQuery = `
 		SELECT
-			id, assignee_id, name, type, subtype, amount, total_night, subtotal, description, transport_detail, created_at, updated_at
+			id, assignee_id, name, type, subtype, amount, total_night, total_days, subtotal, description, transport_detail, created_at, updated_at, direction, currency, exchange_rate
 		FROM assignee_transactions
 		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	getTransactionsByAssigneeIDQuery = `
 		SELECT
-			id, assignee_id, name, type, subtype, amount, total_night, subtotal, description, transport_detail, created_at, updated_at
+			id, assignee_id, name, type, subtype, amount, total_night, total_days, subtotal, description, transport_detail, created_at, updated_at, direction, currency, exchange_rate
 		FROM assignee_transactions
 		WHERE assignee_id = $1 AND deleted_at IS NULL
 		ORDER BY created_at
@@ -61,7 +61,7 @@ const (
 		SELECT
 			at.type as transaction_type,
 			COUNT(*) as average_amount,
-			COALESCE(SUM(at.subtotal), 0) as total_amount
+			COALESCE(SUM(CASE WHEN at.direction = 'credit' THEN -at.subtotal ELSE at.subtotal END), 0) as total_amount
 		FROM assignee_transactions at
 		INNER JOIN assignees a ON at.assignee_id = a.id
 		INNER JOIN business_trips bt ON a.business_trip_id = bt.id
@@ -71,6 +71,22 @@ const (
 		GROUP BY at.type
 		ORDER BY total_amount DESC
 	`
+
+	getCostCenterAllocationsQuery = `
+		SELECT
+			ta.cost_center,
+			COUNT(DISTINCT ta.transaction_id) as transaction_count,
+			COALESCE(SUM(CASE WHEN at.direction = 'credit' THEN -at.subtotal ELSE at.subtotal END * ta.percentage / 100), 0) as allocated_amount
+		FROM transaction_allocations ta
+		INNER JOIN assignee_transactions at ON ta.transaction_id = at.id
+		INNER JOIN assignees a ON at.assignee_id = a.id
+		INNER JOIN business_trips bt ON a.business_trip_id = bt.id
+		WHERE at.deleted_at IS NULL AND a.deleted_at IS NULL AND bt.deleted_at IS NULL
+		AND ($1::timestamp IS NULL OR bt.start_date >= $1)
+		AND ($2::timestamp IS NULL OR bt.start_date <= $2)
+		GROUP BY ta.cost_center
+		ORDER BY allocated_amount DESC
+	`
 )
 
 // NewBusinessTripTransactionRepository creates a new instance of BusinessTripTransactionRepository
@@ -102,8 +118,8 @@ func (r *businessTripTransactionRepository) CreateTransaction(ctx context.Contex
 	// Use insert query from business_trip_repository.go
 	query := `
 		INSERT INTO assignee_transactions (
-			id, assignee_id, name, type, subtype, amount, total_night, subtotal, description, transport_detail, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			id, assignee_id, name, type, subtype, amount, total_night, total_days, subtotal, description, transport_detail, created_at, updated_at, direction, currency, exchange_rate
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 		RETURNING id
 	`
 
@@ -116,11 +132,15 @@ func (r *businessTripTransactionRepository) CreateTransaction(ctx context.Contex
 		transaction.Subtype,
 		transaction.Amount,
 		transaction.TotalNight,
+		transaction.TotalDays,
 		transaction.Subtotal,
 		transaction.Description,
 		transaction.TransportDetail,
 		now,
 		now,
+		transaction.GetDirection(),
+		transaction.Currency,
+		transaction.GetExchangeRate(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create transaction: %w", err)
@@ -130,6 +150,10 @@ func (r *businessTripTransactionRepository) CreateTransaction(ctx context.Contex
 		return nil, fmt.Errorf("returned ID %s does not match expected ID %s", returnedID, transaction.ID)
 	}
 
+	if err := saveTransactionAllocations(ctx, r.db, transaction); err != nil {
+		return nil, err
+	}
+
 	// Set timestamps
 	transaction.CreatedAt = now
 	transaction.UpdatedAt = now
@@ -139,8 +163,17 @@ func (r *businessTripTransactionRepository) CreateTransaction(ctx context.Contex
 
 // GetTransactionByID retrieves a transaction by ID
 func (r *businessTripTransactionRepository) GetTransactionByID(ctx context.Context, id string) (*entity.Transaction, error) {
+	return r.getTransactionByID(ctx, getTransactionByIDQuery, id)
+}
+
+// GetTransactionByIDForUpdate retrieves a transaction by ID and locks its row until the transaction ends
+func (r *businessTripTransactionRepository) GetTransactionByIDForUpdate(ctx context.Context, id string) (*entity.Transaction, error) {
+	return r.getTransactionByID(ctx, getTransactionByIDQuery+" FOR UPDATE", id)
+}
+
+func (r *businessTripTransactionRepository) getTransactionByID(ctx context.Context, query, id string) (*entity.Transaction, error) {
 	var transaction entity.Transaction
-	err := r.db.GetContext(ctx, &transaction, getTransactionByIDQuery, id)
+	err := r.db.GetContext(ctx, &transaction, query, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -148,6 +181,13 @@ func (r *businessTripTransactionRepository) GetTransactionByID(ctx context.Conte
 		return nil, fmt.Errorf("failed to get transaction: %w", err)
 	}
 
+	if err := loadTransactionAllocations(ctx, r.db, []*entity.Transaction{&transaction}); err != nil {
+		return nil, err
+	}
+	if err := loadTransactionAttachments(ctx, r.db, []*entity.Transaction{&transaction}); err != nil {
+		return nil, err
+	}
+
 	return &transaction, nil
 }
 
@@ -158,7 +198,7 @@ func (r *businessTripTransactionRepository) UpdateTransaction(ctx context.Contex
 	// Use update query from business_trip_repository.go
 	query := `
 		UPDATE assignee_transactions
-		SET name = $2, type = $3, subtype = $4, amount = $5, total_night = $6, subtotal = $7, description = $8, transport_detail = $9, updated_at = $10
+		SET name = $2, type = $3, subtype = $4, amount = $5, total_night = $6, total_days = $7, subtotal = $8, description = $9, transport_detail = $10, updated_at = $11, direction = $12, currency = $13, exchange_rate = $14
 		WHERE id = $1 AND deleted_at IS NULL
 	`
 
@@ -169,10 +209,14 @@ func (r *businessTripTransactionRepository) UpdateTransaction(ctx context.Contex
 		transaction.Subtype,
 		transaction.Amount,
 		transaction.TotalNight,
+		transaction.TotalDays,
 		transaction.Subtotal,
 		transaction.Description,
 		transaction.TransportDetail,
 		now,
+		transaction.GetDirection(),
+		transaction.Currency,
+		transaction.GetExchangeRate(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update transaction: %w", err)
@@ -187,11 +231,40 @@ func (r *businessTripTransactionRepository) UpdateTransaction(ctx context.Contex
 		return nil, fmt.Errorf("transaction with ID %s not found", transaction.ID)
 	}
 
+	if err := saveTransactionAllocations(ctx, r.db, transaction); err != nil {
+		return nil, err
+	}
+
 	transaction.UpdatedAt = now
 
 	return transaction, nil
 }
 
+// UpdateTransactionSubtotal stores a recomputed subtotal without touching the other columns or the allocations
+func (r *businessTripTransactionRepository) UpdateTransactionSubtotal(ctx context.Context, id string, subtotal float64) error {
+	query := `
+		UPDATE assignee_transactions
+		SET subtotal = $2, updated_at = $3
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	res, err := r.db.ExecContext(ctx, query, id, subtotal, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to update transaction subtotal: %w", err)
+	}
+
+	rowAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowAffected == 0 {
+		return fmt.Errorf("%w: %s", entity.ErrTransactionNotFound, id)
+	}
+
+	return nil
+}
+
 // DeleteTransaction soft deletes a transaction
 func (r *businessTripTransactionRepository) DeleteTransaction(ctx context.Context, id string) error {
 	now := time.Now()
@@ -242,6 +315,13 @@ func (r *businessTripTransactionRepository) GetTransactionsByAssigneeID(ctx cont
 		return nil, fmt.Errorf("error iterating rows: %w", err)
 	}
 
+	if err := loadTransactionAllocations(ctx, r.db, transactions); err != nil {
+		return nil, err
+	}
+	if err := loadTransactionAttachments(ctx, r.db, transactions); err != nil {
+		return nil, err
+	}
+
 	return transactions, nil
 }
 
@@ -316,3 +396,13 @@ func (r *businessTripTransactionRepository) GetTypeStats(ctx context.Context, st
 
 	return stats, nil
 }
+
+// GetCostCenterAllocations sums allocated transaction cost per cost center for trips starting within the range
+func (r *businessTripTransactionRepository) GetCostCenterAllocations(ctx context.Context, startDate, endDate *time.Time) ([]*repository.CostCenterAllocationData, error) {
+	var data []*repository.CostCenterAllocationData
+	if err := r.db.SelectContext(ctx, &data, getCostCenterAllocationsQuery, startDate, endDate); err != nil {
+		return nil, fmt.Errorf("failed to query cost center allocations: %w", err)
+	}
+
+	return data, nil
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 
 	"sandbox/internal/domain/entity"
 	"sandbox/internal/domain/repository"
@@ -120,6 +121,11 @@ func (r *workPaperItemRepository) List(ctx context.Context, params *pagination.Q
 			return nil, 0, err
 		}
 	}
+	for _, group := range params.OrGroups {
+		if err := countBuilder.AddOrGroup(group); err != nil {
+			return nil, 0, err
+		}
+	}
 	// Always include deleted_at filter
 	countBuilder.AddFilter(pagination.Filter{
 		Field:    "deleted_at",
@@ -143,6 +149,11 @@ func (r *workPaperItemRepository) List(ctx context.Context, params *pagination.Q
 			return nil, 0, err
 		}
 	}
+	for _, group := range params.OrGroups {
+		if err := queryBuilder.AddOrGroup(group); err != nil {
+			return nil, 0, err
+		}
+	}
 	// Always include deleted_at filter
 	queryBuilder.AddFilter(pagination.Filter{
 		Field:    "deleted_at",
@@ -209,6 +220,82 @@ func (r *workPaperItemRepository) ListActive(ctx context.Context) ([]*entity.Wor
 	return items, nil
 }
 
+const listAllWorkPaperItemsQuery = `
+		SELECT id, type, number, statement, explanation, filling_guide, parent_id, level, sort_order, is_active, created_at, updated_at, deleted_at
+		FROM work_paper_items
+		WHERE deleted_at IS NULL
+		ORDER BY level, sort_order, number ASC
+	`
+
+func (r *workPaperItemRepository) ListAll(ctx context.Context) ([]*entity.WorkPaperItem, error) {
+	var items []*entity.WorkPaperItem
+	err := r.db.SelectContext(ctx, &items, listAllWorkPaperItemsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query work paper items: %w", err)
+	}
+
+	return items, nil
+}
+
+func (r *workPaperItemRepository) ListAllForUpdate(ctx context.Context) ([]*entity.WorkPaperItem, error) {
+	var items []*entity.WorkPaperItem
+	err := r.db.SelectContext(ctx, &items, listAllWorkPaperItemsQuery+" FOR UPDATE")
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock work paper items: %w", err)
+	}
+
+	return items, nil
+}
+
+func (r *workPaperItemRepository) UpdatePositions(ctx context.Context, items []*entity.WorkPaperItem) error {
+	beginner, ok := r.db.(database.TxBeginner)
+	if !ok {
+		// Already running inside the caller's transaction
+		return r.updatePositions(ctx, r.db, items)
+	}
+	return database.WithinTx(ctx, beginner, func(tx database.DBTx) error {
+		return r.updatePositions(ctx, tx, items)
+	})
+}
+
+func (r *workPaperItemRepository) updatePositions(ctx context.Context, db database.Queryer, items []*entity.WorkPaperItem) error {
+	query := `
+		UPDATE work_paper_items
+		SET parent_id = $2, level = $3, sort_order = $4, updated_at = $5
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	now := time.Now()
+	for _, item := range items {
+		result, err := db.ExecContext(ctx, query, item.ID, item.ParentID, item.Level, item.SortOrder, now)
+		if err != nil {
+			return fmt.Errorf("failed to update work paper item position: %w", err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rowsAffected == 0 {
+			return fmt.Errorf("%w: %s", entity.ErrWorkPaperItemNotFound, item.ID)
+		}
+	}
+
+	for _, item := range items {
+		item.UpdatedAt = now
+	}
+	return nil
+}
+
+// WithTransaction returns a repository that runs its queries in tx when tx is a database.DBTx,
+// and the repository itself otherwise
+func (r *workPaperItemRepository) WithTransaction(tx interface{}) repository.WorkPaperItemRepository {
+	dbTx, ok := tx.(database.DBTx)
+	if !ok {
+		return r
+	}
+	return &workPaperItemRepository{db: dbTx}
+}
+
 // Work paper repository
 type workPaperRepository struct {
 	db database.Queryer
@@ -223,12 +310,12 @@ func (r *workPaperRepository) Create(ctx context.Context, wp *entity.WorkPaper)
 
 	query := `
 		INSERT INTO work_papers (
-			id, organization_id, year, semester, status, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+			id, organization_id, year, semester, status, version, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	`
 
 	_, err := r.db.ExecContext(ctx, query,
-		wp.ID, wp.OrganizationID, wp.Year, wp.Semester, wp.Status, wp.CreatedAt, wp.UpdatedAt,
+		wp.ID, wp.OrganizationID, wp.Year, wp.Semester, wp.Status, wp.Version, wp.CreatedAt, wp.UpdatedAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create work paper: %w", err)
@@ -238,7 +325,7 @@ func (r *workPaperRepository) Create(ctx context.Context, wp *entity.WorkPaper)
 
 func (r *workPaperRepository) GetByID(ctx context.Context, id string) (*entity.WorkPaper, error) {
 	query := `
-		SELECT id, organization_id, year, semester, status, created_at, updated_at, deleted_at
+		SELECT id, organization_id, year, semester, status, version, created_at, updated_at, deleted_at
 		FROM work_papers
 		WHERE id = $1 AND deleted_at IS NULL
 	`
@@ -256,7 +343,7 @@ func (r *workPaperRepository) GetByID(ctx context.Context, id string) (*entity.W
 
 func (r *workPaperRepository) GetByOrganizationYearSemester(ctx context.Context, organizationID string, year, semester int) (*entity.WorkPaper, error) {
 	query := `
-		SELECT id, organization_id, year, semester, status, created_at, updated_at, deleted_at
+		SELECT id, organization_id, year, semester, status, version, created_at, updated_at, deleted_at
 		FROM work_papers
 		WHERE organization_id = $1 AND year = $2 AND semester = $3 AND deleted_at IS NULL
 	`
@@ -272,18 +359,38 @@ func (r *workPaperRepository) GetByOrganizationYearSemester(ctx context.Context,
 	return &wp, nil
 }
 
+// Update saves the work paper if it still has the version it was read with.
+// It returns entity.ErrVersionConflict when another update got there first.
 func (r *workPaperRepository) Update(ctx context.Context, wp *entity.WorkPaper) (*entity.WorkPaper, error) {
 	query := `
 		UPDATE work_papers
-		SET status = $2, updated_at = $3
-		WHERE id = $1
+		SET status = $2, updated_at = $3, version = version + 1
+		WHERE id = $1 AND version = $4 AND deleted_at IS NULL
 	`
 
 	now := time.Now()
-	_, err := r.db.ExecContext(ctx, query, wp.ID, wp.Status, now)
+	res, err := r.db.ExecContext(ctx, query, wp.ID, wp.Status, now, wp.Version)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update work paper: %w", err)
 	}
+
+	rowAffected, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowAffected == 0 {
+		// Tell a version mismatch apart from a missing work paper
+		var exists bool
+		if err := r.db.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM work_papers WHERE id = $1 AND deleted_at IS NULL)`, wp.ID); err != nil {
+			return nil, fmt.Errorf("failed to check work paper existence: %w", err)
+		}
+		if !exists {
+			return nil, entity.ErrWorkPaperNotFound
+		}
+		return nil, entity.ErrVersionConflict
+	}
+
+	wp.Version++
 	wp.UpdatedAt = now
 	return wp, nil
 }
@@ -305,7 +412,7 @@ func (r *workPaperRepository) Delete(ctx context.Context, id string) error {
 
 func (r *workPaperRepository) List(ctx context.Context, params interface{}) ([]*entity.WorkPaper, int64, error) {
 	query := `
-		SELECT id, organization_id, year, semester, status, created_at, updated_at, deleted_at
+		SELECT id, organization_id, year, semester, status, version, created_at, updated_at, deleted_at
 		FROM work_papers
 		WHERE deleted_at IS NULL
 		ORDER BY created_at DESC
@@ -322,7 +429,7 @@ func (r *workPaperRepository) List(ctx context.Context, params interface{}) ([]*
 
 func (r *workPaperRepository) ListByOrganization(ctx context.Context, organizationID string) ([]*entity.WorkPaper, error) {
 	query := `
-		SELECT id, organization_id, year, semester, status, created_at, updated_at, deleted_at
+		SELECT id, organization_id, year, semester, status, version, created_at, updated_at, deleted_at
 		FROM work_papers
 		WHERE organization_id = $1 AND deleted_at IS NULL
 		ORDER BY year DESC, semester DESC
@@ -386,6 +493,9 @@ func (r *workPaperRepository) GetByFilter(ctx context.Context, filter *repositor
 				Value:    filter.Semester,
 			})
 		}
+		if condition := workPaperCompletionCondition(filter.Completion); condition != "" {
+			countBuilder.AddCondition(condition)
+		}
 	}
 
 	countQuery, countArgs := countBuilder.Build()
@@ -398,7 +508,7 @@ func (r *workPaperRepository) GetByFilter(ctx context.Context, filter *repositor
 	// Build main query
 	queryBuilder := pagination.NewQueryBuilder(`
 		SELECT
-			id, organization_id, year, semester, status, created_at, updated_at, deleted_at
+			id, organization_id, year, semester, status, version, created_at, updated_at, deleted_at
 		FROM work_papers`)
 
 	// Add deleted_at filter to main query
@@ -438,6 +548,9 @@ func (r *workPaperRepository) GetByFilter(ctx context.Context, filter *repositor
 				Value:    filter.Semester,
 			})
 		}
+		if condition := workPaperCompletionCondition(filter.Completion); condition != "" {
+			queryBuilder.AddCondition(condition)
+		}
 	}
 
 	// Add default ordering by created_at DESC
@@ -461,6 +574,27 @@ func (r *workPaperRepository) GetByFilter(ctx context.Context, filter *repositor
 	return workPapers, totalCount, nil
 }
 
+// fullyLinkedWorkPapers selects the work papers whose notes all have a Drive link. It aggregates the
+// notes, so it cannot be expressed with the query builder's column filters.
+const fullyLinkedWorkPapers = `
+	SELECT work_paper_id
+	FROM work_paper_notes
+	WHERE deleted_at IS NULL
+	GROUP BY work_paper_id
+	HAVING COUNT(*) FILTER (WHERE gdrive_link IS NULL OR gdrive_link = '') = 0`
+
+// workPaperCompletionCondition returns the condition on work_papers.id for a completion filter, or an
+// empty string when the filter is not set
+func workPaperCompletionCondition(completion string) string {
+	switch completion {
+	case repository.WorkPaperCompletionComplete:
+		return "id IN (" + fullyLinkedWorkPapers + ")"
+	case repository.WorkPaperCompletionIncomplete:
+		return "id NOT IN (" + fullyLinkedWorkPapers + ")"
+	}
+	return ""
+}
+
 // Work paper note repository
 type workPaperNoteRepository struct {
 	db database.Queryer
@@ -473,13 +607,13 @@ func NewWorkPaperNoteRepository(db database.Queryer) repository.WorkPaperNoteRep
 func (r *workPaperNoteRepository) Create(ctx context.Context, note *entity.WorkPaperNote) (*entity.WorkPaperNote, error) {
 	query := `
 		INSERT INTO work_paper_notes (
-			id, work_paper_id, master_item_id, gdrive_link, is_valid, notes, last_llm_response, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			id, work_paper_id, master_item_id, gdrive_link, is_valid, notes, last_llm_response, version, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
 
 	_, err := r.db.ExecContext(ctx, query,
 		note.ID, note.WorkPaperID, note.MasterItemID, note.GDriveLink, note.IsValid,
-		note.Notes, note.LastLLMResponse, note.CreatedAt, note.UpdatedAt,
+		note.Notes, note.LastLLMResponse, note.Version, note.CreatedAt, note.UpdatedAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create work paper note: %w", err)
@@ -501,7 +635,7 @@ func (r *workPaperNoteRepository) CreateBatch(ctx context.Context, notes []*enti
 
 func (r *workPaperNoteRepository) GetByID(ctx context.Context, id string) (*entity.WorkPaperNote, error) {
 	query := `
-		SELECT id, work_paper_id, master_item_id, gdrive_link, is_valid, notes, last_llm_response, created_at, updated_at, deleted_at
+		SELECT id, work_paper_id, master_item_id, gdrive_link, is_valid, notes, last_llm_response, version, created_at, updated_at, deleted_at
 		FROM work_paper_notes
 		WHERE id = $1 AND deleted_at IS NULL
 	`
@@ -532,7 +666,7 @@ func (r *workPaperNoteRepository) GetByID(ctx context.Context, id string) (*enti
 
 func (r *workPaperNoteRepository) GetByWorkPaper(ctx context.Context, workPaperID string) ([]*entity.WorkPaperNote, error) {
 	query := `
-		SELECT id, work_paper_id, master_item_id, gdrive_link, is_valid, notes, last_llm_response, created_at, updated_at, deleted_at
+		SELECT id, work_paper_id, master_item_id, gdrive_link, is_valid, notes, last_llm_response, version, created_at, updated_at, deleted_at
 		FROM work_paper_notes
 		WHERE work_paper_id = $1 AND deleted_at IS NULL
 		ORDER BY created_at ASC
@@ -580,20 +714,40 @@ func (r *workPaperNoteRepository) getMasterItemByID(ctx context.Context, masterI
 	return &masterItem, nil
 }
 
+// Update saves the note if it still has the version it was read with.
+// It returns entity.ErrVersionConflict when another update got there first.
 func (r *workPaperNoteRepository) Update(ctx context.Context, note *entity.WorkPaperNote) (*entity.WorkPaperNote, error) {
 	query := `
 		UPDATE work_paper_notes
-		SET gdrive_link = $2, is_valid = $3, notes = $4, last_llm_response = $5, updated_at = $6
-		WHERE id = $1
+		SET gdrive_link = $2, is_valid = $3, notes = $4, last_llm_response = $5, updated_at = $6, version = version + 1
+		WHERE id = $1 AND version = $7 AND deleted_at IS NULL
 	`
 
 	now := time.Now()
-	_, err := r.db.ExecContext(ctx, query,
-		note.ID, note.GDriveLink, note.IsValid, note.Notes, note.LastLLMResponse, now,
+	res, err := r.db.ExecContext(ctx, query,
+		note.ID, note.GDriveLink, note.IsValid, note.Notes, note.LastLLMResponse, now, note.Version,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update work paper note: %w", err)
 	}
+
+	rowAffected, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowAffected == 0 {
+		// Tell a version mismatch apart from a missing note
+		var exists bool
+		if err := r.db.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM work_paper_notes WHERE id = $1 AND deleted_at IS NULL)`, note.ID); err != nil {
+			return nil, fmt.Errorf("failed to check work paper note existence: %w", err)
+		}
+		if !exists {
+			return nil, entity.ErrWorkPaperNoteNotFound
+		}
+		return nil, entity.ErrVersionConflict
+	}
+
+	note.Version++
 	note.UpdatedAt = now
 	return note, nil
 }
@@ -613,13 +767,352 @@ func (r *workPaperNoteRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-func (r *workPaperNoteRepository) List(ctx context.Context, params interface{}) ([]*entity.WorkPaperNote, int64, error) {
-	// Simplified implementation - can be expanded later
-	return nil, 0, nil
+// workPaperNoteFilterFields maps the filterable fields of a work paper note to their columns
+var workPaperNoteFilterFields = map[string]string{
+	"work_paper_id":  "n.work_paper_id",
+	"is_valid":       "n.is_valid",
+	"master_item_id": "n.master_item_id",
+}
+
+// workPaperNoteSortFields maps the sortable fields of a work paper note to their columns
+var workPaperNoteSortFields = map[string]string{
+	"created_at": "n.created_at",
+}
+
+// workPaperNoteWithItemRow is a work paper note joined with its master item. The item columns are
+// NULL when the item has been deleted.
+type workPaperNoteWithItemRow struct {
+	entity.WorkPaperNote
+	ItemID           *uuid.UUID `db:"item_id"`
+	ItemType         *string    `db:"item_type"`
+	ItemNumber       *string    `db:"item_number"`
+	ItemStatement    *string    `db:"item_statement"`
+	ItemExplanation  *string    `db:"item_explanation"`
+	ItemFillingGuide *string    `db:"item_filling_guide"`
+	ItemParentID     *uuid.UUID `db:"item_parent_id"`
+	ItemLevel        *int       `db:"item_level"`
+	ItemSortOrder    *int       `db:"item_sort_order"`
+	ItemIsActive     *bool      `db:"item_is_active"`
+	ItemCreatedAt    *time.Time `db:"item_created_at"`
+	ItemUpdatedAt    *time.Time `db:"item_updated_at"`
+}
+
+func (row *workPaperNoteWithItemRow) toEntity() *entity.WorkPaperNote {
+	note := row.WorkPaperNote
+	if row.ItemID != nil {
+		note.MasterItem = &entity.WorkPaperItem{
+			ID:           *row.ItemID,
+			Type:         derefString(row.ItemType),
+			Number:       derefString(row.ItemNumber),
+			Statement:    derefString(row.ItemStatement),
+			Explanation:  derefString(row.ItemExplanation),
+			FillingGuide: derefString(row.ItemFillingGuide),
+			ParentID:     row.ItemParentID,
+			Level:        derefInt(row.ItemLevel),
+			SortOrder:    derefInt(row.ItemSortOrder),
+			IsActive:     row.ItemIsActive != nil && *row.ItemIsActive,
+		}
+		if row.ItemCreatedAt != nil {
+			note.MasterItem.CreatedAt = *row.ItemCreatedAt
+		}
+		if row.ItemUpdatedAt != nil {
+			note.MasterItem.UpdatedAt = *row.ItemUpdatedAt
+		}
+	}
+	return &note
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func derefInt(i *int) int {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
+
+// workPaperNoteWithItemSelect selects notes together with their master item, scanned into workPaperNoteWithItemRow
+const workPaperNoteWithItemSelect = `
+		SELECT
+			n.id, n.work_paper_id, n.master_item_id, n.gdrive_link, n.is_valid, n.notes, n.last_llm_response,
+			n.version, n.created_at, n.updated_at, n.deleted_at,
+			i.id AS item_id, i.type AS item_type, i.number AS item_number, i.statement AS item_statement,
+			i.explanation AS item_explanation, i.filling_guide AS item_filling_guide, i.parent_id AS item_parent_id,
+			i.level AS item_level, i.sort_order AS item_sort_order, i.is_active AS item_is_active,
+			i.created_at AS item_created_at, i.updated_at AS item_updated_at
+		FROM work_paper_notes n
+		LEFT JOIN work_paper_items i ON i.id = n.master_item_id AND i.deleted_at IS NULL`
+
+// List returns a page of work paper notes across work papers, each with its master item loaded through
+// a single join. Notes can be filtered on work_paper_id, is_valid and master_item_id and sorted on
+// created_at, newest first by default.
+func (r *workPaperNoteRepository) List(ctx context.Context, params *pagination.QueryParams) ([]*entity.WorkPaperNote, int64, error) {
+	filters := make([]pagination.Filter, 0, len(params.Filters)+1)
+	for _, filter := range params.Filters {
+		column, ok := workPaperNoteFilterFields[filter.Field]
+		if !ok {
+			return nil, 0, fmt.Errorf("filtering work paper notes by %q is not allowed", filter.Field)
+		}
+		filter.Field = column
+		filters = append(filters, filter)
+	}
+	// Always include deleted_at filter
+	filters = append(filters, pagination.Filter{
+		Field:    "n.deleted_at",
+		Operator: "is",
+		Value:    nil,
+	})
+
+	sorts := make([]pagination.Sort, 0, len(params.Sorts))
+	for _, sort := range params.Sorts {
+		column, ok := workPaperNoteSortFields[sort.Field]
+		if !ok {
+			return nil, 0, fmt.Errorf("sorting work paper notes by %q is not allowed", sort.Field)
+		}
+		sort.Field = column
+		sorts = append(sorts, sort)
+	}
+	if len(sorts) == 0 {
+		sorts = []pagination.Sort{{Field: "n.created_at", Order: "desc"}}
+	}
+
+	// Build count query
+	countBuilder := pagination.NewQueryBuilder("SELECT COUNT(*) FROM work_paper_notes n")
+	for _, filter := range filters {
+		if err := countBuilder.AddFilter(filter); err != nil {
+			return nil, 0, err
+		}
+	}
+	countQuery, countArgs := countBuilder.Build()
+	var totalCount int64
+	if err := r.db.GetContext(ctx, &totalCount, countQuery, countArgs...); err != nil {
+		return nil, 0, fmt.Errorf("failed to count work paper notes: %w", err)
+	}
+
+	// Build main query
+	queryBuilder := pagination.NewQueryBuilder(workPaperNoteWithItemSelect)
+	for _, filter := range filters {
+		if err := queryBuilder.AddFilter(filter); err != nil {
+			return nil, 0, err
+		}
+	}
+	for _, sort := range sorts {
+		if err := queryBuilder.AddSort(sort); err != nil {
+			return nil, 0, err
+		}
+	}
+	query, args := queryBuilder.Build()
+
+	// Add pagination
+	offset := (params.Pagination.Page - 1) * params.Pagination.Limit
+	query += fmt.Sprintf(" LIMIT %d OFFSET %d", params.Pagination.Limit, offset)
+
+	var rows []*workPaperNoteWithItemRow
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to query work paper notes: %w", err)
+	}
+
+	notes := make([]*entity.WorkPaperNote, 0, len(rows))
+	for _, row := range rows {
+		notes = append(notes, row.toEntity())
+	}
+
+	return notes, totalCount, nil
+}
+
+func (r *workPaperNoteRepository) GetStats(ctx context.Context, workPaperID string) (*repository.WorkPaperNoteStats, error) {
+	query := `
+		SELECT
+			COUNT(*) AS total,
+			COUNT(CASE WHEN gdrive_link IS NOT NULL AND gdrive_link <> '' THEN 1 END) AS with_link,
+			COUNT(CASE WHEN is_valid = true THEN 1 END) AS valid,
+			COUNT(CASE WHEN is_valid = false THEN 1 END) AS invalid
+		FROM work_paper_notes
+		WHERE work_paper_id = $1 AND deleted_at IS NULL
+	`
+
+	var stats repository.WorkPaperNoteStats
+	if err := r.db.GetContext(ctx, &stats, query, workPaperID); err != nil {
+		return nil, fmt.Errorf("failed to get work paper note statistics: %w", err)
+	}
+	return &stats, nil
+}
+
+// GetNotesNeedingAttention returns the notes of a work paper that have no Drive link or were marked
+// invalid, in the order of their master items
+func (r *workPaperNoteRepository) GetNotesNeedingAttention(ctx context.Context, workPaperID string) ([]*entity.WorkPaperNote, error) {
+	query := workPaperNoteWithItemSelect + `
+		WHERE n.work_paper_id = $1 AND n.deleted_at IS NULL
+			AND (n.gdrive_link IS NULL OR n.gdrive_link = '' OR n.is_valid = false)
+		ORDER BY i.sort_order NULLS LAST, i.number, n.created_at
+	`
+
+	var rows []*workPaperNoteWithItemRow
+	if err := r.db.SelectContext(ctx, &rows, query, workPaperID); err != nil {
+		return nil, fmt.Errorf("failed to query work paper notes needing attention: %w", err)
+	}
+
+	notes := make([]*entity.WorkPaperNote, 0, len(rows))
+	for _, row := range rows {
+		notes = append(notes, row.toEntity())
+	}
+
+	return notes, nil
 }
 
+// WithTransaction returns a repository that runs its queries in tx when tx is a database.DBTx,
+// and the repository itself otherwise
 func (r *workPaperNoteRepository) WithTransaction(tx interface{}) repository.WorkPaperNoteRepository {
-	return r
+	dbTx, ok := tx.(database.DBTx)
+	if !ok {
+		return r
+	}
+	return &workPaperNoteRepository{db: dbTx}
+}
+
+// Work paper note check history repository
+type workPaperNoteCheckHistoryRepository struct {
+	db database.Queryer
+}
+
+func NewWorkPaperNoteCheckHistoryRepository(db database.Queryer) repository.WorkPaperNoteCheckHistoryRepository {
+	return &workPaperNoteCheckHistoryRepository{db: db}
+}
+
+func (r *workPaperNoteCheckHistoryRepository) Create(ctx context.Context, history *entity.WorkPaperNoteCheckHistory) error {
+	query := `
+		INSERT INTO work_paper_note_check_history (
+			id, work_paper_note_id, is_valid, notes, model, prompt_tokens, completion_tokens, total_tokens, checked_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		history.ID, history.WorkPaperNoteID, history.IsValid, history.Notes, history.Model,
+		history.PromptTokens, history.CompletionTokens, history.TotalTokens, history.CheckedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create work paper note check history: %w", err)
+	}
+	return nil
+}
+
+func (r *workPaperNoteCheckHistoryRepository) ListByNoteID(ctx context.Context, noteID string) ([]*entity.WorkPaperNoteCheckHistory, error) {
+	query := `
+		SELECT id, work_paper_note_id, is_valid, notes, model, prompt_tokens, completion_tokens, total_tokens, checked_at
+		FROM work_paper_note_check_history
+		WHERE work_paper_note_id = $1
+		ORDER BY checked_at DESC
+	`
+
+	history := []*entity.WorkPaperNoteCheckHistory{}
+	err := r.db.SelectContext(ctx, &history, query, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list work paper note check history: %w", err)
+	}
+	return history, nil
+}
+
+// LLM verdict cache repository
+type llmVerdictCacheRepository struct {
+	db database.Queryer
+}
+
+func NewLLMVerdictCacheRepository(db database.Queryer) repository.LLMVerdictCacheRepository {
+	return &llmVerdictCacheRepository{db: db}
+}
+
+// llmVerdictRow is an llm_verdict_cache row; the missing items are a Postgres text array
+type llmVerdictRow struct {
+	entity.LLMVerdict
+	MissingItems pq.StringArray `db:"missing_items"`
+}
+
+func (r *llmVerdictCacheRepository) Get(ctx context.Context, documentHash, itemHash string) (*entity.LLMVerdict, error) {
+	query := `
+		SELECT document_hash, item_hash, is_valid, notes, confidence, missing_items, model, created_at, expires_at
+		FROM llm_verdict_cache
+		WHERE document_hash = $1 AND item_hash = $2 AND expires_at > NOW()
+	`
+
+	var row llmVerdictRow
+	err := r.db.GetContext(ctx, &row, query, documentHash, itemHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get cached LLM verdict: %w", err)
+	}
+	verdict := row.LLMVerdict
+	verdict.MissingItems = []string(row.MissingItems)
+	return &verdict, nil
+}
+
+func (r *llmVerdictCacheRepository) Upsert(ctx context.Context, verdict *entity.LLMVerdict) error {
+	query := `
+		INSERT INTO llm_verdict_cache (document_hash, item_hash, is_valid, notes, confidence, missing_items, model, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (document_hash, item_hash) DO UPDATE
+		SET is_valid = EXCLUDED.is_valid, notes = EXCLUDED.notes, confidence = EXCLUDED.confidence,
+			missing_items = EXCLUDED.missing_items, model = EXCLUDED.model,
+			created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+	`
+
+	missingItems := verdict.MissingItems
+	if missingItems == nil {
+		missingItems = []string{}
+	}
+	_, err := r.db.ExecContext(ctx, query,
+		verdict.DocumentHash, verdict.ItemHash, verdict.IsValid, verdict.Notes, verdict.Confidence,
+		pq.StringArray(missingItems), verdict.Model, verdict.CreatedAt, verdict.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to cache LLM verdict: %w", err)
+	}
+	return nil
+}
+
+// LLM token usage repository
+type llmTokenUsageRepository struct {
+	db database.Queryer
+}
+
+func NewLLMTokenUsageRepository(db database.Queryer) repository.LLMTokenUsageRepository {
+	return &llmTokenUsageRepository{db: db}
+}
+
+func (r *llmTokenUsageRepository) GetTotal(ctx context.Context, month time.Time) (int64, error) {
+	query := `SELECT total_tokens FROM llm_token_usage WHERE month = $1`
+
+	var total int64
+	err := r.db.GetContext(ctx, &total, query, month)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get LLM token usage: %w", err)
+	}
+	return total, nil
+}
+
+func (r *llmTokenUsageRepository) Add(ctx context.Context, month time.Time, tokens int64) error {
+	// A single upsert keeps concurrent checks from losing each other's increments
+	query := `
+		INSERT INTO llm_token_usage (month, total_tokens, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (month) DO UPDATE
+		SET total_tokens = llm_token_usage.total_tokens + EXCLUDED.total_tokens, updated_at = NOW()
+	`
+
+	_, err := r.db.ExecContext(ctx, query, month, tokens)
+	if err != nil {
+		return fmt.Errorf("failed to add LLM token usage: %w", err)
+	}
+	return nil
 }
 
 // Backward compatibility factory functions (deprecated)
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"sandbox/internal/domain/repository"
+	"sandbox/pkg/database"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func TestGetWorkPapersByCompletion(t *testing.T) {
+	tests := []struct {
+		completion string
+		want       string
+	}{
+		{repository.WorkPaperCompletionComplete, "(id IN ("},
+		{repository.WorkPaperCompletionIncomplete, "(id NOT IN ("},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.completion, func(t *testing.T) {
+			recorder := &recordingDriver{err: errors.New("stop after the count query")}
+			db := sqlx.NewDb(sql.OpenDB(recorder), "postgres")
+			defer db.Close()
+
+			repo := NewWorkPaperRepository(database.NewDB(db))
+			filter := &repository.WorkPaperFilter{Status: "draft", Completion: tt.completion}
+			if _, _, err := repo.GetByFilter(context.Background(), filter, 1, 10); err == nil {
+				t.Fatal("GetByFilter() error = nil, want the driver error")
+			}
+
+			if len(recorder.queries) != 1 {
+				t.Fatalf("ran %d queries, want 1", len(recorder.queries))
+			}
+			query := recorder.queries[0]
+			for _, want := range []string{"status = $1", tt.want, "HAVING COUNT(*) FILTER (WHERE gdrive_link IS NULL OR gdrive_link = '') = 0"} {
+				if !strings.Contains(query, want) {
+					t.Errorf("count query %q does not contain %q", query, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"sandbox/internal/domain/entity"
+)
+
+const (
+	// uniqueViolation is the Postgres error code for a unique constraint or index violation
+	uniqueViolation = "23505"
+
+	assigneeSPDNumberIndex = "uq_assignees_business_trip_spd_number"
+)
+
+// isUniqueViolation reports whether err was raised by the unique constraint or index named constraint
+func isUniqueViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation && pqErr.Constraint == constraint
+}
+
+// assigneeWriteError translates a duplicate SPD number rejected by the database into ErrDuplicateSPDNumber
+func assigneeWriteError(err error, spdNumber, action string) error {
+	if isUniqueViolation(err, assigneeSPDNumberIndex) {
+		return fmt.Errorf("%w: %s already exists for this business trip", entity.ErrDuplicateSPDNumber, spdNumber)
+	}
+	return fmt.Errorf("failed to %s assignee: %w", action, err)
+}
@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"sandbox/pkg/database"
+	"sandbox/pkg/featureflag"
+)
+
+const getFeatureFlagOverridesQuery = `
+	SELECT name, enabled
+	FROM feature_flag_overrides
+	WHERE organization_id = $1
+`
+
+// NewFeatureFlagRepository creates a store for per-organization feature flag overrides
+func NewFeatureFlagRepository(db database.Queryer) featureflag.OverrideStore {
+	return &featureFlagRepository{
+		db: db,
+	}
+}
+
+type featureFlagRepository struct {
+	db database.Queryer
+}
+
+// GetOverrides returns the flags overridden for the organization
+func (r *featureFlagRepository) GetOverrides(ctx context.Context, organizationID string) (map[featureflag.Name]bool, error) {
+	var rows []struct {
+		Name    string `db:"name"`
+		Enabled bool   `db:"enabled"`
+	}
+	if err := r.db.SelectContext(ctx, &rows, getFeatureFlagOverridesQuery, organizationID); err != nil {
+		return nil, fmt.Errorf("failed to get feature flag overrides: %w", err)
+	}
+
+	overrides := make(map[featureflag.Name]bool, len(rows))
+	for _, row := range rows {
+		overrides[featureflag.Name(row.Name)] = row.Enabled
+	}
+
+	return overrides, nil
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/pkg/database"
+)
+
+// NewSignatureAuditLogRepository creates a store for the signer management audit trail
+func NewSignatureAuditLogRepository(db database.Queryer) repository.SignatureAuditLogRepository {
+	return &signatureAuditLogRepository{
+		db: db,
+	}
+}
+
+type signatureAuditLogRepository struct {
+	db database.Queryer
+}
+
+// CreateBatch inserts all entries with a single statement
+func (r *signatureAuditLogRepository) CreateBatch(ctx context.Context, entries []*entity.SignatureAuditLog) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	const columns = 9
+	placeholders := make([]string, 0, len(entries))
+	args := make([]interface{}, 0, len(entries)*columns)
+	for i, entry := range entries {
+		n := i * columns
+		placeholders = append(placeholders, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
+			n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8, n+9))
+		args = append(args, entry.ID, entry.WorkPaperID, entry.SignatureID, entry.SignerUserID,
+			entry.Action, entry.Outcome, entry.ActorID, entry.ActorName, entry.CreatedAt)
+	}
+
+	query := `
+		INSERT INTO signature_audit_log (
+			id, work_paper_id, signature_id, signer_user_id, action, outcome, actor_id, actor_name, created_at
+		) VALUES ` + strings.Join(placeholders, ", ")
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to create signature audit log: %w", err)
+	}
+
+	return nil
+}
+
+// WithTransaction returns a repository that writes in tx when tx is a database.DBTx,
+// and the repository itself otherwise
+func (r *signatureAuditLogRepository) WithTransaction(tx interface{}) repository.SignatureAuditLogRepository {
+	dbTx, ok := tx.(database.DBTx)
+	if !ok {
+		return r
+	}
+	return &signatureAuditLogRepository{db: dbTx}
+}
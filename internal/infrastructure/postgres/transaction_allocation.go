@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/pkg/database"
+)
+
+// SQL queries for transaction allocation operations
+const (
+	deleteTransactionAllocationsQuery = `
+		DELETE FROM transaction_allocations
+		WHERE transaction_id = $1
+	`
+
+	insertTransactionAllocationQuery = `
+		INSERT INTO transaction_allocations (
+			id, transaction_id, cost_center, percentage, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	getTransactionAllocationsQueryTemplate = `
+		SELECT id, transaction_id, cost_center, percentage, created_at, updated_at
+		FROM transaction_allocations
+		WHERE transaction_id IN (%s)
+		ORDER BY percentage DESC, cost_center
+	`
+)
+
+// saveTransactionAllocations replaces the allocation splits of a transaction.
+// A nil Allocations slice leaves the stored splits untouched. The splits are deleted and re-inserted in
+// the caller's transaction, or in a transaction of their own when db is not one, so a failed insert never
+// leaves the transaction with partial splits.
+func saveTransactionAllocations(ctx context.Context, db database.Queryer, transaction *entity.Transaction) error {
+	if transaction.Allocations == nil {
+		return nil
+	}
+	if err := entity.ValidateAllocations(transaction.Allocations); err != nil {
+		return err
+	}
+
+	beginner, ok := db.(database.TxBeginner)
+	if !ok {
+		// Already running inside the caller's transaction
+		return replaceTransactionAllocations(ctx, db, transaction)
+	}
+	return database.WithinTx(ctx, beginner, func(tx database.DBTx) error {
+		return replaceTransactionAllocations(ctx, tx, transaction)
+	})
+}
+
+func replaceTransactionAllocations(ctx context.Context, db database.Queryer, transaction *entity.Transaction) error {
+	if _, err := db.ExecContext(ctx, deleteTransactionAllocationsQuery, transaction.ID); err != nil {
+		return fmt.Errorf("failed to clear transaction allocations: %w", err)
+	}
+
+	now := time.Now()
+	for _, allocation := range transaction.Allocations {
+		if allocation.ID == "" {
+			allocation.ID = uuid.New().String()
+		}
+		allocation.TransactionID = transaction.ID
+
+		_, err := db.ExecContext(ctx, insertTransactionAllocationQuery,
+			allocation.ID,
+			allocation.TransactionID,
+			allocation.CostCenter,
+			allocation.Percentage,
+			now,
+			now,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create transaction allocation: %w", err)
+		}
+
+		allocation.CreatedAt = now
+		allocation.UpdatedAt = now
+	}
+
+	return nil
+}
+
+// loadTransactionAllocations fetches the allocation splits of all given transactions in one query
+func loadTransactionAllocations(ctx context.Context, db database.Queryer, transactions []*entity.Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(transactions))
+	args := make([]interface{}, len(transactions))
+	byID := make(map[string]*entity.Transaction, len(transactions))
+	for i, transaction := range transactions {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = transaction.ID
+		byID[transaction.ID] = transaction
+		transaction.Allocations = make([]*entity.TransactionAllocation, 0)
+	}
+
+	query := fmt.Sprintf(getTransactionAllocationsQueryTemplate, strings.Join(placeholders, ","))
+
+	var allocations []*entity.TransactionAllocation
+	if err := db.SelectContext(ctx, &allocations, query, args...); err != nil {
+		return fmt.Errorf("failed to get transaction allocations: %w", err)
+	}
+
+	for _, allocation := range allocations {
+		if transaction, ok := byID[allocation.TransactionID]; ok {
+			transaction.Allocations = append(transaction.Allocations, allocation)
+		}
+	}
+
+	return nil
+}
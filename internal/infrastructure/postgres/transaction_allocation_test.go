@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/pkg/database"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// allocationDriver records the allocation statements and how the transaction around them ended.
+// Inserting the split of failCostCenter fails.
+type allocationDriver struct {
+	failCostCenter        string
+	statements            []string
+	committed, rolledBack bool
+}
+
+func (d *allocationDriver) Open(string) (driver.Conn, error)             { return d, nil }
+func (d *allocationDriver) Connect(context.Context) (driver.Conn, error) { return d, nil }
+func (d *allocationDriver) Driver() driver.Driver                        { return d }
+func (d *allocationDriver) Prepare(string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("prepare not supported")
+}
+func (d *allocationDriver) Close() error              { return nil }
+func (d *allocationDriver) Begin() (driver.Tx, error) { return d, nil }
+func (d *allocationDriver) Commit() error {
+	d.committed = true
+	return nil
+}
+func (d *allocationDriver) Rollback() error {
+	d.rolledBack = true
+	return nil
+}
+
+func (d *allocationDriver) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	d.statements = append(d.statements, query)
+	if query == insertTransactionAllocationQuery && args[2].Value == d.failCostCenter {
+		return nil, errors.New("insert failed")
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func newAllocationTestDB(t *testing.T, d *allocationDriver) database.DB {
+	t.Helper()
+	db := sql.OpenDB(d)
+	t.Cleanup(func() { db.Close() })
+	return database.NewDB(sqlx.NewDb(db, "postgres"))
+}
+
+func allocatedTransaction(splits map[string]float64) *entity.Transaction {
+	transaction := &entity.Transaction{ID: "transaction-1", Allocations: []*entity.TransactionAllocation{}}
+	for costCenter, percentage := range splits {
+		transaction.Allocations = append(transaction.Allocations, &entity.TransactionAllocation{CostCenter: costCenter, Percentage: percentage})
+	}
+	return transaction
+}
+
+func TestSaveTransactionAllocations(t *testing.T) {
+	t.Run("percentages that do not add up", func(t *testing.T) {
+		d := &allocationDriver{}
+		err := saveTransactionAllocations(context.Background(), newAllocationTestDB(t, d), allocatedTransaction(map[string]float64{"CC-1": 60, "CC-2": 30}))
+		if !errors.Is(err, entity.ErrInvalidAllocation) {
+			t.Fatalf("saveTransactionAllocations() error = %v, want ErrInvalidAllocation", err)
+		}
+		if len(d.statements) != 0 {
+			t.Errorf("statements = %d, want none", len(d.statements))
+		}
+	})
+
+	t.Run("replaces the splits in one transaction", func(t *testing.T) {
+		d := &allocationDriver{}
+		err := saveTransactionAllocations(context.Background(), newAllocationTestDB(t, d), allocatedTransaction(map[string]float64{"CC-1": 60, "CC-2": 40}))
+		if err != nil {
+			t.Fatalf("saveTransactionAllocations() error = %v", err)
+		}
+		if len(d.statements) != 3 {
+			t.Errorf("statements = %d, want a delete and two inserts", len(d.statements))
+		}
+		if !d.committed || d.rolledBack {
+			t.Errorf("committed = %v, rolled back = %v, want a commit", d.committed, d.rolledBack)
+		}
+	})
+
+	t.Run("failed insert rolls back the delete", func(t *testing.T) {
+		d := &allocationDriver{failCostCenter: "CC-2"}
+		err := saveTransactionAllocations(context.Background(), newAllocationTestDB(t, d), allocatedTransaction(map[string]float64{"CC-1": 60, "CC-2": 40}))
+		if err == nil {
+			t.Fatal("saveTransactionAllocations() error = nil, want the insert error")
+		}
+		if d.committed || !d.rolledBack {
+			t.Errorf("committed = %v, rolled back = %v, want a rollback", d.committed, d.rolledBack)
+		}
+	})
+
+	t.Run("caller's transaction", func(t *testing.T) {
+		d := &allocationDriver{}
+		tx, err := newAllocationTestDB(t, d).BeginTx(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("BeginTx() error = %v", err)
+		}
+		if err := saveTransactionAllocations(context.Background(), tx, allocatedTransaction(map[string]float64{"CC-1": 100})); err != nil {
+			t.Fatalf("saveTransactionAllocations() error = %v", err)
+		}
+		if d.committed || d.rolledBack {
+			t.Errorf("committed = %v, rolled back = %v, want the caller's transaction left open", d.committed, d.rolledBack)
+		}
+		tx.Rollback()
+	})
+}
@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/pkg/database"
+)
+
+// SQL queries for transaction attachment operations
+const (
+	insertTransactionAttachmentQuery = `
+		INSERT INTO transaction_attachments (
+			id, transaction_id, file_name, content_type, file_id, storage_url, uploaded_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	getTransactionAttachmentsQuery = `
+		SELECT id, transaction_id, file_name, content_type, file_id, storage_url, uploaded_at
+		FROM transaction_attachments
+		WHERE transaction_id = $1
+		ORDER BY uploaded_at, id
+	`
+
+	getTransactionAttachmentsQueryTemplate = `
+		SELECT id, transaction_id, file_name, content_type, file_id, storage_url, uploaded_at
+		FROM transaction_attachments
+		WHERE transaction_id IN (%s)
+		ORDER BY uploaded_at, id
+	`
+
+	deleteTransactionAttachmentQuery = `
+		DELETE FROM transaction_attachments
+		WHERE id = $1 AND transaction_id = $2
+	`
+)
+
+// AddTransactionAttachment records a receipt file stored for a transaction
+func (r *businessTripTransactionRepository) AddTransactionAttachment(ctx context.Context, attachment *entity.TransactionAttachment) (*entity.TransactionAttachment, error) {
+	if attachment.ID == "" {
+		attachment.ID = uuid.New().String()
+	}
+
+	_, err := r.db.ExecContext(ctx, insertTransactionAttachmentQuery,
+		attachment.ID,
+		attachment.TransactionID,
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.FileID,
+		attachment.StorageURL,
+		attachment.UploadedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// GetTransactionAttachments retrieves the receipt files of a transaction, oldest first
+func (r *businessTripTransactionRepository) GetTransactionAttachments(ctx context.Context, transactionID string) ([]*entity.TransactionAttachment, error) {
+	attachments := make([]*entity.TransactionAttachment, 0)
+	if err := r.db.SelectContext(ctx, &attachments, getTransactionAttachmentsQuery, transactionID); err != nil {
+		return nil, fmt.Errorf("failed to get transaction attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+// DeleteTransactionAttachment removes a receipt record from a transaction.
+// The stored file itself is left in place.
+func (r *businessTripTransactionRepository) DeleteTransactionAttachment(ctx context.Context, transactionID, attachmentID string) error {
+	result, err := r.db.ExecContext(ctx, deleteTransactionAttachmentQuery, attachmentID, transactionID)
+	if err != nil {
+		return fmt.Errorf("failed to delete transaction attachment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entity.ErrAttachmentNotFound
+	}
+
+	return nil
+}
+
+// loadTransactionAttachments fetches the receipt files of all given transactions in one query
+func loadTransactionAttachments(ctx context.Context, db database.Queryer, transactions []*entity.Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(transactions))
+	args := make([]interface{}, len(transactions))
+	byID := make(map[string]*entity.Transaction, len(transactions))
+	for i, transaction := range transactions {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = transaction.ID
+		byID[transaction.ID] = transaction
+		transaction.Attachments = make([]*entity.TransactionAttachment, 0)
+	}
+
+	query := fmt.Sprintf(getTransactionAttachmentsQueryTemplate, strings.Join(placeholders, ","))
+
+	var attachments []*entity.TransactionAttachment
+	if err := db.SelectContext(ctx, &attachments, query, args...); err != nil {
+		return fmt.Errorf("failed to get transaction attachments: %w", err)
+	}
+
+	for _, attachment := range attachments {
+		if transaction, ok := byID[attachment.TransactionID]; ok {
+			transaction.Attachments = append(transaction.Attachments, attachment)
+		}
+	}
+
+	return nil
+}
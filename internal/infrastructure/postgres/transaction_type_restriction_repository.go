@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/pkg/database"
+)
+
+const getAllowedTransactionTypesQuery = `
+	SELECT organization_id, transaction_type, transaction_subtype
+	FROM organization_transaction_types
+	WHERE organization_id = $1
+`
+
+// NewTransactionTypeRestrictionRepository creates a store for per-organization transaction type restrictions
+func NewTransactionTypeRestrictionRepository(db database.Queryer) repository.TransactionTypeRestrictionRepository {
+	return &transactionTypeRestrictionRepository{
+		db: db,
+	}
+}
+
+type transactionTypeRestrictionRepository struct {
+	db database.Queryer
+}
+
+// GetAllowedTransactionTypes returns the transaction types the organization allows
+func (r *transactionTypeRestrictionRepository) GetAllowedTransactionTypes(ctx context.Context, organizationID string) ([]*entity.AllowedTransactionType, error) {
+	var allowed []*entity.AllowedTransactionType
+	if err := r.db.SelectContext(ctx, &allowed, getAllowedTransactionTypesQuery, organizationID); err != nil {
+		return nil, fmt.Errorf("failed to get allowed transaction types: %w", err)
+	}
+
+	return allowed, nil
+}
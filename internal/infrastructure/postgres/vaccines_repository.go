@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 
 	"sandbox/internal/domain/entity"
 	"sandbox/internal/domain/repository"
@@ -224,6 +225,89 @@ func (r *vaccinesRepository) ListMasterVaccinesByType(ctx context.Context, vacci
 	return vaccines, nil
 }
 
+func (r *vaccinesRepository) BulkUpsertMasterVaccines(ctx context.Context, vaccines []*entity.MasterVaccine) ([]repository.MasterVaccineUpsertResult, error) {
+	beginner, ok := r.db.(database.TxBeginner)
+	if !ok {
+		// Already running inside the caller's transaction
+		return r.bulkUpsertMasterVaccines(ctx, r.db, vaccines)
+	}
+
+	var results []repository.MasterVaccineUpsertResult
+	err := database.WithinTx(ctx, beginner, func(tx database.DBTx) error {
+		var err error
+		results, err = r.bulkUpsertMasterVaccines(ctx, tx, vaccines)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (r *vaccinesRepository) bulkUpsertMasterVaccines(ctx context.Context, db database.Queryer, vaccines []*entity.MasterVaccine) ([]repository.MasterVaccineUpsertResult, error) {
+	codeTakenQuery := `
+		SELECT EXISTS (
+			SELECT 1 FROM master_vaccines
+			WHERE vaccine_code = $1 AND LOWER(vaccine_name_en) <> LOWER($2)
+		)
+	`
+
+	// xmax is only zero for a row this statement inserted, which tells a created vaccine from an updated one
+	upsertQuery := `
+		INSERT INTO master_vaccines (
+			id, vaccine_code, vaccine_name_id, vaccine_name_en, description_id, description_en,
+			vaccine_type, is_active, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)
+		ON CONFLICT ((LOWER(vaccine_name_en))) DO UPDATE
+		SET vaccine_code = EXCLUDED.vaccine_code,
+			vaccine_name_id = EXCLUDED.vaccine_name_id,
+			vaccine_name_en = EXCLUDED.vaccine_name_en,
+			description_id = EXCLUDED.description_id,
+			description_en = EXCLUDED.description_en,
+			vaccine_type = EXCLUDED.vaccine_type,
+			is_active = EXCLUDED.is_active,
+			updated_at = EXCLUDED.updated_at
+		RETURNING id, vaccine_code, vaccine_name_id, vaccine_name_en, description_id, description_en,
+			vaccine_type, is_active, created_at, updated_at, (xmax = 0) AS created
+	`
+
+	now := time.Now()
+	results := make([]repository.MasterVaccineUpsertResult, 0, len(vaccines))
+	for _, vaccine := range vaccines {
+		var codeTaken bool
+		if err := db.GetContext(ctx, &codeTaken, codeTakenQuery, vaccine.VaccineCode, vaccine.VaccineNameEN); err != nil {
+			return nil, fmt.Errorf("failed to check master vaccine code: %w", err)
+		}
+		if codeTaken {
+			results = append(results, repository.MasterVaccineUpsertResult{
+				Vaccine: vaccine,
+				Err:     fmt.Errorf("%w: %s", entity.ErrVaccineCodeTaken, vaccine.VaccineCode),
+			})
+			continue
+		}
+
+		var upserted struct {
+			entity.MasterVaccine
+			Created bool `db:"created"`
+		}
+		err := db.GetContext(ctx, &upserted, upsertQuery,
+			uuid.New().String(), vaccine.VaccineCode, vaccine.VaccineNameID, vaccine.VaccineNameEN,
+			vaccine.DescriptionID, vaccine.DescriptionEN, vaccine.VaccineType, vaccine.IsActive, now,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upsert master vaccine %s: %w", vaccine.VaccineNameEN, err)
+		}
+
+		results = append(results, repository.MasterVaccineUpsertResult{
+			Vaccine: &upserted.MasterVaccine,
+			Created: upserted.Created,
+		})
+	}
+
+	return results, nil
+}
+
 // Country operations
 
 func (r *vaccinesRepository) CreateCountry(ctx context.Context, country *entity.Country) (*entity.Country, error) {
@@ -399,3 +483,224 @@ func (r *vaccinesRepository) ListActiveCountries(ctx context.Context) ([]*entity
 
 	return countries, nil
 }
+
+// Alias operations
+
+func (r *vaccinesRepository) ListCountryAliases(ctx context.Context) ([]*entity.CountryAlias, error) {
+	query := `
+		SELECT alias, country_name, created_at, updated_at
+		FROM country_aliases
+		ORDER BY alias ASC
+	`
+
+	var aliases []*entity.CountryAlias
+	err := r.db.SelectContext(ctx, &aliases, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list country aliases: %w", err)
+	}
+
+	return aliases, nil
+}
+
+func (r *vaccinesRepository) UpsertCountryAlias(ctx context.Context, alias *entity.CountryAlias) (*entity.CountryAlias, error) {
+	query := `
+		INSERT INTO country_aliases (alias, country_name, created_at, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (alias) DO UPDATE
+		SET country_name = EXCLUDED.country_name, updated_at = EXCLUDED.updated_at
+		RETURNING alias, country_name, created_at, updated_at
+	`
+
+	var upserted entity.CountryAlias
+	err := r.db.GetContext(ctx, &upserted, query, alias.Alias, alias.CountryName, alias.CreatedAt, alias.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert country alias: %w", err)
+	}
+
+	return &upserted, nil
+}
+
+func (r *vaccinesRepository) ListVaccineAliases(ctx context.Context) ([]*entity.VaccineAlias, error) {
+	query := `
+		SELECT alias, vaccine_name, created_at, updated_at
+		FROM vaccine_aliases
+		ORDER BY alias ASC
+	`
+
+	var aliases []*entity.VaccineAlias
+	err := r.db.SelectContext(ctx, &aliases, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vaccine aliases: %w", err)
+	}
+
+	return aliases, nil
+}
+
+func (r *vaccinesRepository) UpsertVaccineAlias(ctx context.Context, alias *entity.VaccineAlias) (*entity.VaccineAlias, error) {
+	query := `
+		INSERT INTO vaccine_aliases (alias, vaccine_name, created_at, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (alias) DO UPDATE
+		SET vaccine_name = EXCLUDED.vaccine_name, updated_at = EXCLUDED.updated_at
+		RETURNING alias, vaccine_name, created_at, updated_at
+	`
+
+	var upserted entity.VaccineAlias
+	err := r.db.GetContext(ctx, &upserted, query, alias.Alias, alias.VaccineName, alias.CreatedAt, alias.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert vaccine alias: %w", err)
+	}
+
+	return &upserted, nil
+}
+
+// CDC recommendation cache operations
+
+func (r *vaccinesRepository) GetCDCRecommendationCache(ctx context.Context, countryCode, language string) (*entity.CDCRecommendationCache, error) {
+	query := `
+		SELECT country_code, language, recommendation, fetched_at
+		FROM cdc_recommendation_cache
+		WHERE country_code = $1 AND language = $2
+	`
+
+	var cache entity.CDCRecommendationCache
+	err := r.db.GetContext(ctx, &cache, query, countryCode, language)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get cached CDC recommendation: %w", err)
+	}
+
+	return &cache, nil
+}
+
+func (r *vaccinesRepository) UpsertCDCRecommendationCache(ctx context.Context, cache *entity.CDCRecommendationCache) error {
+	query := `
+		INSERT INTO cdc_recommendation_cache (country_code, language, recommendation, fetched_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (country_code, language) DO UPDATE
+		SET recommendation = EXCLUDED.recommendation, fetched_at = EXCLUDED.fetched_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query, cache.CountryCode, cache.Language, cache.Recommendation, cache.FetchedAt)
+	if err != nil {
+		return fmt.Errorf("failed to cache CDC recommendation: %w", err)
+	}
+
+	return nil
+}
+
+// vaccineRecommendationHistoryRow is a vaccine_recommendation_history row; the vaccine IDs are Postgres arrays
+type vaccineRecommendationHistoryRow struct {
+	ID                    string         `db:"id"`
+	CountryCode           string         `db:"country_code"`
+	CountryName           string         `db:"country_name"`
+	Language              string         `db:"language"`
+	BusinessTripID        *string        `db:"business_trip_id"`
+	AssigneeID            *string        `db:"assignee_id"`
+	RequiredVaccineIDs    pq.StringArray `db:"required_vaccine_ids"`
+	RecommendedVaccineIDs pq.StringArray `db:"recommended_vaccine_ids"`
+	ConsiderVaccineIDs    pq.StringArray `db:"consider_vaccine_ids"`
+	MalariaRisk           string         `db:"malaria_risk"`
+	MalariaProphylaxis    string         `db:"malaria_prophylaxis"`
+	GeneratedAt           time.Time      `db:"generated_at"`
+	CreatedAt             time.Time      `db:"created_at"`
+}
+
+func (r *vaccinesRepository) CreateVaccineRecommendationHistory(ctx context.Context, history *entity.VaccineRecommendationHistory) error {
+	query := `
+		INSERT INTO vaccine_recommendation_history (
+			id, country_code, country_name, language, business_trip_id, assignee_id,
+			required_vaccine_ids, recommended_vaccine_ids, consider_vaccine_ids,
+			malaria_risk, malaria_prophylaxis, generated_at, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+
+	if history.ID == "" {
+		history.ID = uuid.New().String()
+	}
+	if history.CreatedAt.IsZero() {
+		history.CreatedAt = time.Now()
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		history.ID,
+		history.CountryCode,
+		history.CountryName,
+		history.Language,
+		history.BusinessTripID,
+		history.AssigneeID,
+		pq.StringArray(nonNilIDs(history.RequiredVaccineIDs)),
+		pq.StringArray(nonNilIDs(history.RecommendedVaccineIDs)),
+		pq.StringArray(nonNilIDs(history.ConsiderVaccineIDs)),
+		history.MalariaRisk,
+		history.MalariaProphylaxis,
+		history.GeneratedAt,
+		history.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record vaccine recommendation history: %w", err)
+	}
+
+	return nil
+}
+
+func (r *vaccinesRepository) ListVaccineRecommendationHistory(ctx context.Context, filter repository.VaccineRecommendationHistoryFilter) ([]*entity.VaccineRecommendationHistory, error) {
+	query := `
+		SELECT
+			id, country_code, country_name, language, business_trip_id, assignee_id,
+			required_vaccine_ids, recommended_vaccine_ids, consider_vaccine_ids,
+			malaria_risk, malaria_prophylaxis, generated_at, created_at
+		FROM vaccine_recommendation_history
+		WHERE ($1::uuid IS NULL OR business_trip_id = $1::uuid)
+			AND ($2::uuid IS NULL OR assignee_id = $2::uuid)
+			AND ($3::text IS NULL OR country_code = $3::text)
+		ORDER BY created_at DESC, id
+		LIMIT $4
+	`
+
+	optional := func(value string) sql.NullString {
+		return sql.NullString{String: value, Valid: value != ""}
+	}
+
+	var rows []vaccineRecommendationHistoryRow
+	err := r.db.SelectContext(ctx, &rows, query,
+		optional(filter.BusinessTripID),
+		optional(filter.AssigneeID),
+		optional(filter.CountryCode),
+		filter.Limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vaccine recommendation history: %w", err)
+	}
+
+	history := make([]*entity.VaccineRecommendationHistory, 0, len(rows))
+	for _, row := range rows {
+		history = append(history, &entity.VaccineRecommendationHistory{
+			ID:                    row.ID,
+			CountryCode:           row.CountryCode,
+			CountryName:           row.CountryName,
+			Language:              row.Language,
+			BusinessTripID:        row.BusinessTripID,
+			AssigneeID:            row.AssigneeID,
+			RequiredVaccineIDs:    row.RequiredVaccineIDs,
+			RecommendedVaccineIDs: row.RecommendedVaccineIDs,
+			ConsiderVaccineIDs:    row.ConsiderVaccineIDs,
+			MalariaRisk:           row.MalariaRisk,
+			MalariaProphylaxis:    row.MalariaProphylaxis,
+			GeneratedAt:           row.GeneratedAt,
+			CreatedAt:             row.CreatedAt,
+		})
+	}
+
+	return history, nil
+}
+
+// nonNilIDs stores an empty array instead of NULL for a recommendation without vaccines of a kind
+func nonNilIDs(ids []string) []string {
+	if ids == nil {
+		return []string{}
+	}
+	return ids
+}
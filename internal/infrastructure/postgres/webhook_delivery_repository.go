@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/pkg/database"
+)
+
+// NewWebhookDeliveryRepository creates a store for the webhook delivery log
+func NewWebhookDeliveryRepository(db database.Queryer) repository.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{
+		db: db,
+	}
+}
+
+type webhookDeliveryRepository struct {
+	db database.Queryer
+}
+
+// Create inserts one delivery attempt
+func (r *webhookDeliveryRepository) Create(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (
+			id, event_id, event_type, business_trip_id, url, payload, attempt, status_code, error, succeeded, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	_, err := r.db.ExecContext(ctx, query, delivery.ID, delivery.EventID, delivery.EventType, delivery.BusinessTripID,
+		delivery.URL, delivery.Payload, delivery.Attempt, delivery.StatusCode, delivery.Error, delivery.Succeeded, delivery.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook delivery: %w", err)
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"sandbox/internal/domain/entity"
 	"sandbox/internal/domain/repository"
+	"sandbox/pkg/database"
 	"sandbox/pkg/pagination"
 
 	"github.com/google/uuid"
@@ -15,11 +16,11 @@ import (
 )
 
 type workPaperSignatureRepository struct {
-	db *sqlx.DB
+	db database.Queryer
 }
 
 // NewWorkPaperSignatureRepository creates a new work paper signature repository
-func NewWorkPaperSignatureRepository(db *sqlx.DB) repository.WorkPaperSignatureRepository {
+func NewWorkPaperSignatureRepository(db database.Queryer) repository.WorkPaperSignatureRepository {
 	return &workPaperSignatureRepository{
 		db: db,
 	}
@@ -36,7 +37,7 @@ func (r *workPaperSignatureRepository) Create(ctx context.Context, signature *en
 			:signature_data, :signature_type, :status, :notes, :created_at, :updated_at
 		)`
 
-	_, err := r.db.NamedExecContext(ctx, query, signature)
+	err := r.namedExec(ctx, query, signature)
 	if err != nil {
 		return fmt.Errorf("failed to create work paper signature: %w", err)
 	}
@@ -235,7 +236,7 @@ func (r *workPaperSignatureRepository) Update(ctx context.Context, signature *en
 			notes = :notes, updated_at = :updated_at
 		WHERE id = :id AND deleted_at IS NULL`
 
-	_, err := r.db.NamedExecContext(ctx, query, signature)
+	err := r.namedExec(ctx, query, signature)
 	if err != nil {
 		return fmt.Errorf("failed to update work paper signature: %w", err)
 	}
@@ -314,3 +315,23 @@ func (r *workPaperSignatureRepository) GetRecentSignatures(ctx context.Context,
 
 	return signatures, nil
 }
+
+// WithTransaction returns a repository that runs its queries in tx when tx is a database.DBTx,
+// and the repository itself otherwise
+func (r *workPaperSignatureRepository) WithTransaction(tx interface{}) repository.WorkPaperSignatureRepository {
+	dbTx, ok := tx.(database.DBTx)
+	if !ok {
+		return r
+	}
+	return &workPaperSignatureRepository{db: dbTx}
+}
+
+// namedExec binds the named parameters of query from arg, so it also runs inside a transaction
+func (r *workPaperSignatureRepository) namedExec(ctx context.Context, query string, arg interface{}) error {
+	boundQuery, args, err := sqlx.Named(query, arg)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, r.db.Rebind(boundQuery), args...)
+	return err
+}
@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 
 	"sandbox/internal/domain/entity"
+	"sandbox/pkg/logging"
 )
 
 type Client struct {
@@ -63,12 +65,10 @@ type AuthResponse struct {
 
 func NewClient(apiKey, apiSecret string) *Client {
 	return &Client{
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		apiKey:    apiKey,
-		apiSecret: apiSecret,
-		baseURL:   "https://api.zoom.us/v2",
+		httpClient: logging.NewHTTPClient("zoom", 30*time.Second),
+		apiKey:     apiKey,
+		apiSecret:  apiSecret,
+		baseURL:    "https://api.zoom.us/v2",
 	}
 }
 
@@ -168,3 +168,48 @@ func (c *Client) CreateZoomMeeting(ctx context.Context, meeting entity.Meeting)
 
 	return &result, nil
 }
+
+// GetZoomMeeting looks up a meeting by its Zoom meeting ID. It returns entity.ErrMeetingNotFound when
+// Zoom does not know the meeting.
+func (c *Client) GetZoomMeeting(ctx context.Context, meetingID string) (*entity.Meeting, error) {
+	accessToken, err := c.getAccessToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/meetings/%s", c.baseURL, url.PathEscape(meetingID))
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get meeting: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", entity.ErrMeetingNotFound, meetingID)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Zoom API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var zoomResp CreateMeetingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&zoomResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &entity.Meeting{
+		ID:       fmt.Sprintf("%d", zoomResp.ID),
+		Title:    zoomResp.Topic,
+		JoinURL:  zoomResp.JoinURL,
+		Duration: zoomResp.Duration,
+		Timezone: zoomResp.Timezone,
+		Password: zoomResp.Password,
+	}, nil
+}
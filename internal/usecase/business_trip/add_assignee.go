@@ -17,15 +17,23 @@ type AddAssigneeUseCase struct {
 	transactionRepo         repository.BusinessTripTransactionRepository
 	userService             *service.UserService
 	db                      database.DB
+	duplicates              *service.DuplicateTransactionDetector
+	typePolicy              *service.TransactionTypePolicy
+	amountCaps              entity.TransactionAmountCaps
+	baseCurrency            string
 }
 
-func NewAddAssigneeUseCase(businessTripRepo repository.BusinessTripRepository, assigneeRepo repository.AssigneeRepository, transactionRepo repository.BusinessTripTransactionRepository, userService *service.UserService, db database.DB) *AddAssigneeUseCase {
+func NewAddAssigneeUseCase(businessTripRepo repository.BusinessTripRepository, assigneeRepo repository.AssigneeRepository, transactionRepo repository.BusinessTripTransactionRepository, userService *service.UserService, db database.DB, duplicates *service.DuplicateTransactionDetector, typePolicy *service.TransactionTypePolicy, amountCaps entity.TransactionAmountCaps, baseCurrency string) *AddAssigneeUseCase {
 	return &AddAssigneeUseCase{
 		businessTripRepo:        businessTripRepo,
 		assigneeRepo:            assigneeRepo,
 		transactionRepo:         transactionRepo,
 		userService:             userService,
 		db:                      db,
+		duplicates:              duplicates,
+		typePolicy:              typePolicy,
+		amountCaps:              amountCaps,
+		baseCurrency:            baseCurrency,
 	}
 }
 
@@ -48,35 +56,43 @@ func (uc *AddAssigneeUseCase) Execute(ctx context.Context, businessTripID string
 		employeeNumber = req.EmployeeID
 	}
 
-	userData, err := uc.userService.GetSingleUserDataByEmployeeID(ctx, employeeNumber)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch user data for employee number %s: %w", employeeNumber, err)
-	}
-
-	// Create assignee with data from external API
-	assignee := &entity.Assignee{
-		Name:           userData.Name,
-		SPDNumber:      req.SPDNumber,
-		EmployeeID:     userData.EmployeeID,
-		EmployeeName:   userData.Name,
-		EmployeeNumber: userData.EmployeeNumber,
-		Position:       req.Position, // Keep position from request as it might be specific to the trip
-		Rank:           req.Rank,     // Keep rank from request as it might be specific to the trip
+	assignee, identityWarning := uc.assigneeFromIdentity(ctx, employeeNumber, req)
+	if assignee.Position == "" || assignee.Rank == "" {
+		return nil, entity.ErrAssigneeDetailsBlank
 	}
 
 	for _, txReq := range req.Transactions {
-		transaction := &entity.Transaction{
-			Name:            txReq.Name,
-			Type:            entity.TransactionType(txReq.Type),
-			Subtype:         entity.TransactionSubtype(txReq.Subtype),
-			Amount:          txReq.Amount,
-			TotalNight:      txReq.TotalNight,
-			Description:     txReq.Description,
-			TransportDetail: txReq.TransportDetail,
+		transaction, err := entity.NewTransaction(
+			txReq.Name,
+			entity.TransactionType(txReq.Type),
+			entity.TransactionSubtype(txReq.Subtype),
+			entity.TransactionDirection(txReq.Direction),
+			txReq.Amount,
+			txReq.Amount, // Will be calculated in NewTransaction
+			txReq.TotalNight,
+			txReq.TotalDays,
+			txReq.Description,
+			txReq.TransportDetail,
+			uc.amountCaps,
+			txReq.AllowLargeAmount,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := transaction.SetCurrency(txReq.Currency, txReq.ExchangeRate, uc.baseCurrency); err != nil {
+			return nil, err
 		}
 		assignee.Transactions = append(assignee.Transactions, transaction)
 	}
 
+	if err := assignee.ValidateCredits(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.typePolicy.CheckTransactions(ctx, businessTrip, assignee.Transactions...); err != nil {
+		return nil, err
+	}
+
 	// Set business trip ID before creating
 	assignee.BusinessTripID = businessTripID
 
@@ -105,17 +121,61 @@ func (uc *AddAssigneeUseCase) Execute(ctx context.Context, businessTripID string
 	}
 
 	return &AssigneeResponse{
-		ID:             createdAssignee.GetID(),
-		Name:           createdAssignee.GetName(),
-		SPDNumber:      createdAssignee.GetSPDNumber(),
-		EmployeeID:     createdAssignee.GetEmployeeID(),
-		EmployeeName:   createdAssignee.GetEmployeeName(),
-		EmployeeNumber: createdAssignee.GetEmployeeNumber(),
-		Position:       createdAssignee.GetPosition(),
-		Rank:           createdAssignee.GetRank(),
-		TotalCost:      createdAssignee.GetTotalCost(),
-		Transactions:   []TransactionResponse{}, // Empty for now
-		CreatedAt:      createdAssignee.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:      createdAssignee.UpdatedAt.Format(time.RFC3339),
+		ID:              createdAssignee.GetID(),
+		Name:            createdAssignee.GetName(),
+		SPDNumber:       createdAssignee.GetSPDNumber(),
+		EmployeeID:      createdAssignee.GetEmployeeID(),
+		EmployeeName:    createdAssignee.GetEmployeeName(),
+		EmployeeNumber:  createdAssignee.GetEmployeeNumber(),
+		Position:        createdAssignee.GetPosition(),
+		Rank:            createdAssignee.GetRank(),
+		TotalCost:       createdAssignee.GetTotalCost(),
+		Transactions:    []TransactionResponse{}, // Empty for now
+		CreatedAt:       createdAssignee.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:       createdAssignee.UpdatedAt.Format(time.RFC3339),
+		Warnings:        uc.duplicates.DetectInAssignee(assignee),
+		IdentityWarning: identityWarning,
 	}, nil
 }
+
+// assigneeFromIdentity builds the assignee from the identity service's record of the employee. Name,
+// employee ID, employee name, position and rank the caller left blank are filled in from that record;
+// values the caller gave are kept. When the lookup fails the assignee is built from the request alone, and the returned warning
+// says so.
+func (uc *AddAssigneeUseCase) assigneeFromIdentity(ctx context.Context, employeeNumber string, req *AssigneeRequest) (*entity.Assignee, string) {
+	assignee := &entity.Assignee{
+		Name:           req.Name,
+		SPDNumber:      req.SPDNumber,
+		EmployeeID:     req.EmployeeID,
+		EmployeeName:   req.EmployeeName,
+		EmployeeNumber: employeeNumber,
+		Position:       req.Position,
+		Rank:           req.Rank,
+	}
+
+	userData, err := uc.userService.GetSingleUserDataByEmployeeID(ctx, employeeNumber)
+	if err != nil {
+		if assignee.EmployeeName == "" {
+			assignee.EmployeeName = req.Name
+		}
+		return assignee, fmt.Sprintf("employee number %s could not be looked up, the assignee was saved with the details in the request: %v", employeeNumber, err)
+	}
+
+	if assignee.Name == "" {
+		assignee.Name = userData.Name
+	}
+	if assignee.EmployeeID == "" {
+		assignee.EmployeeID = userData.EmployeeID
+	}
+	assignee.EmployeeNumber = userData.EmployeeNumber
+	if assignee.EmployeeName == "" {
+		assignee.EmployeeName = userData.Name
+	}
+	if assignee.Position == "" {
+		assignee.Position = userData.Position
+	}
+	if assignee.Rank == "" {
+		assignee.Rank = userData.Rank
+	}
+	return assignee, ""
+}
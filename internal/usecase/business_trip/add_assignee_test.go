@@ -0,0 +1,130 @@
+package business_trip
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/internal/domain/service"
+	"sandbox/internal/infrastructure"
+	"sandbox/pkg/database"
+)
+
+// stubIdentityService knows a single employee, or fails every lookup when err is set
+type stubIdentityService struct {
+	infrastructure.IdentityServiceInterface
+	user *infrastructure.User
+	err  error
+}
+
+func (s *stubIdentityService) GetSingleUserByEmployeeID(context.Context, string) (*infrastructure.User, error) {
+	return s.user, s.err
+}
+
+// stubPassthroughDB runs transactions without a database
+type stubPassthroughDB struct {
+	database.DB
+}
+
+func (db *stubPassthroughDB) WithTransaction(ctx context.Context, fn func(ctx context.Context, tx database.DBTx) error) error {
+	return fn(ctx, nil)
+}
+
+// stubCreatedAssigneeRepository records the assignee it was asked to create
+type stubCreatedAssigneeRepository struct {
+	repository.AssigneeRepository
+	created *entity.Assignee
+}
+
+func (r *stubCreatedAssigneeRepository) Create(_ context.Context, assignee *entity.Assignee) (*entity.Assignee, error) {
+	r.created = assignee
+	return assignee, nil
+}
+
+func TestAddAssigneeAutofillsFromIdentity(t *testing.T) {
+	employee := &infrastructure.User{
+		ID:         "user-1",
+		EmployeeID: "198001012005011001",
+		FirstName:  "Budi",
+		LastName:   "Santoso",
+		Position:   "Auditor Ahli Muda",
+		Rank:       "III/c",
+	}
+
+	tests := []struct {
+		name        string
+		identity    *stubIdentityService
+		req         AssigneeRequest
+		want        entity.Assignee
+		wantWarning bool
+		wantErr     error
+	}{
+		{
+			name:     "blank fields are filled in",
+			identity: &stubIdentityService{user: employee},
+			req:      AssigneeRequest{Name: "Budi", SPDNumber: "SPD-1", EmployeeNumber: employee.EmployeeID},
+			want: entity.Assignee{Name: "Budi", EmployeeID: "user-1", EmployeeName: "Budi Santoso",
+				EmployeeNumber: employee.EmployeeID, Position: "Auditor Ahli Muda", Rank: "III/c"},
+		},
+		{
+			name:     "given fields are kept",
+			identity: &stubIdentityService{user: employee},
+			req: AssigneeRequest{Name: "Pak Budi", SPDNumber: "SPD-1", EmployeeID: "user-given", EmployeeNumber: employee.EmployeeID,
+				EmployeeName: "Budi S.", Position: "Ketua Tim", Rank: "IV/a"},
+			want: entity.Assignee{Name: "Pak Budi", EmployeeID: "user-given", EmployeeName: "Budi S.",
+				EmployeeNumber: employee.EmployeeID, Position: "Ketua Tim", Rank: "IV/a"},
+		},
+		{
+			name:     "failed lookup keeps the request",
+			identity: &stubIdentityService{err: errors.New("identity service unavailable")},
+			req: AssigneeRequest{Name: "Budi", SPDNumber: "SPD-1", EmployeeID: "user-1", EmployeeNumber: employee.EmployeeID,
+				Position: "Ketua Tim", Rank: "IV/a"},
+			want: entity.Assignee{Name: "Budi", EmployeeID: "user-1", EmployeeName: "Budi",
+				EmployeeNumber: employee.EmployeeID, Position: "Ketua Tim", Rank: "IV/a"},
+			wantWarning: true,
+		},
+		{
+			name:     "failed lookup without position and rank",
+			identity: &stubIdentityService{err: errors.New("identity service unavailable")},
+			req:      AssigneeRequest{Name: "Budi", SPDNumber: "SPD-1", EmployeeNumber: employee.EmployeeID},
+			wantErr:  entity.ErrAssigneeDetailsBlank,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trip := newTestBusinessTrip(t)
+			assigneeRepo := &stubCreatedAssigneeRepository{}
+			uc := NewAddAssigneeUseCase(&stubBusinessTripRepository{trip: trip}, assigneeRepo, nil,
+				service.NewUserService(tt.identity), &stubPassthroughDB{},
+				service.NewDuplicateTransactionDetector(service.DuplicateTransactionConfig{}),
+				service.NewTransactionTypePolicy(nil), nil, entity.DefaultBaseCurrency)
+
+			if err := tt.req.ValidateForAutofill(); err != nil {
+				t.Fatalf("ValidateForAutofill() error = %v", err)
+			}
+			response, err := uc.Execute(context.Background(), trip.ID, &tt.req)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			got := assigneeRepo.created
+			if got.Name != tt.want.Name || got.EmployeeID != tt.want.EmployeeID || got.EmployeeName != tt.want.EmployeeName ||
+				got.EmployeeNumber != tt.want.EmployeeNumber || got.Position != tt.want.Position || got.Rank != tt.want.Rank {
+				t.Errorf("created assignee = %+v, want %+v", got, tt.want)
+			}
+			if hasWarning := strings.Contains(response.IdentityWarning, "could not be looked up"); hasWarning != tt.wantWarning {
+				t.Errorf("IdentityWarning = %q, want a warning: %v", response.IdentityWarning, tt.wantWarning)
+			}
+		})
+	}
+}
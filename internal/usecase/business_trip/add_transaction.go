@@ -6,21 +6,30 @@ import (
 
 	"sandbox/internal/domain/entity"
 	"sandbox/internal/domain/repository"
+	"sandbox/internal/domain/service"
 )
 
 type AddTransactionUseCase struct {
 	businessTripRepo repository.BusinessTripRepository
 	assigneeRepo     repository.AssigneeRepository
+	typePolicy       *service.TransactionTypePolicy
+	amountCaps       entity.TransactionAmountCaps
+	baseCurrency     string
 }
 
-func NewAddTransactionUseCase(businessTripRepo repository.BusinessTripRepository, assigneeRepo repository.AssigneeRepository) *AddTransactionUseCase {
+func NewAddTransactionUseCase(businessTripRepo repository.BusinessTripRepository, assigneeRepo repository.AssigneeRepository, typePolicy *service.TransactionTypePolicy, amountCaps entity.TransactionAmountCaps, baseCurrency string) *AddTransactionUseCase {
 	return &AddTransactionUseCase{
 		businessTripRepo: businessTripRepo,
 		assigneeRepo:     assigneeRepo,
+		typePolicy:       typePolicy,
+		amountCaps:       amountCaps,
+		baseCurrency:     baseCurrency,
 	}
 }
 
-func (uc *AddTransactionUseCase) Execute(ctx context.Context, assigneeID string, req TransactionRequest) (*TransactionResponse, error) {
+// Execute adds a transaction to the assignee. allowLockedTrip lets an administrator add it even
+// though the business trip is completed or canceled.
+func (uc *AddTransactionUseCase) Execute(ctx context.Context, assigneeID string, req TransactionRequest, allowLockedTrip bool) (*TransactionResponse, error) {
 	assignee, err := uc.assigneeRepo.GetAssigneeByID(ctx, assigneeID)
 	if err != nil {
 		return nil, err
@@ -29,15 +38,52 @@ func (uc *AddTransactionUseCase) Execute(ctx context.Context, assigneeID string,
 		return nil, entity.ErrAssigneeNotFound
 	}
 
-	transaction := &entity.Transaction{
-		Name:            req.Name,
-		Type:            entity.TransactionType(req.Type),
-		Subtype:         entity.TransactionSubtype(req.Subtype),
-		Amount:          req.Amount,
-		TotalNight:      req.TotalNight,
-		Description:     req.Description,
-		TransportDetail: req.TransportDetail,
-		AssigneeID:      assigneeID,
+	businessTrip, err := uc.businessTripRepo.GetByID(ctx, assignee.BusinessTripID)
+	if err != nil {
+		return nil, err
+	}
+	if businessTrip == nil {
+		return nil, entity.ErrBusinessTripNotFound
+	}
+	if err := ensureTripEditable(businessTrip, allowLockedTrip); err != nil {
+		return nil, err
+	}
+
+	transaction, err := entity.NewTransaction(
+		req.Name,
+		entity.TransactionType(req.Type),
+		entity.TransactionSubtype(req.Subtype),
+		entity.TransactionDirection(req.Direction),
+		req.Amount,
+		req.Amount, // Will be calculated in NewTransaction
+		req.TotalNight,
+		req.TotalDays,
+		req.Description,
+		req.TransportDetail,
+		uc.amountCaps,
+		req.AllowLargeAmount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := transaction.SetCurrency(req.Currency, req.ExchangeRate, uc.baseCurrency); err != nil {
+		return nil, err
+	}
+	transaction.AssigneeID = assigneeID
+
+	if err := uc.typePolicy.CheckTransactions(ctx, businessTrip, transaction); err != nil {
+		return nil, err
+	}
+
+	// A new debit cannot leave a credit without its cost
+	if transaction.IsCredit() {
+		if err := validateAssigneeCredits(ctx, uc.businessTripRepo, assignee, transaction); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := applyAllocations(transaction, req.Allocations); err != nil {
+		return nil, err
 	}
 
 	createdTransaction, err := uc.businessTripRepo.CreateTransaction(ctx, transaction)
@@ -50,12 +96,18 @@ func (uc *AddTransactionUseCase) Execute(ctx context.Context, assigneeID string,
 		Name:            createdTransaction.GetName(),
 		Type:            string(createdTransaction.GetType()),
 		Subtype:         string(createdTransaction.GetSubtype()),
+		Direction:       string(createdTransaction.GetDirection()),
 		Amount:          createdTransaction.GetAmount(),
 		TotalNight:      createdTransaction.GetTotalNight(),
+		TotalDays:       createdTransaction.GetTotalDays(),
 		Subtotal:        createdTransaction.GetSubtotal(),
 		Description:     createdTransaction.GetDescription(),
 		TransportDetail: createdTransaction.GetTransportDetail(),
 		CreatedAt:       createdTransaction.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:       createdTransaction.UpdatedAt.Format(time.RFC3339),
+		Currency:        createdTransaction.Currency,
+		ExchangeRate:    createdTransaction.GetExchangeRate(),
+		BaseAmount:      createdTransaction.BaseAmount(),
+		Allocations:     toAllocationResponses(createdTransaction),
 	}, nil
 }
@@ -0,0 +1,102 @@
+package business_trip
+
+import (
+	"context"
+	"fmt"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/internal/domain/service"
+)
+
+// AttachmentFile is an uploaded receipt file read from the request
+type AttachmentFile struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// AddTransactionAttachmentRequest represents the receipts to attach to a transaction
+type AddTransactionAttachmentRequest struct {
+	AssigneeID    string
+	TransactionID string
+	Files         []AttachmentFile
+}
+
+func (r AddTransactionAttachmentRequest) Validate() error {
+	if r.AssigneeID == "" {
+		return fmt.Errorf("assignee ID is required")
+	}
+	if r.TransactionID == "" {
+		return fmt.Errorf("transaction ID is required")
+	}
+	if len(r.Files) == 0 {
+		return fmt.Errorf("%w: at least one file is required", entity.ErrInvalidAttachment)
+	}
+	for _, file := range r.Files {
+		if err := entity.ValidateAttachmentFile(file.FileName, file.ContentType, len(file.Content)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddTransactionAttachmentUseCase uploads receipts to the drive and attaches them to a transaction
+type AddTransactionAttachmentUseCase struct {
+	transactionRepo repository.BusinessTripTransactionRepository
+	driveService    service.DriveService
+	folderID        string
+}
+
+// NewAddTransactionAttachmentUseCase creates a new instance of AddTransactionAttachmentUseCase.
+// folderID is the drive folder receipts are uploaded to.
+func NewAddTransactionAttachmentUseCase(transactionRepo repository.BusinessTripTransactionRepository, driveService service.DriveService, folderID string) *AddTransactionAttachmentUseCase {
+	return &AddTransactionAttachmentUseCase{
+		transactionRepo: transactionRepo,
+		driveService:    driveService,
+		folderID:        folderID,
+	}
+}
+
+func (uc *AddTransactionAttachmentUseCase) Execute(ctx context.Context, req AddTransactionAttachmentRequest) ([]AttachmentResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := getAssigneeTransaction(ctx, uc.transactionRepo, req.AssigneeID, req.TransactionID); err != nil {
+		return nil, err
+	}
+
+	attachments := make([]*entity.TransactionAttachment, 0, len(req.Files))
+	for _, file := range req.Files {
+		driveFile, err := uc.driveService.UploadFile(ctx, uc.folderID, file.FileName, file.ContentType, file.Content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to store %s: %w", file.FileName, err)
+		}
+
+		attachment, err := entity.NewTransactionAttachment(req.TransactionID, file.FileName, file.ContentType, driveFile.ID, driveFile.URL)
+		if err != nil {
+			return nil, err
+		}
+
+		attachment, err = uc.transactionRepo.AddTransactionAttachment(ctx, attachment)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	return toAttachmentResponses(attachments), nil
+}
+
+// getAssigneeTransaction loads a transaction and checks that it belongs to the assignee
+func getAssigneeTransaction(ctx context.Context, transactionRepo repository.BusinessTripTransactionRepository, assigneeID, transactionID string) (*entity.Transaction, error) {
+	transaction, err := transactionRepo.GetTransactionByID(ctx, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	if transaction == nil || transaction.AssigneeID != assigneeID {
+		return nil, entity.ErrTransactionNotFound
+	}
+	return transaction, nil
+}
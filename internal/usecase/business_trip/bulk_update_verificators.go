@@ -0,0 +1,247 @@
+package business_trip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/internal/domain/service"
+	"sandbox/pkg/database"
+)
+
+// BulkUpdateVerificatorsRequest represents the request to approve or reject several verificators at once
+type BulkUpdateVerificatorsRequest struct {
+	VerificatorIDs    []string `json:"verificator_ids"`
+	Status            string   `json:"status"`             // "approved" or "rejected"
+	VerificationNotes string   `json:"verification_notes"` // Shared notes applied to every verificator
+	BusinessTripID    string   `json:"business_trip_id"`   // Optional scope, all IDs must belong to this trip
+
+	// ChangedBy is the user ID recorded in the verificator status history
+	ChangedBy string `json:"-"`
+}
+
+func (r BulkUpdateVerificatorsRequest) Validate() error {
+	if len(r.VerificatorIDs) == 0 {
+		return fmt.Errorf("at least one verificator ID is required")
+	}
+
+	validStatuses := map[string]bool{
+		"approved": true,
+		"rejected": true,
+	}
+	if !validStatuses[r.Status] {
+		return fmt.Errorf("status must be one of: approved, rejected")
+	}
+
+	return nil
+}
+
+// BulkUpdateVerificatorResult represents the outcome for a single verificator ID
+type BulkUpdateVerificatorResult struct {
+	VerificatorID  string `json:"verificator_id"`
+	BusinessTripID string `json:"business_trip_id,omitempty"`
+	// BusinessTripStatus is the status of the trip after the update, which moves it to ongoing once every
+	// verificator approved or back to draft once any rejected
+	BusinessTripStatus string `json:"business_trip_status,omitempty"`
+	Success            bool   `json:"success"`
+	Reason             string `json:"reason,omitempty"`
+}
+
+// BulkUpdateVerificatorsResponse represents the response after a bulk update
+type BulkUpdateVerificatorsResponse struct {
+	Status       string                         `json:"status"`
+	UpdatedCount int                            `json:"updated_count"`
+	SkippedCount int                            `json:"skipped_count"`
+	Results      []*BulkUpdateVerificatorResult `json:"results"`
+}
+
+type BulkUpdateVerificatorsUseCase struct {
+	businessTripRepo repository.BusinessTripRepository
+	db               database.DB
+	webhooks         *service.BusinessTripWebhookDispatcher
+}
+
+func NewBulkUpdateVerificatorsUseCase(businessTripRepo repository.BusinessTripRepository, db database.DB, webhooks *service.BusinessTripWebhookDispatcher) *BulkUpdateVerificatorsUseCase {
+	return &BulkUpdateVerificatorsUseCase{
+		businessTripRepo: businessTripRepo,
+		db:               db,
+		webhooks:         webhooks,
+	}
+}
+
+// bulkVerifiedTrip is a business trip touched by a bulk update with its status before and after it
+type bulkVerifiedTrip struct {
+	trip      *entity.BusinessTrip
+	oldStatus entity.BusinessTripStatus
+	newStatus entity.BusinessTripStatus
+	updated   bool
+}
+
+// Execute applies the status in a single transaction. The verificators and then their trips are locked
+// in ID order, so concurrent updates cannot change a status between reading and writing it. Like the
+// verify endpoint, only verificators of trips that are ready to verify are updated, and each updated trip
+// follows its verificators' outcome.
+func (uc *BulkUpdateVerificatorsUseCase) Execute(ctx context.Context, req BulkUpdateVerificatorsRequest) (*BulkUpdateVerificatorsResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	targetStatus := entity.VerificatorStatus(req.Status)
+	response := &BulkUpdateVerificatorsResponse{Status: req.Status}
+
+	ids := make([]string, 0, len(req.VerificatorIDs))
+	seen := make(map[string]bool, len(req.VerificatorIDs))
+	for _, id := range req.VerificatorIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	var trips []*bulkVerifiedTrip
+	err := uc.db.WithTransaction(ctx, func(ctx context.Context, tx database.DBTx) error {
+		businessTripRepoWithTx := uc.businessTripRepo.(interface {
+			WithTransaction(database.DBTx) repository.BusinessTripRepository
+		}).WithTransaction(tx)
+
+		lockOrder := append([]string(nil), ids...)
+		sort.Strings(lockOrder)
+		verificators := make(map[string]*entity.Verificator, len(ids))
+		tripIDs := make(map[string]bool)
+		for _, id := range lockOrder {
+			verificator, err := businessTripRepoWithTx.GetVerificatorByIDForUpdate(ctx, id)
+			if err != nil {
+				return fmt.Errorf("failed to get verificator %s: %w", id, err)
+			}
+			if verificator == nil {
+				continue
+			}
+			// Reject the whole call when a scope is given and an ID falls outside it
+			if req.BusinessTripID != "" && verificator.BusinessTripID != req.BusinessTripID {
+				return fmt.Errorf("%w: verificator %s, business trip %s", entity.ErrVerificatorNotOnTrip, id, req.BusinessTripID)
+			}
+			verificators[id] = verificator
+			tripIDs[verificator.BusinessTripID] = true
+		}
+
+		tripsByID := make(map[string]*bulkVerifiedTrip, len(tripIDs))
+		for _, tripID := range sortedKeys(tripIDs) {
+			if err := businessTripRepoWithTx.LockByID(ctx, tripID); err != nil {
+				if errors.Is(err, entity.ErrBusinessTripNotFound) {
+					continue
+				}
+				return err
+			}
+			trip, err := businessTripRepoWithTx.GetByID(ctx, tripID)
+			if err != nil {
+				return fmt.Errorf("failed to get business trip %s: %w", tripID, err)
+			}
+			if trip == nil {
+				continue
+			}
+			verified := &bulkVerifiedTrip{trip: trip, oldStatus: trip.GetStatus(), newStatus: trip.GetStatus()}
+			tripsByID[tripID] = verified
+			trips = append(trips, verified)
+		}
+
+		results := make([]*BulkUpdateVerificatorResult, 0, len(ids))
+		var idsToUpdate []string
+		var history []*entity.VerificatorStatusHistory
+		for _, id := range ids {
+			verificator := verificators[id]
+			if verificator == nil {
+				results = append(results, &BulkUpdateVerificatorResult{
+					VerificatorID: id,
+					Reason:        "verificator not found",
+				})
+				continue
+			}
+
+			result := &BulkUpdateVerificatorResult{
+				VerificatorID:  id,
+				BusinessTripID: verificator.BusinessTripID,
+			}
+			results = append(results, result)
+
+			verified := tripsByID[verificator.BusinessTripID]
+			switch {
+			case verified == nil:
+				result.Reason = "business trip not found"
+			case verified.oldStatus != entity.BusinessTripStatusReadyToVerify:
+				result.Reason = fmt.Sprintf("%s, current status: %s", entity.ErrTripNotReadyToVerify, verified.oldStatus)
+			case verificator.GetStatus() == targetStatus:
+				result.Reason = fmt.Sprintf("verificator is already %s", targetStatus)
+			default:
+				result.Success = true
+				verified.updated = true
+				idsToUpdate = append(idsToUpdate, id)
+				history = append(history, &entity.VerificatorStatusHistory{
+					VerificatorID: id,
+					FromStatus:    verificator.GetStatus(),
+					ToStatus:      targetStatus,
+					Notes:         req.VerificationNotes,
+					ChangedBy:     optionalUserID(req.ChangedBy),
+				})
+			}
+		}
+
+		now := time.Now()
+		updated, err := businessTripRepoWithTx.BulkUpdateVerificators(ctx, idsToUpdate, targetStatus, req.VerificationNotes, now)
+		if err != nil {
+			return err
+		}
+		if int(updated) != len(idsToUpdate) {
+			return fmt.Errorf("expected to update %d verificators, updated %d", len(idsToUpdate), updated)
+		}
+
+		for _, entry := range history {
+			entry.ChangedAt = now
+		}
+		if err := businessTripRepoWithTx.CreateVerificatorStatusHistory(ctx, history); err != nil {
+			return err
+		}
+
+		for _, verified := range trips {
+			if !verified.updated {
+				continue
+			}
+			verified.newStatus, err = applyVerificationOutcome(ctx, businessTripRepoWithTx, verified.trip)
+			if err != nil {
+				return err
+			}
+		}
+		for _, result := range results {
+			if verified := tripsByID[result.BusinessTripID]; verified != nil {
+				result.BusinessTripStatus = string(verified.newStatus)
+			}
+		}
+
+		response.Results = results
+		response.UpdatedCount = len(idsToUpdate)
+		response.SkippedCount = len(results) - len(idsToUpdate)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, verified := range trips {
+		uc.webhooks.StatusChanged(ctx, verified.trip.ID, verified.oldStatus, verified.newStatus)
+	}
+
+	return response, nil
+}
+
+// sortedKeys returns the keys of set in ascending order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
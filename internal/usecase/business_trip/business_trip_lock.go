@@ -0,0 +1,25 @@
+package business_trip
+
+import (
+	"fmt"
+
+	"sandbox/internal/domain/entity"
+)
+
+// ensureTripEditable rejects changes to the assignees and transactions of a completed or canceled
+// business trip. allowLocked lets an administrator correct a locked trip when it is reopened.
+func ensureTripEditable(businessTrip *entity.BusinessTrip, allowLocked bool) error {
+	if businessTrip.IsLocked() && !allowLocked {
+		return fmt.Errorf("%w: business trip is %s", entity.ErrBusinessTripLocked, businessTrip.Status)
+	}
+	return nil
+}
+
+// ensureLockedStatusChange rejects moving a completed or canceled business trip to a status
+// CanTransitionTo does not allow, even for an administrator overriding the lock
+func ensureLockedStatusChange(businessTrip *entity.BusinessTrip, newStatus entity.BusinessTripStatus) error {
+	if !businessTrip.IsLocked() || newStatus == businessTrip.Status || businessTrip.CanTransitionTo(newStatus) {
+		return nil
+	}
+	return fmt.Errorf("%w: cannot transition from %s to %s", entity.ErrBusinessTripLocked, businessTrip.Status, newStatus)
+}
@@ -0,0 +1,172 @@
+package business_trip
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/internal/domain/service"
+)
+
+// stubLockedTripRepository serves one trip with a single transaction and records every write
+type stubLockedTripRepository struct {
+	repository.BusinessTripRepository
+	trip        *entity.BusinessTrip
+	transaction *entity.Transaction
+	writes      int
+}
+
+func (r *stubLockedTripRepository) GetByID(context.Context, string) (*entity.BusinessTrip, error) {
+	return r.trip, nil
+}
+
+func (r *stubLockedTripRepository) GetTransactionByID(context.Context, string) (*entity.Transaction, error) {
+	return r.transaction, nil
+}
+
+func (r *stubLockedTripRepository) GetTransactionsByAssigneeID(context.Context, string) ([]*entity.Transaction, error) {
+	return []*entity.Transaction{r.transaction}, nil
+}
+
+func (r *stubLockedTripRepository) CreateTransaction(_ context.Context, transaction *entity.Transaction) (*entity.Transaction, error) {
+	r.writes++
+	return transaction, nil
+}
+
+func (r *stubLockedTripRepository) UpdateTransaction(_ context.Context, transaction *entity.Transaction) (*entity.Transaction, error) {
+	r.writes++
+	return transaction, nil
+}
+
+func (r *stubLockedTripRepository) DeleteTransaction(context.Context, string) error {
+	r.writes++
+	return nil
+}
+
+// stubLockedAssigneeRepository serves a single assignee and counts restores
+type stubLockedAssigneeRepository struct {
+	repository.AssigneeRepository
+	assignee *entity.Assignee
+	restores int
+}
+
+func (r *stubLockedAssigneeRepository) RestoreAssignee(context.Context, string, string) error {
+	r.restores++
+	return nil
+}
+
+func (r *stubLockedAssigneeRepository) GetAssigneeByID(context.Context, string) (*entity.Assignee, error) {
+	return r.assignee, nil
+}
+
+func newLockTestRepositories(t *testing.T, status entity.BusinessTripStatus) (*stubLockedTripRepository, *stubLockedAssigneeRepository) {
+	t.Helper()
+
+	trip := newTestBusinessTrip(t)
+	trip.Status = status
+	assignee := &entity.Assignee{ID: "assignee-1", BusinessTripID: trip.ID}
+
+	return &stubLockedTripRepository{
+			trip:        trip,
+			transaction: &entity.Transaction{ID: "transaction-1", AssigneeID: assignee.ID},
+		},
+		&stubLockedAssigneeRepository{assignee: assignee}
+}
+
+// transactionMutations adds, updates, patches and deletes a transaction of the assignee
+func transactionMutations(tripRepo *stubLockedTripRepository, assigneeRepo *stubLockedAssigneeRepository, allowLocked bool) map[string]error {
+	ctx := context.Background()
+
+	_, addErr := NewAddTransactionUseCase(tripRepo, assigneeRepo, service.NewTransactionTypePolicy(nil), nil, entity.DefaultBaseCurrency).Execute(ctx, "assignee-1", TransactionRequest{
+		Name:   "Hotel",
+		Type:   string(entity.TransactionTypeAccommodation),
+		Amount: 100,
+	}, allowLocked)
+	_, updateErr := NewUpdateTransactionUseCase(tripRepo, assigneeRepo, service.NewTransactionTypePolicy(nil), nil, entity.DefaultBaseCurrency).Execute(ctx, UpdateTransactionRequest{
+		BusinessTripID:  tripRepo.trip.ID,
+		AssigneeID:      "assignee-1",
+		TransactionID:   "transaction-1",
+		Name:            "Hotel",
+		Type:            string(entity.TransactionTypeAccommodation),
+		Amount:          100,
+		AllowLockedTrip: allowLocked,
+	})
+	amount := 150.0
+	_, patchErr := NewPatchTransactionUseCase(tripRepo, assigneeRepo, service.NewTransactionTypePolicy(nil), nil, entity.DefaultBaseCurrency).Execute(ctx, PatchTransactionRequest{
+		BusinessTripID:  tripRepo.trip.ID,
+		AssigneeID:      "assignee-1",
+		TransactionID:   "transaction-1",
+		Amount:          &amount,
+		AllowLockedTrip: allowLocked,
+	})
+	deleteErr := NewDeleteTransactionUseCase(tripRepo, assigneeRepo).Execute(ctx, "transaction-1", allowLocked)
+
+	return map[string]error{
+		"AddTransaction":    addErr,
+		"UpdateTransaction": updateErr,
+		"PatchTransaction":  patchErr,
+		"DeleteTransaction": deleteErr,
+	}
+}
+
+func TestMutationsRejectedOnLockedTrip(t *testing.T) {
+	for _, status := range []entity.BusinessTripStatus{entity.BusinessTripStatusCompleted, entity.BusinessTripStatusCanceled} {
+		tripRepo, assigneeRepo := newLockTestRepositories(t, status)
+
+		errs := transactionMutations(tripRepo, assigneeRepo, false)
+		_, errs["UpdateAssignee"] = NewUpdateAssigneeUseCase(tripRepo, assigneeRepo, nil).Execute(context.Background(), UpdateAssigneeRequest{
+			BusinessTripID: tripRepo.trip.ID,
+			AssigneeID:     "assignee-1",
+		})
+		errs["RestoreAssignee"] = NewRestoreAssigneeUseCase(tripRepo, assigneeRepo).Execute(context.Background(), tripRepo.trip.ID, "assignee-1", false)
+
+		for name, err := range errs {
+			if !errors.Is(err, entity.ErrBusinessTripLocked) {
+				t.Errorf("%s on %s trip: error = %v, want ErrBusinessTripLocked", name, status, err)
+			}
+		}
+		if tripRepo.writes != 0 || assigneeRepo.restores != 0 {
+			t.Errorf("%s trip was written %d times and restored %d times, want none", status, tripRepo.writes, assigneeRepo.restores)
+		}
+	}
+}
+
+func TestTransactionMutationsAllowedWithLockOverride(t *testing.T) {
+	tripRepo, assigneeRepo := newLockTestRepositories(t, entity.BusinessTripStatusCompleted)
+
+	errs := transactionMutations(tripRepo, assigneeRepo, true)
+
+	for name, err := range errs {
+		if err != nil {
+			t.Errorf("%s with override: error = %v, want nil", name, err)
+		}
+	}
+	if tripRepo.writes != len(errs) {
+		t.Errorf("writes = %d, want %d", tripRepo.writes, len(errs))
+	}
+}
+
+func TestRestoreAssigneeAllowedWithLockOverride(t *testing.T) {
+	tripRepo, assigneeRepo := newLockTestRepositories(t, entity.BusinessTripStatusCompleted)
+
+	if err := NewRestoreAssigneeUseCase(tripRepo, assigneeRepo).Execute(context.Background(), tripRepo.trip.ID, "assignee-1", true); err != nil {
+		t.Fatalf("RestoreAssignee with override: error = %v, want nil", err)
+	}
+	if assigneeRepo.restores != 1 {
+		t.Errorf("restores = %d, want 1", assigneeRepo.restores)
+	}
+}
+
+func TestTransactionMutationsAllowedOnOpenTrip(t *testing.T) {
+	tripRepo, assigneeRepo := newLockTestRepositories(t, entity.BusinessTripStatusOngoing)
+
+	errs := transactionMutations(tripRepo, assigneeRepo, false)
+
+	for name, err := range errs {
+		if err != nil {
+			t.Errorf("%s on ongoing trip: error = %v, want nil", name, err)
+		}
+	}
+}
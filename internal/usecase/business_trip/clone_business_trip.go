@@ -0,0 +1,140 @@
+package business_trip
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/invopop/validation"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/pkg/database"
+)
+
+type CloneBusinessTripUseCase struct {
+	businessTripRepo repository.BusinessTripRepository
+	assigneeRepo     repository.AssigneeRepository
+	transactionRepo  repository.BusinessTripTransactionRepository
+	db               database.DB
+}
+
+func NewCloneBusinessTripUseCase(businessTripRepo repository.BusinessTripRepository, assigneeRepo repository.AssigneeRepository, transactionRepo repository.BusinessTripTransactionRepository, db database.DB) *CloneBusinessTripUseCase {
+	return &CloneBusinessTripUseCase{
+		businessTripRepo: businessTripRepo,
+		assigneeRepo:     assigneeRepo,
+		transactionRepo:  transactionRepo,
+		db:               db,
+	}
+}
+
+// CloneBusinessTripRequest represents the optional date overrides for a cloned business trip.
+// A new start date shifts the SPD, departure and return dates along with it.
+type CloneBusinessTripRequest struct {
+	BusinessTripID string `params:"tripId" json:"-"`
+	StartDate      string `json:"start_date"`
+	EndDate        string `json:"end_date"`
+}
+
+func (r CloneBusinessTripRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.BusinessTripID, validation.Required),
+		validation.Field(&r.StartDate, validation.Date("2006-01-02")),
+		validation.Field(&r.EndDate, validation.Date("2006-01-02")),
+	)
+}
+
+// Execute copies the source trip with its assignees, transactions and verificators into a new draft
+func (uc *CloneBusinessTripUseCase) Execute(ctx context.Context, req CloneBusinessTripRequest) (*BusinessTripResponse, error) {
+	source, err := uc.businessTripRepo.GetByID(ctx, req.BusinessTripID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get business trip: %w", err)
+	}
+	if source == nil {
+		return nil, entity.ErrBusinessTripNotFound
+	}
+
+	// Reload transactions through the transaction repository so their allocations are copied too
+	for _, assignee := range source.Assignees {
+		transactions, err := uc.transactionRepo.GetTransactionsByAssigneeID(ctx, assignee.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transactions: %w", err)
+		}
+		assignee.Transactions = transactions
+	}
+
+	clone := source.CloneAsDraft()
+
+	startDate, err := parseOptionalDate(req.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	endDate, err := parseOptionalDate(req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	if err := clone.Reschedule(startDate, endDate); err != nil {
+		return nil, err
+	}
+
+	err = database.WithinTx(ctx, uc.db, func(tx database.DBTx) error {
+		businessTripRepoWithTx := uc.businessTripRepo.(interface {
+			WithTransaction(database.DBTx) repository.BusinessTripRepository
+		}).WithTransaction(tx)
+
+		assigneeRepoWithTx := uc.assigneeRepo.(interface {
+			WithTransaction(database.DBTx) repository.AssigneeRepository
+		}).WithTransaction(tx)
+
+		transactionRepoWithTx := uc.transactionRepo.(interface {
+			WithTransaction(database.DBTx) repository.BusinessTripTransactionRepository
+		}).WithTransaction(tx)
+
+		// Create generates a new business trip number for the clone
+		if _, err := businessTripRepoWithTx.Create(ctx, clone); err != nil {
+			return err
+		}
+
+		for _, assignee := range clone.Assignees {
+			if _, err := assigneeRepoWithTx.Create(ctx, assignee); err != nil {
+				return err
+			}
+
+			for _, transaction := range assignee.Transactions {
+				if _, err := transactionRepoWithTx.CreateTransaction(ctx, transaction); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, verificator := range clone.Verificators {
+			if _, err := businessTripRepoWithTx.CreateVerificator(ctx, verificator); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := uc.businessTripRepo.GetByID(ctx, clone.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return FromEntity(created), nil
+}
+
+func parseOptionalDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
@@ -0,0 +1,96 @@
+package business_trip
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// completionPlaceholder matches a named placeholder such as {{business_trip_number}}. Word splits text
+// it autocorrects or spellchecks into several runs, so placeholders must be typed (or pasted) in one go.
+var completionPlaceholder = regexp.MustCompile(`\{\{\s*([a-z_]+)\s*\}\}`)
+
+// fillDocxTemplate replaces the placeholders in the body, headers and footers of a DOCX template with
+// fields. Unknown placeholders are left as they are so a typo shows up in the generated document.
+// Line breaks in a value become line breaks in the document.
+func fillDocxTemplate(template []byte, fields map[string]string) ([]byte, error) {
+	reader, err := zip.NewReader(bytes.NewReader(template), int64(len(template)))
+	if err != nil {
+		return nil, fmt.Errorf("completion template is not a DOCX file: %w", err)
+	}
+
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for _, file := range reader.File {
+		content, err := readZipFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if isDocxTextPart(file.Name) {
+			content = replacePlaceholders(content, fields)
+		}
+
+		header := file.FileHeader
+		part, err := writer.CreateHeader(&header)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", file.Name, err)
+		}
+		if _, err := part.Write(content); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", file.Name, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write completion document: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file.Name, err)
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file.Name, err)
+	}
+	return content, nil
+}
+
+// isDocxTextPart reports whether the part holds document text: the body, headers or footers
+func isDocxTextPart(name string) bool {
+	if name == "word/document.xml" {
+		return true
+	}
+	return strings.HasSuffix(name, ".xml") &&
+		(strings.HasPrefix(name, "word/header") || strings.HasPrefix(name, "word/footer"))
+}
+
+func replacePlaceholders(content []byte, fields map[string]string) []byte {
+	return completionPlaceholder.ReplaceAllFunc(content, func(match []byte) []byte {
+		name := string(completionPlaceholder.FindSubmatch(match)[1])
+		value, ok := fields[name]
+		if !ok {
+			return match
+		}
+		return []byte(docxText(value))
+	})
+}
+
+// docxText escapes value for use inside a w:t element and turns line breaks into w:br elements
+func docxText(value string) string {
+	lines := strings.Split(value, "\n")
+	for i, line := range lines {
+		var escaped bytes.Buffer
+		_ = xml.EscapeText(&escaped, []byte(line))
+		lines[i] = escaped.String()
+	}
+	return strings.Join(lines, `</w:t><w:br/><w:t xml:space="preserve">`)
+}
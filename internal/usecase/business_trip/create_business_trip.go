@@ -2,12 +2,16 @@ package business_trip
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 
+	"github.com/google/uuid"
+
 	"sandbox/internal/domain/entity"
 	"sandbox/internal/domain/repository"
 	"sandbox/internal/domain/service"
 	"sandbox/pkg/database"
+	"sandbox/pkg/featureflag"
 )
 
 type CreateBusinessTripUseCase struct {
@@ -16,15 +20,29 @@ type CreateBusinessTripUseCase struct {
 	transactionRepo  repository.BusinessTripTransactionRepository
 	userService      *service.UserService
 	db               database.DB
+	flags            *featureflag.Service
+	duplicates       *service.DuplicateTransactionDetector
+	typePolicy       *service.TransactionTypePolicy
+	maxTripDays      int
+	maxVerificators  int
+	amountCaps       entity.TransactionAmountCaps
+	baseCurrency     string
 }
 
-func NewCreateBusinessTripUseCase(businessTripRepo repository.BusinessTripRepository, assigneeRepo repository.AssigneeRepository, transactionRepo repository.BusinessTripTransactionRepository, userService *service.UserService, db database.DB) *CreateBusinessTripUseCase {
+func NewCreateBusinessTripUseCase(businessTripRepo repository.BusinessTripRepository, assigneeRepo repository.AssigneeRepository, transactionRepo repository.BusinessTripTransactionRepository, userService *service.UserService, db database.DB, flags *featureflag.Service, duplicates *service.DuplicateTransactionDetector, typePolicy *service.TransactionTypePolicy, maxTripDays, maxVerificators int, amountCaps entity.TransactionAmountCaps, baseCurrency string) *CreateBusinessTripUseCase {
 	return &CreateBusinessTripUseCase{
 		businessTripRepo: businessTripRepo,
 		assigneeRepo:     assigneeRepo,
 		transactionRepo:  transactionRepo,
 		userService:      userService,
 		db:               db,
+		flags:            flags,
+		duplicates:       duplicates,
+		typePolicy:       typePolicy,
+		maxTripDays:      maxTripDays,
+		maxVerificators:  maxVerificators,
+		amountCaps:       amountCaps,
+		baseCurrency:     baseCurrency,
 	}
 }
 
@@ -43,14 +61,39 @@ func (uc *CreateBusinessTripUseCase) Execute(ctx context.Context, req BusinessTr
 		return nil, fmt.Errorf("failed to fetch user data: %w", err)
 	}
 
-	bt, err := req.ToEntity()
+	// Without strict validation, assignees unknown to the user service keep the data from the request
+	if uc.flags.Enabled(ctx, featureflag.StrictIdentityValidation) {
+		for _, employeeNumber := range employeeNumbers {
+			if _, exists := userDataMap[employeeNumber]; !exists {
+				return nil, fmt.Errorf("%w: %s", entity.ErrUnknownEmployee, employeeNumber)
+			}
+		}
+	}
+
+	bt, err := req.ToEntity(uc.baseCurrency, uc.maxVerificators, uc.amountCaps)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := checkTripDuration(bt, uc.maxTripDays, req.Force); err != nil {
+		return nil, err
+	}
+
+	// The trip belongs to the creator's organization, whose transaction type restrictions it keeps
+	if organizationID := featureflag.OrganizationID(ctx); organizationID != "" && organizationID != uuid.Nil.String() {
+		bt.OrganizationID = sql.NullString{String: organizationID, Valid: true}
+	}
+
+	if err := uc.typePolicy.CheckAssignees(ctx, bt, bt.Assignees); err != nil {
+		return nil, err
+	}
+
+	// Likely duplicates are reported back, not rejected, so the user can review them
+	warnings := uc.duplicates.DetectInAssignees(bt.Assignees)
+
 	var completeBusinessTrip *entity.BusinessTrip
 
-	err = uc.db.WithTransaction(ctx, func(ctx context.Context, tx database.DBTx) error {
+	err = database.WithinTx(ctx, uc.db, func(tx database.DBTx) error {
 		// Create transaction-aware repositories
 		businessTripRepoWithTx := uc.businessTripRepo.(interface {
 			WithTransaction(database.DBTx) repository.BusinessTripRepository
@@ -123,5 +166,8 @@ func (uc *CreateBusinessTripUseCase) Execute(ctx context.Context, req BusinessTr
 		return nil, err
 	}
 
-	return FromEntity(completeBusinessTrip), nil
+	response := FromEntity(completeBusinessTrip)
+	response.Warnings = warnings
+
+	return response, nil
 }
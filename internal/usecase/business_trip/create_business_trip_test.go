@@ -0,0 +1,88 @@
+package business_trip
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/service"
+	"sandbox/pkg/database"
+	"sandbox/pkg/featureflag"
+)
+
+var errTransactionStarted = errors.New("transaction started")
+
+// stubUnreachableDB fails every transaction, so reaching it shows the request passed validation
+type stubUnreachableDB struct {
+	database.DB
+}
+
+func (db *stubUnreachableDB) BeginTx(context.Context, *sql.TxOptions) (database.DBTx, error) {
+	return nil, errTransactionStarted
+}
+
+func tripRequestWithVerificators(count int) BusinessTripRequest {
+	req := BusinessTripRequest{
+		StartDate:       "2025-03-10",
+		EndDate:         "2025-03-14",
+		ActivityPurpose: "Audit",
+		DestinationCity: "Bandung",
+		SPDDate:         "2025-03-01",
+		DepartureDate:   "2025-03-10",
+		ReturnDate:      "2025-03-14",
+	}
+	for i := 0; i < count; i++ {
+		req.Verificators = append(req.Verificators, VerificatorRequest{
+			UserID:         fmt.Sprintf("user-%d", i),
+			UserName:       fmt.Sprintf("Verificator %d", i),
+			EmployeeNumber: fmt.Sprintf("1980%04d", i),
+			Position:       "Reviewer",
+		})
+	}
+	return req
+}
+
+func TestCreateBusinessTripEnforcesMaxVerificators(t *testing.T) {
+	tests := []struct {
+		name         string
+		verificators int
+		wantErr      error
+	}{
+		{"under the cap", 2, errTransactionStarted},
+		{"exactly at the cap", 3, errTransactionStarted},
+		{"one over the cap", 4, entity.ErrTooManyVerificators},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewCreateBusinessTripUseCase(nil, nil, nil, service.NewUserService(nil), &stubUnreachableDB{},
+				featureflag.NewService(nil, nil), service.NewDuplicateTransactionDetector(service.DuplicateTransactionConfig{}),
+				service.NewTransactionTypePolicy(nil), entity.DefaultMaxTripDays, 3, nil, entity.DefaultBaseCurrency)
+
+			_, err := uc.Execute(context.Background(), tripRequestWithVerificators(tt.verificators))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAddVerificatorEnforcesMaxVerificators(t *testing.T) {
+	bt, err := tripRequestWithVerificators(2).ToEntity(entity.DefaultBaseCurrency, 2, nil)
+	if err != nil {
+		t.Fatalf("ToEntity() error = %v", err)
+	}
+	if _, err := bt.AddVerificator("user-9", "Verificator 9", "19800009", "Reviewer", 2); !errors.Is(err, entity.ErrTooManyVerificators) {
+		t.Fatalf("AddVerificator() error = %v, want ErrTooManyVerificators", err)
+	}
+	if len(bt.Verificators) != 2 {
+		t.Errorf("trip has %d verificators, want 2", len(bt.Verificators))
+	}
+
+	if _, err := bt.AddVerificator("user-9", "Verificator 9", "19800009", "Reviewer", 0); err != nil {
+		t.Errorf("AddVerificator() without a cap error = %v", err)
+	}
+}
@@ -9,15 +9,19 @@ import (
 
 type DeleteTransactionUseCase struct {
 	businessTripRepo repository.BusinessTripRepository
+	assigneeRepo     repository.AssigneeRepository
 }
 
-func NewDeleteTransactionUseCase(businessTripRepo repository.BusinessTripRepository) *DeleteTransactionUseCase {
+func NewDeleteTransactionUseCase(businessTripRepo repository.BusinessTripRepository, assigneeRepo repository.AssigneeRepository) *DeleteTransactionUseCase {
 	return &DeleteTransactionUseCase{
 		businessTripRepo: businessTripRepo,
+		assigneeRepo:     assigneeRepo,
 	}
 }
 
-func (uc *DeleteTransactionUseCase) Execute(ctx context.Context, transactionID string) error {
+// Execute deletes the transaction. allowLockedTrip lets an administrator delete it even though
+// the business trip is completed or canceled.
+func (uc *DeleteTransactionUseCase) Execute(ctx context.Context, transactionID string, allowLockedTrip bool) error {
 	transaction, err := uc.businessTripRepo.GetTransactionByID(ctx, transactionID)
 	if err != nil {
 		return fmt.Errorf("failed to get transaction: %w", err)
@@ -26,6 +30,25 @@ func (uc *DeleteTransactionUseCase) Execute(ctx context.Context, transactionID s
 		return fmt.Errorf("transaction not found")
 	}
 
+	assignee, err := uc.assigneeRepo.GetAssigneeByID(ctx, transaction.AssigneeID)
+	if err != nil {
+		return fmt.Errorf("failed to get assignee: %w", err)
+	}
+	if assignee == nil {
+		return fmt.Errorf("assignee not found")
+	}
+
+	businessTrip, err := uc.businessTripRepo.GetByID(ctx, assignee.BusinessTripID)
+	if err != nil {
+		return fmt.Errorf("failed to get business trip: %w", err)
+	}
+	if businessTrip == nil {
+		return fmt.Errorf("business trip not found")
+	}
+	if err := ensureTripEditable(businessTrip, allowLockedTrip); err != nil {
+		return err
+	}
+
 	err = uc.businessTripRepo.DeleteTransaction(ctx, transactionID)
 	if err != nil {
 		return fmt.Errorf("failed to delete transaction: %w", err)
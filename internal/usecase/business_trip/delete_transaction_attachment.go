@@ -0,0 +1,28 @@
+package business_trip
+
+import (
+	"context"
+
+	"sandbox/internal/domain/repository"
+)
+
+// DeleteTransactionAttachmentUseCase detaches a receipt from a transaction
+type DeleteTransactionAttachmentUseCase struct {
+	transactionRepo repository.BusinessTripTransactionRepository
+}
+
+// NewDeleteTransactionAttachmentUseCase creates a new instance of DeleteTransactionAttachmentUseCase
+func NewDeleteTransactionAttachmentUseCase(transactionRepo repository.BusinessTripTransactionRepository) *DeleteTransactionAttachmentUseCase {
+	return &DeleteTransactionAttachmentUseCase{
+		transactionRepo: transactionRepo,
+	}
+}
+
+// Execute removes the attachment record; the file stays in the drive for the audit trail
+func (uc *DeleteTransactionAttachmentUseCase) Execute(ctx context.Context, assigneeID, transactionID, attachmentID string) error {
+	if _, err := getAssigneeTransaction(ctx, uc.transactionRepo, assigneeID, transactionID); err != nil {
+		return err
+	}
+
+	return uc.transactionRepo.DeleteTransactionAttachment(ctx, transactionID, attachmentID)
+}
@@ -0,0 +1,78 @@
+package business_trip
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+)
+
+// ExportBusinessTripsRequest selects which business trips are exported and how much of each is included
+type ExportBusinessTripsRequest struct {
+	StartDate *time.Time
+	EndDate   *time.Time
+	// Full includes every assignee's transactions; otherwise assignees are exported without them
+	Full bool
+}
+
+type ExportBusinessTripsNDJSONUseCase struct {
+	businessTripRepo repository.BusinessTripRepository
+	assigneeRepo     repository.AssigneeRepository
+}
+
+func NewExportBusinessTripsNDJSONUseCase(businessTripRepo repository.BusinessTripRepository, assigneeRepo repository.AssigneeRepository) *ExportBusinessTripsNDJSONUseCase {
+	return &ExportBusinessTripsNDJSONUseCase{
+		businessTripRepo: businessTripRepo,
+		assigneeRepo:     assigneeRepo,
+	}
+}
+
+// Execute writes every matching business trip to w as newline-delimited JSON, one trip per line.
+// Trips are written as they are read, so output may already have been sent when an error is returned.
+func (uc *ExportBusinessTripsNDJSONUseCase) Execute(ctx context.Context, req ExportBusinessTripsRequest, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+
+	return uc.businessTripRepo.StreamAll(ctx, req.StartDate, req.EndDate, func(bt *entity.BusinessTrip) error {
+		if err := uc.loadRelations(ctx, bt, req.Full); err != nil {
+			return err
+		}
+		return encoder.Encode(FromEntity(bt))
+	})
+}
+
+func (uc *ExportBusinessTripsNDJSONUseCase) loadRelations(ctx context.Context, bt *entity.BusinessTrip, full bool) error {
+	tripID := bt.GetID()
+
+	var assignees []*entity.Assignee
+	var err error
+	if full {
+		assignees, err = uc.assigneeRepo.GetAssigneesByBusinessTripID(ctx, tripID)
+	} else {
+		assignees, err = uc.assigneeRepo.GetAssigneesByBusinessTripIDWithoutTransactions(ctx, tripID)
+	}
+	if err != nil {
+		return err
+	}
+
+	if full {
+		for _, assignee := range assignees {
+			transactions, err := uc.businessTripRepo.GetTransactionsByAssigneeID(ctx, assignee.GetID())
+			if err != nil {
+				return err
+			}
+			assignee.Transactions = transactions
+		}
+	}
+	bt.Assignees = assignees
+
+	verificators, err := uc.businessTripRepo.GetVerificatorsByBusinessTripID(ctx, tripID)
+	if err != nil {
+		return err
+	}
+	bt.Verificators = verificators
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package business_trip
+
+import (
+	"context"
+	"io"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/internal/infrastructure/excel"
+)
+
+type ExportTransactionsCSVUseCase struct {
+	businessTripRepo repository.BusinessTripRepository
+	assigneeRepo     repository.AssigneeRepository
+	generator        *excel.Generator
+}
+
+func NewExportTransactionsCSVUseCase(businessTripRepo repository.BusinessTripRepository, assigneeRepo repository.AssigneeRepository, generator *excel.Generator) *ExportTransactionsCSVUseCase {
+	return &ExportTransactionsCSVUseCase{
+		businessTripRepo: businessTripRepo,
+		assigneeRepo:     assigneeRepo,
+		generator:        generator,
+	}
+}
+
+// Execute writes every transaction of the business trip to w as CSV.
+// All data is loaded before anything is written, so errors can still be reported to the caller.
+func (uc *ExportTransactionsCSVUseCase) Execute(ctx context.Context, businessTripID string, w io.Writer) error {
+	businessTrip, err := uc.businessTripRepo.GetByID(ctx, businessTripID)
+	if err != nil {
+		return err
+	}
+	if businessTrip == nil {
+		return entity.ErrBusinessTripNotFound
+	}
+
+	assignees, err := uc.assigneeRepo.GetAssigneesByBusinessTripID(ctx, businessTripID)
+	if err != nil {
+		return err
+	}
+
+	var rows []excel.TransactionCSVRow
+	for _, assignee := range assignees {
+		transactions, err := uc.businessTripRepo.GetTransactionsByAssigneeID(ctx, assignee.GetID())
+		if err != nil {
+			return err
+		}
+
+		for _, tx := range transactions {
+			rows = append(rows, excel.TransactionCSVRow{
+				AssigneeName: assignee.GetName(),
+				SpdNumber:    assignee.GetSPDNumber(),
+				Type:         string(tx.GetType()),
+				Subtype:      string(tx.GetSubtype()),
+				Direction:    string(tx.GetDirection()),
+				Amount:       tx.GetAmount(),
+				TotalNight:   tx.GetTotalNight(),
+				Subtotal:     tx.GetSubtotal(),
+			})
+		}
+	}
+
+	return uc.generator.GenerateTransactionsCSV(w, rows)
+}
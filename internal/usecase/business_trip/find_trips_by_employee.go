@@ -0,0 +1,61 @@
+package business_trip
+
+import (
+	"context"
+
+	"sandbox/internal/domain/repository"
+	"sandbox/pkg/pagination"
+)
+
+// FindTripsByEmployeeUseCase lists every business trip an employee was assigned to
+type FindTripsByEmployeeUseCase struct {
+	businessTripRepo repository.BusinessTripRepository
+}
+
+func NewFindTripsByEmployeeUseCase(businessTripRepo repository.BusinessTripRepository) *FindTripsByEmployeeUseCase {
+	return &FindTripsByEmployeeUseCase{
+		businessTripRepo: businessTripRepo,
+	}
+}
+
+// EmployeeBusinessTripResponse is a business trip of the employee with the assignment that matched
+type EmployeeBusinessTripResponse struct {
+	*BusinessTripResponse
+	MatchedAssignee MatchedAssigneeResponse `json:"matched_assignee"`
+}
+
+// MatchedAssigneeResponse identifies the employee's assignment on the business trip
+type MatchedAssigneeResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	SPDNumber string `json:"spd_number"`
+}
+
+// Execute returns a page of the employee's business trips, latest start date first by default
+func (uc *FindTripsByEmployeeUseCase) Execute(ctx context.Context, employeeNumber string, params *pagination.QueryParams) ([]*EmployeeBusinessTripResponse, *pagination.PagedResponse, error) {
+	trips, totalCount, err := uc.businessTripRepo.FindTripsByEmployeeNumber(ctx, employeeNumber, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]*EmployeeBusinessTripResponse, 0, len(trips))
+	for _, trip := range trips {
+		responses = append(responses, &EmployeeBusinessTripResponse{
+			BusinessTripResponse: FromEntity(&trip.BusinessTrip),
+			MatchedAssignee: MatchedAssigneeResponse{
+				ID:        trip.AssigneeID,
+				Name:      trip.AssigneeName,
+				SPDNumber: trip.SPDNumber,
+			},
+		})
+	}
+
+	metadata := pagination.BuildMetadata(totalCount, params.Pagination.Page, params.Pagination.Limit)
+
+	return responses, &pagination.PagedResponse{
+		Page:       params.Pagination.Page,
+		Limit:      params.Pagination.Limit,
+		TotalItems: totalCount,
+		TotalPages: metadata.TotalPage,
+	}, nil
+}
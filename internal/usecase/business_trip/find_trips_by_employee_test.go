@@ -0,0 +1,51 @@
+package business_trip
+
+import (
+	"context"
+	"testing"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/pkg/pagination"
+)
+
+type stubEmployeeTripsRepository struct {
+	repository.BusinessTripRepository
+	trips          []*entity.EmployeeBusinessTrip
+	total          int64
+	employeeNumber string
+}
+
+func (r *stubEmployeeTripsRepository) FindTripsByEmployeeNumber(_ context.Context, employeeNumber string, _ *pagination.QueryParams) ([]*entity.EmployeeBusinessTrip, int64, error) {
+	r.employeeNumber = employeeNumber
+	return r.trips, r.total, nil
+}
+
+func TestFindTripsByEmployeeHighlightsMatchedAssignee(t *testing.T) {
+	repo := &stubEmployeeTripsRepository{
+		trips: []*entity.EmployeeBusinessTrip{
+			{BusinessTrip: entity.BusinessTrip{ID: "trip-2", DestinationCity: "Surabaya"}, AssigneeID: "assignee-2", AssigneeName: "Budi", SPDNumber: "SPD-002"},
+			{BusinessTrip: entity.BusinessTrip{ID: "trip-1", DestinationCity: "Bandung"}, AssigneeID: "assignee-1", AssigneeName: "Budi", SPDNumber: "SPD-001"},
+		},
+		total: 12,
+	}
+	params := &pagination.QueryParams{Pagination: pagination.Pagination{Page: 2, Limit: 10}}
+
+	responses, paged, err := NewFindTripsByEmployeeUseCase(repo).Execute(context.Background(), "19800101", params)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if repo.employeeNumber != "19800101" {
+		t.Errorf("searched employee number %q, want 19800101", repo.employeeNumber)
+	}
+	if len(responses) != 2 {
+		t.Fatalf("responses = %d, want 2", len(responses))
+	}
+	if got := responses[0]; got.ID != "trip-2" || got.MatchedAssignee.ID != "assignee-2" || got.MatchedAssignee.SPDNumber != "SPD-002" {
+		t.Errorf("first trip = %s with assignee %+v, want trip-2 with SPD-002", got.ID, got.MatchedAssignee)
+	}
+	if paged.TotalItems != 12 || paged.TotalPages != 2 || paged.Page != 2 {
+		t.Errorf("pagination = %+v, want page 2 of 2 with 12 items", paged)
+	}
+}
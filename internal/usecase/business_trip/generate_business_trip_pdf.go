@@ -0,0 +1,320 @@
+package business_trip
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"net/url"
+	"strconv"
+	"time"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/internal/infrastructure/cryptography"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/qr"
+	"codeberg.org/go-pdf/fpdf"
+)
+
+// pdfContentWidth is the printable width of an A4 page with the default 10mm margins
+const pdfContentWidth = 190.0
+
+var transactionTypeLabels = map[entity.TransactionType]string{
+	entity.TransactionTypeAccommodation: "Penginapan",
+	entity.TransactionTypeTransport:     "Transportasi",
+	entity.TransactionTypeAllowance:     "Uang Harian",
+	entity.TransactionTypeOther:         "Lain-lain",
+}
+
+// DocumentSigner signs the contents of a generated document, see cryptography.DigitalSignatureService.SignDocument
+type DocumentSigner interface {
+	SignDocument(content []byte) (*cryptography.SignatureResult, error)
+}
+
+// GenerateBusinessTripPDFUseCase renders the official SPD (surat perjalanan dinas) of a business trip as PDF
+type GenerateBusinessTripPDFUseCase struct {
+	businessTripRepo repository.BusinessTripRepository
+	signer           DocumentSigner
+	verifyBaseURL    string
+	now              func() time.Time
+}
+
+// NewGenerateBusinessTripPDFUseCase creates the use case. A nil signer produces unsigned documents; the QR
+// code of a signed document links to the public verification endpoint under verifyBaseURL.
+func NewGenerateBusinessTripPDFUseCase(businessTripRepo repository.BusinessTripRepository, signer DocumentSigner, verifyBaseURL string) *GenerateBusinessTripPDFUseCase {
+	return &GenerateBusinessTripPDFUseCase{
+		businessTripRepo: businessTripRepo,
+		signer:           signer,
+		verifyBaseURL:    verifyBaseURL,
+		now:              time.Now,
+	}
+}
+
+// Execute returns the SPD of the trip as PDF. A trip still waiting for verificator approvals fails with
+// entity.ErrTripNotApproved unless draft is set; drafts carry a watermark and are never signed.
+func (uc *GenerateBusinessTripPDFUseCase) Execute(ctx context.Context, tripID string, draft bool) ([]byte, error) {
+	bt, err := uc.businessTripRepo.GetByID(ctx, tripID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get business trip: %w", err)
+	}
+	if bt == nil {
+		return nil, entity.ErrBusinessTripNotFound
+	}
+	if !draft && !bt.HasAllVerificatorsApproved() {
+		return nil, fmt.Errorf("%w: %d of %d verificators approved", entity.ErrTripNotApproved,
+			bt.GetApprovedVerificatorsCount(), len(bt.Verificators))
+	}
+
+	var signature *cryptography.SignatureResult
+	if uc.signer != nil && !draft {
+		signature, err = uc.signer.SignDocument(signedPDFContent(bt))
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign business trip document: %w", err)
+		}
+	}
+
+	var verifyURL string
+	if signature != nil {
+		verifyURL = businessTripPDFVerifyURL(uc.verifyBaseURL, bt.ID, signature)
+	}
+
+	return renderBusinessTripPDF(bt, completionDocumentFields(bt, uc.now()), draft, signature, verifyURL)
+}
+
+// signedPDFContent is what the signature of a SPD covers. The trip's ETag changes with every edit of the
+// trip, its assignees, transactions or verificators, so the signature of an outdated print no longer
+// matches the trip.
+func signedPDFContent(bt *entity.BusinessTrip) []byte {
+	return []byte(fmt.Sprintf("business_trip_id=%s\nbusiness_trip_number=%s\netag=%s\ntotal_cost=%s",
+		bt.ID, bt.GetBusinessTripNumber(), bt.ETag(), formatRupiah(bt.GetTotalCost())))
+}
+
+// businessTripPDFVerifyURL is the public verification link of a signed SPD, see VerifyBusinessTripPDFUseCase
+func businessTripPDFVerifyURL(baseURL, tripID string, signature *cryptography.SignatureResult) string {
+	query := url.Values{
+		"algorithm": {signature.Algorithm},
+		"signature": {signature.Signature},
+	}
+	return fmt.Sprintf("%s/api/v1/public/business-trips/%s/verify?%s", baseURL, url.PathEscape(tripID), query.Encode())
+}
+
+func renderBusinessTripPDF(bt *entity.BusinessTrip, fields map[string]string, draft bool, signature *cryptography.SignatureResult, verifyURL string) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle("SPD "+fields["business_trip_number"], true)
+	// The core fonts are cp1252; translate so names with accents still print
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	if draft {
+		pdf.SetHeaderFuncMode(func() { drawDraftWatermark(pdf) }, true)
+	}
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(pdfContentWidth, 7, "SURAT PERJALANAN DINAS (SPD)", "", 1, "C", false, 0, "")
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.CellFormat(pdfContentWidth, 6, tr("Nomor: "+fields["business_trip_number"]), "", 1, "C", false, 0, "")
+	pdf.Ln(4)
+
+	pdf.SetFont("Helvetica", "", 10)
+	info := [][2]string{
+		{"Maksud Perjalanan", fields["activity_purpose"]},
+		{"Kota Tujuan", fields["destination_city"]},
+		{"Tanggal SPD", fields["spd_date"]},
+		{"Tanggal Kegiatan", fields["start_date"] + " s.d. " + fields["end_date"]},
+		{"Tanggal Berangkat", fields["departure_date"]},
+		{"Tanggal Kembali", fields["return_date"]},
+		{"Lama Perjalanan", fields["duration_days"] + " hari"},
+	}
+	for _, row := range info {
+		pdf.CellFormat(45, 6, row[0], "", 0, "L", false, 0, "")
+		pdf.CellFormat(5, 6, ":", "", 0, "L", false, 0, "")
+		pdf.MultiCell(pdfContentWidth-50, 6, tr(row[1]), "", "L", false)
+	}
+
+	pdfSectionTitle(pdf, "Pelaksana")
+	assigneeRows := make([][]string, 0, len(bt.Assignees))
+	for i, assignee := range bt.Assignees {
+		assigneeRows = append(assigneeRows, []string{
+			strconv.Itoa(i + 1), assignee.Name, assignee.EmployeeNumber, assignee.Position, assignee.SPDNumber,
+			formatRupiah(assignee.GetTotalCost()),
+		})
+	}
+	pdfTable(pdf, tr, []float64{10, 50, 35, 40, 25, 30}, []string{"No", "Nama", "NIP", "Jabatan", "No. SPD", "Jumlah"}, assigneeRows)
+
+	pdfSectionTitle(pdf, "Rincian Biaya")
+	transactionRows := [][]string{}
+	for _, assignee := range bt.Assignees {
+		for _, tx := range assignee.Transactions {
+			amount := formatRupiah(tx.Amount)
+			if tx.Currency != "" && tx.Currency != entity.DefaultBaseCurrency {
+				amount = tx.Currency + " " + strconv.FormatFloat(tx.Amount, 'f', -1, 64)
+			}
+			transactionRows = append(transactionRows, []string{
+				assignee.Name, tx.Name, transactionTypeLabels[tx.Type], amount, formatRupiah(tx.SignedSubtotal()),
+			})
+		}
+	}
+	pdfTable(pdf, tr, []float64{45, 55, 30, 30, 30}, []string{"Pelaksana", "Uraian", "Jenis", "Jumlah", "Subtotal"}, transactionRows)
+
+	pdf.Ln(2)
+	totals := [][2]string{
+		{"Penginapan", fields["total_accommodation"]},
+		{"Transportasi", fields["total_transport"]},
+		{"Uang Harian", fields["total_allowance"]},
+		{"Lain-lain", fields["total_other"]},
+	}
+	for _, row := range totals {
+		pdf.CellFormat(pdfContentWidth-30, 6, row[0], "", 0, "R", false, 0, "")
+		pdf.CellFormat(30, 6, row[1], "", 1, "R", false, 0, "")
+	}
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(pdfContentWidth-30, 7, "Total Biaya", "T", 0, "R", false, 0, "")
+	pdf.CellFormat(30, 7, fields["total_cost"], "T", 1, "R", false, 0, "")
+
+	pdfSectionTitle(pdf, "Verifikasi")
+	pdfSignatureBlock(pdf, tr, bt.Verificators)
+
+	pdf.Ln(6)
+	pdf.SetFont("Helvetica", "I", 8)
+	pdf.CellFormat(pdfContentWidth, 5, "Dibuat pada "+fields["generated_date"], "", 1, "L", false, 0, "")
+	if signature != nil {
+		if err := pdfSignatureQR(pdf, signature, verifyURL); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to write business trip PDF: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func pdfSectionTitle(pdf *fpdf.Fpdf, title string) {
+	pdf.Ln(4)
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.CellFormat(pdfContentWidth, 7, title, "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 9)
+}
+
+// pdfTable draws a bordered table with a shaded header row; cells are cut to one line
+func pdfTable(pdf *fpdf.Fpdf, tr func(string) string, widths []float64, header []string, rows [][]string) {
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetFillColor(230, 230, 230)
+	for i, title := range header {
+		pdf.CellFormat(widths[i], 7, title, "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", 9)
+	if len(rows) == 0 {
+		pdf.CellFormat(pdfContentWidth, 6, "-", "1", 1, "C", false, 0, "")
+		return
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			align := "L"
+			if i == len(row)-1 {
+				align = "R"
+			}
+			pdf.CellFormat(widths[i], 6, fitPDFText(pdf, tr(cell), widths[i]-2), "1", 0, align, false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+}
+
+// fitPDFText shortens text with an ellipsis until it fits width at the current font
+func fitPDFText(pdf *fpdf.Fpdf, text string, width float64) string {
+	if pdf.GetStringWidth(text) <= width {
+		return text
+	}
+	for len(text) > 0 && pdf.GetStringWidth(text+"...") > width {
+		text = text[:len(text)-1]
+	}
+	return text + "..."
+}
+
+// pdfSignatureBlock prints one signature column per verificator, three per row
+func pdfSignatureBlock(pdf *fpdf.Fpdf, tr func(string) string, verificators []*entity.Verificator) {
+	if len(verificators) == 0 {
+		pdf.CellFormat(pdfContentWidth, 6, "Tidak memerlukan verifikasi", "", 1, "L", false, 0, "")
+		return
+	}
+
+	const perRow = 3
+	columnWidth := pdfContentWidth / perRow
+	for start := 0; start < len(verificators); start += perRow {
+		row := verificators[start:min(start+perRow, len(verificators))]
+		lines := func(line func(v *entity.Verificator) string, style string) {
+			pdf.SetFont("Helvetica", style, 9)
+			for _, v := range row {
+				pdf.CellFormat(columnWidth, 5, fitPDFText(pdf, tr(line(v)), columnWidth-2), "", 0, "C", false, 0, "")
+			}
+			pdf.Ln(-1)
+		}
+
+		lines(func(v *entity.Verificator) string { return v.Position }, "")
+		lines(func(v *entity.Verificator) string {
+			if v.VerifiedAt != nil && v.IsApproved() {
+				return "Disetujui " + formatIndonesianDate(*v.VerifiedAt)
+			}
+			return verificatorStatusLabels[v.Status]
+		}, "I")
+		pdf.Ln(10)
+		lines(func(v *entity.Verificator) string { return v.UserName }, "BU")
+		lines(func(v *entity.Verificator) string { return "NIP " + v.EmployeeNumber }, "")
+		pdf.Ln(4)
+	}
+}
+
+// pdfSignatureQR prints a QR code with the verification link of the document signature; scanning it checks
+// the signature against the trip as it is stored now
+func pdfSignatureQR(pdf *fpdf.Fpdf, signature *cryptography.SignatureResult, verifyURL string) error {
+	code, err := qr.Encode(verifyURL, qr.M, qr.Auto)
+	if err != nil {
+		return fmt.Errorf("failed to encode signature QR code: %w", err)
+	}
+	code, err = barcode.Scale(code, 300, 300)
+	if err != nil {
+		return fmt.Errorf("failed to scale signature QR code: %w", err)
+	}
+	// The PDF writer only embeds 8-bit PNGs, and the QR code is 16-bit gray
+	gray := image.NewGray(code.Bounds())
+	draw.Draw(gray, gray.Bounds(), code, code.Bounds().Min, draw.Src)
+	var qrImage bytes.Buffer
+	if err := png.Encode(&qrImage, gray); err != nil {
+		return fmt.Errorf("failed to encode signature QR code: %w", err)
+	}
+
+	const size = 35.0
+	if pdf.GetY()+size > 287 {
+		pdf.AddPage()
+	}
+	options := fpdf.ImageOptions{ImageType: "PNG"}
+	pdf.RegisterImageOptionsReader("signature-qr", options, &qrImage)
+	y := pdf.GetY()
+	pdf.ImageOptions("signature-qr", 10, y, size, size, false, options, 0, "")
+
+	pdf.SetXY(10+size+4, y+4)
+	pdf.SetFont("Helvetica", "", 8)
+	pdf.MultiCell(pdfContentWidth-size-4, 4, fmt.Sprintf(
+		"Ditandatangani secara elektronik pada %s (%s). Pindai kode QR untuk memeriksa keaslian dokumen.",
+		formatIndonesianDate(signature.Timestamp), signature.Algorithm), "", "L", false)
+	return nil
+}
+
+// drawDraftWatermark prints a large diagonal DRAFT across the page
+func drawDraftWatermark(pdf *fpdf.Fpdf) {
+	pdf.SetFont("Helvetica", "B", 100)
+	pdf.SetTextColor(225, 225, 225)
+	width := pdf.GetStringWidth("DRAFT")
+	pdf.TransformBegin()
+	pdf.TransformRotate(45, 105, 148)
+	pdf.Text(105-width/2, 160, "DRAFT")
+	pdf.TransformEnd()
+	pdf.SetTextColor(0, 0, 0)
+}
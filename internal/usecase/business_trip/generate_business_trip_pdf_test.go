@@ -0,0 +1,133 @@
+package business_trip
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/infrastructure/cryptography"
+)
+
+// stubDocumentSigner records the contents it was asked to sign
+type stubDocumentSigner struct {
+	signed [][]byte
+}
+
+func (s *stubDocumentSigner) SignDocument(content []byte) (*cryptography.SignatureResult, error) {
+	s.signed = append(s.signed, content)
+	return &cryptography.SignatureResult{Signature: "c2lnbmF0dXJl", Algorithm: "RSA-PSS-SHA256", Timestamp: time.Now()}, nil
+}
+
+func newPDFTestTrip(t *testing.T, status entity.VerificatorStatus) *entity.BusinessTrip {
+	t.Helper()
+
+	bt := newTestBusinessTrip(t)
+	bt.ID = "trip-1"
+	bt.SetBusinessTripNumber("BT-000123")
+	nights := 2
+	bt.Assignees = []*entity.Assignee{{
+		ID: "assignee-1", Name: "Siti Rahayu", EmployeeNumber: "198501012010012001", Position: "Auditor", SPDNumber: "SPD-1",
+		Transactions: []*entity.Transaction{
+			{ID: "hotel", Name: "Hotel", Type: entity.TransactionTypeAccommodation, Subtype: entity.TransactionSubtypeHotel,
+				Amount: 500000, TotalNight: &nights, Subtotal: 1000000, Currency: entity.DefaultBaseCurrency, ExchangeRate: 1},
+		},
+	}}
+	bt.Verificators = []*entity.Verificator{{ID: "verificator-1", UserName: "Budi", Position: "Kepala Bagian", Status: status}}
+	return bt
+}
+
+func TestGenerateBusinessTripPDF(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     entity.VerificatorStatus
+		draft      bool
+		wantErr    error
+		wantSigned bool
+	}{
+		{name: "approved trip is signed", status: entity.VerificatorStatusApproved, wantSigned: true},
+		{name: "pending approval", status: entity.VerificatorStatusPending, wantErr: entity.ErrTripNotApproved},
+		{name: "pending approval as draft", status: entity.VerificatorStatusPending, draft: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer := &stubDocumentSigner{}
+			uc := NewGenerateBusinessTripPDFUseCase(&stubBusinessTripRepository{trip: newPDFTestTrip(t, tt.status)}, signer, "https://spd.example.go.id")
+
+			data, err := uc.Execute(context.Background(), "trip-1", tt.draft)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if !bytes.HasPrefix(data, []byte("%PDF-")) {
+				t.Errorf("Execute() returned %d bytes that are not a PDF", len(data))
+			}
+			if signed := len(signer.signed) > 0; signed != tt.wantSigned {
+				t.Errorf("document signed = %v, want %v", signed, tt.wantSigned)
+			}
+		})
+	}
+}
+
+func TestSignedPDFContentChangesWithTransactions(t *testing.T) {
+	bt := newPDFTestTrip(t, entity.VerificatorStatusApproved)
+	before := signedPDFContent(bt)
+
+	bt.Assignees[0].Transactions[0].UpdatedAt = time.Now()
+	if bytes.Equal(before, signedPDFContent(bt)) {
+		t.Error("signed content did not change after a transaction was edited")
+	}
+}
+
+// stubDocumentVerifier accepts a signature only for the content it was made for
+type stubDocumentVerifier struct {
+	signedContent []byte
+}
+
+func (v *stubDocumentVerifier) VerifyDocument(content []byte, signature, signatureAlgorithm string) error {
+	if !bytes.Equal(content, v.signedContent) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+func TestVerifyBusinessTripPDF(t *testing.T) {
+	trip := newPDFTestTrip(t, entity.VerificatorStatusApproved)
+	signer := &stubDocumentSigner{}
+	if _, err := NewGenerateBusinessTripPDFUseCase(&stubBusinessTripRepository{trip: trip}, signer, "https://spd.example.go.id").
+		Execute(context.Background(), trip.ID, false); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	uc := NewVerifyBusinessTripPDFUseCase(&stubBusinessTripRepository{trip: trip}, &stubDocumentVerifier{signedContent: signer.signed[0]})
+	req := VerifyBusinessTripPDFRequest{BusinessTripID: trip.ID, Algorithm: "RSA-PSS-SHA256", Signature: "c2lnbmF0dXJl"}
+
+	got, err := uc.Execute(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !got.IsValid || got.BusinessTripNumber != "BT-000123" {
+		t.Errorf("verification = %+v, want a valid signature of BT-000123", got)
+	}
+
+	trip.Assignees[0].Transactions[0].UpdatedAt = time.Now()
+	if got, err := uc.Execute(context.Background(), req); err != nil || got.IsValid {
+		t.Errorf("verification after an edit = %+v, %v, want it not valid", got, err)
+	}
+}
+
+func TestBusinessTripPDFVerifyURL(t *testing.T) {
+	signature := &cryptography.SignatureResult{Signature: "ab+c/d==", Algorithm: "RSA-PSS-SHA256"}
+
+	got := businessTripPDFVerifyURL("https://spd.example.go.id", "trip-1", signature)
+	want := "https://spd.example.go.id/api/v1/public/business-trips/trip-1/verify?algorithm=RSA-PSS-SHA256&signature=ab%2Bc%2Fd%3D%3D"
+	if got != want {
+		t.Errorf("businessTripPDFVerifyURL() = %q, want %q", got, want)
+	}
+}
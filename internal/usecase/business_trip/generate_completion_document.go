@@ -0,0 +1,220 @@
+package business_trip
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+
+	"github.com/fumiama/go-docx"
+)
+
+var indonesianMonths = []string{"", "Januari", "Februari", "Maret", "April", "Mei", "Juni", "Juli", "Agustus", "September", "Oktober", "November", "Desember"}
+
+var verificatorStatusLabels = map[entity.VerificatorStatus]string{
+	entity.VerificatorStatusPending:  "Menunggu",
+	entity.VerificatorStatusApproved: "Disetujui",
+	entity.VerificatorStatusRejected: "Ditolak",
+}
+
+// GenerateCompletionDocumentUseCase renders the completion report (laporan realisasi) of a completed business trip
+type GenerateCompletionDocumentUseCase struct {
+	businessTripRepo repository.BusinessTripRepository
+	templatePath     string
+	now              func() time.Time
+}
+
+// NewGenerateCompletionDocumentUseCase creates a use case that fills the DOCX template at templatePath,
+// or the built-in layout when templatePath is empty
+func NewGenerateCompletionDocumentUseCase(businessTripRepo repository.BusinessTripRepository, templatePath string) *GenerateCompletionDocumentUseCase {
+	return &GenerateCompletionDocumentUseCase{
+		businessTripRepo: businessTripRepo,
+		templatePath:     templatePath,
+		now:              time.Now,
+	}
+}
+
+// Execute returns the completion document of the trip as DOCX. The template is read on every call so it
+// can be replaced without a restart.
+func (uc *GenerateCompletionDocumentUseCase) Execute(ctx context.Context, tripID string) ([]byte, error) {
+	bt, err := uc.businessTripRepo.GetByID(ctx, tripID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get business trip: %w", err)
+	}
+	if bt == nil {
+		return nil, entity.ErrBusinessTripNotFound
+	}
+	if bt.Status != entity.BusinessTripStatusCompleted {
+		return nil, entity.ErrTripNotCompleted
+	}
+
+	fields := completionDocumentFields(bt, uc.now())
+
+	if uc.templatePath == "" {
+		return builtInCompletionDocument(bt, fields)
+	}
+
+	template, err := os.ReadFile(uc.templatePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read completion template: %w", err)
+	}
+	return fillDocxTemplate(template, fields)
+}
+
+// completionDocumentFields maps every placeholder a completion template can use to its value for bt.
+// Credits are deducted from the totals of their type. Keep DEPLOYMENT.md in sync when adding fields.
+func completionDocumentFields(bt *entity.BusinessTrip, now time.Time) map[string]string {
+	totals := make(map[entity.TransactionType]float64)
+	assignees := make([]string, 0, len(bt.Assignees))
+	for i, assignee := range bt.Assignees {
+		for _, tx := range assignee.Transactions {
+			totals[tx.Type] += tx.SignedSubtotal()
+		}
+		assignees = append(assignees, fmt.Sprintf("%d. %s (NIP %s) - %s: %s",
+			i+1, assignee.Name, assignee.EmployeeNumber, assignee.Position, formatRupiah(assignee.GetTotalCost())))
+	}
+
+	verificators := make([]string, 0, len(bt.Verificators))
+	for i, v := range bt.Verificators {
+		line := fmt.Sprintf("%d. %s - %s: %s", i+1, v.UserName, v.Position, verificatorStatusLabels[v.Status])
+		if v.VerifiedAt != nil {
+			line += " (" + formatIndonesianDate(*v.VerifiedAt) + ")"
+		}
+		verificators = append(verificators, line)
+	}
+
+	return map[string]string{
+		"business_trip_number": bt.GetBusinessTripNumber(),
+		"activity_purpose":     bt.ActivityPurpose,
+		"destination_city":     bt.DestinationCity,
+		"spd_date":             formatIndonesianDate(bt.SPDDate),
+		"start_date":           formatIndonesianDate(bt.StartDate),
+		"end_date":             formatIndonesianDate(bt.EndDate),
+		"departure_date":       formatIndonesianDate(bt.DepartureDate),
+		"return_date":          formatIndonesianDate(bt.ReturnDate),
+		"duration_days":        strconv.Itoa(bt.DurationDays()),
+		"assignees":            strings.Join(assignees, "\n"),
+		"assignee_count":       strconv.Itoa(len(bt.Assignees)),
+		"verificators":         strings.Join(verificators, "\n"),
+		"total_accommodation":  formatRupiah(totals[entity.TransactionTypeAccommodation]),
+		"total_transport":      formatRupiah(totals[entity.TransactionTypeTransport]),
+		"total_allowance":      formatRupiah(totals[entity.TransactionTypeAllowance]),
+		"total_other":          formatRupiah(totals[entity.TransactionTypeOther]),
+		"total_cost":           formatRupiah(bt.GetTotalCost()),
+		"generated_date":       formatIndonesianDate(now),
+	}
+}
+
+// builtInCompletionDocument lays out the completion document when no template is configured
+func builtInCompletionDocument(bt *entity.BusinessTrip, fields map[string]string) ([]byte, error) {
+	f := docx.New().WithDefaultTheme()
+
+	p := f.AddParagraph().Justification("center")
+	p.AddText("LAPORAN REALISASI PERJALANAN DINAS").Bold().Size("24")
+	p = f.AddParagraph().Justification("center")
+	p.AddText("Nomor: " + fields["business_trip_number"]).Bold()
+
+	f.AddParagraph()
+
+	info := [][2]string{
+		{"Maksud Perjalanan", fields["activity_purpose"]},
+		{"Kota Tujuan", fields["destination_city"]},
+		{"Tanggal SPD", fields["spd_date"]},
+		{"Tanggal Kegiatan", fields["start_date"] + " s.d. " + fields["end_date"]},
+		{"Tanggal Berangkat", fields["departure_date"]},
+		{"Tanggal Kembali", fields["return_date"]},
+		{"Lama Perjalanan", fields["duration_days"] + " hari"},
+	}
+	for _, row := range info {
+		f.AddParagraph().AddText(row[0] + " : " + row[1])
+	}
+
+	f.AddParagraph()
+	f.AddParagraph().AddText("Pelaksana").Bold()
+	assigneeRows := [][]string{{"No", "Nama", "NIP", "Jabatan", "Jumlah"}}
+	for i, assignee := range bt.Assignees {
+		assigneeRows = append(assigneeRows, []string{
+			strconv.Itoa(i + 1), assignee.Name, assignee.EmployeeNumber, assignee.Position, formatRupiah(assignee.GetTotalCost()),
+		})
+	}
+	addTextTable(f, assigneeRows)
+
+	f.AddParagraph()
+	f.AddParagraph().AddText("Rincian Biaya").Bold()
+	addTextTable(f, [][]string{
+		{"Jenis", "Jumlah"},
+		{"Penginapan", fields["total_accommodation"]},
+		{"Transportasi", fields["total_transport"]},
+		{"Uang Harian", fields["total_allowance"]},
+		{"Lain-lain", fields["total_other"]},
+		{"Total", fields["total_cost"]},
+	})
+
+	f.AddParagraph()
+	f.AddParagraph().AddText("Verifikasi").Bold()
+	verificatorRows := [][]string{{"Nama", "Jabatan", "Status", "Tanggal"}}
+	for _, v := range bt.Verificators {
+		verifiedAt := "-"
+		if v.VerifiedAt != nil {
+			verifiedAt = formatIndonesianDate(*v.VerifiedAt)
+		}
+		verificatorRows = append(verificatorRows, []string{v.UserName, v.Position, verificatorStatusLabels[v.Status], verifiedAt})
+	}
+	addTextTable(f, verificatorRows)
+
+	f.AddParagraph()
+	f.AddParagraph().AddText("Dibuat pada " + fields["generated_date"])
+
+	var buf bytes.Buffer
+	if _, err := f.WriteTo(&buf); err != nil {
+		return nil, fmt.Errorf("failed to write completion document: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// addTextTable adds a table with a bold header row; every cell gets a paragraph so Word opens the file
+func addTextTable(f *docx.Docx, rows [][]string) {
+	table := f.AddTable(len(rows), len(rows[0]), 9000, nil)
+	for i, row := range table.TableRows {
+		for j, cell := range row.TableCells {
+			if len(cell.Paragraphs) == 0 {
+				cell.AddParagraph()
+			}
+			run := cell.Paragraphs[0].AddText(rows[i][j])
+			if i == 0 {
+				run.Bold()
+			}
+		}
+	}
+}
+
+func formatIndonesianDate(t time.Time) string {
+	return fmt.Sprintf("%d %s %d", t.Day(), indonesianMonths[t.Month()], t.Year())
+}
+
+// formatRupiah formats amount as whole rupiah with dots between thousands, e.g. Rp 1.250.000
+func formatRupiah(amount float64) string {
+	rounded := int64(math.Round(amount))
+	sign := ""
+	if rounded < 0 {
+		sign = "-"
+		rounded = -rounded
+	}
+
+	digits := strconv.FormatInt(rounded, 10)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(d)
+	}
+	return sign + "Rp " + b.String()
+}
@@ -0,0 +1,153 @@
+package business_trip
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fumiama/go-docx"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+)
+
+type stubCompletedTripRepository struct {
+	repository.BusinessTripRepository
+	trip *entity.BusinessTrip
+}
+
+func (r *stubCompletedTripRepository) GetByID(context.Context, string) (*entity.BusinessTrip, error) {
+	return r.trip, nil
+}
+
+func completedTrip() *entity.BusinessTrip {
+	verifiedAt := time.Date(2025, 3, 14, 9, 0, 0, 0, time.UTC)
+	return &entity.BusinessTrip{
+		ID:              "trip-1",
+		ActivityPurpose: "Monitoring <P2P> & evaluasi",
+		DestinationCity: "Surabaya",
+		StartDate:       time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC),
+		EndDate:         time.Date(2025, 3, 12, 0, 0, 0, 0, time.UTC),
+		DepartureDate:   time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC),
+		ReturnDate:      time.Date(2025, 3, 12, 0, 0, 0, 0, time.UTC),
+		Status:          entity.BusinessTripStatusCompleted,
+		Assignees: []*entity.Assignee{
+			{Name: "Budi", EmployeeNumber: "1001", Position: "Analis", Transactions: []*entity.Transaction{
+				{Type: entity.TransactionTypeAccommodation, Subtotal: 1500000},
+				{Type: entity.TransactionTypeAccommodation, Direction: entity.TransactionDirectionCredit, Subtotal: 250000},
+				{Type: entity.TransactionTypeTransport, Subtotal: 750000},
+			}},
+			{Name: "Sari", EmployeeNumber: "1002", Position: "Auditor", Transactions: []*entity.Transaction{
+				{Type: entity.TransactionTypeAllowance, Subtotal: 450000},
+			}},
+		},
+		Verificators: []*entity.Verificator{
+			{UserName: "Ani", Position: "Kepala Bagian", Status: entity.VerificatorStatusApproved, VerifiedAt: &verifiedAt},
+		},
+	}
+}
+
+// writeCompletionTemplate builds a DOCX template with one placeholder per paragraph
+func writeCompletionTemplate(t *testing.T, placeholders ...string) string {
+	t.Helper()
+	f := docx.New().WithDefaultTheme()
+	for _, placeholder := range placeholders {
+		f.AddParagraph().AddText(placeholder)
+	}
+
+	path := filepath.Join(t.TempDir(), "completion.docx")
+	out, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+	if _, err := f.WriteTo(out); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func documentXML(t *testing.T, data []byte) string {
+	t.Helper()
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("generated document is not a zip: %v", err)
+	}
+	for _, file := range reader.File {
+		if file.Name != "word/document.xml" {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rc.Close()
+		content, err := io.ReadAll(rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(content)
+	}
+	t.Fatal("generated document has no word/document.xml")
+	return ""
+}
+
+func TestGenerateCompletionDocumentFillsTemplate(t *testing.T) {
+	path := writeCompletionTemplate(t,
+		"Tujuan: {{activity_purpose}}", "Berangkat {{ departure_date }}", "{{total_accommodation}}",
+		"{{total_cost}}", "{{assignees}}", "{{verificators}}", "{{unknown_field}}")
+	uc := NewGenerateCompletionDocumentUseCase(&stubCompletedTripRepository{trip: completedTrip()}, path)
+
+	data, err := uc.Execute(context.Background(), "trip-1")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	body := documentXML(t, data)
+	for _, want := range []string{
+		"Tujuan: Monitoring &lt;P2P&gt; &amp; evaluasi",
+		"Berangkat 10 Maret 2025",
+		"Rp 1.250.000",
+		"Rp 2.450.000",
+		"1. Budi (NIP 1001) - Analis: Rp 2.000.000</w:t><w:br/>",
+		"1. Ani - Kepala Bagian: Disetujui (14 Maret 2025)",
+		"{{unknown_field}}",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("document does not contain %q", want)
+		}
+	}
+}
+
+func TestGenerateCompletionDocumentBuiltInLayout(t *testing.T) {
+	uc := NewGenerateCompletionDocumentUseCase(&stubCompletedTripRepository{trip: completedTrip()}, "")
+
+	data, err := uc.Execute(context.Background(), "trip-1")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	body := documentXML(t, data)
+	for _, want := range []string{"LAPORAN REALISASI PERJALANAN DINAS", "Surabaya", "Rp 2.450.000", "Disetujui"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("document does not contain %q", want)
+		}
+	}
+}
+
+func TestGenerateCompletionDocumentRequiresCompletedTrip(t *testing.T) {
+	trip := completedTrip()
+	trip.Status = entity.BusinessTripStatusOngoing
+	uc := NewGenerateCompletionDocumentUseCase(&stubCompletedTripRepository{trip: trip}, "")
+
+	if _, err := uc.Execute(context.Background(), "trip-1"); !errors.Is(err, entity.ErrTripNotCompleted) {
+		t.Errorf("Execute() error = %v, want ErrTripNotCompleted", err)
+	}
+}
@@ -48,13 +48,18 @@ func (uc *GetAssigneeUseCase) Execute(ctx context.Context, assigneeID string) (*
 			Name:            transaction.Name,
 			Type:            string(transaction.Type),
 			Subtype:         string(transaction.Subtype),
+			Direction:       string(transaction.GetDirection()),
 			Amount:          transaction.Amount,
 			TotalNight:      transaction.TotalNight,
+			TotalDays:       transaction.TotalDays,
 			Subtotal:        transaction.Subtotal,
 			Description:     transaction.Description,
 			TransportDetail: transaction.TransportDetail,
 			CreatedAt:       transaction.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 			UpdatedAt:       transaction.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			Currency:        transaction.Currency,
+			ExchangeRate:    transaction.GetExchangeRate(),
+			BaseAmount:      transaction.BaseAmount(),
 		}
 	}
 
@@ -5,18 +5,27 @@ import (
 
 	"sandbox/internal/domain/entity"
 	"sandbox/internal/domain/repository"
+	"sandbox/pkg/logging"
 )
 
 type GetBusinessTripUseCase struct {
-	businessTripRepo repository.BusinessTripRepository
+	businessTripRepo   repository.BusinessTripRepository
+	meetingRepo        repository.MeetingRepository
+	verificatorPreview int
 }
 
-func NewGetBusinessTripUseCase(businessTripRepo repository.BusinessTripRepository) *GetBusinessTripUseCase {
+// NewGetBusinessTripUseCase creates the trip detail use case. The detail embeds at most
+// verificatorPreview verificators, or all of them when it is 0.
+func NewGetBusinessTripUseCase(businessTripRepo repository.BusinessTripRepository, meetingRepo repository.MeetingRepository, verificatorPreview int) *GetBusinessTripUseCase {
 	return &GetBusinessTripUseCase{
-		businessTripRepo: businessTripRepo,
+		businessTripRepo:   businessTripRepo,
+		meetingRepo:        meetingRepo,
+		verificatorPreview: verificatorPreview,
 	}
 }
 
+// Execute returns the trip detail without its meeting summary, so a poll answered with 304 Not Modified
+// costs no Zoom call; LoadMeeting adds the summary to a detail that is sent
 func (uc *GetBusinessTripUseCase) Execute(ctx context.Context, id string) (*BusinessTripResponse, error) {
 	businessTrip, err := uc.businessTripRepo.GetByID(ctx, id)
 	if err != nil {
@@ -26,5 +35,28 @@ func (uc *GetBusinessTripUseCase) Execute(ctx context.Context, id string) (*Busi
 		return nil, entity.ErrBusinessTripNotFound
 	}
 
-	return FromEntity(businessTrip), nil
+	response := FromEntity(businessTrip)
+	response.ETag = businessTrip.ETag()
+	if uc.verificatorPreview > 0 && len(response.Verificators) > uc.verificatorPreview {
+		// The full list is paged through GET /business-trips/{tripId}/verificators
+		response.Verificators = response.Verificators[:uc.verificatorPreview]
+		response.VerificatorsTruncated = true
+	}
+	return response, nil
+}
+
+// LoadMeeting looks up the Zoom meeting linked to the trip and adds its summary to response
+func (uc *GetBusinessTripUseCase) LoadMeeting(ctx context.Context, response *BusinessTripResponse) {
+	if response.MeetingID == "" || uc.meetingRepo == nil {
+		return
+	}
+
+	// The trip is still worth showing when Zoom is unavailable, so only the meeting ID is returned then
+	meeting, err := uc.meetingRepo.GetZoomMeeting(ctx, response.MeetingID)
+	if err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "failed to get meeting of business trip",
+			"meeting_id", response.MeetingID, "business_trip_id", response.ID, "error", err)
+		return
+	}
+	response.Meeting = toMeetingSummary(meeting)
 }
@@ -33,19 +33,19 @@ func (uc *GetBusinessTripSummaryUseCase) Execute(ctx context.Context, businessTr
 		return nil, err
 	}
 
-	costByType := make(map[string]float64)
-	totalTransactions := 0
+	breakdown, err := uc.businessTripRepo.GetCostBreakdownByTrip(ctx, businessTripID)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, assignee := range assignees {
-		transactions, err := uc.businessTripRepo.GetTransactionsByAssigneeID(ctx, assignee.GetID())
-		if err != nil {
-			return nil, err
-		}
+	costByType := make(map[string]float64, len(breakdown))
+	countByType := make(map[string]int, len(breakdown))
+	totalTransactions := 0
 
-		for _, tx := range transactions {
-			costByType[string(tx.GetType())] += tx.GetSubtotal()
-			totalTransactions++
-		}
+	for _, typeCost := range breakdown {
+		costByType[typeCost.TransactionType] = typeCost.TotalAmount
+		countByType[typeCost.TransactionType] = int(typeCost.TransactionCount)
+		totalTransactions += int(typeCost.TransactionCount)
 	}
 
 	return &BusinessTripSummary{
@@ -54,5 +54,6 @@ func (uc *GetBusinessTripSummaryUseCase) Execute(ctx context.Context, businessTr
 		TotalAssignees:    len(assignees),
 		TotalTransactions: totalTransactions,
 		CostByType:        costByType,
+		CountByType:       countByType,
 	}, nil
 }
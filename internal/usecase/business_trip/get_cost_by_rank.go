@@ -0,0 +1,33 @@
+package business_trip
+
+import (
+	"context"
+	"time"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+)
+
+// CostByRankResponse holds the trip spend per assignee rank, highest spend first
+type CostByRankResponse struct {
+	Ranks     []*repository.RankCostData `json:"ranks"`
+	TotalCost float64                    `json:"total_cost"`
+}
+
+// GetCostByRank groups the spend of business trips in the date range by the rank of their assignees
+func (uc *GetDashboardUseCase) GetCostByRank(ctx context.Context, startDate, endDate *time.Time, destination string) (*CostByRankResponse, error) {
+	if startDate != nil && endDate != nil && startDate.After(*endDate) {
+		return nil, entity.ErrInvalidDateRange
+	}
+
+	ranks, err := uc.businessTripRepo.GetCostByRank(ctx, startDate, endDate, destination)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CostByRankResponse{Ranks: ranks}
+	for _, rank := range ranks {
+		response.TotalCost += rank.TotalCost
+	}
+	return response, nil
+}
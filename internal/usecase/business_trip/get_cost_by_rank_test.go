@@ -0,0 +1,47 @@
+package business_trip
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+)
+
+type stubRankCostRepository struct {
+	repository.BusinessTripRepository
+	called bool
+}
+
+func (r *stubRankCostRepository) GetCostByRank(context.Context, *time.Time, *time.Time, string) ([]*repository.RankCostData, error) {
+	r.called = true
+	return []*repository.RankCostData{
+		{Rank: "IV/a", TotalCost: 3000, TripCount: 2, AssigneeCount: 2, AverageCostPerAssignee: 1500},
+		{Rank: "III/b", TotalCost: 500, TripCount: 1, AssigneeCount: 1, AverageCostPerAssignee: 500},
+	}, nil
+}
+
+func TestGetCostByRank(t *testing.T) {
+	repo := &stubRankCostRepository{}
+	uc := NewGetDashboardUseCase(repo, nil, nil)
+
+	response, err := uc.GetCostByRank(context.Background(), nil, nil, "")
+	if err != nil {
+		t.Fatalf("GetCostByRank() error = %v", err)
+	}
+	if len(response.Ranks) != 2 || response.TotalCost != 3500 {
+		t.Errorf("GetCostByRank() = %d ranks totalling %v, want 2 ranks totalling 3500", len(response.Ranks), response.TotalCost)
+	}
+
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, -1, 0)
+	repo.called = false
+	if _, err := uc.GetCostByRank(context.Background(), &start, &end, ""); !errors.Is(err, entity.ErrInvalidDateRange) {
+		t.Errorf("GetCostByRank() with start after end error = %v, want ErrInvalidDateRange", err)
+	}
+	if repo.called {
+		t.Error("repository queried for an invalid date range")
+	}
+}
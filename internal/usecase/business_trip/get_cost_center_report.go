@@ -0,0 +1,76 @@
+package business_trip
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+)
+
+// GetCostCenterReportUseCase sums allocated transaction cost per cost center
+type GetCostCenterReportUseCase struct {
+	transactionRepo repository.BusinessTripTransactionRepository
+}
+
+// NewGetCostCenterReportUseCase creates a new use case instance
+func NewGetCostCenterReportUseCase(transactionRepo repository.BusinessTripTransactionRepository) *GetCostCenterReportUseCase {
+	return &GetCostCenterReportUseCase{
+		transactionRepo: transactionRepo,
+	}
+}
+
+// GetCostCenterReportRequest represents the date range of the report, matched against the trip start date
+type GetCostCenterReportRequest struct {
+	StartDate *time.Time `json:"start_date,omitempty"`
+	EndDate   *time.Time `json:"end_date,omitempty"`
+}
+
+// CostCenterReportItem represents the cost allocated to one cost center
+type CostCenterReportItem struct {
+	CostCenter       string  `json:"cost_center"`
+	TransactionCount int64   `json:"transaction_count"`
+	AllocatedAmount  float64 `json:"allocated_amount"`
+}
+
+// GetCostCenterReportResponse represents the cost center report
+type GetCostCenterReportResponse struct {
+	StartDate   string                 `json:"start_date,omitempty"`
+	EndDate     string                 `json:"end_date,omitempty"`
+	CostCenters []CostCenterReportItem `json:"cost_centers"`
+	TotalAmount float64                `json:"total_amount"`
+}
+
+// Execute builds the cost center report. Only transactions with allocation splits are included.
+func (uc *GetCostCenterReportUseCase) Execute(ctx context.Context, req GetCostCenterReportRequest) (*GetCostCenterReportResponse, error) {
+	if req.StartDate != nil && req.EndDate != nil && req.StartDate.After(*req.EndDate) {
+		return nil, entity.ErrInvalidDateRange
+	}
+
+	data, err := uc.transactionRepo.GetCostCenterAllocations(ctx, req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cost center allocations: %w", err)
+	}
+
+	response := &GetCostCenterReportResponse{
+		CostCenters: make([]CostCenterReportItem, len(data)),
+	}
+	if req.StartDate != nil {
+		response.StartDate = req.StartDate.Format("2006-01-02")
+	}
+	if req.EndDate != nil {
+		response.EndDate = req.EndDate.Format("2006-01-02")
+	}
+
+	for i, item := range data {
+		response.CostCenters[i] = CostCenterReportItem{
+			CostCenter:       item.CostCenter,
+			TransactionCount: item.TransactionCount,
+			AllocatedAmount:  item.AllocatedAmount,
+		}
+		response.TotalAmount += item.AllocatedAmount
+	}
+
+	return response, nil
+}
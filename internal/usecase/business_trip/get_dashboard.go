@@ -203,7 +203,12 @@ func (uc *GetDashboardUseCase) getMonthlyStats(ctx context.Context, req GetDashb
 	now := time.Now()
 	twelveMonthsAgo := now.AddDate(-12, 0, 0)
 
-	monthlyData, err := uc.businessTripRepo.GetMonthlyStats(ctx, twelveMonthsAgo, now, req.Destination)
+	return uc.monthlyStatsBetween(ctx, twelveMonthsAgo, now, req.Destination)
+}
+
+// monthlyStatsBetween retrieves monthly business trip statistics for the given period
+func (uc *GetDashboardUseCase) monthlyStatsBetween(ctx context.Context, startDate, endDate time.Time, destination string) ([]MonthlyStats, error) {
+	monthlyData, err := uc.businessTripRepo.GetMonthlyStats(ctx, startDate, endDate, destination)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,95 @@
+package business_trip
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"sandbox/internal/domain/repository"
+)
+
+// DashboardSection holds one statistic of the dashboard summary. Error is set instead of Data when
+// that statistic failed to load, so the rest of the dashboard can still be shown.
+type DashboardSection[T any] struct {
+	Data  T      `json:"data"`
+	Error string `json:"error,omitempty"`
+}
+
+// DashboardSummaryResponse combines all dashboard statistics in a single response
+type DashboardSummaryResponse struct {
+	StatusCounts         DashboardSection[*repository.StatusCounts] `json:"status_counts"`
+	TotalCost            DashboardSection[float64]                  `json:"total_cost"`
+	DestinationStats     DashboardSection[[]DestinationStats]       `json:"destination_stats"`
+	MonthlyStats         DashboardSection[[]MonthlyStats]           `json:"monthly_stats"`
+	TransactionTypeStats DashboardSection[[]TransactionTypeStats]   `json:"transaction_type_stats"`
+	UpcomingCount        DashboardSection[int64]                    `json:"upcoming_count"`
+}
+
+// load runs fetch and stores its result or error in the section
+func (s *DashboardSection[T]) load(fetch func() (T, error)) {
+	data, err := fetch()
+	if err != nil {
+		s.Error = err.Error()
+		return
+	}
+	s.Data = data
+}
+
+// GetDashboardSummary loads every dashboard statistic concurrently. A failing statistic only sets the
+// error of its own section. Monthly stats cover the last 12 months unless both dates are given.
+func (uc *GetDashboardUseCase) GetDashboardSummary(ctx context.Context, startDate, endDate *time.Time, destination string) *DashboardSummaryResponse {
+	req := GetDashboardRequest{
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Destination: destination,
+	}
+
+	response := &DashboardSummaryResponse{}
+	g, ctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		response.StatusCounts.load(func() (*repository.StatusCounts, error) {
+			return uc.businessTripRepo.GetStatusCounts(ctx, startDate, endDate, destination)
+		})
+		return nil
+	})
+	g.Go(func() error {
+		response.TotalCost.load(func() (float64, error) {
+			return uc.businessTripRepo.GetTotalCost(ctx, startDate, endDate, destination)
+		})
+		return nil
+	})
+	g.Go(func() error {
+		response.DestinationStats.load(func() ([]DestinationStats, error) {
+			return uc.getDestinationStats(ctx, req)
+		})
+		return nil
+	})
+	g.Go(func() error {
+		response.MonthlyStats.load(func() ([]MonthlyStats, error) {
+			if startDate != nil && endDate != nil {
+				return uc.monthlyStatsBetween(ctx, *startDate, *endDate, destination)
+			}
+			return uc.getMonthlyStats(ctx, req)
+		})
+		return nil
+	})
+	g.Go(func() error {
+		response.TransactionTypeStats.load(func() ([]TransactionTypeStats, error) {
+			return uc.getTransactionTypeStats(ctx, req)
+		})
+		return nil
+	})
+	g.Go(func() error {
+		response.UpcomingCount.load(func() (int64, error) {
+			return uc.businessTripRepo.GetUpcomingCount(ctx)
+		})
+		return nil
+	})
+
+	// Sections record their own errors, so the group never fails
+	_ = g.Wait()
+
+	return response
+}
@@ -0,0 +1,69 @@
+package business_trip
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"sandbox/internal/domain/repository"
+)
+
+// stubDashboardRepository serves fixed dashboard statistics and fails the monthly stats
+type stubDashboardRepository struct {
+	repository.BusinessTripRepository
+}
+
+// stubTypeStatsRepository serves fixed transaction type statistics
+type stubTypeStatsRepository struct {
+	repository.BusinessTripTransactionRepository
+}
+
+func (r *stubDashboardRepository) GetStatusCounts(context.Context, *time.Time, *time.Time, string) (*repository.StatusCounts, error) {
+	return &repository.StatusCounts{Total: 3, Completed: 1}, nil
+}
+
+func (r *stubDashboardRepository) GetTotalCost(context.Context, *time.Time, *time.Time, string) (float64, error) {
+	return 1500, nil
+}
+
+func (r *stubDashboardRepository) GetMonthlyStats(context.Context, time.Time, time.Time, string) ([]*repository.MonthlyData, error) {
+	return nil, errors.New("monthly stats unavailable")
+}
+
+func (r *stubDashboardRepository) GetDestinationStats(context.Context, *time.Time, *time.Time, string) ([]*repository.DestinationData, error) {
+	return []*repository.DestinationData{{Destination: "Bandung", TotalTrips: 3, TotalCost: 1500}}, nil
+}
+
+func (r *stubDashboardRepository) GetUpcomingCount(context.Context) (int64, error) {
+	return 2, nil
+}
+
+func (r *stubTypeStatsRepository) GetTypeStats(context.Context, *time.Time, *time.Time) ([]*repository.TransactionTypeData, error) {
+	return []*repository.TransactionTypeData{{TransactionType: "transport", TotalTransactions: 2, TotalAmount: 1000}}, nil
+}
+
+func TestGetDashboardSummaryKeepsOtherSectionsWhenOneFails(t *testing.T) {
+	uc := NewGetDashboardUseCase(&stubDashboardRepository{}, nil, &stubTypeStatsRepository{})
+
+	summary := uc.GetDashboardSummary(context.Background(), nil, nil, "")
+
+	if summary.MonthlyStats.Error == "" {
+		t.Error("MonthlyStats.Error is empty, want the repository error")
+	}
+	if summary.StatusCounts.Error != "" || summary.StatusCounts.Data.Total != 3 {
+		t.Errorf("StatusCounts = %+v, want total 3 without error", summary.StatusCounts)
+	}
+	if summary.TotalCost.Error != "" || summary.TotalCost.Data != 1500 {
+		t.Errorf("TotalCost = %+v, want 1500 without error", summary.TotalCost)
+	}
+	if summary.DestinationStats.Error != "" || len(summary.DestinationStats.Data) != 1 {
+		t.Errorf("DestinationStats = %+v, want one destination without error", summary.DestinationStats)
+	}
+	if summary.TransactionTypeStats.Error != "" || len(summary.TransactionTypeStats.Data) != 1 {
+		t.Errorf("TransactionTypeStats = %+v, want one type without error", summary.TransactionTypeStats)
+	}
+	if summary.UpcomingCount.Error != "" || summary.UpcomingCount.Data != 2 {
+		t.Errorf("UpcomingCount = %+v, want 2 without error", summary.UpcomingCount)
+	}
+}
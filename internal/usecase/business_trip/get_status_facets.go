@@ -0,0 +1,34 @@
+package business_trip
+
+import (
+	"context"
+	"time"
+
+	"sandbox/internal/domain/entity"
+)
+
+// StatusFacetsResponse holds the number of business trips per status for filter UIs
+type StatusFacetsResponse struct {
+	Statuses map[entity.BusinessTripStatus]int64 `json:"statuses"`
+	Total    int64                               `json:"total"`
+}
+
+// GetStatusFacets counts business trips per status. Every status is present in the response, with a
+// zero count when no trip has it, so filter UIs can render a stable list.
+func (uc *GetDashboardUseCase) GetStatusFacets(ctx context.Context, startDate, endDate *time.Time, destination string) (*StatusFacetsResponse, error) {
+	counts, err := uc.businessTripRepo.GetStatusCounts(ctx, startDate, endDate, destination)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StatusFacetsResponse{
+		Statuses: map[entity.BusinessTripStatus]int64{
+			entity.BusinessTripStatusDraft:         counts.Draft,
+			entity.BusinessTripStatusReadyToVerify: counts.ReadyToVerify,
+			entity.BusinessTripStatusOngoing:       counts.Ongoing,
+			entity.BusinessTripStatusCompleted:     counts.Completed,
+			entity.BusinessTripStatusCanceled:      counts.Canceled,
+		},
+		Total: counts.Total,
+	}, nil
+}
@@ -0,0 +1,37 @@
+package business_trip
+
+import (
+	"context"
+	"testing"
+
+	"sandbox/internal/domain/entity"
+)
+
+func TestGetStatusFacetsIncludesEmptyStatuses(t *testing.T) {
+	uc := NewGetDashboardUseCase(&stubDashboardRepository{}, nil, nil)
+
+	facets, err := uc.GetStatusFacets(context.Background(), nil, nil, "")
+	if err != nil {
+		t.Fatalf("GetStatusFacets() error = %v", err)
+	}
+
+	if facets.Total != 3 {
+		t.Errorf("total = %d, want 3", facets.Total)
+	}
+	want := map[entity.BusinessTripStatus]int64{
+		entity.BusinessTripStatusDraft:         0,
+		entity.BusinessTripStatusReadyToVerify: 0,
+		entity.BusinessTripStatusOngoing:       0,
+		entity.BusinessTripStatusCompleted:     1,
+		entity.BusinessTripStatusCanceled:      0,
+	}
+	if len(facets.Statuses) != len(want) {
+		t.Fatalf("statuses = %v, want %v", facets.Statuses, want)
+	}
+	for status, count := range want {
+		got, ok := facets.Statuses[status]
+		if !ok || got != count {
+			t.Errorf("statuses[%s] = %d (present %v), want %d", status, got, ok, count)
+		}
+	}
+}
@@ -23,13 +23,23 @@ type GetTransactionResponse struct {
 	Name            string  `json:"name"`
 	Type            string  `json:"type"`
 	Subtype         string  `json:"subtype"`
+	Direction       string  `json:"direction"`
 	Amount          float64 `json:"amount"`
 	TotalNight      *int    `json:"totalNight,omitempty"`
+	TotalDays       *int    `json:"totalDays,omitempty"`
 	Subtotal        float64 `json:"subtotal"`
 	Description     string  `json:"description,omitempty"`
 	TransportDetail string  `json:"transportDetail,omitempty"`
 	CreatedAt       string  `json:"createdAt"`
 	UpdatedAt       string  `json:"updatedAt"`
+
+	// Amount is in Currency; BaseAmount and Subtotal are converted to the base currency with ExchangeRate
+	Currency     string  `json:"currency"`
+	ExchangeRate float64 `json:"exchangeRate"`
+	BaseAmount   float64 `json:"baseAmount"`
+
+	Allocations []AllocationResponse `json:"allocations,omitempty"`
+	Attachments []AttachmentResponse `json:"attachments,omitempty"`
 }
 
 func (uc *GetTransactionUseCase) Execute(ctx context.Context, transactionID string) (*GetTransactionResponse, error) {
@@ -47,12 +57,19 @@ func (uc *GetTransactionUseCase) Execute(ctx context.Context, transactionID stri
 		Name:            transaction.Name,
 		Type:            string(transaction.Type),
 		Subtype:         string(transaction.Subtype),
+		Direction:       string(transaction.GetDirection()),
 		Amount:          transaction.Amount,
 		TotalNight:      transaction.TotalNight,
+		TotalDays:       transaction.TotalDays,
 		Subtotal:        transaction.Subtotal,
 		Description:     transaction.Description,
 		TransportDetail: transaction.TransportDetail,
 		CreatedAt:       transaction.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:       transaction.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Currency:        transaction.Currency,
+		ExchangeRate:    transaction.GetExchangeRate(),
+		BaseAmount:      transaction.BaseAmount(),
+		Allocations:     toAllocationResponses(transaction),
+		Attachments:     toAttachmentResponses(transaction.GetAttachments()),
 	}, nil
 }
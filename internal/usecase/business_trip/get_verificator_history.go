@@ -0,0 +1,68 @@
+package business_trip
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+)
+
+// VerificatorStatusHistoryResponse represents one status change of a verificator
+type VerificatorStatusHistoryResponse struct {
+	ID         string  `json:"id"`
+	FromStatus string  `json:"from_status"`
+	ToStatus   string  `json:"to_status"`
+	Notes      string  `json:"notes"`
+	ChangedBy  *string `json:"changed_by"`
+	ChangedAt  string  `json:"changed_at"`
+}
+
+type GetVerificatorHistoryUseCase struct {
+	businessTripRepo repository.BusinessTripRepository
+}
+
+func NewGetVerificatorHistoryUseCase(businessTripRepo repository.BusinessTripRepository) *GetVerificatorHistoryUseCase {
+	return &GetVerificatorHistoryUseCase{
+		businessTripRepo: businessTripRepo,
+	}
+}
+
+// Execute returns the status changes of a verificator, oldest first
+func (uc *GetVerificatorHistoryUseCase) Execute(ctx context.Context, verificatorID string) ([]*VerificatorStatusHistoryResponse, error) {
+	verificator, err := uc.businessTripRepo.GetVerificatorByID(ctx, verificatorID)
+	if err != nil {
+		return nil, err
+	}
+	if verificator == nil {
+		return nil, fmt.Errorf("%w: %s", entity.ErrVerificatorNotFound, verificatorID)
+	}
+
+	history, err := uc.businessTripRepo.GetVerificatorHistory(ctx, verificatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*VerificatorStatusHistoryResponse, 0, len(history))
+	for _, entry := range history {
+		responses = append(responses, &VerificatorStatusHistoryResponse{
+			ID:         entry.ID,
+			FromStatus: string(entry.FromStatus),
+			ToStatus:   string(entry.ToStatus),
+			Notes:      entry.Notes,
+			ChangedBy:  entry.ChangedBy,
+			ChangedAt:  entry.ChangedAt.Format(time.RFC3339),
+		})
+	}
+
+	return responses, nil
+}
+
+// optionalUserID returns nil for an unknown user so the history stores NULL instead of an empty ID
+func optionalUserID(userID string) *string {
+	if userID == "" {
+		return nil
+	}
+	return &userID
+}
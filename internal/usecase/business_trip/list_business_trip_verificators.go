@@ -0,0 +1,73 @@
+package business_trip
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/pkg/pagination"
+)
+
+// ListBusinessTripVerificatorsUseCase pages through the verificators of one business trip, for trips
+// with more verificators than the trip detail embeds
+type ListBusinessTripVerificatorsUseCase struct {
+	businessTripRepo repository.BusinessTripRepository
+}
+
+func NewListBusinessTripVerificatorsUseCase(businessTripRepo repository.BusinessTripRepository) *ListBusinessTripVerificatorsUseCase {
+	return &ListBusinessTripVerificatorsUseCase{
+		businessTripRepo: businessTripRepo,
+	}
+}
+
+// Execute lists a page of the trip's verificators, oldest first unless params sort otherwise
+func (uc *ListBusinessTripVerificatorsUseCase) Execute(ctx context.Context, businessTripID string, params *pagination.QueryParams) ([]VerificatorResponse, *pagination.PagedResponse, error) {
+	businessTrip, err := uc.businessTripRepo.GetByID(ctx, businessTripID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get business trip: %w", err)
+	}
+	if businessTrip == nil {
+		return nil, nil, fmt.Errorf("%w: %s", entity.ErrBusinessTripNotFound, businessTripID)
+	}
+
+	params.Filters = append(params.Filters, pagination.Filter{Field: "v.business_trip_id", Operator: "eq", Value: businessTripID})
+	if len(params.Sorts) == 0 {
+		params.Sorts = []pagination.Sort{{Field: "v.created_at", Order: "asc"}}
+	}
+
+	verificators, totalCount, err := uc.businessTripRepo.ListVerificators(ctx, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]VerificatorResponse, len(verificators))
+	for i, v := range verificators {
+		responses[i] = VerificatorResponse{
+			ID:                v.ID,
+			BusinessTripID:    v.BusinessTripID,
+			UserID:            v.UserID,
+			UserName:          v.UserName,
+			EmployeeNumber:    v.EmployeeNumber,
+			Position:          v.Position,
+			Status:            string(v.Status),
+			VerificationNotes: v.VerificationNotes,
+			CreatedAt:         v.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:         v.UpdatedAt.Format(time.RFC3339),
+		}
+		if v.VerifiedAt != nil {
+			verifiedAt := v.VerifiedAt.Format(time.RFC3339)
+			responses[i].VerifiedAt = &verifiedAt
+		}
+	}
+
+	metadata := pagination.BuildMetadata(totalCount, params.Pagination.Page, params.Pagination.Limit)
+
+	return responses, &pagination.PagedResponse{
+		Page:       params.Pagination.Page,
+		Limit:      params.Pagination.Limit,
+		TotalItems: totalCount,
+		TotalPages: metadata.TotalPage,
+	}, nil
+}
@@ -0,0 +1,92 @@
+package business_trip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/pkg/pagination"
+)
+
+// stubVerificatorListRepository records the query it was asked to list verificators with
+type stubVerificatorListRepository struct {
+	stubBusinessTripRepository
+	params *pagination.QueryParams
+}
+
+func (r *stubVerificatorListRepository) ListVerificators(_ context.Context, params *pagination.QueryParams) ([]*entity.VerificatorWithBusinessTrip, int64, error) {
+	r.params = params
+	return []*entity.VerificatorWithBusinessTrip{{ID: "v-1", BusinessTripID: r.trip.ID, Status: entity.VerificatorStatusPending}}, 41, nil
+}
+
+func TestListBusinessTripVerificators(t *testing.T) {
+	trip := newTestBusinessTrip(t)
+	repo := &stubVerificatorListRepository{stubBusinessTripRepository: stubBusinessTripRepository{trip: trip}}
+	params := &pagination.QueryParams{Pagination: pagination.Pagination{Page: 2, Limit: 20}}
+
+	got, page, err := NewListBusinessTripVerificatorsUseCase(repo).Execute(context.Background(), trip.ID, params)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	tripFilter := pagination.Filter{Field: "v.business_trip_id", Operator: "eq", Value: trip.ID}
+	if len(repo.params.Filters) != 1 || repo.params.Filters[0] != tripFilter {
+		t.Errorf("Filters = %+v, want only the trip filter", repo.params.Filters)
+	}
+	if len(repo.params.Sorts) != 1 || repo.params.Sorts[0] != (pagination.Sort{Field: "v.created_at", Order: "asc"}) {
+		t.Errorf("Sorts = %+v, want oldest first", repo.params.Sorts)
+	}
+	if len(got) != 1 || got[0].ID != "v-1" || got[0].Status != "pending" {
+		t.Errorf("Execute() = %+v, want the repository's verificator", got)
+	}
+	if page.TotalItems != 41 || page.TotalPages != 3 || page.Page != 2 {
+		t.Errorf("page = %+v, want 41 items on 3 pages", page)
+	}
+}
+
+func TestListBusinessTripVerificatorsTripNotFound(t *testing.T) {
+	repo := &stubVerificatorListRepository{}
+	_, _, err := NewListBusinessTripVerificatorsUseCase(repo).Execute(context.Background(), "missing", &pagination.QueryParams{})
+	if !errors.Is(err, entity.ErrBusinessTripNotFound) {
+		t.Fatalf("Execute() error = %v, want ErrBusinessTripNotFound", err)
+	}
+	if repo.params != nil {
+		t.Error("verificators were listed for a missing trip")
+	}
+}
+
+func TestGetBusinessTripVerificatorPreview(t *testing.T) {
+	tests := []struct {
+		name          string
+		preview       int
+		wantCount     int
+		wantTruncated bool
+	}{
+		{"capped", 3, 3, true},
+		{"exactly the preview", 7, 7, false},
+		{"no cap", 0, 7, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trip := newTestBusinessTrip(t)
+			for i := 0; i < 7; i++ {
+				trip.Verificators = append(trip.Verificators, &entity.Verificator{ID: fmt.Sprintf("v-%d", i), Status: entity.VerificatorStatusPending})
+			}
+
+			got, err := NewGetBusinessTripUseCase(&stubBusinessTripRepository{trip: trip}, nil, tt.preview).Execute(context.Background(), trip.ID)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if len(got.Verificators) != tt.wantCount || got.VerificatorsTruncated != tt.wantTruncated {
+				t.Errorf("Execute() embeds %d verificators (truncated %v), want %d (truncated %v)",
+					len(got.Verificators), got.VerificatorsTruncated, tt.wantCount, tt.wantTruncated)
+			}
+			if got.Verificators[0].ID != "v-0" {
+				t.Errorf("first verificator = %s, want v-0", got.Verificators[0].ID)
+			}
+		})
+	}
+}
@@ -23,21 +23,33 @@ func (uc *ListBusinessTripsUseCase) Execute(ctx context.Context, params *paginat
 		return nil, nil, err
 	}
 
+	// List does not load assignees, so their count and cost come from one grouped query for the page
+	tripIDs := make([]string, len(businessTrips))
+	for i, bt := range businessTrips {
+		tripIDs[i] = bt.ID
+	}
+	summaries, err := uc.businessTripRepo.GetListSummaries(ctx, tripIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// Convert entities to response DTOs
 	var responses []*BusinessTripResponse
 	for _, bt := range businessTrips {
-		responses = append(responses, FromEntity(bt))
+		response := FromEntity(bt)
+		if summary, ok := summaries[bt.ID]; ok {
+			response.AssigneeCount = summary.AssigneeCount
+			response.TotalCost = summary.TotalCost
+		}
+		responses = append(responses, response)
 	}
 
-	totalPages := int(totalCount) / params.Pagination.Limit
-	if int(totalCount)%params.Pagination.Limit > 0 {
-		totalPages++
-	}
+	metadata := pagination.BuildMetadata(totalCount, params.Pagination.Page, params.Pagination.Limit)
 
 	return responses, &pagination.PagedResponse{
 		Page:       params.Pagination.Page,
 		Limit:      params.Pagination.Limit,
 		TotalItems: totalCount,
-		TotalPages: totalPages,
+		TotalPages: metadata.TotalPage,
 	}, nil
 }
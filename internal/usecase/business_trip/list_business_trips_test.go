@@ -0,0 +1,52 @@
+package business_trip
+
+import (
+	"context"
+	"testing"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/pkg/pagination"
+)
+
+// stubListRepository lists trips without assignees, like the real List, and serves their summaries
+type stubListRepository struct {
+	repository.BusinessTripRepository
+	trips     []*entity.BusinessTrip
+	summaries map[string]*repository.BusinessTripListSummary
+	queried   []string
+}
+
+func (r *stubListRepository) List(context.Context, *pagination.QueryParams) ([]*entity.BusinessTrip, int64, error) {
+	return r.trips, int64(len(r.trips)), nil
+}
+
+func (r *stubListRepository) GetListSummaries(_ context.Context, ids []string) (map[string]*repository.BusinessTripListSummary, error) {
+	r.queried = ids
+	return r.summaries, nil
+}
+
+func TestListBusinessTripsIncludesAssigneeCountAndCost(t *testing.T) {
+	repo := &stubListRepository{
+		trips: []*entity.BusinessTrip{{ID: "trip-1"}, {ID: "trip-2"}},
+		summaries: map[string]*repository.BusinessTripListSummary{
+			"trip-1": {BusinessTripID: "trip-1", AssigneeCount: 2, TotalCost: 1750000},
+		},
+	}
+	params := &pagination.QueryParams{Pagination: pagination.Pagination{Page: 1, Limit: 10}}
+
+	responses, _, err := NewListBusinessTripsUseCase(repo).Execute(context.Background(), params)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(repo.queried) != 2 {
+		t.Errorf("summaries queried for %v, want both trips of the page", repo.queried)
+	}
+	if got := responses[0]; got.AssigneeCount != 2 || got.TotalCost != 1750000 {
+		t.Errorf("trip-1 assignee_count/total_cost = %d/%v, want 2/1750000", got.AssigneeCount, got.TotalCost)
+	}
+	if got := responses[1]; got.AssigneeCount != 0 || got.TotalCost != 0 {
+		t.Errorf("trip without assignees has assignee_count/total_cost = %d/%v, want 0/0", got.AssigneeCount, got.TotalCost)
+	}
+}
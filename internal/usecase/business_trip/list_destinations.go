@@ -0,0 +1,35 @@
+package business_trip
+
+import (
+	"context"
+
+	"sandbox/internal/domain/repository"
+)
+
+// Number of destinations returned for an autocomplete query
+const (
+	DefaultDestinationLimit = 10
+	MaxDestinationLimit     = 50
+)
+
+// ListDestinationsUseCase suggests destination cities for autocomplete
+type ListDestinationsUseCase struct {
+	businessTripRepo repository.BusinessTripRepository
+}
+
+func NewListDestinationsUseCase(businessTripRepo repository.BusinessTripRepository) *ListDestinationsUseCase {
+	return &ListDestinationsUseCase{
+		businessTripRepo: businessTripRepo,
+	}
+}
+
+// Execute returns the destinations starting with query with their trip counts, the most visited first.
+// A limit outside 1 to MaxDestinationLimit falls back to the default or the maximum.
+func (uc *ListDestinationsUseCase) Execute(ctx context.Context, query string, limit int) ([]*repository.DestinationSuggestion, error) {
+	if limit <= 0 {
+		limit = DefaultDestinationLimit
+	}
+	limit = min(limit, MaxDestinationLimit)
+
+	return uc.businessTripRepo.ListDistinctDestinations(ctx, query, limit)
+}
@@ -0,0 +1,50 @@
+package business_trip
+
+import (
+	"context"
+	"testing"
+
+	"sandbox/internal/domain/repository"
+)
+
+type stubDestinationRepository struct {
+	repository.BusinessTripRepository
+	prefix string
+	limit  int
+}
+
+func (r *stubDestinationRepository) ListDistinctDestinations(_ context.Context, prefix string, limit int) ([]*repository.DestinationSuggestion, error) {
+	r.prefix = prefix
+	r.limit = limit
+	return []*repository.DestinationSuggestion{{Destination: "Bandung", TripCount: 4}}, nil
+}
+
+func TestListDestinationsLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int
+		wantLimit int
+	}{
+		{"default", 0, DefaultDestinationLimit},
+		{"negative", -5, DefaultDestinationLimit},
+		{"within range", 25, 25},
+		{"maximum", MaxDestinationLimit, MaxDestinationLimit},
+		{"above maximum", MaxDestinationLimit + 1, MaxDestinationLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubDestinationRepository{}
+			got, err := NewListDestinationsUseCase(repo).Execute(context.Background(), "band", tt.limit)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if repo.limit != tt.wantLimit || repo.prefix != "band" {
+				t.Errorf("repository called with (%q, %d), want (%q, %d)", repo.prefix, repo.limit, "band", tt.wantLimit)
+			}
+			if len(got) != 1 || got[0].Destination != "Bandung" || got[0].TripCount != 4 {
+				t.Errorf("Execute() = %+v, want the repository's destinations", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package business_trip
+
+import (
+	"context"
+
+	"sandbox/internal/domain/repository"
+)
+
+// ListTransactionAttachmentsUseCase lists the receipts attached to a transaction
+type ListTransactionAttachmentsUseCase struct {
+	transactionRepo repository.BusinessTripTransactionRepository
+}
+
+// NewListTransactionAttachmentsUseCase creates a new instance of ListTransactionAttachmentsUseCase
+func NewListTransactionAttachmentsUseCase(transactionRepo repository.BusinessTripTransactionRepository) *ListTransactionAttachmentsUseCase {
+	return &ListTransactionAttachmentsUseCase{
+		transactionRepo: transactionRepo,
+	}
+}
+
+func (uc *ListTransactionAttachmentsUseCase) Execute(ctx context.Context, assigneeID, transactionID string) ([]AttachmentResponse, error) {
+	if _, err := getAssigneeTransaction(ctx, uc.transactionRepo, assigneeID, transactionID); err != nil {
+		return nil, err
+	}
+
+	attachments, err := uc.transactionRepo.GetTransactionAttachments(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := toAttachmentResponses(attachments)
+	if responses == nil {
+		responses = []AttachmentResponse{}
+	}
+	return responses, nil
+}
@@ -45,13 +45,20 @@ func (uc *ListTransactionsUseCase) Execute(ctx context.Context, assigneeID strin
 			Name:            transaction.Name,
 			Type:            string(transaction.Type),
 			Subtype:         string(transaction.Subtype),
+			Direction:       string(transaction.GetDirection()),
 			Amount:          transaction.Amount,
 			TotalNight:      transaction.TotalNight,
+			TotalDays:       transaction.TotalDays,
 			Subtotal:        transaction.Subtotal,
 			Description:     transaction.Description,
 			TransportDetail: transaction.TransportDetail,
 			CreatedAt:       transaction.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 			UpdatedAt:       transaction.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			Currency:        transaction.Currency,
+			ExchangeRate:    transaction.GetExchangeRate(),
+			BaseAmount:      transaction.BaseAmount(),
+			Allocations:     toAllocationResponses(transaction),
+			Attachments:     toAttachmentResponses(transaction.GetAttachments()),
 		}
 	}
 
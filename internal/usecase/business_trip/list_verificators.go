@@ -95,16 +95,12 @@ func (uc *ListVerificatorsUseCase) Execute(ctx context.Context, params *paginati
 		responses = append(responses, response)
 	}
 
-	// Calculate pagination info
-	totalPages := int(totalCount) / params.Pagination.Limit
-	if int(totalCount)%params.Pagination.Limit > 0 {
-		totalPages++
-	}
+	metadata := pagination.BuildMetadata(totalCount, params.Pagination.Page, params.Pagination.Limit)
 
 	return responses, &pagination.PagedResponse{
 		Page:       params.Pagination.Page,
 		Limit:      params.Pagination.Limit,
 		TotalItems: totalCount,
-		TotalPages: totalPages,
+		TotalPages: metadata.TotalPage,
 	}, nil
 }
@@ -1,10 +1,13 @@
 package business_trip
 
 import (
+	"errors"
 	"time"
 
 	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/service"
 	"sandbox/pkg/nullable"
+	"sandbox/pkg/pagination"
 
 	"github.com/invopop/validation"
 )
@@ -32,6 +35,12 @@ type BusinessTripRequest struct {
 	DocumentLink       string               `json:"document_link"`
 	Verificators       []VerificatorRequest `json:"verificators"`
 	Assignees          []AssigneeRequest    `json:"assignees"`
+
+	// Force skips the maximum trip duration check for genuine long assignments
+	Force bool `json:"force"`
+
+	// AllowLockedTrip lets an administrator replace the assignees of a completed or canceled trip
+	AllowLockedTrip bool `json:"-" form:"-"`
 }
 
 func (r BusinessTripRequest) Validate() error {
@@ -94,7 +103,8 @@ func (r BusinessTripRequest) Validate() error {
 	return nil
 }
 
-func (r BusinessTripRequest) ToEntity() (*entity.BusinessTrip, error) {
+// ToEntity builds the business trip; transactions without a currency are in baseCurrency
+func (r BusinessTripRequest) ToEntity(baseCurrency string, maxVerificators int, amountCaps entity.TransactionAmountCaps) (*entity.BusinessTrip, error) {
 	startDate, err := time.Parse("2006-01-02", r.StartDate)
 	if err != nil {
 		return nil, err
@@ -143,7 +153,7 @@ func (r BusinessTripRequest) ToEntity() (*entity.BusinessTrip, error) {
 
 	// Add verificators
 	for _, vr := range r.Verificators {
-		_, err := bt.AddVerificator(vr.UserID, vr.UserName, vr.EmployeeNumber, vr.Position)
+		_, err := bt.AddVerificator(vr.UserID, vr.UserName, vr.EmployeeNumber, vr.Position, maxVerificators)
 		if err != nil {
 			return nil, err
 		}
@@ -165,21 +175,37 @@ func (r BusinessTripRequest) ToEntity() (*entity.BusinessTrip, error) {
 				transactionReq.Name,
 				txType,
 				subtype,
+				entity.TransactionDirection(transactionReq.Direction),
 				transactionReq.Amount,
 				transactionReq.Amount, // Will be calculated in NewTransaction
 				transactionReq.TotalNight,
+				transactionReq.TotalDays,
 				transactionReq.Description,
 				transactionReq.TransportDetail,
+				amountCaps,
+				transactionReq.AllowLargeAmount,
 			)
 			if err != nil {
 				return nil, err
 			}
 
+			if err := transaction.SetCurrency(transactionReq.Currency, transactionReq.ExchangeRate, baseCurrency); err != nil {
+				return nil, err
+			}
+
+			if err := applyAllocations(transaction, transactionReq.Allocations); err != nil {
+				return nil, err
+			}
+
 			err = assignee.AddTransaction(transaction)
 			if err != nil {
 				return nil, err
 			}
 		}
+
+		if err := assignee.ValidateCredits(); err != nil {
+			return nil, err
+		}
 	}
 
 	return bt, nil
@@ -198,12 +224,22 @@ type AssigneeRequest struct {
 }
 
 func (r AssigneeRequest) Validate() error {
+	return r.validate(true)
+}
+
+// ValidateForAutofill validates a request for adding a single assignee, where position and rank may be
+// left blank for AddAssigneeUseCase to fill in from the identity service
+func (r AssigneeRequest) ValidateForAutofill() error {
+	return r.validate(false)
+}
+
+func (r AssigneeRequest) validate(detailsRequired bool) error {
 	err := validation.ValidateStruct(&r,
 		validation.Field(&r.Name, validation.Required, validation.Length(1, 255)),
 		validation.Field(&r.SPDNumber, validation.Required, validation.Length(1, 100)),
 		validation.Field(&r.EmployeeNumber, validation.Required, validation.Length(1, 50)),
-		validation.Field(&r.Position, validation.Required, validation.Length(1, 255)),
-		validation.Field(&r.Rank, validation.Required, validation.Length(1, 100)),
+		validation.Field(&r.Position, validation.When(detailsRequired, validation.Required), validation.Length(1, 255)),
+		validation.Field(&r.Rank, validation.When(detailsRequired, validation.Required), validation.Length(1, 100)),
 		validation.Field(&r.Transactions, validation.Each()),
 	)
 	if err != nil {
@@ -227,8 +263,32 @@ type TransactionRequest struct {
 	Subtype         string  `json:"subtype"`
 	Amount          float64 `json:"amount"`
 	TotalNight      *int    `json:"total_night"`
+	TotalDays       *int    `json:"total_days"`
 	Description     string  `json:"description"`
 	TransportDetail string  `json:"transport_detail"`
+	// Direction is "debit" (default) for a cost or "credit" for a refund of a cost of the same type and subtype
+	Direction string `json:"direction"`
+	// Currency is the ISO 4217 code the amount was paid in, the base currency when empty.
+	// ExchangeRate converts it to the base currency and is required for any other currency.
+	Currency     string  `json:"currency"`
+	ExchangeRate float64 `json:"exchange_rate"`
+	// AllowLargeAmount lets an administrator enter an amount above the configured cap of its type
+	AllowLargeAmount bool `json:"allow_large_amount"`
+
+	Allocations []AllocationRequest `json:"allocations"`
+}
+
+// AllocationRequest represents a cost center split of a transaction
+type AllocationRequest struct {
+	CostCenter string  `json:"cost_center"`
+	Percentage float64 `json:"percentage"`
+}
+
+func (r AllocationRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.CostCenter, validation.Required, validation.Length(1, 100)),
+		validation.Field(&r.Percentage, validation.Required, validation.Min(0.01), validation.Max(100.0)),
+	)
 }
 
 // VerificatorRequest represents the request body for a verificator
@@ -262,8 +322,11 @@ func (r TransactionRequest) Validate() error {
 		validation.Field(&r.Subtype, validation.Length(0, 50)),
 		validation.Field(&r.Amount, validation.Required, validation.Min(0.0)),
 		validation.Field(&r.TotalNight, validation.Min(0)),
+		validation.Field(&r.TotalDays, validation.Min(0)),
 		validation.Field(&r.Description, validation.Length(0, 1000)),
 		validation.Field(&r.TransportDetail, validation.Length(0, 1000)),
+		validation.Field(&r.Currency, validation.By(validateCurrencyCode)),
+		validation.Field(&r.ExchangeRate, validation.Min(0.0)),
 	)
 	if err != nil {
 		return err
@@ -296,6 +359,18 @@ func (r TransactionRequest) Validate() error {
 		}
 	}
 
+	if err := validateSubtypeOfType(r.Type, r.Subtype); err != nil {
+		return validation.NewError("subtype", err.Error())
+	}
+
+	if _, err := entity.NormalizeTransactionDirection(entity.TransactionDirection(r.Direction), entity.TransactionSubtype(r.Subtype)); err != nil {
+		return validation.NewError("direction", err.Error())
+	}
+
+	if err := validateAllocationRequests(r.Allocations); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -312,6 +387,15 @@ type UpdateBusinessTripRequest struct {
 	ReturnDate         nullable.NullString `json:"return_date"`
 	Status             nullable.NullString `json:"status"`
 	DocumentLink       nullable.NullString `json:"document_link"`
+
+	// Version is the version the client last read; when set, the update fails if the trip has changed since
+	Version *int `json:"version"`
+
+	// Force skips the maximum trip duration check for genuine long assignments
+	Force bool `json:"force"`
+
+	// AllowLockedTrip lets an administrator change more than the status of a completed or canceled trip
+	AllowLockedTrip bool `json:"-" form:"-"`
 }
 
 // UpdateBusinessTripWithAssigneesRequest represents the request body for updating a business trip with full replace of assignees and transactions
@@ -327,8 +411,15 @@ type UpdateBusinessTripWithAssigneesRequest struct {
 	ReturnDate         string               `json:"return_date"`
 	Status             string               `json:"status"`
 	DocumentLink       string               `json:"document_link"`
+	Version            *int                 `json:"version"`
 	Verificators       []VerificatorRequest `json:"verificators"`
 	Assignees          []AssigneeRequest    `json:"assignees"`
+
+	// Force skips the maximum trip duration check for genuine long assignments
+	Force bool `json:"force"`
+
+	// AllowLockedTrip lets an administrator replace the assignees of a completed or canceled trip
+	AllowLockedTrip bool `json:"-" form:"-"`
 }
 
 func (r UpdateBusinessTripRequest) Validate() error {
@@ -440,7 +531,8 @@ func (r UpdateBusinessTripWithAssigneesRequest) Validate() error {
 	return nil
 }
 
-func (r UpdateBusinessTripWithAssigneesRequest) ToEntity(businessTripID string) (*entity.BusinessTrip, error) {
+// ToEntity builds the updated business trip; transactions without a currency are in baseCurrency
+func (r UpdateBusinessTripWithAssigneesRequest) ToEntity(businessTripID, baseCurrency string, maxVerificators int, amountCaps entity.TransactionAmountCaps) (*entity.BusinessTrip, error) {
 	startDate, err := time.Parse("2006-01-02", r.StartDate)
 	if err != nil {
 		return nil, err
@@ -492,7 +584,7 @@ func (r UpdateBusinessTripWithAssigneesRequest) ToEntity(businessTripID string)
 
 	// Add verificators
 	for _, vr := range r.Verificators {
-		_, err := bt.AddVerificator(vr.UserID, vr.UserName, vr.EmployeeNumber, vr.Position)
+		_, err := bt.AddVerificator(vr.UserID, vr.UserName, vr.EmployeeNumber, vr.Position, maxVerificators)
 		if err != nil {
 			return nil, err
 		}
@@ -514,21 +606,37 @@ func (r UpdateBusinessTripWithAssigneesRequest) ToEntity(businessTripID string)
 				transactionReq.Name,
 				txType,
 				subtype,
+				entity.TransactionDirection(transactionReq.Direction),
 				transactionReq.Amount,
 				transactionReq.Amount, // Will be calculated in NewTransaction
 				transactionReq.TotalNight,
+				transactionReq.TotalDays,
 				transactionReq.Description,
 				transactionReq.TransportDetail,
+				amountCaps,
+				transactionReq.AllowLargeAmount,
 			)
 			if err != nil {
 				return nil, err
 			}
 
+			if err := transaction.SetCurrency(transactionReq.Currency, transactionReq.ExchangeRate, baseCurrency); err != nil {
+				return nil, err
+			}
+
+			if err := applyAllocations(transaction, transactionReq.Allocations); err != nil {
+				return nil, err
+			}
+
 			err = assignee.AddTransaction(transaction)
 			if err != nil {
 				return nil, err
 			}
 		}
+
+		if err := assignee.ValidateCredits(); err != nil {
+			return nil, err
+		}
 	}
 
 	return bt, nil
@@ -547,11 +655,33 @@ type BusinessTripResponse struct {
 	ReturnDate         string                `json:"return_date"`
 	Status             string                `json:"status"`
 	DocumentLink       string                `json:"document_link"`
+	MeetingID          string                `json:"meeting_id,omitempty"`
+	Version            int                   `json:"version"`
 	TotalCost          float64               `json:"total_cost"`
+	AssigneeCount      int                   `json:"assignee_count"`
 	Verificators       []VerificatorResponse `json:"verificators"`
 	Assignees          []AssigneeResponse    `json:"assignees"`
 	CreatedAt          string                `json:"created_at"`
 	UpdatedAt          string                `json:"updated_at"`
+
+	// VerificatorsTruncated is set when the trip detail embeds only the first verificators
+	VerificatorsTruncated bool `json:"verificators_truncated"`
+
+	// Meeting summarises the linked meeting; it is left out when the meeting could not be looked up
+	Meeting *MeetingSummaryResponse `json:"meeting,omitempty"`
+
+	// Warnings lists likely duplicate transactions in the request; they were saved anyway
+	Warnings []service.DuplicateTransactionWarning `json:"warnings,omitempty"`
+
+	// ETag identifies this version of the trip for conditional requests; it is sent as a header
+	ETag string `json:"-"`
+}
+
+// MeetingSummaryResponse represents the meeting a business trip is made to attend
+type MeetingSummaryResponse struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	JoinURL string `json:"join_url"`
 }
 
 // VerificatorResponse represents the response body for a verificator
@@ -583,6 +713,12 @@ type AssigneeResponse struct {
 	Transactions   []TransactionResponse `json:"transactions"`
 	CreatedAt      string                `json:"created_at"`
 	UpdatedAt      string                `json:"updated_at"`
+
+	// Warnings lists likely duplicate transactions in the request; they were saved anyway
+	Warnings []service.DuplicateTransactionWarning `json:"warnings,omitempty"`
+
+	// IdentityWarning is set when the employee could not be looked up and the request's details were used
+	IdentityWarning string `json:"identity_warning,omitempty"`
 }
 
 // TransactionResponse represents the response body for a transaction
@@ -591,13 +727,139 @@ type TransactionResponse struct {
 	Name            string  `json:"name"`
 	Type            string  `json:"type"`
 	Subtype         string  `json:"subtype"`
+	Direction       string  `json:"direction"`
 	Amount          float64 `json:"amount"`
 	TotalNight      *int    `json:"total_night,omitempty"`
+	TotalDays       *int    `json:"total_days,omitempty"`
 	Subtotal        float64 `json:"subtotal"`
 	Description     string  `json:"description,omitempty"`
 	TransportDetail string  `json:"transport_detail,omitempty"`
 	CreatedAt       string  `json:"created_at"`
 	UpdatedAt       string  `json:"updated_at"`
+
+	// Amount is in Currency; BaseAmount and Subtotal are converted to the base currency with ExchangeRate
+	Currency     string  `json:"currency"`
+	ExchangeRate float64 `json:"exchange_rate"`
+	BaseAmount   float64 `json:"base_amount"`
+
+	Allocations []AllocationResponse `json:"allocations,omitempty"`
+	Attachments []AttachmentResponse `json:"attachments,omitempty"`
+}
+
+// AttachmentResponse represents a receipt file attached to a transaction
+type AttachmentResponse struct {
+	ID          string `json:"id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	FileID      string `json:"file_id"`
+	StorageURL  string `json:"storage_url"`
+	UploadedAt  string `json:"uploaded_at"`
+}
+
+// AllocationResponse represents a cost center split of a transaction with the amount it carries
+type AllocationResponse struct {
+	CostCenter string  `json:"cost_center"`
+	Percentage float64 `json:"percentage"`
+	Amount     float64 `json:"amount"`
+}
+
+// validateSubtypeOfType rejects a subtype that does not belong to the transaction type. Unknown types
+// are reported by the type field itself.
+func validateSubtypeOfType(txType, subtype string) error {
+	err := entity.ValidateTransactionSubtype(entity.TransactionType(txType), entity.TransactionSubtype(subtype))
+	if !errors.Is(err, entity.ErrIncompatibleSubtype) {
+		return nil
+	}
+	return err
+}
+
+// validateCurrencyCode rejects currency codes outside the supported ISO 4217 set; empty means the base currency
+func validateCurrencyCode(value interface{}) error {
+	code, _ := value.(string)
+	if code != "" && !entity.IsSupportedCurrency(entity.NormalizeCurrency(code)) {
+		return validation.NewError("validation_currency_unsupported", "must be a supported ISO 4217 currency code")
+	}
+	return nil
+}
+
+// validateAllocationRequests validates each split and checks that together they cover 100% of the transaction
+func validateAllocationRequests(reqs []AllocationRequest) error {
+	for _, req := range reqs {
+		if err := req.Validate(); err != nil {
+			return err
+		}
+	}
+
+	allocations, err := toAllocations(reqs)
+	if err != nil {
+		return err
+	}
+
+	return entity.ValidateAllocations(allocations)
+}
+
+// toAllocations converts allocation requests into entities
+func toAllocations(reqs []AllocationRequest) ([]*entity.TransactionAllocation, error) {
+	allocations := make([]*entity.TransactionAllocation, 0, len(reqs))
+	for _, req := range reqs {
+		allocation, err := entity.NewTransactionAllocation(req.CostCenter, req.Percentage)
+		if err != nil {
+			return nil, err
+		}
+		allocations = append(allocations, allocation)
+	}
+	return allocations, nil
+}
+
+// applyAllocations sets the requested splits on a transaction; an empty request leaves it unallocated
+func applyAllocations(transaction *entity.Transaction, reqs []AllocationRequest) error {
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	allocations, err := toAllocations(reqs)
+	if err != nil {
+		return err
+	}
+
+	return transaction.SetAllocations(allocations)
+}
+
+// toAllocationResponses converts the splits of a transaction into responses
+func toAllocationResponses(transaction *entity.Transaction) []AllocationResponse {
+	if len(transaction.GetAllocations()) == 0 {
+		return nil
+	}
+
+	responses := make([]AllocationResponse, len(transaction.GetAllocations()))
+	for i, allocation := range transaction.GetAllocations() {
+		responses[i] = AllocationResponse{
+			CostCenter: allocation.CostCenter,
+			Percentage: allocation.Percentage,
+			Amount:     allocation.AllocatedAmount(transaction.GetSubtotal()),
+		}
+	}
+	return responses
+}
+
+// toAttachmentResponses converts the receipt files of a transaction into responses
+func toAttachmentResponses(attachments []*entity.TransactionAttachment) []AttachmentResponse {
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	responses := make([]AttachmentResponse, len(attachments))
+	for i, attachment := range attachments {
+		responses[i] = AttachmentResponse{
+			ID:          attachment.ID,
+			FileName:    attachment.FileName,
+			ContentType: attachment.ContentType,
+			FileID:      attachment.FileID,
+			StorageURL:  attachment.StorageURL,
+			UploadedAt:  attachment.UploadedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+	}
+	return responses
 }
 
 // BusinessTripListResponse represents the response for business trip list
@@ -622,11 +884,12 @@ type QueryParams struct {
 	SortDirection   string `query:"sort_direction"`
 }
 
-func (p QueryParams) Validate() error {
+// Validate checks the query parameters, allowing a limit up to the maximum of limits
+func (p QueryParams) Validate(limits pagination.Limits) error {
 	// Basic validation with invopop
 	err := validation.ValidateStruct(&p,
 		validation.Field(&p.Page, validation.Min(1)),
-		validation.Field(&p.Limit, validation.Min(1), validation.Max(100)),
+		validation.Field(&p.Limit, validation.Min(1), validation.Max(limits.OrDefaults().Max)),
 		validation.Field(&p.StartDate, validation.Length(0, 50)),
 		validation.Field(&p.EndDate, validation.Length(0, 50)),
 		validation.Field(&p.Status, validation.Length(0, 20)),
@@ -664,12 +927,13 @@ func (p QueryParams) Validate() error {
 	return nil
 }
 
-func (p QueryParams) SetDefaults() QueryParams {
+// SetDefaults fills the unset query parameters, taking the limit from the default of limits
+func (p QueryParams) SetDefaults(limits pagination.Limits) QueryParams {
 	if p.Page <= 0 {
 		p.Page = 1
 	}
 	if p.Limit <= 0 {
-		p.Limit = 10
+		p.Limit = limits.PageSize(0)
 	}
 	if p.SortBy == "" {
 		p.SortBy = "created_at"
@@ -692,13 +956,20 @@ func FromEntity(bt *entity.BusinessTrip) *BusinessTripResponse {
 				Name:            tx.GetName(),
 				Type:            string(tx.GetType()),
 				Subtype:         string(tx.GetSubtype()),
+				Direction:       string(tx.GetDirection()),
 				Amount:          tx.GetAmount(),
 				TotalNight:      tx.GetTotalNight(),
+				TotalDays:       tx.GetTotalDays(),
 				Subtotal:        tx.GetSubtotal(),
 				Description:     tx.GetDescription(),
 				TransportDetail: tx.GetTransportDetail(),
 				CreatedAt:       tx.CreatedAt.Format(time.RFC3339),
 				UpdatedAt:       tx.UpdatedAt.Format(time.RFC3339),
+				Currency:        tx.Currency,
+				ExchangeRate:    tx.GetExchangeRate(),
+				BaseAmount:      tx.BaseAmount(),
+				Allocations:     toAllocationResponses(tx),
+				Attachments:     toAttachmentResponses(tx.GetAttachments()),
 			}
 		}
 
@@ -753,7 +1024,10 @@ func FromEntity(bt *entity.BusinessTrip) *BusinessTripResponse {
 		ReturnDate:         bt.GetReturnDate().Format("2006-01-02"),
 		Status:             string(bt.GetStatus()),
 		DocumentLink:       bt.GetDocumentLink(),
+		MeetingID:          bt.GetMeetingID(),
+		Version:            bt.GetVersion(),
 		TotalCost:          bt.GetTotalCost(),
+		AssigneeCount:      len(bt.GetAssignees()),
 		Verificators:       verificators,
 		Assignees:          assignees,
 		CreatedAt:          bt.CreatedAt.Format(time.RFC3339),
@@ -769,14 +1043,14 @@ func FromEntities(businessTrips []*entity.BusinessTrip, total int, page, limit i
 		btResponses[i] = *response
 	}
 
-	totalPages := (total + limit - 1) / limit
+	metadata := pagination.BuildMetadata(int64(total), page, limit)
 
 	return &BusinessTripListResponse{
 		BusinessTrips: btResponses,
 		Total:         total,
 		Page:          page,
 		Limit:         limit,
-		TotalPages:    totalPages,
+		TotalPages:    metadata.TotalPage,
 	}
 }
 
@@ -787,6 +1061,7 @@ type BusinessTripSummary struct {
 	TotalAssignees    int                `json:"total_assignees"`
 	TotalTransactions int                `json:"total_transactions"`
 	CostByType        map[string]float64 `json:"cost_by_type"`
+	CountByType       map[string]int     `json:"count_by_type"`
 }
 
 // AssigneeSummary represents the summary of an assignee
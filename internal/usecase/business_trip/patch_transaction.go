@@ -0,0 +1,195 @@
+package business_trip
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/invopop/validation"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/internal/domain/service"
+	"sandbox/pkg/nullable"
+)
+
+// PatchTransactionUseCase changes only the fields of a transaction present in the request
+type PatchTransactionUseCase struct {
+	businessTripRepo repository.BusinessTripRepository
+	assigneeRepo     repository.AssigneeRepository
+	typePolicy       *service.TransactionTypePolicy
+	amountCaps       entity.TransactionAmountCaps
+	baseCurrency     string
+}
+
+func NewPatchTransactionUseCase(businessTripRepo repository.BusinessTripRepository, assigneeRepo repository.AssigneeRepository, typePolicy *service.TransactionTypePolicy, amountCaps entity.TransactionAmountCaps, baseCurrency string) *PatchTransactionUseCase {
+	return &PatchTransactionUseCase{
+		businessTripRepo: businessTripRepo,
+		assigneeRepo:     assigneeRepo,
+		typePolicy:       typePolicy,
+		amountCaps:       amountCaps,
+		baseCurrency:     baseCurrency,
+	}
+}
+
+// PatchTransactionRequest represents a partial update of a transaction; omitted or null fields keep their value
+type PatchTransactionRequest struct {
+	BusinessTripID  string              `params:"businessTripId" json:"businessTripId"`
+	AssigneeID      string              `params:"assigneeId" json:"assigneeId"`
+	TransactionID   string              `params:"transactionId" json:"transactionId"`
+	Name            nullable.NullString `json:"name"`
+	Type            nullable.NullString `json:"type"`
+	Subtype         nullable.NullString `json:"subtype"`
+	Direction       nullable.NullString `json:"direction"`
+	Amount          *float64            `json:"amount"`
+	TotalNight      *int                `json:"totalNight"`
+	TotalDays       *int                `json:"totalDays"`
+	Description     nullable.NullString `json:"description"`
+	TransportDetail nullable.NullString `json:"transportDetail"`
+
+	// A new Currency needs its ExchangeRate unless it is the base currency; ExchangeRate alone
+	// re-rates the current currency
+	Currency     nullable.NullString `json:"currency"`
+	ExchangeRate *float64            `json:"exchangeRate"`
+
+	// Allocations replaces the cost center splits when present; send an empty list to remove them
+	Allocations []AllocationRequest `json:"allocations"`
+
+	// AllowLargeAmount lets an administrator enter an amount above the configured cap of its type
+	AllowLargeAmount bool `json:"allow_large_amount"`
+
+	// AllowLockedTrip lets an administrator update a transaction of a completed or canceled trip
+	AllowLockedTrip bool `json:"-" form:"-"`
+}
+
+func (r PatchTransactionRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.BusinessTripID, validation.Required),
+		validation.Field(&r.AssigneeID, validation.Required),
+		validation.Field(&r.TransactionID, validation.Required),
+		validation.Field(&r.Name, validation.When(r.Name.IsSet(), validation.By(func(interface{}) error {
+			return validation.Validate(r.Name.String, validation.Required, validation.Length(1, 255))
+		}))),
+		validation.Field(&r.Type, validation.When(r.Type.IsSet(), validation.By(func(interface{}) error {
+			return validation.Validate(r.Type.String, validation.Required, validation.In("accommodation", "transport", "other", "allowance"))
+		}))),
+		validation.Field(&r.Direction, validation.When(r.Direction.IsSet(), validation.By(func(interface{}) error {
+			return validation.Validate(r.Direction.String, validation.In(string(entity.TransactionDirectionDebit), string(entity.TransactionDirectionCredit)))
+		}))),
+		validation.Field(&r.Amount, validation.Min(0.0)),
+		validation.Field(&r.TotalNight, validation.Min(0)),
+		validation.Field(&r.TotalDays, validation.Min(0)),
+		validation.Field(&r.Currency, validation.When(r.Currency.IsSet(), validation.By(func(interface{}) error {
+			return validateCurrencyCode(r.Currency.String)
+		}))),
+		validation.Field(&r.ExchangeRate, validation.Min(0.0)),
+		validation.Field(&r.Allocations, validation.By(func(interface{}) error {
+			return validateAllocationRequests(r.Allocations)
+		})),
+	)
+}
+
+func (uc *PatchTransactionUseCase) Execute(ctx context.Context, req PatchTransactionRequest) (*UpdateTransactionResponse, error) {
+	businessTrip, assignee, transaction, err := loadEditableTransaction(ctx, uc.businessTripRepo, uc.assigneeRepo,
+		req.BusinessTripID, req.AssigneeID, req.TransactionID, req.AllowLockedTrip)
+	if err != nil {
+		return nil, err
+	}
+
+	// The stored type and subtype decide whether a legacy allowance may keep an empty subtype
+	stored := *transaction
+
+	if req.Name.IsSet() {
+		transaction.Name = strings.TrimSpace(req.Name.String)
+	}
+	if req.Type.IsSet() {
+		transaction.Type = entity.TransactionType(req.Type.String)
+	}
+	if req.Subtype.IsSet() {
+		transaction.Subtype = entity.TransactionSubtype(req.Subtype.String)
+	}
+	if req.Amount != nil {
+		transaction.Amount = *req.Amount
+	}
+	if req.TotalNight != nil {
+		transaction.TotalNight = req.TotalNight
+	}
+	if req.TotalDays != nil {
+		transaction.TotalDays = req.TotalDays
+	}
+	if req.Description.IsSet() {
+		transaction.Description = strings.TrimSpace(req.Description.String)
+	}
+	if req.TransportDetail.IsSet() {
+		transaction.TransportDetail = strings.TrimSpace(req.TransportDetail.String)
+	}
+
+	if transaction.TotalNight != nil && transaction.TotalDays != nil {
+		return nil, entity.ErrNightsAndDaysBothSet
+	}
+
+	// An amount already above the cap is kept when only other fields change
+	if (req.Amount != nil || req.Type.IsSet()) && !req.AllowLargeAmount {
+		if err := uc.amountCaps.Check(transaction.Type, transaction.Amount); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Type.IsSet() || req.Subtype.IsSet() {
+		if err := stored.ValidateSubtypeChange(transaction.Type, transaction.Subtype); err != nil {
+			return nil, err
+		}
+	}
+
+	// A credit must keep a subtype, so the direction is checked again when either of them changes
+	if req.Direction.IsSet() || req.Subtype.IsSet() {
+		direction := transaction.GetDirection()
+		if req.Direction.IsSet() {
+			direction = entity.TransactionDirection(req.Direction.String)
+		}
+		transaction.Direction, err = entity.NormalizeTransactionDirection(direction, transaction.Subtype)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Currency.IsSet() || req.ExchangeRate != nil {
+		currency, exchangeRate := transaction.Currency, transaction.ExchangeRate
+		if req.Currency.IsSet() && entity.NormalizeCurrency(req.Currency.String) != currency {
+			currency, exchangeRate = req.Currency.String, 0
+		}
+		if req.ExchangeRate != nil {
+			exchangeRate = *req.ExchangeRate
+		}
+		if err := transaction.SetCurrency(currency, exchangeRate, uc.baseCurrency); err != nil {
+			return nil, err
+		}
+	} else if req.Amount != nil || req.TotalNight != nil || req.TotalDays != nil || req.Type.IsSet() || req.Subtype.IsSet() {
+		transaction.Subtotal = transaction.CalculateSubtotal()
+	}
+
+	if err := uc.typePolicy.CheckTransactions(ctx, businessTrip, transaction); err != nil {
+		return nil, err
+	}
+
+	if err := validateAssigneeCredits(ctx, uc.businessTripRepo, assignee, transaction); err != nil {
+		return nil, err
+	}
+
+	if req.Allocations != nil {
+		allocations, err := toAllocations(req.Allocations)
+		if err != nil {
+			return nil, err
+		}
+		if err := transaction.SetAllocations(allocations); err != nil {
+			return nil, err
+		}
+	}
+
+	updatedTransaction, err := uc.businessTripRepo.UpdateTransaction(ctx, transaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update transaction: %w", err)
+	}
+
+	return toUpdateTransactionResponse(updatedTransaction), nil
+}
@@ -0,0 +1,141 @@
+package business_trip
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/service"
+	"sandbox/pkg/nullable"
+)
+
+func TestPatchTransactionKeepsOmittedFields(t *testing.T) {
+	tripRepo, assigneeRepo := newLockTestRepositories(t, entity.BusinessTripStatusOngoing)
+	nights := 2
+	tripRepo.transaction = &entity.Transaction{
+		ID:          "transaction-1",
+		AssigneeID:  "assignee-1",
+		Name:        "Hotel",
+		Type:        entity.TransactionTypeAccommodation,
+		Subtype:     entity.TransactionSubtypeHotel,
+		Amount:      500,
+		TotalNight:  &nights,
+		Subtotal:    1000,
+		Description: "Two nights",
+	}
+	useCase := NewPatchTransactionUseCase(tripRepo, assigneeRepo, service.NewTransactionTypePolicy(nil), nil, entity.DefaultBaseCurrency)
+	req := PatchTransactionRequest{BusinessTripID: tripRepo.trip.ID, AssigneeID: "assignee-1", TransactionID: "transaction-1"}
+
+	describe := req
+	describe.Description = nullable.NullString{String: "Near the venue", Valid: true}
+	got, err := useCase.Execute(context.Background(), describe)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got.Description != "Near the venue" || got.Name != "Hotel" || got.Amount != 500 || got.Subtotal != 1000 {
+		t.Errorf("after description patch = %+v, want only the description changed", got)
+	}
+
+	threeNights := 3
+	reprice := req
+	reprice.TotalNight = &threeNights
+	got, err = useCase.Execute(context.Background(), reprice)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got.Subtotal != 1500 || got.Amount != 500 {
+		t.Errorf("after total night patch amount/subtotal = %v/%v, want 500/1500", got.Amount, got.Subtotal)
+	}
+}
+
+func TestPatchTransactionCurrency(t *testing.T) {
+	tripRepo, assigneeRepo := newLockTestRepositories(t, entity.BusinessTripStatusOngoing)
+	nights := 2
+	tripRepo.transaction = &entity.Transaction{
+		ID:           "transaction-1",
+		AssigneeID:   "assignee-1",
+		Name:         "Hotel",
+		Type:         entity.TransactionTypeAccommodation,
+		Subtype:      entity.TransactionSubtypeHotel,
+		Amount:       100,
+		TotalNight:   &nights,
+		Subtotal:     200,
+		Currency:     entity.DefaultBaseCurrency,
+		ExchangeRate: 1,
+	}
+	useCase := NewPatchTransactionUseCase(tripRepo, assigneeRepo, service.NewTransactionTypePolicy(nil), nil, entity.DefaultBaseCurrency)
+	req := PatchTransactionRequest{BusinessTripID: tripRepo.trip.ID, AssigneeID: "assignee-1", TransactionID: "transaction-1"}
+	rate, newRate := 16000.0, 16500.0
+
+	withoutRate := req
+	withoutRate.Currency = nullable.NullString{String: "USD", Valid: true}
+	if _, err := useCase.Execute(context.Background(), withoutRate); !errors.Is(err, entity.ErrInvalidExchangeRate) {
+		t.Fatalf("foreign currency without rate error = %v, want ErrInvalidExchangeRate", err)
+	}
+
+	foreign := withoutRate
+	foreign.ExchangeRate = &rate
+	got, err := useCase.Execute(context.Background(), foreign)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got.Currency != "USD" || got.Amount != 100 || got.BaseAmount != 1600000 || got.Subtotal != 3200000 {
+		t.Errorf("after currency patch = %+v, want 100 USD at 16000 for two nights", got)
+	}
+
+	rerate := req
+	rerate.ExchangeRate = &newRate
+	got, err = useCase.Execute(context.Background(), rerate)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got.Currency != "USD" || got.Subtotal != 3300000 {
+		t.Errorf("after rate patch currency/subtotal = %s/%v, want USD/3300000", got.Currency, got.Subtotal)
+	}
+
+	base := req
+	base.Currency = nullable.NullString{String: "idr", Valid: true}
+	got, err = useCase.Execute(context.Background(), base)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got.Currency != entity.DefaultBaseCurrency || got.ExchangeRate != 1 || got.Subtotal != 200 {
+		t.Errorf("after base currency patch = %+v, want 200 IDR at rate 1", got)
+	}
+}
+
+func TestPatchTransactionRejectsNightsAndDays(t *testing.T) {
+	tripRepo, assigneeRepo := newLockTestRepositories(t, entity.BusinessTripStatusOngoing)
+	nights, days := 2, 3
+	tripRepo.transaction = &entity.Transaction{
+		ID:         "transaction-1",
+		AssigneeID: "assignee-1",
+		Name:       "Hotel",
+		Type:       entity.TransactionTypeAccommodation,
+		Subtype:    entity.TransactionSubtypeHotel,
+		Amount:     500,
+		TotalNight: &nights,
+	}
+	useCase := NewPatchTransactionUseCase(tripRepo, assigneeRepo, service.NewTransactionTypePolicy(nil), nil, entity.DefaultBaseCurrency)
+
+	_, err := useCase.Execute(context.Background(), PatchTransactionRequest{
+		BusinessTripID: tripRepo.trip.ID,
+		AssigneeID:     "assignee-1",
+		TransactionID:  "transaction-1",
+		TotalDays:      &days,
+	})
+	if !errors.Is(err, entity.ErrNightsAndDaysBothSet) {
+		t.Errorf("Execute() error = %v, want ErrNightsAndDaysBothSet", err)
+	}
+
+	tripRepo.transaction = nil
+	_, err = useCase.Execute(context.Background(), PatchTransactionRequest{
+		BusinessTripID: tripRepo.trip.ID,
+		AssigneeID:     "assignee-1",
+		TransactionID:  "transaction-2",
+	})
+	if !errors.Is(err, entity.ErrTransactionNotFound) {
+		t.Errorf("Execute() for a missing transaction error = %v, want ErrTransactionNotFound", err)
+	}
+}
@@ -0,0 +1,55 @@
+package business_trip
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"sandbox/internal/domain/repository"
+)
+
+var (
+	ErrInvalidNumberPreviewYear = errors.New("year must be between 1 and 9999")
+)
+
+// NextBusinessTripNumberResponse is the number the next created business trip is expected to receive.
+// It is advisory: concurrent creates may take the number before the client submits its trip.
+type NextBusinessTripNumberResponse struct {
+	BusinessTripNumber string `json:"business_trip_number"`
+	Year               int    `json:"year"`
+	Advisory           bool   `json:"advisory"`
+}
+
+// PreviewBusinessTripNumberUseCase previews the next generated business trip number without consuming it
+type PreviewBusinessTripNumberUseCase struct {
+	businessTripRepo repository.BusinessTripRepository
+}
+
+// NewPreviewBusinessTripNumberUseCase creates a new instance of PreviewBusinessTripNumberUseCase
+func NewPreviewBusinessTripNumberUseCase(businessTripRepo repository.BusinessTripRepository) *PreviewBusinessTripNumberUseCase {
+	return &PreviewBusinessTripNumberUseCase{
+		businessTripRepo: businessTripRepo,
+	}
+}
+
+// Execute returns the next number for trips created in year, defaulting to the current year when year is 0.
+// The year only changes the result when numbers are scoped per year.
+func (uc *PreviewBusinessTripNumberUseCase) Execute(ctx context.Context, year int) (*NextBusinessTripNumberResponse, error) {
+	if year == 0 {
+		year = time.Now().Year()
+	}
+	if year < 1 || year > 9999 {
+		return nil, ErrInvalidNumberPreviewYear
+	}
+
+	number, err := uc.businessTripRepo.PeekNextBusinessTripNumber(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NextBusinessTripNumberResponse{
+		BusinessTripNumber: number,
+		Year:               year,
+		Advisory:           true,
+	}, nil
+}
@@ -0,0 +1,112 @@
+package business_trip
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/pkg/database"
+)
+
+// subtotalRecomputeBatchSize is the number of transactions recomputed per database transaction, so a
+// large trip does not hold its row locks for the whole run
+const subtotalRecomputeBatchSize = 100
+
+// RecomputeTransactionSubtotalsUseCase brings the stored subtotals of a business trip's transactions in
+// line with the current subtotal rules after those rules change
+type RecomputeTransactionSubtotalsUseCase struct {
+	businessTripRepo repository.BusinessTripRepository
+	transactionRepo  repository.BusinessTripTransactionRepository
+	db               database.DB
+}
+
+func NewRecomputeTransactionSubtotalsUseCase(businessTripRepo repository.BusinessTripRepository, transactionRepo repository.BusinessTripTransactionRepository, db database.DB) *RecomputeTransactionSubtotalsUseCase {
+	return &RecomputeTransactionSubtotalsUseCase{
+		businessTripRepo: businessTripRepo,
+		transactionRepo:  transactionRepo,
+		db:               db,
+	}
+}
+
+// RecomputeTransactionSubtotalsResponse reports how many of the trip's transactions had a stale subtotal
+type RecomputeTransactionSubtotalsResponse struct {
+	BusinessTripID string `json:"business_trip_id"`
+	Checked        int    `json:"checked"`
+	Updated        int    `json:"updated"`
+}
+
+// Execute reloads every transaction of the trip, recomputes its subtotal and stores it when it differs
+// from the stored one. Each batch is committed on its own, so a failure keeps the earlier batches.
+func (uc *RecomputeTransactionSubtotalsUseCase) Execute(ctx context.Context, businessTripID string) (*RecomputeTransactionSubtotalsResponse, error) {
+	businessTrip, err := uc.businessTripRepo.GetByID(ctx, businessTripID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get business trip: %w", err)
+	}
+	if businessTrip == nil {
+		return nil, entity.ErrBusinessTripNotFound
+	}
+
+	var transactionIDs []string
+	for _, assignee := range businessTrip.Assignees {
+		for _, transaction := range assignee.Transactions {
+			transactionIDs = append(transactionIDs, transaction.ID)
+		}
+	}
+
+	response := &RecomputeTransactionSubtotalsResponse{BusinessTripID: businessTripID}
+	for start := 0; start < len(transactionIDs); start += subtotalRecomputeBatchSize {
+		batch := transactionIDs[start:min(start+subtotalRecomputeBatchSize, len(transactionIDs))]
+
+		updated := 0
+		err := database.WithinTx(ctx, uc.db, func(tx database.DBTx) error {
+			businessTripRepoWithTx := uc.businessTripRepo.(interface {
+				WithTransaction(database.DBTx) repository.BusinessTripRepository
+			}).WithTransaction(tx)
+			transactionRepoWithTx := uc.transactionRepo.(interface {
+				WithTransaction(database.DBTx) repository.BusinessTripTransactionRepository
+			}).WithTransaction(tx)
+
+			// Lock the trip first, so a locked trip cannot be reopened or edited by an override while
+			// its subtotals are rewritten
+			if err := businessTripRepoWithTx.LockByID(ctx, businessTripID); err != nil {
+				return err
+			}
+
+			for _, id := range batch {
+				// Reload and lock the transaction, it may have been edited since the trip was read
+				transaction, err := transactionRepoWithTx.GetTransactionByIDForUpdate(ctx, id)
+				if err != nil {
+					return err
+				}
+				if transaction == nil {
+					continue
+				}
+
+				subtotal := transaction.CalculateSubtotal()
+				if sameCents(subtotal, transaction.Subtotal) {
+					continue
+				}
+				if err := transactionRepoWithTx.UpdateTransactionSubtotal(ctx, id, subtotal); err != nil {
+					return err
+				}
+				updated++
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to recompute subtotals after %d updates: %w", response.Updated, err)
+		}
+
+		response.Checked += len(batch)
+		response.Updated += updated
+	}
+
+	return response, nil
+}
+
+// sameCents reports whether two amounts are equal at the precision subtotals are stored with
+func sameCents(a, b float64) bool {
+	return math.Round(a*100) == math.Round(b*100)
+}
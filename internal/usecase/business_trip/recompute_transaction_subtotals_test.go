@@ -0,0 +1,106 @@
+package business_trip
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/pkg/database"
+)
+
+// stubSubtotalTransactionRepository serves stored transactions by ID and records the subtotal updates
+type stubSubtotalTransactionRepository struct {
+	repository.BusinessTripTransactionRepository
+	transactions map[string]*entity.Transaction
+	updated      map[string]float64
+}
+
+func (r *stubSubtotalTransactionRepository) WithTransaction(database.DBTx) repository.BusinessTripTransactionRepository {
+	return r
+}
+
+func (r *stubSubtotalTransactionRepository) GetTransactionByIDForUpdate(_ context.Context, id string) (*entity.Transaction, error) {
+	return r.transactions[id], nil
+}
+
+func (r *stubSubtotalTransactionRepository) UpdateTransactionSubtotal(_ context.Context, id string, subtotal float64) error {
+	r.updated[id] = subtotal
+	return nil
+}
+
+// stubLockingTripRepository serves one trip and counts the row locks taken on it
+type stubLockingTripRepository struct {
+	stubBusinessTripRepository
+	locks int
+}
+
+func (r *stubLockingTripRepository) WithTransaction(database.DBTx) repository.BusinessTripRepository {
+	return r
+}
+
+func (r *stubLockingTripRepository) LockByID(context.Context, string) error {
+	r.locks++
+	return nil
+}
+
+func TestRecomputeTransactionSubtotalsUpdatesOnlyStaleRows(t *testing.T) {
+	nights, days := 3, 2
+	stored := map[string]*entity.Transaction{
+		// Stored before nights were multiplied in
+		"hotel": {ID: "hotel", Type: entity.TransactionTypeAccommodation, Amount: 500000, TotalNight: &nights, Subtotal: 500000},
+		"allowance": {ID: "allowance", Type: entity.TransactionTypeAllowance, Subtype: entity.TransactionSubtypeDailyAllowance,
+			Amount: 150000, TotalDays: &days, Subtotal: 300000},
+		"taxi": {ID: "taxi", Type: entity.TransactionTypeTransport, Amount: 75000, Subtotal: 75000},
+	}
+	trip := newTestBusinessTrip(t)
+	trip.Assignees = []*entity.Assignee{{ID: "assignee-1", Transactions: []*entity.Transaction{{ID: "hotel"}, {ID: "allowance"}, {ID: "taxi"}}}}
+
+	transactionRepo := &stubSubtotalTransactionRepository{transactions: stored, updated: map[string]float64{}}
+	uc := NewRecomputeTransactionSubtotalsUseCase(&stubLockingTripRepository{stubBusinessTripRepository: stubBusinessTripRepository{trip: trip}}, transactionRepo, &stubTxDB{})
+
+	response, err := uc.Execute(context.Background(), trip.ID)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if response.Checked != 3 || response.Updated != 1 {
+		t.Errorf("checked/updated = %d/%d, want 3/1", response.Checked, response.Updated)
+	}
+	if len(transactionRepo.updated) != 1 || transactionRepo.updated["hotel"] != 1500000 {
+		t.Errorf("updated subtotals = %v, want only hotel at 1500000", transactionRepo.updated)
+	}
+}
+
+func TestRecomputeTransactionSubtotalsCommitsInBatches(t *testing.T) {
+	stored := map[string]*entity.Transaction{}
+	assignee := &entity.Assignee{ID: "assignee-1"}
+	for i := 0; i < subtotalRecomputeBatchSize+1; i++ {
+		id := fmt.Sprintf("tx-%d", i)
+		stored[id] = &entity.Transaction{ID: id, Type: entity.TransactionTypeTransport, Amount: 100, Subtotal: 0}
+		assignee.Transactions = append(assignee.Transactions, &entity.Transaction{ID: id})
+	}
+	trip := newTestBusinessTrip(t)
+	trip.Assignees = []*entity.Assignee{assignee}
+
+	db := &stubTxDB{}
+	transactionRepo := &stubSubtotalTransactionRepository{transactions: stored, updated: map[string]float64{}}
+	tripRepo := &stubLockingTripRepository{stubBusinessTripRepository: stubBusinessTripRepository{trip: trip}}
+	uc := NewRecomputeTransactionSubtotalsUseCase(tripRepo, transactionRepo, db)
+
+	response, err := uc.Execute(context.Background(), trip.ID)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if response.Updated != subtotalRecomputeBatchSize+1 {
+		t.Errorf("updated = %d, want %d", response.Updated, subtotalRecomputeBatchSize+1)
+	}
+	if db.commits != 2 {
+		t.Errorf("commits = %d, want one per batch of %d", db.commits, subtotalRecomputeBatchSize)
+	}
+	if tripRepo.locks != 2 {
+		t.Errorf("trip locks = %d, want one per batch", tripRepo.locks)
+	}
+}
@@ -0,0 +1,41 @@
+package business_trip
+
+import (
+	"context"
+	"fmt"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+)
+
+// RestoreAssigneeUseCase brings back an assignee that was deleted by mistake, together with the
+// transactions that were deleted with it
+type RestoreAssigneeUseCase struct {
+	businessTripRepo repository.BusinessTripRepository
+	assigneeRepo     repository.AssigneeRepository
+}
+
+func NewRestoreAssigneeUseCase(businessTripRepo repository.BusinessTripRepository, assigneeRepo repository.AssigneeRepository) *RestoreAssigneeUseCase {
+	return &RestoreAssigneeUseCase{
+		businessTripRepo: businessTripRepo,
+		assigneeRepo:     assigneeRepo,
+	}
+}
+
+// Execute restores the deleted assignee of the business trip. Restoring fails with
+// ErrDuplicateSPDNumber when another assignee of the trip took over the SPD number in the meantime, and
+// with ErrBusinessTripLocked on a completed or canceled trip unless allowLockedTrip is set.
+func (uc *RestoreAssigneeUseCase) Execute(ctx context.Context, businessTripID, assigneeID string, allowLockedTrip bool) error {
+	businessTrip, err := uc.businessTripRepo.GetByID(ctx, businessTripID)
+	if err != nil {
+		return fmt.Errorf("failed to get business trip: %w", err)
+	}
+	if businessTrip == nil {
+		return fmt.Errorf("%w: %s", entity.ErrBusinessTripNotFound, businessTripID)
+	}
+	if err := ensureTripEditable(businessTrip, allowLockedTrip); err != nil {
+		return err
+	}
+
+	return uc.assigneeRepo.RestoreAssignee(ctx, businessTripID, assigneeID)
+}
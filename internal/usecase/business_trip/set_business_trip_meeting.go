@@ -0,0 +1,83 @@
+package business_trip
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+)
+
+// SetBusinessTripMeetingRequest links a business trip to the meeting it is made to attend
+type SetBusinessTripMeetingRequest struct {
+	// MeetingID is the Zoom meeting ID; an empty one unlinks the trip from its meeting
+	MeetingID string `json:"meeting_id"`
+
+	// AllowLockedTrip lets an administrator change the meeting of a completed or canceled trip
+	AllowLockedTrip bool `json:"-" form:"-"`
+}
+
+// SetBusinessTripMeetingResponse is the meeting the trip is linked to, or nil when it was unlinked
+type SetBusinessTripMeetingResponse struct {
+	BusinessTripID string                  `json:"business_trip_id"`
+	Meeting        *MeetingSummaryResponse `json:"meeting"`
+}
+
+type SetBusinessTripMeetingUseCase struct {
+	businessTripRepo repository.BusinessTripRepository
+	meetingRepo      repository.MeetingRepository
+}
+
+func NewSetBusinessTripMeetingUseCase(businessTripRepo repository.BusinessTripRepository, meetingRepo repository.MeetingRepository) *SetBusinessTripMeetingUseCase {
+	return &SetBusinessTripMeetingUseCase{
+		businessTripRepo: businessTripRepo,
+		meetingRepo:      meetingRepo,
+	}
+}
+
+// Execute links the business trip to the meeting, after checking with Zoom that the meeting exists,
+// or unlinks it when the request has no meeting ID
+func (uc *SetBusinessTripMeetingUseCase) Execute(ctx context.Context, businessTripID string, req *SetBusinessTripMeetingRequest) (*SetBusinessTripMeetingResponse, error) {
+	businessTrip, err := uc.businessTripRepo.GetByID(ctx, businessTripID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get business trip: %w", err)
+	}
+	if businessTrip == nil {
+		return nil, fmt.Errorf("%w: %s", entity.ErrBusinessTripNotFound, businessTripID)
+	}
+	if err := ensureTripEditable(businessTrip, req.AllowLockedTrip); err != nil {
+		return nil, err
+	}
+
+	response := &SetBusinessTripMeetingResponse{BusinessTripID: businessTripID}
+
+	meetingID := strings.TrimSpace(req.MeetingID)
+	if meetingID == "" {
+		if err := uc.businessTripRepo.SetMeeting(ctx, businessTripID, sql.NullString{}); err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
+
+	meeting, err := uc.meetingRepo.GetZoomMeeting(ctx, meetingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get meeting: %w", err)
+	}
+
+	if err := uc.businessTripRepo.SetMeeting(ctx, businessTripID, sql.NullString{String: meetingID, Valid: true}); err != nil {
+		return nil, err
+	}
+
+	response.Meeting = toMeetingSummary(meeting)
+	return response, nil
+}
+
+func toMeetingSummary(meeting *entity.Meeting) *MeetingSummaryResponse {
+	return &MeetingSummaryResponse{
+		ID:      meeting.ID,
+		Title:   meeting.Title,
+		JoinURL: meeting.JoinURL,
+	}
+}
@@ -0,0 +1,116 @@
+package business_trip
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+)
+
+// stubMeetingTripRepository records the meeting the trip was linked to
+type stubMeetingTripRepository struct {
+	stubBusinessTripRepository
+	meetingID *sql.NullString
+}
+
+func (r *stubMeetingTripRepository) SetMeeting(_ context.Context, _ string, meetingID sql.NullString) error {
+	r.meetingID = &meetingID
+	return nil
+}
+
+// stubMeetingRepository knows a single Zoom meeting
+type stubMeetingRepository struct {
+	repository.MeetingRepository
+	meeting *entity.Meeting
+}
+
+func (r *stubMeetingRepository) GetZoomMeeting(_ context.Context, meetingID string) (*entity.Meeting, error) {
+	if r.meeting == nil || r.meeting.ID != meetingID {
+		return nil, fmt.Errorf("%w: %s", entity.ErrMeetingNotFound, meetingID)
+	}
+	return r.meeting, nil
+}
+
+func TestSetBusinessTripMeeting(t *testing.T) {
+	meeting := &entity.Meeting{ID: "81234567890", Title: "Rapat Koordinasi", JoinURL: "https://zoom.us/j/81234567890"}
+
+	tests := []struct {
+		name        string
+		meetingID   string
+		wantErr     error
+		wantLinked  sql.NullString
+		wantMeeting bool
+	}{
+		{"link", "81234567890", nil, sql.NullString{String: "81234567890", Valid: true}, true},
+		{"unlink", " ", nil, sql.NullString{}, false},
+		{"unknown meeting", "80000000000", entity.ErrMeetingNotFound, sql.NullString{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tripRepo := &stubMeetingTripRepository{stubBusinessTripRepository: stubBusinessTripRepository{trip: newTestBusinessTrip(t)}}
+			uc := NewSetBusinessTripMeetingUseCase(tripRepo, &stubMeetingRepository{meeting: meeting})
+
+			response, err := uc.Execute(context.Background(), "trip-1", &SetBusinessTripMeetingRequest{MeetingID: tt.meetingID})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+				}
+				if tripRepo.meetingID != nil {
+					t.Errorf("trip linked to %+v, want it left alone", *tripRepo.meetingID)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			if tripRepo.meetingID == nil || *tripRepo.meetingID != tt.wantLinked {
+				t.Errorf("trip linked to %+v, want %+v", tripRepo.meetingID, tt.wantLinked)
+			}
+			if (response.Meeting != nil) != tt.wantMeeting {
+				t.Fatalf("Meeting = %+v, want a meeting: %v", response.Meeting, tt.wantMeeting)
+			}
+			if tt.wantMeeting && (response.Meeting.Title != meeting.Title || response.Meeting.JoinURL != meeting.JoinURL) {
+				t.Errorf("Meeting = %+v, want the summary of %+v", response.Meeting, meeting)
+			}
+		})
+	}
+}
+
+// countingMeetingRepository counts the Zoom lookups
+type countingMeetingRepository struct {
+	stubMeetingRepository
+	lookups int
+}
+
+func (r *countingMeetingRepository) GetZoomMeeting(ctx context.Context, meetingID string) (*entity.Meeting, error) {
+	r.lookups++
+	return r.stubMeetingRepository.GetZoomMeeting(ctx, meetingID)
+}
+
+func TestGetBusinessTripLooksUpMeetingOnlyWhenLoaded(t *testing.T) {
+	trip := newTestBusinessTrip(t)
+	trip.MeetingID = sql.NullString{String: "81234567890", Valid: true}
+	meetings := &countingMeetingRepository{stubMeetingRepository: stubMeetingRepository{
+		meeting: &entity.Meeting{ID: "81234567890", Title: "Rapat Koordinasi", JoinURL: "https://zoom.us/j/81234567890"},
+	}}
+	uc := NewGetBusinessTripUseCase(&stubBusinessTripRepository{trip: trip}, meetings, 0)
+
+	response, err := uc.Execute(context.Background(), trip.ID)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if meetings.lookups != 0 || response.Meeting != nil {
+		t.Fatalf("Execute() looked up the meeting %d times, want none before the detail is sent", meetings.lookups)
+	}
+
+	uc.LoadMeeting(context.Background(), response)
+	if meetings.lookups != 1 || response.Meeting == nil || response.Meeting.JoinURL != "https://zoom.us/j/81234567890" {
+		t.Errorf("LoadMeeting() lookups = %d meeting = %+v, want the linked meeting", meetings.lookups, response.Meeting)
+	}
+}
@@ -0,0 +1,112 @@
+package business_trip
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/service"
+	"sandbox/pkg/nullable"
+)
+
+func TestTransactionAmountCap(t *testing.T) {
+	caps := entity.TransactionAmountCaps{
+		entity.TransactionTypeAccommodation: 2_000_000,
+		entity.TransactionTypeTransport:     0,
+	}
+
+	tests := []struct {
+		name             string
+		txType           string
+		subtype          string
+		amount           float64
+		allowLargeAmount bool
+		wantErr          bool
+	}{
+		{"below the cap", "accommodation", "hotel", 1_999_999.99, false, false},
+		{"exactly the cap", "accommodation", "hotel", 2_000_000, false, false},
+		{"just above the cap", "accommodation", "hotel", 2_000_000.01, false, true},
+		{"extra zero", "accommodation", "hotel", 20_000_000, false, true},
+		{"above the cap with override", "accommodation", "hotel", 20_000_000, true, false},
+		{"zero cap does not limit", "transport", "flight", 50_000_000, false, false},
+		{"type without a cap", "other", "other", 50_000_000, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := entity.NewTransaction("Cost", entity.TransactionType(tt.txType), entity.TransactionSubtype(tt.subtype),
+				entity.TransactionDirectionDebit, tt.amount, 0, nil, nil, "", "", caps, tt.allowLargeAmount)
+			if tt.wantErr && !errors.Is(err, entity.ErrAmountExceedsCap) {
+				t.Errorf("NewTransaction() error = %v, want ErrAmountExceedsCap", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("NewTransaction() error = %v", err)
+			}
+
+			tripRepo, assigneeRepo := newLockTestRepositories(t, entity.BusinessTripStatusOngoing)
+			add := NewAddTransactionUseCase(tripRepo, assigneeRepo, service.NewTransactionTypePolicy(nil), caps, entity.DefaultBaseCurrency)
+			_, err = add.Execute(context.Background(), "assignee-1", TransactionRequest{
+				Name: "Cost", Type: tt.txType, Subtype: tt.subtype, Amount: tt.amount, AllowLargeAmount: tt.allowLargeAmount,
+			}, false)
+			if errors.Is(err, entity.ErrAmountExceedsCap) != tt.wantErr {
+				t.Errorf("AddTransaction error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTransactionAmountCapNotConfigured(t *testing.T) {
+	if _, err := entity.NewTransaction("Cost", entity.TransactionTypeAccommodation, entity.TransactionSubtypeHotel,
+		entity.TransactionDirectionDebit, 50_000_000, 0, nil, nil, "", "", nil, false); err != nil {
+		t.Errorf("NewTransaction() without caps error = %v", err)
+	}
+}
+
+func TestUpdateAndPatchTransactionAmountCap(t *testing.T) {
+	caps := entity.TransactionAmountCaps{entity.TransactionTypeAccommodation: 2_000_000}
+	tripRepo, assigneeRepo := newLockTestRepositories(t, entity.BusinessTripStatusOngoing)
+	nights := 1
+	tripRepo.transaction = &entity.Transaction{
+		ID:         "transaction-1",
+		AssigneeID: "assignee-1",
+		Name:       "Hotel",
+		Type:       entity.TransactionTypeAccommodation,
+		Subtype:    entity.TransactionSubtypeHotel,
+		Amount:     500_000,
+		TotalNight: &nights,
+	}
+	policy := service.NewTransactionTypePolicy(nil)
+	update := NewUpdateTransactionUseCase(tripRepo, assigneeRepo, policy, caps, entity.DefaultBaseCurrency)
+	patch := NewPatchTransactionUseCase(tripRepo, assigneeRepo, policy, caps, entity.DefaultBaseCurrency)
+
+	updateReq := UpdateTransactionRequest{
+		BusinessTripID: tripRepo.trip.ID, AssigneeID: "assignee-1", TransactionID: "transaction-1",
+		Name: "Hotel", Type: "accommodation", Subtype: "hotel", Amount: 20_000_000, TotalNight: &nights,
+	}
+	if _, err := update.Execute(context.Background(), updateReq); !errors.Is(err, entity.ErrAmountExceedsCap) {
+		t.Errorf("update above the cap error = %v, want ErrAmountExceedsCap", err)
+	}
+
+	tooLarge := 20_000_000.0
+	patchReq := PatchTransactionRequest{BusinessTripID: tripRepo.trip.ID, AssigneeID: "assignee-1", TransactionID: "transaction-1", Amount: &tooLarge}
+	if _, err := patch.Execute(context.Background(), patchReq); !errors.Is(err, entity.ErrAmountExceedsCap) {
+		t.Errorf("patch above the cap error = %v, want ErrAmountExceedsCap", err)
+	}
+
+	patchReq.AllowLargeAmount = true
+	got, err := patch.Execute(context.Background(), patchReq)
+	if err != nil {
+		t.Fatalf("patch above the cap with override error = %v", err)
+	}
+	if got.Amount != tooLarge {
+		t.Errorf("amount = %v, want %v", got.Amount, tooLarge)
+	}
+
+	// Editing another field keeps an amount an administrator already allowed
+	describe := PatchTransactionRequest{BusinessTripID: tripRepo.trip.ID, AssigneeID: "assignee-1", TransactionID: "transaction-1",
+		Description: nullable.NullString{String: "Conference hotel", Valid: true}}
+	if _, err := patch.Execute(context.Background(), describe); err != nil {
+		t.Errorf("patch of the description error = %v", err)
+	}
+}
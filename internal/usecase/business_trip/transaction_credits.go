@@ -0,0 +1,27 @@
+package business_trip
+
+import (
+	"context"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+)
+
+// validateAssigneeCredits checks the credits of the assignee once changed is saved: changed replaces the
+// stored transaction with the same ID or is added when it is new
+func validateAssigneeCredits(ctx context.Context, businessTripRepo repository.BusinessTripRepository, assignee *entity.Assignee, changed *entity.Transaction) error {
+	stored, err := businessTripRepo.GetTransactionsByAssigneeID(ctx, assignee.ID)
+	if err != nil {
+		return err
+	}
+
+	transactions := make([]*entity.Transaction, 0, len(stored)+1)
+	for _, transaction := range stored {
+		if changed.ID == "" || transaction.ID != changed.ID {
+			transactions = append(transactions, transaction)
+		}
+	}
+	transactions = append(transactions, changed)
+
+	return (&entity.Assignee{ID: assignee.ID, Transactions: transactions}).ValidateCredits()
+}
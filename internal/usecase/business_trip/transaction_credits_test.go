@@ -0,0 +1,88 @@
+package business_trip
+
+import (
+	"errors"
+	"testing"
+
+	"sandbox/internal/domain/entity"
+)
+
+func tripRequestWithTransactions(transactions ...TransactionRequest) BusinessTripRequest {
+	req := tripRequestWithVerificators(0)
+	req.Assignees = []AssigneeRequest{{
+		Name:           "Budi",
+		EmployeeNumber: "198001012010011001",
+		SPDNumber:      "SPD-001",
+		Position:       "Auditor",
+		Rank:           "III/a",
+		Transactions:   transactions,
+	}}
+	return req
+}
+
+func TestTripTotalsSubtractCredits(t *testing.T) {
+	nights := 2
+	req := tripRequestWithTransactions(
+		TransactionRequest{Name: "Hotel", Type: "accommodation", Subtype: "hotel", Amount: 500, TotalNight: &nights},
+		TransactionRequest{Name: "Flight", Type: "transport", Subtype: "flight", Amount: 800},
+		TransactionRequest{Name: "Hotel refund", Type: "accommodation", Subtype: "hotel", Direction: "credit", Amount: 300},
+		TransactionRequest{Name: "Taxi", Type: "transport", Subtype: "taxi", Amount: 50, Direction: "debit"},
+	)
+
+	bt, err := req.ToEntity(entity.DefaultBaseCurrency, entity.DefaultMaxVerificators, nil)
+	if err != nil {
+		t.Fatalf("ToEntity() error = %v", err)
+	}
+
+	if got := bt.GetTotalCost(); got != 1550 {
+		t.Errorf("trip total = %v, want 1550 (1000 + 800 - 300 + 50)", got)
+	}
+	refund := bt.Assignees[0].Transactions[2]
+	if !refund.IsCredit() || refund.Subtotal != 300 {
+		t.Errorf("refund direction/subtotal = %s/%v, want credit/300", refund.Direction, refund.Subtotal)
+	}
+
+	response := FromEntity(bt)
+	if response.TotalCost != 1550 || response.Assignees[0].TotalCost != 1550 {
+		t.Errorf("response totals = %v/%v, want 1550", response.TotalCost, response.Assignees[0].TotalCost)
+	}
+	if got := response.Assignees[0].Transactions[2].Direction; got != "credit" {
+		t.Errorf("refund response direction = %q, want credit", got)
+	}
+}
+
+func TestCreditsMustOffsetCostOfSameKind(t *testing.T) {
+	tests := []struct {
+		name   string
+		credit TransactionRequest
+	}{
+		{"more than the cost", TransactionRequest{Name: "Refund", Type: "transport", Subtype: "flight", Direction: "credit", Amount: 900}},
+		{"other subtype", TransactionRequest{Name: "Refund", Type: "transport", Subtype: "train", Direction: "credit", Amount: 100}},
+		{"other type", TransactionRequest{Name: "Refund", Type: "other", Subtype: "other", Direction: "credit", Amount: 100}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tripRequestWithTransactions(
+				TransactionRequest{Name: "Flight", Type: "transport", Subtype: "flight", Amount: 800},
+				tt.credit,
+			)
+			if _, err := req.ToEntity(entity.DefaultBaseCurrency, entity.DefaultMaxVerificators, nil); !errors.Is(err, entity.ErrCreditExceedsCost) {
+				t.Errorf("ToEntity() error = %v, want ErrCreditExceedsCost", err)
+			}
+		})
+	}
+}
+
+func TestCreditRequiresSubtype(t *testing.T) {
+	req := TransactionRequest{Name: "Refund", Type: "transport", Direction: "credit", Amount: 100}
+	if err := req.Validate(); err == nil {
+		t.Error("Validate() accepted a credit without subtype")
+	}
+
+	req.Direction = "refund"
+	req.Subtype = "flight"
+	if err := req.Validate(); err == nil {
+		t.Error("Validate() accepted an unknown direction")
+	}
+}
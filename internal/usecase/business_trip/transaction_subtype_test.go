@@ -0,0 +1,65 @@
+package business_trip
+
+import (
+	"errors"
+	"testing"
+
+	"sandbox/internal/domain/entity"
+)
+
+func TestTransactionSubtypeMustBelongToType(t *testing.T) {
+	tests := []struct {
+		name    string
+		txType  string
+		subtype string
+		wantErr bool
+	}{
+		{"hotel accommodation", "accommodation", "hotel", false},
+		{"rental car transport", "transport", "rental_car", false},
+		{"meal allowance", "allowance", "meal", false},
+		{"other other", "other", "other", false},
+		{"transport without subtype", "transport", "", false},
+		{"flight accommodation", "accommodation", "flight", true},
+		{"hotel allowance", "allowance", "hotel", true},
+		{"taxi other", "other", "taxi", true},
+		{"allowance without subtype", "allowance", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := TransactionRequest{Name: "Cost", Type: tt.txType, Subtype: tt.subtype, Amount: 100}
+			if err := req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			_, err := entity.NewTransaction("Cost", entity.TransactionType(tt.txType), entity.TransactionSubtype(tt.subtype),
+				entity.TransactionDirectionDebit, 100, 0, nil, nil, "", "", nil, false)
+			if tt.wantErr && !errors.Is(err, entity.ErrIncompatibleSubtype) {
+				t.Errorf("NewTransaction() error = %v, want ErrIncompatibleSubtype", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("NewTransaction() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestLegacyAllowanceMayKeepEmptySubtype(t *testing.T) {
+	legacy := &entity.Transaction{Type: entity.TransactionTypeAllowance}
+	if err := legacy.ValidateSubtypeChange(entity.TransactionTypeAllowance, ""); err != nil {
+		t.Errorf("legacy allowance keeping an empty subtype: error = %v", err)
+	}
+	if err := legacy.ValidateSubtypeChange(entity.TransactionTypeAllowance, entity.TransactionSubtypeHotel); !errors.Is(err, entity.ErrIncompatibleSubtype) {
+		t.Errorf("legacy allowance moving to hotel: error = %v, want ErrIncompatibleSubtype", err)
+	}
+
+	transport := &entity.Transaction{Type: entity.TransactionTypeTransport}
+	if err := transport.ValidateSubtypeChange(entity.TransactionTypeAllowance, ""); !errors.Is(err, entity.ErrIncompatibleSubtype) {
+		t.Errorf("transport becoming an allowance without subtype: error = %v, want ErrIncompatibleSubtype", err)
+	}
+
+	req := UpdateTransactionRequest{BusinessTripID: "trip", AssigneeID: "assignee", TransactionID: "tx", Name: "Allowance", Type: "allowance", Amount: 100}
+	if err := req.Validate(); err != nil {
+		t.Errorf("UpdateTransactionRequest.Validate() with an empty allowance subtype: error = %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package business_trip
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/invopop/validation"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/pkg/database"
+)
+
+// TransferAssigneeUseCase moves an assignee that was added to the wrong business trip, together
+// with its transactions, to another business trip
+type TransferAssigneeUseCase struct {
+	businessTripRepo repository.BusinessTripRepository
+	assigneeRepo     repository.AssigneeRepository
+	db               database.DB
+}
+
+func NewTransferAssigneeUseCase(businessTripRepo repository.BusinessTripRepository, assigneeRepo repository.AssigneeRepository, db database.DB) *TransferAssigneeUseCase {
+	return &TransferAssigneeUseCase{
+		businessTripRepo: businessTripRepo,
+		assigneeRepo:     assigneeRepo,
+		db:               db,
+	}
+}
+
+type TransferAssigneeRequest struct {
+	AssigneeID           string `params:"assigneeId" json:"assigneeId"`
+	TargetBusinessTripID string `json:"targetBusinessTripId"`
+
+	// AllowLockedTrip lets an administrator move an assignee from or to a completed or canceled trip
+	AllowLockedTrip bool `json:"-" form:"-"`
+}
+
+func (r TransferAssigneeRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.AssigneeID, validation.Required),
+		validation.Field(&r.TargetBusinessTripID, validation.Required),
+	)
+}
+
+type TransferAssigneeResponse struct {
+	ID                     string `json:"id"`
+	PreviousBusinessTripID string `json:"previousBusinessTripId"`
+	BusinessTripID         string `json:"businessTripId"`
+	Name                   string `json:"name"`
+	SPDNumber              string `json:"spdNumber"`
+	UpdatedAt              string `json:"updatedAt"`
+}
+
+// Execute moves the assignee after checking that both trips exist and are editable and that the
+// assignee's SPD number is not used on the target trip yet
+func (uc *TransferAssigneeUseCase) Execute(ctx context.Context, req TransferAssigneeRequest) (*TransferAssigneeResponse, error) {
+	var response *TransferAssigneeResponse
+	err := database.WithinTx(ctx, uc.db, func(tx database.DBTx) error {
+		repoWithTx := uc.businessTripRepo.(interface {
+			WithTransaction(database.DBTx) repository.BusinessTripRepository
+		}).WithTransaction(tx)
+
+		assigneeRepoWithTx := uc.assigneeRepo.(interface {
+			WithTransaction(database.DBTx) repository.AssigneeRepository
+		}).WithTransaction(tx)
+
+		assignee, err := assigneeRepoWithTx.GetAssigneeByID(ctx, req.AssigneeID)
+		if err != nil {
+			return fmt.Errorf("failed to get assignee: %w", err)
+		}
+		if assignee == nil {
+			return entity.ErrAssigneeNotFound
+		}
+		if assignee.BusinessTripID == req.TargetBusinessTripID {
+			return fmt.Errorf("%w: %s", entity.ErrAssigneeOnSameTrip, req.TargetBusinessTripID)
+		}
+
+		for _, tripID := range []string{assignee.BusinessTripID, req.TargetBusinessTripID} {
+			businessTrip, err := repoWithTx.GetByID(ctx, tripID)
+			if err != nil {
+				return fmt.Errorf("failed to get business trip: %w", err)
+			}
+			if businessTrip == nil {
+				return fmt.Errorf("%w: %s", entity.ErrBusinessTripNotFound, tripID)
+			}
+			if err := ensureTripEditable(businessTrip, req.AllowLockedTrip); err != nil {
+				return err
+			}
+		}
+
+		targetAssignees, err := assigneeRepoWithTx.GetAssigneesByBusinessTripIDWithoutTransactions(ctx, req.TargetBusinessTripID)
+		if err != nil {
+			return fmt.Errorf("failed to get assignees: %w", err)
+		}
+		for _, existingAssignee := range targetAssignees {
+			if strings.EqualFold(existingAssignee.SPDNumber, assignee.SPDNumber) {
+				return fmt.Errorf("%w: %s already exists for this business trip", entity.ErrDuplicateSPDNumber, assignee.SPDNumber)
+			}
+		}
+
+		previousBusinessTripID := assignee.BusinessTripID
+		if err := assigneeRepoWithTx.MoveToBusinessTrip(ctx, assignee, req.TargetBusinessTripID); err != nil {
+			return err
+		}
+
+		response = &TransferAssigneeResponse{
+			ID:                     assignee.ID,
+			PreviousBusinessTripID: previousBusinessTripID,
+			BusinessTripID:         assignee.BusinessTripID,
+			Name:                   assignee.Name,
+			SPDNumber:              assignee.SPDNumber,
+			UpdatedAt:              assignee.UpdatedAt.Format(time.RFC3339),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,140 @@
+package business_trip
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/pkg/database"
+)
+
+// stubTxDB hands out transactions that record whether they were committed or rolled back
+type stubTxDB struct {
+	database.DB
+	committed  bool
+	rolledBack bool
+	commits    int
+}
+
+type stubTx struct {
+	database.DBTx
+	db *stubTxDB
+}
+
+func (tx *stubTx) Commit() error {
+	tx.db.committed = true
+	tx.db.commits++
+	return nil
+}
+
+func (tx *stubTx) Rollback() error {
+	tx.db.rolledBack = true
+	return nil
+}
+
+func (db *stubTxDB) BeginTx(context.Context, *sql.TxOptions) (database.DBTx, error) {
+	return &stubTx{db: db}, nil
+}
+
+type stubTransferTripRepository struct {
+	repository.BusinessTripRepository
+	trips map[string]*entity.BusinessTrip
+}
+
+func (r *stubTransferTripRepository) WithTransaction(database.DBTx) repository.BusinessTripRepository {
+	return r
+}
+
+func (r *stubTransferTripRepository) GetByID(_ context.Context, id string) (*entity.BusinessTrip, error) {
+	return r.trips[id], nil
+}
+
+type stubTransferAssigneeRepository struct {
+	repository.AssigneeRepository
+	assignees []*entity.Assignee
+	moved     bool
+}
+
+func (r *stubTransferAssigneeRepository) WithTransaction(database.DBTx) repository.AssigneeRepository {
+	return r
+}
+
+func (r *stubTransferAssigneeRepository) GetAssigneeByID(_ context.Context, id string) (*entity.Assignee, error) {
+	for _, assignee := range r.assignees {
+		if assignee.ID == id {
+			return assignee, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *stubTransferAssigneeRepository) GetAssigneesByBusinessTripIDWithoutTransactions(_ context.Context, businessTripID string) ([]*entity.Assignee, error) {
+	var assignees []*entity.Assignee
+	for _, assignee := range r.assignees {
+		if assignee.BusinessTripID == businessTripID {
+			assignees = append(assignees, assignee)
+		}
+	}
+	return assignees, nil
+}
+
+func (r *stubTransferAssigneeRepository) MoveToBusinessTrip(_ context.Context, assignee *entity.Assignee, businessTripID string) error {
+	r.moved = true
+	assignee.BusinessTripID = businessTripID
+	return nil
+}
+
+func TestTransferAssignee(t *testing.T) {
+	tests := []struct {
+		name         string
+		assigneeID   string
+		targetTripID string
+		wantErr      error
+	}{
+		{name: "moves to an editable trip", assigneeID: "assignee-1", targetTripID: "trip-2"},
+		{name: "unknown assignee", assigneeID: "assignee-9", targetTripID: "trip-2", wantErr: entity.ErrAssigneeNotFound},
+		{name: "unknown target trip", assigneeID: "assignee-1", targetTripID: "trip-9", wantErr: entity.ErrBusinessTripNotFound},
+		{name: "same trip", assigneeID: "assignee-1", targetTripID: "trip-1", wantErr: entity.ErrAssigneeOnSameTrip},
+		{name: "completed target trip", assigneeID: "assignee-1", targetTripID: "trip-completed", wantErr: entity.ErrBusinessTripLocked},
+		{name: "completed source trip", assigneeID: "assignee-locked", targetTripID: "trip-2", wantErr: entity.ErrBusinessTripLocked},
+		{name: "SPD number taken on the target trip", assigneeID: "assignee-1", targetTripID: "trip-3", wantErr: entity.ErrDuplicateSPDNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tripRepo := &stubTransferTripRepository{trips: map[string]*entity.BusinessTrip{
+				"trip-1":         {ID: "trip-1", Status: entity.BusinessTripStatusOngoing},
+				"trip-2":         {ID: "trip-2", Status: entity.BusinessTripStatusDraft},
+				"trip-3":         {ID: "trip-3", Status: entity.BusinessTripStatusOngoing},
+				"trip-completed": {ID: "trip-completed", Status: entity.BusinessTripStatusCompleted},
+			}}
+			assigneeRepo := &stubTransferAssigneeRepository{assignees: []*entity.Assignee{
+				{ID: "assignee-1", BusinessTripID: "trip-1", SPDNumber: "SPD-001"},
+				{ID: "assignee-2", BusinessTripID: "trip-3", SPDNumber: "spd-001"},
+				{ID: "assignee-locked", BusinessTripID: "trip-completed", SPDNumber: "SPD-002"},
+			}}
+			db := &stubTxDB{}
+			uc := NewTransferAssigneeUseCase(tripRepo, assigneeRepo, db)
+
+			response, err := uc.Execute(context.Background(), TransferAssigneeRequest{
+				AssigneeID:           tt.assigneeID,
+				TargetBusinessTripID: tt.targetTripID,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if assigneeRepo.moved || db.committed {
+					t.Errorf("moved = %v committed = %v, want the transfer rolled back", assigneeRepo.moved, db.committed)
+				}
+				return
+			}
+			if !db.committed || response.PreviousBusinessTripID != "trip-1" || response.BusinessTripID != tt.targetTripID {
+				t.Errorf("response = %+v committed = %v, want a committed move from trip-1 to %s", response, db.committed, tt.targetTripID)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package business_trip
+
+import (
+	"sandbox/internal/domain/entity"
+)
+
+// checkTripDuration holds the trip to the configured maximum duration unless the caller forces it
+// for a genuine long assignment
+func checkTripDuration(businessTrip *entity.BusinessTrip, maxDays int, force bool) error {
+	if force {
+		return nil
+	}
+	return businessTrip.ValidateDuration(maxDays)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/invopop/validation"
 
+	"sandbox/internal/domain/entity"
 	"sandbox/internal/domain/repository"
 	"sandbox/internal/domain/service"
 )
@@ -36,6 +37,9 @@ type UpdateAssigneeRequest struct {
 	EmployeeNumber string `json:"employeeNumber"`
 	Position       string `json:"position"`
 	Rank           string `json:"rank"`
+
+	// AllowLockedTrip lets an administrator update an assignee of a completed or canceled trip
+	AllowLockedTrip bool `json:"-" form:"-"`
 }
 
 func (r UpdateAssigneeRequest) Validate() error {
@@ -72,6 +76,9 @@ func (uc *UpdateAssigneeUseCase) Execute(ctx context.Context, req UpdateAssignee
 	if businessTrip == nil {
 		return nil, fmt.Errorf("business trip not found")
 	}
+	if err := ensureTripEditable(businessTrip, req.AllowLockedTrip); err != nil {
+		return nil, err
+	}
 
 	assignee, err := uc.assigneeRepo.GetAssigneeByID(ctx, req.AssigneeID)
 	if err != nil {
@@ -92,7 +99,7 @@ func (uc *UpdateAssigneeUseCase) Execute(ctx context.Context, req UpdateAssignee
 
 	for _, existingAssignee := range assignees {
 		if existingAssignee.ID != req.AssigneeID && strings.EqualFold(existingAssignee.SPDNumber, req.SPDNumber) {
-			return nil, fmt.Errorf("SPD number %s already exists for this business trip", req.SPDNumber)
+			return nil, fmt.Errorf("%w: %s already exists for this business trip", entity.ErrDuplicateSPDNumber, req.SPDNumber)
 		}
 	}
 
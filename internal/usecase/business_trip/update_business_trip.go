@@ -7,15 +7,23 @@ import (
 
 	"sandbox/internal/domain/entity"
 	"sandbox/internal/domain/repository"
+	"sandbox/internal/domain/service"
+	"sandbox/pkg/featureflag"
 )
 
 type UpdateBusinessTripUseCase struct {
 	businessTripRepo repository.BusinessTripRepository
+	flags            *featureflag.Service
+	webhooks         *service.BusinessTripWebhookDispatcher
+	maxTripDays      int
 }
 
-func NewUpdateBusinessTripUseCase(businessTripRepo repository.BusinessTripRepository) *UpdateBusinessTripUseCase {
+func NewUpdateBusinessTripUseCase(businessTripRepo repository.BusinessTripRepository, flags *featureflag.Service, webhooks *service.BusinessTripWebhookDispatcher, maxTripDays int) *UpdateBusinessTripUseCase {
 	return &UpdateBusinessTripUseCase{
 		businessTripRepo: businessTripRepo,
+		flags:            flags,
+		webhooks:         webhooks,
+		maxTripDays:      maxTripDays,
 	}
 }
 
@@ -27,6 +35,15 @@ func (uc *UpdateBusinessTripUseCase) Execute(ctx context.Context, req UpdateBusi
 	if businessTrip == nil {
 		return nil, entity.ErrBusinessTripNotFound
 	}
+	if req.Version != nil && *req.Version != businessTrip.Version {
+		return nil, entity.ErrStaleBusinessTrip
+	}
+	// A completed or canceled trip only takes a legal status change, such as reactivating a canceled trip
+	if req.changesDetails() {
+		if err := ensureTripEditable(businessTrip, req.AllowLockedTrip); err != nil {
+			return nil, err
+		}
+	}
 
 	// Update fields if provided
 	if req.StartDate.IsSet() {
@@ -71,20 +88,25 @@ func (uc *UpdateBusinessTripUseCase) Execute(ctx context.Context, req UpdateBusi
 		businessTrip.ReturnDate = returnDate
 	}
 
-	// Validate the updated business trip
-	if businessTrip.StartDate.After(businessTrip.EndDate) {
-		return nil, entity.ErrInvalidDateRange
-	}
-	if businessTrip.DepartureDate.After(businessTrip.ReturnDate) {
-		return nil, entity.ErrInvalidDateRange
+	// Re-check the date ordering on the merged trip so a partial update cannot break it
+	if err := businessTrip.ValidateDateOrder(); err != nil {
+		return nil, err
 	}
-	if businessTrip.SPDDate.After(businessTrip.DepartureDate) {
-		return nil, entity.ErrInvalidDateRange
+	// Only a change of the travel dates is held to the duration cap, so trips created with force stay editable
+	if req.DepartureDate.IsSet() || req.ReturnDate.IsSet() {
+		if err := checkTripDuration(businessTrip, uc.maxTripDays, req.Force); err != nil {
+			return nil, err
+		}
 	}
 
 	// Update status if provided
+	oldStatus := businessTrip.Status
 	if req.Status.IsSet() {
 		newStatus := entity.BusinessTripStatus(req.Status.String)
+		if newStatus == entity.BusinessTripStatusReadyToVerify && len(businessTrip.GetVerificators()) == 0 &&
+			uc.flags.Enabled(ctx, featureflag.RequireVerificators) {
+			return nil, entity.ErrVerificatorsRequired
+		}
 		if err := businessTrip.UpdateStatus(newStatus); err != nil {
 			return nil, err
 		}
@@ -101,5 +123,14 @@ func (uc *UpdateBusinessTripUseCase) Execute(ctx context.Context, req UpdateBusi
 		return nil, err
 	}
 
+	uc.webhooks.StatusChanged(ctx, updatedBusinessTrip.ID, oldStatus, updatedBusinessTrip.Status)
+
 	return FromEntity(updatedBusinessTrip), nil
 }
+
+// changesDetails reports whether the request changes anything other than the status
+func (r UpdateBusinessTripRequest) changesDetails() bool {
+	return r.BusinessTripNumber.IsSet() || r.StartDate.IsSet() || r.EndDate.IsSet() || r.ActivityPurpose.IsSet() ||
+		r.DestinationCity.IsSet() || r.SPDDate.IsSet() || r.DepartureDate.IsSet() || r.ReturnDate.IsSet() ||
+		r.DocumentLink.IsSet()
+}
@@ -0,0 +1,232 @@
+package business_trip
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/pkg/featureflag"
+	"sandbox/pkg/nullable"
+)
+
+// stubBusinessTripRepository serves a single trip and records whether it was saved
+type stubBusinessTripRepository struct {
+	repository.BusinessTripRepository
+	trip    *entity.BusinessTrip
+	updated bool
+}
+
+func (r *stubBusinessTripRepository) GetByID(context.Context, string) (*entity.BusinessTrip, error) {
+	return r.trip, nil
+}
+
+func (r *stubBusinessTripRepository) Update(_ context.Context, bt *entity.BusinessTrip) (*entity.BusinessTrip, error) {
+	r.updated = true
+	return bt, nil
+}
+
+func newTestBusinessTrip(t *testing.T) *entity.BusinessTrip {
+	t.Helper()
+
+	date := func(s string) time.Time {
+		d, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	bt, err := entity.NewBusinessTrip(
+		date("2025-03-10"), date("2025-03-14"), // start, end
+		date("2025-03-01"),                     // SPD
+		date("2025-03-10"), date("2025-03-14"), // departure, return
+		"Audit", "Bandung",
+	)
+	if err != nil {
+		t.Fatalf("NewBusinessTrip() error = %v", err)
+	}
+	return bt
+}
+
+func TestUpdateBusinessTripRejectsSPDDateAfterUnchangedDepartureDate(t *testing.T) {
+	repo := &stubBusinessTripRepository{trip: newTestBusinessTrip(t)}
+	uc := NewUpdateBusinessTripUseCase(repo, featureflag.NewService(nil, nil), nil, entity.DefaultMaxTripDays)
+
+	_, err := uc.Execute(context.Background(), UpdateBusinessTripRequest{
+		BusinessTripID: repo.trip.ID,
+		SPDDate:        nullable.NullString{String: "2025-03-11", Valid: true},
+	})
+
+	if !errors.Is(err, entity.ErrDateOrderViolation) {
+		t.Fatalf("Execute() error = %v, want %v", err, entity.ErrDateOrderViolation)
+	}
+	if !strings.Contains(err.Error(), "SPD date must be before or equal to departure date") {
+		t.Errorf("error %q does not name the violated rule", err)
+	}
+	if repo.updated {
+		t.Error("trip was saved despite the date order violation")
+	}
+}
+
+func TestUpdateBusinessTripRejectsDepartureDateMovedBeforeSPDDate(t *testing.T) {
+	repo := &stubBusinessTripRepository{trip: newTestBusinessTrip(t)}
+	uc := NewUpdateBusinessTripUseCase(repo, featureflag.NewService(nil, nil), nil, entity.DefaultMaxTripDays)
+
+	_, err := uc.Execute(context.Background(), UpdateBusinessTripRequest{
+		BusinessTripID: repo.trip.ID,
+		DepartureDate:  nullable.NullString{String: "2025-02-28", Valid: true},
+	})
+
+	if !errors.Is(err, entity.ErrDateOrderViolation) {
+		t.Fatalf("Execute() error = %v, want %v", err, entity.ErrDateOrderViolation)
+	}
+	if repo.updated {
+		t.Error("trip was saved despite the date order violation")
+	}
+}
+
+func TestUpdateBusinessTripAcceptsValidPartialDateUpdate(t *testing.T) {
+	repo := &stubBusinessTripRepository{trip: newTestBusinessTrip(t)}
+	uc := NewUpdateBusinessTripUseCase(repo, featureflag.NewService(nil, nil), nil, entity.DefaultMaxTripDays)
+
+	_, err := uc.Execute(context.Background(), UpdateBusinessTripRequest{
+		BusinessTripID: repo.trip.ID,
+		SPDDate:        nullable.NullString{String: "2025-03-10", Valid: true},
+	})
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !repo.updated {
+		t.Error("valid update was not saved")
+	}
+}
+
+func TestUpdateBusinessTripRejectsStaleVersion(t *testing.T) {
+	repo := &stubBusinessTripRepository{trip: newTestBusinessTrip(t)}
+	repo.trip.Version = 3
+	uc := NewUpdateBusinessTripUseCase(repo, featureflag.NewService(nil, nil), nil, entity.DefaultMaxTripDays)
+
+	staleVersion := 2
+	_, err := uc.Execute(context.Background(), UpdateBusinessTripRequest{
+		BusinessTripID:  repo.trip.ID,
+		ActivityPurpose: nullable.NullString{String: "Review", Valid: true},
+		Version:         &staleVersion,
+	})
+
+	if !errors.Is(err, entity.ErrStaleBusinessTrip) {
+		t.Fatalf("Execute() error = %v, want %v", err, entity.ErrStaleBusinessTrip)
+	}
+	if repo.updated {
+		t.Error("trip was saved with a stale version")
+	}
+}
+
+func TestUpdateBusinessTripMaxDuration(t *testing.T) {
+	// The test trip departs on 2025-03-10; counting both days, 2026-03-09 makes it exactly 365 days long
+	tests := []struct {
+		name       string
+		returnDate string
+		force      bool
+		wantErr    bool
+	}{
+		{"one day under the cap", "2026-03-08", false, false},
+		{"exactly at the cap", "2026-03-09", false, false},
+		{"one day over the cap", "2026-03-10", false, true},
+		{"over the cap with force", "2026-03-10", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubBusinessTripRepository{trip: newTestBusinessTrip(t)}
+			uc := NewUpdateBusinessTripUseCase(repo, featureflag.NewService(nil, nil), nil, entity.DefaultMaxTripDays)
+
+			_, err := uc.Execute(context.Background(), UpdateBusinessTripRequest{
+				BusinessTripID: repo.trip.ID,
+				ReturnDate:     nullable.NullString{String: tt.returnDate, Valid: true},
+				Force:          tt.force,
+			})
+
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("Execute() error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, entity.ErrTripTooLong) {
+				t.Fatalf("Execute() error = %v, want %v", err, entity.ErrTripTooLong)
+			}
+			if !strings.Contains(err.Error(), "366 days") {
+				t.Errorf("error %q does not report the computed duration", err)
+			}
+			if repo.updated {
+				t.Error("trip was saved despite exceeding the maximum duration")
+			}
+		})
+	}
+}
+
+func TestUpdateBusinessTripKeepsForcedLongTripEditable(t *testing.T) {
+	repo := &stubBusinessTripRepository{trip: newTestBusinessTrip(t)}
+	repo.trip.ReturnDate = repo.trip.DepartureDate.AddDate(2, 0, 0)
+	uc := NewUpdateBusinessTripUseCase(repo, featureflag.NewService(nil, nil), nil, entity.DefaultMaxTripDays)
+
+	_, err := uc.Execute(context.Background(), UpdateBusinessTripRequest{
+		BusinessTripID:  repo.trip.ID,
+		ActivityPurpose: nullable.NullString{String: "Review", Valid: true},
+	})
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v, want updates that leave the dates alone to pass", err)
+	}
+}
+
+func TestUpdateBusinessTripOnLockedTrip(t *testing.T) {
+	tests := []struct {
+		name            string
+		req             UpdateBusinessTripRequest
+		allowLockedTrip bool
+		wantErr         error
+	}{
+		{name: "reactivated", req: UpdateBusinessTripRequest{Status: nullable.NullString{String: "draft", Valid: true}}},
+		{name: "illegal status change", req: UpdateBusinessTripRequest{Status: nullable.NullString{String: "ongoing", Valid: true}},
+			wantErr: errors.New("cannot transition from canceled to ongoing")},
+		{name: "details changed", req: UpdateBusinessTripRequest{ActivityPurpose: nullable.NullString{String: "Review", Valid: true}},
+			wantErr: entity.ErrBusinessTripLocked},
+		{name: "details changed with override", req: UpdateBusinessTripRequest{ActivityPurpose: nullable.NullString{String: "Review", Valid: true}},
+			allowLockedTrip: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubBusinessTripRepository{trip: newTestBusinessTrip(t)}
+			repo.trip.Status = entity.BusinessTripStatusCanceled
+			uc := NewUpdateBusinessTripUseCase(repo, featureflag.NewService(nil, nil), nil, entity.DefaultMaxTripDays)
+
+			req := tt.req
+			req.BusinessTripID = repo.trip.ID
+			req.AllowLockedTrip = tt.allowLockedTrip
+			_, err := uc.Execute(context.Background(), req)
+
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("Execute() error = %v", err)
+				}
+				if !repo.updated {
+					t.Error("allowed update was not saved")
+				}
+				return
+			}
+			if err == nil || !errors.Is(err, tt.wantErr) && err.Error() != tt.wantErr.Error() {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if repo.updated {
+				t.Error("locked trip was saved")
+			}
+		})
+	}
+}
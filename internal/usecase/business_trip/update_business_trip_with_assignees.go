@@ -16,15 +16,29 @@ type UpdateBusinessTripWithAssigneesUseCase struct {
 	transactionRepo  repository.BusinessTripTransactionRepository
 	userService      *service.UserService
 	db               database.DB
+	duplicates       *service.DuplicateTransactionDetector
+	typePolicy       *service.TransactionTypePolicy
+	webhooks         *service.BusinessTripWebhookDispatcher
+	maxTripDays      int
+	maxVerificators  int
+	amountCaps       entity.TransactionAmountCaps
+	baseCurrency     string
 }
 
-func NewUpdateBusinessTripWithAssigneesUseCase(businessTripRepo repository.BusinessTripRepository, assigneeRepo repository.AssigneeRepository, transactionRepo repository.BusinessTripTransactionRepository, userService *service.UserService, db database.DB) *UpdateBusinessTripWithAssigneesUseCase {
+func NewUpdateBusinessTripWithAssigneesUseCase(businessTripRepo repository.BusinessTripRepository, assigneeRepo repository.AssigneeRepository, transactionRepo repository.BusinessTripTransactionRepository, userService *service.UserService, db database.DB, duplicates *service.DuplicateTransactionDetector, typePolicy *service.TransactionTypePolicy, webhooks *service.BusinessTripWebhookDispatcher, maxTripDays, maxVerificators int, amountCaps entity.TransactionAmountCaps, baseCurrency string) *UpdateBusinessTripWithAssigneesUseCase {
 	return &UpdateBusinessTripWithAssigneesUseCase{
 		businessTripRepo: businessTripRepo,
 		assigneeRepo:     assigneeRepo,
 		transactionRepo:  transactionRepo,
 		userService:      userService,
 		db:               db,
+		duplicates:       duplicates,
+		typePolicy:       typePolicy,
+		webhooks:         webhooks,
+		maxTripDays:      maxTripDays,
+		maxVerificators:  maxVerificators,
+		amountCaps:       amountCaps,
+		baseCurrency:     baseCurrency,
 	}
 }
 
@@ -50,13 +64,21 @@ func (uc *UpdateBusinessTripWithAssigneesUseCase) Execute(ctx context.Context, r
 		return nil, fmt.Errorf("failed to fetch user data: %w", err)
 	}
 
-	bt, err := req.ToEntity(req.BusinessTripID)
+	bt, err := req.ToEntity(req.BusinessTripID, uc.baseCurrency, uc.maxVerificators, uc.amountCaps)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert request to entity: %w", err)
 	}
 
+	if err := checkTripDuration(bt, uc.maxTripDays, req.Force); err != nil {
+		return nil, err
+	}
+
+	// Likely duplicates are reported back, not rejected, so the user can review them
+	warnings := uc.duplicates.DetectInAssignees(bt.Assignees)
+
 	var result *entity.BusinessTrip
-	err = uc.db.WithTransaction(ctx, func(ctx context.Context, tx database.DBTx) error {
+	var oldStatus entity.BusinessTripStatus
+	err = database.WithinTx(ctx, uc.db, func(tx database.DBTx) error {
 		repoWithTx := uc.businessTripRepo.(interface {
 			WithTransaction(database.DBTx) repository.BusinessTripRepository
 		}).WithTransaction(tx)
@@ -69,6 +91,29 @@ func (uc *UpdateBusinessTripWithAssigneesUseCase) Execute(ctx context.Context, r
 			WithTransaction(database.DBTx) repository.BusinessTripTransactionRepository
 		}).WithTransaction(tx)
 
+		// The trip is rebuilt from the request, so carry over the stored version for the optimistic lock
+		current, err := repoWithTx.GetByID(ctx, req.BusinessTripID)
+		if err != nil {
+			return fmt.Errorf("failed to get business trip: %w", err)
+		}
+		if current == nil {
+			return entity.ErrBusinessTripNotFound
+		}
+		if err := ensureTripEditable(current, req.AllowLockedTrip); err != nil {
+			return err
+		}
+		if err := ensureLockedStatusChange(current, bt.Status); err != nil {
+			return err
+		}
+		if err := uc.typePolicy.CheckAssignees(ctx, current, bt.Assignees); err != nil {
+			return err
+		}
+		if req.Version != nil && *req.Version != current.Version {
+			return entity.ErrStaleBusinessTrip
+		}
+		bt.Version = current.Version
+		oldStatus = current.Status
+
 		_, err = repoWithTx.Update(ctx, bt)
 		if err != nil {
 			return fmt.Errorf("failed to update business trip: %w", err)
@@ -151,5 +196,10 @@ func (uc *UpdateBusinessTripWithAssigneesUseCase) Execute(ctx context.Context, r
 		return nil, err
 	}
 
-	return FromEntity(result), nil
+	uc.webhooks.StatusChanged(ctx, result.ID, oldStatus, result.Status)
+
+	response := FromEntity(result)
+	response.Warnings = warnings
+
+	return response, nil
 }
@@ -0,0 +1,242 @@
+package business_trip
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/internal/domain/service"
+	"sandbox/internal/infrastructure"
+	"sandbox/pkg/database"
+	"sandbox/pkg/retry"
+)
+
+var errAssigneeInsert = errors.New("assignee insert failed")
+
+type stubNoUsersIdentityService struct {
+	infrastructure.IdentityServiceInterface
+}
+
+func (s *stubNoUsersIdentityService) GetUsersByEmployeeIDs(context.Context, []string) (*infrastructure.UserAPIResponse, error) {
+	return &infrastructure.UserAPIResponse{}, nil
+}
+
+type stubReplaceTripRepository struct {
+	repository.BusinessTripRepository
+	trip                *entity.BusinessTrip
+	deletedTransactions bool
+}
+
+func (r *stubReplaceTripRepository) WithTransaction(database.DBTx) repository.BusinessTripRepository {
+	return r
+}
+
+func (r *stubReplaceTripRepository) GetByID(context.Context, string) (*entity.BusinessTrip, error) {
+	return r.trip, nil
+}
+
+func (r *stubReplaceTripRepository) Update(_ context.Context, bt *entity.BusinessTrip) (*entity.BusinessTrip, error) {
+	return bt, nil
+}
+
+func (r *stubReplaceTripRepository) DeleteTransactionsByAssigneeIDs(context.Context, []string) error {
+	r.deletedTransactions = true
+	return nil
+}
+
+// stubReplaceAssigneeRepository fails to insert the second assignee, after the old ones were deleted
+type stubReplaceAssigneeRepository struct {
+	repository.AssigneeRepository
+	existing []*entity.Assignee
+	deleted  bool
+	created  int
+}
+
+func (r *stubReplaceAssigneeRepository) WithTransaction(database.DBTx) repository.AssigneeRepository {
+	return r
+}
+
+func (r *stubReplaceAssigneeRepository) GetAssigneesByBusinessTripIDWithoutTransactions(context.Context, string) ([]*entity.Assignee, error) {
+	return r.existing, nil
+}
+
+func (r *stubReplaceAssigneeRepository) DeleteAssigneesByBusinessTripID(context.Context, string) error {
+	r.deleted = true
+	return nil
+}
+
+func (r *stubReplaceAssigneeRepository) Create(_ context.Context, assignee *entity.Assignee) (*entity.Assignee, error) {
+	r.created++
+	if r.created > 1 {
+		return nil, errAssigneeInsert
+	}
+	return assignee, nil
+}
+
+type stubReplaceTransactionRepository struct {
+	repository.BusinessTripTransactionRepository
+}
+
+func (r *stubReplaceTransactionRepository) WithTransaction(database.DBTx) repository.BusinessTripTransactionRepository {
+	return r
+}
+
+func (r *stubReplaceTransactionRepository) CreateTransaction(_ context.Context, transaction *entity.Transaction) (*entity.Transaction, error) {
+	return transaction, nil
+}
+
+func (r *stubReplaceTransactionRepository) GetTransactionsByAssigneeID(context.Context, string) ([]*entity.Transaction, error) {
+	return nil, nil
+}
+
+func newReplaceAssigneesUseCase(tripRepo *stubReplaceTripRepository, assigneeRepo *stubReplaceAssigneeRepository, db *stubTxDB, webhooks *service.BusinessTripWebhookDispatcher) *UpdateBusinessTripWithAssigneesUseCase {
+	return NewUpdateBusinessTripWithAssigneesUseCase(tripRepo, assigneeRepo, &stubReplaceTransactionRepository{},
+		service.NewUserService(&stubNoUsersIdentityService{}), db,
+		service.NewDuplicateTransactionDetector(service.DuplicateTransactionConfig{}), service.NewTransactionTypePolicy(nil), webhooks,
+		entity.DefaultMaxTripDays, entity.DefaultMaxVerificators, nil, entity.DefaultBaseCurrency)
+}
+
+// newReplaceAssigneesRequest replaces the assignees of the test trip with one per SPD number
+func newReplaceAssigneesRequest(tripID string, spdNumbers ...string) UpdateBusinessTripWithAssigneesRequest {
+	req := UpdateBusinessTripWithAssigneesRequest{
+		BusinessTripID:  tripID,
+		StartDate:       "2025-03-10",
+		EndDate:         "2025-03-14",
+		ActivityPurpose: "Audit",
+		DestinationCity: "Bandung",
+		SPDDate:         "2025-03-01",
+		DepartureDate:   "2025-03-10",
+		ReturnDate:      "2025-03-14",
+	}
+	for _, spdNumber := range spdNumbers {
+		req.Assignees = append(req.Assignees, AssigneeRequest{
+			Name:           "Assignee " + spdNumber,
+			SPDNumber:      spdNumber,
+			EmployeeNumber: "19800101",
+			Position:       "Auditor",
+			Rank:           "III/a",
+			Transactions:   []TransactionRequest{{Name: "Train", Type: string(entity.TransactionTypeTransport), Amount: 100}},
+		})
+	}
+	return req
+}
+
+func TestUpdateBusinessTripWithAssigneesRollsBackOnFailure(t *testing.T) {
+	trip := newTestBusinessTrip(t)
+	tripRepo := &stubReplaceTripRepository{trip: trip}
+	assigneeRepo := &stubReplaceAssigneeRepository{existing: []*entity.Assignee{{ID: "assignee-old", BusinessTripID: trip.ID}}}
+	db := &stubTxDB{}
+	uc := newReplaceAssigneesUseCase(tripRepo, assigneeRepo, db, nil)
+
+	_, err := uc.Execute(context.Background(), newReplaceAssigneesRequest(trip.ID, "SPD-1", "SPD-2"))
+	if !errors.Is(err, errAssigneeInsert) {
+		t.Fatalf("Execute() error = %v, want %v", err, errAssigneeInsert)
+	}
+	if !tripRepo.deletedTransactions || !assigneeRepo.deleted {
+		t.Fatal("failure happened before the old assignees and transactions were deleted")
+	}
+	if db.committed || !db.rolledBack {
+		t.Errorf("committed = %v, rolledBack = %v, want the replacement rolled back", db.committed, db.rolledBack)
+	}
+}
+
+func TestUpdateBusinessTripWithAssigneesOnLockedTrip(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          entity.BusinessTripStatus
+		requestStatus   string
+		allowLockedTrip bool
+		wantLocked      bool
+	}{
+		{name: "completed", status: entity.BusinessTripStatusCompleted, requestStatus: "completed", wantLocked: true},
+		{name: "canceled", status: entity.BusinessTripStatusCanceled, requestStatus: "canceled", wantLocked: true},
+		{name: "completed with override", status: entity.BusinessTripStatusCompleted, requestStatus: "completed", allowLockedTrip: true},
+		{name: "completed with override back to draft", status: entity.BusinessTripStatusCompleted, allowLockedTrip: true, wantLocked: true},
+		{name: "canceled with override reactivated", status: entity.BusinessTripStatusCanceled, allowLockedTrip: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trip := newTestBusinessTrip(t)
+			trip.Status = tt.status
+			tripRepo := &stubReplaceTripRepository{trip: trip}
+			assigneeRepo := &stubReplaceAssigneeRepository{existing: []*entity.Assignee{{ID: "assignee-old", BusinessTripID: trip.ID}}}
+			db := &stubTxDB{}
+			uc := newReplaceAssigneesUseCase(tripRepo, assigneeRepo, db, nil)
+
+			req := newReplaceAssigneesRequest(trip.ID, "SPD-1")
+			req.Status = tt.requestStatus
+			req.DocumentLink = "https://example.com/report.pdf"
+			req.AllowLockedTrip = tt.allowLockedTrip
+			_, err := uc.Execute(context.Background(), req)
+
+			if !tt.wantLocked {
+				if err != nil {
+					t.Fatalf("Execute() error = %v", err)
+				}
+				if !assigneeRepo.deleted || !db.committed {
+					t.Error("assignees were not replaced despite the override")
+				}
+				return
+			}
+			if !errors.Is(err, entity.ErrBusinessTripLocked) {
+				t.Fatalf("Execute() error = %v, want %v", err, entity.ErrBusinessTripLocked)
+			}
+			if tripRepo.deletedTransactions || assigneeRepo.deleted || assigneeRepo.created > 0 || db.committed {
+				t.Error("assignees of the locked trip were changed")
+			}
+		})
+	}
+}
+
+// recordingWebhookSender records the payloads of the webhooks it is asked to post
+type recordingWebhookSender struct {
+	mu     sync.Mutex
+	bodies [][]byte
+}
+
+func (s *recordingWebhookSender) PostWebhook(_ context.Context, _, _ string, body []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bodies = append(s.bodies, body)
+	return 200, nil
+}
+
+type discardWebhookDeliveryRepository struct{}
+
+func (discardWebhookDeliveryRepository) Create(context.Context, *entity.WebhookDelivery) error {
+	return nil
+}
+
+func TestUpdateBusinessTripWithAssigneesSendsStatusWebhook(t *testing.T) {
+	trip := newTestBusinessTrip(t)
+	trip.Status = entity.BusinessTripStatusOngoing
+	tripRepo := &stubReplaceTripRepository{trip: trip}
+	assigneeRepo := &stubReplaceAssigneeRepository{}
+	sender := &recordingWebhookSender{}
+	webhooks := service.NewBusinessTripWebhookDispatcher(sender, discardWebhookDeliveryRepository{},
+		[]string{"https://erp.example.com/hooks"}, retry.Options{MaxAttempts: 1})
+	uc := newReplaceAssigneesUseCase(tripRepo, assigneeRepo, &stubTxDB{}, webhooks)
+
+	req := newReplaceAssigneesRequest(trip.ID, "SPD-1")
+	req.Status = string(entity.BusinessTripStatusCanceled)
+	if _, err := uc.Execute(context.Background(), req); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	webhooks.Wait()
+
+	if len(sender.bodies) != 1 {
+		t.Fatalf("sent %d webhooks, want 1", len(sender.bodies))
+	}
+	var event service.BusinessTripStatusEvent
+	if err := json.Unmarshal(sender.bodies[0], &event); err != nil {
+		t.Fatalf("decode webhook: %v", err)
+	}
+	if event.BusinessTripID != trip.ID || event.OldStatus != entity.BusinessTripStatusOngoing || event.NewStatus != entity.BusinessTripStatusCanceled {
+		t.Errorf("webhook = %+v, want %s moving from ongoing to canceled", event, trip.ID)
+	}
+}
@@ -9,17 +9,24 @@ import (
 
 	"sandbox/internal/domain/entity"
 	"sandbox/internal/domain/repository"
+	"sandbox/internal/domain/service"
 )
 
 type UpdateTransactionUseCase struct {
 	businessTripRepo repository.BusinessTripRepository
 	assigneeRepo     repository.AssigneeRepository
+	typePolicy       *service.TransactionTypePolicy
+	amountCaps       entity.TransactionAmountCaps
+	baseCurrency     string
 }
 
-func NewUpdateTransactionUseCase(businessTripRepo repository.BusinessTripRepository, assigneeRepo repository.AssigneeRepository) *UpdateTransactionUseCase {
+func NewUpdateTransactionUseCase(businessTripRepo repository.BusinessTripRepository, assigneeRepo repository.AssigneeRepository, typePolicy *service.TransactionTypePolicy, amountCaps entity.TransactionAmountCaps, baseCurrency string) *UpdateTransactionUseCase {
 	return &UpdateTransactionUseCase{
 		businessTripRepo: businessTripRepo,
 		assigneeRepo:     assigneeRepo,
+		typePolicy:       typePolicy,
+		amountCaps:       amountCaps,
+		baseCurrency:     baseCurrency,
 	}
 }
 
@@ -30,10 +37,27 @@ type UpdateTransactionRequest struct {
 	Name            string  `json:"name"`
 	Type            string  `json:"type"`
 	Subtype         string  `json:"subtype"`
+	Direction       string  `json:"direction"`
 	Amount          float64 `json:"amount"`
 	TotalNight      *int    `json:"totalNight"`
+	TotalDays       *int    `json:"totalDays"`
 	Description     string  `json:"description"`
 	TransportDetail string  `json:"transportDetail"`
+
+	// Currency is the ISO 4217 code the amount was paid in, the base currency when empty.
+	// ExchangeRate converts it to the base currency and is required for any other currency.
+	Currency     string  `json:"currency"`
+	ExchangeRate float64 `json:"exchangeRate"`
+
+	// Allocations replaces the cost center splits when present; omit it to keep the current ones
+	// and send an empty list to remove them
+	Allocations []AllocationRequest `json:"allocations"`
+
+	// AllowLargeAmount lets an administrator enter an amount above the configured cap of its type
+	AllowLargeAmount bool `json:"allow_large_amount"`
+
+	// AllowLockedTrip lets an administrator update a transaction of a completed or canceled trip
+	AllowLockedTrip bool `json:"-" form:"-"`
 }
 
 func (r UpdateTransactionRequest) Validate() error {
@@ -43,7 +67,19 @@ func (r UpdateTransactionRequest) Validate() error {
 		validation.Field(&r.TransactionID, validation.Required),
 		validation.Field(&r.Name, validation.Required, validation.Length(1, 255)),
 		validation.Field(&r.Type, validation.Required, validation.In("accommodation", "transport", "other", "allowance")),
-		validation.Field(&r.Amount, validation.Required, validation.Min(0)),
+		// An empty subtype is checked against the stored transaction, legacy allowances may keep it
+		validation.Field(&r.Subtype, validation.When(r.Subtype != "", validation.By(func(interface{}) error {
+			return validateSubtypeOfType(r.Type, r.Subtype)
+		}))),
+		validation.Field(&r.Direction, validation.In(string(entity.TransactionDirectionDebit), string(entity.TransactionDirectionCredit))),
+		validation.Field(&r.Amount, validation.Required, validation.Min(0.0)),
+		validation.Field(&r.TotalNight, validation.Min(0)),
+		validation.Field(&r.TotalDays, validation.Min(0)),
+		validation.Field(&r.Currency, validation.By(validateCurrencyCode)),
+		validation.Field(&r.ExchangeRate, validation.Min(0.0)),
+		validation.Field(&r.Allocations, validation.By(func(interface{}) error {
+			return validateAllocationRequests(r.Allocations)
+		})),
 	)
 }
 
@@ -53,65 +89,82 @@ type UpdateTransactionResponse struct {
 	Name            string  `json:"name"`
 	Type            string  `json:"type"`
 	Subtype         string  `json:"subtype"`
+	Direction       string  `json:"direction"`
 	Amount          float64 `json:"amount"`
 	TotalNight      *int    `json:"totalNight,omitempty"`
+	TotalDays       *int    `json:"totalDays,omitempty"`
 	Subtotal        float64 `json:"subtotal"`
 	Description     string  `json:"description,omitempty"`
 	TransportDetail string  `json:"transportDetail,omitempty"`
 	CreatedAt       string  `json:"createdAt"`
 	UpdatedAt       string  `json:"updatedAt"`
+
+	// Amount is in Currency; BaseAmount and Subtotal are converted to the base currency with ExchangeRate
+	Currency     string  `json:"currency"`
+	ExchangeRate float64 `json:"exchangeRate"`
+	BaseAmount   float64 `json:"baseAmount"`
+
+	Allocations []AllocationResponse `json:"allocations,omitempty"`
 }
 
 func (uc *UpdateTransactionUseCase) Execute(ctx context.Context, req UpdateTransactionRequest) (*UpdateTransactionResponse, error) {
-	businessTrip, err := uc.businessTripRepo.GetByID(ctx, req.BusinessTripID)
+	businessTrip, assignee, transaction, err := loadEditableTransaction(ctx, uc.businessTripRepo, uc.assigneeRepo,
+		req.BusinessTripID, req.AssigneeID, req.TransactionID, req.AllowLockedTrip)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get business trip: %w", err)
-	}
-	if businessTrip == nil {
-		return nil, fmt.Errorf("business trip not found")
+		return nil, err
 	}
 
-	assignee, err := uc.assigneeRepo.GetAssigneeByID(ctx, req.AssigneeID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get assignee: %w", err)
-	}
-	if assignee == nil {
-		return nil, fmt.Errorf("assignee not found")
+	if req.TotalNight != nil && req.TotalDays != nil {
+		return nil, entity.ErrNightsAndDaysBothSet
 	}
 
-	if assignee.BusinessTripID != req.BusinessTripID {
-		return nil, fmt.Errorf("assignee does not belong to the specified business trip")
+	txType := entity.TransactionType(req.Type)
+	subtype := entity.TransactionSubtype(req.Subtype)
+	if err := transaction.ValidateSubtypeChange(txType, subtype); err != nil {
+		return nil, err
 	}
-
-	transaction, err := uc.businessTripRepo.GetTransactionByID(ctx, req.TransactionID)
+	direction, err := entity.NormalizeTransactionDirection(entity.TransactionDirection(req.Direction), subtype)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get transaction: %w", err)
-	}
-	if transaction == nil {
-		return nil, fmt.Errorf("transaction not found")
+		return nil, err
 	}
 
-	if transaction.AssigneeID != req.AssigneeID {
-		return nil, fmt.Errorf("transaction does not belong to the specified assignee")
-	}
-
-	txType := entity.TransactionType(req.Type)
-	subtotal := req.Amount
-
-	// For accommodation type, calculate subtotal based on total night
-	if txType == entity.TransactionTypeAccommodation && req.TotalNight != nil && *req.TotalNight > 0 {
-		subtotal = req.Amount * float64(*req.TotalNight)
+	if !req.AllowLargeAmount {
+		if err := uc.amountCaps.Check(txType, req.Amount); err != nil {
+			return nil, err
+		}
 	}
 
 	// Update transaction details
 	transaction.Name = strings.TrimSpace(req.Name)
 	transaction.Type = txType
-	transaction.Subtype = entity.TransactionSubtype(req.Subtype)
+	transaction.Subtype = subtype
 	transaction.Amount = req.Amount
 	transaction.TotalNight = req.TotalNight
-	transaction.Subtotal = subtotal
+	transaction.TotalDays = req.TotalDays
+	if err := transaction.SetCurrency(req.Currency, req.ExchangeRate, uc.baseCurrency); err != nil {
+		return nil, err
+	}
 	transaction.Description = strings.TrimSpace(req.Description)
 	transaction.TransportDetail = strings.TrimSpace(req.TransportDetail)
+	transaction.Direction = direction
+
+	if err := uc.typePolicy.CheckTransactions(ctx, businessTrip, transaction); err != nil {
+		return nil, err
+	}
+
+	if err := validateAssigneeCredits(ctx, uc.businessTripRepo, assignee, transaction); err != nil {
+		return nil, err
+	}
+
+	if req.Allocations != nil {
+		allocations, err := toAllocations(req.Allocations)
+		if err != nil {
+			return nil, err
+		}
+		if err := transaction.SetAllocations(allocations); err != nil {
+			return nil, err
+		}
+	}
 
 	// Save updated transaction
 	updatedTransaction, err := uc.businessTripRepo.UpdateTransaction(ctx, transaction)
@@ -119,18 +172,75 @@ func (uc *UpdateTransactionUseCase) Execute(ctx context.Context, req UpdateTrans
 		return nil, fmt.Errorf("failed to update transaction: %w", err)
 	}
 
+	return toUpdateTransactionResponse(updatedTransaction), nil
+}
+
+// loadEditableTransaction loads a transaction and its trip after checking that its trip can be edited and that it
+// belongs to the given assignee of that trip
+func loadEditableTransaction(
+	ctx context.Context,
+	businessTripRepo repository.BusinessTripRepository,
+	assigneeRepo repository.AssigneeRepository,
+	businessTripID, assigneeID, transactionID string,
+	allowLockedTrip bool,
+) (*entity.BusinessTrip, *entity.Assignee, *entity.Transaction, error) {
+	businessTrip, err := businessTripRepo.GetByID(ctx, businessTripID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get business trip: %w", err)
+	}
+	if businessTrip == nil {
+		return nil, nil, nil, entity.ErrBusinessTripNotFound
+	}
+	if err := ensureTripEditable(businessTrip, allowLockedTrip); err != nil {
+		return nil, nil, nil, err
+	}
+
+	assignee, err := assigneeRepo.GetAssigneeByID(ctx, assigneeID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get assignee: %w", err)
+	}
+	if assignee == nil {
+		return nil, nil, nil, entity.ErrAssigneeNotFound
+	}
+
+	if assignee.BusinessTripID != businessTripID {
+		return nil, nil, nil, fmt.Errorf("assignee does not belong to the specified business trip")
+	}
+
+	transaction, err := businessTripRepo.GetTransactionByID(ctx, transactionID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	if transaction == nil {
+		return nil, nil, nil, entity.ErrTransactionNotFound
+	}
+
+	if transaction.AssigneeID != assigneeID {
+		return nil, nil, nil, fmt.Errorf("transaction does not belong to the specified assignee")
+	}
+
+	return businessTrip, assignee, transaction, nil
+}
+
+func toUpdateTransactionResponse(transaction *entity.Transaction) *UpdateTransactionResponse {
 	return &UpdateTransactionResponse{
-		ID:              updatedTransaction.ID,
-		AssigneeID:      updatedTransaction.AssigneeID,
-		Name:            updatedTransaction.Name,
-		Type:            string(updatedTransaction.Type),
-		Subtype:         string(updatedTransaction.Subtype),
-		Amount:          updatedTransaction.Amount,
-		TotalNight:      updatedTransaction.TotalNight,
-		Subtotal:        updatedTransaction.Subtotal,
-		Description:     updatedTransaction.Description,
-		TransportDetail: updatedTransaction.TransportDetail,
-		CreatedAt:       updatedTransaction.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt:       updatedTransaction.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-	}, nil
+		ID:              transaction.ID,
+		AssigneeID:      transaction.AssigneeID,
+		Name:            transaction.Name,
+		Type:            string(transaction.Type),
+		Subtype:         string(transaction.Subtype),
+		Direction:       string(transaction.GetDirection()),
+		Amount:          transaction.Amount,
+		TotalNight:      transaction.TotalNight,
+		TotalDays:       transaction.TotalDays,
+		Subtotal:        transaction.Subtotal,
+		Description:     transaction.Description,
+		TransportDetail: transaction.TransportDetail,
+		CreatedAt:       transaction.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:       transaction.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Currency:        transaction.Currency,
+		ExchangeRate:    transaction.GetExchangeRate(),
+		BaseAmount:      transaction.BaseAmount(),
+		Allocations:     toAllocationResponses(transaction),
+	}
 }
@@ -0,0 +1,199 @@
+package business_trip
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/pkg/database"
+)
+
+// stubInlineDB runs the transaction function directly, without a real transaction
+type stubInlineDB struct {
+	database.DB
+}
+
+func (db *stubInlineDB) WithTransaction(ctx context.Context, fn func(ctx context.Context, tx database.DBTx) error) error {
+	return fn(ctx, nil)
+}
+
+// stubVerificatorRepository serves verificators of one trip and records the status history written
+type stubVerificatorRepository struct {
+	repository.BusinessTripRepository
+	trip         *entity.BusinessTrip
+	verificators map[string]*entity.Verificator
+	history      []*entity.VerificatorStatusHistory
+}
+
+func (r *stubVerificatorRepository) WithTransaction(database.DBTx) repository.BusinessTripRepository {
+	return r
+}
+
+func (r *stubVerificatorRepository) GetByID(context.Context, string) (*entity.BusinessTrip, error) {
+	return r.trip, nil
+}
+
+func (r *stubVerificatorRepository) Update(_ context.Context, bt *entity.BusinessTrip) (*entity.BusinessTrip, error) {
+	return bt, nil
+}
+
+func (r *stubVerificatorRepository) LockByID(context.Context, string) error {
+	if r.trip == nil {
+		return entity.ErrBusinessTripNotFound
+	}
+	return nil
+}
+
+func (r *stubVerificatorRepository) GetVerificatorByIDForUpdate(_ context.Context, id string) (*entity.Verificator, error) {
+	return r.verificators[id], nil
+}
+
+func (r *stubVerificatorRepository) GetVerificatorByBusinessTripIDAndUserID(_ context.Context, _, userID string) (*entity.Verificator, error) {
+	for _, verificator := range r.verificators {
+		if verificator.UserID == userID {
+			return verificator, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *stubVerificatorRepository) GetVerificatorsByBusinessTripID(context.Context, string) ([]*entity.Verificator, error) {
+	var verificators []*entity.Verificator
+	for _, verificator := range r.verificators {
+		verificators = append(verificators, verificator)
+	}
+	return verificators, nil
+}
+
+func (r *stubVerificatorRepository) UpdateVerificator(_ context.Context, verificator *entity.Verificator) (*entity.Verificator, error) {
+	return verificator, nil
+}
+
+func (r *stubVerificatorRepository) BulkUpdateVerificators(_ context.Context, ids []string, status entity.VerificatorStatus, _ string, _ time.Time) (int64, error) {
+	for _, id := range ids {
+		r.verificators[id].Status = status
+	}
+	return int64(len(ids)), nil
+}
+
+func (r *stubVerificatorRepository) CreateVerificatorStatusHistory(_ context.Context, history []*entity.VerificatorStatusHistory) error {
+	r.history = append(r.history, history...)
+	return nil
+}
+
+func TestVerifyBusinessTripRecordsStatusHistory(t *testing.T) {
+	trip := newTestBusinessTrip(t)
+	trip.Status = entity.BusinessTripStatusReadyToVerify
+	repo := &stubVerificatorRepository{
+		trip: trip,
+		verificators: map[string]*entity.Verificator{
+			"verificator-1": {ID: "verificator-1", BusinessTripID: trip.ID, UserID: "user-1", Status: entity.VerificatorStatusPending},
+		},
+	}
+
+	_, err := NewVerifyBusinessTripUseCase(repo, nil, &stubInlineDB{}, nil).Execute(context.Background(), VerifyBusinessTripRequest{
+		BusinessTripID:     trip.ID,
+		VerificationStatus: "rejected",
+		VerificationNotes:  "Receipts missing",
+	}, entity.AuthenticatedUser{ID: "user-1"})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(repo.history) != 1 {
+		t.Fatalf("recorded %d history entries, want 1", len(repo.history))
+	}
+	got := repo.history[0]
+	if got.VerificatorID != "verificator-1" || got.FromStatus != entity.VerificatorStatusPending ||
+		got.ToStatus != entity.VerificatorStatusRejected || got.Notes != "Receipts missing" ||
+		got.ChangedBy == nil || *got.ChangedBy != "user-1" || got.ChangedAt.IsZero() {
+		t.Errorf("history entry = %+v, want pending to rejected by user-1 with the notes", got)
+	}
+}
+
+func TestBulkUpdateVerificatorsRecordsOnlyChangedStatuses(t *testing.T) {
+	trip := newTestBusinessTrip(t)
+	trip.Status = entity.BusinessTripStatusReadyToVerify
+	repo := &stubVerificatorRepository{
+		trip: trip,
+		verificators: map[string]*entity.Verificator{
+			"verificator-1": {ID: "verificator-1", BusinessTripID: trip.ID, Status: entity.VerificatorStatusRejected},
+			"verificator-2": {ID: "verificator-2", BusinessTripID: trip.ID, Status: entity.VerificatorStatusApproved},
+		},
+	}
+
+	_, err := NewBulkUpdateVerificatorsUseCase(repo, &stubInlineDB{}, nil).Execute(context.Background(), BulkUpdateVerificatorsRequest{
+		VerificatorIDs: []string{"verificator-1", "verificator-2"},
+		Status:         "approved",
+		ChangedBy:      "admin-1",
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(repo.history) != 1 {
+		t.Fatalf("recorded %d history entries, want 1 for the verificator that changed", len(repo.history))
+	}
+	got := repo.history[0]
+	if got.VerificatorID != "verificator-1" || got.FromStatus != entity.VerificatorStatusRejected ||
+		got.ToStatus != entity.VerificatorStatusApproved || got.ChangedBy == nil || *got.ChangedBy != "admin-1" {
+		t.Errorf("history entry = %+v, want verificator-1 from rejected to approved by admin-1", got)
+	}
+}
+
+func TestBulkUpdateVerificatorsMovesTripForward(t *testing.T) {
+	trip := newTestBusinessTrip(t)
+	trip.Status = entity.BusinessTripStatusReadyToVerify
+	repo := &stubVerificatorRepository{
+		trip: trip,
+		verificators: map[string]*entity.Verificator{
+			"verificator-1": {ID: "verificator-1", BusinessTripID: trip.ID, Status: entity.VerificatorStatusApproved},
+			"verificator-2": {ID: "verificator-2", BusinessTripID: trip.ID, Status: entity.VerificatorStatusPending},
+		},
+	}
+
+	response, err := NewBulkUpdateVerificatorsUseCase(repo, &stubInlineDB{}, nil).Execute(context.Background(), BulkUpdateVerificatorsRequest{
+		VerificatorIDs: []string{"verificator-2"},
+		Status:         "approved",
+		ChangedBy:      "admin-1",
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if trip.Status != entity.BusinessTripStatusOngoing {
+		t.Errorf("trip status = %s, want ongoing once the last verificator approved", trip.Status)
+	}
+	if got := response.Results[0].BusinessTripStatus; got != string(entity.BusinessTripStatusOngoing) {
+		t.Errorf("result business_trip_status = %q, want ongoing", got)
+	}
+}
+
+func TestBulkUpdateVerificatorsSkipsTripsNotReadyToVerify(t *testing.T) {
+	trip := newTestBusinessTrip(t)
+	trip.Status = entity.BusinessTripStatusDraft
+	repo := &stubVerificatorRepository{
+		trip: trip,
+		verificators: map[string]*entity.Verificator{
+			"verificator-1": {ID: "verificator-1", BusinessTripID: trip.ID, Status: entity.VerificatorStatusPending},
+		},
+	}
+
+	response, err := NewBulkUpdateVerificatorsUseCase(repo, &stubInlineDB{}, nil).Execute(context.Background(), BulkUpdateVerificatorsRequest{
+		VerificatorIDs: []string{"verificator-1"},
+		Status:         "approved",
+		ChangedBy:      "admin-1",
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if response.UpdatedCount != 0 || response.Results[0].Success || response.Results[0].Reason == "" {
+		t.Errorf("results = %+v, want the verificator skipped with a reason", response.Results[0])
+	}
+	if repo.verificators["verificator-1"].Status != entity.VerificatorStatusPending || len(repo.history) != 0 {
+		t.Error("verificator of a draft trip was updated")
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"sandbox/internal/domain/entity"
 	"sandbox/internal/domain/repository"
+	"sandbox/internal/domain/service"
 	"sandbox/pkg/database"
 )
 
@@ -15,6 +16,14 @@ type VerifyBusinessTripRequest struct {
 	BusinessTripID     string `params:"tripId" json:"tripId"`
 	VerificationStatus string `json:"status"`             // "approved" or "rejected"
 	VerificationNotes  string `json:"verification_notes"` // Optional notes
+	// AutoTransition moves the business trip forward once every verificator has responded.
+	// Defaults to true when omitted; set to false to manage the trip status manually.
+	AutoTransition *bool `json:"auto_transition"`
+}
+
+// ShouldAutoTransition reports whether the business trip status should follow the verificator outcome
+func (r VerifyBusinessTripRequest) ShouldAutoTransition() bool {
+	return r.AutoTransition == nil || *r.AutoTransition
 }
 
 func (r VerifyBusinessTripRequest) Validate() error {
@@ -52,6 +61,7 @@ type VerifyBusinessTripResponse struct {
 type VerifyBusinessTripUseCase struct {
 	businessTripRepo repository.BusinessTripRepository
 	db               database.DB
+	webhooks         *service.BusinessTripWebhookDispatcher
 }
 
 // getUserIDFromContext extracts user ID from context
@@ -64,10 +74,11 @@ func getUserIDFromContext(ctx context.Context) (string, error) {
 	return userID, nil
 }
 
-func NewVerifyBusinessTripUseCase(businessTripRepo repository.BusinessTripRepository, userService interface{}, db database.DB) *VerifyBusinessTripUseCase {
+func NewVerifyBusinessTripUseCase(businessTripRepo repository.BusinessTripRepository, userService interface{}, db database.DB, webhooks *service.BusinessTripWebhookDispatcher) *VerifyBusinessTripUseCase {
 	return &VerifyBusinessTripUseCase{
 		businessTripRepo: businessTripRepo,
 		db:               db,
+		webhooks:         webhooks,
 	}
 }
 
@@ -78,35 +89,43 @@ func (uc *VerifyBusinessTripUseCase) Execute(ctx context.Context, req VerifyBusi
 	}
 
 	var result *VerifyBusinessTripResponse
+	var oldStatus entity.BusinessTripStatus
 	err := uc.db.WithTransaction(ctx, func(ctx context.Context, tx database.DBTx) error {
 		// Create transaction-aware repository
 		businessTripRepoWithTx := uc.businessTripRepo.(interface {
 			WithTransaction(database.DBTx) repository.BusinessTripRepository
 		}).WithTransaction(tx)
 
-		// Get verificator for this business trip and user
+		// Get business trip by ID
+		businessTrip, err := businessTripRepoWithTx.GetByID(ctx, req.BusinessTripID)
+		if err != nil {
+			return fmt.Errorf("failed to get business trip: %w", err)
+		}
+		if businessTrip == nil {
+			return fmt.Errorf("%w: %s", entity.ErrBusinessTripNotFound, req.BusinessTripID)
+		}
+
+		// The verificator is looked up by the authenticated user, so nobody can answer for another verificator
 		verificator, err := businessTripRepoWithTx.GetVerificatorByBusinessTripIDAndUserID(ctx, req.BusinessTripID, authenticatedUser.ID)
 		if err != nil {
 			return fmt.Errorf("failed to get verificator: %w", err)
 		}
+		if verificator == nil {
+			return entity.ErrNotTripVerificator
+		}
 
 		// Check if verificator is still pending
 		if !verificator.IsPending() {
-			return fmt.Errorf("verificator has already %s this business trip", verificator.GetStatus())
-		}
-
-		// Get business trip by ID
-		businessTrip, err := businessTripRepoWithTx.GetByID(ctx, req.BusinessTripID)
-		if err != nil {
-			return fmt.Errorf("failed to get business trip: %w", err)
+			return fmt.Errorf("%w: already %s", entity.ErrAlreadyVerified, verificator.GetStatus())
 		}
 
 		// Check if business trip is in ready_to_verify status
 		if businessTrip.GetStatus() != entity.BusinessTripStatusReadyToVerify {
-			return fmt.Errorf("business trip must be in ready_to_verify status to be verified, current status: %s", businessTrip.GetStatus())
+			return fmt.Errorf("%w, current status: %s", entity.ErrTripNotReadyToVerify, businessTrip.GetStatus())
 		}
 
 		// Update verificator status
+		previousStatus := verificator.GetStatus()
 		verificatorStatus := entity.VerificatorStatus(req.VerificationStatus)
 		if err := verificator.UpdateStatus(verificatorStatus, req.VerificationNotes); err != nil {
 			return fmt.Errorf("failed to update verificator status: %w", err)
@@ -118,47 +137,24 @@ func (uc *VerifyBusinessTripUseCase) Execute(ctx context.Context, req VerifyBusi
 			return fmt.Errorf("failed to update verificator: %w", err)
 		}
 
-		// Check if all verificators have now responded (approved or rejected)
-		allVerificators, err := businessTripRepoWithTx.GetVerificatorsByBusinessTripID(ctx, req.BusinessTripID)
+		err = businessTripRepoWithTx.CreateVerificatorStatusHistory(ctx, []*entity.VerificatorStatusHistory{{
+			VerificatorID: updatedVerificator.GetID(),
+			FromStatus:    previousStatus,
+			ToStatus:      updatedVerificator.GetStatus(),
+			Notes:         updatedVerificator.GetVerificationNotes(),
+			ChangedBy:     optionalUserID(authenticatedUser.ID),
+			ChangedAt:     updatedVerificator.UpdatedAt,
+		}})
 		if err != nil {
-			return fmt.Errorf("failed to get all verificators: %w", err)
-		}
-
-		// Update business trip status based on verificator responses
-		newBusinessTripStatus := businessTrip.GetStatus()
-		allApproved := true
-		anyRejected := false
-
-		for _, v := range allVerificators {
-			if v.IsRejected() {
-				anyRejected = true
-				allApproved = false
-				break
-			}
-			if v.IsPending() {
-				allApproved = false
-				break
-			}
-		}
-
-		if anyRejected {
-			// If any verificator rejected, mark as ongoing (so it can be fixed and resubmitted)
-			newBusinessTripStatus = entity.BusinessTripStatusOngoing
-		} else if allApproved {
-			// If all approved, mark as ongoing (ready for execution)
-			newBusinessTripStatus = entity.BusinessTripStatusOngoing
+			return err
 		}
 
-		// Update business trip status if changed
-		if newBusinessTripStatus != businessTrip.GetStatus() {
-			if err := businessTrip.UpdateStatus(newBusinessTripStatus); err != nil {
-				return fmt.Errorf("failed to update business trip status: %w", err)
-			}
-
-			// Update business trip in database
-			_, err = businessTripRepoWithTx.Update(ctx, businessTrip)
+		oldStatus = businessTrip.GetStatus()
+		newBusinessTripStatus := oldStatus
+		if req.ShouldAutoTransition() {
+			newBusinessTripStatus, err = applyVerificationOutcome(ctx, businessTripRepoWithTx, businessTrip)
 			if err != nil {
-				return fmt.Errorf("failed to update business trip: %w", err)
+				return err
 			}
 		}
 
@@ -188,5 +184,36 @@ func (uc *VerifyBusinessTripUseCase) Execute(ctx context.Context, req VerifyBusi
 		return nil, err
 	}
 
+	uc.webhooks.StatusChanged(ctx, req.BusinessTripID, oldStatus, entity.BusinessTripStatus(result.BusinessTripStatus))
+
 	return result, nil
 }
+
+// applyVerificationOutcome reloads the verificators of the business trip and moves the trip forward once
+// every verificator has responded: all approved moves it to ongoing, any rejection sends it back to draft
+// for rework. It returns the trip status afterwards.
+func applyVerificationOutcome(ctx context.Context, businessTripRepo repository.BusinessTripRepository, businessTrip *entity.BusinessTrip) (entity.BusinessTripStatus, error) {
+	allVerificators, err := businessTripRepo.GetVerificatorsByBusinessTripID(ctx, businessTrip.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get all verificators: %w", err)
+	}
+	businessTrip.Verificators = allVerificators
+
+	targetStatus := businessTrip.GetStatus()
+	if businessTrip.HasAnyVerificatorRejected() {
+		targetStatus = entity.BusinessTripStatusDraft
+	} else if businessTrip.HasAllVerificatorsApproved() {
+		targetStatus = entity.BusinessTripStatusOngoing
+	}
+
+	if targetStatus == businessTrip.GetStatus() || !businessTrip.CanTransitionTo(targetStatus) {
+		return businessTrip.GetStatus(), nil
+	}
+	if err := businessTrip.UpdateStatus(targetStatus); err != nil {
+		return "", fmt.Errorf("failed to update business trip status: %w", err)
+	}
+	if _, err := businessTripRepo.Update(ctx, businessTrip); err != nil {
+		return "", fmt.Errorf("failed to update business trip: %w", err)
+	}
+	return targetStatus, nil
+}
@@ -0,0 +1,70 @@
+package business_trip
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+)
+
+// DocumentVerifier checks a document signature, see cryptography.DigitalSignatureService.VerifyDocument
+type DocumentVerifier interface {
+	VerifyDocument(content []byte, signature, signatureAlgorithm string) error
+}
+
+// VerifyBusinessTripPDFRequest holds the signature printed in the QR code of a signed SPD
+type VerifyBusinessTripPDFRequest struct {
+	BusinessTripID string `params:"tripId"`
+	Algorithm      string `query:"algorithm"`
+	Signature      string `query:"signature"`
+}
+
+// VerifyBusinessTripPDFResponse tells whether a printed SPD still matches the stored trip. It only carries
+// what the print already shows, since the endpoint is public.
+type VerifyBusinessTripPDFResponse struct {
+	BusinessTripID     string `json:"business_trip_id"`
+	BusinessTripNumber string `json:"business_trip_number"`
+	IsValid            bool   `json:"is_valid"`
+	VerifiedAt         string `json:"verified_at"`
+	ErrorMessage       string `json:"error_message,omitempty"`
+}
+
+// VerifyBusinessTripPDFUseCase checks the signature of a signed SPD PDF against the trip as it is stored now
+type VerifyBusinessTripPDFUseCase struct {
+	businessTripRepo repository.BusinessTripRepository
+	verifier         DocumentVerifier
+}
+
+// NewVerifyBusinessTripPDFUseCase creates the use case
+func NewVerifyBusinessTripPDFUseCase(businessTripRepo repository.BusinessTripRepository, verifier DocumentVerifier) *VerifyBusinessTripPDFUseCase {
+	return &VerifyBusinessTripPDFUseCase{
+		businessTripRepo: businessTripRepo,
+		verifier:         verifier,
+	}
+}
+
+// Execute verifies the signature over the signed content of the trip. The signature of a print made before
+// the trip was last edited no longer matches and is reported as not valid.
+func (uc *VerifyBusinessTripPDFUseCase) Execute(ctx context.Context, req VerifyBusinessTripPDFRequest) (*VerifyBusinessTripPDFResponse, error) {
+	bt, err := uc.businessTripRepo.GetByID(ctx, req.BusinessTripID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get business trip: %w", err)
+	}
+	if bt == nil {
+		return nil, entity.ErrBusinessTripNotFound
+	}
+
+	response := &VerifyBusinessTripPDFResponse{
+		BusinessTripID:     bt.ID,
+		BusinessTripNumber: bt.GetBusinessTripNumber(),
+		IsValid:            true,
+		VerifiedAt:         time.Now().UTC().Format(time.RFC3339),
+	}
+	if err := uc.verifier.VerifyDocument(signedPDFContent(bt), req.Signature, req.Algorithm); err != nil {
+		response.IsValid = false
+		response.ErrorMessage = err.Error()
+	}
+	return response, nil
+}
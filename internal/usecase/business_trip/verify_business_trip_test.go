@@ -0,0 +1,60 @@
+package business_trip
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"sandbox/internal/domain/entity"
+)
+
+func TestVerifyBusinessTripAsAuthenticatedVerificator(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		noTrip     bool
+		tripStatus entity.BusinessTripStatus
+		status     entity.VerificatorStatus
+		wantErr    error
+	}{
+		{name: "own pending verificator", userID: "user-1", tripStatus: entity.BusinessTripStatusReadyToVerify, status: entity.VerificatorStatusPending},
+		{name: "not a verificator of the trip", userID: "user-2", tripStatus: entity.BusinessTripStatusReadyToVerify, status: entity.VerificatorStatusPending, wantErr: entity.ErrNotTripVerificator},
+		{name: "missing trip", userID: "user-1", noTrip: true, wantErr: entity.ErrBusinessTripNotFound},
+		{name: "already responded", userID: "user-1", tripStatus: entity.BusinessTripStatusReadyToVerify, status: entity.VerificatorStatusApproved, wantErr: entity.ErrAlreadyVerified},
+		{name: "trip not ready", userID: "user-1", tripStatus: entity.BusinessTripStatusDraft, status: entity.VerificatorStatusPending, wantErr: entity.ErrTripNotReadyToVerify},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trip := newTestBusinessTrip(t)
+			trip.Status = tt.tripStatus
+			verificator := &entity.Verificator{ID: "verificator-1", BusinessTripID: trip.ID, UserID: "user-1", Status: tt.status}
+			repo := &stubVerificatorRepository{trip: trip, verificators: map[string]*entity.Verificator{verificator.ID: verificator}}
+			if tt.noTrip {
+				repo.trip = nil
+			}
+
+			response, err := NewVerifyBusinessTripUseCase(repo, nil, &stubInlineDB{}, nil).Execute(context.Background(), VerifyBusinessTripRequest{
+				BusinessTripID:     trip.ID,
+				VerificationStatus: "approved",
+				VerificationNotes:  "Complete",
+			}, entity.AuthenticatedUser{ID: tt.userID})
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+				}
+				if len(repo.history) != 0 {
+					t.Error("a rejected verification changed the verificator")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if response.ID != "verificator-1" || response.Status != "approved" || response.VerificationNotes != "Complete" {
+				t.Errorf("Execute() = %+v, want verificator-1 approved with the notes", response)
+			}
+		})
+	}
+}
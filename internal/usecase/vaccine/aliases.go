@@ -0,0 +1,140 @@
+package vaccine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/service"
+)
+
+// AliasKind selects which alias table an alias belongs to
+type AliasKind string
+
+const (
+	AliasKindCountry AliasKind = "countries"
+	AliasKindVaccine AliasKind = "vaccines"
+)
+
+// ErrUnknownAliasKind is returned for an alias kind other than countries or vaccines
+var ErrUnknownAliasKind = errors.New("alias kind must be countries or vaccines")
+
+// ErrInvalidAlias wraps the validation error of an alias that cannot be saved
+var ErrInvalidAlias = errors.New("invalid alias")
+
+// AliasResponse is one alias and the country or vaccine name it resolves to
+type AliasResponse struct {
+	Alias     string    `json:"alias"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ListAliasesUseCase struct {
+	vaccinesRepo VaccinesRepository
+}
+
+func NewListAliasesUseCase(vaccinesRepo VaccinesRepository) *ListAliasesUseCase {
+	return &ListAliasesUseCase{
+		vaccinesRepo: vaccinesRepo,
+	}
+}
+
+func (uc *ListAliasesUseCase) Execute(ctx context.Context, kind AliasKind) ([]*AliasResponse, error) {
+	switch kind {
+	case AliasKindCountry:
+		aliases, err := uc.vaccinesRepo.ListCountryAliases(ctx)
+		if err != nil {
+			return nil, err
+		}
+		responses := make([]*AliasResponse, 0, len(aliases))
+		for _, alias := range aliases {
+			responses = append(responses, countryAliasResponse(alias))
+		}
+		return responses, nil
+	case AliasKindVaccine:
+		aliases, err := uc.vaccinesRepo.ListVaccineAliases(ctx)
+		if err != nil {
+			return nil, err
+		}
+		responses := make([]*AliasResponse, 0, len(aliases))
+		for _, alias := range aliases {
+			responses = append(responses, vaccineAliasResponse(alias))
+		}
+		return responses, nil
+	default:
+		return nil, ErrUnknownAliasKind
+	}
+}
+
+type UpsertAliasRequest struct {
+	Kind  AliasKind `json:"-"`
+	Alias string    `json:"alias"`
+	Name  string    `json:"name"`
+}
+
+type UpsertAliasUseCase struct {
+	vaccinesRepo VaccinesRepository
+	cdcService   *service.CDCService
+}
+
+func NewUpsertAliasUseCase(vaccinesRepo VaccinesRepository, cdcService *service.CDCService) *UpsertAliasUseCase {
+	return &UpsertAliasUseCase{
+		vaccinesRepo: vaccinesRepo,
+		cdcService:   cdcService,
+	}
+}
+
+// Execute creates the alias or points an existing one at a new name. The CDC service cache is dropped
+// so the change is used by the next recommendation lookup instead of after the cache TTL.
+func (uc *UpsertAliasUseCase) Execute(ctx context.Context, req *UpsertAliasRequest) (*AliasResponse, error) {
+	var response *AliasResponse
+
+	switch req.Kind {
+	case AliasKindCountry:
+		alias, err := entity.NewCountryAlias(req.Alias, req.Name)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidAlias, err)
+		}
+		upserted, err := uc.vaccinesRepo.UpsertCountryAlias(ctx, alias)
+		if err != nil {
+			return nil, err
+		}
+		response = countryAliasResponse(upserted)
+	case AliasKindVaccine:
+		alias, err := entity.NewVaccineAlias(req.Alias, req.Name)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidAlias, err)
+		}
+		upserted, err := uc.vaccinesRepo.UpsertVaccineAlias(ctx, alias)
+		if err != nil {
+			return nil, err
+		}
+		response = vaccineAliasResponse(upserted)
+	default:
+		return nil, ErrUnknownAliasKind
+	}
+
+	uc.cdcService.InvalidateAliases()
+	return response, nil
+}
+
+func countryAliasResponse(alias *entity.CountryAlias) *AliasResponse {
+	return &AliasResponse{
+		Alias:     alias.Alias,
+		Name:      alias.CountryName,
+		CreatedAt: alias.CreatedAt,
+		UpdatedAt: alias.UpdatedAt,
+	}
+}
+
+func vaccineAliasResponse(alias *entity.VaccineAlias) *AliasResponse {
+	return &AliasResponse{
+		Alias:     alias.Alias,
+		Name:      alias.VaccineName,
+		CreatedAt: alias.CreatedAt,
+		UpdatedAt: alias.UpdatedAt,
+	}
+}
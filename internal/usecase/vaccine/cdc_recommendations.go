@@ -5,12 +5,15 @@ import (
 	"fmt"
 	"time"
 
+	"sandbox/internal/domain/entity"
 	"sandbox/internal/domain/service"
 )
 
 type GetCDCRecommendationsRequest struct {
 	CountryCode string `param:"countryCode"`
 	Language    string `query:"language"`
+	// ForceRefresh fetches the recommendation from the CDC website even when a cached one is still fresh
+	ForceRefresh bool `query:"forceRefresh"`
 }
 
 type GetCDCRecommendationsResponse struct {
@@ -20,15 +23,19 @@ type GetCDCRecommendationsResponse struct {
 	CountryCode string                                `json:"country_code"`
 	Language    string                                `json:"language"`
 	LastUpdated time.Time                             `json:"last_updated"`
+	// HistoryID identifies the recorded recommendation; it is only set by RecordCDCRecommendationUseCase
+	HistoryID string `json:"history_id,omitempty"`
 }
 
 type GetCDCRecommendationsUseCase struct {
-	cdcService *service.CDCService
+	cdcService   *service.CDCService
+	vaccinesRepo VaccinesRepository
 }
 
-func NewGetCDCRecommendationsUseCase(cdcService *service.CDCService) *GetCDCRecommendationsUseCase {
+func NewGetCDCRecommendationsUseCase(cdcService *service.CDCService, vaccinesRepo VaccinesRepository) *GetCDCRecommendationsUseCase {
 	return &GetCDCRecommendationsUseCase{
-		cdcService: cdcService,
+		cdcService:   cdcService,
+		vaccinesRepo: vaccinesRepo,
 	}
 }
 
@@ -46,7 +53,7 @@ func (uc *GetCDCRecommendationsUseCase) Execute(ctx context.Context, req *GetCDC
 		language = "en"
 	}
 
-	recommendation, err := uc.cdcService.GetVaccineRecommendationsByCountry(ctx, req.CountryCode, language)
+	recommendation, err := uc.cdcService.GetVaccineRecommendationsByCountry(ctx, req.CountryCode, language, req.ForceRefresh)
 	if err != nil {
 		return nil, err
 	}
@@ -57,6 +64,22 @@ func (uc *GetCDCRecommendationsUseCase) Execute(ctx context.Context, req *GetCDC
 		Success:     true,
 		CountryCode: req.CountryCode,
 		Language:    language,
-		LastUpdated: time.Now(),
+		LastUpdated: recommendation.FetchedAt,
 	}, nil
 }
+
+func vaccineIDs(vaccines []*entity.MasterVaccine) []string {
+	ids := make([]string, 0, len(vaccines))
+	for _, vaccine := range vaccines {
+		ids = append(ids, vaccine.ID)
+	}
+	return ids
+}
+
+// optionalID returns nil for an empty ID so the history stores NULL
+func optionalID(id string) *string {
+	if id == "" {
+		return nil
+	}
+	return &id
+}
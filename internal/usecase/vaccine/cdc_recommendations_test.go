@@ -0,0 +1,144 @@
+package vaccine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/internal/domain/service"
+)
+
+// stubHistoryVaccinesRepository serves a cached recommendation and records the history written
+type stubHistoryVaccinesRepository struct {
+	VaccinesRepository
+	cache      *entity.CDCRecommendationCache
+	historyErr error
+	history    []*entity.VaccineRecommendationHistory
+}
+
+func (r *stubHistoryVaccinesRepository) GetCDCRecommendationCache(context.Context, string, string) (*entity.CDCRecommendationCache, error) {
+	return r.cache, nil
+}
+
+func (r *stubHistoryVaccinesRepository) CreateVaccineRecommendationHistory(_ context.Context, history *entity.VaccineRecommendationHistory) error {
+	if r.historyErr != nil {
+		return r.historyErr
+	}
+	history.ID = "history-1"
+	r.history = append(r.history, history)
+	return nil
+}
+
+// stubRecordTripRepository serves the business trip the recommendation is recorded for
+type stubRecordTripRepository struct {
+	repository.BusinessTripRepository
+	trip *entity.BusinessTrip
+}
+
+func (r *stubRecordTripRepository) GetByID(_ context.Context, id string) (*entity.BusinessTrip, error) {
+	if r.trip == nil || r.trip.ID != id {
+		return nil, nil
+	}
+	return r.trip, nil
+}
+
+// stubRecordAssigneeRepository serves the assignees the recommendation may be recorded for
+type stubRecordAssigneeRepository struct {
+	repository.AssigneeRepository
+	assignees map[string]*entity.Assignee
+}
+
+func (r *stubRecordAssigneeRepository) GetAssigneeByID(_ context.Context, id string) (*entity.Assignee, error) {
+	return r.assignees[id], nil
+}
+
+func TestCDCRecommendationHistory(t *testing.T) {
+	fetchedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	data, err := json.Marshal(service.CountryVaccineRecommendation{
+		CountryCode:         "JPN",
+		CountryName:         "Japan",
+		RequiredVaccines:    []*entity.MasterVaccine{{ID: "vaccine-1"}},
+		RecommendedVaccines: []*entity.MasterVaccine{{ID: "vaccine-2"}, {ID: "vaccine-3"}},
+		MalariaRisk:         "none",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := &entity.CDCRecommendationCache{CountryCode: "jpn", Language: "en", Recommendation: data, FetchedAt: fetchedAt}
+	tripID := "0b6f0c3e-3f57-4bf5-9a2b-1c1b7c9f0a11"
+	otherTripID := "5d0c2f7a-8f0e-4d53-9f3b-2e6a1c7d9b22"
+	assigneeID := "8e3b7d61-2c4a-4f0e-b1d9-6a5c3e2f1a33"
+	trips := &stubRecordTripRepository{trip: &entity.BusinessTrip{ID: tripID}}
+	assignees := &stubRecordAssigneeRepository{assignees: map[string]*entity.Assignee{
+		assigneeID: {ID: assigneeID, BusinessTripID: tripID},
+	}}
+
+	t.Run("anonymous lookup records nothing", func(t *testing.T) {
+		repo := &stubHistoryVaccinesRepository{cache: cache}
+		uc := NewGetCDCRecommendationsUseCase(service.NewCDCService(repo, nil, nil, service.CDCOptions{}), repo)
+
+		response, err := uc.Execute(context.Background(), &GetCDCRecommendationsRequest{CountryCode: "JPN"})
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if response.HistoryID != "" || len(repo.history) != 0 || response.Data == nil || response.Data.CountryName != "Japan" {
+			t.Errorf("Execute() = %+v with %d recorded, want the recommendation without history", response, len(repo.history))
+		}
+	})
+
+	t.Run("recorded for the assignee and its trip", func(t *testing.T) {
+		repo := &stubHistoryVaccinesRepository{cache: cache}
+		uc := NewRecordCDCRecommendationUseCase(service.NewCDCService(repo, nil, nil, service.CDCOptions{}), repo, trips, assignees)
+
+		response, err := uc.Execute(context.Background(), &RecordCDCRecommendationRequest{CountryCode: "JPN", AssigneeID: assigneeID})
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if response.HistoryID != "history-1" || len(repo.history) != 1 {
+			t.Fatalf("HistoryID = %q with %d recorded, want one recorded recommendation", response.HistoryID, len(repo.history))
+		}
+
+		got := repo.history[0]
+		if got.BusinessTripID == nil || *got.BusinessTripID != tripID || got.AssigneeID == nil || *got.AssigneeID != assigneeID {
+			t.Errorf("recorded for trip %v and assignee %v, want trip %s and assignee %s", got.BusinessTripID, got.AssigneeID, tripID, assigneeID)
+		}
+		if !reflect.DeepEqual(got.RequiredVaccineIDs, []string{"vaccine-1"}) ||
+			!reflect.DeepEqual(got.RecommendedVaccineIDs, []string{"vaccine-2", "vaccine-3"}) || len(got.ConsiderVaccineIDs) != 0 {
+			t.Errorf("recorded vaccines = %v / %v / %v", got.RequiredVaccineIDs, got.RecommendedVaccineIDs, got.ConsiderVaccineIDs)
+		}
+		if got.Language != "en" || got.MalariaRisk != "none" || !got.GeneratedAt.Equal(fetchedAt) {
+			t.Errorf("recorded %+v, want language en, malaria risk none, generated at %v", got, fetchedAt)
+		}
+	})
+
+	rejected := []struct {
+		name    string
+		req     RecordCDCRecommendationRequest
+		wantErr error
+	}{
+		{"unknown trip", RecordCDCRecommendationRequest{CountryCode: "JPN", BusinessTripID: otherTripID}, entity.ErrBusinessTripNotFound},
+		{"assignee of another trip", RecordCDCRecommendationRequest{CountryCode: "JPN", BusinessTripID: otherTripID, AssigneeID: assigneeID}, entity.ErrAssigneeNotFound},
+	}
+	for _, tt := range rejected {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubHistoryVaccinesRepository{cache: cache}
+			uc := NewRecordCDCRecommendationUseCase(service.NewCDCService(repo, nil, nil, service.CDCOptions{}), repo, trips, assignees)
+
+			if _, err := uc.Execute(context.Background(), &tt.req); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(repo.history) != 0 {
+				t.Errorf("recorded %d recommendations, want none", len(repo.history))
+			}
+		})
+	}
+
+	if err := (RecordCDCRecommendationRequest{CountryCode: "JPN"}).Validate(); err == nil {
+		t.Error("Validate() accepted a recommendation without a business trip or assignee")
+	}
+}
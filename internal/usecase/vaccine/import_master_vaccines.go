@@ -0,0 +1,210 @@
+package vaccine
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"sandbox/internal/domain/entity"
+)
+
+// MaxMasterVaccineImportRows caps the rows of one import, which is written in a single transaction
+const MaxMasterVaccineImportRows = 1000
+
+// Import row statuses
+const (
+	ImportStatusCreated = "created"
+	ImportStatusUpdated = "updated"
+	ImportStatusError   = "error"
+)
+
+// ErrInvalidImport wraps the reason an import cannot be read at all, e.g. a CSV without the required columns
+var ErrInvalidImport = errors.New("invalid master vaccine import")
+
+// masterVaccineCSVHeader lists the CSV columns; description_id, description_en and is_active may be left out
+var masterVaccineCSVHeader = []string{
+	"vaccine_code",
+	"vaccine_name_id",
+	"vaccine_name_en",
+	"description_id",
+	"description_en",
+	"vaccine_type",
+	"is_active",
+}
+
+// ImportMasterVaccineRow is one master vaccine of an import; the English name identifies the vaccine
+type ImportMasterVaccineRow struct {
+	VaccineCode   string  `json:"vaccine_code"`
+	VaccineNameID string  `json:"vaccine_name_id"`
+	VaccineNameEN string  `json:"vaccine_name_en"`
+	DescriptionID *string `json:"description_id"`
+	DescriptionEN *string `json:"description_en"`
+	VaccineType   string  `json:"vaccine_type"`
+	// IsActive defaults to true
+	IsActive *bool `json:"is_active"`
+}
+
+// ImportMasterVaccineResult is the outcome of one row, numbered from 1 in the order of the import
+type ImportMasterVaccineResult struct {
+	Row           int    `json:"row"`
+	VaccineCode   string `json:"vaccine_code"`
+	VaccineNameEN string `json:"vaccine_name_en"`
+	Status        string `json:"status"`
+	ID            string `json:"id,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+type ImportMasterVaccinesResponse struct {
+	Created int                          `json:"created"`
+	Updated int                          `json:"updated"`
+	Errored int                          `json:"errored"`
+	Results []*ImportMasterVaccineResult `json:"results"`
+}
+
+type ImportMasterVaccinesUseCase struct {
+	vaccinesRepo VaccinesRepository
+}
+
+func NewImportMasterVaccinesUseCase(vaccinesRepo VaccinesRepository) *ImportMasterVaccinesUseCase {
+	return &ImportMasterVaccinesUseCase{
+		vaccinesRepo: vaccinesRepo,
+	}
+}
+
+// ParseMasterVaccinesCSV reads an import from CSV with a header row naming the columns in any order
+func ParseMasterVaccinesCSV(r io.Reader) ([]ImportMasterVaccineRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read CSV header: %v", ErrInvalidImport, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"vaccine_code", "vaccine_name_id", "vaccine_name_en", "vaccine_type"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: CSV is missing the %s column (columns: %s)", ErrInvalidImport, required, strings.Join(masterVaccineCSVHeader, ", "))
+		}
+	}
+
+	field := func(record []string, name string) (string, bool) {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return "", false
+		}
+		return record[i], true
+	}
+
+	var rows []ImportMasterVaccineRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+		}
+
+		var row ImportMasterVaccineRow
+		row.VaccineCode, _ = field(record, "vaccine_code")
+		row.VaccineNameID, _ = field(record, "vaccine_name_id")
+		row.VaccineNameEN, _ = field(record, "vaccine_name_en")
+		row.VaccineType, _ = field(record, "vaccine_type")
+		if description, ok := field(record, "description_id"); ok && description != "" {
+			row.DescriptionID = &description
+		}
+		if description, ok := field(record, "description_en"); ok && description != "" {
+			row.DescriptionEN = &description
+		}
+		if value, ok := field(record, "is_active"); ok && strings.TrimSpace(value) != "" {
+			isActive, err := strconv.ParseBool(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("%w: row %d: is_active must be true or false", ErrInvalidImport, len(rows)+1)
+			}
+			row.IsActive = &isActive
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// Execute validates every row and upserts the valid ones by English name in a single transaction. Invalid
+// rows are reported in the results and do not stop the others from being imported.
+func (uc *ImportMasterVaccinesUseCase) Execute(ctx context.Context, rows []ImportMasterVaccineRow) (*ImportMasterVaccinesResponse, error) {
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no vaccines to import", ErrInvalidImport)
+	}
+	if len(rows) > MaxMasterVaccineImportRows {
+		return nil, fmt.Errorf("%w: at most %d vaccines can be imported at once", ErrInvalidImport, MaxMasterVaccineImportRows)
+	}
+
+	response := &ImportMasterVaccinesResponse{Results: make([]*ImportMasterVaccineResult, len(rows))}
+
+	var vaccines []*entity.MasterVaccine
+	var vaccineRows []int
+	seenNames := make(map[string]int, len(rows))
+	for i, row := range rows {
+		result := &ImportMasterVaccineResult{Row: i + 1, VaccineCode: row.VaccineCode, VaccineNameEN: row.VaccineNameEN}
+		response.Results[i] = result
+
+		vaccine, err := entity.NewMasterVaccine(row.VaccineCode, row.VaccineNameID, row.VaccineNameEN,
+			entity.VaccineType(strings.ToLower(strings.TrimSpace(row.VaccineType))), row.DescriptionID, row.DescriptionEN)
+		if err != nil {
+			result.Status, result.Error = ImportStatusError, err.Error()
+			continue
+		}
+		if row.IsActive != nil {
+			vaccine.IsActive = *row.IsActive
+		}
+
+		name := strings.ToLower(vaccine.VaccineNameEN)
+		if first, ok := seenNames[name]; ok {
+			result.Status, result.Error = ImportStatusError, fmt.Sprintf("vaccine name (English) is already imported in row %d", first)
+			continue
+		}
+		seenNames[name] = i + 1
+
+		vaccines = append(vaccines, vaccine)
+		vaccineRows = append(vaccineRows, i)
+	}
+
+	if len(vaccines) > 0 {
+		upserted, err := uc.vaccinesRepo.BulkUpsertMasterVaccines(ctx, vaccines)
+		if err != nil {
+			return nil, err
+		}
+		for j, outcome := range upserted {
+			result := response.Results[vaccineRows[j]]
+			switch {
+			case outcome.Err != nil:
+				result.Status, result.Error = ImportStatusError, outcome.Err.Error()
+			case outcome.Created:
+				result.Status, result.ID = ImportStatusCreated, outcome.Vaccine.ID
+			default:
+				result.Status, result.ID = ImportStatusUpdated, outcome.Vaccine.ID
+			}
+		}
+	}
+
+	for _, result := range response.Results {
+		switch result.Status {
+		case ImportStatusCreated:
+			response.Created++
+		case ImportStatusUpdated:
+			response.Updated++
+		default:
+			response.Errored++
+		}
+	}
+
+	return response, nil
+}
@@ -0,0 +1,89 @@
+package vaccine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+)
+
+// stubImportVaccinesRepository knows the English names and codes of the existing vaccines
+type stubImportVaccinesRepository struct {
+	VaccinesRepository
+	existing map[string]string // lowercase English name -> code
+	upserted []*entity.MasterVaccine
+}
+
+func (r *stubImportVaccinesRepository) BulkUpsertMasterVaccines(_ context.Context, vaccines []*entity.MasterVaccine) ([]repository.MasterVaccineUpsertResult, error) {
+	r.upserted = vaccines
+	results := make([]repository.MasterVaccineUpsertResult, 0, len(vaccines))
+	for _, vaccine := range vaccines {
+		name := strings.ToLower(vaccine.VaccineNameEN)
+		taken := false
+		for existingName, code := range r.existing {
+			if code == vaccine.VaccineCode && existingName != name {
+				taken = true
+			}
+		}
+		if taken {
+			results = append(results, repository.MasterVaccineUpsertResult{Vaccine: vaccine, Err: fmt.Errorf("%w: %s", entity.ErrVaccineCodeTaken, vaccine.VaccineCode)})
+			continue
+		}
+		_, exists := r.existing[name]
+		vaccine.ID = "id-" + vaccine.VaccineCode
+		results = append(results, repository.MasterVaccineUpsertResult{Vaccine: vaccine, Created: !exists})
+	}
+	return results, nil
+}
+
+func TestImportMasterVaccines(t *testing.T) {
+	csv := `vaccine_code,vaccine_name_id,vaccine_name_en,vaccine_type,is_active
+yf,Demam Kuning,Yellow Fever,travel,
+BCG,BCG (Tuberkulosis),BCG (Tuberculosis),routine,false
+JE,Ensefalitis Jepang,,travel,
+RAB,Rabies,Rabies,unknown,
+YF2,Demam Kuning,yellow fever,travel,
+TYP,Tifoid,Typhoid,TRAVEL,
+`
+	rows, err := ParseMasterVaccinesCSV(strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("ParseMasterVaccinesCSV() error = %v", err)
+	}
+	// Typhoid reuses the code of the existing BCG vaccine
+	rows[5].VaccineCode = "BCG"
+
+	repo := &stubImportVaccinesRepository{existing: map[string]string{"bcg (tuberculosis)": "BCG"}}
+	response, err := NewImportMasterVaccinesUseCase(repo).Execute(context.Background(), rows)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	wantStatuses := []string{ImportStatusCreated, ImportStatusUpdated, ImportStatusError, ImportStatusError, ImportStatusError, ImportStatusError}
+	for i, want := range wantStatuses {
+		if got := response.Results[i]; got.Row != i+1 || got.Status != want {
+			t.Errorf("row %d = %+v, want status %s", i+1, got, want)
+		}
+	}
+	if response.Created != 1 || response.Updated != 1 || response.Errored != 4 {
+		t.Errorf("counts = %d created, %d updated, %d errored, want 1, 1, 4", response.Created, response.Updated, response.Errored)
+	}
+	if !strings.Contains(response.Results[4].Error, "row 1") {
+		t.Errorf("duplicate name error = %q, want it to name row 1", response.Results[4].Error)
+	}
+	if len(repo.upserted) != 3 || repo.upserted[0].VaccineCode != "YF" || repo.upserted[1].IsActive {
+		t.Errorf("upserted %+v, want YF, an inactive BCG and Typhoid", repo.upserted)
+	}
+}
+
+func TestImportMasterVaccinesRejectsUnreadableImports(t *testing.T) {
+	if _, err := ParseMasterVaccinesCSV(strings.NewReader("vaccine_code,vaccine_name_en\nYF,Yellow Fever\n")); !errors.Is(err, ErrInvalidImport) {
+		t.Errorf("ParseMasterVaccinesCSV() without required columns error = %v, want ErrInvalidImport", err)
+	}
+	if _, err := NewImportMasterVaccinesUseCase(&stubImportVaccinesRepository{}).Execute(context.Background(), nil); !errors.Is(err, ErrInvalidImport) {
+		t.Errorf("Execute() without rows error = %v, want ErrInvalidImport", err)
+	}
+}
@@ -52,6 +52,6 @@ func (uc *ListCountriesUseCase) Execute(ctx context.Context, req *ListCountriesR
 		Page:       page,
 		Limit:      limit,
 		TotalItems: total,
-		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+		TotalPages: pagination.BuildMetadata(total, page, limit).TotalPage,
 	}, nil
 }
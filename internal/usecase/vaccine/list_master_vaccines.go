@@ -52,6 +52,6 @@ func (uc *ListMasterVaccinesUseCase) Execute(ctx context.Context, req *ListMaste
 		Page:       page,
 		Limit:      limit,
 		TotalItems: total,
-		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+		TotalPages: pagination.BuildMetadata(total, page, limit).TotalPage,
 	}, nil
 }
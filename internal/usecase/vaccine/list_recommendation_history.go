@@ -0,0 +1,98 @@
+package vaccine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"sandbox/internal/domain/repository"
+)
+
+type ListRecommendationHistoryRequest struct {
+	BusinessTripID string `query:"businessTripId"`
+	AssigneeID     string `query:"assigneeId"`
+	CountryCode    string `query:"countryCode"`
+	Limit          int    `query:"limit"`
+}
+
+// RecommendationHistoryResponse is a vaccine recommendation as it was served
+type RecommendationHistoryResponse struct {
+	ID                    string   `json:"id"`
+	CountryCode           string   `json:"country_code"`
+	CountryName           string   `json:"country_name"`
+	Language              string   `json:"language"`
+	BusinessTripID        *string  `json:"business_trip_id"`
+	AssigneeID            *string  `json:"assignee_id"`
+	RequiredVaccineIDs    []string `json:"required_vaccine_ids"`
+	RecommendedVaccineIDs []string `json:"recommended_vaccine_ids"`
+	ConsiderVaccineIDs    []string `json:"consider_vaccine_ids"`
+	MalariaRisk           string   `json:"malaria_risk"`
+	MalariaProphylaxis    string   `json:"malaria_prophylaxis"`
+	GeneratedAt           string   `json:"generated_at"`
+	CreatedAt             string   `json:"created_at"`
+}
+
+type ListRecommendationHistoryUseCase struct {
+	vaccinesRepo VaccinesRepository
+}
+
+func NewListRecommendationHistoryUseCase(vaccinesRepo VaccinesRepository) *ListRecommendationHistoryUseCase {
+	return &ListRecommendationHistoryUseCase{
+		vaccinesRepo: vaccinesRepo,
+	}
+}
+
+// Validate requires a business trip or assignee, so the history is never listed for everyone at once
+func (r ListRecommendationHistoryRequest) Validate() error {
+	if r.BusinessTripID == "" && r.AssigneeID == "" {
+		return fmt.Errorf("businessTripId or assigneeId is required")
+	}
+	if r.BusinessTripID != "" {
+		if _, err := uuid.Parse(r.BusinessTripID); err != nil {
+			return fmt.Errorf("businessTripId must be a valid UUID")
+		}
+	}
+	if r.AssigneeID != "" {
+		if _, err := uuid.Parse(r.AssigneeID); err != nil {
+			return fmt.Errorf("assigneeId must be a valid UUID")
+		}
+	}
+	return nil
+}
+
+// Execute returns the recommendations served for the business trip or assignee, latest first. The
+// caller caps req.Limit.
+func (uc *ListRecommendationHistoryUseCase) Execute(ctx context.Context, req *ListRecommendationHistoryRequest) ([]*RecommendationHistoryResponse, error) {
+	history, err := uc.vaccinesRepo.ListVaccineRecommendationHistory(ctx, repository.VaccineRecommendationHistoryFilter{
+		BusinessTripID: req.BusinessTripID,
+		AssigneeID:     req.AssigneeID,
+		CountryCode:    req.CountryCode,
+		Limit:          req.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*RecommendationHistoryResponse, 0, len(history))
+	for _, entry := range history {
+		responses = append(responses, &RecommendationHistoryResponse{
+			ID:                    entry.ID,
+			CountryCode:           entry.CountryCode,
+			CountryName:           entry.CountryName,
+			Language:              entry.Language,
+			BusinessTripID:        entry.BusinessTripID,
+			AssigneeID:            entry.AssigneeID,
+			RequiredVaccineIDs:    entry.RequiredVaccineIDs,
+			RecommendedVaccineIDs: entry.RecommendedVaccineIDs,
+			ConsiderVaccineIDs:    entry.ConsiderVaccineIDs,
+			MalariaRisk:           entry.MalariaRisk,
+			MalariaProphylaxis:    entry.MalariaProphylaxis,
+			GeneratedAt:           entry.GeneratedAt.Format(time.RFC3339),
+			CreatedAt:             entry.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return responses, nil
+}
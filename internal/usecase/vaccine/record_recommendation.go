@@ -0,0 +1,121 @@
+package vaccine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/internal/domain/service"
+)
+
+type RecordCDCRecommendationRequest struct {
+	CountryCode string `json:"-"`
+	Language    string `json:"language"`
+	// BusinessTripID and AssigneeID key the recorded recommendation to the trip or assignee it was
+	// looked up for; at least one is required
+	BusinessTripID string `json:"businessTripId"`
+	AssigneeID     string `json:"assigneeId"`
+}
+
+// RecordCDCRecommendationUseCase serves a vaccine recommendation like GetCDCRecommendationsUseCase and
+// records it in the recommendation history of a business trip or assignee
+type RecordCDCRecommendationUseCase struct {
+	cdcService       *service.CDCService
+	vaccinesRepo     VaccinesRepository
+	businessTripRepo repository.BusinessTripRepository
+	assigneeRepo     repository.AssigneeRepository
+}
+
+func NewRecordCDCRecommendationUseCase(cdcService *service.CDCService, vaccinesRepo VaccinesRepository, businessTripRepo repository.BusinessTripRepository, assigneeRepo repository.AssigneeRepository) *RecordCDCRecommendationUseCase {
+	return &RecordCDCRecommendationUseCase{
+		cdcService:       cdcService,
+		vaccinesRepo:     vaccinesRepo,
+		businessTripRepo: businessTripRepo,
+		assigneeRepo:     assigneeRepo,
+	}
+}
+
+// Validate requires a business trip or assignee, since history without one can never be listed
+func (r RecordCDCRecommendationRequest) Validate() error {
+	if r.CountryCode == "" {
+		return fmt.Errorf("country code is required")
+	}
+	if r.BusinessTripID == "" && r.AssigneeID == "" {
+		return fmt.Errorf("businessTripId or assigneeId is required")
+	}
+	if r.BusinessTripID != "" {
+		if _, err := uuid.Parse(r.BusinessTripID); err != nil {
+			return fmt.Errorf("businessTripId must be a valid UUID")
+		}
+	}
+	if r.AssigneeID != "" {
+		if _, err := uuid.Parse(r.AssigneeID); err != nil {
+			return fmt.Errorf("assigneeId must be a valid UUID")
+		}
+	}
+	return nil
+}
+
+// Execute checks that the business trip and assignee exist and belong together, then records the
+// recommendation for them. An assignee given alone is recorded for its business trip as well.
+func (uc *RecordCDCRecommendationUseCase) Execute(ctx context.Context, req *RecordCDCRecommendationRequest) (*GetCDCRecommendationsResponse, error) {
+	businessTripID := req.BusinessTripID
+	if req.AssigneeID != "" {
+		assignee, err := uc.assigneeRepo.GetAssigneeByID(ctx, req.AssigneeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get assignee: %w", err)
+		}
+		if assignee == nil || (businessTripID != "" && assignee.BusinessTripID != businessTripID) {
+			return nil, entity.ErrAssigneeNotFound
+		}
+		businessTripID = assignee.BusinessTripID
+	}
+
+	businessTrip, err := uc.businessTripRepo.GetByID(ctx, businessTripID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get business trip: %w", err)
+	}
+	if businessTrip == nil {
+		return nil, entity.ErrBusinessTripNotFound
+	}
+
+	language := req.Language
+	if language == "" {
+		language = "en"
+	}
+
+	recommendation, err := uc.cdcService.GetVaccineRecommendationsByCountry(ctx, req.CountryCode, language, false)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &entity.VaccineRecommendationHistory{
+		CountryCode:           req.CountryCode,
+		CountryName:           recommendation.CountryName,
+		Language:              language,
+		BusinessTripID:        &businessTrip.ID,
+		AssigneeID:            optionalID(req.AssigneeID),
+		RequiredVaccineIDs:    vaccineIDs(recommendation.RequiredVaccines),
+		RecommendedVaccineIDs: vaccineIDs(recommendation.RecommendedVaccines),
+		ConsiderVaccineIDs:    vaccineIDs(recommendation.ConsiderVaccines),
+		MalariaRisk:           recommendation.MalariaRisk,
+		MalariaProphylaxis:    recommendation.MalariaProphylaxis,
+		GeneratedAt:           recommendation.FetchedAt,
+	}
+	if err := uc.vaccinesRepo.CreateVaccineRecommendationHistory(ctx, history); err != nil {
+		return nil, fmt.Errorf("failed to record vaccine recommendation history: %w", err)
+	}
+
+	return &GetCDCRecommendationsResponse{
+		Data:        recommendation,
+		Message:     "CDC vaccine recommendation recorded successfully",
+		Success:     true,
+		CountryCode: req.CountryCode,
+		Language:    language,
+		LastUpdated: recommendation.FetchedAt,
+		HistoryID:   history.ID,
+	}, nil
+}
@@ -0,0 +1,164 @@
+package work_paper
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/invopop/validation"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/internal/domain/service"
+	"sandbox/pkg/database"
+)
+
+// BulkValidateWorkPaperNotesUseCase updates the validation of many notes of a work paper at once
+type BulkValidateWorkPaperNotesUseCase struct {
+	deskService       service.DeskService
+	workPaperNoteRepo repository.WorkPaperNoteRepository
+	db                database.DB
+}
+
+// NewBulkValidateWorkPaperNotesUseCase creates a new use case instance
+func NewBulkValidateWorkPaperNotesUseCase(deskService service.DeskService, workPaperNoteRepo repository.WorkPaperNoteRepository, db database.DB) *BulkValidateWorkPaperNotesUseCase {
+	return &BulkValidateWorkPaperNotesUseCase{
+		deskService:       deskService,
+		workPaperNoteRepo: workPaperNoteRepo,
+		db:                db,
+	}
+}
+
+// NoteValidationItem is the new validation of one note
+type NoteValidationItem struct {
+	NoteID  string `json:"note_id"`
+	IsValid *bool  `json:"is_valid"`
+	Notes   string `json:"notes"`
+	// Version is the version the client last read; when set, the whole batch fails if the note has changed since
+	Version *int `json:"version"`
+}
+
+// BulkValidateWorkPaperNotesRequest holds the validations to apply to the notes of one work paper
+type BulkValidateWorkPaperNotesRequest struct {
+	WorkPaperID string
+	Items       []NoteValidationItem
+}
+
+// Validate checks that every item names a note and that no note is listed twice
+func (req *BulkValidateWorkPaperNotesRequest) Validate() error {
+	if req.WorkPaperID == "" {
+		return validation.NewError("work_paper_id", "Work paper ID is required")
+	}
+	if len(req.Items) == 0 {
+		return validation.NewError("items", "At least one note is required")
+	}
+
+	seen := make(map[string]bool, len(req.Items))
+	for i, item := range req.Items {
+		if item.NoteID == "" {
+			return validation.NewError("note_id", fmt.Sprintf("Note ID of item %d is required", i))
+		}
+		if seen[item.NoteID] {
+			return validation.NewError("note_id", fmt.Sprintf("Note %s is listed more than once", item.NoteID))
+		}
+		seen[item.NoteID] = true
+	}
+	return nil
+}
+
+// NoteValidationResult is the state of one note after the bulk update
+type NoteValidationResult struct {
+	NoteID    string  `json:"note_id"`
+	IsValid   *bool   `json:"is_valid"`
+	Notes     *string `json:"notes"`
+	Version   int     `json:"version"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+// BulkValidateWorkPaperNotesResponse lists every updated note and the recomputed progress of the work paper
+type BulkValidateWorkPaperNotesResponse struct {
+	WorkPaperID string                         `json:"work_paper_id"`
+	Results     []*NoteValidationResult        `json:"results"`
+	Progress    *service.WorkPaperNoteProgress `json:"progress"`
+}
+
+// NotesNotInWorkPaperError is returned when some notes of a bulk update do not belong to the work paper
+type NotesNotInWorkPaperError struct {
+	NoteIDs []string
+}
+
+func (e *NotesNotInWorkPaperError) Error() string {
+	return fmt.Sprintf("%s in this work paper: %s", entity.ErrWorkPaperNoteNotFound.Error(), strings.Join(e.NoteIDs, ", "))
+}
+
+func (e *NotesNotInWorkPaperError) Unwrap() error {
+	return entity.ErrWorkPaperNoteNotFound
+}
+
+// Execute applies every validation in a single transaction: either all notes are updated or none are.
+// All notes are checked to belong to the work paper before anything is written.
+func (uc *BulkValidateWorkPaperNotesUseCase) Execute(ctx context.Context, req BulkValidateWorkPaperNotesRequest) (*BulkValidateWorkPaperNotesResponse, error) {
+	if _, err := uc.deskService.GetWorkPaper(ctx, req.WorkPaperID); err != nil {
+		return nil, err
+	}
+
+	results := make([]*NoteValidationResult, 0, len(req.Items))
+	err := uc.db.WithTransaction(ctx, func(ctx context.Context, tx database.DBTx) error {
+		noteRepo := uc.workPaperNoteRepo.WithTransaction(tx)
+
+		notes, err := noteRepo.GetByWorkPaper(ctx, req.WorkPaperID)
+		if err != nil {
+			return fmt.Errorf("failed to get work paper notes: %w", err)
+		}
+		notesByID := make(map[string]*entity.WorkPaperNote, len(notes))
+		for _, note := range notes {
+			notesByID[note.ID.String()] = note
+		}
+
+		var missing []string
+		for _, item := range req.Items {
+			if notesByID[item.NoteID] == nil {
+				missing = append(missing, item.NoteID)
+			}
+		}
+		if len(missing) > 0 {
+			return &NotesNotInWorkPaperError{NoteIDs: missing}
+		}
+
+		for _, item := range req.Items {
+			note := notesByID[item.NoteID]
+			if item.Version != nil && *item.Version != note.Version {
+				return fmt.Errorf("note %s: %w", item.NoteID, entity.ErrVersionConflict)
+			}
+
+			note.UpdateValidation(item.IsValid, item.Notes)
+			updated, err := noteRepo.Update(ctx, note)
+			if err != nil {
+				return fmt.Errorf("failed to update work paper note %s: %w", item.NoteID, err)
+			}
+
+			results = append(results, &NoteValidationResult{
+				NoteID:    updated.ID.String(),
+				IsValid:   updated.IsValid,
+				Notes:     updated.Notes,
+				Version:   updated.Version,
+				UpdatedAt: updated.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	progress, err := uc.deskService.GetWorkPaperProgress(ctx, req.WorkPaperID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BulkValidateWorkPaperNotesResponse{
+		WorkPaperID: req.WorkPaperID,
+		Results:     results,
+		Progress:    progress,
+	}, nil
+}
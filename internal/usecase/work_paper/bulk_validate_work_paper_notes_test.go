@@ -0,0 +1,190 @@
+package work_paper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/internal/domain/service"
+	"sandbox/pkg/database"
+)
+
+// stubBulkValidateDB runs the transaction function directly and records whether it was rolled back
+type stubBulkValidateDB struct {
+	database.DB
+	rolledBack bool
+}
+
+func (db *stubBulkValidateDB) WithTransaction(ctx context.Context, fn func(ctx context.Context, tx database.DBTx) error) error {
+	err := fn(ctx, nil)
+	db.rolledBack = err != nil
+	return err
+}
+
+// stubBulkValidateNoteRepository serves the notes of one work paper and records every update
+type stubBulkValidateNoteRepository struct {
+	repository.WorkPaperNoteRepository
+	notes   []*entity.WorkPaperNote
+	updated []string
+}
+
+func (r *stubBulkValidateNoteRepository) WithTransaction(interface{}) repository.WorkPaperNoteRepository {
+	return r
+}
+
+func (r *stubBulkValidateNoteRepository) GetByWorkPaper(context.Context, string) ([]*entity.WorkPaperNote, error) {
+	return r.notes, nil
+}
+
+func (r *stubBulkValidateNoteRepository) Update(_ context.Context, note *entity.WorkPaperNote) (*entity.WorkPaperNote, error) {
+	r.updated = append(r.updated, note.ID.String())
+	note.Version++
+	return note, nil
+}
+
+type stubBulkValidateDeskService struct {
+	service.DeskService
+	getErr   error
+	progress *service.WorkPaperNoteProgress
+}
+
+func (s *stubBulkValidateDeskService) GetWorkPaper(context.Context, string) (*entity.WorkPaper, error) {
+	if s.getErr != nil {
+		return nil, s.getErr
+	}
+	return &entity.WorkPaper{}, nil
+}
+
+func (s *stubBulkValidateDeskService) GetWorkPaperProgress(context.Context, string) (*service.WorkPaperNoteProgress, error) {
+	return s.progress, nil
+}
+
+func newBulkValidateUseCase(notes ...*entity.WorkPaperNote) (*BulkValidateWorkPaperNotesUseCase, *stubBulkValidateNoteRepository, *stubBulkValidateDB) {
+	noteRepo := &stubBulkValidateNoteRepository{notes: notes}
+	db := &stubBulkValidateDB{}
+	desk := &stubBulkValidateDeskService{progress: &service.WorkPaperNoteProgress{Total: len(notes), Valid: len(notes), Percentage: 100}}
+	return NewBulkValidateWorkPaperNotesUseCase(desk, noteRepo, db), noteRepo, db
+}
+
+func TestBulkValidateWorkPaperNotes(t *testing.T) {
+	first := &entity.WorkPaperNote{ID: uuid.New(), Version: 1}
+	second := &entity.WorkPaperNote{ID: uuid.New(), Version: 4}
+	uc, noteRepo, _ := newBulkValidateUseCase(first, second)
+
+	valid, invalid := true, false
+	version := 4
+	resp, err := uc.Execute(context.Background(), BulkValidateWorkPaperNotesRequest{
+		WorkPaperID: uuid.NewString(),
+		Items: []NoteValidationItem{
+			{NoteID: first.ID.String(), IsValid: &valid},
+			{NoteID: second.ID.String(), IsValid: &invalid, Notes: "Dokumen belum ditandatangani", Version: &version},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(noteRepo.updated) != 2 || len(resp.Results) != 2 {
+		t.Fatalf("updated %d notes with %d results, want 2 and 2", len(noteRepo.updated), len(resp.Results))
+	}
+	if got := resp.Results[0]; got.NoteID != first.ID.String() || !*got.IsValid || got.Notes != nil || got.Version != 2 {
+		t.Errorf("first result = %+v, want valid without notes at version 2", got)
+	}
+	if got := resp.Results[1]; *got.IsValid || got.Notes == nil || *got.Notes != "Dokumen belum ditandatangani" || got.Version != 5 {
+		t.Errorf("second result = %+v, want invalid with its notes at version 5", got)
+	}
+	if resp.Progress == nil || resp.Progress.Total != 2 {
+		t.Errorf("progress = %+v, want the recomputed progress of the work paper", resp.Progress)
+	}
+}
+
+func TestBulkValidateWorkPaperNotesOutsideWorkPaper(t *testing.T) {
+	note := &entity.WorkPaperNote{ID: uuid.New()}
+	uc, noteRepo, _ := newBulkValidateUseCase(note)
+
+	valid := true
+	otherID := uuid.NewString()
+	_, err := uc.Execute(context.Background(), BulkValidateWorkPaperNotesRequest{
+		WorkPaperID: uuid.NewString(),
+		Items: []NoteValidationItem{
+			{NoteID: note.ID.String(), IsValid: &valid},
+			{NoteID: otherID, IsValid: &valid},
+		},
+	})
+
+	var notInWorkPaper *NotesNotInWorkPaperError
+	if !errors.As(err, &notInWorkPaper) || !errors.Is(err, entity.ErrWorkPaperNoteNotFound) {
+		t.Fatalf("Execute() error = %v, want NotesNotInWorkPaperError", err)
+	}
+	if len(notInWorkPaper.NoteIDs) != 1 || notInWorkPaper.NoteIDs[0] != otherID {
+		t.Errorf("missing notes = %v, want [%s]", notInWorkPaper.NoteIDs, otherID)
+	}
+	if len(noteRepo.updated) != 0 {
+		t.Errorf("updated %v before checking that every note belongs to the work paper", noteRepo.updated)
+	}
+}
+
+func TestBulkValidateWorkPaperNotesVersionConflictRollsBack(t *testing.T) {
+	first := &entity.WorkPaperNote{ID: uuid.New(), Version: 1}
+	second := &entity.WorkPaperNote{ID: uuid.New(), Version: 3}
+	uc, _, db := newBulkValidateUseCase(first, second)
+
+	valid := true
+	stale := 2
+	_, err := uc.Execute(context.Background(), BulkValidateWorkPaperNotesRequest{
+		WorkPaperID: uuid.NewString(),
+		Items: []NoteValidationItem{
+			{NoteID: first.ID.String(), IsValid: &valid},
+			{NoteID: second.ID.String(), IsValid: &valid, Version: &stale},
+		},
+	})
+
+	if !errors.Is(err, entity.ErrVersionConflict) {
+		t.Fatalf("Execute() error = %v, want ErrVersionConflict", err)
+	}
+	if !db.rolledBack {
+		t.Error("the batch was committed after a version conflict")
+	}
+}
+
+func TestBulkValidateWorkPaperNotesUnknownWorkPaper(t *testing.T) {
+	noteRepo := &stubBulkValidateNoteRepository{}
+	desk := &stubBulkValidateDeskService{getErr: entity.ErrWorkPaperNotFound}
+	uc := NewBulkValidateWorkPaperNotesUseCase(desk, noteRepo, &stubBulkValidateDB{})
+
+	valid := true
+	_, err := uc.Execute(context.Background(), BulkValidateWorkPaperNotesRequest{
+		WorkPaperID: uuid.NewString(),
+		Items:       []NoteValidationItem{{NoteID: uuid.NewString(), IsValid: &valid}},
+	})
+	if !errors.Is(err, entity.ErrWorkPaperNotFound) {
+		t.Errorf("Execute() error = %v, want ErrWorkPaperNotFound", err)
+	}
+}
+
+func TestBulkValidateWorkPaperNotesRequestValidate(t *testing.T) {
+	noteID := uuid.NewString()
+	tests := []struct {
+		name    string
+		req     BulkValidateWorkPaperNotesRequest
+		wantErr bool
+	}{
+		{"valid", BulkValidateWorkPaperNotesRequest{WorkPaperID: "wp", Items: []NoteValidationItem{{NoteID: noteID}}}, false},
+		{"no work paper", BulkValidateWorkPaperNotesRequest{Items: []NoteValidationItem{{NoteID: noteID}}}, true},
+		{"no items", BulkValidateWorkPaperNotesRequest{WorkPaperID: "wp"}, true},
+		{"item without note", BulkValidateWorkPaperNotesRequest{WorkPaperID: "wp", Items: []NoteValidationItem{{}}}, true},
+		{"note listed twice", BulkValidateWorkPaperNotesRequest{WorkPaperID: "wp", Items: []NoteValidationItem{{NoteID: noteID}, {NoteID: noteID}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
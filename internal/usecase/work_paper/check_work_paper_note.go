@@ -21,6 +21,12 @@ func NewCheckWorkPaperNoteUseCase(deskService service.DeskService) *CheckWorkPap
 // CheckRequest represents the request payload for checking a document
 type CheckRequest struct {
 	NoteID string `json:"note_id" validate:"required"`
+	// SkipCache asks the LLM again even when the same documents were already checked against the same item
+	SkipCache bool `json:"skip_cache"`
+	// Model asks for one of service.SupportedLLMModels instead of the default model for this check
+	Model string `json:"model,omitempty"`
+	// Temperature overrides the model's sampling temperature for this check
+	Temperature *float64 `json:"temperature,omitempty" validate:"omitnil,min=0,max=2"`
 }
 
 // CheckResponse represents the response payload for checking a document
@@ -28,12 +34,17 @@ type CheckResponse struct {
 	IsValid bool   `json:"is_valid"`
 	Notes   string `json:"notes"`
 	Model   string `json:"model"`
+	// Cached is set when the verdict was reused from an earlier check
+	Cached bool `json:"cached"`
 }
 
 // Execute executes the use case
 func (uc *CheckWorkPaperNoteUseCase) Execute(ctx context.Context, req CheckRequest) (*CheckResponse, error) {
 	// Call service to check document
-	checkResp, err := uc.deskService.CheckDocument(ctx, req.NoteID)
+	checkResp, err := uc.deskService.CheckDocument(ctx, req.NoteID, req.SkipCache, service.LLMOptions{
+		Model:       req.Model,
+		Temperature: req.Temperature,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -43,6 +54,7 @@ func (uc *CheckWorkPaperNoteUseCase) Execute(ctx context.Context, req CheckReque
 		IsValid: checkResp.IsValid,
 		Notes:   checkResp.Notes,
 		Model:   checkResp.Model,
+		Cached:  checkResp.Cached,
 	}
 
 	return response, nil
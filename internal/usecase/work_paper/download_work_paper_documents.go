@@ -0,0 +1,37 @@
+package work_paper
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"sandbox/internal/domain/service"
+)
+
+// DownloadWorkPaperDocumentsUseCase handles downloading every document linked from a work paper's notes
+// as one zip archive
+type DownloadWorkPaperDocumentsUseCase struct {
+	deskService service.DeskService
+}
+
+// NewDownloadWorkPaperDocumentsUseCase creates a new use case instance
+func NewDownloadWorkPaperDocumentsUseCase(deskService service.DeskService) *DownloadWorkPaperDocumentsUseCase {
+	return &DownloadWorkPaperDocumentsUseCase{
+		deskService: deskService,
+	}
+}
+
+// Prepare checks that the work paper exists and returns the file name of its archive. It is called
+// before the response is started, so a missing work paper can still be reported with a 404.
+func (uc *DownloadWorkPaperDocumentsUseCase) Prepare(ctx context.Context, workPaperID string) (string, error) {
+	wp, err := uc.deskService.GetWorkPaper(ctx, workPaperID)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("work_paper_%s_%d_S%d_documents.zip", wp.ID, wp.Year, wp.Semester), nil
+}
+
+// Execute streams the zip archive of the work paper's documents to w
+func (uc *DownloadWorkPaperDocumentsUseCase) Execute(ctx context.Context, workPaperID string, w io.Writer) error {
+	return uc.deskService.WriteWorkPaperDocumentsZip(ctx, workPaperID, w)
+}
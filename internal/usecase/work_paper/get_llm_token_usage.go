@@ -0,0 +1,24 @@
+package work_paper
+
+import (
+	"context"
+
+	"sandbox/internal/domain/service"
+)
+
+// GetLLMTokenUsageUseCase handles reading the LLM token usage of the current month
+type GetLLMTokenUsageUseCase struct {
+	tokenBudget *service.TokenBudget
+}
+
+// NewGetLLMTokenUsageUseCase creates a new use case instance
+func NewGetLLMTokenUsageUseCase(tokenBudget *service.TokenBudget) *GetLLMTokenUsageUseCase {
+	return &GetLLMTokenUsageUseCase{
+		tokenBudget: tokenBudget,
+	}
+}
+
+// Execute executes the use case
+func (uc *GetLLMTokenUsageUseCase) Execute(ctx context.Context) (*service.TokenBudgetUsage, error) {
+	return uc.tokenBudget.Usage(ctx)
+}
@@ -0,0 +1,62 @@
+package work_paper
+
+import (
+	"context"
+
+	"sandbox/internal/domain/service"
+)
+
+// GetNoteCheckHistoryUseCase handles listing the LLM check history of a work paper note
+type GetNoteCheckHistoryUseCase struct {
+	deskService service.DeskService
+}
+
+// NewGetNoteCheckHistoryUseCase creates a new use case instance
+func NewGetNoteCheckHistoryUseCase(deskService service.DeskService) *GetNoteCheckHistoryUseCase {
+	return &GetNoteCheckHistoryUseCase{
+		deskService: deskService,
+	}
+}
+
+// NoteCheckHistoryResponse represents a single check history entry in the response
+type NoteCheckHistoryResponse struct {
+	ID               string `json:"id"`
+	WorkPaperNoteID  string `json:"work_paper_note_id"`
+	IsValid          bool   `json:"is_valid"`
+	Notes            string `json:"notes"`
+	Model            string `json:"model"`
+	PromptTokens     int    `json:"prompt_tokens"`
+	CompletionTokens int    `json:"completion_tokens"`
+	TotalTokens      int    `json:"total_tokens"`
+	CheckedAt        string `json:"checked_at"`
+}
+
+// Execute executes the use case, returning entries newest first
+func (uc *GetNoteCheckHistoryUseCase) Execute(ctx context.Context, noteID string) ([]*NoteCheckHistoryResponse, error) {
+	history, err := uc.deskService.GetWorkPaperNoteCheckHistory(ctx, noteID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*NoteCheckHistoryResponse, 0, len(history))
+	for _, entry := range history {
+		response := &NoteCheckHistoryResponse{
+			ID:               entry.ID.String(),
+			WorkPaperNoteID:  entry.WorkPaperNoteID.String(),
+			IsValid:          entry.IsValid,
+			PromptTokens:     entry.PromptTokens,
+			CompletionTokens: entry.CompletionTokens,
+			TotalTokens:      entry.TotalTokens,
+			CheckedAt:        entry.CheckedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+		if entry.Notes != nil {
+			response.Notes = *entry.Notes
+		}
+		if entry.Model != nil {
+			response.Model = *entry.Model
+		}
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
@@ -0,0 +1,77 @@
+package work_paper
+
+import (
+	"context"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/service"
+)
+
+// Reasons a work paper note needs the reviewer's attention
+const (
+	AttentionReasonMissingLink = "missing_link"
+	AttentionReasonInvalid     = "invalid"
+)
+
+// GetNotesNeedingAttentionUseCase handles listing the notes of a work paper that still need work
+type GetNotesNeedingAttentionUseCase struct {
+	deskService service.DeskService
+}
+
+// NewGetNotesNeedingAttentionUseCase creates a new use case instance
+func NewGetNotesNeedingAttentionUseCase(deskService service.DeskService) *GetNotesNeedingAttentionUseCase {
+	return &GetNotesNeedingAttentionUseCase{
+		deskService: deskService,
+	}
+}
+
+// NoteNeedingAttentionResponse represents a single to-do entry in the response
+type NoteNeedingAttentionResponse struct {
+	ID                  string   `json:"id"`
+	MasterItemID        string   `json:"master_item_id"`
+	MasterItemNumber    string   `json:"master_item_number"`
+	MasterItemStatement string   `json:"master_item_statement"`
+	GDriveLink          string   `json:"gdrive_link"`
+	IsValid             *bool    `json:"is_valid"`
+	Notes               string   `json:"notes"`
+	Reasons             []string `json:"reasons"`
+}
+
+// Execute executes the use case, returning the notes in the order of their master items
+func (uc *GetNotesNeedingAttentionUseCase) Execute(ctx context.Context, workPaperID string) ([]*NoteNeedingAttentionResponse, error) {
+	notes, err := uc.deskService.GetWorkPaperNotesNeedingAttention(ctx, workPaperID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*NoteNeedingAttentionResponse, 0, len(notes))
+	for _, note := range notes {
+		response := &NoteNeedingAttentionResponse{
+			ID:           note.ID.String(),
+			MasterItemID: note.MasterItemID.String(),
+			GDriveLink:   note.GetGDriveLink(),
+			IsValid:      note.IsValid,
+			Notes:        note.GetNotes(),
+			Reasons:      attentionReasons(note),
+		}
+		if note.MasterItem != nil {
+			response.MasterItemNumber = note.MasterItem.Number
+			response.MasterItemStatement = note.MasterItem.Statement
+		}
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
+
+// attentionReasons explains why a note was listed, so the UI does not have to repeat the query's rules
+func attentionReasons(note *entity.WorkPaperNote) []string {
+	reasons := []string{}
+	if note.GetGDriveLink() == "" {
+		reasons = append(reasons, AttentionReasonMissingLink)
+	}
+	if note.IsValid != nil && !*note.IsValid {
+		reasons = append(reasons, AttentionReasonInvalid)
+	}
+	return reasons
+}
@@ -0,0 +1,67 @@
+package work_paper
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/service"
+)
+
+type stubAttentionDeskService struct {
+	service.DeskService
+	notes []*entity.WorkPaperNote
+	err   error
+}
+
+func (s *stubAttentionDeskService) GetWorkPaperNotesNeedingAttention(context.Context, string) ([]*entity.WorkPaperNote, error) {
+	return s.notes, s.err
+}
+
+func TestGetNotesNeedingAttention(t *testing.T) {
+	link := "https://drive.google.com/file/d/abc"
+	invalid := false
+	item := &entity.WorkPaperItem{ID: uuid.New(), Number: "1.1", Statement: "Rencana strategis tersedia"}
+	missingLink := &entity.WorkPaperNote{ID: uuid.New(), MasterItemID: item.ID, MasterItem: item}
+	invalidNote := &entity.WorkPaperNote{ID: uuid.New(), MasterItemID: uuid.New(), GDriveLink: &link, IsValid: &invalid}
+	both := &entity.WorkPaperNote{ID: uuid.New(), MasterItemID: uuid.New(), IsValid: &invalid}
+
+	uc := NewGetNotesNeedingAttentionUseCase(&stubAttentionDeskService{notes: []*entity.WorkPaperNote{missingLink, invalidNote, both}})
+	got, err := uc.Execute(context.Background(), uuid.NewString())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("Execute() returned %d notes, want 3", len(got))
+	}
+
+	if got[0].ID != missingLink.ID.String() || got[0].MasterItemNumber != "1.1" || got[0].MasterItemStatement != item.Statement {
+		t.Errorf("first note = %+v, want the master item number and statement of %s", got[0], missingLink.ID)
+	}
+	wantReasons := [][]string{
+		{AttentionReasonMissingLink},
+		{AttentionReasonInvalid},
+		{AttentionReasonMissingLink, AttentionReasonInvalid},
+	}
+	for i, want := range wantReasons {
+		if !reflect.DeepEqual(got[i].Reasons, want) {
+			t.Errorf("note %d reasons = %v, want %v", i, got[i].Reasons, want)
+		}
+	}
+	if got[1].GDriveLink != link || got[1].MasterItemNumber != "" {
+		t.Errorf("second note = %+v, want its link and no master item", got[1])
+	}
+}
+
+func TestGetNotesNeedingAttentionError(t *testing.T) {
+	wantErr := errors.New("work paper not found")
+	uc := NewGetNotesNeedingAttentionUseCase(&stubAttentionDeskService{err: wantErr})
+
+	if _, err := uc.Execute(context.Background(), uuid.NewString()); !errors.Is(err, wantErr) {
+		t.Errorf("Execute() error = %v, want %v", err, wantErr)
+	}
+}
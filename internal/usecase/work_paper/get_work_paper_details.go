@@ -2,22 +2,34 @@ package work_paper
 
 import (
 	"context"
+	"log"
 
+	"sandbox/internal/domain/entity"
 	"sandbox/internal/domain/service"
 )
 
 // GetWorkPaperDetailsUseCase handles getting work paper with all related details
 type GetWorkPaperDetailsUseCase struct {
-	deskService service.DeskService
+	deskService       service.DeskService
+	ensureNotesOnRead bool
 }
 
-// NewGetWorkPaperDetailsUseCase creates a new use case instance
-func NewGetWorkPaperDetailsUseCase(deskService service.DeskService) *GetWorkPaperDetailsUseCase {
+// NewGetWorkPaperDetailsUseCase creates a new use case instance.
+// When ensureNotesOnRead is set, missing notes are created before the details are returned.
+func NewGetWorkPaperDetailsUseCase(deskService service.DeskService, ensureNotesOnRead bool) *GetWorkPaperDetailsUseCase {
 	return &GetWorkPaperDetailsUseCase{
-		deskService: deskService,
+		deskService:       deskService,
+		ensureNotesOnRead: ensureNotesOnRead,
 	}
 }
 
+// GetWorkPaperDetailsRequest represents the request for getting work paper details
+type GetWorkPaperDetailsRequest struct {
+	ID          string `json:"id" validate:"required"`
+	OnlyInvalid bool   `json:"only_invalid"`
+	Unchecked   bool   `json:"unchecked"`
+}
+
 // GetWorkPaperDetailsResponse represents the detailed response with all related data
 type GetWorkPaperDetailsResponse struct {
 	ID             string                `json:"id"`
@@ -26,8 +38,11 @@ type GetWorkPaperDetailsResponse struct {
 	Year           int                   `json:"year"`
 	Semester       int                   `json:"semester"`
 	Status         string                `json:"status"`
+	Version        int                   `json:"version"`
 	CreatedAt      string                `json:"created_at"`
 	UpdatedAt      string                `json:"updated_at"`
+	// Progress counts cover all notes, even when the notes list is filtered
+	Progress *service.WorkPaperNoteProgress `json:"progress"`
 	// Include related data
 	WorkPaperNotes []*WorkPaperNoteResponse      `json:"work_paper_notes,omitempty"`
 	Signatures     []*WorkPaperSignatureResponse `json:"signatures,omitempty"`
@@ -41,11 +56,14 @@ type WorkPaperNoteResponse struct {
 	Explanation  string `json:"explanation"`
 	FillingGuide string `json:"filling_guide"`
 	Status       string `json:"status"`
-	DriveLink    string `json:"gdrive_link"`
-	IsValid      *bool  `json:"is_valid"`
-	Notes        string `json:"notes"`
-	CreatedAt    string `json:"created_at"`
-	UpdatedAt    string `json:"updated_at"`
+	// MasterItemActive is false when the master item was deactivated or deleted after the paper was created
+	MasterItemActive bool   `json:"master_item_active"`
+	DriveLink        string `json:"gdrive_link"`
+	IsValid          *bool  `json:"is_valid"`
+	Notes            string `json:"notes"`
+	Version          int    `json:"version"`
+	CreatedAt        string `json:"created_at"`
+	UpdatedAt        string `json:"updated_at"`
 }
 
 // WorkPaperSignatureResponse represents a work paper signature in the detailed response
@@ -65,15 +83,37 @@ type WorkPaperSignatureResponse struct {
 }
 
 // Execute executes the use case
-func (uc *GetWorkPaperDetailsUseCase) Execute(ctx context.Context, workPaperID string) (*GetWorkPaperDetailsResponse, error) {
+func (uc *GetWorkPaperDetailsUseCase) Execute(ctx context.Context, req GetWorkPaperDetailsRequest) (*GetWorkPaperDetailsResponse, error) {
+	workPaperID := req.ID
+
 	// Get the work paper
 	workPaper, err := uc.deskService.GetWorkPaper(ctx, workPaperID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get work paper notes
-	notes, err := uc.deskService.GetWorkPaperNotes(ctx, workPaperID)
+	// Repair papers that are missing notes for active master items
+	if uc.ensureNotesOnRead {
+		created, err := uc.deskService.EnsureWorkPaperNotes(ctx, workPaperID)
+		if err != nil {
+			return nil, err
+		}
+		if len(created) > 0 {
+			log.Printf("Created %d missing notes for work paper %s on read", len(created), workPaperID)
+		}
+	}
+
+	// Get work paper notes, filtered by validation state if requested
+	notes, err := uc.deskService.GetFilteredWorkPaperNotes(ctx, workPaperID, &service.WorkPaperNoteFilter{
+		OnlyInvalid: req.OnlyInvalid,
+		Unchecked:   req.Unchecked,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Progress always covers every note of the paper, whatever the filter
+	progress, err := uc.deskService.GetWorkPaperProgress(ctx, workPaperID)
 	if err != nil {
 		return nil, err
 	}
@@ -87,31 +127,7 @@ func (uc *GetWorkPaperDetailsUseCase) Execute(ctx context.Context, workPaperID s
 	// Convert work paper notes to response format
 	var noteResponses []*WorkPaperNoteResponse
 	for _, note := range notes {
-		noteResponse := &WorkPaperNoteResponse{
-			ID:          note.ID.String(),
-			WorkPaperID: note.WorkPaperID.String(),
-			DriveLink:   note.GetGDriveLink(),
-			CreatedAt:   note.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-			UpdatedAt:   note.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		}
-
-		if note.IsValid != nil {
-			noteResponse.IsValid = note.IsValid
-		}
-
-		// Add statement, explanation, and filling guide from master item if available
-		if note.MasterItem != nil {
-			noteResponse.Statement = note.MasterItem.Statement
-			noteResponse.Explanation = note.MasterItem.Explanation
-			noteResponse.FillingGuide = note.MasterItem.FillingGuide
-			noteResponse.Status = "active"
-			if note.MasterItem.DeletedAt != nil {
-				noteResponse.Status = "inactive"
-			}
-		}
-
-		noteResponse.Notes = note.GetNotes()
-
+		noteResponse := newWorkPaperNoteResponse(note)
 		noteResponses = append(noteResponses, noteResponse)
 	}
 
@@ -143,8 +159,10 @@ func (uc *GetWorkPaperDetailsUseCase) Execute(ctx context.Context, workPaperID s
 		Year:           workPaper.Year,
 		Semester:       workPaper.Semester,
 		Status:         workPaper.Status,
+		Version:        workPaper.Version,
 		CreatedAt:      workPaper.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:      workPaper.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Progress:       progress,
 		WorkPaperNotes: noteResponses,
 		Signatures:     signatureResponses,
 	}
@@ -161,3 +179,35 @@ func (uc *GetWorkPaperDetailsUseCase) Execute(ctx context.Context, workPaperID s
 
 	return response, nil
 }
+
+// newWorkPaperNoteResponse converts a work paper note, with its master item when loaded, to its response
+func newWorkPaperNoteResponse(note *entity.WorkPaperNote) *WorkPaperNoteResponse {
+	noteResponse := &WorkPaperNoteResponse{
+		ID:               note.ID.String(),
+		WorkPaperID:      note.WorkPaperID.String(),
+		MasterItemActive: note.IsMasterItemActive(),
+		Status:           "inactive",
+		DriveLink:        note.GetGDriveLink(),
+		Version:          note.Version,
+		CreatedAt:        note.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:        note.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if noteResponse.MasterItemActive {
+		noteResponse.Status = "active"
+	}
+
+	if note.IsValid != nil {
+		noteResponse.IsValid = note.IsValid
+	}
+
+	// Add statement, explanation, and filling guide from master item if available
+	if note.MasterItem != nil {
+		noteResponse.Statement = note.MasterItem.Statement
+		noteResponse.Explanation = note.MasterItem.Explanation
+		noteResponse.FillingGuide = note.MasterItem.FillingGuide
+	}
+
+	noteResponse.Notes = note.GetNotes()
+
+	return noteResponse
+}
@@ -0,0 +1,42 @@
+package work_paper
+
+import (
+	"context"
+
+	"sandbox/internal/domain/repository"
+	"sandbox/pkg/pagination"
+)
+
+// ListWorkPaperNotesUseCase handles listing work paper notes across work papers
+type ListWorkPaperNotesUseCase struct {
+	workPaperNoteRepo repository.WorkPaperNoteRepository
+}
+
+// NewListWorkPaperNotesUseCase creates a new use case instance
+func NewListWorkPaperNotesUseCase(workPaperNoteRepo repository.WorkPaperNoteRepository) *ListWorkPaperNotesUseCase {
+	return &ListWorkPaperNotesUseCase{
+		workPaperNoteRepo: workPaperNoteRepo,
+	}
+}
+
+// Execute executes the use case
+func (uc *ListWorkPaperNotesUseCase) Execute(ctx context.Context, params *pagination.QueryParams) ([]*WorkPaperNoteResponse, *pagination.PagedResponse, error) {
+	notes, totalCount, err := uc.workPaperNoteRepo.List(ctx, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]*WorkPaperNoteResponse, 0, len(notes))
+	for _, note := range notes {
+		responses = append(responses, newWorkPaperNoteResponse(note))
+	}
+
+	metadata := pagination.BuildMetadata(totalCount, params.Pagination.Page, params.Pagination.Limit)
+
+	return responses, &pagination.PagedResponse{
+		Page:       params.Pagination.Page,
+		Limit:      params.Pagination.Limit,
+		TotalItems: totalCount,
+		TotalPages: metadata.TotalPage,
+	}, nil
+}
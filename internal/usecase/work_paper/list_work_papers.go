@@ -4,28 +4,33 @@ import (
 	"context"
 
 	"sandbox/internal/domain/service"
+	"sandbox/pkg/pagination"
 )
 
 // ListWorkPapersUseCase handles listing work papers
 type ListWorkPapersUseCase struct {
 	deskService service.DeskService
+	pageLimits  pagination.Limits
 }
 
 // NewListWorkPapersUseCase creates a new use case instance
-func NewListWorkPapersUseCase(deskService service.DeskService) *ListWorkPapersUseCase {
+func NewListWorkPapersUseCase(deskService service.DeskService, pageLimits pagination.Limits) *ListWorkPapersUseCase {
 	return &ListWorkPapersUseCase{
 		deskService: deskService,
+		pageLimits:  pageLimits,
 	}
 }
 
-// ListRequest represents the request payload for listing work papers
+// ListRequest represents the request payload for listing work papers. A PageSize of 0 or above the
+// configured maximum falls back to the configured default page size.
 type ListRequest struct {
 	OrganizationID string `json:"organization_id"`
 	Year           *int   `json:"year"`
 	Semester       *int   `json:"semester"`
 	Status         string `json:"status"`
+	Completion     string `json:"completion" validate:"omitempty,oneof=complete incomplete"`
 	Page           int    `json:"page" validate:"min=1"`
-	PageSize       int    `json:"page_size" validate:"min=1,max=100"`
+	PageSize       int    `json:"page_size"`
 }
 
 // ListResponse represents the response payload for listing work papers
@@ -57,12 +62,17 @@ type WorkPaperResponse struct {
 
 // Execute executes the use case
 func (uc *ListWorkPapersUseCase) Execute(ctx context.Context, req ListRequest) (*ListResponse, error) {
+	req.PageSize = uc.pageLimits.PageSize(req.PageSize)
+
 	// Create service request
 	serviceReq := &service.ListWorkPapersRequest{
 		OrganizationID: req.OrganizationID,
 		Year:           req.Year,
 		Semester:       req.Semester,
 		Status:         req.Status,
+		Completion:     req.Completion,
+		Page:           req.Page,
+		PageSize:       req.PageSize,
 	}
 
 	// Get work papers from service
@@ -100,14 +110,8 @@ func (uc *ListWorkPapersUseCase) Execute(ctx context.Context, req ListRequest) (
 	// Calculate pagination
 	page := req.Page
 	pageSize := req.PageSize
-	if pageSize == 0 {
-		pageSize = 10 // default page size
-	}
 
-	totalPages := int(totalCount) / pageSize
-	if int(totalCount)%pageSize > 0 {
-		totalPages++
-	}
+	metadata := pagination.BuildMetadata(totalCount, page, pageSize)
 
 	return &ListResponse{
 		Data: responses,
@@ -115,7 +119,7 @@ func (uc *ListWorkPapersUseCase) Execute(ctx context.Context, req ListRequest) (
 			Count:       len(responses),
 			TotalCount:  int(totalCount),
 			CurrentPage: page,
-			TotalPage:   totalPages,
+			TotalPage:   metadata.TotalPage,
 			PageSize:    pageSize,
 		},
 	}, nil
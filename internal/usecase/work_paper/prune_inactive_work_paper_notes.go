@@ -0,0 +1,43 @@
+package work_paper
+
+import (
+	"context"
+
+	"sandbox/internal/domain/service"
+)
+
+// PruneInactiveWorkPaperNotesUseCase handles removing notes of a draft work paper whose master item is no longer active
+type PruneInactiveWorkPaperNotesUseCase struct {
+	deskService service.DeskService
+}
+
+// NewPruneInactiveWorkPaperNotesUseCase creates a new use case instance
+func NewPruneInactiveWorkPaperNotesUseCase(deskService service.DeskService) *PruneInactiveWorkPaperNotesUseCase {
+	return &PruneInactiveWorkPaperNotesUseCase{
+		deskService: deskService,
+	}
+}
+
+// PruneInactiveWorkPaperNotesResponse lists the notes that were removed
+type PruneInactiveWorkPaperNotesResponse struct {
+	WorkPaperID   string   `json:"work_paper_id"`
+	PrunedNoteIDs []string `json:"pruned_note_ids"`
+}
+
+// Execute executes the use case
+func (uc *PruneInactiveWorkPaperNotesUseCase) Execute(ctx context.Context, workPaperID string) (*PruneInactiveWorkPaperNotesResponse, error) {
+	pruned, err := uc.deskService.PruneInactiveWorkPaperNotes(ctx, workPaperID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PruneInactiveWorkPaperNotesResponse{
+		WorkPaperID:   workPaperID,
+		PrunedNoteIDs: make([]string, 0, len(pruned)),
+	}
+	for _, note := range pruned {
+		response.PrunedNoteIDs = append(response.PrunedNoteIDs, note.ID.String())
+	}
+
+	return response, nil
+}
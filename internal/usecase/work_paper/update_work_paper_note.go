@@ -3,6 +3,7 @@ package work_paper
 import (
 	"context"
 
+	"sandbox/internal/domain/entity"
 	"sandbox/internal/domain/service"
 )
 
@@ -24,6 +25,8 @@ type UpdateWorkPaperNoteRequest struct {
 	GDriveLink string `json:"gdrive_link,omitempty"`
 	IsValid    *bool  `json:"is_valid,omitempty"`
 	Notes      string `json:"notes,omitempty"`
+	// Version is the version the client last read; when set, the update fails if the note has changed since
+	Version *int `json:"version"`
 }
 
 // UpdateWorkPaperNoteResponse represents the response payload for updating work paper note
@@ -34,6 +37,7 @@ type UpdateWorkPaperNoteResponse struct {
 	GDriveLink   *string `json:"gdrive_link"`
 	IsValid      *bool   `json:"is_valid"`
 	Notes        *string `json:"notes"`
+	Version      int     `json:"version"`
 	CreatedAt    string  `json:"created_at"`
 	UpdatedAt    string  `json:"updated_at"`
 }
@@ -46,12 +50,22 @@ func (uc *UpdateWorkPaperNoteUseCase) Execute(ctx context.Context, req UpdateWor
 		return nil, err
 	}
 
+	if req.Version != nil && *req.Version != currentNote.Version {
+		return nil, entity.ErrVersionConflict
+	}
+
+	// Each update below must start from the version the previous one left behind
+	expectedVersion := req.Version
+
 	// Update fields if provided
 	if req.GDriveLink != "" {
-		_, err = uc.deskService.UpdateWorkPaperNoteLink(ctx, req.ID, req.GDriveLink)
+		updatedNote, err := uc.deskService.UpdateWorkPaperNoteLink(ctx, req.ID, req.GDriveLink, expectedVersion)
 		if err != nil {
 			return nil, err
 		}
+		if expectedVersion != nil {
+			expectedVersion = &updatedNote.Version
+		}
 	}
 
 	// Update validation and notes if provided
@@ -69,7 +83,7 @@ func (uc *UpdateWorkPaperNoteUseCase) Execute(ctx context.Context, req UpdateWor
 			notes = currentNote.GetNotes()
 		}
 
-		_, err = uc.deskService.UpdateWorkPaperNoteValidation(ctx, req.ID, isValid, notes)
+		_, err = uc.deskService.UpdateWorkPaperNoteValidation(ctx, req.ID, isValid, notes, expectedVersion)
 		if err != nil {
 			return nil, err
 		}
@@ -89,6 +103,7 @@ func (uc *UpdateWorkPaperNoteUseCase) Execute(ctx context.Context, req UpdateWor
 		GDriveLink:   updatedNote.GDriveLink,
 		IsValid:      updatedNote.IsValid,
 		Notes:        updatedNote.Notes,
+		Version:      updatedNote.Version,
 		CreatedAt:    updatedNote.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:    updatedNote.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
@@ -34,6 +34,8 @@ var AllValidStatuses = []string{"draft", "ongoing", "ready_to_sign", "completed"
 type UpdateStatusRequest struct {
 	ID     string `json:"id" validate:"required"`
 	Status string `json:"status" validate:"required,oneof=draft ongoing ready_to_sign completed"`
+	// Version is the version the client last read; when set, the update fails if the work paper has changed since
+	Version *int `json:"version"`
 }
 
 // UpdateStatusResponse represents the response payload for updating work paper status
@@ -43,6 +45,7 @@ type UpdateStatusResponse struct {
 	Year           int    `json:"year"`
 	Semester       int    `json:"semester"`
 	Status         string `json:"status"`
+	Version        int    `json:"version"`
 	CreatedAt      string `json:"created_at"`
 	UpdatedAt      string `json:"updated_at"`
 }
@@ -81,7 +84,7 @@ func (uc *UpdateWorkPaperStatusUseCase) Execute(ctx context.Context, req UpdateS
 	}
 
 	// Update work paper status
-	err = uc.deskService.UpdateWorkPaperStatus(ctx, req.ID, req.Status)
+	err = uc.deskService.UpdateWorkPaperStatus(ctx, req.ID, req.Status, req.Version)
 	if err != nil {
 		return nil, err
 	}
@@ -99,6 +102,7 @@ func (uc *UpdateWorkPaperStatusUseCase) Execute(ctx context.Context, req UpdateS
 		Year:           updatedWorkPaper.Year,
 		Semester:       updatedWorkPaper.Semester,
 		Status:         updatedWorkPaper.Status,
+		Version:        updatedWorkPaper.Version,
 		CreatedAt:      updatedWorkPaper.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:      updatedWorkPaper.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
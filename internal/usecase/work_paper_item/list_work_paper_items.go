@@ -91,16 +91,13 @@ func (uc *ListWorkPaperItemsUseCase) Execute(ctx context.Context, params *pagina
 		responses = append(responses, response)
 	}
 
-	totalPages := int(totalCount) / params.Pagination.Limit
-	if int(totalCount)%params.Pagination.Limit > 0 {
-		totalPages++
-	}
+	metadata := pagination.BuildMetadata(totalCount, params.Pagination.Page, params.Pagination.Limit)
 
 	return responses, &pagination.PagedResponse{
 		Page:       params.Pagination.Page,
 		Limit:      params.Pagination.Limit,
 		TotalItems: totalCount,
-		TotalPages: totalPages,
+		TotalPages: metadata.TotalPage,
 	}, nil
 }
 
@@ -0,0 +1,94 @@
+package work_paper_item
+
+import (
+	"context"
+	"fmt"
+
+	"sandbox/internal/domain/service"
+
+	"github.com/google/uuid"
+)
+
+// ReorderWorkPaperItemsUseCase handles moving many work paper items at once, e.g. after a drag and drop
+type ReorderWorkPaperItemsUseCase struct {
+	deskService service.DeskService
+}
+
+// NewReorderWorkPaperItemsUseCase creates a new use case instance
+func NewReorderWorkPaperItemsUseCase(deskService service.DeskService) *ReorderWorkPaperItemsUseCase {
+	return &ReorderWorkPaperItemsUseCase{
+		deskService: deskService,
+	}
+}
+
+// ReorderRequest represents the request payload for reordering work paper items
+type ReorderRequest struct {
+	Items []ReorderItem `json:"items" validate:"required,min=1,dive"`
+}
+
+// ReorderItem is the new position of a single work paper item
+type ReorderItem struct {
+	ID        string `json:"id" validate:"required,uuid"`
+	SortOrder int    `json:"sort_order" validate:"min=0"`
+	// ParentID moves the item under another item when set, and to the top level when empty;
+	// the parent is kept when it is omitted
+	ParentID *string `json:"parent_id,omitempty" validate:"omitnil,uuid|len=0"`
+	// Level overrides the hierarchy level, which otherwise follows the new parent
+	Level *int `json:"level,omitempty" validate:"omitempty,min=1"`
+}
+
+// Execute executes the use case and returns the moved items in their new order
+func (uc *ReorderWorkPaperItemsUseCase) Execute(ctx context.Context, req ReorderRequest) ([]ItemResponse, error) {
+	positions := make([]service.WorkPaperItemPosition, 0, len(req.Items))
+	for _, item := range req.Items {
+		itemID, err := uuid.Parse(item.ID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid work paper item ID %q: %w", item.ID, err)
+		}
+
+		position := service.WorkPaperItemPosition{
+			ID:        itemID,
+			SortOrder: item.SortOrder,
+			Level:     item.Level,
+		}
+		if item.ParentID != nil {
+			position.MoveParent = true
+			if *item.ParentID != "" {
+				parentID, err := uuid.Parse(*item.ParentID)
+				if err != nil {
+					return nil, fmt.Errorf("invalid parent ID %q: %w", *item.ParentID, err)
+				}
+				position.ParentID = &parentID
+			}
+		}
+		positions = append(positions, position)
+	}
+
+	items, err := uc.deskService.ReorderWorkPaperItems(ctx, positions)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]ItemResponse, 0, len(items))
+	for _, item := range items {
+		response := ItemResponse{
+			ID:           item.ID.String(),
+			Type:         item.Type,
+			Number:       item.Number,
+			Statement:    item.Statement,
+			Explanation:  item.Explanation,
+			FillingGuide: item.FillingGuide,
+			Level:        item.Level,
+			SortOrder:    item.SortOrder,
+			IsActive:     item.IsActive,
+			CreatedAt:    item.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			UpdatedAt:    item.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+		if item.ParentID != nil {
+			response.ParentID = item.ParentID.String()
+		}
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
@@ -3,6 +3,7 @@ package work_paper_signature
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"sandbox/internal/domain/entity"
@@ -60,13 +61,12 @@ func (uc *CreateDigitalSignatureUseCase) Execute(request *CreateDigitalSignature
 		return nil, err
 	}
 
-	// Validate signature can be signed
-	if signature.IsRejected() {
-		return nil, ErrCannotSignRejectedSignature
-	}
-
-	if signature.IsSigned() {
-		return nil, entity.ErrAlreadySigned
+	// Validate signature can be signed before creating the digital signature
+	if err := signature.ValidateTransition(entity.SignatureStatusSigned); err != nil {
+		if errors.Is(err, entity.ErrSignatureRejected) {
+			return nil, ErrCannotSignRejectedSignature
+		}
+		return nil, err
 	}
 
 	// Create payload for digital signature
@@ -92,6 +92,15 @@ func (uc *CreateDigitalSignatureUseCase) Execute(request *CreateDigitalSignature
 		signatureResult.Timestamp,
 	)
 
+	// Embed a trusted timestamp when a timestamp authority is configured
+	timestampToken, err := uc.cryptoService.TimestampSignature(ctx, signatureResult.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain trusted timestamp: %w", err)
+	}
+	if timestampToken != nil {
+		digitalSignature.AttachTimestamp(timestampToken.Token, timestampToken.Authority, timestampToken.TrustedTime)
+	}
+
 	// Mark signature as verified (we just created it, so it should be valid)
 	digitalSignature.MarkVerified()
 
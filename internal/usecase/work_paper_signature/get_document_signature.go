@@ -0,0 +1,115 @@
+package work_paper_signature
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/internal/infrastructure/cryptography"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrDocumentSignatureNotFound = errors.New("no digital signature found for document")
+)
+
+// DocumentSignatureRecord is the stored signing metadata of one digital signature on a document
+type DocumentSignatureRecord struct {
+	SignatureID   string `json:"signature_id"`
+	SignerID      string `json:"signer_id"`
+	SignerName    string `json:"signer_name"`
+	SignedAt      string `json:"signed_at"`
+	Algorithm     string `json:"algorithm"`
+	HashAlgorithm string `json:"hash_algorithm"`
+	PayloadHash   string `json:"payload_hash"` // Hex digest of the signed payload
+	PublicKeyID   string `json:"public_key_id"`
+	CertificateID string `json:"certificate_id"`
+	IsValid       bool   `json:"is_valid"`
+	ErrorMessage  string `json:"error_message,omitempty"`
+}
+
+// GetDocumentSignatureResponse lists the digital signatures recorded for a document
+type GetDocumentSignatureResponse struct {
+	DocumentID string                    `json:"document_id"`
+	Signatures []DocumentSignatureRecord `json:"signatures"`
+}
+
+// GetDocumentSignatureUseCase looks up signing records by document (work paper) ID
+type GetDocumentSignatureUseCase struct {
+	workPaperSignatureRepo repository.WorkPaperSignatureRepository
+	cryptoService          *cryptography.DigitalSignatureService
+}
+
+// NewGetDocumentSignatureUseCase creates a new instance of GetDocumentSignatureUseCase
+func NewGetDocumentSignatureUseCase(
+	workPaperSignatureRepo repository.WorkPaperSignatureRepository,
+	cryptoService *cryptography.DigitalSignatureService,
+) *GetDocumentSignatureUseCase {
+	return &GetDocumentSignatureUseCase{
+		workPaperSignatureRepo: workPaperSignatureRepo,
+		cryptoService:          cryptoService,
+	}
+}
+
+// Execute returns the signing metadata and current validity of every digital signature on the document.
+// Verification here is read-only; use the verify endpoint to record the result.
+func (uc *GetDocumentSignatureUseCase) Execute(ctx context.Context, documentID string) (*GetDocumentSignatureResponse, error) {
+	workPaperID, err := uuid.Parse(documentID)
+	if err != nil {
+		return nil, ErrDocumentSignatureNotFound
+	}
+
+	signatures, err := uc.workPaperSignatureRepo.GetSignedSignatures(ctx, workPaperID)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]DocumentSignatureRecord, 0, len(signatures))
+	for _, signature := range signatures {
+		digitalSignature := signature.GetDigitalSignature()
+		if signature.SignatureType != entity.SignatureTypeDigital || digitalSignature == nil {
+			continue
+		}
+
+		record := DocumentSignatureRecord{
+			SignatureID:   signature.ID.String(),
+			SignerID:      signature.UserID,
+			SignerName:    signature.UserName,
+			SignedAt:      digitalSignature.Timestamp.Format(time.RFC3339),
+			Algorithm:     digitalSignature.Algorithm,
+			PublicKeyID:   digitalSignature.PublicKeyID,
+			CertificateID: digitalSignature.CertificateID,
+		}
+
+		if hashAlgorithm, err := cryptography.HashAlgorithmFromSignatureAlgorithm(digitalSignature.Algorithm); err == nil {
+			record.HashAlgorithm = string(hashAlgorithm)
+			if payload, err := base64.StdEncoding.DecodeString(digitalSignature.Payload); err == nil {
+				if sum, err := hashAlgorithm.Sum(payload); err == nil {
+					record.PayloadHash = hex.EncodeToString(sum)
+				}
+			}
+		}
+
+		if _, err := verifyStoredSignature(uc.cryptoService, signature, digitalSignature); err != nil {
+			record.ErrorMessage = err.Error()
+		} else {
+			record.IsValid = true
+		}
+
+		records = append(records, record)
+	}
+
+	if len(records) == 0 {
+		return nil, ErrDocumentSignatureNotFound
+	}
+
+	return &GetDocumentSignatureResponse{
+		DocumentID: documentID,
+		Signatures: records,
+	}, nil
+}
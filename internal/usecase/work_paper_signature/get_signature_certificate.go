@@ -0,0 +1,81 @@
+package work_paper_signature
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/internal/infrastructure/cryptography"
+
+	"github.com/google/uuid"
+)
+
+// ErrNoSigningCertificate is returned when no X.509 certificate is configured for the signing key
+var ErrNoSigningCertificate = errors.New("no signing certificate configured")
+
+// GetSignatureCertificateResponse holds the verification material for a digital signature
+type GetSignatureCertificateResponse struct {
+	CertificateID string
+	PEM           []byte
+	SignedAt      time.Time
+}
+
+// GetSignatureCertificateUseCase returns the certificate used to produce a digital signature
+type GetSignatureCertificateUseCase struct {
+	workPaperSignatureRepo repository.WorkPaperSignatureRepository
+	cryptoService          *cryptography.DigitalSignatureService
+}
+
+// NewGetSignatureCertificateUseCase creates a new instance of GetSignatureCertificateUseCase
+func NewGetSignatureCertificateUseCase(
+	workPaperSignatureRepo repository.WorkPaperSignatureRepository,
+	cryptoService *cryptography.DigitalSignatureService,
+) *GetSignatureCertificateUseCase {
+	return &GetSignatureCertificateUseCase{
+		workPaperSignatureRepo: workPaperSignatureRepo,
+		cryptoService:          cryptoService,
+	}
+}
+
+// Execute loads the PEM X.509 certificate of the key that produced a signed digital signature.
+// Non-digital and unsigned signatures return ErrNoDigitalSignature, and ErrNoSigningCertificate is
+// returned when the signing key has no certificate configured.
+func (uc *GetSignatureCertificateUseCase) Execute(ctx context.Context, workPaperSignatureID string) (*GetSignatureCertificateResponse, error) {
+	signatureID, err := uuid.Parse(workPaperSignatureID)
+	if err != nil {
+		return nil, ErrSignatureNotFound
+	}
+
+	signature, err := uc.workPaperSignatureRepo.GetByID(ctx, signatureID)
+	if err != nil {
+		if errors.Is(err, entity.ErrSignatureNotFound) {
+			return nil, ErrSignatureNotFound
+		}
+		return nil, err
+	}
+
+	if signature.SignatureType != entity.SignatureTypeDigital || !signature.IsSigned() || signature.SignedAt == nil {
+		return nil, ErrNoDigitalSignature
+	}
+
+	digitalSignature := signature.GetDigitalSignature()
+	if digitalSignature == nil {
+		return nil, ErrNoDigitalSignature
+	}
+
+	certificate, err := uc.cryptoService.Certificate()
+	if err != nil {
+		return nil, err
+	}
+	if certificate == nil {
+		return nil, ErrNoSigningCertificate
+	}
+
+	return &GetSignatureCertificateResponse{
+		CertificateID: digitalSignature.CertificateID,
+		PEM:           certificate.PEM,
+		SignedAt:      *signature.SignedAt,
+	}, nil
+}
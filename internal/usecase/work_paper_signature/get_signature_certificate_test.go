@@ -0,0 +1,47 @@
+package work_paper_signature
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+
+	"sandbox/internal/domain/entity"
+)
+
+func TestGetSignatureCertificateReturnsX509Certificate(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	t.Run("certificate", func(t *testing.T) {
+		cryptoService := newCertifiedCryptoService(t, now.Add(-time.Hour), now.Add(time.Hour))
+		signature := newDigitallySignedSignature(t, cryptoService, now)
+		signature.Status, signature.SignedAt = entity.SignatureStatusSigned, &now
+
+		got, err := NewGetSignatureCertificateUseCase(&stubVerifySignatureRepository{signature: signature}, cryptoService).
+			Execute(context.Background(), signature.ID.String())
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		block, _ := pem.Decode(got.PEM)
+		if block == nil || block.Type != "CERTIFICATE" {
+			t.Fatalf("PEM = %q, want a certificate", got.PEM)
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			t.Errorf("ParseCertificate() error = %v", err)
+		}
+	})
+
+	t.Run("no certificate configured", func(t *testing.T) {
+		cryptoService := newCertifiedCryptoService(t, time.Time{}, time.Time{})
+		signature := newDigitallySignedSignature(t, cryptoService, now)
+		signature.Status, signature.SignedAt = entity.SignatureStatusSigned, &now
+
+		_, err := NewGetSignatureCertificateUseCase(&stubVerifySignatureRepository{signature: signature}, cryptoService).
+			Execute(context.Background(), signature.ID.String())
+		if !errors.Is(err, ErrNoSigningCertificate) {
+			t.Errorf("Execute() error = %v, want ErrNoSigningCertificate", err)
+		}
+	})
+}
@@ -0,0 +1,119 @@
+package work_paper_signature
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/pkg/pagination"
+)
+
+var (
+	ErrInvalidSigningLogQuery = errors.New("invalid signing log query")
+)
+
+// signingLogFilterFields maps the public filter names of the signing log to their columns
+var signingLogFilterFields = map[string]string{
+	"user_id":   "user_id",
+	"doc_id":    "work_paper_id",
+	"signed_at": "signed_at",
+}
+
+// signingLogSortFields maps the public sort names of the signing log to their columns
+var signingLogSortFields = map[string]string{
+	"signed_at": "signed_at",
+	"timestamp": "signed_at",
+	"user_id":   "user_id",
+	"doc_id":    "work_paper_id",
+}
+
+// SigningLogEntry is one digital signing event in the signing log
+type SigningLogEntry struct {
+	SignatureID   string `json:"signature_id"`
+	DocID         string `json:"doc_id"`
+	UserID        string `json:"user_id"`
+	UserName      string `json:"user_name"`
+	Algorithm     string `json:"algorithm"`
+	CertificateID string `json:"certificate_id"`
+	Timestamp     string `json:"timestamp"`
+}
+
+// ListSigningLogUseCase lists digital signings as a searchable audit trail
+type ListSigningLogUseCase struct {
+	signatureRepo repository.WorkPaperSignatureRepository
+}
+
+// NewListSigningLogUseCase creates a new instance of ListSigningLogUseCase
+func NewListSigningLogUseCase(signatureRepo repository.WorkPaperSignatureRepository) *ListSigningLogUseCase {
+	return &ListSigningLogUseCase{
+		signatureRepo: signatureRepo,
+	}
+}
+
+// Execute returns a page of the signing log. Only user_id, doc_id and signed_at can be filtered on;
+// results are sorted by signing time, newest first, unless another whitelisted sort is given.
+func (uc *ListSigningLogUseCase) Execute(ctx context.Context, params *pagination.QueryParams) ([]*SigningLogEntry, *pagination.PagedResponse, error) {
+	query := &pagination.QueryParams{Pagination: params.Pagination}
+
+	for _, filter := range params.Filters {
+		column, ok := signingLogFilterFields[filter.Field]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: filtering by %q is not allowed", ErrInvalidSigningLogQuery, filter.Field)
+		}
+		filter.Field = column
+		query.Filters = append(query.Filters, filter)
+	}
+
+	for _, sort := range params.Sorts {
+		column, ok := signingLogSortFields[sort.Field]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: sorting by %q is not allowed", ErrInvalidSigningLogQuery, sort.Field)
+		}
+		sort.Field = column
+		query.Sorts = append(query.Sorts, sort)
+	}
+	if len(query.Sorts) == 0 {
+		query.Sorts = []pagination.Sort{{Field: "signed_at", Order: "desc"}}
+	}
+
+	// The log only contains completed digital signings
+	query.Filters = append(query.Filters,
+		pagination.Filter{Field: "signature_type", Operator: "eq", Value: entity.SignatureTypeDigital},
+		pagination.Filter{Field: "status", Operator: "eq", Value: entity.SignatureStatusSigned},
+	)
+
+	signatures, totalCount, err := uc.signatureRepo.List(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entries := make([]*SigningLogEntry, 0, len(signatures))
+	for _, signature := range signatures {
+		entry := &SigningLogEntry{
+			SignatureID: signature.ID.String(),
+			DocID:       signature.WorkPaperID.String(),
+			UserID:      signature.UserID,
+			UserName:    signature.UserName,
+		}
+		if signature.SignedAt != nil {
+			entry.Timestamp = signature.SignedAt.Format(time.RFC3339)
+		}
+		if digitalSignature := signature.GetDigitalSignature(); digitalSignature != nil {
+			entry.Algorithm = digitalSignature.Algorithm
+			entry.CertificateID = digitalSignature.CertificateID
+		}
+		entries = append(entries, entry)
+	}
+
+	metadata := pagination.BuildMetadata(totalCount, query.Pagination.Page, query.Pagination.Limit)
+
+	return entries, &pagination.PagedResponse{
+		Page:       query.Pagination.Page,
+		Limit:      query.Pagination.Limit,
+		TotalItems: totalCount,
+		TotalPages: metadata.TotalPage,
+	}, nil
+}
@@ -30,16 +30,13 @@ func (uc *ListWorkPaperSignaturesUseCase) Execute(ctx context.Context, params *p
 		responses = append(responses, FromEntity(signature))
 	}
 
-	totalPages := int(totalCount) / params.Pagination.Limit
-	if int(totalCount)%params.Pagination.Limit > 0 {
-		totalPages++
-	}
+	metadata := pagination.BuildMetadata(totalCount, params.Pagination.Page, params.Pagination.Limit)
 
 	return responses, &pagination.PagedResponse{
 		Page:       params.Pagination.Page,
 		Limit:      params.Pagination.Limit,
 		TotalItems: totalCount,
-		TotalPages: totalPages,
+		TotalPages: metadata.TotalPage,
 	}, nil
 }
 
@@ -3,6 +3,7 @@ package work_paper_signature
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"sandbox/internal/domain/entity"
@@ -17,6 +18,13 @@ var (
 	ErrNoDigitalSignature = errors.New("no digital signature found")
 )
 
+// Verification statuses of a digital signature
+const (
+	VerificationStatusValid   = "valid"
+	VerificationStatusWarning = "warning" // The signature matches but its certificate was not valid when signing
+	VerificationStatusInvalid = "invalid"
+)
+
 // VerifyDigitalSignatureRequest represents the request for verifying a digital signature
 type VerifyDigitalSignatureRequest struct {
 	WorkPaperSignatureID string `json:"work_paper_signature_id" validate:"required"`
@@ -64,16 +72,13 @@ func (uc *VerifyDigitalSignatureUseCase) Execute(request *VerifyDigitalSignature
 		return nil, ErrNoDigitalSignature
 	}
 
-	// Create payload for verification
-	payload := &cryptography.SignaturePayload{
-		UserID:               signature.UserID,
-		WorkPaperID:          signature.WorkPaperID.String(),
-		WorkPaperSignatureID: signature.ID.String(),
-		Timestamp:            digitalSignature.Timestamp,
+	// Verify the signature, then its trusted timestamp if one was embedded
+	var trustedTime *string
+	genTime, err := verifyStoredSignature(uc.cryptoService, signature, digitalSignature)
+	if err == nil && genTime != nil {
+		t := genTime.Format(time.RFC3339)
+		trustedTime = &t
 	}
-
-	// Verify the signature
-	err = uc.cryptoService.VerifySignature(digitalSignature.Signature, payload)
 	if err != nil {
 		// Mark signature as verification failed
 		digitalSignature.MarkVerificationFailed(err.Error())
@@ -87,12 +92,23 @@ func (uc *VerifyDigitalSignatureUseCase) Execute(request *VerifyDigitalSignature
 		return &VerifyDigitalSignatureResponse{
 			WorkPaperSignatureID: request.WorkPaperSignatureID,
 			IsValid:              false,
+			Status:               VerificationStatusInvalid,
 			VerifiedAt:           time.Now().Format(time.RFC3339),
 			Algorithm:            digitalSignature.Algorithm,
 			ErrorMessage:         err.Error(),
 		}, nil
 	}
 
+	// The trusted time is the most reliable signing time; older signatures only have server time
+	signedAt := digitalSignature.Timestamp
+	if genTime != nil {
+		signedAt = *genTime
+	}
+	check, err := checkSigningCertificate(uc.cryptoService, signedAt, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
 	// Mark signature as verified
 	digitalSignature.MarkVerified()
 
@@ -103,19 +119,112 @@ func (uc *VerifyDigitalSignatureUseCase) Execute(request *VerifyDigitalSignature
 	}
 
 	return &VerifyDigitalSignatureResponse{
-		WorkPaperSignatureID: request.WorkPaperSignatureID,
-		IsValid:              true,
-		VerifiedAt:           time.Now().Format(time.RFC3339),
-		Algorithm:            digitalSignature.Algorithm,
-		ErrorMessage:         "",
+		WorkPaperSignatureID:      request.WorkPaperSignatureID,
+		IsValid:                   true,
+		Status:                    check.status,
+		VerifiedAt:                time.Now().Format(time.RFC3339),
+		Algorithm:                 digitalSignature.Algorithm,
+		TrustedTime:               trustedTime,
+		TimestampAuthority:        digitalSignature.TimestampAuthority,
+		Certificate:               check.certificate,
+		CertificateValidAtSigning: check.validAtSigning,
+		CertificateCurrentlyValid: check.currentlyValid,
+		Warning:                   check.warning,
+		ErrorMessage:              "",
 	}, nil
 }
 
 // VerifyDigitalSignatureResponse represents the response after verifying a digital signature
 type VerifyDigitalSignatureResponse struct {
-	WorkPaperSignatureID string `json:"work_paper_signature_id"`
-	IsValid              bool   `json:"is_valid"`
-	VerifiedAt           string `json:"verified_at"`
-	Algorithm            string `json:"algorithm"`
-	ErrorMessage         string `json:"error_message,omitempty"`
+	WorkPaperSignatureID string  `json:"work_paper_signature_id"`
+	IsValid              bool    `json:"is_valid"`
+	Status               string  `json:"status"` // valid, warning or invalid
+	VerifiedAt           string  `json:"verified_at"`
+	Algorithm            string  `json:"algorithm"`
+	TrustedTime          *string `json:"trusted_time,omitempty"`        // Time asserted by the timestamp authority
+	TimestampAuthority   string  `json:"timestamp_authority,omitempty"` // Empty when server time was used
+
+	// Certificate fields are omitted when no signing certificate is configured
+	Certificate               *cryptography.CertificateInfo `json:"certificate,omitempty"`
+	CertificateValidAtSigning *bool                         `json:"certificate_valid_at_signing,omitempty"`
+	CertificateCurrentlyValid *bool                         `json:"certificate_currently_valid,omitempty"`
+
+	Warning      string `json:"warning,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+// certificateCheck is the outcome of comparing a signing time against the signing certificate
+type certificateCheck struct {
+	status         string
+	certificate    *cryptography.CertificateInfo
+	validAtSigning *bool
+	currentlyValid *bool
+	warning        string
+}
+
+// checkSigningCertificate compares the signing time and now against the validity window of the
+// signing certificate. A signature made outside the window is downgraded to a warning; a certificate
+// that merely expired after signing keeps the signature valid.
+func checkSigningCertificate(cryptoService *cryptography.DigitalSignatureService, signedAt, now time.Time) (*certificateCheck, error) {
+	certificate, err := cryptoService.Certificate()
+	if err != nil {
+		return nil, err
+	}
+	if certificate == nil {
+		return &certificateCheck{status: VerificationStatusValid}, nil
+	}
+
+	validAtSigning := certificate.ValidAt(signedAt)
+	currentlyValid := certificate.ValidAt(now)
+	check := &certificateCheck{
+		status:         VerificationStatusValid,
+		certificate:    certificate,
+		validAtSigning: &validAtSigning,
+		currentlyValid: &currentlyValid,
+	}
+
+	switch {
+	case signedAt.Before(certificate.NotBefore):
+		check.status = VerificationStatusWarning
+		check.warning = fmt.Sprintf("signed at %s, before the certificate became valid at %s",
+			signedAt.Format(time.RFC3339), certificate.NotBefore.Format(time.RFC3339))
+	case signedAt.After(certificate.NotAfter):
+		check.status = VerificationStatusWarning
+		check.warning = fmt.Sprintf("signed at %s, after the certificate expired at %s",
+			signedAt.Format(time.RFC3339), certificate.NotAfter.Format(time.RFC3339))
+	}
+
+	return check, nil
+}
+
+// verifyStoredSignature checks a stored digital signature against the payload rebuilt from the
+// signature record, using the hash it was created with. It returns the trusted time when a
+// timestamp token is embedded.
+func verifyStoredSignature(cryptoService *cryptography.DigitalSignatureService, signature *entity.WorkPaperSignature, digitalSignature *entity.DigitalSignature) (*time.Time, error) {
+	payload := &cryptography.SignaturePayload{
+		UserID:               signature.UserID,
+		WorkPaperID:          signature.WorkPaperID.String(),
+		WorkPaperSignatureID: signature.ID.String(),
+		Timestamp:            digitalSignature.Timestamp,
+	}
+
+	hashAlgorithm, err := cryptography.HashAlgorithmFromSignatureAlgorithm(digitalSignature.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	payload.SetHashAlgorithm(hashAlgorithm)
+
+	if err := cryptoService.VerifySignature(digitalSignature.Signature, payload); err != nil {
+		return nil, err
+	}
+
+	if !digitalSignature.HasTrustedTimestamp() {
+		return nil, nil
+	}
+
+	genTime, err := cryptoService.VerifySignatureTimestamp(digitalSignature.TimestampToken, digitalSignature.Signature)
+	if err != nil {
+		return nil, err
+	}
+	return &genTime, nil
 }
@@ -0,0 +1,169 @@
+package work_paper_signature
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"sandbox/internal/domain/entity"
+	"sandbox/internal/domain/repository"
+	"sandbox/internal/infrastructure/cryptography"
+)
+
+type stubVerifySignatureRepository struct {
+	repository.WorkPaperSignatureRepository
+	signature *entity.WorkPaperSignature
+}
+
+func (r *stubVerifySignatureRepository) GetByID(context.Context, uuid.UUID) (*entity.WorkPaperSignature, error) {
+	return r.signature, nil
+}
+
+func (r *stubVerifySignatureRepository) Update(context.Context, *entity.WorkPaperSignature) error {
+	return nil
+}
+
+// newCertifiedCryptoService writes a fresh key pair and, unless notBefore is zero, a self-signed
+// certificate with the given validity window, and returns a service using them
+func newCertifiedCryptoService(t *testing.T, notBefore, notAfter time.Time) *cryptography.DigitalSignatureService {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal public key: %v", err)
+	}
+
+	dir := t.TempDir()
+	files := map[string]*pem.Block{
+		"private.pem": {Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)},
+		"public.pem":  {Type: "PUBLIC KEY", Bytes: publicDER},
+	}
+	if !notBefore.IsZero() {
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "Work Paper Signing"},
+			NotBefore:    notBefore,
+			NotAfter:     notAfter,
+		}
+		certificateDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		if err != nil {
+			t.Fatalf("create certificate: %v", err)
+		}
+		files["certificate.pem"] = &pem.Block{Type: "CERTIFICATE", Bytes: certificateDER}
+	}
+	for name, block := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), pem.EncodeToMemory(block), 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	service := cryptography.NewDigitalSignatureService(filepath.Join(dir, "private.pem"), filepath.Join(dir, "public.pem"))
+	if !notBefore.IsZero() {
+		service.SetCertificatePath(filepath.Join(dir, "certificate.pem"))
+	}
+	return service
+}
+
+// newDigitallySignedSignature signs a work paper signature at signedAt with the given service
+func newDigitallySignedSignature(t *testing.T, cryptoService *cryptography.DigitalSignatureService, signedAt time.Time) *entity.WorkPaperSignature {
+	t.Helper()
+
+	signature := &entity.WorkPaperSignature{
+		ID:            uuid.New(),
+		WorkPaperID:   uuid.New(),
+		UserID:        "user-1",
+		SignatureType: entity.SignatureTypeDigital,
+	}
+	result, err := cryptoService.SignPayload(&cryptography.SignaturePayload{
+		UserID:               signature.UserID,
+		WorkPaperID:          signature.WorkPaperID.String(),
+		WorkPaperSignatureID: signature.ID.String(),
+		Timestamp:            signedAt,
+	})
+	if err != nil {
+		t.Fatalf("SignPayload() error = %v", err)
+	}
+
+	signature.AddSignatureData(entity.SignatureData{
+		DigitalSignature: entity.NewDigitalSignature(result.Signature, result.Payload, result.Algorithm, "default", "default", result.Timestamp),
+	})
+	return signature
+}
+
+func TestVerifyDigitalSignatureChecksCertificateWindow(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	signedAt := now.AddDate(0, -1, 0)
+	day := 24 * time.Hour
+	yes, no := true, false
+
+	tests := []struct {
+		name                string
+		notBefore, notAfter time.Time
+		wantStatus          string
+		wantValidAtSigning  *bool
+		wantCurrentlyValid  *bool
+	}{
+		{name: "no certificate", wantStatus: VerificationStatusValid},
+		{name: "valid", notBefore: signedAt.Add(-day), notAfter: now.Add(365 * day),
+			wantStatus: VerificationStatusValid, wantValidAtSigning: &yes, wantCurrentlyValid: &yes},
+		{name: "expired after signing", notBefore: signedAt.Add(-day), notAfter: signedAt.Add(day),
+			wantStatus: VerificationStatusValid, wantValidAtSigning: &yes, wantCurrentlyValid: &no},
+		{name: "expired before signing", notBefore: signedAt.Add(-2 * day), notAfter: signedAt.Add(-day),
+			wantStatus: VerificationStatusWarning, wantValidAtSigning: &no, wantCurrentlyValid: &no},
+		{name: "not yet valid at signing", notBefore: signedAt.Add(day), notAfter: now.Add(365 * day),
+			wantStatus: VerificationStatusWarning, wantValidAtSigning: &no, wantCurrentlyValid: &yes},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cryptoService := newCertifiedCryptoService(t, tt.notBefore, tt.notAfter)
+			signature := newDigitallySignedSignature(t, cryptoService, signedAt)
+			useCase := NewVerifyDigitalSignatureUseCase(&stubVerifySignatureRepository{signature: signature}, cryptoService)
+
+			response, err := useCase.Execute(&VerifyDigitalSignatureRequest{WorkPaperSignatureID: signature.ID.String()})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if !response.IsValid || response.Status != tt.wantStatus {
+				t.Fatalf("is_valid = %v status = %s (%s), want a matching signature with status %s",
+					response.IsValid, response.Status, response.ErrorMessage, tt.wantStatus)
+			}
+			if (response.Warning != "") != (tt.wantStatus == VerificationStatusWarning) {
+				t.Errorf("warning = %q for status %s", response.Warning, response.Status)
+			}
+			assertOptionalBool(t, "certificate_valid_at_signing", response.CertificateValidAtSigning, tt.wantValidAtSigning)
+			assertOptionalBool(t, "certificate_currently_valid", response.CertificateCurrentlyValid, tt.wantCurrentlyValid)
+		})
+	}
+}
+
+func assertOptionalBool(t *testing.T, field string, got, want *bool) {
+	t.Helper()
+	if (got == nil) != (want == nil) || got != nil && *got != *want {
+		t.Errorf("%s = %v, want %v", field, formatOptionalBool(got), formatOptionalBool(want))
+	}
+}
+
+func formatOptionalBool(b *bool) string {
+	if b == nil {
+		return "omitted"
+	}
+	if *b {
+		return "true"
+	}
+	return "false"
+}
@@ -1,12 +1,19 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"sandbox/config"
 	httpRouter "sandbox/internal/delivery/http"
 	"sandbox/internal/delivery/http/middleware"
+	"sandbox/pkg/logging"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -18,29 +25,112 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	// Structured logger used for request-scoped and external client logs
+	slog.SetDefault(slog.New(logging.NewHandler(os.Stdout, cfg.Server.LogFormat, logging.ParseLevel(cfg.Server.LogLevel))))
+
 	// Initialize dependency injection container
 	container := config.NewContainer(cfg)
 
+	// Background jobs stop when the server shuts down
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	// Start the verificator reminder job when a schedule is configured
+	if cfg.BusinessTrip.ReminderIntervalMinutes > 0 {
+		go container.VerificatorReminderService.Run(jobsCtx, time.Duration(cfg.BusinessTrip.ReminderIntervalMinutes)*time.Minute)
+	}
+
 	// Setup Fiber app
 	app := fiber.New(fiber.Config{
 		ErrorHandler: customErrorHandler,
 	})
 
 	// Setup middleware
+	app.Use(middleware.ConfigureCorrelationID())
 	app.Use(middleware.ConfigureLogger())
 	app.Use(middleware.ConfigureRecovery())
-	app.Use(middleware.ConfigureCORS(cfg.CORS.AllowOrigins))
+	app.Use(middleware.ConfigureCORS(middleware.CORSOptions{
+		AllowOrigins:     cfg.CORS.AllowOrigins,
+		AllowMethods:     cfg.CORS.AllowMethods,
+		AllowHeaders:     cfg.CORS.AllowHeaders,
+		ExposeHeaders:    cfg.CORS.ExposeHeaders,
+		AllowCredentials: cfg.CORS.AllowCredentials,
+	}))
 
 	// Setup routes with all handlers
-	httpRouter.SetupRoutes(app, container.TransactionHandler, container.MeetingHandler, container.BusinessTripHandler, container.AssigneeHandler, container.BusinessTripTransactionHandler, container.WorkPaperItemHandler, container.WorkPaperHandler, container.VaccineHandler, container.WorkPaperSignatureHandler, container.BusinessTripDashboardHandler, container.BusinessTripVerificationHandler)
+	httpRouter.SetupRoutes(app, container.TransactionHandler, container.MeetingHandler, container.BusinessTripHandler, container.AssigneeHandler, container.BusinessTripTransactionHandler, container.WorkPaperItemHandler, container.WorkPaperHandler, container.VaccineHandler, container.WorkPaperSignatureHandler, container.BusinessTripDashboardHandler, container.BusinessTripVerificationHandler, container.FeatureFlagHandler, httpRouter.RouteTimeouts{
+		BusinessTrips: time.Duration(cfg.Server.BusinessTripTimeoutSeconds) * time.Second,
+		Signatures:    time.Duration(cfg.Server.SignatureTimeoutSeconds) * time.Second,
+	}, httpRouter.RouteRateLimits{
+		Default: middleware.RateLimit(cfg.RateLimit.Default),
+		LLM:     middleware.RateLimit(cfg.RateLimit.LLM),
+		CDC:     middleware.RateLimit(cfg.RateLimit.CDC),
+	})
 
 	// Start server
 	fmt.Printf("🚀 Server running on port %s\n", cfg.Server.Port)
 	fmt.Printf("📝 Environment: %s\n", "development")
 
-	if err := app.Listen(":" + cfg.Server.Port); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- app.Listen(":" + cfg.Server.Port)
+	}()
+
+	select {
+	case err := <-serverErr:
+		if err != nil {
+			log.Fatalf("Failed to start server: %v", err)
+		}
+		return
+	case sig := <-signals:
+		shutdown(app, container, stopJobs, signals, sig, time.Duration(cfg.Server.ShutdownTimeoutSeconds)*time.Second)
+	}
+}
+
+// shutdown stops accepting connections, waits up to gracePeriod for in-flight requests and the webhooks
+// they dispatched, and then closes the database. Another signal on signals during the shutdown exits
+// immediately.
+func shutdown(app *fiber.App, container *config.Container, stopJobs context.CancelFunc, signals <-chan os.Signal, sig os.Signal, gracePeriod time.Duration) {
+	slog.Info("Shutdown signal received, draining in-flight requests", "signal", sig.String(), "grace_period", gracePeriod.String())
+
+	go func() {
+		sig := <-signals
+		slog.Warn("Second shutdown signal received, exiting immediately", "signal", sig.String())
+		os.Exit(1)
+	}()
+
+	stopJobs()
+	deadline := time.Now().Add(gracePeriod)
+
+	if err := app.ShutdownWithTimeout(gracePeriod); err != nil {
+		slog.Error("HTTP server did not shut down within the grace period", "error", err)
+	} else {
+		slog.Info("HTTP server stopped")
 	}
+
+	// Webhook deliveries record every attempt, so they have to finish before the database is closed
+	webhooksDone := make(chan struct{})
+	go func() {
+		container.BusinessTripWebhookDispatcher.Wait()
+		close(webhooksDone)
+	}()
+	select {
+	case <-webhooksDone:
+		slog.Info("Pending webhooks delivered")
+	case <-time.After(time.Until(deadline)):
+		slog.Error("Webhooks still pending at the end of the grace period, their remaining attempts are dropped")
+	}
+
+	if err := container.DBx.Close(); err != nil {
+		slog.Error("Failed to close database connections", "error", err)
+	} else {
+		slog.Info("Database connections closed")
+	}
+
+	slog.Info("Shutdown complete")
 }
 
 // customErrorHandler handles errors globally
@@ -52,7 +142,8 @@ func customErrorHandler(c *fiber.Ctx, err error) error {
 	}
 
 	return c.Status(code).JSON(fiber.Map{
-		"error": err.Error(),
-		"code":  code,
+		"error":          err.Error(),
+		"code":           code,
+		"correlation_id": logging.CorrelationID(c.UserContext()),
 	})
 }
@@ -0,0 +1,4 @@
+-- Migration: Remove work paper note check history
+-- Description: Drops the work_paper_note_check_history table
+
+DROP TABLE IF EXISTS work_paper_note_check_history;
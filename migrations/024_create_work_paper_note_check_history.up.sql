@@ -0,0 +1,22 @@
+-- Migration: Add work paper note check history
+-- Description: Records every LLM document check so prior verdicts are not lost when last_llm_response is overwritten
+
+CREATE TABLE IF NOT EXISTS work_paper_note_check_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    work_paper_note_id UUID NOT NULL REFERENCES work_paper_notes(id) ON DELETE CASCADE,
+    is_valid BOOLEAN NOT NULL,
+    notes TEXT NULL,
+    model VARCHAR(100) NULL,
+    prompt_tokens INTEGER NOT NULL DEFAULT 0,
+    completion_tokens INTEGER NOT NULL DEFAULT 0,
+    total_tokens INTEGER NOT NULL DEFAULT 0,
+    checked_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_work_paper_note_check_history_note_id_checked_at
+ON work_paper_note_check_history (work_paper_note_id, checked_at DESC);
+
+COMMENT ON TABLE work_paper_note_check_history IS 'History of LLM document checks performed on work paper notes';
+COMMENT ON COLUMN work_paper_note_check_history.is_valid IS 'Verdict returned by the LLM for this check';
+COMMENT ON COLUMN work_paper_note_check_history.notes IS 'Notes returned by the LLM for this check';
+COMMENT ON COLUMN work_paper_note_check_history.model IS 'LLM model used for this check';
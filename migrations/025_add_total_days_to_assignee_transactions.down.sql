@@ -0,0 +1,4 @@
+-- Migration: Remove total_days from assignee transactions
+-- Description: Drops the total_days column used for daily allowance proration
+
+ALTER TABLE assignee_transactions DROP COLUMN IF EXISTS total_days;
@@ -0,0 +1,6 @@
+-- Migration: Add total_days to assignee transactions
+-- Description: Stores the number of days used to prorate daily allowance transactions
+
+ALTER TABLE assignee_transactions ADD COLUMN total_days INTEGER CHECK (total_days >= 0);
+
+COMMENT ON COLUMN assignee_transactions.total_days IS 'Number of days for daily_allowance subtype transactions (subtotal = amount * total_days)';
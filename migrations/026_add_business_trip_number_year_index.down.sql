@@ -0,0 +1,4 @@
+-- Migration: Remove per-year unique index for business trip numbers
+-- Description: Drops the index used by the per_year number scope; recreate the global index first if it was dropped
+
+DROP INDEX IF EXISTS idx_business_trips_business_trip_number_year;
@@ -0,0 +1,8 @@
+-- Migration: Add per-year unique index for business trip numbers
+-- Description: Backs BUSINESS_TRIP_NUMBER_SCOPE=per_year, where the BT-XXXXXX sequence restarts every year.
+-- The global index from migration 004 is kept, so the default global scope is unchanged.
+-- To switch to per_year, drop idx_business_trips_business_trip_number after applying this migration.
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_business_trips_business_trip_number_year
+    ON business_trips (business_trip_number, (EXTRACT(YEAR FROM created_at)))
+    WHERE business_trip_number IS NOT NULL;
@@ -0,0 +1,6 @@
+-- Migration: Remove last_reminded_at from business trip verificators
+-- Description: Drops the reminder tracking column
+
+DROP INDEX IF EXISTS idx_business_trip_verificators_last_reminded_at;
+
+ALTER TABLE business_trip_verificators DROP COLUMN IF EXISTS last_reminded_at;
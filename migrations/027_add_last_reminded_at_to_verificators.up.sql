@@ -0,0 +1,8 @@
+-- Migration: Add last_reminded_at to business trip verificators
+-- Description: Records when a pending verificator was last sent a reminder so they are notified at most once per day
+
+ALTER TABLE business_trip_verificators ADD COLUMN IF NOT EXISTS last_reminded_at TIMESTAMP NULL;
+
+CREATE INDEX IF NOT EXISTS idx_business_trip_verificators_last_reminded_at ON business_trip_verificators(last_reminded_at);
+
+COMMENT ON COLUMN business_trip_verificators.last_reminded_at IS 'Time the verificator was last reminded about a pending verification';
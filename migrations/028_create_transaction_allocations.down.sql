@@ -0,0 +1,6 @@
+-- Migration: Remove transaction allocations
+-- Description: Drops the transaction_allocations table
+
+DROP TRIGGER IF EXISTS update_transaction_allocations_updated_at ON transaction_allocations;
+
+DROP TABLE IF EXISTS transaction_allocations;
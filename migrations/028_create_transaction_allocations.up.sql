@@ -0,0 +1,23 @@
+-- Migration: Create transaction allocations
+-- Description: Splits an assignee transaction across cost centers by percentage
+
+CREATE TABLE IF NOT EXISTS transaction_allocations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    transaction_id UUID NOT NULL REFERENCES assignee_transactions(id) ON DELETE CASCADE,
+    cost_center VARCHAR(100) NOT NULL,
+    percentage NUMERIC(5,2) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE(transaction_id, cost_center),
+    CONSTRAINT chk_transaction_allocation_percentage CHECK (percentage > 0 AND percentage <= 100)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_allocations_transaction_id ON transaction_allocations(transaction_id);
+CREATE INDEX IF NOT EXISTS idx_transaction_allocations_cost_center ON transaction_allocations(cost_center);
+
+CREATE TRIGGER update_transaction_allocations_updated_at BEFORE UPDATE ON transaction_allocations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE transaction_allocations IS 'Cost center splits of assignee transactions; percentages per transaction sum to 100';
+COMMENT ON COLUMN transaction_allocations.cost_center IS 'Cost center code the share is charged to';
+COMMENT ON COLUMN transaction_allocations.percentage IS 'Share of the transaction subtotal charged to the cost center';
@@ -0,0 +1,4 @@
+-- Migration: Remove version from business trips
+-- Description: Drops the optimistic locking version column
+
+ALTER TABLE business_trips DROP COLUMN IF EXISTS version;
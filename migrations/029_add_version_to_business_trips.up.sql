@@ -0,0 +1,6 @@
+-- Migration: Add version to business trips
+-- Description: Adds an optimistic locking version that is incremented on every business trip update
+
+ALTER TABLE business_trips ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+COMMENT ON COLUMN business_trips.version IS 'Optimistic locking version; updates must match it and increment it';
@@ -0,0 +1,19 @@
+-- Migration: Create feature flag overrides
+-- Description: Per-organization overrides of the feature flags configured through FEATURE_FLAGS
+
+CREATE TABLE IF NOT EXISTS feature_flag_overrides (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id VARCHAR(100) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE(organization_id, name)
+);
+
+CREATE TRIGGER update_feature_flag_overrides_updated_at BEFORE UPDATE ON feature_flag_overrides
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE feature_flag_overrides IS 'Per-organization feature flag values that take precedence over the configured defaults';
+COMMENT ON COLUMN feature_flag_overrides.organization_id IS 'Organization ID from the identity service';
+COMMENT ON COLUMN feature_flag_overrides.name IS 'Feature flag name, e.g. require-verificators';
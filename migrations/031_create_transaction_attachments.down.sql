@@ -0,0 +1,4 @@
+-- Migration: Remove transaction attachments
+-- Description: Drops the transaction_attachments table
+
+DROP TABLE IF EXISTS transaction_attachments;
@@ -0,0 +1,18 @@
+-- Migration: Create transaction attachments
+-- Description: Stores receipt files uploaded to the drive for assignee transactions
+
+CREATE TABLE IF NOT EXISTS transaction_attachments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    transaction_id UUID NOT NULL REFERENCES assignee_transactions(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    file_id VARCHAR(255) NOT NULL,
+    storage_url TEXT NOT NULL DEFAULT '',
+    uploaded_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_attachments_transaction_id ON transaction_attachments(transaction_id);
+
+COMMENT ON TABLE transaction_attachments IS 'Receipt files attached to assignee transactions for audit';
+COMMENT ON COLUMN transaction_attachments.file_id IS 'ID of the stored file in Google Drive';
+COMMENT ON COLUMN transaction_attachments.storage_url IS 'Link to view the stored file';
@@ -0,0 +1,6 @@
+-- Migration: Remove organization transaction types
+-- Description: Drops the organization_transaction_types table
+
+DROP TRIGGER IF EXISTS update_organization_transaction_types_updated_at ON organization_transaction_types;
+
+DROP TABLE IF EXISTS organization_transaction_types;
@@ -0,0 +1,19 @@
+-- Migration: Create organization transaction types
+-- Description: Per-organization whitelist of transaction types; organizations without rows accept every type
+
+CREATE TABLE IF NOT EXISTS organization_transaction_types (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id VARCHAR(100) NOT NULL,
+    transaction_type VARCHAR(50) NOT NULL CHECK (transaction_type IN ('accommodation', 'transport', 'other', 'allowance')),
+    transaction_subtype VARCHAR(50) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE(organization_id, transaction_type, transaction_subtype)
+);
+
+CREATE TRIGGER update_organization_transaction_types_updated_at BEFORE UPDATE ON organization_transaction_types
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE organization_transaction_types IS 'Transaction types an organization allows on its business trips';
+COMMENT ON COLUMN organization_transaction_types.organization_id IS 'Organization ID from the identity service';
+COMMENT ON COLUMN organization_transaction_types.transaction_subtype IS 'Allowed subtype, e.g. taxi; empty allows every subtype of the type';
@@ -0,0 +1,6 @@
+-- Migration: Narrow business trip number
+-- Description: Restores VARCHAR(10); fails if numbers longer than 10 characters were issued
+
+ALTER TABLE business_trips ALTER COLUMN business_trip_number TYPE VARCHAR(10);
+
+COMMENT ON COLUMN business_trips.business_trip_number IS 'Auto-generated business trip number in format BT-XXXX';
@@ -0,0 +1,7 @@
+-- Migration: Widen business trip number
+-- Description: Room for configurable number formats such as SPD-2024-0001 (BUSINESS_TRIP_NUMBER_PREFIX/WIDTH).
+-- The generator refuses formats longer than this column, see business_trip_number.MaxNumberLength.
+
+ALTER TABLE business_trips ALTER COLUMN business_trip_number TYPE VARCHAR(30);
+
+COMMENT ON COLUMN business_trips.business_trip_number IS 'Auto-generated business trip number, BT-000001 unless a custom format is configured';
@@ -0,0 +1,4 @@
+-- Migration: Remove signature audit log
+-- Description: Drops the signature_audit_log table
+
+DROP TABLE IF EXISTS signature_audit_log;
@@ -0,0 +1,21 @@
+-- Migration: Create signature audit log
+-- Description: Records every signer added, removed or preserved by a work paper signer management action
+
+CREATE TABLE IF NOT EXISTS signature_audit_log (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    work_paper_id UUID NOT NULL REFERENCES work_papers(id) ON DELETE CASCADE,
+    signature_id UUID,
+    signer_user_id VARCHAR(255) NOT NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('add', 'remove', 'replace')),
+    outcome VARCHAR(20) NOT NULL CHECK (outcome IN ('added', 'removed', 'preserved')),
+    actor_id VARCHAR(255) NOT NULL DEFAULT '',
+    actor_name VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_signature_audit_log_work_paper_id ON signature_audit_log (work_paper_id, created_at);
+
+COMMENT ON TABLE signature_audit_log IS 'Audit trail of signer management actions on work papers';
+COMMENT ON COLUMN signature_audit_log.action IS 'Signer management action that was requested: add, remove or replace';
+COMMENT ON COLUMN signature_audit_log.outcome IS 'What the action did to this signer: added, removed or preserved';
+COMMENT ON COLUMN signature_audit_log.actor_id IS 'User ID of the caller who performed the action';
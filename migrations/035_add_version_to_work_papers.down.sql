@@ -0,0 +1,5 @@
+-- Migration: Remove version from work papers and notes
+-- Description: Drops the optimistic locking version columns
+
+ALTER TABLE work_paper_notes DROP COLUMN IF EXISTS version;
+ALTER TABLE work_papers DROP COLUMN IF EXISTS version;
@@ -0,0 +1,8 @@
+-- Migration: Add version to work papers and notes
+-- Description: Adds an optimistic locking version that is incremented on every work paper and work paper note update
+
+ALTER TABLE work_papers ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE work_paper_notes ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+COMMENT ON COLUMN work_papers.version IS 'Optimistic locking version; updates must match it and increment it';
+COMMENT ON COLUMN work_paper_notes.version IS 'Optimistic locking version; updates must match it and increment it';
@@ -0,0 +1,5 @@
+-- Migration: Drop country and vaccine aliases
+-- Description: Drops the alias tables used to resolve CDC recommendations
+
+DROP TABLE IF EXISTS vaccine_aliases;
+DROP TABLE IF EXISTS country_aliases;
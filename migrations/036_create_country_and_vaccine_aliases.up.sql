@@ -0,0 +1,208 @@
+-- Migration: Create country and vaccine aliases
+-- Description: Moves the country and vaccine name mappings used to resolve CDC recommendations out of the code
+
+CREATE TABLE IF NOT EXISTS country_aliases (
+    alias VARCHAR(100) PRIMARY KEY,
+    country_name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS vaccine_aliases (
+    alias VARCHAR(100) PRIMARY KEY,
+    vaccine_name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE country_aliases IS 'Lowercase country codes and names mapped to the full country name stored in countries';
+COMMENT ON TABLE vaccine_aliases IS 'Lowercase vaccine names mapped to the vaccine name stored in master_vaccines';
+
+INSERT INTO country_aliases (alias, country_name) VALUES
+    ('jpn', 'Japan'),
+    ('idn', 'Indonesia'),
+    ('mys', 'Malaysia'),
+    ('tha', 'Thailand'),
+    ('vnm', 'Vietnam'),
+    ('phl', 'Philippines'),
+    ('sgp', 'Singapore'),
+    ('chn', 'China'),
+    ('kor', 'South Korea'),
+    ('ind', 'India'),
+    ('usa', 'United States'),
+    ('gbr', 'United Kingdom'),
+    ('deu', 'Germany'),
+    ('fra', 'France'),
+    ('ita', 'Italy'),
+    ('esp', 'Spain'),
+    ('nld', 'Netherlands'),
+    ('aus', 'Australia'),
+    ('can', 'Canada'),
+    ('bra', 'Brazil'),
+    ('arg', 'Argentina'),
+    ('mex', 'Mexico'),
+    ('sau', 'Saudi Arabia'),
+    ('are', 'United Arab Emirates'),
+    ('egy', 'Egypt'),
+    ('zaf', 'South Africa'),
+    ('tur', 'Turkey'),
+    ('isr', 'Israel'),
+    ('mmr', 'Myanmar'),
+    ('khm', 'Cambodia'),
+    ('lao', 'Laos'),
+    ('brn', 'Brunei Darussalam'),
+    ('hkg', 'Hong Kong'),
+    ('twn', 'Taiwan'),
+    ('pak', 'Pakistan'),
+    ('bgd', 'Bangladesh'),
+    ('lka', 'Sri Lanka'),
+    ('mdv', 'Maldives'),
+    ('qat', 'Qatar'),
+    ('che', 'Switzerland'),
+    ('aut', 'Austria'),
+    ('bel', 'Belgium'),
+    ('swe', 'Sweden'),
+    ('chl', 'Chile'),
+    ('per', 'Peru'),
+    ('col', 'Colombia'),
+    ('mar', 'Morocco'),
+    ('ken', 'Kenya'),
+    ('tza', 'Tanzania'),
+    ('nzl', 'New Zealand'),
+    ('png', 'Papua New Guinea'),
+    ('fji', 'Fiji'),
+    ('jp', 'Japan'),
+    ('id', 'Indonesia'),
+    ('my', 'Malaysia'),
+    ('th', 'Thailand'),
+    ('vn', 'Vietnam'),
+    ('ph', 'Philippines'),
+    ('sg', 'Singapore'),
+    ('cn', 'China'),
+    ('kr', 'South Korea'),
+    ('in', 'India'),
+    ('us', 'United States'),
+    ('gb', 'United Kingdom'),
+    ('de', 'Germany'),
+    ('fr', 'France'),
+    ('it', 'Italy'),
+    ('es', 'Spain'),
+    ('nl', 'Netherlands'),
+    ('au', 'Australia'),
+    ('ca', 'Canada'),
+    ('br', 'Brazil'),
+    ('ar', 'Argentina'),
+    ('mx', 'Mexico'),
+    ('sa', 'Saudi Arabia'),
+    ('ae', 'United Arab Emirates'),
+    ('eg', 'Egypt'),
+    ('za', 'South Africa'),
+    ('tr', 'Turkey'),
+    ('il', 'Israel'),
+    ('mm', 'Myanmar'),
+    ('kh', 'Cambodia'),
+    ('la', 'Laos'),
+    ('bn', 'Brunei Darussalam'),
+    ('hk', 'Hong Kong'),
+    ('tw', 'Taiwan'),
+    ('pk', 'Pakistan'),
+    ('bd', 'Bangladesh'),
+    ('lk', 'Sri Lanka'),
+    ('mv', 'Maldives'),
+    ('qa', 'Qatar'),
+    ('ch', 'Switzerland'),
+    ('at', 'Austria'),
+    ('be', 'Belgium'),
+    ('se', 'Sweden'),
+    ('cl', 'Chile'),
+    ('pe', 'Peru'),
+    ('co', 'Colombia'),
+    ('ma', 'Morocco'),
+    ('ke', 'Kenya'),
+    ('tz', 'Tanzania'),
+    ('nz', 'New Zealand'),
+    ('pg', 'Papua New Guinea'),
+    ('fj', 'Fiji'),
+    ('japan', 'Japan'),
+    ('indonesia', 'Indonesia'),
+    ('malaysia', 'Malaysia'),
+    ('thailand', 'Thailand'),
+    ('vietnam', 'Vietnam'),
+    ('philippines', 'Philippines'),
+    ('singapore', 'Singapore'),
+    ('china', 'China'),
+    ('south korea', 'South Korea'),
+    ('korea', 'South Korea'),
+    ('india', 'India'),
+    ('united states', 'United States'),
+    ('america', 'United States'),
+    ('uk', 'United Kingdom'),
+    ('united kingdom', 'United Kingdom'),
+    ('england', 'United Kingdom'),
+    ('germany', 'Germany'),
+    ('france', 'France'),
+    ('italy', 'Italy'),
+    ('spain', 'Spain'),
+    ('netherlands', 'Netherlands'),
+    ('holland', 'Netherlands'),
+    ('australia', 'Australia'),
+    ('canada', 'Canada'),
+    ('brazil', 'Brazil'),
+    ('argentina', 'Argentina'),
+    ('mexico', 'Mexico'),
+    ('saudi arabia', 'Saudi Arabia'),
+    ('united arab emirates', 'United Arab Emirates'),
+    ('uae', 'United Arab Emirates'),
+    ('egypt', 'Egypt'),
+    ('south africa', 'South Africa'),
+    ('turkey', 'Turkey'),
+    ('israel', 'Israel'),
+    ('myanmar', 'Myanmar'),
+    ('cambodia', 'Cambodia'),
+    ('laos', 'Laos'),
+    ('brunei', 'Brunei Darussalam'),
+    ('hong kong', 'Hong Kong'),
+    ('taiwan', 'Taiwan'),
+    ('pakistan', 'Pakistan'),
+    ('bangladesh', 'Bangladesh'),
+    ('sri lanka', 'Sri Lanka'),
+    ('maldives', 'Maldives'),
+    ('qatar', 'Qatar'),
+    ('switzerland', 'Switzerland'),
+    ('austria', 'Austria'),
+    ('belgium', 'Belgium'),
+    ('sweden', 'Sweden'),
+    ('chile', 'Chile'),
+    ('peru', 'Peru'),
+    ('colombia', 'Colombia'),
+    ('morocco', 'Morocco'),
+    ('kenya', 'Kenya'),
+    ('tanzania', 'Tanzania'),
+    ('new zealand', 'New Zealand'),
+    ('papua new guinea', 'Papua New Guinea'),
+    ('fiji', 'Fiji')
+ON CONFLICT (alias) DO NOTHING;
+
+INSERT INTO vaccine_aliases (alias, vaccine_name) VALUES
+    ('hepatitis a', 'Hepatitis A'),
+    ('hepatitis b', 'Hepatitis B'),
+    ('typhoid', 'Typhoid'),
+    ('yellow fever', 'Yellow Fever'),
+    ('rabies', 'Rabies'),
+    ('meningitis', 'Meningococcal'),
+    ('meningococcal', 'Meningococcal'),
+    ('cholera', 'Cholera'),
+    ('japanese encephalitis', 'Japanese Encephalitis'),
+    ('influenza', 'Influenza'),
+    ('flu', 'Influenza'),
+    ('mmr', 'MMR'),
+    ('measles', 'MMR'),
+    ('mumps', 'MMR'),
+    ('rubella', 'MMR'),
+    ('tetanus', 'Tetanus'),
+    ('diphtheria', 'Diphtheria'),
+    ('pertussis', 'Pertussis'),
+    ('polio', 'Polio'),
+    ('covid', 'COVID-19'),
+    ('covid-19', 'COVID-19')
+ON CONFLICT (alias) DO NOTHING;
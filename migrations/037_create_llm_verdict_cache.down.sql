@@ -0,0 +1,4 @@
+-- Migration: Drop LLM verdict cache
+-- Description: Drops the cache of LLM verdicts
+
+DROP TABLE IF EXISTS llm_verdict_cache;
@@ -0,0 +1,20 @@
+-- Migration: Create LLM verdict cache
+-- Description: Reuses the LLM verdict for a document set that was already checked against the same work paper item
+
+CREATE TABLE IF NOT EXISTS llm_verdict_cache (
+    document_hash CHAR(64) NOT NULL,
+    item_hash CHAR(64) NOT NULL,
+    is_valid BOOLEAN NOT NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    model VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (document_hash, item_hash)
+);
+
+CREATE INDEX IF NOT EXISTS idx_llm_verdict_cache_expires_at ON llm_verdict_cache (expires_at);
+
+COMMENT ON TABLE llm_verdict_cache IS 'LLM verdicts keyed by the content of the checked documents and the work paper item they were checked against';
+COMMENT ON COLUMN llm_verdict_cache.document_hash IS 'SHA-256 over the sorted SHA-256 hashes of the checked documents';
+COMMENT ON COLUMN llm_verdict_cache.item_hash IS 'SHA-256 of the number, statement, explanation and filling guide of the work paper item';
+COMMENT ON COLUMN llm_verdict_cache.expires_at IS 'The verdict is no longer reused after this time';
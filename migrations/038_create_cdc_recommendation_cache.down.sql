@@ -0,0 +1,4 @@
+-- Migration: Drop CDC recommendation cache
+-- Description: Drops the cache of vaccine recommendations extracted from the CDC website
+
+DROP TABLE IF EXISTS cdc_recommendation_cache;
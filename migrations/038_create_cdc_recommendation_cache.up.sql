@@ -0,0 +1,14 @@
+-- Migration: Create CDC recommendation cache
+-- Description: Stores the vaccine recommendations extracted from the CDC website so they are not fetched and extracted on every request
+
+CREATE TABLE IF NOT EXISTS cdc_recommendation_cache (
+    country_code VARCHAR(100) NOT NULL,
+    language VARCHAR(10) NOT NULL,
+    recommendation JSONB NOT NULL,
+    fetched_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (country_code, language)
+);
+
+COMMENT ON TABLE cdc_recommendation_cache IS 'Vaccine recommendations extracted from the CDC website per requested country and language';
+COMMENT ON COLUMN cdc_recommendation_cache.country_code IS 'Lowercase country code or name as requested';
+COMMENT ON COLUMN cdc_recommendation_cache.fetched_at IS 'When the recommendation was fetched from the CDC website';
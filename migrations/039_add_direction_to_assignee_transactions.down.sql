@@ -0,0 +1,5 @@
+-- Migration: Remove direction from assignee transactions
+-- Description: Drops the debit/credit direction of transactions
+
+ALTER TABLE assignee_transactions DROP CONSTRAINT IF EXISTS chk_assignee_transaction_direction;
+ALTER TABLE assignee_transactions DROP COLUMN IF EXISTS direction;
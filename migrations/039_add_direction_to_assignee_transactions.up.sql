@@ -0,0 +1,7 @@
+-- Migration: Add direction to assignee transactions
+-- Description: Marks transactions as debit (a cost) or credit (a refund) so refunds can be recorded and subtracted from trip totals
+
+ALTER TABLE assignee_transactions ADD COLUMN direction VARCHAR(10) NOT NULL DEFAULT 'debit';
+ALTER TABLE assignee_transactions ADD CONSTRAINT chk_assignee_transaction_direction CHECK (direction IN ('debit', 'credit'));
+
+COMMENT ON COLUMN assignee_transactions.direction IS 'debit adds the subtotal to the trip cost, credit (a refund) subtracts it';
@@ -0,0 +1,4 @@
+-- Migration: Drop webhook deliveries
+-- Description: Drops the log of webhook delivery attempts
+
+DROP TABLE IF EXISTS webhook_deliveries;
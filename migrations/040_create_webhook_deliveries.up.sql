@@ -0,0 +1,24 @@
+-- Migration: Create webhook deliveries
+-- Description: Records every attempt to deliver a business trip status webhook to a receiver
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id UUID NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    business_trip_id UUID NOT NULL,
+    url TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    attempt INTEGER NOT NULL,
+    status_code INTEGER,
+    error TEXT NOT NULL DEFAULT '',
+    succeeded BOOLEAN NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_webhook_deliveries_event_id ON webhook_deliveries (event_id, attempt);
+CREATE INDEX idx_webhook_deliveries_business_trip_id ON webhook_deliveries (business_trip_id, created_at);
+
+COMMENT ON TABLE webhook_deliveries IS 'One row per attempt to POST a webhook event to a configured URL';
+COMMENT ON COLUMN webhook_deliveries.event_id IS 'ID of the event, sent in the payload; retries of the same event share it so receivers can ignore duplicates';
+COMMENT ON COLUMN webhook_deliveries.attempt IS 'Attempt number for this event and URL, starting at 1';
+COMMENT ON COLUMN webhook_deliveries.status_code IS 'HTTP status returned by the receiver, NULL when no response was received';
@@ -0,0 +1,6 @@
+-- Migration: Drop unique SPD number per business trip
+-- Description: Restores the table constraint from 001, which also counts soft deleted assignees
+
+DROP INDEX IF EXISTS uq_assignees_business_trip_spd_number;
+
+ALTER TABLE assignees ADD CONSTRAINT assignees_business_trip_id_spd_number_key UNIQUE (business_trip_id, spd_number);
@@ -0,0 +1,11 @@
+-- Migration: Add unique SPD number per business trip
+-- Description: Rejects a second active assignee with the same SPD number on a business trip, so concurrent
+-- requests cannot both pass the application check. Soft deleted assignees do not count. The table constraint
+-- from 001 also counted them and is replaced by the partial index. Existing duplicates must be resolved
+-- before this migration can run.
+
+ALTER TABLE assignees DROP CONSTRAINT IF EXISTS assignees_business_trip_id_spd_number_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_assignees_business_trip_spd_number
+    ON assignees(business_trip_id, spd_number)
+    WHERE deleted_at IS NULL;
@@ -0,0 +1,4 @@
+-- Migration: Drop verificator status history
+-- Description: Drops the log of verificator status changes
+
+DROP TABLE IF EXISTS verificator_status_history;
@@ -0,0 +1,18 @@
+-- Migration: Create verificator status history
+-- Description: Records every verificator status change so auditors can see who approved or rejected a business trip and when
+
+CREATE TABLE IF NOT EXISTS verificator_status_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    verificator_id UUID NOT NULL REFERENCES business_trip_verificators(id) ON DELETE CASCADE,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    changed_by VARCHAR(100),
+    changed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_verificator_status_history_verificator_id ON verificator_status_history (verificator_id, changed_at);
+
+COMMENT ON TABLE verificator_status_history IS 'One row per verificator status change, written in the same transaction as the change';
+COMMENT ON COLUMN verificator_status_history.notes IS 'Verification notes given with the change';
+COMMENT ON COLUMN verificator_status_history.changed_by IS 'User ID of whoever made the change, NULL when unknown';
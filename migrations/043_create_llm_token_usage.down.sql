@@ -0,0 +1,4 @@
+-- Migration: Drop LLM token usage
+-- Description: Drops the monthly LLM token usage
+
+DROP TABLE IF EXISTS llm_token_usage;
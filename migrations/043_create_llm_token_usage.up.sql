@@ -0,0 +1,12 @@
+-- Migration: Create LLM token usage
+-- Description: Tracks the Gemini tokens spent on document checks per calendar month, to enforce a monthly budget
+
+CREATE TABLE IF NOT EXISTS llm_token_usage (
+    month DATE PRIMARY KEY,
+    total_tokens BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE llm_token_usage IS 'Cumulative LLM token usage of document checks per calendar month';
+COMMENT ON COLUMN llm_token_usage.month IS 'First day of the month (UTC) the tokens were spent in';
+COMMENT ON COLUMN llm_token_usage.total_tokens IS 'Sum of the total tokens reported by every successful LLM response in the month';
@@ -0,0 +1,6 @@
+-- Migration: Remove currency from assignee transactions
+-- Description: Drops the currency and exchange rate of transactions
+
+ALTER TABLE assignee_transactions DROP CONSTRAINT IF EXISTS chk_assignee_transaction_exchange_rate;
+ALTER TABLE assignee_transactions DROP COLUMN IF EXISTS exchange_rate;
+ALTER TABLE assignee_transactions DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,9 @@
+-- Migration: Add currency to assignee transactions
+-- Description: Records the currency a transaction was paid in and its exchange rate to the base currency, so costs of international trips can be entered as paid
+
+ALTER TABLE assignee_transactions ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'IDR';
+ALTER TABLE assignee_transactions ADD COLUMN exchange_rate DECIMAL(18,6) NOT NULL DEFAULT 1;
+ALTER TABLE assignee_transactions ADD CONSTRAINT chk_assignee_transaction_exchange_rate CHECK (exchange_rate > 0);
+
+COMMENT ON COLUMN assignee_transactions.currency IS 'ISO 4217 code of the currency amount was paid in';
+COMMENT ON COLUMN assignee_transactions.exchange_rate IS 'Rate converting amount to the base currency; subtotal is amount * exchange_rate * nights or days';
@@ -0,0 +1,3 @@
+-- Migration: Soft delete transactions of deleted assignees
+-- Description: Nothing to undo; the transactions deleted by the up migration cannot be told apart from
+-- transactions deleted together with their assignee afterwards
@@ -0,0 +1,11 @@
+-- Migration: Soft delete transactions of deleted assignees
+-- Description: Deleting an assignee now deletes its transactions with the same deleted_at. Transactions of
+-- assignees deleted before still count in cost totals, so they are deleted with their assignee's deleted_at,
+-- which also lets those assignees be restored together with their transactions.
+
+UPDATE assignee_transactions t
+SET deleted_at = a.deleted_at
+FROM assignees a
+WHERE t.assignee_id = a.id
+    AND a.deleted_at IS NOT NULL
+    AND t.deleted_at IS NULL;
@@ -0,0 +1,5 @@
+-- Migration: Remove meeting from business trips
+-- Description: Drops the link between business trips and Zoom meetings
+
+DROP INDEX IF EXISTS idx_business_trips_meeting_id;
+ALTER TABLE business_trips DROP COLUMN IF EXISTS meeting_id;
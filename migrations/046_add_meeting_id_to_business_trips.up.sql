@@ -0,0 +1,11 @@
+-- Migration: Add meeting to business trips
+-- Description: Links a business trip to the Zoom meeting it was made to attend. Meetings are only kept in
+-- Zoom, so meeting_id holds the Zoom meeting ID and is checked against Zoom when the link is made.
+
+ALTER TABLE business_trips ADD COLUMN meeting_id VARCHAR(32);
+
+CREATE INDEX IF NOT EXISTS idx_business_trips_meeting_id
+    ON business_trips(meeting_id)
+    WHERE meeting_id IS NOT NULL AND deleted_at IS NULL;
+
+COMMENT ON COLUMN business_trips.meeting_id IS 'Zoom meeting ID of the meeting the trip is made to attend';
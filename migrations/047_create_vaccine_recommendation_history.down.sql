@@ -0,0 +1,4 @@
+-- Migration: Drop vaccine recommendation history
+-- Description: Drops the log of vaccine recommendations served
+
+DROP TABLE IF EXISTS vaccine_recommendation_history;
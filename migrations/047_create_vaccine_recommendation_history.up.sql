@@ -0,0 +1,30 @@
+-- Migration: Create vaccine recommendation history
+-- Description: Keeps every vaccine recommendation served, so a traveler can see the health guidance that was current when a trip was planned
+
+CREATE TABLE IF NOT EXISTS vaccine_recommendation_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    country_code VARCHAR(100) NOT NULL,
+    country_name VARCHAR(255) NOT NULL DEFAULT '',
+    language VARCHAR(10) NOT NULL,
+    business_trip_id UUID REFERENCES business_trips(id) ON DELETE CASCADE,
+    assignee_id UUID REFERENCES assignees(id) ON DELETE CASCADE,
+    required_vaccine_ids UUID[] NOT NULL DEFAULT '{}',
+    recommended_vaccine_ids UUID[] NOT NULL DEFAULT '{}',
+    consider_vaccine_ids UUID[] NOT NULL DEFAULT '{}',
+    malaria_risk TEXT NOT NULL DEFAULT '',
+    malaria_prophylaxis TEXT NOT NULL DEFAULT '',
+    generated_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_vaccine_recommendation_history_business_trip_id
+    ON vaccine_recommendation_history (business_trip_id, created_at)
+    WHERE business_trip_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_vaccine_recommendation_history_assignee_id
+    ON vaccine_recommendation_history (assignee_id, created_at)
+    WHERE assignee_id IS NOT NULL;
+
+COMMENT ON TABLE vaccine_recommendation_history IS 'One row per vaccine recommendation served, optionally for a business trip or assignee';
+COMMENT ON COLUMN vaccine_recommendation_history.country_code IS 'Country code or name as requested';
+COMMENT ON COLUMN vaccine_recommendation_history.generated_at IS 'When the recommendation was fetched from the CDC website';
+COMMENT ON COLUMN vaccine_recommendation_history.created_at IS 'When the recommendation was served';
@@ -0,0 +1,4 @@
+-- Migration: Drop unique master vaccine English names
+-- Description: Allows master vaccines to share an English name again
+
+DROP INDEX IF EXISTS idx_master_vaccines_vaccine_name_en_unique;
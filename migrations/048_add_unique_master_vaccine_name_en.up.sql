@@ -0,0 +1,25 @@
+-- Migration: Make master vaccine English names unique
+-- Description: The English name is the natural key of the master vaccine import, so re-importing a vaccine updates it instead of adding a duplicate.
+-- Vaccines whose English names differ only in case are not merged here, because requirements and recommendation history
+-- point at them by ID; the migration stops and lists them so they can be merged by hand first.
+
+DO $$
+DECLARE
+    duplicates TEXT;
+BEGIN
+    SELECT string_agg(names, '; ')
+    INTO duplicates
+    FROM (
+        SELECT LOWER(vaccine_name_en) || ' (' || string_agg(vaccine_code || ': ' || vaccine_name_en, ', ' ORDER BY vaccine_code) || ')' AS names
+        FROM master_vaccines
+        GROUP BY LOWER(vaccine_name_en)
+        HAVING COUNT(*) > 1
+    ) groups;
+
+    IF duplicates IS NOT NULL THEN
+        RAISE EXCEPTION 'master_vaccines has English names that differ only in case, merge them before making the name unique: %', duplicates;
+    END IF;
+END $$;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_master_vaccines_vaccine_name_en_unique
+    ON master_vaccines (LOWER(vaccine_name_en));
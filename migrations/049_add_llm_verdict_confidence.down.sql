@@ -0,0 +1,6 @@
+-- Migration: Drop confidence and missing items from LLM verdict cache
+-- Description: Cached verdicts keep only their validity and notes again
+
+ALTER TABLE llm_verdict_cache
+    DROP COLUMN IF EXISTS missing_items,
+    DROP COLUMN IF EXISTS confidence;
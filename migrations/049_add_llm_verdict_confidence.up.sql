@@ -0,0 +1,9 @@
+-- Migration: Add confidence and missing items to LLM verdict cache
+-- Description: Keeps the structured parts of the LLM answer with the cached verdict
+
+ALTER TABLE llm_verdict_cache
+    ADD COLUMN IF NOT EXISTS confidence DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS missing_items TEXT[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN llm_verdict_cache.confidence IS 'Confidence of the model in the verdict from 0 to 1, NULL when its answer was not the requested JSON';
+COMMENT ON COLUMN llm_verdict_cache.missing_items IS 'Requirements the model found unmet by the documents';
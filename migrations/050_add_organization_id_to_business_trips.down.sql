@@ -0,0 +1,4 @@
+-- Migration: Remove organization from business trips
+-- Description: Drops the organization recorded when a business trip was created
+
+ALTER TABLE business_trips DROP COLUMN IF EXISTS organization_id;
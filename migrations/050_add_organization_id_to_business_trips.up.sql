@@ -0,0 +1,8 @@
+-- Migration: Add organization to business trips
+-- Description: Records the organization of the user who created a business trip, so its transaction type
+-- restrictions apply whoever edits the trip later. Trips created before this migration keep NULL and are
+-- checked against the organization of the caller, as before.
+
+ALTER TABLE business_trips ADD COLUMN organization_id VARCHAR(100);
+
+COMMENT ON COLUMN business_trips.organization_id IS 'Organization ID from the identity service of the user who created the trip';
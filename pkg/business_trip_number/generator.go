@@ -3,18 +3,129 @@ package business_trip_number
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Scope controls the range in which business trip numbers must be unique
+type Scope string
+
+const (
+	// ScopeGlobal keeps a single sequence across all years (BT-000001 is issued once)
+	ScopeGlobal Scope = "global"
+	// ScopePerYear restarts the sequence every year, keyed on the trip's created_at year
+	ScopePerYear Scope = "per_year"
+)
+
+// Unique indexes backing each scope, see migrations 004 and 026
+const (
+	globalIndexName  = "idx_business_trips_business_trip_number"
+	perYearIndexName = "idx_business_trips_business_trip_number_year"
 )
 
+// maxGenerateAttempts bounds the collision retry so a corrupt sequence cannot loop forever
+const maxGenerateAttempts = 10
+
+// MaxNumberLength is the length of the business_trips.business_trip_number column, see migration 033
+const MaxNumberLength = 30
+
+// YearToken is replaced with the year a number is issued in when it appears in a prefix
+const YearToken = "{YYYY}"
+
+// ErrNumberTooLong is returned when a rendered number would not fit the business_trip_number column
+var ErrNumberTooLong = errors.New("business trip number exceeds the column length")
+
+// Format controls how business trip numbers are rendered: Prefix followed by the sequence, zero-padded
+// to Width digits. Each rendered prefix has its own sequence, so a prefix containing YearToken restarts
+// numbering every year. A sequence outgrowing Width continues with more digits instead of wrapping.
+type Format struct {
+	Prefix string
+	Width  int
+}
+
+// DefaultFormat renders numbers as BT-000001
+var DefaultFormat = Format{Prefix: "BT-", Width: 6}
+
+// Validate checks that the format renders numbers of Width digits that fit the column
+func (f Format) Validate() error {
+	if f.Width < 1 {
+		return fmt.Errorf("invalid business trip number width %d: must be at least 1", f.Width)
+	}
+	if length := utf8.RuneCountInString(f.prefix(9999)) + f.Width; length > MaxNumberLength {
+		return fmt.Errorf("%w: prefix %q with %d digits renders %d characters, the column allows %d",
+			ErrNumberTooLong, f.Prefix, f.Width, length, MaxNumberLength)
+	}
+	return nil
+}
+
+// Render returns the number for sequence seq issued in year
+func (f Format) Render(year, seq int) (string, error) {
+	return fitColumn(fmt.Sprintf("%s%0*d", f.prefix(year), f.Width, seq))
+}
+
+// RenderRandom returns a number with a random suffix of Width characters, for when no sequence is available
+func (f Format) RenderRandom(year int) (string, error) {
+	suffix := strings.ReplaceAll(uuid.New().String(), "-", "")
+	if f.Width < len(suffix) {
+		suffix = suffix[:f.Width]
+	}
+	return fitColumn(f.prefix(year) + suffix)
+}
+
+// prefix renders the prefix for numbers issued in year
+func (f Format) prefix(year int) string {
+	return strings.ReplaceAll(f.Prefix, YearToken, strconv.Itoa(year))
+}
+
+// pattern is a regular expression matching every number of the sequence for year
+func (f Format) pattern(year int) string {
+	return fmt.Sprintf("^%s[0-9]{%d,}$", regexp.QuoteMeta(f.prefix(year)), f.Width)
+}
+
+func fitColumn(number string) (string, error) {
+	if length := utf8.RuneCountInString(number); length > MaxNumberLength {
+		return "", fmt.Errorf("%w: %s is %d characters, the column allows %d", ErrNumberTooLong, number, length, MaxNumberLength)
+	}
+	return number, nil
+}
+
+// ParseScope converts a configuration value into a Scope, defaulting to global when empty
+func ParseScope(value string) (Scope, error) {
+	switch Scope(value) {
+	case "", ScopeGlobal:
+		return ScopeGlobal, nil
+	case ScopePerYear:
+		return ScopePerYear, nil
+	default:
+		return "", fmt.Errorf("invalid business trip number scope %q: must be %q or %q", value, ScopeGlobal, ScopePerYear)
+	}
+}
+
 // Generator handles business trip number generation
 type Generator struct {
-	db *sql.DB
+	db     *sql.DB
+	scope  Scope
+	format Format
 }
 
-// NewGenerator creates a new business trip number generator
-func NewGenerator(db *sql.DB) *Generator {
-	return &Generator{db: db}
+// NewGenerator creates a new business trip number generator for the given uniqueness scope and format.
+// A zero format uses DefaultFormat.
+func NewGenerator(db *sql.DB, scope Scope, format Format) *Generator {
+	if scope == "" {
+		scope = ScopeGlobal
+	}
+	if format == (Format{}) {
+		format = DefaultFormat
+	}
+	return &Generator{db: db, scope: scope, format: format}
 }
 
 // SetDatabase allows updating the database connection (useful for testing)
@@ -22,7 +133,18 @@ func (g *Generator) SetDatabase(db *sql.DB) {
 	g.db = db
 }
 
-// GenerateNextNumber generates the next business trip number in format BT-XXXXXX
+// Scope returns the uniqueness scope used by the generator
+func (g *Generator) Scope() Scope {
+	return g.scope
+}
+
+// Format returns the format the generator renders numbers in
+func (g *Generator) Format() Format {
+	return g.format
+}
+
+// GenerateNextNumber generates the next business trip number in the configured format, BT-000001 by default.
+// With ScopePerYear the sequence and collision check only consider trips created in the current year.
 func (g *Generator) GenerateNextNumber(ctx context.Context) (string, error) {
 	// Start a transaction for atomic operation
 	tx, err := g.db.BeginTx(ctx, nil)
@@ -31,49 +153,129 @@ func (g *Generator) GenerateNextNumber(ctx context.Context) (string, error) {
 	}
 	defer tx.Rollback()
 
-	// Get the current maximum sequence number
+	nextNumber, err := g.findNextNumber(ctx, tx, time.Now().Year())
+	if err != nil {
+		return "", err
+	}
+
+	// Commit the transaction
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nextNumber, nil
+}
+
+// PeekNextNumber returns the number GenerateNextNumber would issue for trips created in year, without
+// reserving it. The result is advisory only: a concurrent create may take the number first.
+// year is ignored with ScopeGlobal since the sequence never restarts.
+func (g *Generator) PeekNextNumber(ctx context.Context, year int) (string, error) {
+	return g.findNextNumber(ctx, g.db, year)
+}
+
+type rowQueryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// findNextNumber returns the first free number after the highest one issued in the scope
+func (g *Generator) findNextNumber(ctx context.Context, q rowQueryer, year int) (string, error) {
+	maxFilter, scopeArgs := g.scopeFilter(3, year)
+	checkFilter, _ := g.scopeFilter(2, year)
+
+	// Get the current maximum sequence number among the numbers sharing this year's prefix
 	var maxSeq int
 	query := `
-		SELECT COALESCE(MAX(CAST(SUBSTRING(business_trip_number FROM 4) AS INTEGER)), 0)
+		SELECT COALESCE(MAX(CAST(SUBSTRING(business_trip_number FROM $1) AS BIGINT)), 0)
 		FROM business_trips
-		WHERE business_trip_number LIKE 'BT-%'
-		AND business_trip_number ~ '^BT-[0-9]{6}$'
+		WHERE business_trip_number ~ $2
 		AND deleted_at IS NULL
-	`
+	` + maxFilter
 
-	err = tx.QueryRowContext(ctx, query).Scan(&maxSeq)
+	sequenceStart := utf8.RuneCountInString(g.format.prefix(year)) + 1
+	maxArgs := append([]interface{}{sequenceStart, g.format.pattern(year)}, scopeArgs...)
+	err := q.QueryRowContext(ctx, query, maxArgs...).Scan(&maxSeq)
 	if err != nil && err != sql.ErrNoRows {
 		return "", fmt.Errorf("failed to get max sequence number: %w", err)
 	}
 
-	// Increment the sequence
-	nextSeq := maxSeq + 1
-	nextNumber := fmt.Sprintf("BT-%06d", nextSeq)
-
-	// Verify this number doesn't exist (double-check)
-	var exists bool
+	// Verify the number doesn't exist within the scope (double-check), moving on to the next on collision
 	checkQuery := `
 		SELECT EXISTS(
 			SELECT 1 FROM business_trips
 			WHERE business_trip_number = $1
 			AND deleted_at IS NULL
+	` + checkFilter + `
 		)
 	`
-	err = tx.QueryRowContext(ctx, checkQuery, nextNumber).Scan(&exists)
+
+	for attempt := 1; attempt <= maxGenerateAttempts; attempt++ {
+		nextNumber, err := g.format.Render(year, maxSeq+attempt)
+		if err != nil {
+			return "", err
+		}
+
+		var exists bool
+		err = q.QueryRowContext(ctx, checkQuery, append([]interface{}{nextNumber}, scopeArgs...)...).Scan(&exists)
+		if err != nil {
+			return "", fmt.Errorf("failed to check number existence: %w", err)
+		}
+
+		if exists {
+			log.Printf("Business trip number %s already exists in %s scope, trying next", nextNumber, g.scope)
+			continue
+		}
+
+		return nextNumber, nil
+	}
+
+	return "", fmt.Errorf("failed to find a free business trip number after %d attempts", maxGenerateAttempts)
+}
+
+// ValidateConstraint checks that the database unique index matches the configured scope.
+// Global scope needs the global index; per-year scope needs the per-year index and must not
+// keep the global one, otherwise numbers reused in a new year would be rejected.
+func (g *Generator) ValidateConstraint(ctx context.Context) error {
+	hasGlobal, err := g.indexExists(ctx, globalIndexName)
+	if err != nil {
+		return err
+	}
+	hasPerYear, err := g.indexExists(ctx, perYearIndexName)
 	if err != nil {
-		return "", fmt.Errorf("failed to check number existence: %w", err)
+		return err
 	}
 
-	if exists {
-		// If by some chance it exists, try again recursively
-		log.Printf("Business trip number %s already exists, trying next", nextNumber)
-		return g.GenerateNextNumber(ctx)
+	switch g.scope {
+	case ScopeGlobal:
+		if !hasGlobal {
+			return fmt.Errorf("business trip number scope is %q but unique index %s is missing", g.scope, globalIndexName)
+		}
+	case ScopePerYear:
+		if !hasPerYear {
+			return fmt.Errorf("business trip number scope is %q but unique index %s is missing", g.scope, perYearIndexName)
+		}
+		if hasGlobal {
+			return fmt.Errorf("business trip number scope is %q but global unique index %s still exists; drop it to allow numbers to restart each year", g.scope, globalIndexName)
+		}
+	default:
+		return fmt.Errorf("unsupported business trip number scope %q", g.scope)
 	}
 
-	// Commit the transaction
-	if err := tx.Commit(); err != nil {
-		return "", fmt.Errorf("failed to commit transaction: %w", err)
+	return nil
+}
+
+func (g *Generator) indexExists(ctx context.Context, name string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM pg_indexes WHERE tablename = 'business_trips' AND indexname = $1)`
+	if err := g.db.QueryRowContext(ctx, query, name).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check index %s: %w", name, err)
 	}
+	return exists, nil
+}
 
-	return nextNumber, nil
+// scopeFilter returns the extra WHERE condition for the scope using placeholder $n
+func (g *Generator) scopeFilter(n, year int) (string, []interface{}) {
+	if g.scope == ScopePerYear {
+		return fmt.Sprintf("\t\tAND EXTRACT(YEAR FROM created_at) = $%d\n", n), []interface{}{year}
+	}
+	return "", nil
 }
@@ -0,0 +1,192 @@
+package business_trip_number
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// numbersDriver is a minimal database/sql driver answering the generator's queries from an in-memory
+// list of issued numbers
+type numbersDriver struct {
+	mu      sync.Mutex
+	numbers []string
+}
+
+func (d *numbersDriver) Open(string) (driver.Conn, error) { return &numbersConn{driver: d}, nil }
+
+type numbersConn struct{ driver *numbersDriver }
+
+func (c *numbersConn) Prepare(string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("prepare not supported")
+}
+func (c *numbersConn) Close() error { return nil }
+func (c *numbersConn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("transactions not supported")
+}
+
+func (c *numbersConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.driver.mu.Lock()
+	defer c.driver.mu.Unlock()
+
+	if strings.Contains(query, "EXISTS") {
+		exists := false
+		for _, number := range c.driver.numbers {
+			exists = exists || number == args[0].Value
+		}
+		return &singleValueRows{value: exists}, nil
+	}
+
+	// SUBSTRING(business_trip_number FROM $1) of the numbers matching $2
+	start := int(args[0].Value.(int64))
+	pattern := regexp.MustCompile(args[1].Value.(string))
+	maxSeq := int64(0)
+	for _, number := range c.driver.numbers {
+		if !pattern.MatchString(number) {
+			continue
+		}
+		seq, err := strconv.ParseInt(number[start-1:], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		if seq > maxSeq {
+			maxSeq = seq
+		}
+	}
+	return &singleValueRows{value: maxSeq}, nil
+}
+
+type singleValueRows struct {
+	value driver.Value
+	done  bool
+}
+
+func (r *singleValueRows) Columns() []string { return []string{"value"} }
+func (r *singleValueRows) Close() error      { return nil }
+
+func (r *singleValueRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	dest[0] = r.value
+	r.done = true
+	return nil
+}
+
+var registerOnce sync.Once
+var testDriver = &numbersDriver{}
+
+func newTestGenerator(t *testing.T, format Format, numbers ...string) *Generator {
+	t.Helper()
+	registerOnce.Do(func() { sql.Register("business-trip-numbers", testDriver) })
+	testDriver.numbers = numbers
+
+	db, err := sql.Open("business-trip-numbers", "")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return NewGenerator(db, ScopeGlobal, format)
+}
+
+func TestPeekNextNumberDefaultFormat(t *testing.T) {
+	g := newTestGenerator(t, Format{}, "BT-000041", "BT-000042", "BT-abc123")
+
+	got, err := g.PeekNextNumber(context.Background(), 2024)
+	if err != nil {
+		t.Fatalf("PeekNextNumber() error = %v", err)
+	}
+	if got != "BT-000043" {
+		t.Errorf("PeekNextNumber() = %s, want BT-000043", got)
+	}
+}
+
+func TestPeekNextNumberYearRollover(t *testing.T) {
+	format := Format{Prefix: "SPD-{YYYY}-", Width: 4}
+	g := newTestGenerator(t, format, "SPD-2024-0001", "SPD-2024-0002", "SPD-2024-0003")
+
+	got, err := g.PeekNextNumber(context.Background(), 2024)
+	if err != nil {
+		t.Fatalf("PeekNextNumber(2024) error = %v", err)
+	}
+	if got != "SPD-2024-0004" {
+		t.Errorf("PeekNextNumber(2024) = %s, want SPD-2024-0004", got)
+	}
+
+	got, err = g.PeekNextNumber(context.Background(), 2025)
+	if err != nil {
+		t.Fatalf("PeekNextNumber(2025) error = %v", err)
+	}
+	if got != "SPD-2025-0001" {
+		t.Errorf("PeekNextNumber(2025) = %s, want the sequence to restart at SPD-2025-0001", got)
+	}
+}
+
+func TestPeekNextNumberOutgrowsWidth(t *testing.T) {
+	format := Format{Prefix: "SPD-{YYYY}-", Width: 4}
+	g := newTestGenerator(t, format, "SPD-2024-0001", "SPD-2024-9999")
+
+	got, err := g.PeekNextNumber(context.Background(), 2024)
+	if err != nil {
+		t.Fatalf("PeekNextNumber() error = %v", err)
+	}
+	if got != "SPD-2024-10000" {
+		t.Errorf("PeekNextNumber() = %s, want SPD-2024-10000 instead of wrapping back to an issued number", got)
+	}
+
+	// The wider number is part of the same sequence
+	testDriver.numbers = append(testDriver.numbers, got)
+	got, err = g.PeekNextNumber(context.Background(), 2024)
+	if err != nil {
+		t.Fatalf("PeekNextNumber() error = %v", err)
+	}
+	if got != "SPD-2024-10001" {
+		t.Errorf("PeekNextNumber() = %s, want SPD-2024-10001", got)
+	}
+}
+
+func TestPeekNextNumberExceedingColumn(t *testing.T) {
+	format := Format{Prefix: "DIVISION-{YYYY}-TRIP-", Width: 4}
+	g := newTestGenerator(t, format, "DIVISION-2024-TRIP-99999999999")
+
+	if _, err := g.PeekNextNumber(context.Background(), 2024); !errors.Is(err, ErrNumberTooLong) {
+		t.Errorf("PeekNextNumber() error = %v, want ErrNumberTooLong", err)
+	}
+}
+
+func TestFormatValidate(t *testing.T) {
+	tests := []struct {
+		format  Format
+		wantErr bool
+	}{
+		{DefaultFormat, false},
+		{Format{Prefix: "SPD-{YYYY}-", Width: 4}, false},
+		{Format{Prefix: "BT-", Width: 0}, true},
+		{Format{Prefix: "A-VERY-LONG-DIVISION-{YYYY}-", Width: 6}, true},
+	}
+
+	for _, tt := range tests {
+		if err := tt.format.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%+v) error = %v, wantErr %v", tt.format, err, tt.wantErr)
+		}
+	}
+}
+
+func TestRenderRandomFitsFormat(t *testing.T) {
+	got, err := DefaultFormat.RenderRandom(2024)
+	if err != nil {
+		t.Fatalf("RenderRandom() error = %v", err)
+	}
+	if !strings.HasPrefix(got, "BT-") || len(got) != len("BT-")+DefaultFormat.Width {
+		t.Errorf("RenderRandom() = %s, want BT- followed by %d characters", got, DefaultFormat.Width)
+	}
+}
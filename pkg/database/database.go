@@ -39,6 +39,39 @@ type DB interface {
 	UnderlyingDB() *sql.DB
 }
 
+// TxBeginner starts database transactions
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (DBTx, error)
+}
+
+// WithinTx runs fn inside a transaction. The transaction is committed when fn returns nil and
+// rolled back when fn returns an error or panics; a panic is re-raised after the rollback.
+func WithinTx(ctx context.Context, db TxBeginner, fn func(tx DBTx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("transaction failed: %v, rollback failed: %w", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
 // NewDB creates a new DB instance from sqlx.DB
 func NewDB(db *sqlx.DB) DB {
 	return &dbWrapper{db: db}
@@ -87,26 +120,9 @@ func (w *dbWrapper) BeginTx(ctx context.Context, opts *sql.TxOptions) (DBTx, err
 }
 
 func (w *dbWrapper) WithTransaction(ctx context.Context, fn func(ctx context.Context, tx DBTx) error) error {
-	tx, err := w.BeginTx(ctx, nil)
-	if err != nil {
-		return err
-	}
-
-	defer func() {
-		if p := recover(); p != nil {
-			tx.Rollback()
-			panic(p)
-		}
-	}()
-
-	if err := fn(ctx, tx); err != nil {
-		if rbErr := tx.Rollback(); rbErr != nil {
-			return fmt.Errorf("transaction failed: %v, rollback failed: %w", err, rbErr)
-		}
-		return err
-	}
-
-	return tx.Commit()
+	return WithinTx(ctx, w, func(tx DBTx) error {
+		return fn(ctx, tx)
+	})
 }
 
 func (w *dbWrapper) UnderlyingDB() *sql.DB {
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+// fakeTx records whether it was committed or rolled back
+type fakeTx struct {
+	DBTx
+	committed  bool
+	rolledBack bool
+}
+
+func (t *fakeTx) Commit() error {
+	t.committed = true
+	return nil
+}
+
+func (t *fakeTx) Rollback() error {
+	t.rolledBack = true
+	return nil
+}
+
+type fakeBeginner struct {
+	tx *fakeTx
+}
+
+func (b *fakeBeginner) BeginTx(context.Context, *sql.TxOptions) (DBTx, error) {
+	b.tx = &fakeTx{}
+	return b.tx, nil
+}
+
+func TestWithinTxCommitsOnSuccess(t *testing.T) {
+	db := &fakeBeginner{}
+
+	err := WithinTx(context.Background(), db, func(tx DBTx) error {
+		if tx != db.tx {
+			t.Error("callback did not receive the started transaction")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithinTx() error = %v", err)
+	}
+
+	if !db.tx.committed || db.tx.rolledBack {
+		t.Errorf("committed = %v, rolledBack = %v, want committed only", db.tx.committed, db.tx.rolledBack)
+	}
+}
+
+func TestWithinTxRollsBackOnError(t *testing.T) {
+	db := &fakeBeginner{}
+	wantErr := errors.New("boom")
+
+	err := WithinTx(context.Background(), db, func(DBTx) error {
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("WithinTx() error = %v, want %v", err, wantErr)
+	}
+
+	if db.tx.committed || !db.tx.rolledBack {
+		t.Errorf("committed = %v, rolledBack = %v, want rolled back only", db.tx.committed, db.tx.rolledBack)
+	}
+}
+
+func TestWithinTxRollsBackOnPanic(t *testing.T) {
+	db := &fakeBeginner{}
+
+	defer func() {
+		if p := recover(); p != "boom" {
+			t.Errorf("recovered %v, want the original panic to be re-raised", p)
+		}
+		if db.tx.committed || !db.tx.rolledBack {
+			t.Errorf("committed = %v, rolledBack = %v, want rolled back only", db.tx.committed, db.tx.rolledBack)
+		}
+	}()
+
+	_ = WithinTx(context.Background(), db, func(DBTx) error {
+		panic("boom")
+	})
+}
@@ -0,0 +1,143 @@
+package featureflag
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Name identifies a feature flag
+type Name string
+
+// Known feature flags. Every flag used by the application is declared here so GET /flags can list it.
+const (
+	// StrictIdentityValidation rejects new business trips whose assignees are unknown to the user service
+	StrictIdentityValidation Name = "strict-identity-validation"
+	// RequireVerificators prevents a business trip from being submitted for verification without verificators
+	RequireVerificators Name = "require-verificators"
+)
+
+// Known returns every declared flag with its built-in default
+func Known() map[Name]bool {
+	return map[Name]bool{
+		StrictIdentityValidation: false,
+		RequireVerificators:      false,
+	}
+}
+
+// OrganizationIDKey is the key the HTTP middleware stores the caller's organization ID under in the
+// request locals; fiber's request context resolves Value(OrganizationIDKey) to it
+const OrganizationIDKey = "organization_id"
+
+type organizationIDContextKey struct{}
+
+// WithOrganizationID returns a copy of ctx carrying the organization the flags are evaluated for
+func WithOrganizationID(ctx context.Context, organizationID string) context.Context {
+	return context.WithValue(ctx, organizationIDContextKey{}, organizationID)
+}
+
+// OrganizationID returns the organization ID carried by ctx, or "" when there is none
+func OrganizationID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if id, ok := ctx.Value(organizationIDContextKey{}).(string); ok {
+		return id
+	}
+	if id, ok := ctx.Value(OrganizationIDKey).(string); ok {
+		return id
+	}
+	return ""
+}
+
+// OverrideStore loads the per-organization flag overrides
+type OverrideStore interface {
+	GetOverrides(ctx context.Context, organizationID string) (map[Name]bool, error)
+}
+
+// Service evaluates feature flags from configured defaults and optional per-organization overrides
+type Service struct {
+	defaults map[Name]bool
+	store    OverrideStore
+}
+
+// NewService creates a flag service. defaults are layered over the built-in defaults of the known
+// flags; store may be nil to disable per-organization overrides.
+func NewService(defaults map[Name]bool, store OverrideStore) *Service {
+	merged := Known()
+	for name, enabled := range defaults {
+		merged[name] = enabled
+	}
+	return &Service{defaults: merged, store: store}
+}
+
+// Enabled reports whether the flag is on for the organization in ctx.
+// Override lookup failures fall back to the configured default so a database hiccup never flips behavior.
+func (s *Service) Enabled(ctx context.Context, name Name) bool {
+	return s.Effective(ctx)[name]
+}
+
+// Effective returns every flag with its value for the organization in ctx
+func (s *Service) Effective(ctx context.Context) map[Name]bool {
+	flags := make(map[Name]bool, len(s.defaults))
+	for name, enabled := range s.defaults {
+		flags[name] = enabled
+	}
+
+	organizationID := OrganizationID(ctx)
+	if s.store == nil || organizationID == "" || organizationID == uuid.Nil.String() {
+		return flags
+	}
+
+	overrides, err := s.store.GetOverrides(ctx, organizationID)
+	if err != nil {
+		log.Printf("Failed to load feature flag overrides for organization %s: %v", organizationID, err)
+		return flags
+	}
+	for name, enabled := range overrides {
+		// Overrides of flags that were removed from the code are ignored
+		if _, known := flags[name]; known {
+			flags[name] = enabled
+		}
+	}
+
+	return flags
+}
+
+// ParseDefaults parses "name=true" entries from configuration. A bare name enables the flag.
+func ParseDefaults(entries []string) (map[Name]bool, error) {
+	defaults := make(map[Name]bool, len(entries))
+	for _, entry := range entries {
+		name, value, hasValue := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+
+		enabled := true
+		if hasValue {
+			parsed, err := strconv.ParseBool(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for feature flag %q: %w", name, err)
+			}
+			enabled = parsed
+		}
+
+		if _, ok := Known()[Name(name)]; !ok {
+			return nil, fmt.Errorf("unknown feature flag %q, known flags: %s", name, strings.Join(knownNames(), ", "))
+		}
+		defaults[Name(name)] = enabled
+	}
+	return defaults, nil
+}
+
+func knownNames() []string {
+	var names []string
+	for name := range Known() {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	return names
+}
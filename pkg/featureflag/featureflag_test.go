@@ -0,0 +1,115 @@
+package featureflag
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// stubOverrideStore serves fixed overrides per organization and counts lookups
+type stubOverrideStore struct {
+	overrides map[string]map[Name]bool
+	err       error
+	lookups   int
+}
+
+func (s *stubOverrideStore) GetOverrides(_ context.Context, organizationID string) (map[Name]bool, error) {
+	s.lookups++
+	return s.overrides[organizationID], s.err
+}
+
+func TestEnabledResolvesOrganizationOverrides(t *testing.T) {
+	store := &stubOverrideStore{overrides: map[string]map[Name]bool{
+		"org-strict":  {StrictIdentityValidation: true},
+		"org-relaxed": {RequireVerificators: false},
+	}}
+	flags := NewService(map[Name]bool{RequireVerificators: true}, store)
+
+	tests := []struct {
+		name             string
+		ctx              context.Context
+		wantStrict       bool
+		wantVerificators bool
+	}{
+		{"no organization", context.Background(), false, true},
+		{"nil organization", WithOrganizationID(context.Background(), uuid.Nil.String()), false, true},
+		{"organization without overrides", WithOrganizationID(context.Background(), "org-other"), false, true},
+		{"override enables a flag", WithOrganizationID(context.Background(), "org-strict"), true, true},
+		{"override disables a configured default", WithOrganizationID(context.Background(), "org-relaxed"), false, false},
+		{"organization from request locals", context.WithValue(context.Background(), OrganizationIDKey, "org-strict"), true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := flags.Enabled(tt.ctx, StrictIdentityValidation); got != tt.wantStrict {
+				t.Errorf("Enabled(%s) = %v, want %v", StrictIdentityValidation, got, tt.wantStrict)
+			}
+			if got := flags.Enabled(tt.ctx, RequireVerificators); got != tt.wantVerificators {
+				t.Errorf("Enabled(%s) = %v, want %v", RequireVerificators, got, tt.wantVerificators)
+			}
+		})
+	}
+}
+
+func TestEnabledFallsBackToDefaultsWhenOverridesFail(t *testing.T) {
+	store := &stubOverrideStore{
+		overrides: map[string]map[Name]bool{"org-1": {RequireVerificators: false}},
+		err:       errors.New("connection refused"),
+	}
+	flags := NewService(map[Name]bool{RequireVerificators: true}, store)
+
+	if !flags.Enabled(WithOrganizationID(context.Background(), "org-1"), RequireVerificators) {
+		t.Error("failed override lookup changed the configured default")
+	}
+}
+
+func TestEnabledSkipsStoreWithoutOrganization(t *testing.T) {
+	store := &stubOverrideStore{}
+	flags := NewService(nil, store)
+
+	flags.Enabled(context.Background(), StrictIdentityValidation)
+	flags.Enabled(WithOrganizationID(context.Background(), uuid.Nil.String()), StrictIdentityValidation)
+
+	if store.lookups != 0 {
+		t.Errorf("override store was queried %d times, want none", store.lookups)
+	}
+}
+
+func TestUnknownFlags(t *testing.T) {
+	const unknown Name = "removed-flag"
+	store := &stubOverrideStore{overrides: map[string]map[Name]bool{"org-1": {unknown: true}}}
+	flags := NewService(nil, store)
+	ctx := WithOrganizationID(context.Background(), "org-1")
+
+	if flags.Enabled(ctx, unknown) {
+		t.Errorf("Enabled(%s) = true, want false for a flag the code does not declare", unknown)
+	}
+
+	effective := flags.Effective(ctx)
+	if _, listed := effective[unknown]; listed {
+		t.Errorf("Effective() lists the unknown flag %s", unknown)
+	}
+	if len(effective) != len(Known()) {
+		t.Errorf("Effective() has %d flags, want the %d known ones", len(effective), len(Known()))
+	}
+
+	if _, err := ParseDefaults([]string{string(unknown)}); err == nil {
+		t.Errorf("ParseDefaults(%q) error = nil, want an unknown flag error", unknown)
+	}
+}
+
+func TestParseDefaults(t *testing.T) {
+	defaults, err := ParseDefaults([]string{"strict-identity-validation", " require-verificators = false "})
+	if err != nil {
+		t.Fatalf("ParseDefaults() error = %v", err)
+	}
+	if !defaults[StrictIdentityValidation] || defaults[RequireVerificators] {
+		t.Errorf("ParseDefaults() = %v, want strict identity validation on and require verificators off", defaults)
+	}
+
+	if _, err := ParseDefaults([]string{"require-verificators=maybe"}); err == nil {
+		t.Error("ParseDefaults() with an invalid value error = nil, want an error")
+	}
+}
@@ -0,0 +1,156 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// CorrelationIDHeader is the request/response header carrying the correlation ID
+const CorrelationIDHeader = "X-Correlation-ID"
+
+// RequestIDHeader is accepted as the correlation ID when a caller does not send X-Correlation-ID
+const RequestIDHeader = "X-Request-ID"
+
+// CorrelationIDKey is the key the HTTP middleware stores the correlation ID under in the
+// request locals; fiber's request context resolves Value(CorrelationIDKey) to it
+const CorrelationIDKey = "correlation_id"
+
+type correlationIDContextKey struct{}
+
+// WithCorrelationID returns a copy of ctx carrying the correlation ID
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDContextKey{}, correlationID)
+}
+
+// CorrelationID returns the correlation ID carried by ctx, or "" when there is none
+func CorrelationID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if id, ok := ctx.Value(correlationIDContextKey{}).(string); ok {
+		return id
+	}
+	if id, ok := ctx.Value(CorrelationIDKey).(string); ok {
+		return id
+	}
+	return ""
+}
+
+// FromContext returns the default logger tagged with the request's correlation ID, if any
+func FromContext(ctx context.Context) *slog.Logger {
+	logger := slog.Default()
+	if id := CorrelationID(ctx); id != "" {
+		logger = logger.With(slog.String(CorrelationIDKey, id))
+	}
+	return logger
+}
+
+// Transport is an http.RoundTripper that logs the metadata of every outgoing request at debug
+// level: method, host, path, status and duration. Query strings, headers and bodies are never
+// logged since they may carry API keys or document content.
+type Transport struct {
+	// Service names the external system in the log entries, e.g. "zoom"
+	Service string
+	// Base is the underlying transport; http.DefaultTransport when nil
+	Base http.RoundTripper
+}
+
+// NewHTTPClient returns an http.Client with the given timeout whose requests are logged by Transport
+func NewHTTPClient(service string, timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &Transport{Service: service},
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+
+	attrs := []any{
+		slog.String("service", t.Service),
+		slog.String("method", req.Method),
+		slog.String("host", req.URL.Host),
+		slog.String("path", req.URL.Path),
+		slog.Duration("duration", time.Since(start)),
+	}
+	logger := FromContext(req.Context())
+	if err != nil {
+		logger.ErrorContext(req.Context(), "external request failed", append(attrs, slog.String("error", ErrorMessage(err)))...)
+		return resp, err
+	}
+
+	logger.DebugContext(req.Context(), "external request", append(attrs, slog.Int("status", resp.StatusCode))...)
+	return resp, nil
+}
+
+// LogCall logs the outcome of an external call that does not go through Transport, such as an SDK call
+func LogCall(ctx context.Context, service, operation string, start time.Time, err error) {
+	attrs := []any{
+		slog.String("service", service),
+		slog.String("operation", operation),
+		slog.Duration("duration", time.Since(start)),
+	}
+	logger := FromContext(ctx)
+	if err != nil {
+		logger.ErrorContext(ctx, "external call failed", append(attrs, slog.String("error", ErrorMessage(err)))...)
+		return
+	}
+	logger.DebugContext(ctx, "external call", attrs...)
+}
+
+// ErrorMessage returns the message of err for logging. The query string, fragment and password of a
+// URL reported by a *url.Error are removed, since API clients may pass keys as query parameters.
+func ErrorMessage(err error) string {
+	msg := err.Error()
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && urlErr.URL != "" {
+		msg = strings.ReplaceAll(msg, urlErr.URL, redactURL(urlErr.URL))
+	}
+	return msg
+}
+
+// redactURL strips everything from a URL that may carry a credential
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		if i := strings.IndexAny(raw, "?#"); i >= 0 {
+			return raw[:i]
+		}
+		return raw
+	}
+	u.RawQuery = ""
+	u.ForceQuery = false
+	u.Fragment = ""
+	return u.Redacted()
+}
+
+// NewHandler returns the slog handler for LOG_FORMAT: json, or text for any other value
+func NewHandler(w io.Writer, format string, level slog.Level) slog.Handler {
+	opts := &slog.HandlerOptions{Level: level}
+	if format == "json" {
+		return slog.NewJSONHandler(w, opts)
+	}
+	return slog.NewTextHandler(w, opts)
+}
+
+// ParseLevel converts a LOG_LEVEL value (debug, info, warn, error) into a slog.Level, defaulting to info
+func ParseLevel(value string) slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(value)); err != nil {
+		return slog.LevelInfo
+	}
+	return level
+}
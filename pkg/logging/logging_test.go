@@ -0,0 +1,142 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testAPIKey = "AIzaSy-secret-test-key"
+
+// captureLogs routes the default logger to a buffer for the duration of the test
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestErrorMessageRemovesURLQuery(t *testing.T) {
+	err := fmt.Errorf("failed to call Gemini API: %w", &url.Error{
+		Op:  "Post",
+		URL: "https://generativelanguage.googleapis.com/v1beta/models/gemini-2.5-flash:generateContent?key=" + testAPIKey,
+		Err: context.DeadlineExceeded,
+	})
+
+	got := ErrorMessage(err)
+	want := `failed to call Gemini API: Post "https://generativelanguage.googleapis.com/v1beta/models/gemini-2.5-flash:generateContent": context deadline exceeded`
+	if got != want {
+		t.Errorf("ErrorMessage() = %q, want %q", got, want)
+	}
+	if plain := ErrorMessage(errors.New("invalid response")); plain != "invalid response" {
+		t.Errorf("ErrorMessage() = %q, want the message unchanged", plain)
+	}
+}
+
+func TestFailedCallsDoNotLogAPIKey(t *testing.T) {
+	logs := captureLogs(t)
+
+	client := &http.Client{Transport: &Transport{
+		Service: "gemini",
+		Base: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, &url.Error{Op: "Post", URL: req.URL.String(), Err: errors.New("connection reset by peer")}
+		}),
+	}}
+	_, err := client.Post("https://generativelanguage.googleapis.com/v1beta/models/m:generateContent?key="+testAPIKey, "application/json", nil)
+	if err == nil {
+		t.Fatal("Post() succeeded, want the transport error")
+	}
+	LogCall(context.Background(), "gemini", "generateContent", time.Now(), err)
+
+	if strings.Contains(logs.String(), testAPIKey) {
+		t.Errorf("logs contain the API key:\n%s", logs.String())
+	}
+	if !strings.Contains(logs.String(), "connection reset by peer") {
+		t.Errorf("logs = %q, want the cause of the failure", logs.String())
+	}
+}
+
+func TestTransportLogsCorrelationID(t *testing.T) {
+	logs := captureLogs(t)
+
+	client := &http.Client{Transport: &Transport{
+		Service: "zoom",
+		Base: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusCreated, Body: http.NoBody, Request: req}, nil
+		}),
+	}}
+	ctx := WithCorrelationID(context.Background(), "req-123")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.zoom.us/v2/users/me/meetings?token="+testAPIKey, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	resp.Body.Close()
+
+	for _, want := range []string{"correlation_id=req-123", "service=zoom", "path=/v2/users/me/meetings", "status=201"} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("logs = %q, want %s", logs.String(), want)
+		}
+	}
+	if strings.Contains(logs.String(), testAPIKey) {
+		t.Errorf("logs contain the query string:\n%s", logs.String())
+	}
+}
+
+func TestLogCallLogsCorrelationID(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{"set by WithCorrelationID", WithCorrelationID(context.Background(), "req-123")},
+		// fiber's request context resolves the locals set by the correlation ID middleware
+		{"set in the request locals", context.WithValue(context.Background(), CorrelationIDKey, "req-123")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := captureLogs(t)
+
+			LogCall(tt.ctx, "drive", "files.get", time.Now(), nil)
+			LogCall(tt.ctx, "drive", "files.get", time.Now(), errors.New("not found"))
+
+			lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+			if len(lines) != 2 {
+				t.Fatalf("logged %d entries, want 2:\n%s", len(lines), logs.String())
+			}
+			for _, line := range lines {
+				if !strings.Contains(line, "correlation_id=req-123") {
+					t.Errorf("log entry %q has no correlation ID", line)
+				}
+			}
+		})
+	}
+}
+
+func TestLogCallWithoutCorrelationID(t *testing.T) {
+	logs := captureLogs(t)
+
+	LogCall(context.Background(), "notification", "send", time.Now(), nil)
+
+	if strings.Contains(logs.String(), CorrelationIDKey) {
+		t.Errorf("logs = %q, want no correlation ID outside a request", logs.String())
+	}
+}
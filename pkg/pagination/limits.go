@@ -0,0 +1,41 @@
+package pagination
+
+const (
+	// DefaultPageSize is the page size of a list request that does not ask for one
+	DefaultPageSize = 20
+	// MaxPageSize is the largest page size a list request may ask for; it keeps public endpoints from
+	// being asked for the whole table at once
+	MaxPageSize = 100
+)
+
+// Limits are the page sizes of a list endpoint. Unset limits fall back to DefaultPageSize and MaxPageSize.
+type Limits struct {
+	// Default is used when the request does not ask for a page size, or asks for one out of range
+	Default int
+	// Max is the largest page size the request may ask for
+	Max int
+}
+
+// OrDefaults fills unset limits with the package defaults and keeps the default within the maximum
+func (l Limits) OrDefaults() Limits {
+	if l.Max <= 0 {
+		l.Max = MaxPageSize
+	}
+	if l.Default <= 0 {
+		l.Default = DefaultPageSize
+	}
+	if l.Default > l.Max {
+		l.Default = l.Max
+	}
+	return l
+}
+
+// PageSize returns the requested page size, or the default one when the request asks for none or for
+// more than the maximum
+func (l Limits) PageSize(requested int) int {
+	l = l.OrDefaults()
+	if requested < 1 || requested > l.Max {
+		return l.Default
+	}
+	return requested
+}
@@ -0,0 +1,58 @@
+package pagination
+
+import "testing"
+
+func TestLimitsPageSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		limits    Limits
+		requested int
+		want      int
+	}{
+		{"not requested", Limits{Default: 20, Max: 100}, 0, 20},
+		{"negative", Limits{Default: 20, Max: 100}, -1, 20},
+		{"within range", Limits{Default: 20, Max: 100}, 50, 50},
+		{"maximum", Limits{Default: 20, Max: 100}, 100, 100},
+		{"above maximum", Limits{Default: 20, Max: 100}, 101, 20},
+		{"raised maximum", Limits{Default: 20, Max: 1000}, 1000, 1000},
+		{"unset limits", Limits{}, 0, DefaultPageSize},
+		{"unset limits above maximum", Limits{}, MaxPageSize + 1, DefaultPageSize},
+		{"default above maximum", Limits{Default: 50, Max: 30}, 0, 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.limits.PageSize(tt.requested); got != tt.want {
+				t.Errorf("%+v.PageSize(%d) = %d, want %d", tt.limits, tt.requested, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAppliesLimits(t *testing.T) {
+	parser := &QueryParser{Limits: Limits{Default: 10, Max: 500}}
+
+	tests := []struct {
+		limit string
+		want  int
+	}{
+		{"", 10},
+		{"250", 250},
+		{"501", 10},
+		{"abc", 10},
+	}
+
+	for _, tt := range tests {
+		params := map[string]string{}
+		if tt.limit != "" {
+			params["limit"] = tt.limit
+		}
+		got, err := parser.Parse(params)
+		if err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+		if got.Pagination.Limit != tt.want {
+			t.Errorf("Parse(limit=%q) limit = %d, want %d", tt.limit, got.Pagination.Limit, tt.want)
+		}
+	}
+}
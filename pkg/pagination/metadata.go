@@ -0,0 +1,44 @@
+package pagination
+
+// Metadata describes one page of a paginated list
+type Metadata struct {
+	CurrentPage int   `json:"current_page"`
+	PageSize    int   `json:"page_size"`
+	TotalCount  int64 `json:"total_count"`
+	TotalPage   int   `json:"total_page"`
+	// Count is the number of items on the current page
+	Count int `json:"count"`
+}
+
+// BuildMetadata calculates the page metadata of a list of total items split into pages of limit items.
+// A page below 1 is treated as the first page, and a limit of 0 or less as a single page holding everything.
+func BuildMetadata(total int64, page, limit int) Metadata {
+	if total < 0 {
+		total = 0
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	metadata := Metadata{
+		CurrentPage: page,
+		PageSize:    limit,
+		TotalCount:  total,
+	}
+
+	if limit <= 0 {
+		if total > 0 {
+			metadata.TotalPage = 1
+		}
+		if page == 1 {
+			metadata.Count = int(total)
+		}
+		return metadata
+	}
+
+	metadata.TotalPage = int((total + int64(limit) - 1) / int64(limit))
+	if remaining := total - int64(page-1)*int64(limit); remaining > 0 {
+		metadata.Count = int(min(remaining, int64(limit)))
+	}
+	return metadata
+}
@@ -0,0 +1,29 @@
+package pagination
+
+import "testing"
+
+func TestBuildMetadata(t *testing.T) {
+	tests := []struct {
+		name  string
+		total int64
+		page  int
+		limit int
+		want  Metadata
+	}{
+		{"exact pages", 20, 2, 10, Metadata{CurrentPage: 2, PageSize: 10, TotalCount: 20, TotalPage: 2, Count: 10}},
+		{"partial last page", 21, 3, 10, Metadata{CurrentPage: 3, PageSize: 10, TotalCount: 21, TotalPage: 3, Count: 1}},
+		{"page past the end", 21, 5, 10, Metadata{CurrentPage: 5, PageSize: 10, TotalCount: 21, TotalPage: 3, Count: 0}},
+		{"empty list", 0, 1, 10, Metadata{CurrentPage: 1, PageSize: 10, TotalCount: 0, TotalPage: 0, Count: 0}},
+		{"zero limit", 7, 1, 0, Metadata{CurrentPage: 1, PageSize: 0, TotalCount: 7, TotalPage: 1, Count: 7}},
+		{"zero limit empty list", 0, 1, 0, Metadata{CurrentPage: 1, PageSize: 0, TotalCount: 0, TotalPage: 0, Count: 0}},
+		{"page below one", 5, 0, 10, Metadata{CurrentPage: 1, PageSize: 10, TotalCount: 5, TotalPage: 1, Count: 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BuildMetadata(tt.total, tt.page, tt.limit); got != tt.want {
+				t.Errorf("BuildMetadata(%d, %d, %d) = %+v, want %+v", tt.total, tt.page, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
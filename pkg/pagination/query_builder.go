@@ -1,12 +1,20 @@
 package pagination
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 )
 
+// ErrInvalidFilter is returned for a filter or sort the client got wrong, such as an unknown field or
+// operator or a value the operator cannot take, so handlers can answer 400 instead of 500
+var ErrInvalidFilter = errors.New("invalid filter")
+
 type QueryParams struct {
-	Filters    []Filter
+	Filters []Filter
+	// OrGroups are filter groups matched when any filter in the group matches
+	OrGroups   [][]Filter
 	Sorts      []Sort
 	Pagination Pagination
 }
@@ -35,6 +43,15 @@ type PagedResponse struct {
 	TotalPages int         `json:"total_pages"`
 }
 
+// SearchGroup returns a case-insensitive "contains" filter on each field, for use with AddOrGroup
+func SearchGroup(term string, fields ...string) []Filter {
+	filters := make([]Filter, 0, len(fields))
+	for _, field := range fields {
+		filters = append(filters, Filter{Field: field, Operator: "ilike", Value: term})
+	}
+	return filters
+}
+
 type QueryBuilder struct {
 	baseQuery   string
 	whereClause []string
@@ -51,54 +68,123 @@ func NewQueryBuilder(baseQuery string) *QueryBuilder {
 }
 
 func (qb *QueryBuilder) AddFilter(filter Filter) error {
+	condition, args, err := qb.buildCondition(filter, qb.argCounter)
+	if err != nil {
+		return err
+	}
+
+	qb.whereClause = append(qb.whereClause, condition)
+	qb.args = append(qb.args, args...)
+	qb.argCounter += len(args)
+	return nil
+}
+
+// AddCondition adds a fixed SQL condition, such as a subquery, as a single AND term. The condition is
+// not escaped, so it must never contain user input.
+func (qb *QueryBuilder) AddCondition(condition string) {
+	qb.whereClause = append(qb.whereClause, "("+condition+")")
+}
+
+// AddOrGroup adds filters joined by OR, wrapped in parentheses, as a single AND term.
+// Nothing is added if any filter in the group is invalid.
+func (qb *QueryBuilder) AddOrGroup(filters []Filter) error {
+	if len(filters) == 0 {
+		return nil
+	}
+
+	conditions := make([]string, 0, len(filters))
+	var groupArgs []interface{}
+	for _, filter := range filters {
+		condition, args, err := qb.buildCondition(filter, qb.argCounter+len(groupArgs))
+		if err != nil {
+			return err
+		}
+		conditions = append(conditions, condition)
+		groupArgs = append(groupArgs, args...)
+	}
+
+	qb.whereClause = append(qb.whereClause, "("+strings.Join(conditions, " OR ")+")")
+	qb.args = append(qb.args, groupArgs...)
+	qb.argCounter += len(groupArgs)
+	return nil
+}
+
+// buildCondition renders a single filter using placeholders starting at $argStart
+func (qb *QueryBuilder) buildCondition(filter Filter, argStart int) (string, []interface{}, error) {
 	operator := qb.mapOperator(filter.Operator)
 	if operator == "" {
-		return fmt.Errorf("unsupported operator: %s", filter.Operator)
+		return "", nil, fmt.Errorf("%w: unsupported operator: %s", ErrInvalidFilter, filter.Operator)
 	}
 
 	field := qb.sanitizeField(filter.Field)
 	if !qb.isValidField(field) {
-		return fmt.Errorf("invalid field: %s", field)
+		return "", nil, fmt.Errorf("%w: invalid field: %s", ErrInvalidFilter, field)
 	}
 
 	if operator == "IN" || operator == "NOT IN" {
-		values, ok := filter.Value.([]interface{})
+		values, ok := sliceValues(filter.Value)
 		if !ok {
-			return fmt.Errorf("IN/NOT IN operator requires array value")
+			return "", nil, fmt.Errorf("%w: IN/NOT IN operator requires array value", ErrInvalidFilter)
+		}
+		if len(values) == 0 {
+			return "", nil, fmt.Errorf("%w: IN/NOT IN operator requires at least one value", ErrInvalidFilter)
 		}
 		placeholders := make([]string, len(values))
-		for i, v := range values {
-			placeholders[i] = fmt.Sprintf("$%d", qb.argCounter)
-			qb.args = append(qb.args, v)
-			qb.argCounter++
+		for i := range values {
+			placeholders[i] = fmt.Sprintf("$%d", argStart+i)
+		}
+		return fmt.Sprintf("%s %s (%s)", field, operator, strings.Join(placeholders, ",")), values, nil
+	} else if operator == "BETWEEN" {
+		values, ok := sliceValues(filter.Value)
+		if !ok {
+			return "", nil, fmt.Errorf("%w: BETWEEN operator requires a list of two values", ErrInvalidFilter)
 		}
-		qb.whereClause = append(qb.whereClause, fmt.Sprintf("%s %s (%s)", field, operator, strings.Join(placeholders, ",")))
+		if len(values) != 2 {
+			return "", nil, fmt.Errorf("%w: BETWEEN operator requires exactly two values, got %d", ErrInvalidFilter, len(values))
+		}
+		if values[0] == nil || values[1] == nil {
+			return "", nil, fmt.Errorf("%w: BETWEEN operator does not accept null bounds", ErrInvalidFilter)
+		}
+		if reflect.TypeOf(values[0]) != reflect.TypeOf(values[1]) {
+			return "", nil, fmt.Errorf("%w: BETWEEN operator requires values of the same type, got %T and %T", ErrInvalidFilter, values[0], values[1])
+		}
+		return fmt.Sprintf("%s BETWEEN $%d AND $%d", field, argStart, argStart+1), values, nil
 	} else if operator == "LIKE" || operator == "ILIKE" {
-		qb.whereClause = append(qb.whereClause, fmt.Sprintf("LOWER(%s) %s LOWER($%d)", field, operator, qb.argCounter))
-		qb.args = append(qb.args, "%"+fmt.Sprintf("%v", filter.Value)+"%")
-		qb.argCounter++
+		return fmt.Sprintf("LOWER(%s) %s LOWER($%d)", field, operator, argStart), []interface{}{"%" + fmt.Sprintf("%v", filter.Value) + "%"}, nil
 	} else if operator == "IS" || operator == "IS NOT" {
 		// For IS NULL and IS NOT NULL, don't use parameter binding
 		if filter.Value == nil {
-			qb.whereClause = append(qb.whereClause, fmt.Sprintf("%s %s NULL", field, operator))
-		} else {
-			qb.whereClause = append(qb.whereClause, fmt.Sprintf("%s %s $%d", field, operator, qb.argCounter))
-			qb.args = append(qb.args, filter.Value)
-			qb.argCounter++
+			return fmt.Sprintf("%s %s NULL", field, operator), nil, nil
 		}
-	} else {
-		qb.whereClause = append(qb.whereClause, fmt.Sprintf("%s %s $%d", field, operator, qb.argCounter))
-		qb.args = append(qb.args, filter.Value)
-		qb.argCounter++
+		return fmt.Sprintf("%s %s $%d", field, operator, argStart), []interface{}{filter.Value}, nil
 	}
 
-	return nil
+	return fmt.Sprintf("%s %s $%d", field, operator, argStart), []interface{}{filter.Value}, nil
+}
+
+// sliceValues returns the elements of a slice or array value of any element type, e.g. []string or
+// []int, so each can be bound as its own argument. A []byte is a single value, not a list.
+func sliceValues(value interface{}) ([]interface{}, bool) {
+	if values, ok := value.([]interface{}); ok {
+		return values, true
+	}
+
+	v := reflect.ValueOf(value)
+	if (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) || v.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+
+	values := make([]interface{}, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).Interface()
+	}
+	return values, true
 }
 
 func (qb *QueryBuilder) AddSort(sort Sort) error {
 	field := qb.sanitizeField(sort.Field)
 	if !qb.isValidField(field) {
-		return fmt.Errorf("invalid field: %s", field)
+		return fmt.Errorf("%w: invalid sort field: %s", ErrInvalidFilter, field)
 	}
 
 	order := strings.ToUpper(sort.Order)
@@ -130,18 +216,20 @@ func (qb *QueryBuilder) Build() (string, []interface{}) {
 
 func (qb *QueryBuilder) mapOperator(op string) string {
 	operators := map[string]string{
-		"eq":     "=",
-		"ne":     "!=",
-		"gt":     ">",
-		"gte":    ">=",
-		"lt":     "<",
-		"lte":    "<=",
-		"like":   "LIKE",
-		"ilike":  "ILIKE",
-		"in":     "IN",
-		"nin":    "NOT IN",
-		"is":     "IS",
-		"is_not": "IS NOT",
+		"eq":      "=",
+		"ne":      "!=",
+		"gt":      ">",
+		"gte":     ">=",
+		"lt":      "<",
+		"lte":     "<=",
+		"like":    "LIKE",
+		"ilike":   "ILIKE",
+		"in":      "IN",
+		"nin":     "NOT IN",
+		"not_in":  "NOT IN",
+		"is":      "IS",
+		"is_not":  "IS NOT",
+		"between": "BETWEEN",
 	}
 	return operators[op]
 }
@@ -153,7 +241,15 @@ func (qb *QueryBuilder) sanitizeField(field string) string {
 	return field
 }
 
+// isValidField reports whether field is a whitelisted column, optionally qualified by a table alias such as "v.status"
 func (qb *QueryBuilder) isValidField(field string) bool {
+	if alias, column, ok := strings.Cut(field, "."); ok {
+		if !isAlias(alias) {
+			return false
+		}
+		field = column
+	}
+
 	validFields := map[string]bool{
 		"user_id":          true,
 		"id":               true,
@@ -205,6 +301,26 @@ func (qb *QueryBuilder) isValidField(field string) bool {
 		"year":              true,
 		"semester":          true,
 		"status":            true,
+
+		// Work paper signature fields
+		"signed_at":      true,
+		"signature_type": true,
+
+		// Business trip verificator fields
+		"verified_at": true,
 	}
 	return validFields[field]
 }
+
+// isAlias reports whether s is a plain lowercase table alias such as "v" or "bt2"
+func isAlias(s string) bool {
+	if s == "" || s[0] < 'a' || s[0] > 'z' {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' {
+			return false
+		}
+	}
+	return true
+}
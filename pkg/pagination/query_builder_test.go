@@ -0,0 +1,293 @@
+package pagination
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAddOrGroup(t *testing.T) {
+	qb := NewQueryBuilder("SELECT * FROM work_paper_items")
+
+	if err := qb.AddFilter(Filter{Field: "type", Operator: "eq", Value: "A"}); err != nil {
+		t.Fatalf("AddFilter() error = %v", err)
+	}
+	if err := qb.AddOrGroup(SearchGroup("kinerja", "statement", "number", "explanation")); err != nil {
+		t.Fatalf("AddOrGroup() error = %v", err)
+	}
+	if err := qb.AddFilter(Filter{Field: "is_active", Operator: "eq", Value: true}); err != nil {
+		t.Fatalf("AddFilter() error = %v", err)
+	}
+	if err := qb.AddFilter(Filter{Field: "deleted_at", Operator: "is", Value: nil}); err != nil {
+		t.Fatalf("AddFilter() error = %v", err)
+	}
+
+	query, args := qb.Build()
+
+	wantQuery := "SELECT * FROM work_paper_items WHERE type = $1 AND " +
+		"(LOWER(statement) ILIKE LOWER($2) OR LOWER(number) ILIKE LOWER($3) OR LOWER(explanation) ILIKE LOWER($4)) AND " +
+		"is_active = $5 AND deleted_at IS NULL"
+	if query != wantQuery {
+		t.Errorf("query =\n%s\nwant\n%s", query, wantQuery)
+	}
+
+	wantArgs := []interface{}{"A", "%kinerja%", "%kinerja%", "%kinerja%", true}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("args = %v, want %v", args, wantArgs)
+	}
+}
+
+func TestAddOrGroupWithMultiValueFilters(t *testing.T) {
+	qb := NewQueryBuilder("SELECT * FROM business_trips")
+
+	err := qb.AddOrGroup([]Filter{
+		{Field: "status", Operator: "in", Value: []interface{}{"draft", "ongoing"}},
+		{Field: "amount", Operator: "between", Value: []interface{}{10, 20}},
+	})
+	if err != nil {
+		t.Fatalf("AddOrGroup() error = %v", err)
+	}
+	if err := qb.AddFilter(Filter{Field: "user_id", Operator: "eq", Value: "u-1"}); err != nil {
+		t.Fatalf("AddFilter() error = %v", err)
+	}
+
+	query, args := qb.Build()
+
+	wantQuery := "SELECT * FROM business_trips WHERE (status IN ($1,$2) OR amount BETWEEN $3 AND $4) AND user_id = $5"
+	if query != wantQuery {
+		t.Errorf("query =\n%s\nwant\n%s", query, wantQuery)
+	}
+
+	wantArgs := []interface{}{"draft", "ongoing", 10, 20, "u-1"}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("args = %v, want %v", args, wantArgs)
+	}
+}
+
+func TestAddOrGroupRejectsInvalidFilterAtomically(t *testing.T) {
+	qb := NewQueryBuilder("SELECT * FROM work_paper_items")
+
+	err := qb.AddOrGroup([]Filter{
+		{Field: "statement", Operator: "ilike", Value: "x"},
+		{Field: "password", Operator: "ilike", Value: "x"},
+	})
+	if err == nil {
+		t.Fatal("AddOrGroup() should reject an invalid field")
+	}
+
+	if err := qb.AddFilter(Filter{Field: "type", Operator: "eq", Value: "A"}); err != nil {
+		t.Fatalf("AddFilter() error = %v", err)
+	}
+
+	query, args := qb.Build()
+	if want := "SELECT * FROM work_paper_items WHERE type = $1"; query != want {
+		t.Errorf("query = %s, want %s", query, want)
+	}
+	if !reflect.DeepEqual(args, []interface{}{"A"}) {
+		t.Errorf("args = %v, want [A]", args)
+	}
+}
+
+func TestAddOrGroupEmpty(t *testing.T) {
+	qb := NewQueryBuilder("SELECT * FROM work_paper_items")
+
+	if err := qb.AddOrGroup(nil); err != nil {
+		t.Fatalf("AddOrGroup() error = %v", err)
+	}
+
+	query, args := qb.Build()
+	if query != "SELECT * FROM work_paper_items" || len(args) != 0 {
+		t.Errorf("empty group changed the query: %s %v", query, args)
+	}
+}
+
+func TestAddCondition(t *testing.T) {
+	qb := NewQueryBuilder("SELECT * FROM work_papers")
+
+	if err := qb.AddFilter(Filter{Field: "status", Operator: "eq", Value: "draft"}); err != nil {
+		t.Fatalf("AddFilter() error = %v", err)
+	}
+	qb.AddCondition("id IN (SELECT work_paper_id FROM work_paper_notes GROUP BY work_paper_id HAVING COUNT(*) > 1)")
+	if err := qb.AddFilter(Filter{Field: "year", Operator: "eq", Value: 2025}); err != nil {
+		t.Fatalf("AddFilter() error = %v", err)
+	}
+
+	query, args := qb.Build()
+
+	wantQuery := "SELECT * FROM work_papers WHERE status = $1 AND " +
+		"(id IN (SELECT work_paper_id FROM work_paper_notes GROUP BY work_paper_id HAVING COUNT(*) > 1)) AND year = $2"
+	if query != wantQuery {
+		t.Errorf("query =\n%s\nwant\n%s", query, wantQuery)
+	}
+	if want := []interface{}{"draft", 2025}; !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %v, want %v", args, want)
+	}
+}
+
+func TestAddFilterWithTableAlias(t *testing.T) {
+	qb := NewQueryBuilder("SELECT * FROM business_trip_verificators v LEFT JOIN business_trips bt ON v.business_trip_id = bt.id")
+
+	if err := qb.AddFilter(Filter{Field: "bt.status", Operator: "eq", Value: "ongoing"}); err != nil {
+		t.Fatalf("AddFilter() error = %v", err)
+	}
+	if err := qb.AddFilter(Filter{Field: "v.deleted_at", Operator: "is", Value: nil}); err != nil {
+		t.Fatalf("AddFilter() error = %v", err)
+	}
+
+	query, _ := qb.Build()
+	if want := " WHERE bt.status = $1 AND v.deleted_at IS NULL"; !strings.HasSuffix(query, want) {
+		t.Errorf("query = %s, want suffix %s", query, want)
+	}
+
+	for _, field := range []string{"v.password", "1v.status", "a.b.status", "(select 1).status"} {
+		if err := qb.AddFilter(Filter{Field: field, Operator: "eq", Value: "x"}); err == nil {
+			t.Errorf("AddFilter(%q) should be rejected", field)
+		}
+	}
+}
+
+func TestAddFilterInWithTypedSlices(t *testing.T) {
+	qb := NewQueryBuilder("SELECT * FROM business_trips")
+
+	if err := qb.AddFilter(Filter{Field: "user_id", Operator: "eq", Value: "u-1"}); err != nil {
+		t.Fatalf("AddFilter() error = %v", err)
+	}
+	if err := qb.AddFilter(Filter{Field: "status", Operator: "in", Value: []string{"draft", "ongoing"}}); err != nil {
+		t.Fatalf("AddFilter(in []string) error = %v", err)
+	}
+	if err := qb.AddFilter(Filter{Field: "level", Operator: "not_in", Value: []int{1, 2, 3}}); err != nil {
+		t.Fatalf("AddFilter(not_in []int) error = %v", err)
+	}
+	if err := qb.AddFilter(Filter{Field: "semester", Operator: "in", Value: []int{2}}); err != nil {
+		t.Fatalf("AddFilter(in single element) error = %v", err)
+	}
+	if err := qb.AddFilter(Filter{Field: "deleted_at", Operator: "is", Value: nil}); err != nil {
+		t.Fatalf("AddFilter() error = %v", err)
+	}
+	if err := qb.AddFilter(Filter{Field: "type", Operator: "eq", Value: "A"}); err != nil {
+		t.Fatalf("AddFilter() error = %v", err)
+	}
+
+	query, args := qb.Build()
+
+	wantQuery := "SELECT * FROM business_trips WHERE user_id = $1 AND status IN ($2,$3) AND " +
+		"level NOT IN ($4,$5,$6) AND semester IN ($7) AND deleted_at IS NULL AND type = $8"
+	if query != wantQuery {
+		t.Errorf("query =\n%s\nwant\n%s", query, wantQuery)
+	}
+
+	wantArgs := []interface{}{"u-1", "draft", "ongoing", 1, 2, 3, 2, "A"}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("args = %v, want %v", args, wantArgs)
+	}
+}
+
+func TestAddFilterInRejectsEmptyAndScalarValues(t *testing.T) {
+	qb := NewQueryBuilder("SELECT * FROM business_trips")
+
+	for _, filter := range []Filter{
+		{Field: "status", Operator: "in", Value: []string{}},
+		{Field: "status", Operator: "nin", Value: []interface{}{}},
+		{Field: "status", Operator: "in", Value: "draft"},
+		{Field: "status", Operator: "in", Value: []byte("draft")},
+	} {
+		if err := qb.AddFilter(filter); err == nil {
+			t.Errorf("AddFilter(%s %v) should be rejected", filter.Operator, filter.Value)
+		}
+	}
+
+	if query, args := qb.Build(); query != "SELECT * FROM business_trips" || len(args) != 0 {
+		t.Errorf("rejected filters changed the query: %s %v", query, args)
+	}
+}
+
+func TestAddFilterBetween(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		wantArgs []interface{}
+	}{
+		{"interface slice", []interface{}{from, to}, []interface{}{from, to}},
+		{"time slice", []time.Time{from, to}, []interface{}{from, to}},
+		{"int slice", []int{1, 3}, []interface{}{1, 3}},
+		{"string array", [2]string{"a", "m"}, []interface{}{"a", "m"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qb := NewQueryBuilder("SELECT * FROM business_trips")
+			if err := qb.AddFilter(Filter{Field: "start_date", Operator: "between", Value: tt.value}); err != nil {
+				t.Fatalf("AddFilter(between %v) error = %v", tt.value, err)
+			}
+
+			query, args := qb.Build()
+			if want := "SELECT * FROM business_trips WHERE start_date BETWEEN $1 AND $2"; query != want {
+				t.Errorf("query = %s, want %s", query, want)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestAddFilterBetweenRejectsInvalidBounds(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{"single value", "2024-01-01"},
+		{"one element", []interface{}{from}},
+		{"three elements", []time.Time{from, from, from}},
+		{"empty", []interface{}{}},
+		{"nil bound", []interface{}{from, nil}},
+		{"mismatched types", []interface{}{from, "2024-01-31"}},
+		{"bytes", []byte("ab")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qb := NewQueryBuilder("SELECT * FROM business_trips")
+			err := qb.AddFilter(Filter{Field: "start_date", Operator: "between", Value: tt.value})
+			if !errors.Is(err, ErrInvalidFilter) {
+				t.Fatalf("AddFilter(between %v) error = %v, want ErrInvalidFilter", tt.value, err)
+			}
+			if query, args := qb.Build(); query != "SELECT * FROM business_trips" || len(args) != 0 {
+				t.Errorf("rejected filter changed the query: %s %v", query, args)
+			}
+		})
+	}
+}
+
+func TestInvalidFieldAndOperatorAreInvalidFilters(t *testing.T) {
+	qb := NewQueryBuilder("SELECT * FROM business_trips")
+
+	if err := qb.AddFilter(Filter{Field: "password", Operator: "eq", Value: "x"}); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("AddFilter(unknown field) error = %v, want ErrInvalidFilter", err)
+	}
+	if err := qb.AddFilter(Filter{Field: "status", Operator: "regex", Value: "x"}); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("AddFilter(unknown operator) error = %v, want ErrInvalidFilter", err)
+	}
+	if err := qb.AddSort(Sort{Field: "password", Order: "asc"}); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("AddSort(unknown field) error = %v, want ErrInvalidFilter", err)
+	}
+}
+
+func TestParseSingleValueInFilter(t *testing.T) {
+	params, err := NewQueryParser().Parse(map[string]string{"status": "in draft"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := []interface{}{"draft"}
+	if len(params.Filters) != 1 || !reflect.DeepEqual(params.Filters[0].Value, want) {
+		t.Errorf("filters = %+v, want status in %v", params.Filters, want)
+	}
+}
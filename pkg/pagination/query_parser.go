@@ -8,7 +8,10 @@ import (
 	"time"
 )
 
-type QueryParser struct{}
+// QueryParser turns list query parameters into QueryParams, with the page size bounded by its Limits
+type QueryParser struct {
+	Limits Limits
+}
 
 func NewQueryParser() *QueryParser {
 	return &QueryParser{}
@@ -18,7 +21,7 @@ func (qp *QueryParser) Parse(params map[string]string) (*QueryParams, error) {
 	result := &QueryParams{
 		Filters:    []Filter{},
 		Sorts:      []Sort{},
-		Pagination: Pagination{Page: 1, Limit: 20},
+		Pagination: Pagination{Page: 1, Limit: qp.Limits.PageSize(0)},
 	}
 
 	for key, value := range params {
@@ -30,8 +33,8 @@ func (qp *QueryParser) Parse(params map[string]string) (*QueryParams, error) {
 		}
 
 		if key == "limit" {
-			if limit, err := strconv.Atoi(value); err == nil && limit > 0 && limit <= 100 {
-				result.Pagination.Limit = limit
+			if limit, err := strconv.Atoi(value); err == nil {
+				result.Pagination.Limit = qp.Limits.PageSize(limit)
 			}
 			continue
 		}
@@ -64,6 +67,13 @@ func (qp *QueryParser) parseFilter(key, value string) (Filter, error) {
 
 	typedValue := qp.convertValue(key, filterValue)
 
+	// A single value for a list operator, e.g. "in draft", is a one-element list
+	if operator == "in" || operator == "nin" || operator == "not_in" {
+		if _, ok := typedValue.([]interface{}); !ok {
+			typedValue = []interface{}{typedValue}
+		}
+	}
+
 	return Filter{
 		Field:    key,
 		Operator: operator,
@@ -112,7 +122,7 @@ func (qp *QueryParser) convertSingleValue(field, value string) interface{} {
 		if v, err := strconv.ParseFloat(value, 64); err == nil {
 			return v
 		}
-	case "start_date", "end_date", "spd_date", "departure_date", "return_date", "created_at", "updated_at":
+	case "start_date", "end_date", "spd_date", "departure_date", "return_date", "created_at", "updated_at", "signed_at":
 		if v, err := time.Parse("2006-01-02", value); err == nil {
 			return v
 		}
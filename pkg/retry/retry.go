@@ -0,0 +1,130 @@
+// Package retry runs calls to flaky external APIs again with exponential backoff and jitter
+package retry
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"time"
+
+	"sandbox/pkg/logging"
+)
+
+const (
+	// DefaultMaxAttempts is the number of attempts, including the first call, when none is configured
+	DefaultMaxAttempts = 3
+	// DefaultBaseDelay is the wait before the first retry; it doubles with every further retry
+	DefaultBaseDelay = 500 * time.Millisecond
+	// DefaultMaxDelay caps the wait between two attempts
+	DefaultMaxDelay = 10 * time.Second
+)
+
+// Options configures how often and how long a call is retried
+type Options struct {
+	// MaxAttempts includes the first call; 1 disables retries and 0 uses DefaultMaxAttempts
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	// RetryIf decides whether a failed attempt is retried; nil uses IsRetryable
+	RetryIf func(error) bool
+}
+
+func (o Options) withDefaults() Options {
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = DefaultMaxAttempts
+	}
+	if o.BaseDelay <= 0 {
+		o.BaseDelay = DefaultBaseDelay
+	}
+	if o.MaxDelay <= 0 {
+		o.MaxDelay = DefaultMaxDelay
+	}
+	if o.MaxDelay < o.BaseDelay {
+		o.MaxDelay = o.BaseDelay
+	}
+	if o.RetryIf == nil {
+		o.RetryIf = IsRetryable
+	}
+	return o
+}
+
+// StatusError is returned by API clients for a non-OK HTTP response so the status code can be inspected
+type StatusError struct {
+	StatusCode int
+	Err        error
+}
+
+// NewStatusError wraps err with the HTTP status code of the response that caused it
+func NewStatusError(statusCode int, err error) *StatusError {
+	return &StatusError{StatusCode: statusCode, Err: err}
+}
+
+func (e *StatusError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// IsRetryable reports whether err is worth retrying: timeouts, rate limiting (429) and server errors (5xx).
+// A cancelled context is never retryable.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// Do calls fn until it succeeds, fails with an error opts.RetryIf rejects or runs out of attempts.
+// The wait between attempts grows exponentially with jitter and is cut short when ctx is done, in which
+// case the last error of fn is returned.
+func Do[T any](ctx context.Context, operation string, opts Options, fn func(ctx context.Context) (T, error)) (T, error) {
+	opts = opts.withDefaults()
+
+	var result T
+	var err error
+	for attempt := 1; ; attempt++ {
+		result, err = fn(ctx)
+		if err == nil || attempt >= opts.MaxAttempts || !opts.RetryIf(err) || ctx.Err() != nil {
+			return result, err
+		}
+
+		delay := backoff(opts, attempt)
+		logging.FromContext(ctx).WarnContext(ctx, "retrying failed call",
+			"operation", operation, "attempt", attempt, "max_attempts", opts.MaxAttempts, "delay", delay, "error", logging.ErrorMessage(err))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, err
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the wait after the given failed attempt: half of the exponential delay plus a random
+// share of the other half, so concurrent callers do not retry in lockstep
+func backoff(opts Options, attempt int) time.Duration {
+	delay := opts.MaxDelay
+	if shift := attempt - 1; shift < 32 {
+		if d := opts.BaseDelay << shift; d > 0 && d < opts.MaxDelay {
+			delay = d
+		}
+	}
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
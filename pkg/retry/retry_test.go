@@ -0,0 +1,117 @@
+package retry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+var fastOptions = Options{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"rate limited", NewStatusError(http.StatusTooManyRequests, errors.New("429")), true},
+		{"server error", fmt.Errorf("check failed: %w", NewStatusError(http.StatusServiceUnavailable, errors.New("503"))), true},
+		{"bad request", NewStatusError(http.StatusBadRequest, errors.New("400")), false},
+		{"timeout", fmt.Errorf("call failed: %w", context.DeadlineExceeded), true},
+		{"cancelled", context.Canceled, false},
+		{"other", errors.New("invalid response"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoRetriesTransientErrors(t *testing.T) {
+	calls := 0
+	got, err := Do(context.Background(), "test", fastOptions, func(context.Context) (string, error) {
+		calls++
+		if calls < 3 {
+			return "", NewStatusError(http.StatusInternalServerError, errors.New("500"))
+		}
+		return "ok", nil
+	})
+	if err != nil || got != "ok" {
+		t.Fatalf("Do() = %q, %v, want ok", got, err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+}
+
+func TestDoStopsOnPermanentErrorAndMaxAttempts(t *testing.T) {
+	permanent := NewStatusError(http.StatusBadRequest, errors.New("400"))
+	calls := 0
+	_, err := Do(context.Background(), "test", fastOptions, func(context.Context) (int, error) {
+		calls++
+		return 0, permanent
+	})
+	if !errors.Is(err, permanent) || calls != 1 {
+		t.Errorf("permanent error: calls = %d, err = %v, want 1 call", calls, err)
+	}
+
+	calls = 0
+	_, err = Do(context.Background(), "test", Options{MaxAttempts: 1}, func(context.Context) (int, error) {
+		calls++
+		return 0, NewStatusError(http.StatusBadGateway, errors.New("502"))
+	})
+	if err == nil || calls != 1 {
+		t.Errorf("single attempt: calls = %d, err = %v, want 1 failed call", calls, err)
+	}
+}
+
+func TestDoStopsWaitingWhenContextIsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	calls := 0
+	start := time.Now()
+	_, err := Do(ctx, "test", Options{MaxAttempts: 5, BaseDelay: time.Hour}, func(context.Context) (int, error) {
+		calls++
+		return 0, NewStatusError(http.StatusTooManyRequests, errors.New("429"))
+	})
+	if elapsed := time.Since(start); elapsed > time.Minute {
+		t.Fatalf("Do() waited %v after the context was cancelled", elapsed)
+	}
+	if err == nil || calls != 1 {
+		t.Errorf("calls = %d, err = %v, want 1 failed call", calls, err)
+	}
+}
+
+func TestDoDoesNotLogURLQuery(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	defer slog.SetDefault(previous)
+
+	_, _ = Do(context.Background(), "test", fastOptions, func(context.Context) (int, error) {
+		return 0, fmt.Errorf("failed to call Gemini API: %w", &url.Error{
+			Op:  "Post",
+			URL: "https://generativelanguage.googleapis.com/v1beta/models/m:generateContent?key=secret-key",
+			Err: context.DeadlineExceeded,
+		})
+	})
+
+	if !strings.Contains(logs.String(), "retrying failed call") {
+		t.Fatalf("logs = %q, want the retries logged", logs.String())
+	}
+	if strings.Contains(logs.String(), "secret-key") {
+		t.Errorf("logs contain the URL query:\n%s", logs.String())
+	}
+}